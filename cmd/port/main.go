@@ -7,10 +7,15 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"time"
 
 	"charm.land/fang/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/commands"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/logging"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/port-experimental/port-cli/internal/styles"
 	"github.com/spf13/cobra"
@@ -54,7 +59,15 @@ func init() {
 //go:embed logo.txt
 var logo string
 
-func main() {
+// rootState is what main needs from the root command after it has run.
+type rootState struct {
+	noColor  bool
+	eventLog *logging.Logger
+}
+
+// newRootCmd builds the port command with its global flags and subcommands.
+func newRootCmd() (*cobra.Command, *rootState) {
+	state := &rootState{}
 	rootCmd := &cobra.Command{
 		Use:   "port",
 		Short: "Port CLI - Modular command-line interface for Port",
@@ -72,47 +85,71 @@ func main() {
 
 Credentials can be provided via:
   1. By calling port auth login
-  2. CLI flags (--client-id, --client-secret) - highest priority
-  3. Environment variables (PORT_CLIENT_ID, PORT_CLIENT_SECRET)
+  2. CLI flags (--client-id, --client-secret or --client-secret-file) - highest priority
+  3. Environment variables (PORT_CLIENT_ID, PORT_CLIENT_SECRET or PORT_CLIENT_SECRET_FILE)
   4. Configuration file (~/.port/config.yaml)`,
 		Version: version,
 	}
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitcode.New(exitcode.Usage, err)
+	})
 
 	// Global flags
 	var (
 		configFile         string
 		clientID           string
 		clientSecret       string
+		clientSecretFile   string
 		apiURL             string
 		targetClientID     string
 		targetClientSecret string
+		targetSecretFile   string
 		targetAPIURL       string
+		apiVersion         string
 		debug              bool
-		noColor            bool
 		quiet              bool
 		verbose            bool
 		yes                bool
+		compactJSON        bool
+		cacheTTL           time.Duration
+		noCache            bool
+		rateLimit          float64
+		concurrency        int
+		headerFlags        []string
+		logFile            string
+		logFormat          string
 	)
 
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "Path to configuration file")
 	rootCmd.PersistentFlags().StringVar(&clientID, "client-id", "", "Base org Port API client ID (overrides config/env)")
 	rootCmd.PersistentFlags().StringVar(&clientSecret, "client-secret", "", "Base org Port API client secret (overrides config/env)")
+	rootCmd.PersistentFlags().StringVar(&clientSecretFile, "client-secret-file", "", "Read the base org client secret from this file, e.g. a mounted secret (below --client-secret, above env/config)")
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "Base org Port API URL (overrides config/env)")
 	rootCmd.PersistentFlags().StringVar(&targetClientID, "target-client-id", "", "Target org Port API client ID (overrides config/env)")
 	rootCmd.PersistentFlags().StringVar(&targetClientSecret, "target-client-secret", "", "Target org Port API client secret (overrides config/env)")
+	rootCmd.PersistentFlags().StringVar(&targetSecretFile, "target-client-secret-file", "", "Read the target org client secret from this file (below --target-client-secret, above env/config)")
 	rootCmd.PersistentFlags().StringVar(&targetAPIURL, "target-api-url", "", "Target org Port API URL (overrides config/env)")
+	rootCmd.PersistentFlags().StringVar(&apiVersion, "api-version", "", "Port API version to pin via the X-Port-API-Version header (overrides config/env)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "Enable debug mode")
 	rootCmd.PersistentFlags().MarkHidden("debug")
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
+	rootCmd.PersistentFlags().BoolVar(&state.noColor, "no-color", false, "Disable color output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress non-error output")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output; import and migrate print each resource as it is created or updated")
+	rootCmd.PersistentFlags().BoolVar(&compactJSON, "compact-json", false, "Print JSON output on a single line instead of indented, for piping into other tools")
 	rootCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Cache successful GET responses under ~/.port/cache for this long, e.g. 5m (0 disables; 'port cache clear' empties it)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "With --cache-ttl, fetch fresh responses instead of cached ones (and cache them)")
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 0, "Send at most this many requests per second to an org without rate_limit in the config (0: no limit)")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 0, "Keep at most this many HTTP requests in flight to an org without concurrency in the config (0: no limit); worker pools keep their own sizes")
+	rootCmd.PersistentFlags().StringArrayVar(&headerFlags, "header", nil, "Add a header to every API request, as \"Key: Value\" (repeatable; merged over PORT_HEADERS; Authorization cannot be set)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append a structured log of requests, resources and phases to this file")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "json", "Format of the --log-file log (json: one object per line)")
 	rootCmd.PersistentFlags().Bool(commands.TreeFlagName, false, "Print the full command tree for this command and exit")
 
 	// Store global flags in context and initialize color output
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Initialize color output early
-		output.Init(noColor)
+		output.Init(state.noColor)
 
 		// Initialize verbosity
 		if quiet {
@@ -122,8 +159,62 @@ Credentials can be provided via:
 		} else {
 			output.SetVerbosity(output.NormalLevel)
 		}
+		output.SetCompactJSON(compactJSON)
+
+		// Client secrets from files rank just below the secret flags
+		if clientSecret == "" && clientSecretFile != "" {
+			secret, err := config.ReadSecretFile(clientSecretFile)
+			if err != nil {
+				return fmt.Errorf("--client-secret-file: %w", err)
+			}
+			clientSecret = secret
+		}
+		if targetClientSecret == "" && targetSecretFile != "" {
+			secret, err := config.ReadSecretFile(targetSecretFile)
+			if err != nil {
+				return fmt.Errorf("--target-client-secret-file: %w", err)
+			}
+			targetClientSecret = secret
+		}
+
+		if cacheTTL < 0 {
+			return exitcode.Usagef("--cache-ttl must not be negative")
+		}
+		if cacheTTL > 0 {
+			if _, err := api.DefaultResponseCacheDir(); err != nil {
+				return err
+			}
+		}
 
-		cmd.SetContext(commands.WithGlobalFlags(cmd.Context(), commands.GlobalFlags{
+		if rateLimit < 0 {
+			return exitcode.Usagef("--rate-limit must not be negative")
+		}
+		if concurrency < 0 {
+			return exitcode.Usagef("--concurrency must not be negative")
+		}
+
+		// Extra request headers: --header over PORT_HEADERS ("Key: Value; Key: Value")
+		headers, err := api.ParseHeaders(config.EnvHeaders())
+		if err != nil {
+			return exitcode.Usagef("PORT_HEADERS: %w", err)
+		}
+		flagHeaders, err := api.ParseHeaders(headerFlags)
+		if err != nil {
+			return exitcode.Usagef("--header: %w", err)
+		}
+		for key, values := range flagHeaders {
+			headers[key] = values
+		}
+
+		logger, err := commands.OpenEventLog(logFile, logFormat)
+		if err != nil {
+			return err
+		}
+		state.eventLog = logger
+		state.eventLog.Log("command.started", logging.Fields{"command": cmd.CommandPath(), "version": version})
+
+		ctx := logging.WithLogger(cmd.Context(), state.eventLog)
+		cmd.SetContext(commands.WithGlobalFlags(ctx, commands.GlobalFlags{
 			ConfigFile:         configFile,
 			ClientID:           clientID,
 			ClientSecret:       clientSecret,
@@ -132,27 +223,47 @@ Credentials can be provided via:
 			TargetClientSecret: targetClientSecret,
 			TargetAPIURL:       targetAPIURL,
 			Debug:              debug,
-			NoColor:            noColor,
+			NoColor:            state.noColor,
 			Quiet:              quiet,
 			Verbose:            verbose,
 			Yes:                yes,
+			Client: config.ClientSettings{
+				Headers:     headers,
+				APIVersion:  apiVersion,
+				RateLimit:   rateLimit,
+				Concurrency: concurrency,
+				CacheTTL:    cacheTTL,
+				NoCache:     noCache,
+			},
 		}))
+		return nil
 	}
 
 	// Add subcommands
 	commands.RegisterAuth(rootCmd)
 	commands.RegisterExport(rootCmd)
 	commands.RegisterImport(rootCmd)
+	commands.RegisterBackup(rootCmd)
 	commands.RegisterClear(rootCmd)
+	commands.RegisterDelete(rootCmd)
 	commands.RegisterMigrate(rootCmd)
 	commands.RegisterCompare(rootCmd)
+	commands.RegisterDiffBundle(rootCmd)
+	commands.RegisterAnalyze(rootCmd)
 	commands.RegisterAPI(rootCmd)
 	commands.RegisterVersion(rootCmd)
 	commands.RegisterConfig(rootCmd)
 	commands.RegisterCompletion(rootCmd)
 	commands.RegisterSkills(rootCmd)
 	commands.RegisterCache(rootCmd)
+	commands.RegisterPing(rootCmd)
+	commands.RegisterSchema(rootCmd)
+	commands.RegisterValidateMapping(rootCmd)
+	return rootCmd, state
+}
 
+func main() {
+	rootCmd, state := newRootCmd()
 	if commands.HasTreeFlag(os.Args[1:]) {
 		target := commands.ResolveTreeTarget(rootCmd, os.Args[1:])
 		commands.PrintCommandTree(os.Stdout, target)
@@ -172,14 +283,24 @@ Credentials can be provided via:
 		return def
 	})
 
-	if err := fang.Execute(
-		context.Background(),
+	ctx, stop := notifyContext(context.Background())
+	err := fang.Execute(
+		ctx,
 		rootCmd,
 		themeFunc,
 		fang.WithVersion(version),
-		fang.WithCommit(commit),
-		fang.WithNotifySignal(os.Interrupt)); err != nil {
-		output.Init(noColor)
+		fang.WithCommit(commit))
+	stop()
+	if state.eventLog != nil {
+		fields := logging.Fields{"exit_code": exitcode.Code(err)}
+		if err != nil {
+			fields["error"] = err
+		}
+		state.eventLog.Log("command.finished", fields)
+		state.eventLog.Close()
+	}
+	if err != nil {
+		output.Init(state.noColor)
 		output.SetVerbosity(output.NormalLevel)
 		formattedErr := output.FormatError(err)
 		if formattedErr != "" {
@@ -187,6 +308,6 @@ Credentials can be provided via:
 		} else {
 			output.ErrorPrintf("%s: %v\n", output.Error("Error"), err)
 		}
-		os.Exit(1)
+		os.Exit(exitcode.Code(err))
 	}
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/output"
+)
+
+func TestCompactJSONFlagReachesAPICommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { output.SetCompactJSON(false) })
+
+	root, _ := newRootCmd()
+	cmd, _, err := root.Find([]string{"api", "pages", "get"})
+	if err != nil {
+		t.Fatalf("find api pages get: %v", err)
+	}
+	if err := cmd.ParseFlags([]string{"--compact-json", "--compact=false"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	cmd.SetContext(context.Background())
+	if err := root.PersistentPreRunE(cmd, nil); err != nil {
+		t.Fatalf("PersistentPreRunE: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := output.NewJSONEncoder(&buf).Encode(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "{\"a\":1}\n" {
+		t.Errorf("expected compact JSON after --compact-json, got %q", got)
+	}
+	if compact, _ := cmd.Flags().GetBool("compact"); compact {
+		t.Error("expected the pages get --compact flag to keep its own value")
+	}
+}
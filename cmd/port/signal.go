@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// forceExitCode is the exit status used when a second signal aborts the CLI.
+const forceExitCode = 130
+
+// notifyContext returns a context that is canceled on the first SIGINT or
+// SIGTERM, letting a running command stop launching requests and report what
+// it completed. A second signal exits immediately. The returned stop function
+// releases the signal handler.
+func notifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		fmt.Fprintln(os.Stderr, "\nInterrupted, finishing in-flight requests. Press Ctrl-C again to force exit.")
+		cancel()
+
+		select {
+		case <-signals:
+			fmt.Fprintln(os.Stderr, "Forced exit.")
+			os.Exit(forceExitCode)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
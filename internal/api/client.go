@@ -3,14 +3,20 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/port-experimental/port-cli/internal/auth"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/logging"
 	"github.com/port-experimental/port-cli/internal/useragent"
 )
 
@@ -22,12 +28,41 @@ const (
 	retryableStatus  = 429               // Too Many Requests
 )
 
+// APIVersionHeader is the request header used to pin the Port API version.
+const APIVersionHeader = "X-Port-API-Version"
+
+// IdempotencyKeyHeader carries a key that stays the same across every retry
+// of one blueprint or entity create, so a server that honors it can tell a
+// retried create from a new one. Other requests do not send it.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random UUID (version 4).
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
 // Client handles authenticated requests to Port's API.
 type Client struct {
 	httpClient *http.Client
 	tokenMgr   *TokenManager
 	apiURL     string
+	apiVersion string
 	timeout    time.Duration
+	cache      *ResponseCache // nil disables response caching
+	throttle   *throttle      // nil sends requests unthrottled
+	headers    http.Header    // extra headers sent with every request
+
+	// refreshMu serializes token refreshes, so concurrent requests that find
+	// the token expired, or are rejected with the same token, trigger a
+	// single refresh.
+	refreshMu sync.Mutex
+	// reauthToken is the last token fetched by reauthenticate. A 401 for it
+	// means the credentials lack access, so it is not refreshed again.
+	reauthToken string
 }
 
 // TokenResponse represents the Port API token response.
@@ -42,7 +77,22 @@ type ClientOpts struct {
 	ClientID     string
 	ClientSecret string
 	APIURL       string
+	APIVersion   string // sent as X-Port-API-Version when set
 	Timeout      time.Duration
+	// RateLimit caps requests per second and Concurrency the requests in
+	// flight at once; 0 leaves them unlimited.
+	RateLimit   float64
+	Concurrency int
+	// Headers are added to every request, usually from the resolved org's
+	// config.OrganizationConfig.Headers. They cannot replace Authorization or
+	// the other headers the client sets.
+	Headers http.Header
+	// CacheTTL caches successful GET responses in CacheDir (by default
+	// DefaultResponseCacheDir) for this long; 0 disables caching. With
+	// NoCache, cached responses are refreshed instead of served.
+	CacheTTL time.Duration
+	NoCache  bool
+	CacheDir string
 }
 
 // NewClient creates a new Port API client.
@@ -52,15 +102,28 @@ func NewClient(opts ClientOpts) *Client {
 	clientSecret := opts.ClientSecret
 	token := opts.Token
 	timeout := opts.Timeout
+	apiVersion := opts.APIVersion
 
 	if apiURL == "" {
-		apiURL = "https://api.getport.io/v1"
+		apiURL = config.PortCloudAPIURL
 	}
 
 	if timeout == 0 {
 		timeout = 300 * time.Second
 	}
 
+	var cache *ResponseCache
+	if opts.CacheTTL > 0 {
+		dir := opts.CacheDir
+		if dir == "" {
+			// Without a home directory there is nowhere to cache
+			dir, _ = DefaultResponseCacheDir()
+		}
+		if dir != "" {
+			cache = NewResponseCache(dir, opts.CacheTTL, opts.NoCache)
+		}
+	}
+
 	// Remove trailing slash
 	if len(apiURL) > 0 && apiURL[len(apiURL)-1] == '/' {
 		apiURL = apiURL[:len(apiURL)-1]
@@ -74,9 +137,13 @@ func NewClient(opts ClientOpts) *Client {
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
-		tokenMgr: tm,
-		apiURL:   apiURL,
-		timeout:  timeout,
+		tokenMgr:   tm,
+		apiURL:     apiURL,
+		apiVersion: apiVersion,
+		timeout:    timeout,
+		cache:      cache,
+		throttle:   newThrottle(opts.RateLimit, opts.Concurrency),
+		headers:    opts.Headers,
 	}
 }
 
@@ -87,7 +154,12 @@ func (c *Client) getToken(ctx context.Context) (string, error) {
 		return token, nil
 	}
 
-	// Refresh token
+	// Refresh token, unless another request did while we waited
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if token, err := c.tokenMgr.GetToken(); err == nil && token != "" {
+		return token, nil
+	}
 	return c.refreshToken(ctx)
 }
 
@@ -108,10 +180,16 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to create auth request: %w", err)
 	}
+	c.setHeaders(req)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", useragent.String())
+	if c.apiVersion != "" {
+		req.Header.Set(APIVersionHeader, c.apiVersion)
+	}
 
+	started := time.Now()
 	resp, err := c.httpClient.Do(req)
+	logRequest(ctx, "POST", req.URL.Path, 0, started, resp, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to authenticate: %w", err)
 	}
@@ -134,38 +212,122 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	return tokenResp.AccessToken, nil
 }
 
-// request makes an authenticated request to the Port API.
-func (c *Client) request(ctx context.Context, method, path string, data any, params map[string]string) (*http.Response, error) {
+// errTokenRejected is returned by reauthenticate when a freshly fetched token
+// was itself rejected, so authenticating again would not help.
+var errTokenRejected = errors.New("token rejected right after re-authentication")
+
+// reauthenticate fetches a new token after a request made with stale was
+// rejected with 401. When another request has already replaced stale, its
+// token is returned instead of authenticating again, so each token is
+// refreshed at most once however many requests it failed.
+func (c *Client) reauthenticate(ctx context.Context, stale string) (string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if current := c.tokenMgr.Current(); current != "" && current != stale {
+		return current, nil
+	}
+	if stale == c.reauthToken {
+		return "", errTokenRejected
+	}
+	token, err := c.refreshToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.reauthToken = token
+	return token, nil
+}
+
+// canReauthenticate reports whether the client holds the credentials needed
+// to fetch a new token.
+func (c *Client) canReauthenticate() bool {
+	return c.tokenMgr.ClientID != "" && c.tokenMgr.ClientSecret != ""
+}
+
+// request makes an authenticated request to the Port API, through the
+// response cache when the client has one.
+func (c *Client) request(ctx context.Context, method, path string, data any, params url.Values) (*http.Response, error) {
+	return c.requestWithKey(ctx, method, path, data, params, "")
+}
+
+// createRequest POSTs a create to the Port API with a new idempotency key,
+// which every retry of this one logical create repeats.
+func (c *Client) createRequest(ctx context.Context, path string, data any) (*http.Response, error) {
+	return c.requestWithKey(ctx, http.MethodPost, path, data, nil, newIdempotencyKey())
+}
+
+// requestWithKey is request with an optional idempotency key, sent as the
+// IdempotencyKeyHeader of every attempt when non-empty.
+func (c *Client) requestWithKey(ctx context.Context, method, path string, data any, params url.Values, idempotencyKey string) (*http.Response, error) {
+	// Responses are only cached for clients identified by a client ID, so
+	// orgs reached with a bare token never share entries.
+	if c.cache != nil && c.tokenMgr.ClientID != "" {
+		return c.cachedRequest(method, path, params, func() (*http.Response, error) {
+			return c.authorizedRequest(ctx, method, path, data, params, idempotencyKey)
+		})
+	}
+	return c.authorizedRequest(ctx, method, path, data, params, idempotencyKey)
+}
+
+// authorizedRequest sends a request with the client's token. A request
+// rejected with 401, such as one outliving its token during a long
+// migration, is retried once with a freshly fetched token.
+func (c *Client) authorizedRequest(ctx context.Context, method, path string, data any, params url.Values, idempotencyKey string) (*http.Response, error) {
 	token, err := c.getToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s%s", c.apiURL, path)
-
-	var reqBody io.Reader
+	var body []byte
 	if data != nil {
-		jsonData, err := json.Marshal(data)
+		body, err = json.Marshal(data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	resp, err := c.send(ctx, method, path, body, params, token, idempotencyKey)
+	if !HasStatus(err, http.StatusUnauthorized) || !c.canReauthenticate() {
+		return resp, err
+	}
+	fresh, authErr := c.reauthenticate(ctx, token)
+	if authErr != nil {
+		return nil, err
+	}
+	return c.send(ctx, method, path, body, params, fresh, idempotencyKey)
+}
+
+// send makes one request with token, retrying rate limits and network errors.
+// A non-empty idempotencyKey is sent as the IdempotencyKeyHeader of every
+// attempt.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, params url.Values, token, idempotencyKey string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("%s%s", c.apiURL, path)
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewBuffer(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	c.setHeaders(req)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", useragent.String())
+	if c.apiVersion != "" {
+		req.Header.Set(APIVersionHeader, c.apiVersion)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
 
 	// Add query parameters
 	if params != nil {
 		q := req.URL.Query()
-		for k, v := range params {
-			q.Set(k, v)
+		for k, vs := range params {
+			q[k] = append(q[k], vs...)
 		}
 		req.URL.RawQuery = q.Encode()
 	}
@@ -186,19 +348,33 @@ func (c *Client) request(ctx context.Context, method, path string, data any, par
 			}
 		}
 
+		release, err := c.throttle.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		started := time.Now()
 		resp, err = c.httpClient.Do(req)
+		release()
+		logRequest(ctx, method, req.URL.Path, attempt, started, resp, err)
 		if err != nil {
 			if attempt == maxRetries {
 				return nil, fmt.Errorf("failed to execute request after %d attempts: %w", maxRetries+1, err)
 			}
 			// Retry on network errors
+			if budgetErr := spendRetry(ctx, err); budgetErr != nil {
+				return nil, budgetErr
+			}
 			continue
 		}
 
 		// Check if status code is retryable (429 Too Many Requests)
 		if resp.StatusCode == retryableStatus && attempt < maxRetries {
 			delay := retryAfterDelay(resp, attempt)
+			body, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
+			if budgetErr := spendRetry(ctx, newAPIError(method, endpoint, resp.StatusCode, body)); budgetErr != nil {
+				return nil, budgetErr
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
@@ -207,17 +383,12 @@ func (c *Client) request(ctx context.Context, method, path string, data any, par
 			continue
 		}
 
-		// Non-retryable status codes
-		if resp.StatusCode >= 400 {
+		// Non-2xx responses become an *APIError so callers never try to
+		// decode an error body as a success payload.
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			body, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			// Create more descriptive error message
-			statusText := resp.Status
-			bodyStr := string(body)
-			if bodyStr != "" {
-				return nil, fmt.Errorf("API request to %s %s failed: %s. Body: %s", url, method, statusText, bodyStr)
-			}
-			return nil, fmt.Errorf("API request to %s %s failed: %s", url, method, statusText)
+			return nil, newAPIError(method, endpoint, resp.StatusCode, body)
 		}
 
 		// Success
@@ -227,6 +398,26 @@ func (c *Client) request(ctx context.Context, method, path string, data any, par
 	return resp, err
 }
 
+// logRequest logs one request attempt to the context's structured logger.
+func logRequest(ctx context.Context, method, path string, attempt int, started time.Time, resp *http.Response, err error) {
+	logger := logging.FromContext(ctx)
+	if logger == nil {
+		return
+	}
+	fields := logging.Fields{
+		"method":      method,
+		"path":        path,
+		"attempt":     attempt + 1,
+		"duration_ms": time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err
+	} else {
+		fields["status"] = resp.StatusCode
+	}
+	logger.Log("request", fields)
+}
+
 // retryAfterDelay returns how long to wait after a 429 response.
 // Reads Retry-After header first; falls back to exponential backoff.
 func retryAfterDelay(resp *http.Response, attempt int) time.Duration {
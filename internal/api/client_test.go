@@ -3,14 +3,19 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/port-experimental/port-cli/internal/auth"
+	"github.com/port-experimental/port-cli/internal/config"
 	"github.com/port-experimental/port-cli/internal/useragent"
 )
 
@@ -59,8 +64,8 @@ func TestNewClient(t *testing.T) {
 func TestNewClient_DefaultURL(t *testing.T) {
 	client := NewClient(ClientOpts{ClientID: "test-id", ClientSecret: "test-secret", APIURL: "", Timeout: 0})
 
-	if client.apiURL != "https://api.getport.io/v1" {
-		t.Errorf("Expected default apiURL 'https://api.getport.io/v1', got '%s'", client.apiURL)
+	if client.apiURL != config.PortCloudAPIURL {
+		t.Errorf("Expected default apiURL '%s', got '%s'", config.PortCloudAPIURL, client.apiURL)
 	}
 }
 
@@ -265,6 +270,62 @@ func TestClient_request_Retry(t *testing.T) {
 	}
 }
 
+func TestClient_request_IdempotencyKey(t *testing.T) {
+	var keys []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", ExpiresIn: 3600, TokenType: "Bearer"})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		bodies = append(bodies, string(body))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "test-id", ClientSecret: "test-secret", APIURL: server.URL})
+	entity := map[string]string{"identifier": "svc"}
+	for i := 0; i < 2; i++ {
+		resp, err := client.createRequest(context.Background(), "/blueprints/service/entities", entity)
+		if err != nil {
+			t.Fatalf("create %d: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+	for _, method := range []string{"GET", "POST"} {
+		resp, err := client.request(context.Background(), method, "/blueprints/service/entities/search", entity, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(keys) != 5 {
+		t.Fatalf("expected 5 requests (one retried), got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("a retried create should repeat its idempotency key, got %q then %q", keys[0], keys[1])
+	}
+	if keys[2] == "" || keys[2] == keys[0] {
+		t.Errorf("a new create should get a new idempotency key, got %q after %q", keys[2], keys[0])
+	}
+	if keys[3] != "" {
+		t.Errorf("a GET should not send an idempotency key, got %q", keys[3])
+	}
+	if keys[4] != "" {
+		t.Errorf("a POST that is not a create should not send an idempotency key, got %q", keys[4])
+	}
+	if bodies[1] != bodies[0] || bodies[1] == "" {
+		t.Errorf("the retried create should resend its body, got %q then %q", bodies[0], bodies[1])
+	}
+}
+
 func TestClient_Close(t *testing.T) {
 	client := NewClient(ClientOpts{ClientID: "test-id", ClientSecret: "test-secret", APIURL: "https://api.getport.io/v1", Timeout: 0})
 
@@ -324,3 +385,131 @@ func TestClient_UserAgent(t *testing.T) {
 		}
 	}
 }
+
+// TestClient_APIVersionHeader verifies that a pinned API version is sent on
+// every request, and that no header is sent by default.
+func TestClient_APIVersionHeader(t *testing.T) {
+	var mu sync.Mutex
+	var versions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		versions = append(versions, r.Header.Get(APIVersionHeader))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(TokenResponse{AccessToken: "tok", ExpiresIn: 3600})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"ok": "1"})
+	}))
+	defer server.Close()
+
+	run := func(opts ClientOpts) []string {
+		versions = nil
+		client := NewClient(opts)
+		resp, err := client.request(context.Background(), "GET", "/test", nil, nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		return versions
+	}
+
+	for _, v := range run(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}) {
+		if v != "" {
+			t.Errorf("expected no API version header by default, got %q", v)
+		}
+	}
+
+	got := run(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, APIVersion: "2024-06-01"})
+	if len(got) < 2 {
+		t.Fatalf("expected at least 2 requests, got %d", len(got))
+	}
+	for _, v := range got {
+		if v != "2024-06-01" {
+			t.Errorf("expected API version header 2024-06-01, got %q", v)
+		}
+	}
+}
+
+// reauthServer issues tokens "token-1", "token-2", ... and accepts API
+// requests only with the token accepted reports as valid.
+func reauthServer(t *testing.T, accepted func(token string) bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			n := issued.Add(1)
+			json.NewEncoder(w).Encode(TokenResponse{AccessToken: fmt.Sprintf("token-%d", n), ExpiresIn: 3600})
+			return
+		}
+		if !accepted(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "unauthorized"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestClient_request_ReauthenticatesOn401(t *testing.T) {
+	server, issued := reauthServer(t, func(token string) bool { return token == "token-2" })
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.request(context.Background(), "GET", "/test", nil, nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("expected the request to succeed after re-authenticating, got %v", err)
+		}
+	}
+	if got := issued.Load(); got != 2 {
+		t.Errorf("expected one initial token and one refresh, got %d token requests", got)
+	}
+}
+
+func TestClient_request_RejectedFreshTokenIsNotRefreshedAgain(t *testing.T) {
+	server, issued := reauthServer(t, func(string) bool { return false })
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.request(context.Background(), "GET", "/test", nil, nil)
+		if !HasStatus(err, http.StatusUnauthorized) {
+			t.Fatalf("expected a 401 APIError, got %v", err)
+		}
+	}
+	if got := issued.Load(); got != 2 {
+		t.Errorf("expected a single refresh for a persistently rejected client, got %d token requests", got)
+	}
+}
+
+func TestClient_request_NoReauthenticationWithoutCredentials(t *testing.T) {
+	server, issued := reauthServer(t, func(string) bool { return false })
+	token := &auth.Token{Token: "static"}
+	token.Claims.Expiry = time.Now().Add(time.Hour)
+	client := NewClient(ClientOpts{Token: token, APIURL: server.URL})
+
+	if _, err := client.request(context.Background(), "GET", "/test", nil, nil); !HasStatus(err, http.StatusUnauthorized) {
+		t.Fatalf("expected a 401 APIError, got %v", err)
+	}
+	if got := issued.Load(); got != 0 {
+		t.Errorf("expected no token requests without client credentials, got %d", got)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// APIError is returned for every non-2xx response from the Port API.
+type APIError struct {
+	Method  string
+	URL     string
+	Status  int    // HTTP status code
+	Code    string // Port error code from the response body's "error" field
+	Message string // human-readable message from the response body's "message" field
+	Body    string // raw response body
+}
+
+// newAPIError builds an APIError from a response and its already-read body.
+// Bodies that are not Port's {"error": ..., "message": ...} shape leave Code
+// and Message empty.
+func newAPIError(method, url string, status int, body []byte) *APIError {
+	e := &APIError{Method: method, URL: url, Status: status, Body: string(body)}
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &parsed) == nil {
+		e.Code = parsed.Error
+		e.Message = parsed.Message
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status))
+	if e.Body != "" {
+		return fmt.Sprintf("API request to %s %s failed: %s. Body: %s", e.URL, e.Method, status, e.Body)
+	}
+	return fmt.Sprintf("API request to %s %s failed: %s", e.URL, e.Method, status)
+}
+
+// AsAPIError returns the *APIError in err's chain, if there is one.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// HasStatus reports whether err is an APIError with one of the given HTTP
+// statuses.
+func HasStatus(err error, statuses ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && slices.Contains(statuses, apiErr.Status)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_request_ReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", ExpiresIn: 3600, TokenType: "Bearer"})
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"ok":false,"error":"identifier_taken","message":"Entity with identifier svc already exists"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "test-id", ClientSecret: "test-secret", APIURL: server.URL})
+	_, err := client.request(context.Background(), "POST", "/blueprints/service/entities", nil, nil)
+	if err == nil {
+		t.Fatal("expected error for 409 response")
+	}
+
+	apiErr, ok := AsAPIError(fmt.Errorf("wrapped: %w", err))
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Status != http.StatusConflict {
+		t.Errorf("Status = %d, want 409", apiErr.Status)
+	}
+	if apiErr.Code != "identifier_taken" {
+		t.Errorf("Code = %q, want identifier_taken", apiErr.Code)
+	}
+	if apiErr.Message != "Entity with identifier svc already exists" {
+		t.Errorf("Message = %q", apiErr.Message)
+	}
+	if !strings.Contains(err.Error(), "409 Conflict. Body: {") {
+		t.Errorf("Error() = %q, want status and body", err.Error())
+	}
+	if !HasStatus(err, http.StatusConflict) || HasStatus(err, http.StatusNotFound) {
+		t.Error("HasStatus did not match the response status")
+	}
+	if !HasStatus(err, http.StatusNotFound, http.StatusConflict) || HasStatus(err, http.StatusBadRequest, http.StatusNotFound) {
+		t.Error("HasStatus did not match one of several statuses")
+	}
+}
+
+func TestNewAPIError_NonJSONBody(t *testing.T) {
+	err := newAPIError("GET", "https://api.example.com/v1/x", http.StatusBadGateway, []byte("<html>bad gateway</html>"))
+	if err.Code != "" || err.Message != "" {
+		t.Errorf("expected empty Code/Message for non-JSON body, got %q/%q", err.Code, err.Message)
+	}
+	if HasStatus(errors.New("409 Conflict"), http.StatusConflict) {
+		t.Error("HasStatus matched a plain error")
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ParseHeaders parses "Key: Value" header values, as given to --header.
+// Blank values are ignored. Authorization is rejected, as the client sets it
+// from the org's credentials.
+func ParseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		key, v, ok := strings.Cut(value, ":")
+		key, v = strings.TrimSpace(key), strings.TrimSpace(v)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid header %q: expected \"Key: Value\"", value)
+		}
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			return nil, fmt.Errorf("the Authorization header cannot be overridden")
+		}
+		headers.Add(key, v)
+	}
+	return headers, nil
+}
+
+// setHeaders adds the client's extra headers to req. It is called before the
+// client sets its own headers, which keep their values.
+func (c *Client) setHeaders(req *http.Request) {
+	for key, values := range c.headers {
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			continue
+		}
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"X-Request-ID: abc-123", " x-feature : beta", "", "X-Feature: canary"})
+	if err != nil {
+		t.Fatalf("ParseHeaders: %v", err)
+	}
+	want := http.Header{"X-Request-Id": {"abc-123"}, "X-Feature": {"beta", "canary"}}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("ParseHeaders = %v, want %v", headers, want)
+	}
+
+	for _, value := range []string{"X-Request-ID", ": value", "X Bad: value", "authorization: Bearer other"} {
+		if _, err := ParseHeaders([]string{value}); err == nil {
+			t.Errorf("ParseHeaders(%q): expected an error", value)
+		}
+	}
+}
+
+func TestClient_SendsCustomHeaders(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.URL.Path] = r.Header.Clone()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/access_token" {
+			w.Write([]byte(`{"ok":true,"accessToken":"tok","expiresIn":3600}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		APIURL:       server.URL,
+		Headers:      http.Header{"X-Request-Id": {"abc-123"}, "Content-Type": {"text/plain"}, "Authorization": {"Bearer other"}},
+	})
+	defer client.Close()
+	if _, err := client.request(context.Background(), "GET", "/blueprints", nil, nil); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	for _, path := range []string{"/auth/access_token", "/blueprints"} {
+		h := seen[path]
+		if h.Get("X-Request-Id") != "abc-123" {
+			t.Errorf("%s: X-Request-Id = %q, want abc-123", path, h.Get("X-Request-Id"))
+		}
+		if h.Get("Content-Type") != "application/json" {
+			t.Errorf("%s: Content-Type = %q, want the client's own", path, h.Get("Content-Type"))
+		}
+	}
+	if auth := seen["/blueprints"].Get("Authorization"); !strings.HasSuffix(auth, "tok") {
+		t.Errorf("Authorization = %q, want the client's token", auth)
+	}
+
+	// Headers belong to the client they were given to, not to later ones
+	plain := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	defer plain.Close()
+	if _, err := plain.request(context.Background(), "GET", "/actions", nil, nil); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if seen["/actions"].Get("X-Request-Id") != "" {
+		t.Errorf("a client without Headers sent %v", seen["/actions"])
+	}
+}
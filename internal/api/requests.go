@@ -4,6 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // Blueprint represents a Port blueprint.
@@ -46,8 +51,20 @@ type RequestParams struct {
 	Params   map[string]string
 }
 
+// queryParams converts single-valued query parameters to url.Values.
+func queryParams(params map[string]string) url.Values {
+	if params == nil {
+		return nil
+	}
+	query := make(url.Values, len(params))
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	return query
+}
+
 func (c *Client) Request(ctx context.Context, params RequestParams) (any, error) {
-	resp, err := c.request(ctx, params.Method, params.Endpoint, params.Data, params.Params)
+	resp, err := c.request(ctx, params.Method, params.Endpoint, params.Data, queryParams(params.Params))
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +115,7 @@ func (c *Client) GetBlueprint(ctx context.Context, identifier string) (Blueprint
 
 // CreateBlueprint creates a new blueprint.
 func (c *Client) CreateBlueprint(ctx context.Context, blueprint Blueprint) (Blueprint, error) {
-	resp, err := c.request(ctx, "POST", "/blueprints", blueprint, nil)
+	resp, err := c.createRequest(ctx, "/blueprints", blueprint)
 	if err != nil {
 		return nil, err
 	}
@@ -160,9 +177,45 @@ func (c *Client) DeleteBlueprint(ctx context.Context, identifier string) error {
 	return nil
 }
 
+// CreateBlueprintRelation adds a relation to a blueprint without rewriting
+// the rest of it.
+func (c *Client) CreateBlueprintRelation(ctx context.Context, blueprintIdentifier, relationIdentifier string, relation map[string]interface{}) error {
+	body := make(map[string]interface{}, len(relation)+1)
+	for k, v := range relation {
+		body[k] = v
+	}
+	body["identifier"] = relationIdentifier
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/blueprints/%s/relations", blueprintIdentifier), body, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// UpdateBlueprintRelation replaces one relation of a blueprint.
+func (c *Client) UpdateBlueprintRelation(ctx context.Context, blueprintIdentifier, relationIdentifier string, relation map[string]interface{}) error {
+	resp, err := c.request(ctx, "PUT", fmt.Sprintf("/blueprints/%s/relations/%s", blueprintIdentifier, relationIdentifier), relation, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// DeleteBlueprintRelation removes one relation from a blueprint.
+func (c *Client) DeleteBlueprintRelation(ctx context.Context, blueprintIdentifier, relationIdentifier string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/blueprints/%s/relations/%s", blueprintIdentifier, relationIdentifier), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
 // GetEntities retrieves entities for a blueprint.
 func (c *Client) GetEntities(ctx context.Context, blueprintIdentifier string, params map[string]string) ([]Entity, error) {
-	resp, err := c.request(ctx, "GET", fmt.Sprintf("/blueprints/%s/entities", blueprintIdentifier), nil, params)
+	resp, err := c.request(ctx, "GET", fmt.Sprintf("/blueprints/%s/entities", blueprintIdentifier), nil, queryParams(params))
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +390,7 @@ func (c *Client) GetEntity(ctx context.Context, blueprintIdentifier, entityIdent
 
 // CreateEntity creates a new entity.
 func (c *Client) CreateEntity(ctx context.Context, blueprintIdentifier string, entity Entity) (Entity, error) {
-	resp, err := c.request(ctx, "POST", fmt.Sprintf("/blueprints/%s/entities", blueprintIdentifier), entity, nil)
+	resp, err := c.createRequest(ctx, fmt.Sprintf("/blueprints/%s/entities", blueprintIdentifier), entity)
 	if err != nil {
 		return nil, err
 	}
@@ -381,17 +434,58 @@ func (c *Client) DeleteEntity(ctx context.Context, blueprintIdentifier, entityId
 	return nil
 }
 
+// entityDeleteBatchSize is the number of entities each DeleteEntities bulk
+// request removes.
+const entityDeleteBatchSize = 100
+
+// DeleteEntities deletes entities of a blueprint, running up to concurrency
+// requests at a time. Entities are removed in batches through the bulk delete
+// endpoint; when the API does not offer it (404 or 405), each entity is
+// deleted on its own instead. It returns how many entities were deleted, which
+// is fewer than requested when an error stops it.
+func (c *Client) DeleteEntities(ctx context.Context, blueprintIdentifier string, entityIdentifiers []string, concurrency int) (int, error) {
+	var deleted atomic.Int64
+	var bulkUnsupported atomic.Bool
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(concurrency, 1))
+	for start := 0; start < len(entityIdentifiers); start += entityDeleteBatchSize {
+		batch := entityIdentifiers[start:min(start+entityDeleteBatchSize, len(entityIdentifiers))]
+		g.Go(func() error {
+			if !bulkUnsupported.Load() {
+				_, err := c.BulkDeleteEntities(groupCtx, blueprintIdentifier, batch, false)
+				if err == nil {
+					deleted.Add(int64(len(batch)))
+					return nil
+				}
+				if !HasStatus(err, http.StatusNotFound) && !HasStatus(err, http.StatusMethodNotAllowed) {
+					return err
+				}
+				bulkUnsupported.Store(true)
+			}
+			for _, entityIdentifier := range batch {
+				if err := c.DeleteEntity(groupCtx, blueprintIdentifier, entityIdentifier); err != nil {
+					return fmt.Errorf("failed to delete entity %s: %w", entityIdentifier, err)
+				}
+				deleted.Add(1)
+			}
+			return nil
+		})
+	}
+	err := g.Wait()
+	return int(deleted.Load()), err
+}
+
 // BulkDeleteEntities deletes multiple entities for a blueprint.
 func (c *Client) BulkDeleteEntities(ctx context.Context, blueprintIdentifier string, entityIdentifiers []string, deleteDependents bool) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"entities": entityIdentifiers,
 	}
 
-	params := map[string]string{}
+	params := url.Values{}
 	if deleteDependents {
-		params["delete_dependents"] = "true"
+		params.Set("delete_dependents", "true")
 	} else {
-		params["delete_dependents"] = "false"
+		params.Set("delete_dependents", "false")
 	}
 
 	resp, err := c.request(ctx, "POST", fmt.Sprintf("/blueprints/%s/bulk/entities/delete", blueprintIdentifier), payload, params)
@@ -510,6 +604,42 @@ func (c *Client) DeleteScorecard(ctx context.Context, blueprintIdentifier, score
 	return nil
 }
 
+// ScorecardResult is the level one entity reached in a scorecard.
+type ScorecardResult struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title,omitempty"`
+	Level      string `json:"level"`
+}
+
+// GetScorecardResults retrieves the level each entity of a blueprint reached
+// in a scorecard. Port reports scorecard results on the entities themselves,
+// under their "scorecards" field, so the blueprint's entities are searched
+// page by page. Entities the scorecard has not evaluated have an empty level.
+func (c *Client) GetScorecardResults(ctx context.Context, blueprintIdentifier, scorecardIdentifier string) ([]ScorecardResult, error) {
+	body := paginatedEntitySearchBody()
+	body["include"] = []string{"identifier", "title", "scorecards"}
+
+	var results []ScorecardResult
+	err := c.ForEachEntityPage(ctx, blueprintIdentifier, body, func(entities []Entity) error {
+		for _, entity := range entities {
+			result := ScorecardResult{}
+			result.Identifier, _ = entity["identifier"].(string)
+			result.Title, _ = entity["title"].(string)
+			if scorecards, ok := entity["scorecards"].(map[string]interface{}); ok {
+				if scorecard, ok := scorecards[scorecardIdentifier].(map[string]interface{}); ok {
+					result.Level, _ = scorecard["level"].(string)
+				}
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // GetActions retrieves actions for a blueprint.
 func (c *Client) GetActions(ctx context.Context, blueprintIdentifier string) ([]Action, error) {
 	resp, err := c.request(ctx, "GET", fmt.Sprintf("/blueprints/%s/actions", blueprintIdentifier), nil, nil)
@@ -574,9 +704,16 @@ func (c *Client) DeleteAction(ctx context.Context, blueprintIdentifier, actionId
 	return nil
 }
 
+// teamFields asks GET /teams for every team field, including the member
+// emails, which the API leaves out unless requested.
+var teamFields = url.Values{"fields": {
+	"id", "name", "description", "provider", "createdAt", "updatedAt",
+	"users.email", "users.firstName", "users.lastName", "users.status",
+}}
+
 // GetTeams retrieves all teams.
 func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
-	resp, err := c.request(ctx, "GET", "/teams", nil, nil)
+	resp, err := c.request(ctx, "GET", "/teams", nil, teamFields)
 	if err != nil {
 		return nil, err
 	}
@@ -638,6 +775,26 @@ func (c *Client) DeleteTeam(ctx context.Context, teamName string) error {
 	return nil
 }
 
+// AddTeamMembers adds users, by email, to a team.
+func (c *Client) AddTeamMembers(ctx context.Context, teamName string, emails []string) error {
+	resp, err := c.request(ctx, "POST", fmt.Sprintf("/teams/%s/users", teamName), map[string]interface{}{"users": emails}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// RemoveTeamMembers removes users, by email, from a team.
+func (c *Client) RemoveTeamMembers(ctx context.Context, teamName string, emails []string) error {
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/teams/%s/users", teamName), map[string]interface{}{"users": emails}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
 // GetUsers retrieves all users in the organization.
 func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
 	resp, err := c.request(ctx, "GET", "/users", nil, nil)
@@ -690,7 +847,7 @@ func (c *Client) CreateUserEntitiesBulk(ctx context.Context, entities []Entity,
 		"entities": entities,
 	}
 	path := fmt.Sprintf("/blueprints/_user/entities/bulk?upsert=%t", upsert)
-	resp, err := c.request(ctx, "POST", path, payload, nil)
+	resp, err := c.createRequest(ctx, path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -713,7 +870,7 @@ func (c *Client) BulkUpsertEntities(ctx context.Context, blueprintID string, ent
 		"entities": entities,
 	}
 	path := fmt.Sprintf("/blueprints/%s/entities/bulk?upsert=%t", blueprintID, upsert)
-	resp, err := c.request(ctx, "POST", path, payload, nil)
+	resp, err := c.createRequest(ctx, path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -959,6 +1116,24 @@ func (c *Client) GetIntegrations(ctx context.Context) ([]Integration, error) {
 	return result.Integrations, nil
 }
 
+// CreateIntegration installs a new integration.
+func (c *Client) CreateIntegration(ctx context.Context, integration Integration) (Integration, error) {
+	resp, err := c.request(ctx, "POST", "/integration", integration, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Integration Integration `json:"integration"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode integration: %w", err)
+	}
+
+	return result.Integration, nil
+}
+
 // UpdateIntegrationConfig updates an integration's configuration.
 func (c *Client) UpdateIntegrationConfig(ctx context.Context, integrationIdentifier string, config map[string]interface{}) (Integration, error) {
 	resp, err := c.request(ctx, "PATCH", fmt.Sprintf("/integration/%s/config", integrationIdentifier), config, nil)
@@ -1293,3 +1468,24 @@ func (c *Client) GetAuditLogs(ctx context.Context) ([]AuditLog, error) {
 
 	return result.Audits, nil
 }
+
+// Organization represents the Port organization the client is authenticated to.
+type Organization map[string]interface{}
+
+// GetOrganization retrieves the authenticated organization.
+func (c *Client) GetOrganization(ctx context.Context) (Organization, error) {
+	resp, err := c.request(ctx, "GET", "/organization", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Organization Organization `json:"organization"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode organization: %w", err)
+	}
+
+	return result.Organization, nil
+}
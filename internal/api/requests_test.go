@@ -3,10 +3,12 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -462,3 +464,249 @@ func TestBulkDeleteEntities_Error(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestCreateIntegration(t *testing.T) {
+	var requestPath, requestMethod string
+	var requestBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+			return
+		}
+		requestPath = r.URL.Path
+		requestMethod = r.Method
+		if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":          true,
+			"integration": map[string]interface{}{"installationId": "my-github"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+
+	res, err := client.CreateIntegration(context.Background(), Integration{
+		"installationId":      "my-github",
+		"installationAppType": "github",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestMethod != "POST" || requestPath != "/integration" {
+		t.Errorf("expected POST /integration, got %s %s", requestMethod, requestPath)
+	}
+	if requestBody["installationAppType"] != "github" {
+		t.Errorf("expected installationAppType github in body, got %v", requestBody)
+	}
+	if res["installationId"] != "my-github" {
+		t.Errorf("expected returned integration, got %v", res)
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/organization":
+			if r.Method != http.MethodGet {
+				t.Errorf("expected GET, got %s", r.Method)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":           true,
+				"organization": map[string]interface{}{"id": "org_123", "name": "Acme"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	org, err := client.GetOrganization(context.Background())
+	if err != nil {
+		t.Fatalf("GetOrganization returned error: %v", err)
+	}
+	if org["id"] != "org_123" || org["name"] != "Acme" {
+		t.Fatalf("unexpected organization %v", org)
+	}
+}
+
+func TestGetScorecardResults(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints/service/entities/search":
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"entities": []map[string]interface{}{
+					{"identifier": "svc-1", "title": "Service 1", "scorecards": map[string]interface{}{
+						"readiness": map[string]interface{}{"level": "Gold"},
+						"security":  map[string]interface{}{"level": "Bronze"},
+					}},
+					{"identifier": "svc-2"},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+	results, err := client.GetScorecardResults(context.Background(), "service", "readiness")
+	if err != nil {
+		t.Fatalf("GetScorecardResults returned error: %v", err)
+	}
+	want := []ScorecardResult{{Identifier: "svc-1", Title: "Service 1", Level: "Gold"}, {Identifier: "svc-2"}}
+	if len(results) != len(want) || results[0] != want[0] || results[1] != want[1] {
+		t.Errorf("results = %#v, want %#v", results, want)
+	}
+	if include, _ := body["include"].([]interface{}); len(include) != 3 {
+		t.Errorf("expected the search to include identifier, title and scorecards, got %v", body["include"])
+	}
+}
+
+func TestBlueprintRelationEndpoints(t *testing.T) {
+	var calls []string
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&created)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+	ctx := context.Background()
+	relation := map[string]interface{}{"target": "team", "many": false}
+	if err := client.CreateBlueprintRelation(ctx, "service", "owner", relation); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := client.UpdateBlueprintRelation(ctx, "service", "owner", relation); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := client.DeleteBlueprintRelation(ctx, "service", "owner"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	want := []string{"POST /blueprints/service/relations", "PUT /blueprints/service/relations/owner", "DELETE /blueprints/service/relations/owner"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if created["identifier"] != "owner" || created["target"] != "team" {
+		t.Errorf("unexpected create body %v", created)
+	}
+	if _, ok := relation["identifier"]; ok {
+		t.Error("CreateBlueprintRelation modified the caller's relation")
+	}
+}
+
+func TestDeleteEntities_UsesBulkEndpointInBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.Method == http.MethodPost && r.URL.Path == "/blueprints/service/bulk/entities/delete":
+			var body struct {
+				Entities []interface{} `json:"entities"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			batches = append(batches, body.Entities)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			http.Error(w, "unexpected call", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("svc-%d", i)
+	}
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+	deleted, err := client.DeleteEntities(context.Background(), "service", ids, 4)
+	if err != nil {
+		t.Fatalf("DeleteEntities returned error: %v", err)
+	}
+	if deleted != 250 {
+		t.Errorf("expected 250 deleted, got %d", deleted)
+	}
+	if len(batches) != 3 {
+		t.Errorf("expected 3 bulk requests, got %d", len(batches))
+	}
+}
+
+func TestDeleteEntities_FallsBackToSingleDeletes(t *testing.T) {
+	var mu sync.Mutex
+	var single []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.URL.Path == "/blueprints/service/bulk/entities/delete":
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/blueprints/service/entities/"):
+			mu.Lock()
+			single = append(single, strings.TrimPrefix(r.URL.Path, "/blueprints/service/entities/"))
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			http.Error(w, "unexpected call", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+	deleted, err := client.DeleteEntities(context.Background(), "service", []string{"a", "b", "c"}, 2)
+	if err != nil {
+		t.Fatalf("DeleteEntities returned error: %v", err)
+	}
+	if deleted != 3 || len(single) != 3 {
+		t.Errorf("expected 3 single deletes, got %d deleted and %v", deleted, single)
+	}
+}
+
+func TestGetTeams_RequestsMembers(t *testing.T) {
+	var fields []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/teams":
+			fields = r.URL.Query()["fields"]
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "teams": []interface{}{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Timeout: 0})
+	if _, err := client.GetTeams(context.Background()); err != nil {
+		t.Fatalf("GetTeams returned error: %v", err)
+	}
+	found := false
+	for _, field := range fields {
+		found = found || field == "users.email"
+	}
+	if !found || len(fields) < 2 {
+		t.Errorf("expected the team fields and member emails to be requested, got %v", fields)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ResponseCache keeps the bodies of successful GET responses on disk for a
+// TTL, so scripts that fetch the same blueprints and entities over and over
+// skip the round trips. Entries are keyed by API URL, API version, client ID,
+// path and query, and kept in one directory per API URL and client ID, so
+// orgs never share entries. Any other request sent through a caching client,
+// apart from read-only searches, empties its org's entries, as it may change
+// what a GET returns.
+type ResponseCache struct {
+	dir string
+	ttl time.Duration
+	// refresh skips cached entries but still stores fresh responses.
+	refresh bool
+}
+
+// NewResponseCache creates a cache storing entries in dir for ttl. With
+// refresh, every GET goes to the API and its response replaces the cached one.
+func NewResponseCache(dir string, ttl time.Duration, refresh bool) *ResponseCache {
+	return &ResponseCache{dir: dir, ttl: ttl, refresh: refresh}
+}
+
+// DefaultResponseCacheDir returns the directory of the response cache,
+// ~/.port/cache/responses.
+func DefaultResponseCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".port", "cache", "responses"), nil
+}
+
+// readOnlyPosts are the path suffixes of POST endpoints that only read data,
+// so sending them leaves the cache valid.
+var readOnlyPosts = []string{"/entities/search", "/entities/top-search"}
+
+// isReadOnlyRequest reports whether a non-GET request to path leaves the data
+// a GET returns unchanged.
+func isReadOnlyRequest(method, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	for _, suffix := range readOnlyPosts {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearResponseCache removes every cached response in dir.
+func ClearResponseCache(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear response cache: %w", err)
+	}
+	return nil
+}
+
+// orgDir returns the directory holding c's entries: one per API URL and client
+// ID, so a write to one org leaves other orgs' entries alone.
+func (rc *ResponseCache) orgDir(c *Client) string {
+	sum := sha256.Sum256([]byte(c.apiURL + "\n" + c.tokenMgr.ClientID))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:8]))
+}
+
+// key returns the file name of the entry for a GET of path with params.
+func (rc *ResponseCache) key(c *Client, path string, params url.Values) string {
+	sum := sha256.Sum256([]byte(c.apiURL + "\n" + c.apiVersion + "\n" + c.tokenMgr.ClientID + "\nGET " + path + "?" + params.Encode()))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the body cached in dir under key, unless it is older than the
+// TTL.
+func (rc *ResponseCache) get(dir, key string) ([]byte, bool) {
+	if rc.refresh {
+		return nil, false
+	}
+	path := filepath.Join(dir, key)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > rc.ttl {
+		return nil, false
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// put stores body in dir under key. The cache is best effort: failures to
+// write it are ignored.
+func (rc *ResponseCache) put(dir, key string, body []byte) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(body)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), filepath.Join(dir, key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// cachedRequest serves a GET from the cache when it holds a fresh entry, and
+// otherwise sends it and caches a successful response. Other requests are
+// sent as they are and, unless they only read data, empty the org's entries
+// when they succeed.
+func (c *Client) cachedRequest(method, path string, params url.Values, send func() (*http.Response, error)) (*http.Response, error) {
+	dir := c.cache.orgDir(c)
+	if method != http.MethodGet {
+		resp, err := send()
+		if err == nil && !isReadOnlyRequest(method, path) {
+			ClearResponseCache(dir)
+		}
+		return resp, err
+	}
+
+	key := c.cache.key(c, path, params)
+	if body, ok := c.cache.get(dir, key); ok {
+		return cachedResponse(body), nil
+	}
+	resp, err := send()
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	c.cache.put(dir, key, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// cachedResponse returns a successful response with body.
+func cachedResponse(body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCachingTestClient returns a client caching into dir and the number of
+// GET /blueprints/service requests its server received.
+func newCachingTestClient(t *testing.T, dir string, ttl time.Duration, refresh bool) (*Client, *atomic.Int32) {
+	t.Helper()
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.Method == http.MethodGet && r.URL.Path == "/blueprints/service":
+			n := gets.Add(1)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": map[string]interface{}{"identifier": "service", "title": fmt.Sprintf("v%d", n)}})
+		case r.URL.Path == "/blueprints/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+		default:
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, CacheTTL: ttl, NoCache: refresh, CacheDir: dir})
+	t.Cleanup(func() { client.Close() })
+	return client, &gets
+}
+
+func TestResponseCache_ServesRepeatedGets(t *testing.T) {
+	dir := t.TempDir()
+	client, gets := newCachingTestClient(t, dir, time.Minute, false)
+	ctx := context.Background()
+
+	first, err := client.GetBlueprint(ctx, "service")
+	if err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	second, err := client.GetBlueprint(ctx, "service")
+	if err != nil {
+		t.Fatalf("cached GetBlueprint: %v", err)
+	}
+	if gets.Load() != 1 {
+		t.Errorf("server saw %d GETs, want 1", gets.Load())
+	}
+	if first["title"] != "v1" || second["title"] != "v1" {
+		t.Errorf("titles = %v, %v; want the cached v1 twice", first["title"], second["title"])
+	}
+
+	// Errors are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBlueprint(ctx, "missing"); !HasStatus(err, http.StatusNotFound) {
+			t.Errorf("GetBlueprint(missing) = %v, want a 404", err)
+		}
+	}
+	entries, _ := os.ReadDir(client.cache.orgDir(client))
+	if len(entries) != 1 {
+		t.Errorf("cache holds %d entries, want only the successful GET", len(entries))
+	}
+}
+
+func TestResponseCache_WritesEmptyTheCache(t *testing.T) {
+	dir := t.TempDir()
+	client, gets := newCachingTestClient(t, dir, time.Minute, false)
+	ctx := context.Background()
+
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if _, err := client.UpdateBlueprint(ctx, "service", map[string]interface{}{"title": "Service"}); err != nil {
+		t.Fatalf("UpdateBlueprint: %v", err)
+	}
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("server saw %d GETs, want 2 (the update invalidates the cache)", gets.Load())
+	}
+}
+
+func TestResponseCache_SearchesKeepTheCache(t *testing.T) {
+	dir := t.TempDir()
+	client, gets := newCachingTestClient(t, dir, time.Minute, false)
+	ctx := context.Background()
+
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if _, err := client.SearchEntities(ctx, "service", map[string]interface{}{"query": map[string]interface{}{}}); err != nil {
+		t.Fatalf("SearchEntities: %v", err)
+	}
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if gets.Load() != 1 {
+		t.Errorf("server saw %d GETs, want 1 (a search does not invalidate the cache)", gets.Load())
+	}
+}
+
+func TestResponseCache_WritesKeepOtherOrgsEntries(t *testing.T) {
+	dir := t.TempDir()
+	client, _ := newCachingTestClient(t, dir, time.Minute, false)
+	other, otherGets := newCachingTestClient(t, dir, time.Minute, false)
+	ctx := context.Background()
+
+	if _, err := other.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if _, err := client.UpdateBlueprint(ctx, "service", map[string]interface{}{"title": "Service"}); err != nil {
+		t.Fatalf("UpdateBlueprint: %v", err)
+	}
+	if _, err := other.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if otherGets.Load() != 1 {
+		t.Errorf("other org's server saw %d GETs, want 1 (a write to one org keeps the other's entries)", otherGets.Load())
+	}
+}
+
+func TestResponseCache_ExpiresAndRefreshes(t *testing.T) {
+	dir := t.TempDir()
+	client, gets := newCachingTestClient(t, dir, time.Minute, false)
+	ctx := context.Background()
+
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	orgDir := client.cache.orgDir(client)
+	entries, _ := os.ReadDir(orgDir)
+	if len(entries) != 1 {
+		t.Fatalf("cache holds %d entries, want 1", len(entries))
+	}
+	stale := time.Now().Add(-2 * time.Minute)
+	os.Chtimes(filepath.Join(orgDir, entries[0].Name()), stale, stale)
+	if _, err := client.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("server saw %d GETs, want 2 (the entry expired)", gets.Load())
+	}
+
+	refreshing, refreshGets := newCachingTestClient(t, dir, time.Minute, true)
+	if _, err := refreshing.GetBlueprint(ctx, "service"); err != nil {
+		t.Fatalf("GetBlueprint: %v", err)
+	}
+	if refreshGets.Load() != 1 {
+		t.Errorf("refreshing client saw %d GETs, want 1 despite a fresh entry", refreshGets.Load())
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrRetryBudgetExhausted is returned by a request that needed a retry after
+// the operation's RetryBudget ran out.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget caps the total number of retries across every request in one
+// operation, so an export, import or migration against a struggling API fails
+// fast instead of retrying each request up to the per-request limit. A budget
+// is safe for concurrent use and is shared through the request context; see
+// WithRetryBudget.
+type RetryBudget struct {
+	total     int64
+	remaining atomic.Int64
+	debug     io.Writer
+
+	exhaustOnce sync.Once
+	onExhausted func(error)
+}
+
+// NewRetryBudget returns a budget allowing n retries in total. When debug is
+// non-nil, every retry taken from the budget is logged to it with the number
+// remaining.
+func NewRetryBudget(n int, debug io.Writer) *RetryBudget {
+	b := &RetryBudget{total: int64(n), debug: debug}
+	b.remaining.Store(int64(n))
+	return b
+}
+
+// OnExhausted registers fn to be called once, with the error the request
+// failed with, the first time a request needs a retry the budget no longer
+// has. Commands use it to cancel the rest of the operation.
+func (b *RetryBudget) OnExhausted(fn func(error)) {
+	b.onExhausted = fn
+}
+
+// Remaining returns the number of retries left.
+func (b *RetryBudget) Remaining() int {
+	if r := b.remaining.Load(); r > 0 {
+		return int(r)
+	}
+	return 0
+}
+
+// take spends one retry, reporting false when the budget is already spent.
+func (b *RetryBudget) take() bool {
+	left := b.remaining.Add(-1)
+	if left < 0 {
+		return false
+	}
+	if b.debug != nil {
+		fmt.Fprintf(b.debug, "debug: retry budget: %d of %d retries remaining\n", left, b.total)
+	}
+	return true
+}
+
+type retryBudgetKey struct{}
+
+// WithRetryBudget returns a context whose requests spend their retries from
+// budget. A nil budget leaves retries limited only per request.
+func WithRetryBudget(ctx context.Context, budget *RetryBudget) context.Context {
+	if budget == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// spendRetry takes a retry from the context's budget, if it has one, and
+// returns an error wrapping cause once the budget is exhausted.
+func spendRetry(ctx context.Context, cause error) error {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	if !ok || budget.take() {
+		return nil
+	}
+	err := fmt.Errorf("%w after %d retries: %w", ErrRetryBudgetExhausted, budget.total, cause)
+	if budget.onExhausted != nil {
+		budget.exhaustOnce.Do(func() { budget.onExhausted(err) })
+	}
+	return err
+}
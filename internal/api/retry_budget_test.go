@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetryBudget_SharedAcrossRequests(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true,"accessToken":"tok","expiresIn":3600}`))
+			return
+		}
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var debug bytes.Buffer
+	budget := NewRetryBudget(2, &debug)
+	ctx := WithRetryBudget(context.Background(), budget)
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+
+	_, err := client.request(ctx, "GET", "/first", nil, nil)
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("first request error = %v, want ErrRetryBudgetExhausted", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+		t.Errorf("first request error = %v, want it to wrap the 429 response", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("first request made %d attempts, want 3 (1 + 2 retries)", got)
+	}
+
+	// The budget is spent, so the next request fails after its first attempt.
+	if _, err := client.request(ctx, "GET", "/second", nil, nil); !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("second request error = %v, want ErrRetryBudgetExhausted", err)
+	}
+	if got := attempts.Load(); got != 4 {
+		t.Errorf("made %d attempts in total, want 4", got)
+	}
+	if budget.Remaining() != 0 {
+		t.Errorf("Remaining() = %d, want 0", budget.Remaining())
+	}
+	if !strings.Contains(debug.String(), "1 of 2 retries remaining") || !strings.Contains(debug.String(), "0 of 2 retries remaining") {
+		t.Errorf("debug log = %q, want remaining budget after each retry", debug.String())
+	}
+}
+
+func TestRetryBudget_WithoutBudgetRetriesPerRequest(t *testing.T) {
+	if err := spendRetry(context.Background(), errors.New("boom")); err != nil {
+		t.Errorf("spendRetry without a budget = %v, want nil", err)
+	}
+	if ctx := WithRetryBudget(context.Background(), nil); ctx.Value(retryBudgetKey{}) != nil {
+		t.Error("WithRetryBudget(nil) stored a budget")
+	}
+}
+
+func TestRetryBudget_OnExhaustedCalledOnce(t *testing.T) {
+	budget := NewRetryBudget(1, nil)
+	var calls []error
+	budget.OnExhausted(func(err error) { calls = append(calls, err) })
+	ctx := WithRetryBudget(context.Background(), budget)
+
+	if err := spendRetry(ctx, errors.New("boom")); err != nil {
+		t.Fatalf("first retry = %v, want it to be allowed", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := spendRetry(ctx, errors.New("boom")); !errors.Is(err, ErrRetryBudgetExhausted) {
+			t.Fatalf("retry after the budget = %v, want ErrRetryBudgetExhausted", err)
+		}
+	}
+	if len(calls) != 1 || !errors.Is(calls[0], ErrRetryBudgetExhausted) {
+		t.Fatalf("OnExhausted calls = %v, want one with ErrRetryBudgetExhausted", calls)
+	}
+}
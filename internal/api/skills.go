@@ -308,6 +308,7 @@ func (c *Client) skillsRequest(
 	if err != nil {
 		return fmt.Errorf("failed to create skills request: %w", err)
 	}
+	c.setHeaders(req)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// throttle paces a client's requests to a rate and caps how many are in
+// flight. A nil throttle lets every request through at once.
+type throttle struct {
+	interval time.Duration
+	slots    chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newThrottle(rateLimit float64, concurrency int) *throttle {
+	if rateLimit <= 0 && concurrency <= 0 {
+		return nil
+	}
+	t := &throttle{}
+	if rateLimit > 0 {
+		t.interval = time.Duration(float64(time.Second) / rateLimit)
+	}
+	if concurrency > 0 {
+		t.slots = make(chan struct{}, concurrency)
+	}
+	return t
+}
+
+// acquire waits for a concurrency slot and for the request's turn under the
+// rate limit. Call the returned function once the response has arrived.
+func (t *throttle) acquire(ctx context.Context) (release func(), err error) {
+	if t == nil {
+		return func() {}, nil
+	}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if t.slots != nil {
+			<-t.slots
+		}
+	}
+	if t.interval > 0 {
+		t.mu.Lock()
+		now := time.Now()
+		if t.next.Before(now) {
+			t.next = now
+		}
+		wait := t.next.Sub(now)
+		t.next = t.next.Add(t.interval)
+		t.mu.Unlock()
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottle_PacesRequests(t *testing.T) {
+	throttle := newThrottle(20, 0)
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := throttle.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		release()
+	}
+	// The first request goes out at once, the next three 50ms apart.
+	if elapsed := time.Since(started); elapsed < 140*time.Millisecond {
+		t.Errorf("4 requests at 20/s took %v, want at least 150ms", elapsed)
+	}
+}
+
+func TestThrottle_NilAndCancelled(t *testing.T) {
+	if newThrottle(0, 0) != nil {
+		t.Fatal("expected no throttle without a rate limit or concurrency")
+	}
+	var unthrottled *throttle
+	if _, err := unthrottled.acquire(context.Background()); err != nil {
+		t.Errorf("nil throttle: %v", err)
+	}
+
+	throttle := newThrottle(0, 1)
+	if _, err := throttle.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := throttle.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire with no free slot = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_ConcurrencyLimitsInFlightRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/access_token" {
+			w.Write([]byte(`{"ok":true,"accessToken":"tok","expiresIn":3600}`))
+			return
+		}
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL, Concurrency: 2})
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.request(context.Background(), "GET", "/blueprints", nil, nil); err != nil {
+				t.Errorf("request: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak in-flight requests = %d, want 2", got)
+	}
+}
+
+func TestNewClient_Throttle(t *testing.T) {
+	client := NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret"})
+	defer client.Close()
+	if client.throttle != nil {
+		t.Errorf("client throttle = %+v, want none without limits", client.throttle)
+	}
+
+	client = NewClient(ClientOpts{ClientID: "id", ClientSecret: "secret", RateLimit: 10, Concurrency: 1})
+	defer client.Close()
+	if client.throttle == nil || client.throttle.interval != 100*time.Millisecond || cap(client.throttle.slots) != 1 {
+		t.Errorf("client throttle = %+v, want the org settings", client.throttle)
+	}
+}
@@ -64,6 +64,13 @@ func (tm *TokenManager) refreshToken() (string, time.Time, error) {
 	return "", time.Time{}, nil
 }
 
+// Current returns the cached token, valid or not, without refreshing it.
+func (tm *TokenManager) Current() string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.token
+}
+
 // SetToken sets the token and expiry (used by client during refresh).
 func (tm *TokenManager) SetToken(token string, expiry time.Time) {
 	tm.mu.Lock()
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// RegisterAnalyze registers the analyze command group.
+func RegisterAnalyze(rootCmd *cobra.Command) {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze the structure of a Port organization",
+	}
+
+	analyzeCmd.AddCommand(registerAnalyzeDependents())
+	analyzeCmd.AddCommand(registerAnalyzeGraph())
+	analyzeCmd.AddCommand(registerAnalyzeOrphans())
+	analyzeCmd.AddCommand(registerAnalyzeRelations())
+
+	rootCmd.AddCommand(analyzeCmd)
+}
+
+func registerAnalyzeDependents() *cobra.Command {
+	var org, outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "dependents [blueprint-id]",
+		Short: "List blueprints whose relations target a blueprint",
+		Long: `List blueprints whose relations target a blueprint.
+
+Use this before deleting a blueprint to see what depends on it. Without a
+blueprint ID, every blueprint that has dependents is listed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			blueprints, err := client.GetBlueprints(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list blueprints: %w", err)
+			}
+			index := import_module.NewDependencyIndex(blueprints)
+
+			targets := index.Targets()
+			if len(args) == 1 {
+				if !blueprintExists(blueprints, args[0]) {
+					return fmt.Errorf("blueprint %q not found", args[0])
+				}
+				targets = []string{args[0]}
+			}
+
+			if outputFormat == "json" {
+				jsonData := make(map[string]interface{}, len(targets))
+				for _, target := range targets {
+					deps := index.GetDependents(target)
+					if deps == nil {
+						deps = []import_module.Dependent{}
+					}
+					jsonData[target] = deps
+				}
+				return output.PrintJSON(jsonData)
+			}
+
+			for _, target := range targets {
+				deps := index.GetDependents(target)
+				if len(deps) == 0 {
+					output.Printf("%s: no dependents\n", target)
+					continue
+				}
+				output.Printf("%s: %d dependent relation(s)\n", target, len(deps))
+				for _, dep := range deps {
+					attrs := ""
+					if dep.Required {
+						attrs += " (required)"
+					}
+					if dep.Many {
+						attrs += " (many)"
+					}
+					output.Printf("  %s.%s%s\n", dep.Blueprint, dep.Relation, attrs)
+				}
+			}
+			if len(targets) == 0 {
+				output.Printf("No blueprint relations found.\n")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+func blueprintExists(blueprints []api.Blueprint, id string) bool {
+	for _, bp := range blueprints {
+		if bpID, _ := bp["identifier"].(string); bpID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func registerAnalyzeGraph() *cobra.Command {
+	var org, format, outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the blueprint relation graph as Graphviz DOT or Mermaid",
+		Long: `Export the blueprint relation graph as Graphviz DOT or Mermaid.
+
+Each relation is drawn as an edge from its blueprint to the blueprint it
+targets, labeled with the relation name. Required relations are drawn solid
+and optional ones dashed. Relations on a dependency cycle are drawn in red.`,
+		Example: `  port analyze graph -o graph.dot && dot -Tsvg graph.dot -o graph.svg
+  port analyze graph --format mermaid -o graph.mmd`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--format", format, []string{"dot", "mermaid"}); err != nil {
+				return err
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			blueprints, err := client.GetBlueprints(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list blueprints: %w", err)
+			}
+			graph := import_module.BuildRelationGraph(blueprints)
+
+			write := writeDOTGraph
+			if format == "mermaid" {
+				write = writeMermaidGraph
+			}
+			if outputPath == "" {
+				return write(os.Stdout, graph)
+			}
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", outputPath, err)
+			}
+			if err := write(file, graph); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to write %s: %w", outputPath, err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputPath, err)
+			}
+			output.Printf("Wrote %d blueprint(s) and %d relation(s) to %s\n", len(graph.Blueprints), len(graph.Edges), outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVar(&format, "format", "dot", "Graph format: dot (Graphviz) or mermaid")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "File to write the graph to (default: stdout)")
+
+	return cmd
+}
+
+// relationEdgeLabel labels an edge with its relation name and cardinality.
+func relationEdgeLabel(edge import_module.RelationEdge) string {
+	label := edge.Relation
+	if edge.Many {
+		label += " [many]"
+	}
+	if edge.Required {
+		label += " (required)"
+	}
+	return label
+}
+
+// writeDOTGraph writes graph in Graphviz DOT.
+func writeDOTGraph(w io.Writer, graph import_module.RelationGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph blueprints {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, id := range graph.Blueprints {
+		label := id
+		if title := graph.Titles[id]; title != "" && title != id {
+			label = title + "\n(" + id + ")"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s];\n", strconv.Quote(id), strconv.Quote(label))
+	}
+	for _, edge := range graph.Edges {
+		attrs := []string{"label=" + strconv.Quote(relationEdgeLabel(edge))}
+		if !edge.Required {
+			attrs = append(attrs, "style=dashed")
+		}
+		if edge.Cyclic {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaidGraph writes graph as a Mermaid flowchart. Blueprints are given
+// generated node IDs, as identifiers may clash with Mermaid keywords.
+func writeMermaidGraph(w io.Writer, graph import_module.RelationGraph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	nodes := make(map[string]string, len(graph.Blueprints))
+	node := func(id string) string {
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		n := fmt.Sprintf("bp%d", len(nodes))
+		nodes[id] = n
+		label := id
+		if title := graph.Titles[id]; title != "" && title != id {
+			label = title + " (" + id + ")"
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", n, mermaidEscape(label))
+		return n
+	}
+	for _, id := range graph.Blueprints {
+		node(id)
+	}
+	var cyclic []string
+	for i, edge := range graph.Edges {
+		arrow := "-->"
+		if !edge.Required {
+			arrow = "-.->"
+		}
+		from, to := node(edge.From), node(edge.To)
+		fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", from, arrow, mermaidEscape(relationEdgeLabel(edge)), to)
+		if edge.Cyclic {
+			cyclic = append(cyclic, strconv.Itoa(i))
+		}
+	}
+	if len(cyclic) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red,color:red\n", strings.Join(cyclic, ","))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidEscape escapes the characters that end a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func testRelationGraph() import_module.RelationGraph {
+	return import_module.RelationGraph{
+		Blueprints: []string{"service", "team"},
+		Titles:     map[string]string{"service": `The "Service"`},
+		Edges: []import_module.RelationEdge{
+			{From: "service", To: "team", Relation: "owner", Required: true},
+			{From: "team", To: "team", Relation: "parent", Cyclic: true},
+		},
+	}
+}
+
+func TestWriteDOTGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDOTGraph(&buf, testRelationGraph()); err != nil {
+		t.Fatalf("writeDOTGraph: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`"service" [label="The \"Service\"\n(service)"];`,
+		`"service" -> "team" [label="owner (required)"];`,
+		`"team" -> "team" [label="parent", style=dashed, color=red, fontcolor=red];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected DOT output to contain %s, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteMermaidGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeMermaidGraph(&buf, testRelationGraph()); err != nil {
+		t.Fatalf("writeMermaidGraph: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`bp0["The #quot;Service#quot; (service)"]`,
+		`bp0 -->|"owner (required)"| bp1`,
+		`bp1 -.->|"parent"| bp1`,
+		"linkStyle 1 stroke:red,color:red",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected Mermaid output to contain %s, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// orphanReport is the result of an orphan audit.
+type orphanReport struct {
+	BlueprintsChecked int                             `json:"blueprintsChecked"`
+	EntitiesChecked   int                             `json:"entitiesChecked"`
+	OrphanedEntities  int                             `json:"orphanedEntities"`
+	Violations        []import_module.SchemaViolation `json:"violations"`
+}
+
+func registerAnalyzeOrphans() *cobra.Command {
+	var org, outputFormat string
+	var blueprintIDs []string
+
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "List entities that violate their blueprint's current schema",
+		Long: `List entities that violate their blueprint's current schema.
+
+After a blueprint change narrows an enum, changes a property type or makes a
+property required, existing entities may no longer fit the schema. Each
+entity's properties are checked against its blueprint: required properties
+must be set, values must match their type, and enum values must be allowed.
+The command exits with code 3 when any orphaned entity is found.`,
+		Example: `  port analyze orphans
+  port analyze orphans --blueprint service --output-format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			blueprints, err := client.GetBlueprints(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list blueprints: %w", err)
+			}
+			if len(blueprintIDs) > 0 {
+				selected := make([]api.Blueprint, 0, len(blueprintIDs))
+				for _, id := range blueprintIDs {
+					if !blueprintExists(blueprints, id) {
+						return fmt.Errorf("blueprint %q not found", id)
+					}
+					for _, bp := range blueprints {
+						if bpID, _ := bp["identifier"].(string); bpID == id {
+							selected = append(selected, bp)
+						}
+					}
+				}
+				blueprints = selected
+			}
+
+			report := orphanReport{Violations: []import_module.SchemaViolation{}}
+			for _, bp := range blueprints {
+				bpID, _ := bp["identifier"].(string)
+				err := client.ForEachEntity(cmd.Context(), bpID, func(entities []api.Entity) error {
+					for _, entity := range entities {
+						report.EntitiesChecked++
+						violations := import_module.ValidateEntitySchema(bp, entity)
+						if len(violations) > 0 {
+							report.OrphanedEntities++
+							report.Violations = append(report.Violations, violations...)
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to list entities of blueprint %s: %w", bpID, err)
+				}
+				report.BlueprintsChecked++
+			}
+
+			if outputFormat == "json" {
+				if err := output.PrintJSON(report); err != nil {
+					return err
+				}
+			} else if err := writeOrphanReport(os.Stdout, report); err != nil {
+				return err
+			}
+			if report.OrphanedEntities > 0 {
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("found %d orphaned entit(ies)", report.OrphanedEntities))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringSliceVar(&blueprintIDs, "blueprint", nil, "Blueprint(s) to audit (default: all blueprints)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// writeOrphanReport writes one line per schema violation followed by a
+// summary.
+func writeOrphanReport(w io.Writer, report orphanReport) error {
+	for _, v := range report.Violations {
+		if _, err := fmt.Fprintln(w, v.String()); err != nil {
+			return err
+		}
+	}
+	checked := fmt.Sprintf("checked %d entit(ies) in %d blueprint(s)", report.EntitiesChecked, report.BlueprintsChecked)
+	if report.OrphanedEntities == 0 {
+		_, err := fmt.Fprintf(w, "No orphaned entities found (%s)\n", checked)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d orphaned entit(ies) with %d schema violation(s) (%s)\n", report.OrphanedEntities, len(report.Violations), checked)
+	return err
+}
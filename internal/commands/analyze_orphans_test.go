@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestWriteOrphanReport(t *testing.T) {
+	var buf bytes.Buffer
+	report := orphanReport{
+		BlueprintsChecked: 2,
+		EntitiesChecked:   5,
+		OrphanedEntities:  1,
+		Violations: []import_module.SchemaViolation{
+			{Blueprint: "service", Entity: "svc", Property: "tier", Detail: "value platinum is not one of [gold, silver]"},
+			{Blueprint: "service", Entity: "svc", Property: "owner", Detail: "required property is missing"},
+		},
+	}
+	if err := writeOrphanReport(&buf, report); err != nil {
+		t.Fatalf("writeOrphanReport: %v", err)
+	}
+	want := "service/svc: tier: value platinum is not one of [gold, silver]\n" +
+		"service/svc: owner: required property is missing\n" +
+		"1 orphaned entit(ies) with 2 schema violation(s) (checked 5 entit(ies) in 2 blueprint(s))\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeOrphanReport(&buf, orphanReport{BlueprintsChecked: 1, EntitiesChecked: 3}); err != nil {
+		t.Fatalf("writeOrphanReport: %v", err)
+	}
+	if want := "No orphaned entities found (checked 3 entit(ies) in 1 blueprint(s))\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// relationReport is the result of a relation audit.
+type relationReport struct {
+	BlueprintsChecked int                              `json:"blueprintsChecked"`
+	EntitiesChecked   int                              `json:"entitiesChecked"`
+	Mismatches        []import_module.RelationMismatch `json:"mismatches"`
+}
+
+func registerAnalyzeRelations() *cobra.Command {
+	var org, inputPath, outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "relations",
+		Short: "List relations whose target blueprint or entities do not match",
+		Long: `List relations whose target blueprint or entities do not match.
+
+Port rejects an entity whose relation targets a blueprint that does not
+exist, holds a list where the relation takes one identifier (or the reverse),
+or points at an entity that is missing from the target blueprint. This command
+finds those mismatches ahead of time and names both ends of each one.
+
+By default the organization is audited. With --input, an export bundle is
+checked instead, without contacting Port, for example before importing it.
+Entity identifiers are then only checked against target blueprints that have
+entities in the bundle. The command exits with code 3 when any mismatch is
+found.`,
+		Example: `  port analyze relations
+  port analyze relations --input backup.tar.gz --output-format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+
+			var blueprints []api.Blueprint
+			var entities map[string][]api.Entity
+			if inputPath != "" {
+				data, err := import_module.NewLoader().LoadData(inputPath)
+				if err != nil {
+					return fmt.Errorf("failed to load data: %w", err)
+				}
+				blueprints = data.Blueprints
+				entities = make(map[string][]api.Entity)
+				for _, entity := range data.Entities {
+					bpID, _ := entity["blueprint"].(string)
+					entities[bpID] = append(entities[bpID], entity)
+				}
+			} else {
+				flags := GetGlobalFlags(cmd.Context())
+				configManager := newConfigManager(flags)
+
+				org = resolveOrg(org)
+				cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+				if err != nil {
+					return fmt.Errorf("failed to load configuration: %w", err)
+				}
+
+				useOrg := cfg.GetOrgOrDefault(org)
+				orgConfig, err := cfg.GetOrgConfig(useOrg)
+				if err != nil {
+					return err
+				}
+				token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+				if err != nil {
+					return err
+				}
+				client := api.NewClient(api.ClientOpts{
+					Token:        token,
+					ClientID:     orgConfig.ClientID,
+					ClientSecret: orgConfig.ClientSecret,
+					APIURL:       orgConfig.APIURL,
+					RateLimit:    orgConfig.RateLimit,
+					Concurrency:  orgConfig.Concurrency,
+					Headers:      orgConfig.Headers,
+					APIVersion:   orgConfig.APIVersion,
+					CacheTTL:     orgConfig.CacheTTL,
+					NoCache:      orgConfig.NoCache,
+					Timeout:      0,
+				})
+				defer client.Close()
+
+				blueprints, err = client.GetBlueprints(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to list blueprints: %w", err)
+				}
+				entities, err = relationEntities(cmd.Context(), client, blueprints)
+				if err != nil {
+					return err
+				}
+			}
+
+			report := relationReport{BlueprintsChecked: len(blueprints)}
+			for _, ents := range entities {
+				report.EntitiesChecked += len(ents)
+			}
+			report.Mismatches = import_module.FindRelationMismatches(blueprints, entities)
+			if report.Mismatches == nil {
+				report.Mismatches = []import_module.RelationMismatch{}
+			}
+
+			if outputFormat == "json" {
+				if err := output.PrintJSON(report); err != nil {
+					return err
+				}
+			} else if err := writeRelationReport(os.Stdout, report); err != nil {
+				return err
+			}
+			if len(report.Mismatches) > 0 {
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("found %d relation mismatch(es)", len(report.Mismatches)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&inputPath, "input", "i", "", "Check an export bundle instead of the organization")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// relationEntities lists the entities of every blueprint, keeping only their
+// identifier and relations, as the relation audit needs nothing else.
+func relationEntities(ctx context.Context, client *api.Client, blueprints []api.Blueprint) (map[string][]api.Entity, error) {
+	entities := make(map[string][]api.Entity, len(blueprints))
+	for _, bp := range blueprints {
+		bpID, _ := bp["identifier"].(string)
+		ents := []api.Entity{}
+		err := client.ForEachEntity(ctx, bpID, func(page []api.Entity) error {
+			for _, entity := range page {
+				ents = append(ents, api.Entity{"identifier": entity["identifier"], "relations": entity["relations"]})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of blueprint %s: %w", bpID, err)
+		}
+		entities[bpID] = ents
+	}
+	return entities, nil
+}
+
+// writeRelationReport writes one line per mismatch followed by a summary.
+func writeRelationReport(w io.Writer, report relationReport) error {
+	for _, m := range report.Mismatches {
+		if _, err := fmt.Fprintln(w, m.String()); err != nil {
+			return err
+		}
+	}
+	checked := fmt.Sprintf("checked %d entit(ies) in %d blueprint(s)", report.EntitiesChecked, report.BlueprintsChecked)
+	if len(report.Mismatches) == 0 {
+		_, err := fmt.Fprintf(w, "No relation mismatches found (%s)\n", checked)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d relation mismatch(es) (%s)\n", len(report.Mismatches), checked)
+	return err
+}
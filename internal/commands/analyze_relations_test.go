@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestWriteRelationReport(t *testing.T) {
+	var buf bytes.Buffer
+	report := relationReport{
+		BlueprintsChecked: 2,
+		EntitiesChecked:   4,
+		Mismatches: []import_module.RelationMismatch{
+			{Blueprint: "service", Relation: "cluster", Target: "cluster", Detail: "target blueprint does not exist"},
+			{Blueprint: "service", Relation: "domain", Target: "domain", Entity: "checkout", TargetEntity: "payments", Detail: "no domain entity with this identifier"},
+		},
+	}
+	if err := writeRelationReport(&buf, report); err != nil {
+		t.Fatalf("writeRelationReport: %v", err)
+	}
+	want := "service.cluster -> cluster: target blueprint does not exist\n" +
+		"service/checkout.domain -> domain/payments: no domain entity with this identifier\n" +
+		"2 relation mismatch(es) (checked 4 entit(ies) in 2 blueprint(s))\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeRelationReport(&buf, relationReport{BlueprintsChecked: 1}); err != nil {
+		t.Fatalf("writeRelationReport: %v", err)
+	}
+	if want := "No relation mismatches found (checked 0 entit(ies) in 1 blueprint(s))\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -4,26 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/auth"
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 // formatOutput formats and displays output data.
 func formatOutput(data interface{}, format string) error {
-	if err := validateStringEnum("--format", format, []string{"json", "yaml"}); err != nil {
+	if err := validateStringEnum("--format", format, []string{"json", "yaml", "ids"}); err != nil {
 		return err
 	}
 	switch format {
+	case "ids":
+		return writeIdentifiers(os.Stdout, data)
 	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(data)
+		return output.NewJSONEncoder(os.Stdout).Encode(data)
 	case "yaml":
 		encoder := yaml.NewEncoder(os.Stdout)
 		defer encoder.Close()
@@ -35,6 +38,49 @@ func formatOutput(data interface{}, format string) error {
 	}
 }
 
+// identifierKey returns the field --format ids prints for data's resource
+// type. Teams are identified by name, users by email and action runs by id.
+func identifierKey(data interface{}) string {
+	switch data.(type) {
+	case []api.Team, api.Team:
+		return "name"
+	case []api.User, api.User:
+		return "email"
+	case []api.ActionRun, api.ActionRun:
+		return "id"
+	default:
+		return "identifier"
+	}
+}
+
+// writeIdentifiers writes the identifier of each resource in data to w, one
+// per line, so list output can be piped into xargs and shell completion.
+func writeIdentifiers(w io.Writer, data interface{}) error {
+	key := identifierKey(data)
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var item map[string]interface{}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return fmt.Errorf("--format ids is only supported for resources and resource lists")
+		}
+		items = []map[string]interface{}{item}
+	}
+	for i, item := range items {
+		id, ok := item[key]
+		if !ok || id == nil {
+			return fmt.Errorf("item %d has no %q field to print", i, key)
+		}
+		if _, err := fmt.Fprintln(w, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getOrRefreshCommandToken(cmd *cobra.Command, configManager *config.ConfigManager, org string) (*auth.Token, error) {
 	token, err := configManager.GetOrRefreshToken(cmd.Context(), org)
 	if err != nil && !config.ShouldIgnoreGetOrRefreshTokenError(err) {
@@ -65,6 +111,7 @@ func RegisterAPI(rootCmd *cobra.Command) {
 	blueprintsCmd.AddCommand(registerBlueprintCreate())
 	blueprintsCmd.AddCommand(registerBlueprintUpdate())
 	blueprintsCmd.AddCommand(registerBlueprintDelete())
+	blueprintsCmd.AddCommand(registerBlueprintValidateSchema())
 
 	// Entity subcommands
 	entitiesCmd := &cobra.Command{
@@ -74,9 +121,13 @@ func RegisterAPI(rootCmd *cobra.Command) {
 
 	entitiesCmd.AddCommand(registerEntityList())
 	entitiesCmd.AddCommand(registerEntityGet())
+	entitiesCmd.AddCommand(registerEntityRelations())
+	entitiesCmd.AddCommand(registerEntityExportRelated())
 	entitiesCmd.AddCommand(registerEntityCreate())
 	entitiesCmd.AddCommand(registerEntityUpdate())
 	entitiesCmd.AddCommand(registerEntityDelete())
+	entitiesCmd.AddCommand(registerEntityBulkDelete())
+	entitiesCmd.AddCommand(registerEntityMove())
 
 	// Page subcommands
 	pagesCmd := &cobra.Command{
@@ -120,6 +171,8 @@ func RegisterAPI(rootCmd *cobra.Command) {
 	scorecardsCmd.AddCommand(registerScorecardCreate())
 	scorecardsCmd.AddCommand(registerScorecardUpdate())
 	scorecardsCmd.AddCommand(registerScorecardDelete())
+	scorecardsCmd.AddCommand(registerScorecardLevels())
+	scorecardsCmd.AddCommand(registerScorecardEvaluate())
 
 	// Action subcommands
 	actionsCmd := &cobra.Command{
@@ -230,14 +283,17 @@ func RegisterAPI(rootCmd *cobra.Command) {
 // registerBlueprintList registers the blueprint list command.
 func registerBlueprintList() *cobra.Command {
 	var org, format string
+	var withCounts bool
+	var hasProperties, hasRelations []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all blueprints",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -262,6 +318,12 @@ func registerBlueprintList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -270,13 +332,24 @@ func registerBlueprintList() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to list blueprints: %w", err)
 			}
+			result = filterBlueprintsByPresence(result, hasProperties, hasRelations)
+			if withCounts {
+				counts, err := blueprintCounts(cmd.Context(), client, result)
+				if err != nil {
+					return err
+				}
+				return formatOutput(counts, format)
+			}
 
 			return formatOutput(result, format)
 		},
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
+	cmd.Flags().StringSliceVar(&hasProperties, "has-property", nil, "Only list blueprints whose schema declares these properties (comma separated or repeated; all must match)")
+	cmd.Flags().StringSliceVar(&hasRelations, "has-relation", nil, "Only list blueprints that declare these relations (comma separated or repeated; all must match)")
+	cmd.Flags().BoolVar(&withCounts, "with-counts", false, "List each blueprint as {identifier, title, entityCount, scorecardCount, actionCount}, fetching the counts concurrently")
 
 	return cmd
 }
@@ -292,8 +365,9 @@ func registerBlueprintGet() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			blueprintID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -318,6 +392,12 @@ func registerBlueprintGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -339,15 +419,61 @@ func registerBlueprintGet() *cobra.Command {
 
 // registerBlueprintCreate registers the blueprint create command.
 func registerBlueprintCreate() *cobra.Command {
-	var org, dataFile string
+	var org, dataFile, fromEntity, identifier, title string
+	var dryRun bool
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new blueprint",
+		Long: `Create a new blueprint from a JSON file with --data, or infer one from sample
+entities with --from-entity.
+
+--from-entity reads a JSON array of entities (or an export holding one under
+"entities") and infers a property for each field with its JSON type: string,
+number, boolean, array or object. Fields set in every sample are required.
+Review the inferred schema with --dry-run before creating it.`,
+		Example: `  port api blueprints create --data blueprint.json
+  port api blueprints create --from-entity services.json --identifier service --dry-run`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if (dataFile == "") == (fromEntity == "") {
+				return exitcode.Usagef("exactly one of --data or --from-entity is required")
+			}
+			if fromEntity != "" && identifier == "" {
+				return exitcode.Usagef("--from-entity requires --identifier")
+			}
+			if fromEntity == "" && (identifier != "" || title != "" || dryRun) {
+				return exitcode.Usagef("--identifier, --title and --dry-run can only be used with --from-entity")
+			}
+
+			var blueprint api.Blueprint
+			if fromEntity != "" {
+				samples, err := loadSampleEntities(fromEntity)
+				if err != nil {
+					return fmt.Errorf("failed to load sample entities: %w", err)
+				}
+				if len(samples) == 0 {
+					return fmt.Errorf("no sample entities in %s", fromEntity)
+				}
+				var skipped []string
+				blueprint, skipped = inferBlueprint(identifier, title, samples)
+				for _, name := range skipped {
+					cmd.PrintErrf("Skipped property %q: its values have different types\n", name)
+				}
+				if dryRun {
+					return formatOutput(blueprint, "json")
+				}
+			} else {
+				data, err := loadJSONFile(dataFile)
+				if err != nil {
+					return fmt.Errorf("failed to load data file: %w", err)
+				}
+				blueprint = api.Blueprint(data)
+			}
+
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -363,10 +489,6 @@ func registerBlueprintCreate() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			data, err := loadJSONFile(dataFile)
-			if err != nil {
-				return fmt.Errorf("failed to load data file: %w", err)
-			}
 
 			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
 			if err != nil {
@@ -377,12 +499,17 @@ func registerBlueprintCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
-			// Load data file
 			defer client.Close()
 
-			result, err := client.CreateBlueprint(cmd.Context(), api.Blueprint(data))
+			result, err := client.CreateBlueprint(cmd.Context(), blueprint)
 			if err != nil {
 				return fmt.Errorf("failed to create blueprint: %w", err)
 			}
@@ -394,7 +521,10 @@ func registerBlueprintCreate() *cobra.Command {
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
 	cmd.Flags().StringVar(&dataFile, "data", "", "JSON file with blueprint data")
-	cmd.MarkFlagRequired("data")
+	cmd.Flags().StringVar(&fromEntity, "from-entity", "", "JSON file with sample entities to infer the blueprint schema from")
+	cmd.Flags().StringVar(&identifier, "identifier", "", "Identifier of the inferred blueprint (with --from-entity)")
+	cmd.Flags().StringVar(&title, "title", "", "Title of the inferred blueprint (default: the identifier)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the inferred blueprint without creating it (with --from-entity)")
 
 	return cmd
 }
@@ -410,8 +540,9 @@ func registerBlueprintUpdate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			blueprintID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -442,6 +573,12 @@ func registerBlueprintUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -478,10 +615,7 @@ func registerBlueprintDelete() *cobra.Command {
 			if !force {
 				confirm, err := cmd.Flags().GetBool("yes")
 				if err != nil || !confirm {
-					cmd.Printf("Are you sure you want to delete blueprint '%s'? [y/N]: ", blueprintID)
-					var response string
-					fmt.Scanln(&response)
-					if response != "y" && response != "Y" {
+					if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete blueprint '%s'?", blueprintID)) {
 						cmd.Println("Operation cancelled")
 						return nil
 					}
@@ -489,8 +623,9 @@ func registerBlueprintDelete() *cobra.Command {
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -515,6 +650,12 @@ func registerBlueprintDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -543,8 +684,9 @@ func registerEntityList() *cobra.Command {
 		Short: "List entities",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -569,6 +711,12 @@ func registerEntityList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -603,7 +751,7 @@ func registerEntityList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 	cmd.Flags().StringVarP(&blueprint, "blueprint", "b", "", "Filter by blueprint ID")
 
 	return cmd
@@ -622,8 +770,9 @@ func registerEntityGet() *cobra.Command {
 			entityID := args[1]
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -648,6 +797,12 @@ func registerEntityGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -667,6 +822,77 @@ func registerEntityGet() *cobra.Command {
 	return cmd
 }
 
+// registerEntityRelations registers the entity relations command.
+func registerEntityRelations() *cobra.Command {
+	var org, format string
+
+	cmd := &cobra.Command{
+		Use:   "relations [blueprint-id] [entity-id]",
+		Short: "Show an entity's relations",
+		Long:  "Show only the relations block of an entity, e.g. to spot-check relations after a migration.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blueprintID := args[0]
+			entityID := args[1]
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			entity, err := client.GetEntity(cmd.Context(), blueprintID, entityID)
+			if err != nil {
+				return fmt.Errorf("failed to get entity: %w", err)
+			}
+
+			return formatOutput(entityRelations(entity), format)
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+
+	return cmd
+}
+
+// entityRelations returns entity's relations block, or an empty one when the
+// entity has no relations.
+func entityRelations(entity api.Entity) map[string]interface{} {
+	relations, _ := entity["relations"].(map[string]interface{})
+	if relations == nil {
+		relations = map[string]interface{}{}
+	}
+	return relations
+}
+
 // registerEntityCreate registers the entity create command.
 func registerEntityCreate() *cobra.Command {
 	var org, dataFile string
@@ -679,8 +905,9 @@ func registerEntityCreate() *cobra.Command {
 			blueprintID := args[0]
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -712,6 +939,12 @@ func registerEntityCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -736,18 +969,25 @@ func registerEntityCreate() *cobra.Command {
 // registerEntityUpdate registers the entity update command.
 func registerEntityUpdate() *cobra.Command {
 	var org, dataFile string
+	var merge bool
 
 	cmd := &cobra.Command{
 		Use:   "update [blueprint-id] [entity-id]",
 		Short: "Update an existing entity",
-		Args:  cobra.ExactArgs(2),
+		Long: `Update an existing entity, replacing it with the data file.
+
+With --merge, the current entity is fetched first and the data file's
+properties and relations are merged into it, so a single property can be set
+without repeating the rest of the entity.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			blueprintID := args[0]
 			entityID := args[1]
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -779,11 +1019,26 @@ func registerEntityUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
 
-			result, err := client.UpdateEntity(cmd.Context(), blueprintID, entityID, api.Entity(data))
+			entity := api.Entity(data)
+			if merge {
+				current, err := client.GetEntity(cmd.Context(), blueprintID, entityID)
+				if err != nil {
+					return fmt.Errorf("failed to get entity: %w", err)
+				}
+				entity = mergeEntity(current, entity)
+			}
+
+			result, err := client.UpdateEntity(cmd.Context(), blueprintID, entityID, entity)
 			if err != nil {
 				return fmt.Errorf("failed to update entity: %w", err)
 			}
@@ -795,11 +1050,48 @@ func registerEntityUpdate() *cobra.Command {
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
 	cmd.Flags().StringVar(&dataFile, "data", "", "JSON file with entity data")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge the data file's properties and relations into the current entity instead of replacing it")
 	cmd.MarkFlagRequired("data")
 
 	return cmd
 }
 
+// entityReadOnlyFields are set by Port and dropped from a fetched entity
+// before it is written back.
+var entityReadOnlyFields = []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id", "blueprint"}
+
+// mergeEntity returns current updated with patch: properties and relations
+// are merged key by key, and any other field in patch replaces the current
+// value. Neither argument is modified.
+func mergeEntity(current, patch api.Entity) api.Entity {
+	merged := make(api.Entity, len(current)+len(patch))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for _, field := range entityReadOnlyFields {
+		delete(merged, field)
+	}
+	for k, v := range patch {
+		if k == "properties" || k == "relations" {
+			if base, ok := merged[k].(map[string]interface{}); ok {
+				if overlay, ok := v.(map[string]interface{}); ok {
+					combined := make(map[string]interface{}, len(base)+len(overlay))
+					for name, value := range base {
+						combined[name] = value
+					}
+					for name, value := range overlay {
+						combined[name] = value
+					}
+					merged[k] = combined
+					continue
+				}
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
 // registerEntityDelete registers the entity delete command.
 func registerEntityDelete() *cobra.Command {
 	var org string
@@ -814,18 +1106,16 @@ func registerEntityDelete() *cobra.Command {
 			entityID := args[1]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete entity '%s' from blueprint '%s'? [y/N]: ", entityID, blueprintID)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete entity '%s' from blueprint '%s'?", entityID, blueprintID)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -850,6 +1140,12 @@ func registerEntityDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -882,8 +1178,9 @@ func registerPageGet() *cobra.Command {
 			pageID := args[0]
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -908,6 +1205,12 @@ func registerPageGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -952,18 +1255,16 @@ func registerPageDelete() *cobra.Command {
 			pageID := args[0]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete page '%s'? [y/N]: ", pageID)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete page '%s'?", pageID)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -988,6 +1289,12 @@ func registerPageDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1016,8 +1323,9 @@ func registerPageList() *cobra.Command {
 		Short: "List all pages",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1037,6 +1345,12 @@ func registerPageList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1051,7 +1365,7 @@ func registerPageList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -1065,8 +1379,9 @@ func registerPageCreate() *cobra.Command {
 		Short: "Create a new page",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1090,6 +1405,12 @@ func registerPageCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1122,8 +1443,9 @@ func registerPageUpdate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pageID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1147,6 +1469,12 @@ func registerPageUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1177,8 +1505,9 @@ func registerTeamList() *cobra.Command {
 		Short: "List all teams",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1198,6 +1527,12 @@ func registerTeamList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1212,7 +1547,7 @@ func registerTeamList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -1226,8 +1561,9 @@ func registerTeamCreate() *cobra.Command {
 		Short: "Create a new team",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1251,6 +1587,12 @@ func registerTeamCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1283,8 +1625,9 @@ func registerTeamUpdate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			teamName := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1308,6 +1651,12 @@ func registerTeamUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1342,18 +1691,16 @@ func registerTeamDelete() *cobra.Command {
 			teamName := args[0]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete team '%s'? [y/N]: ", teamName)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete team '%s'?", teamName)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1373,6 +1720,12 @@ func registerTeamDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1403,8 +1756,9 @@ func registerAgentInvoke() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			agentID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1428,6 +1782,12 @@ func registerAgentInvoke() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1461,8 +1821,9 @@ func registerAIInvoke() *cobra.Command {
 		Short: "Invoke Port AI",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1486,6 +1847,12 @@ func registerAIInvoke() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1521,8 +1888,9 @@ func registerAIGet() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			invocationID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1542,6 +1910,12 @@ func registerAIGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1573,8 +1947,9 @@ func registerActionRunList() *cobra.Command {
 		Short: "List all action runs",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1594,6 +1969,12 @@ func registerActionRunList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1608,7 +1989,7 @@ func registerActionRunList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -1624,8 +2005,9 @@ func registerActionRunGet() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1645,6 +2027,12 @@ func registerActionRunGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1675,8 +2063,9 @@ func registerActionRunUpdate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1700,6 +2089,12 @@ func registerActionRunUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1732,8 +2127,9 @@ func registerActionRunApprove() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1757,6 +2153,12 @@ func registerActionRunApprove() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1789,8 +2191,9 @@ func registerActionRunExecute() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			actionID := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1814,6 +2217,12 @@ func registerActionRunExecute() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1844,8 +2253,9 @@ func registerWebhookList() *cobra.Command {
 		Short: "List all webhooks",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1865,6 +2275,12 @@ func registerWebhookList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1879,7 +2295,7 @@ func registerWebhookList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -1895,8 +2311,9 @@ func registerWebhookGet() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1916,6 +2333,12 @@ func registerWebhookGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -1944,8 +2367,9 @@ func registerWebhookCreate() *cobra.Command {
 		Short: "Create a new webhook",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -1969,6 +2393,12 @@ func registerWebhookCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2001,8 +2431,9 @@ func registerWebhookUpdate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2026,6 +2457,12 @@ func registerWebhookUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2060,18 +2497,16 @@ func registerWebhookDelete() *cobra.Command {
 			id := args[0]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete webhook '%s'? [y/N]: ", id)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete webhook '%s'?", id)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2091,6 +2526,12 @@ func registerWebhookDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2119,8 +2560,9 @@ func registerAuditList() *cobra.Command {
 		Short: "List audit log entries",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2140,6 +2582,12 @@ func registerAuditList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2154,7 +2602,7 @@ func registerAuditList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -2203,8 +2651,9 @@ port api call /actions/my-action/runs --data '{"properties": {}}'
 port api call /actions/runs --org my-org`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -2229,6 +2678,12 @@ port api call /actions/runs --org my-org`,
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2290,8 +2745,9 @@ func registerUserList() *cobra.Command {
 		Short: "List all users",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2311,6 +2767,12 @@ func registerUserList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2325,7 +2787,7 @@ func registerUserList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 
 	return cmd
 }
@@ -2341,8 +2803,9 @@ func registerUserGet() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			email := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2362,6 +2825,12 @@ func registerUserGet() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2390,8 +2859,9 @@ func registerScorecardList() *cobra.Command {
 		Short: "List scorecards",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2411,6 +2881,12 @@ func registerScorecardList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2433,7 +2909,7 @@ func registerScorecardList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 	cmd.Flags().StringVarP(&blueprint, "blueprint", "b", "", "Filter by blueprint ID")
 
 	return cmd
@@ -2448,8 +2924,9 @@ func registerScorecardCreate() *cobra.Command {
 		Short: "Create a new scorecard",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2473,6 +2950,12 @@ func registerScorecardCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2508,8 +2991,9 @@ func registerScorecardUpdate() *cobra.Command {
 			blueprintID := args[0]
 			scorecardID := args[1]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2533,6 +3017,12 @@ func registerScorecardUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2568,18 +3058,16 @@ func registerScorecardDelete() *cobra.Command {
 			scorecardID := args[1]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete scorecard '%s' from blueprint '%s'? [y/N]: ", scorecardID, blueprintID)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete scorecard '%s' from blueprint '%s'?", scorecardID, blueprintID)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2599,6 +3087,12 @@ func registerScorecardDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2618,6 +3112,66 @@ func registerScorecardDelete() *cobra.Command {
 	return cmd
 }
 
+// registerScorecardLevels registers the scorecard levels command.
+func registerScorecardLevels() *cobra.Command {
+	var org, format string
+
+	cmd := &cobra.Command{
+		Use:   "levels [blueprint-id] [scorecard-id]",
+		Short: "Show the level each entity reached in a scorecard",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blueprintID := args[0]
+			scorecardID := args[1]
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			results, err := client.GetScorecardResults(cmd.Context(), blueprintID, scorecardID)
+			if err != nil {
+				return fmt.Errorf("failed to get scorecard results: %w", err)
+			}
+
+			return formatOutput(results, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
+
+	return cmd
+}
+
 // registerActionList registers the action list command.
 func registerActionList() *cobra.Command {
 	var org, format, blueprint string
@@ -2627,8 +3181,9 @@ func registerActionList() *cobra.Command {
 		Short: "List actions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2648,6 +3203,12 @@ func registerActionList() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2670,7 +3231,7 @@ func registerActionList() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: json, yaml, ids (one identifier per line)")
 	cmd.Flags().StringVarP(&blueprint, "blueprint", "b", "", "Filter by blueprint ID")
 
 	return cmd
@@ -2685,8 +3246,9 @@ func registerActionCreate() *cobra.Command {
 		Short: "Create a new action",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2710,6 +3272,12 @@ func registerActionCreate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2745,8 +3313,9 @@ func registerActionUpdate() *cobra.Command {
 			blueprintID := args[0]
 			actionID := args[1]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2770,6 +3339,12 @@ func registerActionUpdate() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2805,18 +3380,16 @@ func registerActionDelete() *cobra.Command {
 			actionID := args[1]
 
 			if !force {
-				cmd.Printf("Are you sure you want to delete action '%s' from blueprint '%s'? [y/N]: ", actionID, blueprintID)
-				var response string
-				fmt.Scanln(&response)
-				if response != "y" && response != "Y" {
+				if !askYesNo(cmd, fmt.Sprintf("Are you sure you want to delete action '%s' from blueprint '%s'?", actionID, blueprintID)) {
 					cmd.Println("Operation cancelled")
 					return nil
 				}
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2836,6 +3409,12 @@ func registerActionDelete() *cobra.Command {
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2877,8 +3456,9 @@ func registerPermissionsResourceCmd(
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			getOrg = resolveOrg(getOrg)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, getOrg)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2898,6 +3478,12 @@ func registerPermissionsResourceCmd(
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
@@ -2922,8 +3508,9 @@ func registerPermissionsResourceCmd(
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			updateOrg = resolveOrg(updateOrg)
 			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, updateOrg)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
@@ -2947,6 +3534,12 @@ func registerPermissionsResourceCmd(
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 				Timeout:      0,
 			})
 			defer client.Close()
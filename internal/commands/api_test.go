@@ -1,8 +1,13 @@
 package commands
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/spf13/cobra"
 )
 
@@ -187,7 +192,7 @@ func TestScorecardSubcommandsFlagsParsed(t *testing.T) {
 		t.Fatal("scorecards command not found")
 	}
 
-	for _, sub := range []string{"list", "create", "update", "delete"} {
+	for _, sub := range []string{"list", "create", "update", "delete", "levels"} {
 		subCmd, _, _ := scorecardsCmd.Find([]string{sub})
 		if subCmd == nil {
 			t.Fatalf("scorecards %s command not found", sub)
@@ -445,3 +450,137 @@ func TestAuditSubcommandsFlagsParsed(t *testing.T) {
 		t.Errorf("expected 'yaml', got %q", format)
 	}
 }
+
+func TestWriteIdentifiers(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{
+			name: "blueprints",
+			data: []api.Blueprint{{"identifier": "service", "title": "Service"}, {"identifier": "team"}},
+			want: "service\nteam\n",
+		},
+		{
+			name: "teams by name",
+			data: []api.Team{{"name": "platform"}, {"name": "payments"}},
+			want: "platform\npayments\n",
+		},
+		{
+			name: "users by email",
+			data: []api.User{{"email": "a@example.com"}},
+			want: "a@example.com\n",
+		},
+		{
+			name: "action runs by id",
+			data: []api.ActionRun{{"id": "r_1"}},
+			want: "r_1\n",
+		},
+		{
+			name: "single resource",
+			data: api.Entity{"identifier": "svc-1"},
+			want: "svc-1\n",
+		},
+		{
+			name: "empty list",
+			data: []api.Entity{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeIdentifiers(&buf, tt.data); err != nil {
+				t.Fatalf("writeIdentifiers: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteIdentifiersMissingField(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeIdentifiers(&buf, []api.Blueprint{{"identifier": "service"}, {"title": "No ID"}})
+	if err == nil || !strings.Contains(err.Error(), `"identifier"`) {
+		t.Fatalf("expected missing identifier error, got %v", err)
+	}
+}
+
+func TestFormatOutputRejectsUnknownFormat(t *testing.T) {
+	if err := formatOutput([]api.Blueprint{}, "csv"); err == nil {
+		t.Fatal("expected an error for --format csv")
+	}
+}
+
+func TestMergeEntity(t *testing.T) {
+	current := api.Entity{
+		"identifier": "svc",
+		"title":      "Service",
+		"blueprint":  "service",
+		"updatedAt":  "2026-01-01T00:00:00Z",
+		"properties": map[string]interface{}{"tier": "gold", "lang": "go"},
+		"relations":  map[string]interface{}{"owner": "team-a"},
+	}
+	patch := api.Entity{
+		"title":      "Payments",
+		"properties": map[string]interface{}{"tier": "silver"},
+	}
+
+	merged := mergeEntity(current, patch)
+
+	props := merged["properties"].(map[string]interface{})
+	if props["tier"] != "silver" || props["lang"] != "go" {
+		t.Errorf("expected properties to be merged, got %v", props)
+	}
+	if merged["title"] != "Payments" || merged["relations"].(map[string]interface{})["owner"] != "team-a" {
+		t.Errorf("expected title replaced and relations kept, got %v", merged)
+	}
+	if _, ok := merged["updatedAt"]; ok {
+		t.Errorf("expected read-only fields to be dropped, got %v", merged)
+	}
+	if current["properties"].(map[string]interface{})["tier"] != "gold" {
+		t.Errorf("expected current to be left unmodified, got %v", current)
+	}
+}
+
+func TestEntityRelations(t *testing.T) {
+	entity := api.Entity{
+		"identifier": "svc",
+		"properties": map[string]interface{}{"tier": "gold"},
+		"relations":  map[string]interface{}{"owner": "team-a", "deps": []interface{}{"db"}},
+	}
+	relations := entityRelations(entity)
+	if len(relations) != 2 || relations["owner"] != "team-a" {
+		t.Errorf("expected only the relations block, got %v", relations)
+	}
+
+	if relations := entityRelations(api.Entity{"identifier": "svc"}); relations == nil || len(relations) != 0 {
+		t.Errorf("expected an empty relations block, got %#v", relations)
+	}
+
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterAPI(rootCmd)
+	if cmd, _, err := rootCmd.Find([]string{"api", "entities", "relations"}); err != nil || cmd.Name() != "relations" {
+		t.Fatalf("entities relations command not found: %v", err)
+	}
+}
+
+func TestBlueprintCreateFlagValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"--data", "bp.json", "--from-entity", "entities.json", "--identifier", "svc"},
+		{"--from-entity", "entities.json"},
+		{"--data", "bp.json", "--dry-run"},
+	} {
+		cmd := registerBlueprintCreate()
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		if err := cmd.Execute(); exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("args %v: expected a usage error, got %v", args, err)
+		}
+	}
+}
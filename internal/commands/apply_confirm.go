@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/spf13/cobra"
+)
+
+// confirmUsage is the help text of the --confirm flag of import and migrate.
+const confirmUsage = "Ask for confirmation before applying the diff even when stdin is not a terminal (on a terminal it is asked unless --yes is given)"
+
+// applyConfirmer returns the gate import and migrate pass between the diff and
+// the first change to org: the planned changes are summarized and applied
+// only if the user answers y. It is used on a terminal, or anywhere with
+// --confirm, and never with --yes; nil means no gate.
+func applyConfirmer(cmd *cobra.Command, confirm bool, org string) import_module.ApplyConfirmer {
+	if ShouldSkipConfirm(cmd, false) || (!confirm && !IsInteractive()) {
+		return nil
+	}
+	return func(diff *import_module.DiffResult, entitiesStreamed bool) (bool, error) {
+		creates, updates := writeChangePreview(cmd.ErrOrStderr(), diff, entitiesStreamed, org)
+		question := fmt.Sprintf("Apply these %d create(s) and %d update(s)", creates, updates)
+		if len(diff.Deletions) > 0 {
+			question = fmt.Sprintf("Apply these %d create(s), %d update(s) and %d deletion(s)", creates, updates, len(diff.Deletions))
+		}
+		return askYesNo(cmd, question+"?"), nil
+	}
+}
+
+// writeChangePreview writes the changes diff plans for org, per resource
+// type, and returns the total creates and updates.
+func writeChangePreview(w io.Writer, diff *import_module.DiffResult, entitiesStreamed bool, org string) (creates, updates int) {
+	fmt.Fprintf(w, "\nPlanned changes to %s:\n", org)
+	for _, c := range diff.ChangeCounts() {
+		var parts []string
+		if c.Create > 0 {
+			parts = append(parts, fmt.Sprintf("%d to create", c.Create))
+		}
+		if c.Update > 0 {
+			parts = append(parts, fmt.Sprintf("%d to update", c.Update))
+		}
+		fmt.Fprintf(w, "  %s: %s\n", c.Resource, strings.Join(parts, ", "))
+		creates += c.Create
+		updates += c.Update
+	}
+	if entitiesStreamed {
+		fmt.Fprintf(w, "  entities: compared and applied while they are streamed, not counted here\n")
+	}
+	if len(diff.Deletions) > 0 {
+		fmt.Fprintf(w, "  %d resource(s) to delete, which cannot be undone\n", len(diff.Deletions))
+	}
+	return creates, updates
+}
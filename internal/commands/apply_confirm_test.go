@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/spf13/cobra"
+)
+
+func TestWriteChangePreview(t *testing.T) {
+	diff := &import_module.DiffResult{
+		BlueprintsToCreate: []api.Blueprint{{"identifier": "service"}},
+		ActionsToCreate:    []api.Action{{"identifier": "deploy"}},
+		ActionsToUpdate:    []api.Action{{"identifier": "build"}, {"identifier": "test"}},
+		Deletions:          []export.Deletion{{Type: "pages", Identifier: "old"}},
+	}
+	var buf bytes.Buffer
+	creates, updates := writeChangePreview(&buf, diff, true, "staging")
+	if creates != 2 || updates != 2 {
+		t.Errorf("totals = %d creates, %d updates; want 2 and 2", creates, updates)
+	}
+	for _, want := range []string{
+		"Planned changes to staging:",
+		"  blueprints: 1 to create\n",
+		"  actions: 1 to create, 2 to update\n",
+		"  entities: compared and applied while they are streamed",
+		"  1 resource(s) to delete, which cannot be undone",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("preview missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestApplyConfirmer(t *testing.T) {
+	newCmd := func(input string) (*cobra.Command, *bytes.Buffer) {
+		cmd := &cobra.Command{Use: "import"}
+		var out bytes.Buffer
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetContext(WithGlobalFlags(context.Background(), GlobalFlags{}))
+		return cmd, &out
+	}
+	diff := &import_module.DiffResult{PagesToUpdate: []api.Page{{"identifier": "home"}}}
+
+	cmd, _ := newCmd("y\n")
+	if applyConfirmer(cmd, false, "staging") != nil {
+		t.Error("expected no gate when stdin is not a terminal and --confirm is not given")
+	}
+
+	cmd, out := newCmd("y\n")
+	confirmed, err := applyConfirmer(cmd, true, "staging")(diff, false)
+	if err != nil || !confirmed {
+		t.Errorf("answer y: confirmed %v, err %v", confirmed, err)
+	}
+	if !strings.Contains(out.String(), "Apply these 0 create(s) and 1 update(s)? [y/N]: ") {
+		t.Errorf("prompt = %q", out.String())
+	}
+
+	cmd, _ = newCmd("\n")
+	if confirmed, _ := applyConfirmer(cmd, true, "staging")(diff, false); confirmed {
+		t.Error("an empty answer should decline")
+	}
+
+	cmd, _ = newCmd("y\n")
+	cmd.SetContext(WithGlobalFlags(context.Background(), GlobalFlags{Yes: true}))
+	if applyConfirmer(cmd, true, "staging") != nil {
+		t.Error("expected --yes to skip the gate")
+	}
+}
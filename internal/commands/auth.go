@@ -51,8 +51,9 @@ func registerLogin() *cobra.Command {
 func runLogin(cmd *cobra.Command, org string, withToken bool) error {
 	ctx := cmd.Context()
 	flags := GetGlobalFlags(cmd.Context())
-	configManager := config.NewConfigManager(flags.ConfigFile)
+	configManager := newConfigManager(flags)
 	createdDefaultCfg := false
+	org = resolveOrg(org)
 
 	if exists, err := configManager.Exists(); err != nil {
 		return fmt.Errorf("failed to check if config exists (%w)", err)
@@ -85,8 +86,10 @@ func runLogin(cmd *cobra.Command, org string, withToken bool) error {
 		lipgloss.Printf("%s No org provided or configured as default\n", styles.QuestionMark)
 	}
 
-	apiUrl := "https://api.getport.io/v1"
-	if flags.APIURL == "" && (orgConfig == nil || orgConfig.APIURL == "") {
+	// Ask for the region only when nothing configures the API URL; orgConfig
+	// already falls back to the configured default.
+	apiUrl := cfg.DefaultAPIURL()
+	if flags.APIURL == "" && cfg.Organizations[useOrg].APIURL == "" && cfg.Backend.DefaultAPIURL == "" {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
@@ -106,7 +109,7 @@ func runLogin(cmd *cobra.Command, org string, withToken bool) error {
 		}
 	} else if orgConfig != nil {
 		apiUrl = orgConfig.APIURL
-	} else {
+	} else if flags.APIURL != "" {
 		apiUrl = flags.APIURL
 	}
 
@@ -179,7 +182,8 @@ func registerToken() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
 
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -227,7 +231,8 @@ func registerStatus() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
 
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -285,7 +290,8 @@ func registerLogout() *cobra.Command {
 		Short: "Logout from Port",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
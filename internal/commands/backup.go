@@ -0,0 +1,341 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/backup"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// RegisterBackup registers the backup command group.
+func RegisterBackup(rootCmd *cobra.Command) {
+	var (
+		org          string
+		backupDir    string
+		keep         int
+		format       string
+		skipEntities bool
+		outputFormat string
+	)
+
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up a Port organization to a timestamped bundle",
+		Long: `Back up a Port organization to a timestamped bundle.
+
+Runs a full export into <backup-dir>/<org>-<timestamp>.tar.gz
+(default backup dir: ~/.port/backups/<org>/). Use --keep to prune the
+oldest bundles so that at most N remain.
+
+Use 'port backup list' to see existing bundles and
+'port backup restore <file>' to import one back.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBundleFormatFlags(format, []string{"tar", "json"}, outputFormat, outputFormats); err != nil {
+				return err
+			}
+			if keep < 0 {
+				return fmt.Errorf("--keep must be 0 (keep all) or greater")
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, orgConfig, _, err := configManager.LoadWithDualOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+				"", "", "", "", // No target org for backup
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if orgConfig == nil {
+				return fmt.Errorf("organization configuration not found")
+			}
+
+			dir, err := resolveBackupDir(backupDir, cfg.GetOrgOrDefault(org))
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return fmt.Errorf("failed to create backup directory: %w", err)
+			}
+			outputPath := filepath.Join(dir, backup.FileName(cfg.GetOrgOrDefault(org), time.Now(), format))
+
+			token, err := configManager.GetOrRefreshToken(cmd.Context(), org)
+			if err != nil {
+				if !config.ShouldIgnoreGetOrRefreshTokenError(err) {
+					return err
+				}
+			}
+			exportModule := export.NewModule(token, orgConfig)
+			defer exportModule.Close()
+
+			if outputFormat != "json" {
+				output.Printf("\nBacking up organization: %s\n", cfg.GetOrgOrDefault(org))
+				output.Printf("Backup file: %s\n", outputPath)
+			}
+
+			result, err := exportModule.Execute(cmd.Context(), export.Options{
+				OutputPath:   outputPath,
+				Format:       format,
+				SkipEntities: skipEntities,
+			})
+			if err == nil && !result.Success {
+				err = fmt.Errorf("%v", result.Error)
+			}
+			if err != nil {
+				if outputFormat == "json" {
+					output.PrintJSON(output.JSONResult{Success: false, Error: err.Error()})
+					return err
+				}
+				return fmt.Errorf("backup failed: %w", err)
+			}
+
+			removed, err := backup.Prune(dir, cfg.GetOrgOrDefault(org), keep)
+			if err != nil {
+				return fmt.Errorf("backup written to %s but rotation failed: %w", outputPath, err)
+			}
+
+			if outputFormat == "json" {
+				removedPaths := make([]string, 0, len(removed))
+				for _, b := range removed {
+					removedPaths = append(removedPaths, b.Path)
+				}
+				jsonData := exportJSONSummary(result, exportJSONSummaryOptions{SkipEntities: skipEntities})
+				jsonData["backup_dir"] = dir
+				jsonData["pruned"] = removedPaths
+				return output.PrintJSON(output.JSONResult{
+					Success: true,
+					Message: result.Message,
+					Data:    jsonData,
+				})
+			}
+
+			output.SuccessPrintln("\n✓ Backup completed successfully!")
+			output.Printf("%s\n", result.Message)
+			output.Printf("Blueprints: %d\n", result.BlueprintsCount)
+			output.Printf("Entities: %d\n", result.EntitiesCount)
+			for _, b := range removed {
+				output.Printf("Pruned old backup: %s\n", b.Name)
+			}
+			return nil
+		},
+	}
+
+	backupCmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	backupCmd.PersistentFlags().StringVar(&backupDir, "backup-dir", "", "Directory holding backup bundles (default ~/.port/backups/<org>/)")
+	backupCmd.Flags().IntVar(&keep, "keep", 0, "Keep only the N newest bundles in the backup directory (0 keeps all)")
+	backupCmd.Flags().StringVarP(&format, "format", "f", "tar", "Bundle format: tar (tar.gz) or json; --export-format is an alias")
+	backupCmd.Flags().BoolVar(&skipEntities, "skip-entities", false, "Skip exporting entities (only back up schema and configuration)")
+	backupCmd.Flags().StringVar(&outputFormat, "output-format", "text", "What the command prints: text or json (for the bundle format, see --format)")
+	backupCmd.Flags().SetNormalizeFunc(exportFormatAlias)
+
+	backupCmd.AddCommand(registerBackupList(&backupDir))
+	backupCmd.AddCommand(registerBackupRestore(&backupDir))
+
+	rootCmd.AddCommand(backupCmd)
+}
+
+func registerBackupList(backupDir *string) *cobra.Command {
+	var (
+		org          string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List backup bundles, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+
+			dir, resolvedOrg, err := backupDirForOrg(cmd, *backupDir, org)
+			if err != nil {
+				return err
+			}
+			bundles, err := backup.List(dir, resolvedOrg)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				items := make([]map[string]interface{}, 0, len(bundles))
+				for _, b := range bundles {
+					items = append(items, map[string]interface{}{
+						"name":       b.Name,
+						"path":       b.Path,
+						"created_at": b.CreatedAt.Format(time.RFC3339),
+						"size":       b.Size,
+					})
+				}
+				return output.PrintJSON(map[string]interface{}{
+					"backup_dir": dir,
+					"backups":    items,
+				})
+			}
+
+			if len(bundles) == 0 {
+				output.Printf("No backups found in %s\n", dir)
+				return nil
+			}
+			output.Printf("Backups in %s:\n", dir)
+			for _, b := range bundles {
+				output.Printf("  %-48s %s  %d bytes\n", b.Name, b.CreatedAt.Format(time.RFC3339), b.Size)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+	return cmd
+}
+
+func registerBackupRestore(backupDir *string) *cobra.Command {
+	var (
+		targetOrg    string
+		dryRun       bool
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore a backup bundle by importing it",
+		Long: `Restore a backup bundle by importing it into an organization.
+
+<file> is a path to a bundle, or a bundle name from 'port backup list'
+which is looked up in the backup directory of the target organization.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			targetOrg = resolveOrg(targetOrg)
+			_, _, targetOrgConfig, err := configManager.LoadWithDualOverrides(
+				"", "", "", "", // No base org for restore
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				targetOrg,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if targetOrgConfig == nil {
+				return fmt.Errorf("target organization configuration not found")
+			}
+
+			inputPath := args[0]
+			if _, statErr := os.Stat(inputPath); os.IsNotExist(statErr) && !strings.ContainsAny(inputPath, `/\`) {
+				dir, _, err := backupDirForOrg(cmd, *backupDir, targetOrg)
+				if err != nil {
+					return err
+				}
+				inputPath = filepath.Join(dir, inputPath)
+			}
+
+			token, err := configManager.GetOrRefreshToken(cmd.Context(), targetOrg)
+			if err != nil {
+				if !config.ShouldIgnoreGetOrRefreshTokenError(err) {
+					return err
+				}
+			}
+			importModule := import_module.NewModule(token, targetOrgConfig)
+			defer importModule.Close()
+
+			if outputFormat != "json" {
+				output.Printf("\nRestoring backup: %s\n", inputPath)
+				if dryRun {
+					output.Printf("Dry run mode - no changes will be applied\n")
+				}
+			}
+
+			result, err := importModule.Execute(cmd.Context(), import_module.Options{
+				InputPath:          inputPath,
+				DryRun:             dryRun,
+				IncludeRuleResults: true,
+			})
+			if err != nil {
+				code := exitcode.Failure
+				if result != nil {
+					code = exitcode.Stopped(result.Applied())
+				}
+				if outputFormat == "json" {
+					output.PrintJSON(output.JSONResult{Success: false, Error: err.Error()})
+					return exitcode.New(code, err)
+				}
+				return exitcode.New(code, fmt.Errorf("restore failed: %w", err))
+			}
+
+			if outputFormat == "json" {
+				output.PrintJSON(map[string]interface{}{
+					"success":            result.Success,
+					"message":            result.Message,
+					"input_path":         inputPath,
+					"blueprints_created": result.BlueprintsCreated,
+					"blueprints_updated": result.BlueprintsUpdated,
+					"entities_created":   result.EntitiesCreated,
+					"entities_updated":   result.EntitiesUpdated,
+					"errors":             result.Errors,
+				})
+			} else if result.Success {
+				output.SuccessPrintln("\n✓ Restore completed successfully!")
+				output.Printf("%s\n", result.Message)
+			} else {
+				output.WarningPrintln("\n⚠ Restore completed with errors")
+				output.Printf("%s\n", result.Message)
+			}
+			if !result.Success {
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("restore completed with errors"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&targetOrg, "target-org", "", "Organization to restore into (uses default if not specified)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the restore without applying changes")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+	return cmd
+}
+
+// backupDirForOrg resolves the organization and its backup directory, falling
+// back to PORT_ORG and then the configured default organization when org is
+// empty. The organization is needed even with --backup-dir, since a shared
+// directory can hold bundles of several organizations.
+func backupDirForOrg(cmd *cobra.Command, backupDir, org string) (string, string, error) {
+	flags := GetGlobalFlags(cmd.Context())
+	cfg, err := newConfigManager(flags).Load()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+	resolvedOrg := cfg.GetOrgOrDefault(resolveOrg(org))
+	dir, err := resolveBackupDir(backupDir, resolvedOrg)
+	if err != nil {
+		return "", "", err
+	}
+	return dir, resolvedOrg, nil
+}
+
+func resolveBackupDir(backupDir, org string) (string, error) {
+	if backupDir != "" {
+		return backupDir, nil
+	}
+	return backup.DefaultDir(org)
+}
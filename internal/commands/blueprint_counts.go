@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"golang.org/x/sync/errgroup"
+)
+
+// blueprintCountsConcurrency caps the blueprints whose counts are fetched at
+// once by blueprints list --with-counts.
+const blueprintCountsConcurrency = 8
+
+// blueprintCount is a blueprint list entry annotated by --with-counts.
+type blueprintCount struct {
+	Identifier     string `json:"identifier" yaml:"identifier"`
+	Title          string `json:"title" yaml:"title"`
+	EntityCount    int    `json:"entityCount" yaml:"entityCount"`
+	ScorecardCount int    `json:"scorecardCount" yaml:"scorecardCount"`
+	ActionCount    int    `json:"actionCount" yaml:"actionCount"`
+}
+
+// blueprintCounts returns the number of entities, scorecards and actions of
+// each blueprint, in the order of blueprints. Actions are listed once for the
+// whole organization and counted by the blueprint they belong to.
+func blueprintCounts(ctx context.Context, client *api.Client, blueprints []api.Blueprint) ([]blueprintCount, error) {
+	actions, err := client.GetAllActions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actions: %w", err)
+	}
+	actionCounts := make(map[string]int)
+	for _, action := range actions {
+		if bpID := export.ActionBlueprintID(action); bpID != "" {
+			actionCounts[bpID]++
+		}
+	}
+
+	counts := make([]blueprintCount, len(blueprints))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(blueprintCountsConcurrency)
+	for idx, bp := range blueprints {
+		bpID, _ := bp["identifier"].(string)
+		title, _ := bp["title"].(string)
+		counts[idx] = blueprintCount{Identifier: bpID, Title: title, ActionCount: actionCounts[bpID]}
+		g.Go(func() error {
+			entityCount, err := client.GetEntitiesCount(gctx, bpID)
+			if err != nil {
+				return fmt.Errorf("failed to count entities of blueprint %s: %w", bpID, err)
+			}
+			scorecards, err := client.GetScorecards(gctx, bpID)
+			if err != nil {
+				return fmt.Errorf("failed to list scorecards of blueprint %s: %w", bpID, err)
+			}
+			counts[idx].EntityCount = entityCount
+			counts[idx].ScorecardCount = len(scorecards)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
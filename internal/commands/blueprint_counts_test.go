@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestBlueprintCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/actions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "actions": []map[string]interface{}{
+				{"identifier": "deploy", "trigger": map[string]interface{}{"blueprintIdentifier": "service"}},
+				{"identifier": "notify", "trigger": map[string]interface{}{"event": map[string]interface{}{"blueprintIdentifier": "service"}}},
+				{"identifier": "nightly", "trigger": map[string]interface{}{"type": "cron"}},
+			}})
+		case "/blueprints/service/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 12})
+		case "/blueprints/team/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 3})
+		case "/blueprints/service/scorecards":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecards": []map[string]interface{}{{"identifier": "readiness"}}})
+		case "/blueprints/team/scorecards":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecards": []map[string]interface{}{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	defer client.Close()
+	counts, err := blueprintCounts(context.Background(), client, []api.Blueprint{
+		{"identifier": "service", "title": "Service"},
+		{"identifier": "team", "title": "Team"},
+	})
+	if err != nil {
+		t.Fatalf("blueprintCounts: %v", err)
+	}
+	want := []blueprintCount{
+		{Identifier: "service", Title: "Service", EntityCount: 12, ScorecardCount: 1, ActionCount: 2},
+		{Identifier: "team", Title: "Team", EntityCount: 3},
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("expected %d entries, got %v", len(want), counts)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("entry %d: got %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+}
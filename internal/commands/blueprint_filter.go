@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// filterBlueprintsByPresence returns the blueprints that declare every
+// property in properties, as a key of schema.properties, and every relation
+// in relations, as a key of relations. With no filters, blueprints is
+// returned as is.
+func filterBlueprintsByPresence(blueprints []api.Blueprint, properties, relations []string) []api.Blueprint {
+	if len(properties) == 0 && len(relations) == 0 {
+		return blueprints
+	}
+	filtered := make([]api.Blueprint, 0, len(blueprints))
+	for _, bp := range blueprints {
+		schema, _ := bp["schema"].(map[string]interface{})
+		declaredProps, _ := schema["properties"].(map[string]interface{})
+		declaredRels, _ := bp["relations"].(map[string]interface{})
+		if hasAllKeys(declaredProps, properties) && hasAllKeys(declaredRels, relations) {
+			filtered = append(filtered, bp)
+		}
+	}
+	return filtered
+}
+
+// hasAllKeys reports whether m has every key in keys.
+func hasAllKeys(m map[string]interface{}, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestFilterBlueprintsByPresence(t *testing.T) {
+	blueprints := []api.Blueprint{
+		{
+			"identifier": "service",
+			"schema":     map[string]interface{}{"properties": map[string]interface{}{"cost": map[string]interface{}{"type": "number"}, "language": map[string]interface{}{"type": "string"}}},
+			"relations":  map[string]interface{}{"team": map[string]interface{}{"target": "team"}},
+		},
+		{
+			"identifier": "deployment",
+			"schema":     map[string]interface{}{"properties": map[string]interface{}{"cost": map[string]interface{}{"type": "number"}}},
+			"relations":  map[string]interface{}{"service": map[string]interface{}{"target": "service"}},
+		},
+		{"identifier": "team"},
+	}
+
+	tests := []struct {
+		name       string
+		properties []string
+		relations  []string
+		want       []string
+	}{
+		{"no filters", nil, nil, []string{"service", "deployment", "team"}},
+		{"property", []string{"cost"}, nil, []string{"service", "deployment"}},
+		{"relation", nil, []string{"service"}, []string{"deployment"}},
+		{"filters AND together", []string{"cost", "language"}, []string{"team"}, []string{"service"}},
+		{"no match", []string{"cost"}, []string{"team", "service"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, bp := range filterBlueprintsByPresence(blueprints, tt.properties, tt.relations) {
+				got = append(got, bp["identifier"].(string))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterBlueprintsByPresence = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
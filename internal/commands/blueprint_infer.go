@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// loadSampleEntities reads the sample entities for blueprints create
+// --from-entity: a JSON array of entities, or an object holding one under
+// "entities", as in an export.
+func loadSampleEntities(path string) ([]map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entities []map[string]interface{}
+	if err := json.Unmarshal(content, &entities); err == nil {
+		return entities, nil
+	}
+	var wrapped struct {
+		Entities []map[string]interface{} `json:"entities"`
+	}
+	if err := json.Unmarshal(content, &wrapped); err != nil || wrapped.Entities == nil {
+		return nil, fmt.Errorf("%s must hold a JSON array of entities or an object with an \"entities\" array", path)
+	}
+	return wrapped.Entities, nil
+}
+
+// inferBlueprint infers a blueprint schema from sample entities. Each
+// sample's "properties" object is used when it has one; otherwise its fields
+// other than identifier and title are. A property's type is the JSON type of
+// its values (string, number, boolean, array or object), and properties set
+// in every sample are required. Properties whose values have different types
+// are left out of the schema and returned in skipped, sorted.
+func inferBlueprint(identifier, title string, samples []map[string]interface{}) (bp api.Blueprint, skipped []string) {
+	types := make(map[string]map[string]bool)
+	setIn := make(map[string]int)
+	for _, sample := range samples {
+		for name, value := range sampleProperties(sample) {
+			if value == nil {
+				continue
+			}
+			if types[name] == nil {
+				types[name] = make(map[string]bool)
+			}
+			types[name][jsonTypeName(value)] = true
+			setIn[name]++
+		}
+	}
+
+	properties := make(map[string]interface{}, len(types))
+	required := []interface{}{}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(types[name]) != 1 {
+			skipped = append(skipped, name)
+			continue
+		}
+		for typ := range types[name] {
+			properties[name] = map[string]interface{}{"type": typ, "title": name}
+		}
+		if setIn[name] == len(samples) {
+			required = append(required, name)
+		}
+	}
+
+	if title == "" {
+		title = identifier
+	}
+	return api.Blueprint{
+		"identifier": identifier,
+		"title":      title,
+		"schema": map[string]interface{}{
+			"properties": properties,
+			"required":   required,
+		},
+		"relations": map[string]interface{}{},
+	}, skipped
+}
+
+func sampleProperties(sample map[string]interface{}) map[string]interface{} {
+	if props, ok := sample["properties"].(map[string]interface{}); ok {
+		return props
+	}
+	props := make(map[string]interface{}, len(sample))
+	for name, value := range sample {
+		if name != "identifier" && name != "title" {
+			props[name] = value
+		}
+	}
+	return props
+}
+
+// jsonTypeName returns the JSON schema type of a decoded JSON value.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInferBlueprint(t *testing.T) {
+	samples := []map[string]interface{}{
+		{"identifier": "a", "properties": map[string]interface{}{"lang": "go", "replicas": float64(2), "public": true, "tags": []interface{}{"x"}, "mixed": "1"}},
+		{"identifier": "b", "properties": map[string]interface{}{"lang": "python", "replicas": float64(1), "meta": map[string]interface{}{}, "mixed": float64(1), "public": nil}},
+	}
+
+	bp, skipped := inferBlueprint("svc", "", samples)
+
+	if bp["identifier"] != "svc" || bp["title"] != "svc" {
+		t.Errorf("unexpected identifier/title: %v", bp)
+	}
+	schema := bp["schema"].(map[string]interface{})
+	props := schema["properties"].(map[string]interface{})
+	wantTypes := map[string]string{"lang": "string", "replicas": "number", "public": "boolean", "tags": "array", "meta": "object"}
+	if len(props) != len(wantTypes) {
+		t.Fatalf("properties = %v, want %v", props, wantTypes)
+	}
+	for name, typ := range wantTypes {
+		if got := props[name].(map[string]interface{})["type"]; got != typ {
+			t.Errorf("%s type = %v, want %s", name, got, typ)
+		}
+	}
+	if want := []interface{}{"lang", "replicas"}; !reflect.DeepEqual(schema["required"], want) {
+		t.Errorf("required = %v, want %v", schema["required"], want)
+	}
+	if !reflect.DeepEqual(skipped, []string{"mixed"}) {
+		t.Errorf("skipped = %v, want [mixed]", skipped)
+	}
+}
+
+func TestInferBlueprintFromRawObjects(t *testing.T) {
+	samples := []map[string]interface{}{{"identifier": "a", "title": "A", "owner": "team"}}
+	bp, _ := inferBlueprint("svc", "Service", samples)
+	props := bp["schema"].(map[string]interface{})["properties"].(map[string]interface{})
+	if len(props) != 1 || props["owner"] == nil || bp["title"] != "Service" {
+		t.Errorf("expected only owner to be inferred, got %v", bp)
+	}
+}
+
+func TestLoadSampleEntities(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"array.json":  `[{"identifier": "a"}]`,
+		"export.json": `{"blueprints": [], "entities": [{"identifier": "a"}]}`,
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o644)
+		entities, err := loadSampleEntities(path)
+		if err != nil || len(entities) != 1 {
+			t.Errorf("%s: entities = %v, err = %v", name, entities, err)
+		}
+	}
+	path := filepath.Join(dir, "bad.json")
+	os.WriteFile(path, []byte(`{"identifier": "a"}`), 0o644)
+	if _, err := loadSampleEntities(path); err == nil {
+		t.Error("expected an error for an object without entities")
+	}
+}
@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// blueprintPropertyTypes are the property types Port accepts in a blueprint
+// schema.
+var blueprintPropertyTypes = map[string]bool{
+	"string": true, "number": true, "boolean": true, "object": true, "array": true,
+}
+
+// blueprintStringFormats are the formats Port accepts on string properties
+// and on the items of array properties.
+var blueprintStringFormats = map[string]bool{
+	"date-time": true, "url": true, "email": true, "ipv4": true, "ipv6": true,
+	"yaml": true, "markdown": true, "user": true, "team": true, "timer": true, "proto": true,
+}
+
+// blueprintSchemaIssue is an authoring mistake in a blueprint schema.
+type blueprintSchemaIssue struct {
+	Blueprint string `json:"blueprint"`
+	Path      string `json:"path"`
+	Message   string `json:"message"`
+}
+
+func (i blueprintSchemaIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Blueprint, i.Path, i.Message)
+}
+
+// validateBlueprintSchema checks the schema of bp without contacting Port:
+// each property needs a known type, a format known for that type, and enum
+// and default values of that type; required must list declared properties.
+// Issues are returned in property order.
+func validateBlueprintSchema(bp api.Blueprint) []blueprintSchemaIssue {
+	bpID, _ := bp["identifier"].(string)
+	var issues []blueprintSchemaIssue
+	report := func(path, format string, args ...interface{}) {
+		issues = append(issues, blueprintSchemaIssue{Blueprint: bpID, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	schema, ok := bp["schema"].(map[string]interface{})
+	if !ok {
+		report("schema", "must be an object")
+		return issues
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		report("schema.properties", "must be an object")
+		return issues
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := "schema.properties." + name
+		def, ok := properties[name].(map[string]interface{})
+		if !ok {
+			report(path, "must be an object")
+			continue
+		}
+		validatePropertyDefinition(path, def, report)
+	}
+
+	if required, ok := schema["required"]; ok {
+		list, isList := required.([]interface{})
+		if !isList {
+			report("schema.required", "must be an array of property names")
+			return issues
+		}
+		seen := make(map[string]bool, len(list))
+		for _, item := range list {
+			name, isString := item.(string)
+			switch {
+			case !isString:
+				report("schema.required", "%v is not a property name", item)
+			case properties[name] == nil:
+				report("schema.required", "%q is not a declared property", name)
+			case seen[name]:
+				report("schema.required", "%q is listed more than once", name)
+			}
+			seen[name] = true
+		}
+	}
+	return issues
+}
+
+// validatePropertyDefinition checks one property, or the items of an array
+// property, reporting issues under path.
+func validatePropertyDefinition(path string, def map[string]interface{}, report func(path, format string, args ...interface{})) {
+	propertyType, ok := def["type"].(string)
+	if !ok {
+		report(path+".type", "is missing or not a string")
+		return
+	}
+	if !blueprintPropertyTypes[propertyType] {
+		report(path+".type", "unknown type %q; expected one of string, number, boolean, object, array", propertyType)
+		return
+	}
+
+	if format, ok := def["format"]; ok {
+		name, isString := format.(string)
+		switch {
+		case !isString:
+			report(path+".format", "must be a string")
+		case propertyType != "string":
+			report(path+".format", "format %q is only allowed on string properties", name)
+		case !blueprintStringFormats[name]:
+			report(path+".format", "unknown format %q; expected one of %s", name, describeBlueprintFormats())
+		}
+	}
+
+	var enumValues []interface{}
+	if enum, ok := def["enum"]; ok {
+		list, isList := enum.([]interface{})
+		switch {
+		case !isList:
+			report(path+".enum", "must be an array")
+		case propertyType == "object" || propertyType == "array":
+			report(path+".enum", "is not supported on %s properties", propertyType)
+		case len(list) == 0:
+			report(path+".enum", "must not be empty")
+		default:
+			enumValues = list
+			for _, value := range list {
+				if !matchesPropertyType(value, propertyType) {
+					report(path+".enum", "value %v is not a %s", value, propertyType)
+				}
+			}
+		}
+	}
+	if colors, ok := def["enumColors"].(map[string]interface{}); ok && enumValues != nil {
+		for _, key := range sortedKeys(colors) {
+			if !containsEnumValue(enumValues, key) {
+				report(path+".enumColors", "%q is not an enum value", key)
+			}
+		}
+	}
+
+	if value, ok := def["default"]; ok && value != nil {
+		if !matchesPropertyType(value, propertyType) {
+			report(path+".default", "%v is not a %s", value, propertyType)
+		} else if enumValues != nil && !containsEnumValue(enumValues, value) {
+			report(path+".default", "%v is not an enum value", value)
+		}
+	}
+
+	if items, ok := def["items"]; ok {
+		itemsDef, isObject := items.(map[string]interface{})
+		switch {
+		case propertyType != "array":
+			report(path+".items", "is only allowed on array properties")
+		case !isObject:
+			report(path+".items", "must be an object")
+		default:
+			validatePropertyDefinition(path+".items", itemsDef, report)
+		}
+	}
+}
+
+// describeBlueprintFormats lists blueprintStringFormats, sorted.
+func describeBlueprintFormats() string {
+	formats := make([]string, 0, len(blueprintStringFormats))
+	for f := range blueprintStringFormats {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return strings.Join(formats, ", ")
+}
+
+func matchesPropertyType(value interface{}, propertyType string) bool {
+	switch propertyType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return false
+}
+
+func containsEnumValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value || fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadBlueprintsFile reads a blueprint JSON file: a single blueprint, an
+// array of blueprints, or an export holding them under "blueprints".
+func loadBlueprintsFile(path string) ([]api.Blueprint, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var blueprints []api.Blueprint
+	if err := json.Unmarshal(content, &blueprints); err == nil {
+		return blueprints, nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", path, err)
+	}
+	if _, ok := doc["blueprints"]; ok {
+		var wrapped struct {
+			Blueprints []api.Blueprint `json:"blueprints"`
+		}
+		if err := json.Unmarshal(content, &wrapped); err != nil {
+			return nil, fmt.Errorf("%s: \"blueprints\" must be an array of blueprints", path)
+		}
+		return wrapped.Blueprints, nil
+	}
+	return []api.Blueprint{api.Blueprint(doc)}, nil
+}
+
+func writeBlueprintSchemaIssues(w io.Writer, checked int, issues []blueprintSchemaIssue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "  %s\n", issue); err != nil {
+			return err
+		}
+	}
+	if len(issues) == 0 {
+		_, err := fmt.Fprintf(w, "No schema issues found (%d blueprint(s) checked)\n", checked)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d schema issue(s) (%d blueprint(s) checked)\n", len(issues), checked)
+	return err
+}
+
+// registerBlueprintValidateSchema registers the blueprint validate-schema command.
+func registerBlueprintValidateSchema() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "validate-schema [file]",
+		Short: "Check a blueprint's schema for authoring mistakes",
+		Long: `Check a blueprint's schema for authoring mistakes, without contacting Port.
+
+The file holds a blueprint, an array of blueprints, or an export with a
+"blueprints" array. Every property must have a known type (string, number,
+boolean, object or array) and, on strings and array items, a known format.
+Enum values and defaults must match the property type, enumColors may only
+name enum values, and schema.required may only list declared properties.
+Every issue is reported, rather than the first one Port rejects with a 400 on
+create. The command exits with code 3 when any issue is found.`,
+		Example: `  port api blueprints validate-schema blueprint.json
+  port api blueprints validate-schema export.json --output-format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+			blueprints, err := loadBlueprintsFile(args[0])
+			if err != nil {
+				return exitcode.Usagef("failed to load blueprints: %w", err)
+			}
+
+			issues := []blueprintSchemaIssue{}
+			for _, bp := range blueprints {
+				issues = append(issues, validateBlueprintSchema(bp)...)
+			}
+
+			if outputFormat == "json" {
+				if err := output.PrintJSON(map[string]interface{}{"blueprintsChecked": len(blueprints), "issues": issues}); err != nil {
+					return err
+				}
+			} else if err := writeBlueprintSchemaIssues(os.Stdout, len(blueprints), issues); err != nil {
+				return err
+			}
+			if len(issues) > 0 {
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("found %d schema issue(s)", len(issues)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestValidateBlueprintSchema(t *testing.T) {
+	bp := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"language": map[string]interface{}{"type": "string", "enum": []interface{}{"go", "python"}, "enumColors": map[string]interface{}{"go": "blue", "rust": "red"}},
+				"tier":     map[string]interface{}{"type": "number", "enum": []interface{}{1.0, "2"}, "default": 3.0},
+				"url":      map[string]interface{}{"type": "string", "format": "uri"},
+				"count":    map[string]interface{}{"type": "integer"},
+				"owners":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "format": "user"}},
+				"tags":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "text"}},
+				"healthy":  map[string]interface{}{"type": "boolean", "format": "url"},
+				"notes":    map[string]interface{}{"type": "string", "format": "markdown"},
+			},
+			"required": []interface{}{"language", "owner", "language"},
+		},
+	}
+
+	var got []string
+	for _, issue := range validateBlueprintSchema(bp) {
+		got = append(got, issue.Path+": "+issue.Message)
+	}
+	want := []string{
+		`schema.properties.count.type: unknown type "integer"; expected one of string, number, boolean, object, array`,
+		`schema.properties.healthy.format: format "url" is only allowed on string properties`,
+		`schema.properties.language.enumColors: "rust" is not an enum value`,
+		`schema.properties.tags.items.type: unknown type "text"; expected one of string, number, boolean, object, array`,
+		`schema.properties.tier.enum: value 2 is not a number`,
+		`schema.properties.tier.default: 3 is not an enum value`,
+		`schema.properties.url.format: unknown format "uri"; expected one of date-time, email, ipv4, ipv6, markdown, proto, team, timer, url, user, yaml`,
+		`schema.required: "owner" is not a declared property`,
+		`schema.required: "language" is listed more than once`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues =\n%q\nwant\n%q", got, want)
+	}
+
+	if issues := validateBlueprintSchema(api.Blueprint{"identifier": "empty"}); len(issues) != 1 || issues[0].Path != "schema" {
+		t.Errorf("missing schema: %+v", issues)
+	}
+}
+
+func TestLoadBlueprintsFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"single.json": `{"identifier": "service", "schema": {"properties": {}}}`,
+		"array.json":  `[{"identifier": "service"}, {"identifier": "team"}]`,
+		"export.json": `{"blueprints": [{"identifier": "service"}], "entities": []}`,
+	}
+	wantCounts := map[string]int{"single.json": 1, "array.json": 2, "export.json": 1}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		blueprints, err := loadBlueprintsFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(blueprints) != wantCounts[name] || blueprints[0]["identifier"] != "service" {
+			t.Errorf("%s: loaded %v", name, blueprints)
+		}
+	}
+}
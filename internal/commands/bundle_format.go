@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/spf13/pflag"
+)
+
+// outputFormats lists the --output-format values: what a command prints.
+var outputFormats = []string{"text", "json"}
+
+// resultOutputFormats are the --output-format values of export, import and
+// migrate, which can also print their result as YAML.
+var resultOutputFormats = []string{"text", "json", "yaml"}
+
+// validateBundleFormatFlags validates --format, the format of the bundle a
+// command writes, and --output-format, what the command prints. The two are
+// easily mixed up, so a value that belongs to the other flag is rejected with
+// a pointer to it. An empty format leaves the choice to the output path.
+// outputFormats lists the --output-format values the command accepts.
+func validateBundleFormatFlags(format string, formats []string, outputFormat string, outputFormats []string) error {
+	if !slices.Contains(outputFormats, outputFormat) && slices.Contains(formats, outputFormat) {
+		return exitcode.Usagef("invalid value for --output-format: %s. --output-format sets what the command prints (%s); to write a %s bundle, use --format %s", outputFormat, strings.Join(outputFormats, ", "), outputFormat, outputFormat)
+	}
+	if err := validateStringEnum("--output-format", outputFormat, outputFormats); err != nil {
+		return err
+	}
+	if format == "" {
+		return nil
+	}
+	if !slices.Contains(formats, format) && slices.Contains(outputFormats, format) {
+		return exitcode.Usagef("invalid value for --format: %s. --format sets the bundle format (%s); to change what the command prints, use --output-format %s", format, strings.Join(formats, ", "), format)
+	}
+	return validateStringEnum("--format", format, formats)
+}
+
+// exportFormatAlias accepts --export-format as another name for --format, a
+// name that cannot be confused with --output-format.
+func exportFormatAlias(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "export-format" {
+		name = "format"
+	}
+	return pflag.NormalizedName(name)
+}
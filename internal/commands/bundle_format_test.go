@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/spf13/cobra"
+)
+
+func TestValidateBundleFormatFlags(t *testing.T) {
+	formats := []string{"tar", "json", "ndjson"}
+	tests := []struct {
+		name         string
+		format       string
+		outputFormat string
+		wantErr      string
+	}{
+		{"defaults", "", "text", ""},
+		{"json both", "json", "json", ""},
+		{"text bundle", "text", "text", "use --output-format text"},
+		{"tar output", "", "tar", "use --format tar"},
+		{"unknown bundle", "zip", "text", "Valid values: tar, json, ndjson"},
+		{"unknown output", "", "yaml", "Valid values: text, json"},
+	}
+	if err := validateBundleFormatFlags("", formats, "yaml", resultOutputFormats); err != nil {
+		t.Errorf("yaml output with the result formats: unexpected error %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBundleFormatFlags(tt.format, formats, tt.outputFormat, outputFormats)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if exitcode.Code(err) != exitcode.Usage {
+				t.Errorf("expected a usage error, got exit code %d", exitcode.Code(err))
+			}
+		})
+	}
+}
+
+func TestExportFormatAlias(t *testing.T) {
+	root := &cobra.Command{Use: "port"}
+	RegisterExport(root)
+	root.SetArgs([]string{"export", "--export-format", "text", "-o", "out.tar.gz"})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid value for --format: text") {
+		t.Fatalf("expected --export-format to set --format, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"charm.land/lipgloss/v2"
+	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/styles"
 	"github.com/spf13/cobra"
 )
@@ -22,22 +23,34 @@ func RegisterCache(rootCmd *cobra.Command) {
 }
 
 func registerCacheClear() *cobra.Command {
-	var force bool
+	var force, responsesOnly bool
 
 	cmd := &cobra.Command{
 		Use:   "clear",
-		Short: "Remove everything Port CLI installed locally (hooks, skill files, and config)",
+		Short: "Remove everything Port CLI installed or cached locally (hooks, skill files, config, and API responses)",
 		Long: `Remove everything that Port CLI has installed or cached locally:
 
   • Port hook entries from hooks.json / settings.json (other hooks are preserved)
   • Locally synced skills directories (skills/port/)
   • The skills section from ~/.port/config.yaml
+  • API responses cached with --cache-ttl (~/.port/cache/responses)
 
 This is a full cleanup — use 'port skills clear' if you only want to delete
-the skill files while keeping hooks and configuration intact.
+the skill files while keeping hooks and configuration intact, or
+--responses to only remove the cached API responses.
 
 Use --force to skip the confirmation prompt.`,
+		Example: `  port cache clear
+  port cache clear --responses`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if responsesOnly {
+				if err := clearResponseCache(); err != nil {
+					return err
+				}
+				lipgloss.Printf("%s Cached API responses removed.\n", styles.CheckMark)
+				return nil
+			}
+
 			flags := GetGlobalFlags(cmd.Context())
 			mod, _, err := newSkillsModule(flags)
 			if err != nil {
@@ -47,7 +60,7 @@ Use --force to skip the confirmation prompt.`,
 			if !force {
 				ok, err := confirmPrompt(
 					"Remove everything Port CLI installed locally?",
-					"This will remove all Port hooks, skill files, and skills config.\nOther hooks in your AI tool configs will be left untouched.",
+					"This will remove all Port hooks, skill files, skills config, and cached API responses.\nOther hooks in your AI tool configs will be left untouched.",
 				)
 				if err != nil {
 					return err
@@ -58,6 +71,11 @@ Use --force to skip the confirmation prompt.`,
 				}
 			}
 
+			if err := clearResponseCache(); err != nil {
+				return err
+			}
+			lipgloss.Printf("%s Cached API responses removed.\n", styles.CheckMark)
+
 			result, err := mod.Remove()
 			if err != nil {
 				return fmt.Errorf("failed to clear Port cache: %w", err)
@@ -79,5 +97,15 @@ Use --force to skip the confirmation prompt.`,
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&responsesOnly, "responses", false, "Only remove API responses cached with --cache-ttl, keeping hooks, skills and config")
 	return cmd
 }
+
+// clearResponseCache removes every API response cached with --cache-ttl.
+func clearResponseCache() error {
+	dir, err := api.DefaultResponseCacheDir()
+	if err != nil {
+		return err
+	}
+	return api.ClearResponseCache(dir)
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCacheClear_ResponsesOnly(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	responses := filepath.Join(home, ".port", "cache", "responses")
+	configFile := filepath.Join(home, ".port", "config.yaml")
+	if err := os.MkdirAll(responses, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(responses, "entry"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configFile, []byte("skills: {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterCache(rootCmd)
+	rootCmd.SetArgs([]string{"cache", "clear", "--responses"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("cache clear --responses: %v", err)
+	}
+
+	if _, err := os.Stat(responses); !os.IsNotExist(err) {
+		t.Errorf("expected the response cache to be removed, got %v", err)
+	}
+	if _, err := os.Stat(configFile); err != nil {
+		t.Errorf("expected the config to be kept, got %v", err)
+	}
+}
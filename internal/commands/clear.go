@@ -8,7 +8,6 @@ import (
 
 	"github.com/itchyny/gojq"
 	"github.com/port-experimental/port-cli/internal/api"
-	"github.com/port-experimental/port-cli/internal/config"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -78,8 +77,9 @@ If --org is omitted, the default organization from the Port config is used.`,
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
+			org = resolveOrg(org)
 			cfg, err := configManager.LoadWithOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
@@ -134,6 +134,12 @@ If --org is omitted, the default organization from the Port config is used.`,
 				ClientID:     orgConfig.ClientID,
 				ClientSecret: orgConfig.ClientSecret,
 				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
 			})
 			defer client.Close()
 
@@ -6,7 +6,7 @@ import (
 	"slices"
 	"strings"
 
-	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/port-experimental/port-cli/internal/modules/compare"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +27,8 @@ func RegisterCompare(rootCmd *cobra.Command) {
 		full           bool
 		include        string
 		failOnDiff     bool
+		sortBy         string
+		minChanges     int
 	)
 
 	compareCmd := &cobra.Command{
@@ -54,6 +56,9 @@ Examples:
   # Compare export files
   port compare --source ./staging.tar.gz --target ./prod.tar.gz
 
+  # Triage: most changed resources first, skipping single-field tweaks
+  port compare --source staging --target production --verbose --sort-by changes --min-changes 2
+
   # Output as JSON
   port compare --source staging --target production --output json
 
@@ -63,12 +68,15 @@ Examples:
   # CI/CD mode: fail if differences found
   port compare --source staging --target production --fail-on-diff`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := validateStringEnum("--output", outputFormat, []string{"text", "json", "html"}); err != nil {
+			if err := validateStringEnum("--output", outputFormat, []string{"text", "json", "html", "markdown"}); err != nil {
+				return err
+			}
+			if err := validateModifiedOrderFlags(cmd, sortBy, minChanges, outputFormat); err != nil {
 				return err
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			// Determine if inputs are files or org names
 			sourceFile := ""
@@ -127,6 +135,8 @@ Examples:
 				Full:             full,
 				IncludeResources: includeList,
 				FailOnDiff:       failOnDiff,
+				SortBy:           sortBy,
+				MinChanges:       minChanges,
 			}
 
 			// Create module and execute
@@ -160,7 +170,7 @@ Examples:
 	compareCmd.Flags().StringVar(&targetClientID, "target-client-id", "", "Override target organization client ID")
 	compareCmd.Flags().StringVar(&targetSecret, "target-client-secret", "", "Override target organization client secret")
 
-	compareCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, html")
+	compareCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, html, markdown")
 	compareCmd.Flags().StringVar(&htmlFile, "html-file", "comparison-report.html", "Output path for HTML report")
 	compareCmd.Flags().BoolVar(&htmlSimple, "html-simple", false, "Generate simple HTML (no interactive features)")
 
@@ -168,10 +178,28 @@ Examples:
 	compareCmd.Flags().BoolVar(&full, "full", false, "Show full field-level differences")
 	compareCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resource types to compare")
 	compareCmd.Flags().BoolVar(&failOnDiff, "fail-on-diff", false, "Exit with code 1 if differences found")
+	compareCmd.Flags().StringVar(&sortBy, "sort-by", compare.SortByName, "Order of modified resources in text and markdown output: name or changes (most changed first)")
+	compareCmd.Flags().IntVar(&minChanges, "min-changes", 0, "Hide modified resources with fewer changed fields in text and markdown output")
 
 	rootCmd.AddCommand(compareCmd)
 }
 
+// validateModifiedOrderFlags checks --sort-by and --min-changes, which only
+// affect the text and markdown output.
+func validateModifiedOrderFlags(cmd *cobra.Command, sortBy string, minChanges int, outputFormat string) error {
+	if err := validateStringEnum("--sort-by", sortBy, compare.SortByValues); err != nil {
+		return err
+	}
+	if minChanges < 0 {
+		return exitcode.Usagef("--min-changes must not be negative")
+	}
+	if outputFormat != "text" && outputFormat != "markdown" &&
+		(cmd.Flags().Changed("sort-by") || cmd.Flags().Changed("min-changes")) {
+		return exitcode.Usagef("--sort-by and --min-changes apply to text and markdown output only")
+	}
+	return nil
+}
+
 // isFilePath checks if the input looks like a file path.
 func isFilePath(input string) bool {
 	return strings.HasSuffix(input, ".tar.gz") ||
@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"io"
 	"strings"
 	"testing"
 
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/spf13/cobra"
 )
 
@@ -18,3 +20,31 @@ func TestCompareIncludeEntitiesValid(t *testing.T) {
 		t.Errorf("'entities' should be a valid --include resource, got: %v", err)
 	}
 }
+
+func TestCompareModifiedOrderFlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unknown sort", []string{"--sort-by", "size"}, "invalid value for --sort-by"},
+		{"negative minimum", []string{"--min-changes", "-1"}, "--min-changes must not be negative"},
+		{"json output", []string{"--sort-by", "changes", "-o", "json"}, "apply to text and markdown output only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{}
+			RegisterCompare(root)
+			root.SetArgs(append([]string{"compare", "--source", "src", "--target", "tgt"}, tt.args...))
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			err := root.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if exitcode.Code(err) != exitcode.Usage {
+				t.Errorf("expected a usage error, got exit code %d", exitcode.Code(err))
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ func RegisterConfig(rootCmd *cobra.Command) {
 		Short: "Manage Port CLI configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			if init {
 				if err := configManager.CreateDefaultConfig(); err != nil {
@@ -55,12 +55,72 @@ func RegisterConfig(rootCmd *cobra.Command) {
 	configCmd.Flags().BoolVar(&show, "show", false, "Show current configuration")
 	configCmd.Flags().BoolVar(&init, "init", false, "Initialize configuration file")
 
+	configCmd.AddCommand(registerInit())
 	configCmd.AddCommand(registerGet())
 	configCmd.AddCommand(registerSet())
+	configCmd.AddCommand(registerMigrateSchema())
 
 	rootCmd.AddCommand(configCmd)
 }
 
+// registerInit registers the init command.
+func registerInit() *cobra.Command {
+	var org string
+	var nonInteractive, setDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Initialize the configuration file",
+		Long: `Initialize the configuration file.
+
+Without flags, writes a template config with placeholder credentials to edit by hand.
+With --non-interactive, writes a ready-to-use org from --org, --client-id,
+--client-secret and --api-url. Existing orgs in the config file are preserved.`,
+		Example: `  port config init --non-interactive --org production --client-id $ID --client-secret $SECRET --set-default`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			if !nonInteractive {
+				if err := configManager.CreateDefaultConfig(); err != nil {
+					return fmt.Errorf("failed to create configuration: %w", err)
+				}
+				fmt.Printf("✓ Configuration file created at %s\n", configManager.ConfigPath())
+				fmt.Println("\nPlease edit the file and add your Port credentials.")
+				return nil
+			}
+
+			if org == "" {
+				return fmt.Errorf("--org is required with --non-interactive")
+			}
+			if flags.ClientID == "" || flags.ClientSecret == "" {
+				return fmt.Errorf("--client-id and --client-secret are required with --non-interactive")
+			}
+
+			cfg, err := configManager.UpsertOrg(org, config.OrganizationConfig{
+				ClientID:     flags.ClientID,
+				ClientSecret: flags.ClientSecret,
+				APIURL:       flags.APIURL,
+			}, setDefault)
+			if err != nil {
+				return fmt.Errorf("failed to write configuration: %w", err)
+			}
+
+			fmt.Printf("✓ Organization %s saved to %s\n", org, configManager.ConfigPath())
+			if cfg.DefaultOrg == org {
+				fmt.Printf("  Default org: %s\n", org)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Write the org from flags instead of a placeholder template")
+	cmd.Flags().StringVar(&org, "org", "", "Organization name to add or update")
+	cmd.Flags().BoolVar(&setDefault, "set-default", false, "Make this org the default org")
+
+	return cmd
+}
+
 // registerGet registers the get command.
 func registerGet() *cobra.Command {
 	cmd := &cobra.Command{
@@ -69,7 +129,7 @@ func registerGet() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			cfg, err := configManager.Load()
 			if err != nil {
@@ -111,7 +171,7 @@ func registerSet() *cobra.Command {
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			cfg, err := configManager.Load()
 			if err != nil {
@@ -141,3 +201,39 @@ func registerSet() *cobra.Command {
 	}
 	return cmd
 }
+
+// registerMigrateSchema registers the migrate-schema command.
+func registerMigrateSchema() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-schema",
+		Short: "Upgrade the configuration file to the current format",
+		Long: `Upgrade the configuration file to the current format.
+
+Applies the renames and moves needed by files written by older versions of
+port, records the new schema_version and keeps a copy of the original file
+with a .bak suffix. Comments, ${VAR} references and unknown keys are kept.
+A file that is already current is left untouched.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			migration, err := configManager.MigrateSchema()
+			if err != nil {
+				return fmt.Errorf("failed to migrate configuration: %w", err)
+			}
+			out := cmd.OutOrStdout()
+			if migration.BackupPath == "" {
+				fmt.Fprintf(out, "✓ %s is already at schema version %d\n", configManager.ConfigPath(), migration.To)
+				return nil
+			}
+			fmt.Fprintf(out, "✓ Migrated %s from schema version %d to %d\n", configManager.ConfigPath(), migration.From, migration.To)
+			for _, change := range migration.Changes {
+				fmt.Fprintf(out, "  - %s\n", change)
+			}
+			fmt.Fprintf(out, "  Original saved to %s\n", migration.BackupPath)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -1,8 +1,14 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/port-experimental/port-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -45,3 +51,75 @@ func TestConfigSetArgsParsed(t *testing.T) {
 		t.Errorf("unexpected error parsing args: %v", err)
 	}
 }
+
+func TestConfigInitNonInteractiveWritesOrg(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterConfig(rootCmd)
+	rootCmd.SetArgs([]string{"config", "init", "--non-interactive", "--org", "prod", "--set-default"})
+
+	ctx := WithGlobalFlags(context.Background(), GlobalFlags{
+		ConfigFile:   configPath,
+		ClientID:     "my-id",
+		ClientSecret: "my-secret",
+	})
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("config init failed: %v", err)
+	}
+
+	cfg, err := config.NewConfigManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("failed to load written config: %v", err)
+	}
+	if cfg.DefaultOrg != "prod" {
+		t.Errorf("expected default org prod, got %q", cfg.DefaultOrg)
+	}
+	if cfg.Organizations["prod"].ClientSecret != "my-secret" {
+		t.Errorf("expected client secret to be written, got %+v", cfg.Organizations["prod"])
+	}
+}
+
+func TestConfigInitNonInteractiveRequiresCredentials(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterConfig(rootCmd)
+	rootCmd.SetArgs([]string{"config", "init", "--non-interactive", "--org", "prod"})
+	rootCmd.SilenceUsage = true
+	rootCmd.SilenceErrors = true
+
+	ctx := WithGlobalFlags(context.Background(), GlobalFlags{
+		ConfigFile: filepath.Join(t.TempDir(), "config.yaml"),
+	})
+	err := rootCmd.ExecuteContext(ctx)
+	if err == nil || !strings.Contains(err.Error(), "--client-id") {
+		t.Fatalf("expected missing credentials error, got %v", err)
+	}
+}
+
+func TestConfigMigrateSchemaUpgradesFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("default_org: prod\nplugin:\n  select_all: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterConfig(rootCmd)
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"config", "migrate-schema"})
+	ctx := WithGlobalFlags(context.Background(), GlobalFlags{ConfigFile: configPath})
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("config migrate-schema failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "from schema version 0 to 1") || !strings.Contains(out.String(), configPath+".bak") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	cfg, err := config.NewConfigManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("failed to load migrated config: %v", err)
+	}
+	if cfg.SchemaVersion != config.CurrentSchemaVersion || !cfg.Skills.SelectAll {
+		t.Errorf("unexpected migrated config %+v", cfg)
+	}
+}
@@ -1,6 +1,10 @@
 package commands
 
-import "context"
+import (
+	"context"
+
+	"github.com/port-experimental/port-cli/internal/config"
+)
 
 type contextKey string
 
@@ -20,6 +24,8 @@ type GlobalFlags struct {
 	Quiet              bool
 	Verbose            bool
 	Yes                bool
+	// Client holds the API client settings applied to every resolved org.
+	Client config.ClientSettings
 }
 
 // WithGlobalFlags adds global flags to the context.
@@ -35,3 +41,9 @@ func GetGlobalFlags(ctx context.Context) GlobalFlags {
 	}
 	return flags
 }
+
+// newConfigManager returns a ConfigManager for the --config file whose
+// resolved organizations carry the global API client settings.
+func newConfigManager(flags GlobalFlags) *config.ConfigManager {
+	return config.NewConfigManager(flags.ConfigFile).WithClientSettings(flags.Client)
+}
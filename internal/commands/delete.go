@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// deleteIncludeTypes lists the --include values of the delete command: the
+// resources deleted along with the matching blueprints.
+var deleteIncludeTypes = []string{"entities", "scorecards", "actions"}
+
+// RegisterDelete registers the delete command.
+func RegisterDelete(rootCmd *cobra.Command) {
+	var (
+		org        string
+		blueprints string
+		include    string
+		dryRun     bool
+	)
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete blueprints matching identifier patterns",
+		Long: `Delete the blueprints whose identifiers match --blueprints, and optionally
+their entities, scorecards and actions.
+
+--blueprints takes identifiers or globs (path.Match syntax), comma separated.
+The command lists everything it will delete, in the order it deletes it:
+entities, scorecards and actions first, then the blueprints, each blueprint
+before the blueprints it relates to. It asks for confirmation before deleting
+anything; --yes skips the prompt and --dry-run only prints the list.
+
+System blueprints (identifiers starting with an underscore) are never deleted.
+Port refuses to delete a blueprint that still has entities, or that an
+unselected blueprint relates to; include entities and select the related
+blueprints too.`,
+		Example: `  port delete --blueprints "test-*" --include entities --dry-run
+  port delete --blueprints "test-*,tmp-*" --include entities,scorecards,actions --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var patterns []string
+			for _, pattern := range strings.Split(blueprints, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					patterns = append(patterns, pattern)
+				}
+			}
+			if len(patterns) == 0 {
+				return exitcode.Usagef("--blueprints is required")
+			}
+			if err := export.ValidateBlueprintPatterns(patterns); err != nil {
+				return exitcode.Usagef("invalid --blueprints: %w", err)
+			}
+			var includeList []string
+			for _, resource := range strings.Split(include, ",") {
+				if resource = strings.TrimSpace(resource); resource == "" {
+					continue
+				}
+				if err := validateStringEnum("--include", resource, deleteIncludeTypes); err != nil {
+					return err
+				}
+				includeList = append(includeList, resource)
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			deletions, warnings, err := planDelete(cmd.Context(), client, patterns, includeList)
+			if err != nil {
+				return err
+			}
+			for _, warning := range warnings {
+				output.WarningPrintln(warning)
+			}
+			if len(deletions) == 0 {
+				output.Printf("No blueprints match %s\n", strings.Join(patterns, ", "))
+				return nil
+			}
+			printDeletePlan(deletions, describeTargetOrg(useOrg))
+
+			if dryRun {
+				output.Printf("\nDry run - nothing was deleted\n")
+				return nil
+			}
+			if !ShouldSkipConfirm(cmd, false) {
+				confirmed, err := confirmPrompt(
+					fmt.Sprintf("Delete %d resource(s)?", len(deletions)),
+					fmt.Sprintf("This will delete the resources listed above from organization %q. This cannot be undone.", describeTargetOrg(useOrg)),
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					cmd.Println("Operation cancelled")
+					return nil
+				}
+			}
+
+			importer := import_module.NewImporter(client)
+			importer.SetResourceCallback(func(action, resourceType, identifier string) {
+				if action == import_module.ResourceDeleted {
+					output.Printf("Deleted %s: %s\n", resourceType, identifier)
+				}
+			})
+			deleted := importer.DeleteResources(cmd.Context(), deletions)
+			if errs := importer.CollectedErrors(); len(errs) > 0 {
+				for _, e := range errs {
+					output.ErrorPrintf("  %s\n", e)
+				}
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("deleted %d of %d resource(s); %d failed", deleted, len(deletions), len(errs)))
+			}
+			output.SuccessPrint("✓ Deleted %d resource(s)\n", deleted)
+			return nil
+		},
+	}
+
+	deleteCmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	deleteCmd.Flags().StringVar(&blueprints, "blueprints", "", "Blueprint identifiers or globs to delete, comma separated (e.g. \"test-*\")")
+	deleteCmd.Flags().StringVar(&include, "include", "", "Also delete these resources of the matching blueprints, comma separated: "+strings.Join(deleteIncludeTypes, ", "))
+	deleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be deleted without deleting anything")
+
+	rootCmd.AddCommand(deleteCmd)
+}
+
+// planDelete lists the resources the delete command removes for the given
+// blueprint patterns and included resource types, in deletion order. The
+// warnings name matching system blueprints, which are left alone, and
+// unselected blueprints that relate to selected ones.
+func planDelete(ctx context.Context, client *api.Client, patterns, include []string) ([]export.Deletion, []string, error) {
+	all, err := client.GetBlueprints(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+
+	var warnings []string
+	var matched []api.Blueprint
+	selected := make(map[string]bool)
+	for _, bp := range export.SelectBlueprints(all, nil, patterns) {
+		id, _ := bp["identifier"].(string)
+		if import_module.IsSystemBlueprint(id) {
+			warnings = append(warnings, fmt.Sprintf("Skipping system blueprint %s", id))
+			continue
+		}
+		matched = append(matched, bp)
+		selected[id] = true
+	}
+	for _, bp := range all {
+		id, _ := bp["identifier"].(string)
+		if selected[id] {
+			continue
+		}
+		for _, dep := range import_module.GetAllDependencies(bp) {
+			if selected[dep] {
+				warnings = append(warnings, fmt.Sprintf("Blueprint %s is not selected but relates to %s; Port will refuse to delete %s", id, dep, dep))
+			}
+		}
+	}
+
+	ordered := import_module.BlueprintDeletionOrder(matched)
+	var deletions []export.Deletion
+	for _, resource := range deleteIncludeTypes {
+		if !slices.Contains(include, resource) {
+			continue
+		}
+		for _, bp := range ordered {
+			bpID := bp["identifier"].(string)
+			ids, err := blueprintResourceIDs(ctx, client, resource, bpID)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, id := range ids {
+				deletions = append(deletions, export.Deletion{Type: resource, Blueprint: bpID, Identifier: id})
+			}
+		}
+	}
+	for _, bp := range ordered {
+		deletions = append(deletions, export.Deletion{Type: "blueprints", Identifier: bp["identifier"].(string)})
+	}
+	return deletions, warnings, nil
+}
+
+// blueprintResourceIDs lists the identifiers of blueprint bpID's entities,
+// scorecards or actions.
+func blueprintResourceIDs(ctx context.Context, client *api.Client, resource, bpID string) ([]string, error) {
+	var items []map[string]interface{}
+	switch resource {
+	case "entities":
+		entities, err := client.SearchEntities(ctx, bpID, map[string]interface{}{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search entities for blueprint %q: %w", bpID, err)
+		}
+		for _, e := range entities {
+			items = append(items, e)
+		}
+	case "scorecards":
+		scorecards, err := client.GetScorecards(ctx, bpID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list scorecards for blueprint %q: %w", bpID, err)
+		}
+		for _, sc := range scorecards {
+			items = append(items, sc)
+		}
+	case "actions":
+		actions, err := client.GetActions(ctx, bpID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list actions for blueprint %q: %w", bpID, err)
+		}
+		for _, a := range actions {
+			items = append(items, a)
+		}
+	}
+	var ids []string
+	for _, item := range items {
+		if id, _ := item["identifier"].(string); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// printDeletePlan lists deletions in the order they are made. Entities are
+// counted per blueprint rather than listed one by one.
+func printDeletePlan(deletions []export.Deletion, org string) {
+	output.Printf("Resources to delete from organization %q, in order:\n", org)
+	entityCounts := make(map[string]int)
+	var entityBlueprints []string
+	for _, d := range deletions {
+		if d.Type == "entities" {
+			if entityCounts[d.Blueprint] == 0 {
+				entityBlueprints = append(entityBlueprints, d.Blueprint)
+			}
+			entityCounts[d.Blueprint]++
+		}
+	}
+	for _, bpID := range entityBlueprints {
+		output.Printf("  %d entit(ies) of %s\n", entityCounts[bpID], bpID)
+	}
+	for _, d := range deletions {
+		switch d.Type {
+		case "scorecards":
+			output.Printf("  scorecard %s/%s\n", d.Blueprint, d.Identifier)
+		case "actions":
+			output.Printf("  action %s/%s\n", d.Blueprint, d.Identifier)
+		case "blueprints":
+			output.Printf("  blueprint %s\n", d.Identifier)
+		}
+	}
+}
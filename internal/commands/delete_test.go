@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/spf13/cobra"
+)
+
+// newDeleteServer serves test-deploy relating to test-service, an unselected
+// audit blueprint relating to test-service, and entities and scorecards for
+// the test blueprints.
+func newDeleteServer(t *testing.T) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []map[string]interface{}{
+				{"identifier": "test-service"},
+				{"identifier": "test-deploy", "relations": map[string]interface{}{"service": map[string]interface{}{"target": "test-service"}}},
+				{"identifier": "audit", "relations": map[string]interface{}{"service": map[string]interface{}{"target": "test-service"}}},
+				{"identifier": "_user"},
+			}})
+		case "/blueprints/test-service/entities/search":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []map[string]interface{}{
+				{"identifier": "checkout", "blueprint": "test-service"},
+				{"identifier": "billing", "blueprint": "test-service"},
+			}})
+		case "/blueprints/test-deploy/entities/search":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []map[string]interface{}{
+				{"identifier": "d1", "blueprint": "test-deploy"},
+			}})
+		case "/blueprints/test-service/scorecards":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecards": []map[string]interface{}{
+				{"identifier": "health", "blueprintIdentifier": "test-service"},
+			}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+}
+
+func TestPlanDelete_OrdersDependentsFirst(t *testing.T) {
+	client := newDeleteServer(t)
+	defer client.Close()
+
+	deletions, warnings, err := planDelete(context.Background(), client, []string{"test-*", "_*"}, []string{"entities"})
+	if err != nil {
+		t.Fatalf("planDelete: %v", err)
+	}
+	want := []export.Deletion{
+		{Type: "entities", Blueprint: "test-deploy", Identifier: "d1"},
+		{Type: "entities", Blueprint: "test-service", Identifier: "checkout"},
+		{Type: "entities", Blueprint: "test-service", Identifier: "billing"},
+		{Type: "blueprints", Identifier: "test-deploy"},
+		{Type: "blueprints", Identifier: "test-service"},
+	}
+	if !reflect.DeepEqual(deletions, want) {
+		t.Errorf("deletions =\n%v\nwant\n%v", deletions, want)
+	}
+	joined := strings.Join(warnings, "\n")
+	if !strings.Contains(joined, "system blueprint _user") || !strings.Contains(joined, "audit is not selected but relates to test-service") {
+		t.Errorf("warnings = %v, want the system blueprint and the unselected dependent", warnings)
+	}
+}
+
+func TestPlanDelete_IncludesScorecards(t *testing.T) {
+	client := newDeleteServer(t)
+	defer client.Close()
+
+	deletions, _, err := planDelete(context.Background(), client, []string{"test-service"}, []string{"scorecards"})
+	if err != nil {
+		t.Fatalf("planDelete: %v", err)
+	}
+	want := []export.Deletion{
+		{Type: "scorecards", Blueprint: "test-service", Identifier: "health"},
+		{Type: "blueprints", Identifier: "test-service"},
+	}
+	if !reflect.DeepEqual(deletions, want) {
+		t.Errorf("deletions = %v, want %v", deletions, want)
+	}
+}
+
+func TestDeleteCommand_FlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no blueprints", nil, "--blueprints is required"},
+		{"malformed pattern", []string{"--blueprints", "test-["}, "invalid --blueprints"},
+		{"unknown include", []string{"--blueprints", "test-*", "--include", "pages"}, "invalid value for --include"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{}
+			RegisterDelete(root)
+			root.SetArgs(append([]string{"delete"}, tt.args...))
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			err := root.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if exitcode.Code(err) != exitcode.Usage {
+				t.Errorf("expected a usage error, got exit code %d", exitcode.Code(err))
+			}
+		})
+	}
+}
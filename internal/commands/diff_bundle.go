@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/compare"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// RegisterDiffBundle registers the diff-bundle command.
+func RegisterDiffBundle(rootCmd *cobra.Command) {
+	var outputPath string
+
+	diffBundleCmd := &cobra.Command{
+		Use:   "diff-bundle <old-export> <new-export>",
+		Short: "Write the changes between two exports as a delta bundle",
+		Long: `Write the changes between two exports as a delta bundle.
+
+Compares two export files with the same differ as 'port compare' and writes
+every resource that was added or modified in <new-export>, in full, to a new
+export bundle. Resources removed since <old-export> are listed under
+_deletions. The bundle is usually much smaller than a full export, so it is
+faster to transfer and apply for incremental rollouts:
+
+  port diff-bundle monday.tar.gz tuesday.tar.gz -o delta.tar.gz
+  port import -i delta.tar.gz --prune
+
+'port import' applies the changes; deletions are only applied with --prune.
+The output format follows the file extension, as for 'port export'.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputPath == "" {
+				return exitcode.Usagef("--output is required")
+			}
+
+			loader := import_module.NewLoader()
+			oldData, err := loader.LoadData(args[0])
+			if err != nil {
+				return exitcode.Usagef("failed to load %s: %w", args[0], err)
+			}
+			newData, err := loader.LoadData(args[1])
+			if err != nil {
+				return exitcode.Usagef("failed to load %s: %w", args[1], err)
+			}
+
+			delta := compare.BuildDelta(oldData, newData)
+			if err := export.WriteBundle(delta, outputPath); err != nil {
+				return fmt.Errorf("failed to write delta bundle: %w", err)
+			}
+
+			output.SuccessPrint("✓ Wrote delta bundle to %s: %d changed resource(s), %d deletion(s)\n", outputPath, compare.DeltaSize(delta), len(delta.Deletions))
+			return nil
+		},
+	}
+
+	diffBundleCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (e.g., delta.tar.gz or delta.json)")
+
+	rootCmd.AddCommand(diffBundleCmd)
+}
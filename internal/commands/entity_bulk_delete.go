@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultBulkDeleteParallel is the default number of delete requests
+// entities bulk-delete runs at once.
+const defaultBulkDeleteParallel = 4
+
+// bulkDeleteSearchBody returns the search request that finds the entities to
+// delete: those matching query, or every entity when query is nil.
+func bulkDeleteSearchBody(query map[string]interface{}) map[string]interface{} {
+	if query == nil {
+		query = map[string]interface{}{
+			"combinator": "and",
+			"rules":      []interface{}{},
+		}
+	}
+	return map[string]interface{}{
+		"query":   query,
+		"include": []interface{}{"identifier", "title"},
+		"limit":   1000,
+	}
+}
+
+// entityIdentifiers returns the identifiers of entities, skipping entities
+// without one.
+func entityIdentifiers(entities []api.Entity) []string {
+	ids := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if id, ok := entity["identifier"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// registerEntityBulkDelete registers the entity bulk-delete command.
+func registerEntityBulkDelete() *cobra.Command {
+	var org, blueprint, queryFile string
+	var all, dryRun bool
+	var parallel int
+
+	cmd := &cobra.Command{
+		Use:   "bulk-delete",
+		Short: "Delete the entities of a blueprint that match a search query",
+		Long: `Delete the entities of a blueprint that match a search query.
+
+The entities are found through the search endpoint, using the query in the
+--query file (a Port search rule or rule group, in YAML or JSON), or every
+entity of the blueprint with --all. They are then deleted in batches, running
+up to --parallel requests at a time, within the global --concurrency limit.
+
+Deleting requires --yes. Use --dry-run to list what would be deleted first.`,
+		Example: `  port api entities bulk-delete --blueprint service --query stale.yaml --dry-run
+  port api entities bulk-delete --blueprint service --query stale.yaml --yes
+  port api entities bulk-delete --blueprint testRun --all --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (queryFile == "") == !all {
+				return exitcode.Usagef("exactly one of --query and --all is required")
+			}
+			if parallel < 1 {
+				return exitcode.Usagef("--parallel must be at least 1")
+			}
+			if !dryRun && !ShouldSkipConfirm(cmd, false) {
+				return exitcode.Usagef("bulk-delete requires --yes; use --dry-run to list the matching entities first")
+			}
+			var query map[string]interface{}
+			if queryFile != "" {
+				var err error
+				if query, err = export.LoadSearchQuery(queryFile); err != nil {
+					return exitcode.New(exitcode.Usage, err)
+				}
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			entities, err := client.SearchEntities(cmd.Context(), blueprint, bulkDeleteSearchBody(query))
+			if err != nil {
+				return fmt.Errorf("failed to search entities: %w", err)
+			}
+			ids := entityIdentifiers(entities)
+			output.Printf("Found %d matching entit(ies) in blueprint '%s'\n", len(ids), blueprint)
+			if dryRun {
+				for _, id := range ids {
+					output.Printf("  %s\n", id)
+				}
+				output.Printf("\nDry run - nothing was deleted\n")
+				return nil
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+
+			deleted, err := client.DeleteEntities(cmd.Context(), blueprint, ids, parallel)
+			if err != nil {
+				return exitcode.New(exitcode.Stopped(deleted), fmt.Errorf("deleted %d of %d entities, then failed: %w", deleted, len(ids), err))
+			}
+			output.SuccessPrintln(fmt.Sprintf("Deleted %d entit(ies) from blueprint '%s'", deleted, blueprint))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&blueprint, "blueprint", "b", "", "Blueprint whose entities are deleted")
+	cmd.Flags().StringVar(&queryFile, "query", "", "YAML/JSON file with the Port search rule or rule group the entities must match")
+	cmd.Flags().BoolVar(&all, "all", false, "Delete every entity of the blueprint")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the matching entities without deleting them")
+	cmd.Flags().IntVar(&parallel, "parallel", defaultBulkDeleteParallel, "Maximum number of delete batches sent at once")
+	cmd.MarkFlagRequired("blueprint")
+
+	return cmd
+}
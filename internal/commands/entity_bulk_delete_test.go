@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+)
+
+func TestBulkDeleteSearchBody(t *testing.T) {
+	all := bulkDeleteSearchBody(nil)
+	if query, _ := all["query"].(map[string]interface{}); query["combinator"] != "and" || len(query["rules"].([]interface{})) != 0 {
+		t.Errorf("expected an empty rule group for --all, got %v", all["query"])
+	}
+
+	query := map[string]interface{}{"combinator": "or", "rules": []interface{}{}}
+	if got := bulkDeleteSearchBody(query); !reflect.DeepEqual(got["query"], query) {
+		t.Errorf("expected the query to be sent as-is, got %v", got["query"])
+	}
+}
+
+func TestEntityIdentifiers(t *testing.T) {
+	got := entityIdentifiers([]api.Entity{{"identifier": "a"}, {"title": "no id"}, {"identifier": "b"}})
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("entityIdentifiers = %v", got)
+	}
+}
+
+func TestEntityBulkDelete_FlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no selection", []string{"--blueprint", "service", "--yes"}, "exactly one of --query and --all"},
+		{"both selections", []string{"--blueprint", "service", "--all", "--query", "q.yaml", "--yes"}, "exactly one of --query and --all"},
+		{"no yes", []string{"--blueprint", "service", "--all"}, "requires --yes"},
+		{"bad parallel", []string{"--blueprint", "service", "--all", "--yes", "--parallel", "0"}, "--parallel"},
+		{"missing query file", []string{"--blueprint", "service", "--query", "does-not-exist.yaml", "--yes"}, "failed to read query file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := registerEntityBulkDelete()
+			cmd.Flags().Bool("yes", false, "")
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if exitcode.Code(err) != exitcode.Usage {
+				t.Errorf("expected a usage error, got exit code %d", exitcode.Code(err))
+			}
+		})
+	}
+}
+
+func TestEntityBulkDelete_KeepsGlobalConcurrencyFlag(t *testing.T) {
+	cmd := registerEntityBulkDelete()
+	if cmd.LocalNonPersistentFlags().Lookup("concurrency") != nil {
+		t.Error("bulk-delete must not shadow the global --concurrency flag")
+	}
+	if cmd.Flags().Lookup("parallel") == nil {
+		t.Error("expected a --parallel flag sizing the delete batches")
+	}
+}
@@ -0,0 +1,475 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// entityMovePlan describes moving an entity to another blueprint.
+type entityMovePlan struct {
+	From, To, Identifier string
+	// Entity is the entity to create under To.
+	Entity            api.Entity
+	MappedProperties  []string
+	DroppedProperties []string
+	DroppedRelations  []string
+	Referrers         []entityMoveReferrer
+}
+
+// entityMoveReferrer is an entity whose relations point at the moved entity.
+type entityMoveReferrer struct {
+	Blueprint, Identifier string
+	// Entity is the referrer with its relations repointed, ready to be
+	// written back.
+	Entity  api.Entity
+	Changes []string
+}
+
+// parsePropertyMap parses --map-property values of the form "old=new".
+func parsePropertyMap(values []string) (map[string]string, error) {
+	propertyMap := make(map[string]string, len(values))
+	for _, value := range values {
+		from, to, ok := strings.Cut(value, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid property mapping %q: expected old=new", value)
+		}
+		if existing, dup := propertyMap[from]; dup && existing != to {
+			return nil, fmt.Errorf("property %q is mapped to both %q and %q", from, existing, to)
+		}
+		propertyMap[from] = to
+	}
+	return propertyMap, nil
+}
+
+// blueprintPropertyNames returns the properties declared in bp's schema.
+func blueprintPropertyNames(bp api.Blueprint) map[string]bool {
+	names := make(map[string]bool)
+	schema, _ := bp["schema"].(map[string]interface{})
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name := range properties {
+		names[name] = true
+	}
+	return names
+}
+
+// relationTarget returns the target blueprint of relation name on bp, or "".
+func relationTarget(bp api.Blueprint, name string) string {
+	def, _ := import_module.ExtractRelations(bp)[name].(map[string]interface{})
+	target, _ := def["target"].(string)
+	return target
+}
+
+// relationIsMany reports whether relation name on bp takes a list.
+func relationIsMany(bp api.Blueprint, name string) bool {
+	def, _ := import_module.ExtractRelations(bp)[name].(map[string]interface{})
+	many, _ := def["many"].(bool)
+	return many
+}
+
+// buildMovedEntity returns entity rewritten for the target blueprint.
+// Properties are renamed through propertyMap and kept only when the target
+// schema declares them; relations are kept only when the target blueprint
+// has a relation of the same name and target. The names of mapped and
+// dropped properties and relations are returned sorted.
+func buildMovedEntity(entity api.Entity, source, target api.Blueprint, propertyMap map[string]string) (moved api.Entity, mapped, droppedProperties, droppedRelations []string) {
+	moved = api.Entity{}
+	for _, field := range []string{"identifier", "title", "icon", "team"} {
+		if v, ok := entity[field]; ok && v != nil {
+			moved[field] = v
+		}
+	}
+
+	declared := blueprintPropertyNames(target)
+	properties := make(map[string]interface{})
+	values, _ := entity["properties"].(map[string]interface{})
+	for name, value := range values {
+		newName := name
+		if renamed, ok := propertyMap[name]; ok {
+			newName = renamed
+			mapped = append(mapped, name+" -> "+renamed)
+		}
+		if !declared[newName] {
+			if value != nil {
+				droppedProperties = append(droppedProperties, name)
+			}
+			continue
+		}
+		properties[newName] = value
+	}
+	moved["properties"] = properties
+
+	relations := make(map[string]interface{})
+	relationValues, _ := entity["relations"].(map[string]interface{})
+	for name, value := range relationValues {
+		sourceTarget := relationTarget(source, name)
+		if sourceTarget == "" || relationTarget(target, name) != sourceTarget {
+			if !isEmptyRelationValue(value) {
+				droppedRelations = append(droppedRelations, name)
+			}
+			continue
+		}
+		relations[name] = value
+	}
+	moved["relations"] = relations
+
+	sort.Strings(mapped)
+	sort.Strings(droppedProperties)
+	sort.Strings(droppedRelations)
+	return moved, mapped, droppedProperties, droppedRelations
+}
+
+func isEmptyRelationValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if list, ok := value.([]interface{}); ok {
+		return len(list) == 0
+	}
+	return false
+}
+
+// counterpartRelation picks the relation of bp that replaces relation once
+// the entity it points at moves to blueprint to: relation itself when it
+// already targets to, otherwise the only relation of bp that targets to.
+// It returns "" when there is no such relation or the choice is ambiguous.
+func counterpartRelation(bp api.Blueprint, relation, to string) string {
+	if relationTarget(bp, relation) == to {
+		return relation
+	}
+	var candidates []string
+	for name := range import_module.ExtractRelations(bp) {
+		if relationTarget(bp, name) == to {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) != 1 {
+		return ""
+	}
+	return candidates[0]
+}
+
+// repointReferences rewrites the relations of referrer, an entity of bp,
+// that point at entityID on blueprint from so that they point at entityID
+// on blueprint to. It returns a relations patch and a line per change; the
+// patch is nil when referrer does not reference the entity.
+func repointReferences(referrer api.Entity, bp api.Blueprint, entityID, from, to string) (map[string]interface{}, []string) {
+	values, _ := referrer["relations"].(map[string]interface{})
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if relationTarget(bp, name) == from && relationReferences(values[name], entityID) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	patch := make(map[string]interface{})
+	current := func(name string) interface{} {
+		if v, ok := patch[name]; ok {
+			return v
+		}
+		return values[name]
+	}
+	var changes []string
+	for _, name := range names {
+		patch[name] = removeRelationValue(current(name), entityID)
+		counterpart := counterpartRelation(bp, name, to)
+		if counterpart == "" {
+			changes = append(changes, fmt.Sprintf("%s: reference removed (no relation to %s)", name, to))
+			continue
+		}
+		patch[counterpart] = addRelationValue(current(counterpart), entityID, relationIsMany(bp, counterpart))
+		if counterpart == name {
+			changes = append(changes, fmt.Sprintf("%s: now points at %s", name, to))
+		} else {
+			changes = append(changes, fmt.Sprintf("%s -> %s", name, counterpart))
+		}
+	}
+	return patch, changes
+}
+
+func relationReferences(value interface{}, entityID string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == entityID
+	case []interface{}:
+		for _, item := range v {
+			if item == entityID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func removeRelationValue(value interface{}, entityID string) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		if value == entityID {
+			return nil
+		}
+		return value
+	}
+	kept := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if item != entityID {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func addRelationValue(value interface{}, entityID string, many bool) interface{} {
+	if !many {
+		return entityID
+	}
+	list, _ := value.([]interface{})
+	if relationReferences(list, entityID) {
+		return list
+	}
+	return append(append([]interface{}{}, list...), entityID)
+}
+
+// planEntityMove fetches the entity, the blueprints and every entity that
+// references the entity, and plans the move.
+func planEntityMove(ctx context.Context, client *api.Client, from, entityID, to string, propertyMap map[string]string) (*entityMovePlan, error) {
+	if from == to {
+		return nil, exitcode.Usagef("the entity is already in blueprint %q", to)
+	}
+	blueprints, err := client.GetBlueprints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+	byID := make(map[string]api.Blueprint, len(blueprints))
+	for _, bp := range blueprints {
+		if id, ok := bp["identifier"].(string); ok {
+			byID[id] = bp
+		}
+	}
+	for _, id := range []string{from, to} {
+		if _, ok := byID[id]; !ok {
+			return nil, fmt.Errorf("blueprint %q not found", id)
+		}
+	}
+
+	entity, err := client.GetEntity(ctx, from, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entity: %w", err)
+	}
+	if _, err := client.GetEntity(ctx, to, entityID); err == nil {
+		return nil, fmt.Errorf("entity %q already exists in blueprint %q", entityID, to)
+	} else if !api.HasStatus(err, 404) {
+		return nil, fmt.Errorf("failed to check blueprint %q for entity %q: %w", to, entityID, err)
+	}
+
+	plan := &entityMovePlan{From: from, To: to, Identifier: entityID}
+	plan.Entity, plan.MappedProperties, plan.DroppedProperties, plan.DroppedRelations =
+		buildMovedEntity(entity, byID[from], byID[to], propertyMap)
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, bpID := range ids {
+		bp := byID[bpID]
+		referencesFrom := false
+		for name := range import_module.ExtractRelations(bp) {
+			if relationTarget(bp, name) == from {
+				referencesFrom = true
+				break
+			}
+		}
+		if !referencesFrom {
+			continue
+		}
+		err := client.ForEachEntity(ctx, bpID, func(page []api.Entity) error {
+			for _, referrer := range page {
+				referrerID, _ := referrer["identifier"].(string)
+				if bpID == from && referrerID == entityID {
+					continue
+				}
+				patch, changes := repointReferences(referrer, bp, entityID, from, to)
+				if patch == nil {
+					continue
+				}
+				plan.Referrers = append(plan.Referrers, entityMoveReferrer{
+					Blueprint:  bpID,
+					Identifier: referrerID,
+					Entity:     mergeEntity(referrer, api.Entity{"relations": patch}),
+					Changes:    changes,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of blueprint %q: %w", bpID, err)
+		}
+	}
+	return plan, nil
+}
+
+func printEntityMovePlan(plan *entityMovePlan) {
+	output.Printf("Move entity %q from blueprint %q to %q:\n", plan.Identifier, plan.From, plan.To)
+	for _, m := range plan.MappedProperties {
+		output.Printf("  property %s\n", m)
+	}
+	if len(plan.DroppedProperties) > 0 {
+		output.Printf("  properties not in %s, dropped: %s\n", plan.To, strings.Join(plan.DroppedProperties, ", "))
+	}
+	if len(plan.DroppedRelations) > 0 {
+		output.Printf("  relations not in %s, dropped: %s\n", plan.To, strings.Join(plan.DroppedRelations, ", "))
+	}
+	if len(plan.Referrers) == 0 {
+		output.Printf("No other entity references it.\n")
+		return
+	}
+	output.Printf("%d entit(ies) referencing it will be updated:\n", len(plan.Referrers))
+	for _, r := range plan.Referrers {
+		output.Printf("  %s/%s: %s\n", r.Blueprint, r.Identifier, strings.Join(r.Changes, "; "))
+	}
+}
+
+// applyEntityMove creates the entity under the target blueprint, repoints
+// the referrers and then deletes the original. The original is kept when a
+// referrer could not be updated, so no reference is lost.
+func applyEntityMove(ctx context.Context, client *api.Client, plan *entityMovePlan) error {
+	if _, err := client.CreateEntity(ctx, plan.To, plan.Entity); err != nil {
+		return fmt.Errorf("failed to create entity in blueprint %q: %w", plan.To, err)
+	}
+	var failed []string
+	for _, r := range plan.Referrers {
+		if _, err := client.UpdateEntity(ctx, r.Blueprint, r.Identifier, r.Entity); err != nil {
+			failed = append(failed, fmt.Sprintf("%s/%s: %v", r.Blueprint, r.Identifier, err))
+		}
+	}
+	if len(failed) > 0 {
+		return exitcode.New(exitcode.ResourceErrors, fmt.Errorf(
+			"entity %q was created in blueprint %q but %d referencing entit(ies) could not be updated, so it was not deleted from %q:\n  %s",
+			plan.Identifier, plan.To, len(failed), plan.From, strings.Join(failed, "\n  ")))
+	}
+	if err := client.DeleteEntity(ctx, plan.From, plan.Identifier); err != nil {
+		return fmt.Errorf("entity %q was moved to blueprint %q but could not be deleted from %q: %w", plan.Identifier, plan.To, plan.From, err)
+	}
+	return nil
+}
+
+// registerEntityMove registers the entity move command.
+func registerEntityMove() *cobra.Command {
+	var org string
+	var mapProperties []string
+	var dryRun, force bool
+
+	cmd := &cobra.Command{
+		Use:   "move [blueprint-id] [entity-id] [new-blueprint-id]",
+		Short: "Move an entity to another blueprint",
+		Long: `Move an entity to another blueprint.
+
+Port cannot change an entity's blueprint, so the entity is created under the
+new blueprint with the same identifier, every entity whose relations point at
+it is updated to point at the new one, and the original is deleted.
+
+Properties keep their names unless renamed with --map-property old=new, and
+are dropped when the new blueprint's schema does not declare them. Relations
+are kept when the new blueprint has a relation of the same name and target.
+A referencing entity's relation is repointed in place when it already
+targets the new blueprint, or moved to its blueprint's only relation that
+does; otherwise the reference is removed. Each of these is listed before
+anything is written.`,
+		Example: `  port api entities move service payments legacyService --dry-run
+  port api entities move service payments legacyService --map-property language=lang`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, entityID, to := args[0], args[1], args[2]
+			propertyMap, err := parsePropertyMap(mapProperties)
+			if err != nil {
+				return exitcode.New(exitcode.Usage, err)
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(
+				flags.ClientID,
+				flags.ClientSecret,
+				flags.APIURL,
+				org,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			plan, err := planEntityMove(cmd.Context(), client, from, entityID, to, propertyMap)
+			if err != nil {
+				return err
+			}
+			printEntityMovePlan(plan)
+			if dryRun {
+				output.Printf("\nDry run - nothing was changed\n")
+				return nil
+			}
+			if !ShouldSkipConfirm(cmd, force) {
+				confirmed, err := confirmPrompt(
+					fmt.Sprintf("Move entity %q to blueprint %q?", entityID, to),
+					fmt.Sprintf("This will recreate the entity in %q, update %d referencing entit(ies) and delete it from %q.", to, len(plan.Referrers), from),
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					cmd.Println("Operation cancelled")
+					return nil
+				}
+			}
+
+			if err := applyEntityMove(cmd.Context(), client, plan); err != nil {
+				return err
+			}
+			output.SuccessPrintln(fmt.Sprintf("Entity '%s' moved to blueprint '%s'", entityID, to))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringArrayVar(&mapProperties, "map-property", nil, "Rename a property on the way, as old=new (repeatable)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the plan without changing anything")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
+
+	return cmd
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestParsePropertyMap(t *testing.T) {
+	propertyMap, err := parsePropertyMap([]string{"language=lang", " tier = level "})
+	if err != nil {
+		t.Fatalf("parsePropertyMap: %v", err)
+	}
+	want := map[string]string{"language": "lang", "tier": "level"}
+	if !reflect.DeepEqual(propertyMap, want) {
+		t.Errorf("parsePropertyMap = %v, want %v", propertyMap, want)
+	}
+	for _, values := range [][]string{{"language"}, {"=lang"}, {"a=b", "a=c"}} {
+		if _, err := parsePropertyMap(values); err == nil {
+			t.Errorf("parsePropertyMap(%q): expected an error", values)
+		}
+	}
+}
+
+func TestBuildMovedEntity(t *testing.T) {
+	source := api.Blueprint{
+		"identifier": "service",
+		"relations": map[string]interface{}{
+			"team":   map[string]interface{}{"target": "squad"},
+			"domain": map[string]interface{}{"target": "domain"},
+			"system": map[string]interface{}{"target": "system"},
+		},
+	}
+	target := api.Blueprint{
+		"identifier": "legacyService",
+		"schema": map[string]interface{}{"properties": map[string]interface{}{
+			"lang": map[string]interface{}{"type": "string"},
+			"tier": map[string]interface{}{"type": "string"},
+		}},
+		"relations": map[string]interface{}{
+			"team":   map[string]interface{}{"target": "squad"},
+			"domain": map[string]interface{}{"target": "area"},
+		},
+	}
+	entity := api.Entity{
+		"identifier": "payments",
+		"title":      "Payments",
+		"blueprint":  "service",
+		"createdAt":  "2026-01-01T00:00:00Z",
+		"properties": map[string]interface{}{"language": "go", "tier": "1", "owner": "ops", "unset": nil},
+		"relations":  map[string]interface{}{"team": "core", "domain": "billing", "system": []interface{}{}},
+	}
+
+	moved, mapped, droppedProperties, droppedRelations := buildMovedEntity(entity, source, target, map[string]string{"language": "lang"})
+
+	want := api.Entity{
+		"identifier": "payments",
+		"title":      "Payments",
+		"properties": map[string]interface{}{"lang": "go", "tier": "1"},
+		"relations":  map[string]interface{}{"team": "core"},
+	}
+	if !reflect.DeepEqual(moved, want) {
+		t.Errorf("moved = %v, want %v", moved, want)
+	}
+	if !reflect.DeepEqual(mapped, []string{"language -> lang"}) {
+		t.Errorf("mapped = %v", mapped)
+	}
+	if !reflect.DeepEqual(droppedProperties, []string{"owner"}) {
+		t.Errorf("droppedProperties = %v", droppedProperties)
+	}
+	if !reflect.DeepEqual(droppedRelations, []string{"domain"}) {
+		t.Errorf("droppedRelations = %v", droppedRelations)
+	}
+}
+
+func TestCounterpartRelation(t *testing.T) {
+	bp := api.Blueprint{"relations": map[string]interface{}{
+		"service":  map[string]interface{}{"target": "service"},
+		"legacy":   map[string]interface{}{"target": "legacyService"},
+		"owner":    map[string]interface{}{"target": "user"},
+		"reviewer": map[string]interface{}{"target": "user"},
+	}}
+	if got := counterpartRelation(bp, "service", "legacyService"); got != "legacy" {
+		t.Errorf("counterpartRelation = %q, want legacy", got)
+	}
+	if got := counterpartRelation(bp, "legacy", "legacyService"); got != "legacy" {
+		t.Errorf("counterpartRelation = %q, want legacy", got)
+	}
+	if got := counterpartRelation(bp, "service", "user"); got != "" {
+		t.Errorf("counterpartRelation with two candidates = %q, want none", got)
+	}
+	if got := counterpartRelation(bp, "service", "team"); got != "" {
+		t.Errorf("counterpartRelation without a candidate = %q, want none", got)
+	}
+}
+
+func TestRepointReferences(t *testing.T) {
+	bp := api.Blueprint{"relations": map[string]interface{}{
+		"services": map[string]interface{}{"target": "service", "many": true},
+		"primary":  map[string]interface{}{"target": "service"},
+		"legacy":   map[string]interface{}{"target": "legacyService", "many": true},
+	}}
+	referrer := api.Entity{
+		"identifier": "checkout",
+		"relations": map[string]interface{}{
+			"services": []interface{}{"payments", "search"},
+			"primary":  "payments",
+			"legacy":   []interface{}{"billing"},
+		},
+	}
+
+	patch, changes := repointReferences(referrer, bp, "payments", "service", "legacyService")
+
+	wantPatch := map[string]interface{}{
+		"services": []interface{}{"search"},
+		"primary":  nil,
+		"legacy":   []interface{}{"billing", "payments"},
+	}
+	if !reflect.DeepEqual(patch, wantPatch) {
+		t.Errorf("patch = %v, want %v", patch, wantPatch)
+	}
+	wantChanges := []string{"primary -> legacy", "services -> legacy"}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("changes = %v, want %v", changes, wantChanges)
+	}
+
+	if patch, _ := repointReferences(referrer, bp, "orders", "service", "legacyService"); patch != nil {
+		t.Errorf("expected no patch for an unreferenced entity, got %v", patch)
+	}
+
+	noCounterpart := api.Blueprint{"relations": map[string]interface{}{
+		"primary": map[string]interface{}{"target": "service"},
+	}}
+	patch, changes = repointReferences(api.Entity{"relations": map[string]interface{}{"primary": "payments"}}, noCounterpart, "payments", "service", "legacyService")
+	if !reflect.DeepEqual(patch, map[string]interface{}{"primary": nil}) || len(changes) != 1 {
+		t.Errorf("patch = %v, changes = %v", patch, changes)
+	}
+}
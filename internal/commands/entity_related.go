@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/port-experimental/port-cli/internal/useragent"
+	"github.com/spf13/cobra"
+)
+
+// registerEntityExportRelated registers the entity export-related command.
+func registerEntityExportRelated() *cobra.Command {
+	var org, outputPath string
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "export-related [blueprint-id] [entity-id]",
+		Short: "Export an entity and the entities it relates to",
+		Long: `Export an entity and the entities it relates to, transitively.
+
+Follows the entity's relations to the related entities, then theirs, up to
+--depth hops away, and writes every entity reached together with the
+blueprints they belong to as an export bundle. Each entity is exported once,
+however many paths lead to it. The bundle can be imported with 'port import',
+for example to reproduce an issue with a minimal slice of data. The output
+format follows the file extension, as for 'port export'.`,
+		Example: `  port api entities export-related service checkout --depth 2 -o checkout.json`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputPath == "" {
+				return exitcode.Usagef("--output is required")
+			}
+			if depth < 0 {
+				return exitcode.Usagef("--depth must not be negative")
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			data, warnings, err := collectRelatedEntities(cmd.Context(), client, args[0], args[1], depth)
+			if err != nil {
+				return err
+			}
+			for _, warning := range warnings {
+				output.WarningPrintln(warning)
+			}
+			if err := export.WriteBundle(data, outputPath); err != nil {
+				return fmt.Errorf("failed to write bundle: %w", err)
+			}
+
+			output.SuccessPrint("✓ Wrote %d entit(ies) from %d blueprint(s) to %s\n", len(data.Entities), len(data.Blueprints), outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (e.g., related.json or related.tar.gz)")
+	cmd.Flags().IntVar(&depth, "depth", 1, "How many relation hops to follow from the entity (0 exports only the entity)")
+
+	return cmd
+}
+
+// collectRelatedEntities fetches the entity blueprintID/entityID and, breadth
+// first, every entity reachable through relations within depth hops, along
+// with the blueprints of the entities found. Related entities that no longer
+// exist are reported as warnings rather than failing the export.
+func collectRelatedEntities(ctx context.Context, client *api.Client, blueprintID, entityID string, depth int) (*export.Data, []string, error) {
+	root, err := client.GetEntity(ctx, blueprintID, entityID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get entity: %w", err)
+	}
+
+	data := &export.Data{
+		Blueprints: []api.Blueprint{},
+		Entities:   []api.Entity{},
+		Manifest:   export.Manifest{CLIVersion: useragent.Version(), Selection: []string{"port api entities export-related"}},
+	}
+	blueprints := make(map[string]api.Blueprint)
+	blueprint := func(id string) (api.Blueprint, error) {
+		if bp, ok := blueprints[id]; ok {
+			return bp, nil
+		}
+		bp, err := client.GetBlueprint(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blueprint %s: %w", id, err)
+		}
+		blueprints[id] = bp
+		data.Blueprints = append(data.Blueprints, bp)
+		return bp, nil
+	}
+
+	type queued struct {
+		key    import_module.ResourceKey
+		entity api.Entity
+		hops   int
+	}
+	rootKey := import_module.ResourceKey{Blueprint: blueprintID, Identifier: entityID}
+	visited := map[import_module.ResourceKey]bool{rootKey: true}
+	queue := []queued{{key: rootKey, entity: root}}
+	var warnings []string
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		data.Entities = append(data.Entities, current.entity)
+
+		bp, err := blueprint(current.key.Blueprint)
+		if err != nil {
+			return nil, nil, err
+		}
+		if current.hops == depth {
+			continue
+		}
+
+		for _, target := range relatedEntityKeys(current.entity, bp) {
+			if visited[target] {
+				continue
+			}
+			visited[target] = true
+			entity, err := client.GetEntity(ctx, target.Blueprint, target.Identifier)
+			if api.HasStatus(err, http.StatusNotFound) {
+				warnings = append(warnings, fmt.Sprintf("%s/%s relates to %s/%s, which does not exist", current.key.Blueprint, current.key.Identifier, target.Blueprint, target.Identifier))
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get entity %s/%s: %w", target.Blueprint, target.Identifier, err)
+			}
+			queue = append(queue, queued{key: target, entity: entity, hops: current.hops + 1})
+		}
+	}
+	return data, warnings, nil
+}
+
+// relatedEntityKeys returns the entities entity relates to, resolving each
+// relation's target blueprint from bp, in relation name order. Relations
+// missing from bp are skipped.
+func relatedEntityKeys(entity api.Entity, bp api.Blueprint) []import_module.ResourceKey {
+	relations := entityRelations(entity)
+	definitions := import_module.ExtractRelations(bp)
+	names := make([]string, 0, len(relations))
+	for name := range relations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var keys []import_module.ResourceKey
+	for _, name := range names {
+		definition, _ := definitions[name].(map[string]interface{})
+		target, _ := definition["target"].(string)
+		if target == "" {
+			continue
+		}
+		var ids []interface{}
+		switch v := relations[name].(type) {
+		case string:
+			ids = []interface{}{v}
+		case []interface{}:
+			ids = v
+		}
+		for _, id := range ids {
+			if s, ok := id.(string); ok && s != "" {
+				keys = append(keys, import_module.ResourceKey{Blueprint: target, Identifier: s})
+			}
+		}
+	}
+	return keys
+}
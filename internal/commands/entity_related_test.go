@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// newRelatedEntitiesServer serves a service -> system -> domain chain where
+// the domain relates back to the service, and a service relating to a team
+// that no longer exists.
+func newRelatedEntitiesServer(t *testing.T) (*api.Client, *[]string) {
+	t.Helper()
+	blueprints := map[string]map[string]interface{}{
+		"service": {"identifier": "service", "relations": map[string]interface{}{
+			"system": map[string]interface{}{"target": "system"},
+			"owners": map[string]interface{}{"target": "team", "many": true},
+		}},
+		"system": {"identifier": "system", "relations": map[string]interface{}{"domain": map[string]interface{}{"target": "domain"}}},
+		"domain": {"identifier": "domain", "relations": map[string]interface{}{"flagship": map[string]interface{}{"target": "service"}}},
+	}
+	entities := map[string]map[string]interface{}{
+		"service/checkout": {"identifier": "checkout", "blueprint": "service", "relations": map[string]interface{}{"system": "shop", "owners": []interface{}{"gone"}}},
+		"system/shop":      {"identifier": "shop", "blueprint": "system", "relations": map[string]interface{}{"domain": "commerce"}},
+		"domain/commerce":  {"identifier": "commerce", "blueprint": "domain", "relations": map[string]interface{}{"flagship": "checkout"}},
+	}
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+			return
+		}
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "blueprints" && blueprints[parts[1]] != nil:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": blueprints[parts[1]]})
+		case len(parts) == 4 && parts[2] == "entities":
+			key := parts[1] + "/" + parts[3]
+			fetched = append(fetched, key)
+			if entities[key] == nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "not_found"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entity": entities[key]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}), &fetched
+}
+
+func entityKeys(entities []api.Entity) string {
+	keys := make([]string, len(entities))
+	for i, e := range entities {
+		keys[i] = e["blueprint"].(string) + "/" + e["identifier"].(string)
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestCollectRelatedEntities_FollowsRelationsUntilCycle(t *testing.T) {
+	client, fetched := newRelatedEntitiesServer(t)
+
+	data, warnings, err := collectRelatedEntities(context.Background(), client, "service", "checkout", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := entityKeys(data.Entities); got != "service/checkout,system/shop,domain/commerce" {
+		t.Errorf("entities = %s", got)
+	}
+	if len(data.Blueprints) != 3 {
+		t.Errorf("expected the 3 blueprints of the entities, got %d", len(data.Blueprints))
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "team/gone") {
+		t.Errorf("expected a warning about the missing team, got %v", warnings)
+	}
+	if strings.Count(strings.Join(*fetched, ","), "service/checkout") != 1 {
+		t.Errorf("expected the cycle back to checkout not to refetch it, fetched %v", *fetched)
+	}
+}
+
+func TestCollectRelatedEntities_StopsAtDepth(t *testing.T) {
+	client, _ := newRelatedEntitiesServer(t)
+
+	data, _, err := collectRelatedEntities(context.Background(), client, "service", "checkout", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := entityKeys(data.Entities); got != "service/checkout,system/shop" {
+		t.Errorf("entities = %s", got)
+	}
+
+	data, warnings, err := collectRelatedEntities(context.Background(), client, "service", "checkout", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := entityKeys(data.Entities); got != "service/checkout" || len(warnings) != 0 {
+		t.Errorf("depth 0: entities = %s, warnings = %v", got, warnings)
+	}
+}
@@ -0,0 +1,16 @@
+package commands
+
+import "github.com/port-experimental/port-cli/internal/modules/import_module"
+
+// addErrorDetailsJSON adds the categorized errors of an import or migration
+// to its JSON output: one object per error under "error_details" and their
+// count per category under "counts_by_category". Nothing is added when there
+// are no errors.
+func addErrorDetailsJSON(jsonData map[string]interface{}, errs []*import_module.ImportError) {
+	details, counts := import_module.ErrorReport(errs)
+	if len(details) == 0 {
+		return
+	}
+	jsonData["error_details"] = details
+	jsonData["counts_by_category"] = counts
+}
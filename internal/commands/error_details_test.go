@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestAddErrorDetailsJSON(t *testing.T) {
+	jsonData := map[string]interface{}{}
+	addErrorDetailsJSON(jsonData, nil)
+	if _, ok := jsonData["error_details"]; ok {
+		t.Fatal("expected no error_details key without errors")
+	}
+
+	addErrorDetailsJSON(jsonData, []*import_module.ImportError{
+		import_module.CategorizeError(errors.New("connection refused"), "entity", "svc-a"),
+	})
+	details, ok := jsonData["error_details"].([]import_module.ErrorDetail)
+	if !ok || len(details) != 1 {
+		t.Fatalf("expected one error detail, got %#v", jsonData["error_details"])
+	}
+	if details[0].ResourceID != "svc-a" || details[0].Category != import_module.ErrNetwork || !details[0].Retryable {
+		t.Errorf("unexpected detail %+v", details[0])
+	}
+	counts, ok := jsonData["counts_by_category"].(map[import_module.ErrorCategory]int)
+	if !ok || counts[import_module.ErrNetwork] != 1 {
+		t.Errorf("expected one NETWORK error, got %#v", jsonData["counts_by_category"])
+	}
+}
@@ -1,6 +1,6 @@
 package commands
 
-import "fmt"
+import "github.com/port-experimental/port-cli/internal/exitcode"
 
 const (
 	defaultMaxErrors = 5
@@ -9,7 +9,7 @@ const (
 
 func validateMaxErrorsFlag(maxErrors int) error {
 	if maxErrors < hideAllErrors {
-		return fmt.Errorf("--max-errors must be -1, 0, or greater")
+		return exitcode.Usagef("--max-errors must be -1, 0, or greater")
 	}
 	return nil
 }
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/logging"
+)
+
+// OpenEventLog opens the --log-file event log in the given --log-format. It
+// returns a nil logger, which discards events, when path is empty.
+func OpenEventLog(path, format string) (*logging.Logger, error) {
+	if err := validateStringEnum("--log-format", format, logging.Formats); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+	logger, err := logging.Open(path)
+	if err != nil {
+		return nil, exitcode.Usagef("invalid value for --log-file: %v", err)
+	}
+	return logger, nil
+}
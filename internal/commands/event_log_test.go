@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+	"github.com/port-experimental/port-cli/internal/output"
+)
+
+func TestOpenEventLogValidatesFlags(t *testing.T) {
+	if _, err := OpenEventLog("", "text"); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("expected a usage error for --log-format text, got %v", err)
+	}
+	if _, err := OpenEventLog(filepath.Join(t.TempDir(), "missing", "port.log"), "json"); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("expected a usage error for an unwritable --log-file, got %v", err)
+	}
+	logger, err := OpenEventLog("", "json")
+	if err != nil || logger != nil {
+		t.Fatalf("expected no logger without --log-file, got %v, %v", logger, err)
+	}
+}
+
+func TestResourceLogCallbackLogsWithoutChangingStdout(t *testing.T) {
+	var out bytes.Buffer
+	output.SetWriters(&out, &out)
+	defer output.SetWriters(os.Stdout, os.Stderr)
+
+	path := filepath.Join(t.TempDir(), "port.log")
+	logger, err := OpenEventLog(path, "json")
+	if err != nil {
+		t.Fatalf("OpenEventLog: %v", err)
+	}
+	callback := resourceLogCallback(true, "text", logger)
+	callback(import_module.ResourceCreated, "blueprint", "service")
+	callback(import_module.ResourceSkipped, "entity", "service/api")
+	callback(import_module.ResourceFailed, "entity", "service/web")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := out.String(); !strings.Contains(got, "created blueprint service") || strings.Contains(got, "skipped") || strings.Contains(got, "failed") {
+		t.Errorf("expected only the created resource on stdout, got %q", got)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(content)), "\n"); len(lines) != 3 {
+		t.Errorf("expected 3 logged resource events, got %d: %s", len(lines), content)
+	}
+	if resourceLogCallback(false, "json", nil) != nil {
+		t.Error("expected no callback with neither verbose output nor a log file")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/port-experimental/port-cli/internal/modules/export"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/spf13/cobra"
@@ -18,16 +19,28 @@ func RegisterExport(rootCmd *cobra.Command) {
 		org                           string
 		baseOrg                       string
 		blueprints                    string
+		only                          string
+		deps                          string
 		excludeBlueprints             string
 		excludeBlueprintSchema        string
 		format                        string
 		skipEntities                  bool
+		skipEntitiesFor               string
 		skipSystemBlueprints          bool
 		skipSystemBlueprintProperties bool
 		includeRuleResults            bool
 		include                       string
+		profile                       string
 		outputFormat                  string
+		entityFilterFile              string
+		anonymize                     bool
+		sample                        int
+		dryRun                        bool
+		resume                        bool
+		sortResources                 bool
 		maxErrors                     int
+		resultFile                    string
+		retryBudget                   int
 
 		scorecards   string
 		actions      string
@@ -47,25 +60,27 @@ Exports blueprints, entities, scorecards, actions, and teams to a file.
 Use --skip-entities to only export configuration without entity data.
 Use --include to selectively export specific resource types.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+			if err := validateBundleFormatFlags(format, []string{"tar", "json", "ndjson"}, outputFormat, resultOutputFormats); err != nil {
 				return err
 			}
-			if format != "" {
-				if err := validateStringEnum("--format", format, []string{"tar", "json"}); err != nil {
-					return err
-				}
+			if sample < 0 {
+				return exitcode.Usagef("--sample must not be negative")
+			}
+			if resume && dryRun {
+				return exitcode.Usagef("--resume cannot be used with --dry-run")
 			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			// Use base-org if provided, otherwise use org
 			orgName := baseOrg
 			if orgName == "" {
 				orgName = org
 			}
+			orgName = resolveOrg(orgName)
 
-			_, baseOrgConfig, _, err := configManager.LoadWithDualOverrides(
+			cfg, baseOrgConfig, _, err := configManager.LoadWithDualOverrides(
 				flags.ClientID,
 				flags.ClientSecret,
 				flags.APIURL,
@@ -73,15 +88,24 @@ Use --include to selectively export specific resource types.`,
 				"", "", "", "", // No target org for export
 			)
 			if err != nil {
-				return fmt.Errorf("failed to load configuration: %w", err)
+				return exitcode.Usagef("failed to load configuration: %w", err)
 			}
 
 			if baseOrgConfig == nil {
-				return fmt.Errorf("base organization configuration not found")
+				return exitcode.Usagef("base organization configuration not found")
+			}
+			if err := applyResourceProfile(cmd, cfg, profile, &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err != nil {
+				return err
 			}
 			if err := validateMaxErrorsFlag(maxErrors); err != nil {
 				return err
 			}
+			if err := validateResultFileFlag(resultFile, outputFormat); err != nil {
+				return err
+			}
+			if err := validateRetryBudgetFlag(retryBudget); err != nil {
+				return err
+			}
 
 			orgConfig := baseOrgConfig
 
@@ -94,6 +118,26 @@ Use --include to selectively export specific resource types.`,
 				}
 			}
 
+			// Parse --only identifier globs
+			var onlyPatterns []string
+			for _, pattern := range strings.Split(only, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					onlyPatterns = append(onlyPatterns, pattern)
+				}
+			}
+			if err := export.ValidateBlueprintPatterns(onlyPatterns); err != nil {
+				return exitcode.Usagef("invalid --only: %w", err)
+			}
+			depsMode, err := export.ParseDependencyMode(deps)
+			if err != nil {
+				return exitcode.Usagef("invalid --deps: %w", err)
+			}
+
+			skipEntitiesForList, err := parseSkipEntitiesFor(skipEntitiesFor)
+			if err != nil {
+				return err
+			}
+
 			// Parse exclude-blueprints (deep)
 			var excludeBlueprintList []string
 			if excludeBlueprints != "" {
@@ -133,11 +177,14 @@ Use --include to selectively export specific resource types.`,
 
 			// Parse include list
 			var includeList []string
+			var includePatterns map[string][]string
 			if include != "" {
-				includeList = strings.Split(include, ",")
-				for i := range includeList {
-					includeList[i] = strings.TrimSpace(includeList[i])
+				spec, err := export.ParseIncludeSpec(include)
+				if err != nil {
+					return exitcode.Usagef("invalid --include: %w", err)
 				}
+				includeList = spec.Resources
+				includePatterns = spec.Patterns
 
 				// Validate resource types
 				validResources := map[string]bool{
@@ -150,6 +197,7 @@ Use --include to selectively export specific resource types.`,
 					"automations":           true,
 					"pages":                 true,
 					"integrations":          true,
+					"datasources":           true,
 					"blueprint-permissions": true,
 					"action-permissions":    true,
 					"page-permissions":      true,
@@ -157,7 +205,7 @@ Use --include to selectively export specific resource types.`,
 
 				for _, r := range includeList {
 					if !validResources[r] {
-						return fmt.Errorf("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, blueprint-permissions, action-permissions, page-permissions", r)
+						return exitcode.Usagef("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, blueprint-permissions, action-permissions, page-permissions, permissions", r)
 					}
 				}
 
@@ -165,7 +213,7 @@ Use --include to selectively export specific resource types.`,
 				hasPagePerms := slices.Contains(includeList, "page-permissions")
 				hasPages := slices.Contains(includeList, "pages")
 				if hasPagePerms && !hasPages {
-					return fmt.Errorf("page-permissions requires pages to also be included (add 'pages' to --include)")
+					return exitcode.Usagef("page-permissions requires pages to also be included (add 'pages' to --include)")
 				}
 
 				// Handle conflict between skip_entities and include
@@ -195,9 +243,9 @@ Use --include to selectively export specific resource types.`,
 			}
 
 			// True when the caller explicitly wants blueprint schemas, either via
-			// --blueprints or --include blueprints — as opposed to blueprints only
+			// --blueprints, --only or --include blueprints — as opposed to blueprints only
 			// being pulled in as a byproduct of --actions/--scorecards/--entities.
-			blueprintsExplicitlyRequested := cmd.Flags().Changed("blueprints") || slices.Contains(includeList, "blueprints")
+			blueprintsExplicitlyRequested := cmd.Flags().Changed("blueprints") || len(onlyPatterns) > 0 || slices.Contains(includeList, "blueprints")
 
 			// Auto-include resource types when per-resource flags are explicitly set
 			// (with or without specific IDs — Changed() detects explicit flag usage)
@@ -242,6 +290,14 @@ Use --include to selectively export specific resource types.`,
 			}
 			autoScopeBlueprints := needBlueprints && !blueprintsExplicitlyRequested
 
+			var entityFilters map[string]map[string]interface{}
+			if entityFilterFile != "" {
+				entityFilters, err = export.LoadEntityFilters(entityFilterFile)
+				if err != nil {
+					return exitcode.New(exitcode.Usage, err)
+				}
+			}
+
 			token, err := configManager.GetOrRefreshToken(cmd.Context(), orgName)
 			if err != nil {
 				if !config.ShouldIgnoreGetOrRefreshTokenError(err) {
@@ -253,7 +309,7 @@ Use --include to selectively export specific resource types.`,
 			defer exportModule.Close()
 
 			// Show info only if not quiet and output format is text
-			if outputFormat != "json" {
+			if !structuredOutput(outputFormat) {
 				output.Printf("\nExporting data from base organization: %s\n", orgName)
 				if orgName == "" {
 					output.Printf("(using default organization)\n")
@@ -262,6 +318,9 @@ Use --include to selectively export specific resource types.`,
 				if len(blueprintList) > 0 {
 					output.Printf("Blueprints filter: %s\n", strings.Join(blueprintList, ", "))
 				}
+				if len(onlyPatterns) > 0 {
+					output.Printf("Blueprint patterns: %s\n", strings.Join(onlyPatterns, ", "))
+				}
 				if len(entityList) > 0 {
 					output.Printf("Entities filter: %s\n", strings.Join(entityList, ", "))
 				}
@@ -283,25 +342,43 @@ Use --include to selectively export specific resource types.`,
 				if len(userList) > 0 {
 					output.Printf("Users filter: %s\n", strings.Join(userList, ", "))
 				}
+				if entityFilterFile != "" {
+					output.Printf("Entity filter: %s (%d blueprint(s))\n", entityFilterFile, len(entityFilters))
+				}
+				if anonymize {
+					output.Printf("Anonymizing entity data; teams and users will not be exported\n")
+				}
+				if sample > 0 {
+					output.Printf("Sampling at most %d entities per blueprint\n", sample)
+				}
 				if len(includeList) > 0 {
 					output.Printf("Including only: %s\n", strings.Join(includeList, ", "))
 				} else if skipEntities {
 					output.Printf("Skipping entities (schema only)\n")
 				}
+				if len(skipEntitiesForList) > 0 {
+					output.Printf("Skipping entities of: %s\n", strings.Join(skipEntitiesForList, ", "))
+				}
 			}
 
 			// Execute export
-			result, err := exportModule.Execute(cmd.Context(), export.Options{
+			ctx, stopRetryBudget := withRetryBudget(cmd.Context(), retryBudget, flags.Debug)
+			defer stopRetryBudget()
+			result, err := exportModule.Execute(ctx, export.Options{
 				OutputPath:                    outputPath,
 				Blueprints:                    blueprintList,
+				BlueprintPatterns:             onlyPatterns,
+				Dependencies:                  depsMode,
 				ExcludeBlueprints:             excludeBlueprintList,
 				ExcludeBlueprintSchema:        excludeBlueprintSchemaList,
 				Format:                        format,
 				SkipEntities:                  skipEntities,
+				SkipEntitiesFor:               skipEntitiesForList,
 				SkipSystemBlueprints:          skipSystemBlueprints,
 				SkipSystemBlueprintProperties: skipSystemBlueprintProperties,
 				IncludeRuleResults:            includeRuleResults,
 				IncludeResources:              includeList,
+				IncludePatterns:               includePatterns,
 				AutoScopeBlueprints:           autoScopeBlueprints,
 				Entities:                      entityList,
 				Scorecards:                    scorecardList,
@@ -310,33 +387,39 @@ Use --include to selectively export specific resource types.`,
 				Integrations:                  integrationList,
 				Teams:                         teamList,
 				Users:                         userList,
+				EntityFilters:                 entityFilters,
+				Anonymize:                     anonymize,
+				Sample:                        sample,
+				DryRun:                        dryRun,
+				Resume:                        resume,
+				Sort:                          sortResources,
 			})
 			if err != nil {
-				if outputFormat == "json" {
+				if structuredOutput(outputFormat) {
 					jsonResult := output.JSONResult{
 						Success: false,
 						Error:   err.Error(),
 					}
-					output.PrintJSON(jsonResult)
-					return err
+					printResult(resultFile, outputFormat, jsonResult)
+					return retryBudgetExit(ctx, err)
 				}
-				return fmt.Errorf("export failed: %w", err)
+				return retryBudgetExit(ctx, fmt.Errorf("export failed: %w", err))
 			}
 
 			if !result.Success {
-				if outputFormat == "json" {
+				if structuredOutput(outputFormat) {
 					jsonResult := output.JSONResult{
 						Success: false,
 						Error:   fmt.Sprintf("%v", result.Error),
 					}
-					output.PrintJSON(jsonResult)
-					return fmt.Errorf("export failed: %v", result.Error)
+					printResult(resultFile, outputFormat, jsonResult)
+					return retryBudgetExit(ctx, fmt.Errorf("export failed: %w", result.Error))
 				}
-				return fmt.Errorf("export failed: %v", result.Error)
+				return retryBudgetExit(ctx, fmt.Errorf("export failed: %w", result.Error))
 			}
 
 			// Output in JSON format if requested
-			if outputFormat == "json" {
+			if structuredOutput(outputFormat) {
 				jsonData := exportJSONSummary(result, exportJSONSummaryOptions{
 					SkipEntities:             skipEntities,
 					IncludedResources:        includeList,
@@ -352,19 +435,40 @@ Use --include to selectively export specific resource types.`,
 					Message: result.Message,
 					Data:    jsonData,
 				}
-				return output.PrintJSON(jsonResult)
+				if err := printResult(resultFile, outputFormat, jsonResult); err != nil {
+					return err
+				}
+				return timeoutExitError(result)
 			}
 
 			// Text output
-			output.SuccessPrintln("\n✓ Export completed successfully!")
-			output.Printf("%s\n", result.Message)
+			if result.DryRun {
+				output.Printf("\n%s (%s); nothing was written\n", result.Message, result.Format)
+			} else {
+				output.SuccessPrintln("\n✓ Export completed successfully!")
+				output.Printf("%s\n", result.Message)
+			}
 			output.Printf("Blueprints: %d\n", result.BlueprintsCount)
-			output.Printf("Entities: %d\n", result.EntitiesCount)
+			if result.EntitiesApproximate {
+				output.Printf("Entities: at most %d (entity filters are not applied to counts)\n", result.EntitiesCount)
+			} else {
+				output.Printf("Entities: %d\n", result.EntitiesCount)
+			}
 			output.Printf("Actions: %d\n", result.ActionsCount)
 			output.Printf("Users: %d\n", result.UsersCount)
 			output.Printf("Teams: %d\n", result.TeamsCount)
 			output.Printf("Pages: %d\n", result.PagesCount)
 			output.Printf("Integrations: %d\n", result.IntegrationsCount)
+			output.Printf("Data sources: %d\n", result.DataSourcesCount)
+			if result.Anonymized {
+				output.Printf("Anonymized: entity data replaced with placeholders\n")
+			}
+			if result.Sample > 0 {
+				output.Printf("Sample: at most %d entities per blueprint; not a complete backup\n", result.Sample)
+			}
+			if result.ResumedBlueprints > 0 {
+				output.Printf("Resumed: entities of %d blueprint(s) taken from the interrupted export\n", result.ResumedBlueprints)
+			}
 
 			// Display timeout warnings if any
 			if len(result.TimeoutErrors) > 0 && shouldPrintErrors(len(result.TimeoutErrors), maxErrors) {
@@ -379,7 +483,7 @@ Use --include to selectively export specific resource types.`,
 				output.WarningPrintln("These blueprints were skipped. Consider exporting them separately or contact Port support if this persists.")
 			}
 
-			return nil
+			return timeoutExitError(result)
 		},
 	}
 
@@ -388,16 +492,29 @@ Use --include to selectively export specific resource types.`,
 	exportCmd.Flags().StringVar(&org, "org", "", "Base organization name (uses default if not specified, deprecated: use --base-org)")
 	exportCmd.Flags().StringVar(&baseOrg, "base-org", "", "Base organization name (uses default if not specified)")
 	exportCmd.Flags().StringVarP(&blueprints, "blueprints", "b", "", "Comma-Separated list of blueprint IDs to export (restricts export to blueprints resource type; exports all blueprints if flag set without IDs; pass this flag explicitly to export the full blueprint set even when combined with --actions/--scorecards/--entities)")
+	exportCmd.Flags().StringVar(&only, "only", "", "Comma-separated blueprint identifier globs (e.g. 'team-*'); exports matching blueprints, the blueprints their relations target (see --deps), and their resources. Combines with --blueprints")
+	exportCmd.Flags().StringVar(&deps, "deps", string(export.DepsRequired), "Relation targets exported along with --only matches: required (targets of required relations), all, or none")
 	exportCmd.Flags().StringVar(&excludeBlueprints, "exclude-blueprints", "", "Comma-separated blueprint IDs to exclude entirely (schema + entities + scorecards + actions)")
 	exportCmd.Flags().StringVar(&excludeBlueprintSchema, "exclude-blueprint-schema", "", "Comma-separated blueprint IDs to exclude schema only (entities, scorecards, actions still exported)")
-	exportCmd.Flags().StringVarP(&format, "format", "f", "", "Export format: tar (tar.gz) or json")
+	exportCmd.Flags().StringVarP(&format, "format", "f", "", "Export format: tar (tar.gz), json, or ndjson (one resource per line); --export-format is an alias")
 	exportCmd.Flags().BoolVar(&skipEntities, "skip-entities", false, "Skip exporting entities (only export schema and configuration)")
+	exportCmd.Flags().StringVar(&skipEntitiesFor, "skip-entities-for", "", skipEntitiesForUsage)
 	exportCmd.Flags().BoolVar(&skipSystemBlueprints, "skip-system-blueprints", false, "Skip system blueprint schemas (identifiers starting with _) and their entities")
 	exportCmd.Flags().BoolVar(&skipSystemBlueprintProperties, "skip-system-blueprint-properties", false, "When used with --skip-system-blueprints, do not export custom properties on known system blueprints")
 	exportCmd.Flags().BoolVar(&includeRuleResults, "include-rule-results", true, "Include _rule_result system blueprint entities (use --include-rule-results=false to exclude)")
-	exportCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to export (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations. If not specified, exports all resources.")
-	exportCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+	exportCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to export (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, permissions. Add ':glob' to a type to keep only matching identifiers (e.g., 'blueprints,scorecards:team-*'). If not specified, exports all resources.")
+	exportCmd.Flags().StringVar(&profile, "profile", "", "Resource profile from the config file supplying --include, --exclude-blueprints, --exclude-blueprint-schema and --skip-entities; flags on the command line override it")
+	exportCmd.Flags().StringVar(&outputFormat, "output-format", "text", "What the command prints: text, json or yaml (for the bundle format, see --format)")
+	exportCmd.Flags().SetNormalizeFunc(exportFormatAlias)
+	exportCmd.Flags().StringVar(&resultFile, "result-file", "", "Write the JSON result to this file instead of stdout (requires --output-format json)")
+	exportCmd.Flags().StringVar(&entityFilterFile, "entity-filter", "", "YAML/JSON file mapping blueprint IDs to Port search rules; only matching entities of those blueprints are exported")
+	exportCmd.Flags().BoolVar(&anonymize, "anonymize", false, "Replace entity identifiers, titles and property values with placeholders and drop teams, users and secrets, for sharing the export publicly")
+	exportCmd.Flags().IntVar(&sample, "sample", 0, "Export at most N entities per blueprint, for building test fixtures; blueprints and other resources are exported in full. The bundle is marked as a sample")
+	exportCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be exported (counts per resource type and the destination) without writing it; entities are counted, not fetched")
+	exportCmd.Flags().BoolVar(&resume, "resume", false, "Continue an interrupted export to the same output path, reusing the blueprints whose entities it already fetched")
+	exportCmd.Flags().BoolVar(&sortResources, "sort", false, "Order resources by identifier so exports diff cleanly; by default the order the API returns is kept")
 	exportCmd.Flags().IntVar(&maxErrors, "max-errors", defaultMaxErrors, "Maximum number of errors to show in text output (-1 hides errors, 0 shows all)")
+	exportCmd.Flags().IntVar(&retryBudget, "retry-budget", 0, retryBudgetUsage)
 
 	exportCmd.Flags().StringVar(&scorecards, "scorecards", "", "Comma-Separated scorecard IDs to export (restricts export to scorecards resource type; blueprint schemas exported alongside are scoped to only the blueprints the selected scorecards belong to — use --blueprints to export the full set instead)")
 	exportCmd.Flags().StringVar(&actions, "actions", "", "Comma-Separated action IDs to export (restricts export to actions resource type; exports all actions if flag set without IDs; blueprint schemas exported alongside are scoped to only the blueprints the selected actions belong to — use --blueprints to export the full set instead)")
@@ -409,3 +526,12 @@ Use --include to selectively export specific resource types.`,
 
 	rootCmd.AddCommand(exportCmd)
 }
+
+// timeoutExitError reports an export that was written with some blueprints
+// skipped after timing out, so scripts can tell it apart from a full export.
+func timeoutExitError(result *export.Result) error {
+	if len(result.TimeoutErrors) == 0 {
+		return nil
+	}
+	return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("export completed with %d blueprint(s) skipped after timing out", len(result.TimeoutErrors)))
+}
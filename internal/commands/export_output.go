@@ -10,7 +10,7 @@ type exportJSONSummaryOptions struct {
 }
 
 func exportJSONSummary(result *exportmodule.Result, opts exportJSONSummaryOptions) map[string]interface{} {
-	return map[string]interface{}{
+	summary := map[string]interface{}{
 		"output_path":          result.OutputPath,
 		"format":               result.Format,
 		"blueprints_count":     result.BlueprintsCount,
@@ -21,9 +21,20 @@ func exportJSONSummary(result *exportmodule.Result, opts exportJSONSummaryOption
 		"folders_count":        result.FoldersCount,
 		"pages_count":          result.PagesCount,
 		"integrations_count":   result.IntegrationsCount,
+		"datasources_count":    result.DataSourcesCount,
 		"skipped_entities":     opts.SkipEntities,
 		"included_resources":   opts.IncludedResources,
 		"excluded_blueprints":  opts.ExcludedBlueprints,
 		"schema_only_excluded": opts.SchemaExcludedBlueprints,
+		"anonymized":           result.Anonymized,
+		"sample":               result.Sample,
+		"dry_run":              result.DryRun,
 	}
+	if result.DryRun {
+		summary["entities_count_approximate"] = result.EntitiesApproximate
+	}
+	if result.ResumedBlueprints > 0 {
+		summary["resumed_blueprints"] = result.ResumedBlueprints
+	}
+	return summary
 }
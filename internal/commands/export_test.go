@@ -86,6 +86,7 @@ func TestExportJSONSummaryIncludesP1Fields(t *testing.T) {
 		"pages_count":        7,
 		"integrations_count": 8,
 		"skipped_entities":   true,
+		"dry_run":            false,
 	}
 	for key, want := range checks {
 		if got := data[key]; got != want {
@@ -94,6 +95,26 @@ func TestExportJSONSummaryIncludesP1Fields(t *testing.T) {
 	}
 }
 
+func TestExportJSONSummaryDryRun(t *testing.T) {
+	data := exportJSONSummary(&exportmodule.Result{DryRun: true, EntitiesCount: 120, EntitiesApproximate: true}, exportJSONSummaryOptions{})
+	if data["dry_run"] != true || data["entities_count"] != 120 || data["entities_count_approximate"] != true {
+		t.Fatalf("unexpected dry-run summary %v", data)
+	}
+	if _, ok := exportJSONSummary(&exportmodule.Result{}, exportJSONSummaryOptions{})["entities_count_approximate"]; ok {
+		t.Fatal("entities_count_approximate should only be reported on dry runs")
+	}
+}
+
+func TestExportJSONSummaryResumed(t *testing.T) {
+	data := exportJSONSummary(&exportmodule.Result{EntitiesCount: 40, ResumedBlueprints: 3}, exportJSONSummaryOptions{})
+	if data["resumed_blueprints"] != 3 {
+		t.Fatalf("unexpected resumed summary %v", data)
+	}
+	if _, ok := exportJSONSummary(&exportmodule.Result{}, exportJSONSummaryOptions{})["resumed_blueprints"]; ok {
+		t.Fatal("resumed_blueprints should only be reported when blueprints were resumed")
+	}
+}
+
 func TestExportMaxErrorsFlagParsed(t *testing.T) {
 	rootCmd := &cobra.Command{Use: "port"}
 	RegisterExport(rootCmd)
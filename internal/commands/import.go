@@ -1,11 +1,17 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/envexpand"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/logging"
+	"github.com/port-experimental/port-cli/internal/modules/compare"
+	"github.com/port-experimental/port-cli/internal/modules/export"
 	"github.com/port-experimental/port-cli/internal/modules/import_module"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/spf13/cobra"
@@ -19,17 +25,36 @@ func RegisterImport(rootCmd *cobra.Command) {
 		targetOrg                     string
 		dryRun                        bool
 		skipEntities                  bool
+		skipEntitiesFor               string
 		skipSystemBlueprints          bool
 		skipSystemBlueprintProperties bool
+		includeSystemBlueprints       bool
 		includeRuleResults            bool
 		include                       string
+		profile                       string
 		outputFormat                  string
 		verbose                       bool
 		showPagesPipeline             bool
 		excludeBlueprints             string
 		excludeBlueprintSchema        string
 		usersAsDisabled               bool
+		createIntegrations            bool
+		allowBreaking                 bool
+		prune                         bool
+		replaceAll                    bool
+		confirm                       bool
+		onConflict                    string
+		forceUpdate                   bool
+		strictRelations               bool
+		continueFrom                  string
+		showDiff                      bool
+		transformFile                 string
+		actionURLMapFlags             []string
 		maxErrors                     int
+		resultFile                    string
+		retryBudget                   int
+		reportFile                    string
+		expandEnv                     string
 	)
 
 	importCmd := &cobra.Command{
@@ -39,32 +64,59 @@ func RegisterImport(rootCmd *cobra.Command) {
 
 Imports blueprints, entities, scorecards, actions, teams, automations, pages, and integrations from a file.
 Use --skip-entities to only import configuration without entity data.
-Use --include to selectively import specific resource types.`,
+Use --include to selectively import specific resource types.
+Use --continue-from to resume from a resource type, skipping the types before it.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			// --expand-env has an optional value, so "--expand-env strict"
+			// leaves "strict" as an argument instead of the flag's value.
+			if len(args) > 0 && cmd.Flags().Changed("expand-env") && slices.Contains(envexpand.Modes, args[0]) {
+				return exitcode.Usagef("unexpected argument %q: pass the mode as --expand-env=%s", args[0], args[0])
+			}
+			if err := cobra.NoArgs(cmd, args); err != nil {
+				return exitcode.New(exitcode.Usage, err)
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+			if err := validateStringEnum("--output-format", outputFormat, resultOutputFormats); err != nil {
+				return err
+			}
+			if err := validateStringEnum("--on-conflict", onConflict, import_module.ConflictStrategies); err != nil {
 				return err
 			}
+			if expandEnv != "" {
+				if err := validateStringEnum("--expand-env", expandEnv, envexpand.Modes); err != nil {
+					return err
+				}
+			}
+			if forceUpdate && onConflict != string(import_module.ConflictUpdate) {
+				return exitcode.Usagef("--force-update cannot be used with --on-conflict %s", onConflict)
+			}
+			if includeSystemBlueprints && skipSystemBlueprints {
+				return exitcode.Usagef("--include-system-blueprints cannot be used with --skip-system-blueprints")
+			}
+			if showDiff && !dryRun {
+				return exitcode.Usagef("--show-diff requires --dry-run")
+			}
+			if reportFile != "" {
+				if err := compare.ValidateReportPath(reportFile); err != nil {
+					return exitcode.Usagef("invalid --report: %w", err)
+				}
+			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			// Use target-org if provided, otherwise use org
 			orgName := targetOrg
 			if orgName == "" {
 				orgName = org
 			}
+			orgName = resolveOrg(orgName)
 
-			// Use target org flags if provided, otherwise fall back to base flags
-			targetClientID := flags.TargetClientID
-			targetClientSecret := flags.TargetClientSecret
-			targetAPIURL := flags.TargetAPIURL
-			if targetClientID == "" {
-				targetClientID = flags.ClientID
-				targetClientSecret = flags.ClientSecret
-				targetAPIURL = flags.APIURL
-			}
+			targetClientID, targetClientSecret, targetAPIURL := importCredentials(flags)
 
-			_, _, targetOrgConfig, err := configManager.LoadWithDualOverrides(
+			cfg, _, targetOrgConfig, err := configManager.LoadWithDualOverrides(
 				"", "", "", "", // No base org for import
 				targetClientID,
 				targetClientSecret,
@@ -72,25 +124,37 @@ Use --include to selectively import specific resource types.`,
 				orgName,
 			)
 			if err != nil {
-				return fmt.Errorf("failed to load configuration: %w", err)
+				return exitcode.Usagef("failed to load configuration: %w", err)
 			}
 
 			if targetOrgConfig == nil {
-				return fmt.Errorf("target organization configuration not found")
+				return exitcode.Usagef("target organization configuration not found")
+			}
+			if err := applyResourceProfile(cmd, cfg, profile, &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err != nil {
+				return err
 			}
 			if err := validateMaxErrorsFlag(maxErrors); err != nil {
 				return err
 			}
+			if err := validateResultFileFlag(resultFile, outputFormat); err != nil {
+				return err
+			}
+			if err := validateRetryBudgetFlag(retryBudget); err != nil {
+				return err
+			}
 
 			orgConfig := targetOrgConfig
 
 			// Parse include list
 			var includeList []string
+			var includePatterns map[string][]string
 			if include != "" {
-				includeList = strings.Split(include, ",")
-				for i := range includeList {
-					includeList[i] = strings.TrimSpace(includeList[i])
+				spec, err := export.ParseIncludeSpec(include)
+				if err != nil {
+					return exitcode.Usagef("invalid --include: %w", err)
 				}
+				includeList = spec.Resources
+				includePatterns = spec.Patterns
 
 				// Validate resource types
 				validResources := map[string]bool{
@@ -103,6 +167,7 @@ Use --include to selectively import specific resource types.`,
 					"automations":           true,
 					"pages":                 true,
 					"integrations":          true,
+					"datasources":           true,
 					"blueprint-permissions": true,
 					"action-permissions":    true,
 					"page-permissions":      true,
@@ -110,12 +175,12 @@ Use --include to selectively import specific resource types.`,
 
 				for _, r := range includeList {
 					if !validResources[r] {
-						return fmt.Errorf("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, blueprint-permissions, action-permissions, page-permissions", r)
+						return exitcode.Usagef("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, blueprint-permissions, action-permissions, page-permissions, permissions", r)
 					}
 				}
 
 				if slices.Contains(includeList, "page-permissions") && !slices.Contains(includeList, "pages") {
-					return fmt.Errorf("page-permissions requires pages to also be included (add 'pages' to --include)")
+					return exitcode.Usagef("page-permissions requires pages to also be included (add 'pages' to --include)")
 				}
 
 				// Handle conflict between skip_entities and include
@@ -144,6 +209,20 @@ Use --include to selectively import specific resource types.`,
 				}
 			}
 
+			// Resume from a resource type: drop every type before it in the
+			// import order, keeping to --include when it is set
+			if continueFrom != "" {
+				includeList, err = import_module.ContinueFrom(continueFrom, includeList)
+				if err != nil {
+					return exitcode.Usagef("invalid --continue-from: %v", err)
+				}
+			}
+
+			skipEntitiesForList, err := parseSkipEntitiesFor(skipEntitiesFor)
+			if err != nil {
+				return err
+			}
+
 			// Parse exclude-blueprints (deep)
 			var excludeBlueprintList []string
 			if excludeBlueprints != "" {
@@ -162,6 +241,18 @@ Use --include to selectively import specific resource types.`,
 				}
 			}
 
+			var transforms []import_module.TransformRule
+			if transformFile != "" {
+				transforms, err = import_module.LoadTransforms(transformFile)
+				if err != nil {
+					return exitcode.New(exitcode.Usage, err)
+				}
+			}
+			actionURLMap, err := import_module.ParseActionURLMap(actionURLMapFlags)
+			if err != nil {
+				return exitcode.Usagef("--action-url-map: %v", err)
+			}
+
 			token, err := configManager.GetOrRefreshToken(cmd.Context(), orgName)
 			if err != nil {
 				if !config.ShouldIgnoreGetOrRefreshTokenError(err) {
@@ -173,7 +264,7 @@ Use --include to selectively import specific resource types.`,
 			defer importModule.Close()
 
 			// Show info only if not quiet and output format is text
-			if outputFormat != "json" {
+			if !structuredOutput(outputFormat) {
 				output.Printf("\nImporting data to target organization: %s\n", orgName)
 				if orgName == "" {
 					output.Printf("(using default organization)\n")
@@ -188,12 +279,15 @@ Use --include to selectively import specific resource types.`,
 				} else if skipEntities {
 					output.Printf("Skipping entities (schema only)\n")
 				}
+				if len(skipEntitiesForList) > 0 {
+					output.Printf("Skipping entities of: %s\n", strings.Join(skipEntitiesForList, ", "))
+				}
 			}
 
 			// Progress callback for real-time updates
 			var progressCallback import_module.ProgressCallback
 			var logCallback func(string)
-			if outputFormat != "json" {
+			if !structuredOutput(outputFormat) {
 				lastPhase := ""
 				progressCallback = func(phase string, current, total int) {
 					if phase != lastPhase {
@@ -211,43 +305,90 @@ Use --include to selectively import specific resource types.`,
 				}
 			}
 
+			var confirmApply import_module.ApplyConfirmer
+			if !dryRun {
+				confirmApply = applyConfirmer(cmd, confirm, describeTargetOrg(orgName))
+			}
+			// The change preview lists --replace-all's deletions too
+			var confirmDeletions import_module.DeletionConfirmer
+			if replaceAll && !dryRun && !ShouldSkipConfirm(cmd, false) && confirmApply == nil {
+				confirmDeletions = replaceAllConfirmer(describeTargetOrg(orgName))
+			}
+
 			// Execute import
-			result, err := importModule.Execute(cmd.Context(), import_module.Options{
+			ctx, stopRetryBudget := withRetryBudget(cmd.Context(), retryBudget, flags.Debug)
+			defer stopRetryBudget()
+			result, err := importModule.Execute(ctx, import_module.Options{
 				InputPath:                     input,
 				DryRun:                        dryRun,
 				SkipEntities:                  skipEntities,
+				SkipEntitiesFor:               skipEntitiesForList,
+				RecordEntityChanges:           reportFile != "" || showDiff,
 				SkipSystemBlueprints:          skipSystemBlueprints,
 				SkipSystemBlueprintProperties: skipSystemBlueprintProperties,
+				IncludeSystemBlueprints:       includeSystemBlueprints,
 				IncludeRuleResults:            includeRuleResults,
 				IncludeResources:              includeList,
+				IncludePatterns:               includePatterns,
 				ExcludeBlueprints:             excludeBlueprintList,
 				ExcludeBlueprintSchema:        excludeBlueprintSchemaList,
 				UsersAsDisabled:               usersAsDisabled,
+				CreateIntegrations:            createIntegrations,
+				AllowBreaking:                 allowBreaking,
+				Prune:                         prune,
+				ReplaceAll:                    replaceAll,
+				ConfirmDeletions:              confirmDeletions,
+				ConfirmApply:                  confirmApply,
+				OnConflict:                    import_module.ConflictStrategy(onConflict),
+				ForceUpdate:                   forceUpdate,
+				StrictRelations:               strictRelations,
+				Transforms:                    transforms,
+				ActionURLMap:                  actionURLMap,
+				ExpandEnv:                     envexpand.Mode(expandEnv),
 				Verbose:                       verbose,
 				ShowPagesPipeline:             showPagesPipeline,
 				ProgressCallback:              progressCallback,
+				ResourceCallback:              resourceLogCallback(verbose || flags.Verbose, outputFormat, logging.FromContext(cmd.Context())),
 				LogCallback:                   logCallback,
 			})
 
 			// Clear progress line
-			if outputFormat != "json" && progressCallback != nil {
+			if !structuredOutput(outputFormat) && progressCallback != nil {
 				output.Printf("\n")
 			}
 
+			if errors.Is(err, import_module.ErrDeletionsDeclined) || errors.Is(err, import_module.ErrApplyDeclined) {
+				cmd.Println("Operation cancelled")
+				return nil
+			}
 			if err != nil {
-				if outputFormat == "json" {
+				code := exitcode.Failure
+				if result != nil {
+					code = exitcode.Stopped(result.Applied())
+				}
+				if structuredOutput(outputFormat) {
 					jsonResult := output.JSONResult{
 						Success: false,
 						Error:   err.Error(),
 					}
-					output.PrintJSON(jsonResult)
-					return err
+					if result != nil {
+						jsonResult.Data = importPartialJSON(result)
+					}
+					printResult(resultFile, outputFormat, jsonResult)
+					return retryBudgetExit(ctx, exitcode.New(code, err))
+				}
+				if result != nil {
+					printImportPartialResult(result)
 				}
-				return fmt.Errorf("import failed: %w", err)
+				return retryBudgetExit(ctx, exitcode.New(code, fmt.Errorf("import failed: %w", err)))
+			}
+
+			if reportFile != "" {
+				writeChangeReport(reportFile, result.DiffResult, describeTargetOrg(orgName), input, outputFormat)
 			}
 
 			// Output in JSON format if requested
-			if outputFormat == "json" {
+			if structuredOutput(outputFormat) {
 				jsonData := map[string]interface{}{
 					"success":                       result.Success,
 					"message":                       result.Message,
@@ -261,18 +402,31 @@ Use --include to selectively import specific resource types.`,
 					"actions_updated":               result.ActionsUpdated,
 					"teams_created":                 result.TeamsCreated,
 					"teams_updated":                 result.TeamsUpdated,
+					"team_members_added":            result.TeamMembersAdded,
+					"team_members_removed":          result.TeamMembersRemoved,
 					"users_created":                 result.UsersCreated,
 					"users_updated":                 result.UsersUpdated,
 					"pages_created":                 result.PagesCreated,
 					"pages_updated":                 result.PagesUpdated,
+					"integrations_created":          result.IntegrationsCreated,
 					"integrations_updated":          result.IntegrationsUpdated,
+					"datasources_created":           result.DataSourcesCreated,
+					"datasources_updated":           result.DataSourcesUpdated,
 					"blueprint_permissions_updated": result.BlueprintPermissionsUpdated,
 					"action_permissions_updated":    result.ActionPermissionsUpdated,
 					"page_permissions_updated":      result.PagePermissionsUpdated,
+					"resources_deleted":             result.ResourcesDeleted,
+					"resources_skipped":             result.ResourcesSkipped,
 				}
 				if len(result.Errors) > 0 {
 					jsonData["errors"] = result.Errors
 				}
+				addErrorDetailsJSON(jsonData, result.CategorizedErrors)
+				for _, warning := range result.Warnings {
+					if warning.Type == "breaking_change" {
+						jsonData["breaking_changes"] = warning.Details
+					}
+				}
 				if result.IgnoredRuleResultTargetRelationCount > 0 {
 					jsonData["ignored_rule_result_target_relations_count"] = result.IgnoredRuleResultTargetRelationCount
 					jsonData["ignored_rule_result_target_relation_keys"] = result.IgnoredRuleResultTargetRelationKeys
@@ -280,9 +434,14 @@ Use --include to selectively import specific resource types.`,
 				if showPagesPipeline && len(result.SidebarPipeline) > 0 {
 					jsonData["sidebar_pipeline"] = result.SidebarPipeline
 				}
-				output.PrintJSON(jsonData)
+				if showDiff {
+					jsonData["field_changes"] = compare.UpdatePreviewsJSON(compare.PreviewUpdates(result.DiffResult))
+				}
+				if err := printResult(resultFile, outputFormat, jsonData); err != nil {
+					return err
+				}
 				if !result.Success {
-					return fmt.Errorf("import completed with errors")
+					return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("import completed with errors"))
 				}
 				return nil
 			}
@@ -333,6 +492,10 @@ Use --include to selectively import specific resource types.`,
 						len(result.DiffResult.TeamsToUpdate),
 						len(result.DiffResult.TeamsToSkip))
 				}
+				if len(result.DiffResult.TeamMemberships) > 0 {
+					added, removed := import_module.CountTeamMembershipChanges(result.DiffResult.TeamMemberships)
+					output.Printf("  Team members: %d to add, %d to remove across %d team(s)\n", added, removed, len(result.DiffResult.TeamMemberships))
+				}
 				if len(result.DiffResult.UsersToCreate) > 0 || len(result.DiffResult.UsersToUpdate) > 0 || len(result.DiffResult.UsersToSkip) > 0 {
 					output.Printf("  Users: %d new, %d updated, %d skipped (identical)\n",
 						len(result.DiffResult.UsersToCreate),
@@ -345,11 +508,18 @@ Use --include to selectively import specific resource types.`,
 						len(result.DiffResult.PagesToUpdate),
 						len(result.DiffResult.PagesToSkip))
 				}
-				if len(result.DiffResult.IntegrationsToUpdate) > 0 || len(result.DiffResult.IntegrationsToSkip) > 0 {
-					output.Printf("  Integrations: %d updated, %d skipped (identical)\n",
+				if len(result.DiffResult.IntegrationsToCreate) > 0 || len(result.DiffResult.IntegrationsToUpdate) > 0 || len(result.DiffResult.IntegrationsToSkip) > 0 {
+					output.Printf("  Integrations: %d new, %d updated, %d skipped (identical)\n",
+						len(result.DiffResult.IntegrationsToCreate),
 						len(result.DiffResult.IntegrationsToUpdate),
 						len(result.DiffResult.IntegrationsToSkip))
 				}
+				if len(result.DiffResult.DataSourcesToCreate) > 0 || len(result.DiffResult.DataSourcesToUpdate) > 0 || len(result.DiffResult.DataSourcesToSkip) > 0 {
+					output.Printf("  Data sources: %d new, %d updated, %d skipped (identical)\n",
+						len(result.DiffResult.DataSourcesToCreate),
+						len(result.DiffResult.DataSourcesToUpdate),
+						len(result.DiffResult.DataSourcesToSkip))
+				}
 				if len(result.DiffResult.BlueprintPermissions) > 0 {
 					output.Printf("  Blueprint permissions: %d to update\n",
 						len(result.DiffResult.BlueprintPermissions))
@@ -362,6 +532,11 @@ Use --include to selectively import specific resource types.`,
 					output.Printf("  Page permissions: %d to update\n",
 						len(result.DiffResult.PagePermissions))
 				}
+				if showDiff {
+					var preview strings.Builder
+					compare.FormatUpdatePreviews(&preview, compare.PreviewUpdates(result.DiffResult))
+					output.Printf("%s", preview.String())
+				}
 				output.Printf("\n")
 			}
 
@@ -370,14 +545,24 @@ Use --include to selectively import specific resource types.`,
 			output.Printf("Scorecards created: %d, updated: %d\n", result.ScorecardsCreated, result.ScorecardsUpdated)
 			output.Printf("Actions created: %d, updated: %d\n", result.ActionsCreated, result.ActionsUpdated)
 			output.Printf("Teams created: %d, updated: %d\n", result.TeamsCreated, result.TeamsUpdated)
+			if result.TeamMembersAdded > 0 || result.TeamMembersRemoved > 0 {
+				output.Printf("Team members added: %d, removed: %d\n", result.TeamMembersAdded, result.TeamMembersRemoved)
+			}
 			output.Printf("Users created: %d, updated: %d\n", result.UsersCreated, result.UsersUpdated)
 			output.Printf("Pages created: %d, updated: %d\n", result.PagesCreated, result.PagesUpdated)
-			output.Printf("Integrations updated: %d\n", result.IntegrationsUpdated)
+			output.Printf("Integrations created: %d, updated: %d\n", result.IntegrationsCreated, result.IntegrationsUpdated)
+			output.Printf("Data sources created: %d, updated: %d\n", result.DataSourcesCreated, result.DataSourcesUpdated)
 			if result.BlueprintPermissionsUpdated > 0 || result.ActionPermissionsUpdated > 0 || result.PagePermissionsUpdated > 0 {
 				output.Printf("Blueprint permissions updated: %d\n", result.BlueprintPermissionsUpdated)
 				output.Printf("Action permissions updated: %d\n", result.ActionPermissionsUpdated)
 				output.Printf("Page permissions updated: %d\n", result.PagePermissionsUpdated)
 			}
+			if result.ResourcesDeleted > 0 {
+				output.Printf("Resources deleted: %d\n", result.ResourcesDeleted)
+			}
+			if result.ResourcesSkipped > 0 {
+				output.Printf("Existing resources skipped: %d\n", result.ResourcesSkipped)
+			}
 
 			if showPagesPipeline && len(result.SidebarPipeline) > 0 {
 				output.Printf("\nSidebar pipeline used:\n")
@@ -391,7 +576,7 @@ Use --include to selectively import specific resource types.`,
 				output.Printf("\nWarnings:\n")
 				for _, warning := range result.Warnings {
 					output.WarningPrintln(fmt.Sprintf("  ⚠ %s", warning.Message))
-					if verbose && len(warning.Details) > 0 {
+					if (verbose || warning.Type == "breaking_change") && len(warning.Details) > 0 {
 						for _, detail := range warning.Details {
 							output.Printf("      - %s\n", detail)
 						}
@@ -436,7 +621,7 @@ Use --include to selectively import specific resource types.`,
 			}
 
 			if !result.Success {
-				return fmt.Errorf("import completed with errors")
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("import completed with errors"))
 			}
 			return nil
 		},
@@ -448,17 +633,160 @@ Use --include to selectively import specific resource types.`,
 	importCmd.Flags().StringVar(&targetOrg, "target-org", "", "Target organization name (uses default if not specified)")
 	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate import without applying changes")
 	importCmd.Flags().BoolVar(&skipEntities, "skip-entities", false, "Skip importing entities (only import schema and configuration)")
+	importCmd.Flags().StringVar(&skipEntitiesFor, "skip-entities-for", "", skipEntitiesForUsage)
 	importCmd.Flags().BoolVar(&skipSystemBlueprints, "skip-system-blueprints", false, "Skip system blueprint schemas (identifiers starting with _) and their entities")
 	importCmd.Flags().BoolVar(&skipSystemBlueprintProperties, "skip-system-blueprint-properties", false, "When used with --skip-system-blueprints, do not import custom properties on known system blueprints")
+	importCmd.Flags().BoolVar(&includeSystemBlueprints, "include-system-blueprints", false, "Also diff and update Port-managed system blueprints such as _rule (never creates them). Overwrites org-managed system schema; use with care")
 	importCmd.Flags().BoolVar(&includeRuleResults, "include-rule-results", true, "Include _rule_result system blueprint entities (use --include-rule-results=false to exclude)")
-	importCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to import (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations. If not specified, imports all resources.")
+	importCmd.Flags().StringVar(&continueFrom, "continue-from", "", "Resume an import from this resource type, skipping the types imported before it (order: "+strings.Join(import_module.ImportOrder, ", ")+"). Combines with --include")
+	importCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to import (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, permissions. Add ':glob' to a type to keep only matching identifiers (e.g., 'blueprints,scorecards:team-*'). If not specified, imports all resources.")
+	importCmd.Flags().StringVar(&profile, "profile", "", "Resource profile from the config file supplying --include, --exclude-blueprints, --exclude-blueprint-schema and --skip-entities; flags on the command line override it")
 	importCmd.Flags().StringVar(&excludeBlueprints, "exclude-blueprints", "", "Comma-separated blueprint IDs to exclude entirely (schema + entities + scorecards + actions)")
 	importCmd.Flags().StringVar(&excludeBlueprintSchema, "exclude-blueprint-schema", "", "Comma-separated blueprint IDs to exclude schema only (entities, scorecards, actions still imported)")
-	importCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
-	importCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed error information with categorization")
+	importCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text, json or yaml")
+	importCmd.Flags().StringVar(&resultFile, "result-file", "", "Write the JSON result to this file instead of stdout (requires --output-format json)")
+	importCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed error information with categorization and print each resource as it is created or updated")
 	importCmd.Flags().BoolVar(&showPagesPipeline, "show-pages-pipeline", false, "Show the planned sidebar pages/folders pipeline before execution and include the pipeline used in the output")
 	importCmd.Flags().BoolVar(&usersAsDisabled, "users-as-disabled", false, "Import non-admin users as DISABLED (admin users are imported normally)")
+	importCmd.Flags().BoolVar(&createIntegrations, "create-integrations", false, "Install integrations that are missing in the target org (installation may require credentials not included in the export)")
+	importCmd.Flags().BoolVar(&allowBreaking, "allow-breaking", false, "Apply blueprint schema changes that could invalidate existing entities (removed required properties, type changes, narrowed enums)")
+	importCmd.Flags().StringVar(&onConflict, "on-conflict", string(import_module.ConflictUpdate), "What to do with resources that already exist in the target: update (overwrite), skip (leave untouched) or fail (report an error; create only)")
+	importCmd.Flags().BoolVar(&strictRelations, "strict-relations", false, "Fail the import, before changing anything, when a blueprint relation targets a blueprint missing from both the input and the target (by default such relations are reported as errors and the rest is applied)")
+	importCmd.Flags().BoolVar(&forceUpdate, "force-update", false, "Update every resource that already exists in the target, even when the diff finds it unchanged (escape hatch for a wrong diff; slower)")
+	importCmd.Flags().BoolVar(&prune, "prune", false, "Delete the resources a delta bundle from 'port diff-bundle' lists as removed")
+	importCmd.Flags().BoolVar(&confirm, "confirm", false, confirmUsage)
+	importCmd.Flags().BoolVar(&replaceAll, "replace-all", false, "Make the target mirror the input: also delete every resource the target has that the input lacks, after confirmation (--yes skips it). Requires a full export")
+	importCmd.Flags().StringArrayVar(&actionURLMapFlags, "action-url-map", nil, "Rewrite a URL host, URL prefix, or org/repo value in action invocation methods, as source=target (repeatable)")
+	importCmd.Flags().StringVar(&transformFile, "transform", "", "YAML/JSON file of set/remove/rename rules applied to blueprints and entities before diffing")
+	importCmd.Flags().BoolVar(&showDiff, "show-diff", false, "With --dry-run, print the field-level changes each update would apply")
 	importCmd.Flags().IntVar(&maxErrors, "max-errors", defaultMaxErrors, "Maximum number of errors to show in text output (-1 hides errors, 0 shows all)")
+	importCmd.Flags().IntVar(&retryBudget, "retry-budget", 0, retryBudgetUsage)
+	importCmd.Flags().StringVar(&expandEnv, "expand-env", "", "Substitute ${VAR} and $VAR environment variable references in the input files: lenient leaves unset variables as written, strict fails on them (--expand-env alone means lenient)")
+	importCmd.Flags().Lookup("expand-env").NoOptDefVal = string(envexpand.Lenient)
+	importCmd.Flags().StringVar(&reportFile, "report", "", "Write a report of the planned changes to this file; the format follows the extension (.html, .json or .md)")
 
 	rootCmd.AddCommand(importCmd)
 }
+
+// importCredentials picks the credential flags import authenticates with.
+// The --target-* set is used as a whole when any of its fields is given,
+// otherwise the base set is, so a client ID from one set is never paired
+// with a secret or API URL from the other. Fields left empty fall back to
+// the org's config.
+func importCredentials(flags GlobalFlags) (clientID, clientSecret, apiURL string) {
+	if flags.TargetClientID != "" || flags.TargetClientSecret != "" || flags.TargetAPIURL != "" {
+		return flags.TargetClientID, flags.TargetClientSecret, flags.TargetAPIURL
+	}
+	return flags.ClientID, flags.ClientSecret, flags.APIURL
+}
+
+// importPartialJSON summarizes what an interrupted import completed.
+func importPartialJSON(result *import_module.Result) map[string]interface{} {
+	return map[string]interface{}{
+		"blueprints_created": result.BlueprintsCreated,
+		"blueprints_updated": result.BlueprintsUpdated,
+		"entities_created":   result.EntitiesCreated,
+		"entities_updated":   result.EntitiesUpdated,
+		"scorecards_created": result.ScorecardsCreated,
+		"scorecards_updated": result.ScorecardsUpdated,
+		"actions_created":    result.ActionsCreated,
+		"actions_updated":    result.ActionsUpdated,
+		"teams_created":      result.TeamsCreated,
+		"teams_updated":      result.TeamsUpdated,
+		"users_created":      result.UsersCreated,
+		"users_updated":      result.UsersUpdated,
+		"pages_created":      result.PagesCreated,
+		"pages_updated":      result.PagesUpdated,
+		"errors":             result.Errors,
+	}
+}
+
+// printImportPartialResult prints what an interrupted import completed.
+func printImportPartialResult(result *import_module.Result) {
+	output.WarningPrintf("\n%s\n", result.Message)
+	output.Printf("\nPartial import results:\n")
+	output.Printf("Blueprints created: %d, updated: %d\n", result.BlueprintsCreated, result.BlueprintsUpdated)
+	output.Printf("Entities created: %d, updated: %d\n", result.EntitiesCreated, result.EntitiesUpdated)
+	output.Printf("Scorecards created: %d, updated: %d\n", result.ScorecardsCreated, result.ScorecardsUpdated)
+	output.Printf("Actions created: %d, updated: %d\n", result.ActionsCreated, result.ActionsUpdated)
+	output.Printf("Teams created: %d, updated: %d\n", result.TeamsCreated, result.TeamsUpdated)
+	output.Printf("Users created: %d, updated: %d\n", result.UsersCreated, result.UsersUpdated)
+	output.Printf("Pages created: %d, updated: %d\n", result.PagesCreated, result.PagesUpdated)
+}
+
+// resourceLogCallback returns a callback that prints one line per created,
+// updated or deleted resource and logs every resource event to logger. It
+// returns nil when there is nothing to print or log; nothing is printed when
+// verbose output is off or the output is JSON.
+func resourceLogCallback(verbose bool, outputFormat string, logger *logging.Logger) import_module.ResourceCallback {
+	printing := verbose && !structuredOutput(outputFormat)
+	if !printing && logger == nil {
+		return nil
+	}
+	// Clear any in-place progress line before printing.
+	prefix := "\r"
+	if output.Enabled() {
+		prefix = "\r\033[K"
+	}
+	return func(action, resourceType, identifier string) {
+		logger.Log("resource", logging.Fields{"action": action, "resource_type": resourceType, "identifier": identifier})
+		if !printing || action == import_module.ResourceSkipped || action == import_module.ResourceFailed {
+			return
+		}
+		output.Printf("%s  %s %s %s\n", prefix, action, resourceType, identifier)
+	}
+}
+
+// writeChangeReport renders the changes in diff to path. before names the
+// target organization and after the data applied to it. A report that cannot
+// be written only produces a warning: the changes it describes have already
+// been made.
+func writeChangeReport(path string, diff *import_module.DiffResult, before, after, outputFormat string) {
+	if diff == nil {
+		if !structuredOutput(outputFormat) {
+			output.WarningPrintf("No changes were computed; report %s not written\n", path)
+		}
+		return
+	}
+	if err := compare.WriteReport(path, compare.FromDiffResult(diff, before, after)); err != nil {
+		if !structuredOutput(outputFormat) {
+			output.WarningPrintf("Failed to write report: %v\n", err)
+		}
+		return
+	}
+	if !structuredOutput(outputFormat) {
+		output.Printf("Report written to %s\n", path)
+	}
+}
+
+// describeTargetOrg names a target organization in reports, falling back to
+// the default organization.
+func describeTargetOrg(name string) string {
+	if name == "" {
+		return "default organization"
+	}
+	return name
+}
+
+// replaceAllConfirmer asks the user to approve the deletions --replace-all is
+// about to make in org, summarized per resource type.
+func replaceAllConfirmer(org string) import_module.DeletionConfirmer {
+	return func(deletions []export.Deletion) (bool, error) {
+		counts := make(map[string]int)
+		var types []string
+		for _, d := range deletions {
+			if counts[d.Type] == 0 {
+				types = append(types, d.Type)
+			}
+			counts[d.Type]++
+		}
+		summary := make([]string, len(types))
+		for i, t := range types {
+			summary[i] = fmt.Sprintf("%d %s", counts[t], strings.TrimPrefix(t, "_"))
+		}
+		return confirmPrompt(
+			fmt.Sprintf("Delete %d resource(s) missing from the input?", len(deletions)),
+			fmt.Sprintf("--replace-all will delete %s from organization %q once the import is applied. This cannot be undone.", strings.Join(summary, ", "), org),
+		)
+	}
+}
@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/spf13/cobra"
 )
 
@@ -116,3 +118,65 @@ func TestImportMaxErrorsFlagParsed(t *testing.T) {
 		t.Errorf("expected --max-errors to parse as -1, got %d", maxErrors)
 	}
 }
+
+func TestImportForceUpdateRejectsOtherConflictStrategies(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterImport(rootCmd)
+	rootCmd.SetArgs([]string{"import", "--input", "dummy.json", "--force-update", "--on-conflict", "skip"})
+	rootCmd.SilenceUsage = true
+	rootCmd.SilenceErrors = true
+
+	if err := rootCmd.Execute(); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("expected a usage error, got %v", err)
+	}
+}
+
+func TestImportRejectsExpandEnvModeAsArgument(t *testing.T) {
+	for _, args := range [][]string{
+		{"import", "--expand-env", "strict", "--input", "dummy.json"},
+		{"import", "--input", "dummy.json", "extra"},
+	} {
+		rootCmd := &cobra.Command{Use: "port"}
+		RegisterImport(rootCmd)
+		rootCmd.SetArgs(args)
+		rootCmd.SilenceUsage = true
+		rootCmd.SilenceErrors = true
+
+		err := rootCmd.Execute()
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Fatalf("%v: expected a usage error, got %v", args, err)
+		}
+		if args[1] == "--expand-env" && !strings.Contains(err.Error(), "--expand-env=strict") {
+			t.Errorf("expected the error to suggest --expand-env=strict, got %v", err)
+		}
+	}
+}
+
+func TestImportCredentials(t *testing.T) {
+	base := GlobalFlags{ClientID: "base-id", ClientSecret: "base-secret", APIURL: "https://base.example.com"}
+	tests := []struct {
+		name               string
+		target             GlobalFlags
+		wantID, wantSecret string
+		wantURL            string
+	}{
+		{name: "no target flags uses the base set", wantID: "base-id", wantSecret: "base-secret", wantURL: "https://base.example.com"},
+		{name: "full target set", target: GlobalFlags{TargetClientID: "t-id", TargetClientSecret: "t-secret", TargetAPIURL: "https://t.example.com"}, wantID: "t-id", wantSecret: "t-secret", wantURL: "https://t.example.com"},
+		{name: "target ID only", target: GlobalFlags{TargetClientID: "t-id"}, wantID: "t-id"},
+		{name: "target secret only", target: GlobalFlags{TargetClientSecret: "t-secret"}, wantSecret: "t-secret"},
+		{name: "target API URL only", target: GlobalFlags{TargetAPIURL: "https://t.example.com"}, wantURL: "https://t.example.com"},
+		{name: "target ID and secret", target: GlobalFlags{TargetClientID: "t-id", TargetClientSecret: "t-secret"}, wantID: "t-id", wantSecret: "t-secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := base
+			flags.TargetClientID = tt.target.TargetClientID
+			flags.TargetClientSecret = tt.target.TargetClientSecret
+			flags.TargetAPIURL = tt.target.TargetAPIURL
+			id, secret, url := importCredentials(flags)
+			if id != tt.wantID || secret != tt.wantSecret || url != tt.wantURL {
+				t.Errorf("importCredentials() = (%q, %q, %q), want (%q, %q, %q)", id, secret, url, tt.wantID, tt.wantSecret, tt.wantURL)
+			}
+		})
+	}
+}
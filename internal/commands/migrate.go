@@ -1,11 +1,19 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/logging"
+	"github.com/port-experimental/port-cli/internal/metrics"
+	"github.com/port-experimental/port-cli/internal/modules/compare"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
 	"github.com/port-experimental/port-cli/internal/modules/migrate"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/spf13/cobra"
@@ -17,18 +25,39 @@ func RegisterMigrate(rootCmd *cobra.Command) {
 		sourceOrg                     string
 		baseOrg                       string
 		targetOrg                     string
+		targetOrgs                    string
+		parallelOrgs                  int
 		blueprints                    string
+		only                          string
+		deps                          string
 		dryRun                        bool
 		skipEntities                  bool
+		skipEntitiesFor               string
 		skipSystemBlueprints          bool
 		skipSystemBlueprintProperties bool
+		includeSystemBlueprints       bool
 		includeRuleResults            bool
 		include                       string
+		profile                       string
 		outputFormat                  string
 		excludeBlueprints             string
 		excludeBlueprintSchema        string
 		usersAsDisabled               bool
+		createIntegrations            bool
+		forceUpdate                   bool
+		preserveMetadata              bool
+		strictRelations               bool
+		allowBreaking                 bool
+		teamMapFlags                  []string
+		actionURLMapFlags             []string
 		maxErrors                     int
+		resultFile                    string
+		retryBudget                   int
+		reportFile                    string
+		showTimings                   bool
+		sinceExport                   string
+		confirm                       bool
+		mapFile                       string
 
 		scorecards   string
 		actions      string
@@ -48,31 +77,74 @@ Migrates blueprints, entities, scorecards, actions, teams, users, pages, and int
 Use --skip-entities to only migrate configuration without entity data.
 Use --include to selectively migrate specific resource types.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+			if err := validateStringEnum("--output-format", outputFormat, resultOutputFormats); err != nil {
 				return err
 			}
+			if includeSystemBlueprints && skipSystemBlueprints {
+				return exitcode.Usagef("--include-system-blueprints cannot be used with --skip-system-blueprints")
+			}
 
 			flags := GetGlobalFlags(cmd.Context())
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 
 			// Use base-org if provided, otherwise use source-org
 			sourceOrgName := baseOrg
 			if sourceOrgName == "" {
 				sourceOrgName = sourceOrg
 			}
+			sourceOrgName = resolveOrg(sourceOrgName)
 
 			// Validate that source org is provided
 			if sourceOrgName == "" {
-				return fmt.Errorf("source organization is required. Use --source-org or --base-org")
+				return exitcode.Usagef("source organization is required. Use --source-org or --base-org")
 			}
 
 			// Validate that target org is provided
-			if targetOrg == "" {
-				return fmt.Errorf("target organization is required. Use --target-org")
+			var targetOrgList []string
+			for _, name := range strings.Split(targetOrgs, ",") {
+				if trimmed := strings.TrimSpace(name); trimmed != "" {
+					targetOrgList = append(targetOrgList, trimmed)
+				}
+			}
+			if targetOrg == "" && len(targetOrgList) == 0 {
+				return exitcode.Usagef("target organization is required. Use --target-org or --target-orgs")
+			}
+			if targetOrg != "" && len(targetOrgList) > 0 {
+				return exitcode.Usagef("--target-org and --target-orgs cannot be used together")
+			}
+			batchMode := len(targetOrgList) > 0
+			if batchMode && (flags.TargetClientID != "" || flags.TargetClientSecret != "" || flags.TargetAPIURL != "") {
+				return exitcode.Usagef("--target-orgs cannot be combined with --target-client-id, --target-client-secret or --target-api-url; configure each target org in the config file instead")
 			}
 			if err := validateMaxErrorsFlag(maxErrors); err != nil {
 				return err
 			}
+			if err := validateResultFileFlag(resultFile, outputFormat); err != nil {
+				return err
+			}
+			if err := validateRetryBudgetFlag(retryBudget); err != nil {
+				return err
+			}
+			if batchMode && showTimings {
+				return exitcode.Usagef("--timings cannot be used with --target-orgs")
+			}
+			if batchMode && parallelOrgs < 1 {
+				return exitcode.Usagef("--parallel-orgs must be at least 1")
+			}
+			if confirm && batchMode {
+				return exitcode.Usagef("--confirm cannot be used with --target-orgs")
+			}
+			if sinceExport != "" && batchMode {
+				return exitcode.Usagef("--since-export cannot be used with --target-orgs")
+			}
+			if reportFile != "" {
+				if batchMode {
+					return exitcode.Usagef("--report cannot be used with --target-orgs")
+				}
+				if err := compare.ValidateReportPath(reportFile); err != nil {
+					return exitcode.Usagef("invalid --report: %w", err)
+				}
+			}
 
 			// Use CLI flags if provided, otherwise use org names from config
 			baseClientID := flags.ClientID
@@ -82,7 +154,7 @@ Use --include to selectively migrate specific resource types.`,
 			targetClientSecret := flags.TargetClientSecret
 			targetAPIURL := flags.TargetAPIURL
 
-			_, baseOrgConfig, targetOrgConfig, err := configManager.LoadWithDualOverrides(
+			cfg, baseOrgConfig, targetOrgConfig, err := configManager.LoadWithDualOverrides(
 				baseClientID,
 				baseClientSecret,
 				baseAPIURL,
@@ -93,15 +165,23 @@ Use --include to selectively migrate specific resource types.`,
 				targetOrg,
 			)
 			if err != nil {
-				return fmt.Errorf("failed to load configuration: %w", err)
+				return exitcode.Usagef("failed to load configuration: %w", err)
 			}
 
 			if baseOrgConfig == nil {
-				return fmt.Errorf("base organization configuration not found")
+				return exitcode.Usagef("base organization configuration not found")
 			}
 
-			if targetOrgConfig == nil {
-				return fmt.Errorf("target organization configuration not found")
+			if targetOrgConfig == nil && !batchMode {
+				return exitcode.Usagef("target organization configuration not found")
+			}
+			if err := applyResourceProfile(cmd, cfg, profile, &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err != nil {
+				return err
+			}
+			if !batchMode {
+				if err := checkDistinctOrgs(cmd, baseOrgConfig, targetOrgConfig, sourceOrgName, targetOrg, outputFormat); err != nil {
+					return err
+				}
 			}
 
 			// Parse blueprints list
@@ -113,6 +193,21 @@ Use --include to selectively migrate specific resource types.`,
 				}
 			}
 
+			// Parse --only identifier globs
+			var onlyPatterns []string
+			for _, pattern := range strings.Split(only, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					onlyPatterns = append(onlyPatterns, pattern)
+				}
+			}
+			if err := export.ValidateBlueprintPatterns(onlyPatterns); err != nil {
+				return exitcode.Usagef("invalid --only: %w", err)
+			}
+			depsMode, err := export.ParseDependencyMode(deps)
+			if err != nil {
+				return exitcode.Usagef("invalid --deps: %w", err)
+			}
+
 			// Parse per-resource ID filters
 			parseCSV := func(s string) []string {
 				if s == "" {
@@ -134,11 +229,14 @@ Use --include to selectively migrate specific resource types.`,
 
 			// Parse include list
 			var includeList []string
+			var includePatterns map[string][]string
 			if include != "" {
-				includeList = strings.Split(include, ",")
-				for i := range includeList {
-					includeList[i] = strings.TrimSpace(includeList[i])
+				spec, err := export.ParseIncludeSpec(include)
+				if err != nil {
+					return exitcode.Usagef("invalid --include: %w", err)
 				}
+				includeList = spec.Resources
+				includePatterns = spec.Patterns
 
 				// Validate resource types
 				validResources := map[string]bool{
@@ -151,6 +249,7 @@ Use --include to selectively migrate specific resource types.`,
 					"automations":           true,
 					"pages":                 true,
 					"integrations":          true,
+					"datasources":           true,
 					"blueprint-permissions": true,
 					"action-permissions":    true,
 					"page-permissions":      true,
@@ -158,12 +257,12 @@ Use --include to selectively migrate specific resource types.`,
 
 				for _, r := range includeList {
 					if !validResources[r] {
-						return fmt.Errorf("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, blueprint-permissions, action-permissions, page-permissions", r)
+						return exitcode.Usagef("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, blueprint-permissions, action-permissions, page-permissions, permissions", r)
 					}
 				}
 
 				if slices.Contains(includeList, "page-permissions") && !slices.Contains(includeList, "pages") {
-					return fmt.Errorf("page-permissions requires pages to also be included (add 'pages' to --include)")
+					return exitcode.Usagef("page-permissions requires pages to also be included (add 'pages' to --include)")
 				}
 
 				// Handle conflict between skip_entities and include
@@ -193,9 +292,9 @@ Use --include to selectively migrate specific resource types.`,
 			}
 
 			// True when the caller explicitly wants blueprint schemas, either via
-			// --blueprints or --include blueprints — as opposed to blueprints only
+			// --blueprints, --only or --include blueprints — as opposed to blueprints only
 			// being pulled in as a byproduct of --actions/--scorecards/--entities.
-			blueprintsExplicitlyRequested := cmd.Flags().Changed("blueprints") || slices.Contains(includeList, "blueprints")
+			blueprintsExplicitlyRequested := cmd.Flags().Changed("blueprints") || len(onlyPatterns) > 0 || slices.Contains(includeList, "blueprints")
 
 			// Auto-include resource types when per-resource flags are explicitly set
 			// (with or without specific IDs — Changed() detects explicit flag usage)
@@ -239,6 +338,11 @@ Use --include to selectively migrate specific resource types.`,
 			}
 			autoScopeBlueprints := needBlueprints && !blueprintsExplicitlyRequested
 
+			skipEntitiesForList, err := parseSkipEntitiesFor(skipEntitiesFor)
+			if err != nil {
+				return err
+			}
+
 			// Parse exclude-blueprints flag
 			var excludeBlueprintList []string
 			if excludeBlueprints != "" {
@@ -259,6 +363,64 @@ Use --include to selectively migrate specific resource types.`,
 				}
 			}
 
+			teamMap, err := migrate.ParseTeamMap(teamMapFlags)
+			if err != nil {
+				return exitcode.Usagef("--team-map: %v", err)
+			}
+			actionURLMap, err := import_module.ParseActionURLMap(actionURLMapFlags)
+			if err != nil {
+				return exitcode.Usagef("--action-url-map: %v", err)
+			}
+
+			var sinceSnapshot *export.Data
+			sinceSnapshotFound := false
+			if sinceExport != "" {
+				sinceSnapshot, sinceSnapshotFound, err = loadSinceExport(sinceExport)
+				if err != nil {
+					return exitcode.Usagef("%w", err)
+				}
+			}
+
+			migrateOpts := migrate.Options{
+				Blueprints:                    blueprintList,
+				BlueprintPatterns:             onlyPatterns,
+				Dependencies:                  depsMode,
+				DryRun:                        dryRun,
+				SkipEntities:                  skipEntities,
+				SkipEntitiesFor:               skipEntitiesForList,
+				RecordEntityChanges:           reportFile != "",
+				SkipSystemBlueprints:          skipSystemBlueprints,
+				SkipSystemBlueprintProperties: skipSystemBlueprintProperties,
+				IncludeSystemBlueprints:       includeSystemBlueprints,
+				IncludeRuleResults:            includeRuleResults,
+				IncludeResources:              includeList,
+				IncludePatterns:               includePatterns,
+				AutoScopeBlueprints:           autoScopeBlueprints,
+				ExcludeBlueprints:             excludeBlueprintList,
+				ExcludeBlueprintSchema:        excludeBlueprintSchemaList,
+				UsersAsDisabled:               usersAsDisabled,
+				CreateIntegrations:            createIntegrations,
+				AllowBreaking:                 allowBreaking,
+				ForceUpdate:                   forceUpdate,
+				StrictRelations:               strictRelations,
+				TeamMap:                       teamMap,
+				ActionURLMap:                  actionURLMap,
+				PreserveMetadata:              preserveMetadata,
+				SinceExport:                   sinceSnapshot,
+				Entities:                      entityList,
+				Scorecards:                    scorecardList,
+				Actions:                       actionList,
+				Pages:                         pageList,
+				Integrations:                  integrationList,
+				Teams:                         teamList,
+				Users:                         userList,
+			}
+			if mapFile != "" {
+				if err := applyMapFile(mapFile, &migrateOpts); err != nil {
+					return err
+				}
+			}
+
 			// Create migration module
 			sourceToken, err := configManager.GetOrRefreshToken(cmd.Context(), sourceOrgName)
 			if err != nil {
@@ -266,6 +428,30 @@ Use --include to selectively migrate specific resource types.`,
 					return err
 				}
 			}
+			if batchMode {
+				targets := make([]migrate.Target, 0, len(targetOrgList))
+				for _, name := range targetOrgList {
+					orgCfg, err := cfg.GetOrgConfig(name)
+					if err != nil {
+						return exitcode.Usagef("failed to get target org config %s: %w", name, err)
+					}
+					if err := checkDistinctOrgs(cmd, baseOrgConfig, orgCfg, sourceOrgName, name, outputFormat); err != nil {
+						return err
+					}
+					token, err := configManager.GetOrRefreshToken(cmd.Context(), name)
+					if err != nil && !config.ShouldIgnoreGetOrRefreshTokenError(err) {
+						return err
+					}
+					targets = append(targets, migrate.Target{Org: name, Token: token, Config: orgCfg})
+				}
+				migrateModule := migrate.NewSourceModule(sourceToken, baseOrgConfig)
+				defer migrateModule.Close()
+				migrateModule.SetResourceCallback(resourceLogCallback(flags.Verbose, outputFormat, logging.FromContext(cmd.Context())))
+				ctx, stopRetryBudget := withRetryBudget(cmd.Context(), retryBudget, flags.Debug)
+				defer stopRetryBudget()
+				return runBatchMigration(ctx, migrateModule, sourceOrgName, baseOrgConfig, targets, parallelOrgs, migrateOpts, outputFormat, resultFile, maxErrors)
+			}
+
 			targetToken, err := configManager.GetOrRefreshToken(cmd.Context(), targetOrg)
 			if err != nil {
 				if !config.ShouldIgnoreGetOrRefreshTokenError(err) {
@@ -274,15 +460,25 @@ Use --include to selectively migrate specific resource types.`,
 			}
 			migrateModule := migrate.NewModule(sourceToken, targetToken, baseOrgConfig, targetOrgConfig)
 			defer migrateModule.Close()
-
+			migrateModule.SetResourceCallback(resourceLogCallback(flags.Verbose, outputFormat, logging.FromContext(cmd.Context())))
+			var timings *metrics.Timings
+			if showTimings {
+				timings = metrics.New()
+				migrateModule.SetTimings(timings)
+			}
 			// Show info only if not quiet and output format is text
-			if outputFormat != "json" {
+			if !structuredOutput(outputFormat) {
 				output.Printf("\nMigration:\n")
 				output.Printf("  Source (base org): %s\n", sourceOrgName)
 				output.Printf("  Target org: %s\n", targetOrg)
+				output.Printf("  Source API: %s\n", orgEndpoint(baseOrgConfig))
+				output.Printf("  Target API: %s\n", orgEndpoint(targetOrgConfig))
 				if len(blueprintList) > 0 {
 					output.Printf("  Blueprints: %s\n", strings.Join(blueprintList, ", "))
 				}
+				if len(onlyPatterns) > 0 {
+					output.Printf("  Blueprint patterns: %s\n", strings.Join(onlyPatterns, ", "))
+				}
 				if len(entityList) > 0 {
 					output.Printf("  Entities filter: %s\n", strings.Join(entityList, ", "))
 				}
@@ -310,72 +506,55 @@ Use --include to selectively migrate specific resource types.`,
 				} else if skipEntities {
 					output.Printf("  Skipping entities (schema only)\n")
 				}
+				if len(skipEntitiesForList) > 0 {
+					output.Printf("  Skipping entities of: %s\n", strings.Join(skipEntitiesForList, ", "))
+				}
+				if sinceExport != "" && sinceSnapshotFound {
+					output.Printf("  Since export: only source resources changed since %s\n", sinceExport)
+				} else if sinceExport != "" {
+					output.Printf("  Since export: no snapshot at %s yet; comparing every source resource\n", sinceExport)
+				}
 				if dryRun {
 					output.Printf("  Dry run mode - no changes will be applied\n")
 				}
 			}
 
+			if !dryRun {
+				migrateOpts.ConfirmApply = applyConfirmer(cmd, confirm, targetOrg)
+			}
+
 			// Execute migration
-			result, err := migrateModule.Execute(cmd.Context(), migrate.Options{
-				Blueprints:                    blueprintList,
-				DryRun:                        dryRun,
-				SkipEntities:                  skipEntities,
-				SkipSystemBlueprints:          skipSystemBlueprints,
-				SkipSystemBlueprintProperties: skipSystemBlueprintProperties,
-				IncludeRuleResults:            includeRuleResults,
-				IncludeResources:              includeList,
-				AutoScopeBlueprints:           autoScopeBlueprints,
-				ExcludeBlueprints:             excludeBlueprintList,
-				ExcludeBlueprintSchema:        excludeBlueprintSchemaList,
-				UsersAsDisabled:               usersAsDisabled,
-				Entities:                      entityList,
-				Scorecards:                    scorecardList,
-				Actions:                       actionList,
-				Pages:                         pageList,
-				Integrations:                  integrationList,
-				Teams:                         teamList,
-				Users:                         userList,
-			})
+			ctx, stopRetryBudget := withRetryBudget(cmd.Context(), retryBudget, flags.Debug)
+			defer stopRetryBudget()
+			result, err := migrateModule.Execute(ctx, migrateOpts)
+			if errors.Is(err, import_module.ErrApplyDeclined) {
+				cmd.Println("Operation cancelled")
+				return nil
+			}
 			if err != nil {
+				code := exitcode.Failure
+				if result != nil {
+					code = exitcode.Stopped(result.Applied())
+				}
 				failureMessage := migrationExecutionErrorMessage(err, result, maxErrors)
-				if outputFormat == "json" {
+				if structuredOutput(outputFormat) {
 					jsonData := map[string]interface{}{
 						"success": false,
 						"error":   failureMessage,
 					}
 					if result != nil {
-						jsonData["blueprints_created"] = result.BlueprintsCreated
-						jsonData["blueprints_updated"] = result.BlueprintsUpdated
-						jsonData["blueprints_skipped"] = result.BlueprintsSkipped
-						jsonData["entities_created"] = result.EntitiesCreated
-						jsonData["entities_updated"] = result.EntitiesUpdated
-						jsonData["entities_skipped"] = result.EntitiesSkipped
-						jsonData["scorecards_created"] = result.ScorecardsCreated
-						jsonData["scorecards_updated"] = result.ScorecardsUpdated
-						jsonData["scorecards_skipped"] = result.ScorecardsSkipped
-						jsonData["actions_created"] = result.ActionsCreated
-						jsonData["actions_updated"] = result.ActionsUpdated
-						jsonData["actions_skipped"] = result.ActionsSkipped
-						jsonData["teams_created"] = result.TeamsCreated
-						jsonData["teams_updated"] = result.TeamsUpdated
-						jsonData["teams_skipped"] = result.TeamsSkipped
-						jsonData["users_created"] = result.UsersCreated
-						jsonData["users_updated"] = result.UsersUpdated
-						jsonData["users_skipped"] = result.UsersSkipped
-						jsonData["pages_created"] = result.PagesCreated
-						jsonData["pages_updated"] = result.PagesUpdated
-						jsonData["pages_skipped"] = result.PagesSkipped
-						jsonData["integrations_updated"] = result.IntegrationsUpdated
-						jsonData["integrations_skipped"] = result.IntegrationsSkipped
+						addMigrationCountsJSON(jsonData, result)
 						if len(result.Errors) > 0 {
 							jsonData["errors"] = result.Errors
 						}
+						addErrorDetailsJSON(jsonData, result.CategorizedErrors)
 						if len(result.Warnings) > 0 {
 							jsonData["warnings"] = result.Warnings
 						}
 					}
-					output.PrintJSON(jsonData)
-					return fmt.Errorf("%s", failureMessage)
+					addTimingsJSON(jsonData, timings)
+					printResult(resultFile, outputFormat, jsonData)
+					return retryBudgetExit(ctx, exitcode.New(code, fmt.Errorf("%s", failureMessage)))
 				}
 				output.ErrorPrintf("%s\n", failureMessage)
 				if result != nil {
@@ -387,14 +566,20 @@ Use --include to selectively migrate specific resource types.`,
 					output.Printf("Teams created: %d, updated: %d, skipped: %d\n", result.TeamsCreated, result.TeamsUpdated, result.TeamsSkipped)
 					output.Printf("Users created: %d, updated: %d, skipped: %d\n", result.UsersCreated, result.UsersUpdated, result.UsersSkipped)
 					output.Printf("Pages created: %d, updated: %d, skipped: %d\n", result.PagesCreated, result.PagesUpdated, result.PagesSkipped)
-					output.Printf("Integrations updated: %d, skipped: %d\n", result.IntegrationsUpdated, result.IntegrationsSkipped)
+					output.Printf("Integrations created: %d, updated: %d, skipped: %d\n", result.IntegrationsCreated, result.IntegrationsUpdated, result.IntegrationsSkipped)
+					output.Printf("Data sources created: %d, updated: %d, skipped: %d\n", result.DataSourcesCreated, result.DataSourcesUpdated, result.DataSourcesSkipped)
 				}
-				return fmt.Errorf("%s", failureMessage)
+				printTimings(timings)
+				return retryBudgetExit(ctx, exitcode.New(code, fmt.Errorf("%s", failureMessage)))
+			}
+
+			if reportFile != "" {
+				writeChangeReport(reportFile, result.DiffResult, targetOrg, sourceOrgName, outputFormat)
 			}
 
 			if !result.Success {
 				failureMessage := migrationFailureMessage(result, maxErrors)
-				if outputFormat == "json" {
+				if structuredOutput(outputFormat) {
 					jsonData := map[string]interface{}{
 						"success": false,
 						"error":   failureMessage,
@@ -402,50 +587,37 @@ Use --include to selectively migrate specific resource types.`,
 					if len(result.Errors) > 0 {
 						jsonData["errors"] = result.Errors
 					}
+					addErrorDetailsJSON(jsonData, result.CategorizedErrors)
 					if len(result.Warnings) > 0 {
 						jsonData["warnings"] = result.Warnings
 					}
-					output.PrintJSON(jsonData)
-					return fmt.Errorf("%s", failureMessage)
+					addTimingsJSON(jsonData, timings)
+					printResult(resultFile, outputFormat, jsonData)
+					return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("%s", failureMessage))
+				}
+				printTimings(timings)
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("%s", failureMessage))
+			}
+
+			// Save the source as the snapshot for the next --since-export run,
+			// only after a successful, non-dry run so failed changes are retried
+			if result.SourceSnapshot != nil && !dryRun {
+				if err := writeSinceExport(sinceExport, result.SourceSnapshot); err != nil {
+					return err
 				}
-				return fmt.Errorf("%s", failureMessage)
 			}
 
 			// Output in JSON format if requested
-			if outputFormat == "json" {
+			if structuredOutput(outputFormat) {
 				jsonData := map[string]interface{}{
-					"success":                       true,
-					"message":                       result.Message,
-					"blueprints_created":            result.BlueprintsCreated,
-					"blueprints_updated":            result.BlueprintsUpdated,
-					"blueprints_skipped":            result.BlueprintsSkipped,
-					"entities_created":              result.EntitiesCreated,
-					"entities_updated":              result.EntitiesUpdated,
-					"entities_skipped":              result.EntitiesSkipped,
-					"scorecards_created":            result.ScorecardsCreated,
-					"scorecards_updated":            result.ScorecardsUpdated,
-					"scorecards_skipped":            result.ScorecardsSkipped,
-					"actions_created":               result.ActionsCreated,
-					"actions_updated":               result.ActionsUpdated,
-					"actions_skipped":               result.ActionsSkipped,
-					"teams_created":                 result.TeamsCreated,
-					"teams_updated":                 result.TeamsUpdated,
-					"teams_skipped":                 result.TeamsSkipped,
-					"users_created":                 result.UsersCreated,
-					"users_updated":                 result.UsersUpdated,
-					"users_skipped":                 result.UsersSkipped,
-					"pages_created":                 result.PagesCreated,
-					"pages_updated":                 result.PagesUpdated,
-					"pages_skipped":                 result.PagesSkipped,
-					"integrations_updated":          result.IntegrationsUpdated,
-					"integrations_skipped":          result.IntegrationsSkipped,
-					"blueprint_permissions_updated": result.BlueprintPermissionsUpdated,
-					"action_permissions_updated":    result.ActionPermissionsUpdated,
-					"page_permissions_updated":      result.PagePermissionsUpdated,
+					"success": true,
+					"message": result.Message,
 				}
+				addMigrationCountsJSON(jsonData, result)
 				if len(result.Errors) > 0 {
 					jsonData["errors"] = result.Errors
 				}
+				addErrorDetailsJSON(jsonData, result.CategorizedErrors)
 				if len(result.Warnings) > 0 {
 					jsonData["warnings"] = result.Warnings
 				}
@@ -453,8 +625,17 @@ Use --include to selectively migrate specific resource types.`,
 					jsonData["ignored_rule_result_target_relations_count"] = result.IgnoredRuleResultTargetRelationCount
 					jsonData["ignored_rule_result_target_relation_keys"] = result.IgnoredRuleResultTargetRelationKeys
 				}
+				if sinceExport != "" {
+					jsonData["unchanged_since_export"] = result.UnchangedSinceExport
+					deleted := result.DeletedSinceExport
+					if deleted == nil {
+						deleted = []export.Deletion{}
+					}
+					jsonData["deleted_since_export"] = deleted
+				}
 				addMigrationDetailJSON(jsonData, result)
-				return output.PrintJSON(jsonData)
+				addTimingsJSON(jsonData, timings)
+				return printResult(resultFile, outputFormat, jsonData)
 			}
 
 			// Text output
@@ -499,6 +680,10 @@ Use --include to selectively migrate specific resource types.`,
 						len(result.DiffResult.TeamsToUpdate),
 						len(result.DiffResult.TeamsToSkip))
 				}
+				if len(result.DiffResult.TeamMemberships) > 0 {
+					added, removed := import_module.CountTeamMembershipChanges(result.DiffResult.TeamMemberships)
+					output.Printf("  Team members: %d to add, %d to remove across %d team(s)\n", added, removed, len(result.DiffResult.TeamMemberships))
+				}
 				if len(result.DiffResult.UsersToCreate) > 0 || len(result.DiffResult.UsersToUpdate) > 0 || len(result.DiffResult.UsersToSkip) > 0 {
 					output.Printf("  Users: %d new, %d updated, %d skipped (identical)\n",
 						len(result.DiffResult.UsersToCreate),
@@ -511,11 +696,18 @@ Use --include to selectively migrate specific resource types.`,
 						len(result.DiffResult.PagesToUpdate),
 						len(result.DiffResult.PagesToSkip))
 				}
-				if len(result.DiffResult.IntegrationsToUpdate) > 0 || len(result.DiffResult.IntegrationsToSkip) > 0 {
-					output.Printf("  Integrations: %d updated, %d skipped (identical)\n",
+				if len(result.DiffResult.IntegrationsToCreate) > 0 || len(result.DiffResult.IntegrationsToUpdate) > 0 || len(result.DiffResult.IntegrationsToSkip) > 0 {
+					output.Printf("  Integrations: %d new, %d updated, %d skipped (identical)\n",
+						len(result.DiffResult.IntegrationsToCreate),
 						len(result.DiffResult.IntegrationsToUpdate),
 						len(result.DiffResult.IntegrationsToSkip))
 				}
+				if len(result.DiffResult.DataSourcesToCreate) > 0 || len(result.DiffResult.DataSourcesToUpdate) > 0 || len(result.DiffResult.DataSourcesToSkip) > 0 {
+					output.Printf("  Data sources: %d new, %d updated, %d skipped (identical)\n",
+						len(result.DiffResult.DataSourcesToCreate),
+						len(result.DiffResult.DataSourcesToUpdate),
+						len(result.DiffResult.DataSourcesToSkip))
+				}
 				output.Printf("\n")
 			}
 
@@ -524,15 +716,25 @@ Use --include to selectively migrate specific resource types.`,
 			output.Printf("Scorecards created: %d, updated: %d, skipped: %d\n", result.ScorecardsCreated, result.ScorecardsUpdated, result.ScorecardsSkipped)
 			output.Printf("Actions created: %d, updated: %d, skipped: %d\n", result.ActionsCreated, result.ActionsUpdated, result.ActionsSkipped)
 			output.Printf("Teams created: %d, updated: %d, skipped: %d\n", result.TeamsCreated, result.TeamsUpdated, result.TeamsSkipped)
+			if result.TeamMembersAdded > 0 || result.TeamMembersRemoved > 0 {
+				output.Printf("Team members added: %d, removed: %d\n", result.TeamMembersAdded, result.TeamMembersRemoved)
+			}
 			output.Printf("Users created: %d, updated: %d, skipped: %d\n", result.UsersCreated, result.UsersUpdated, result.UsersSkipped)
 			output.Printf("Pages created: %d, updated: %d, skipped: %d\n", result.PagesCreated, result.PagesUpdated, result.PagesSkipped)
-			output.Printf("Integrations updated: %d, skipped: %d\n", result.IntegrationsUpdated, result.IntegrationsSkipped)
+			output.Printf("Integrations created: %d, updated: %d, skipped: %d\n", result.IntegrationsCreated, result.IntegrationsUpdated, result.IntegrationsSkipped)
+			output.Printf("Data sources created: %d, updated: %d, skipped: %d\n", result.DataSourcesCreated, result.DataSourcesUpdated, result.DataSourcesSkipped)
 			if flags.Verbose {
 				printMigrationVerboseDetails(result)
 			}
 			if result.PagePermissionsUpdated > 0 {
 				output.Printf("Page permissions updated: %d\n", result.PagePermissionsUpdated)
 			}
+			if sinceExport != "" {
+				output.Printf("Unchanged since snapshot: %d resource(s) not compared with the target\n", result.UnchangedSinceExport)
+				if result.SourceSnapshot != nil && !dryRun {
+					output.Printf("Snapshot for the next run written to %s\n", sinceExport)
+				}
+			}
 
 			if len(result.Warnings) > 0 {
 				output.Printf("\nWarnings:\n")
@@ -554,6 +756,7 @@ Use --include to selectively migrate specific resource types.`,
 				}
 			}
 
+			printTimings(timings)
 			return nil
 		},
 	}
@@ -561,19 +764,39 @@ Use --include to selectively migrate specific resource types.`,
 	migrateCmd.Flags().StringVarP(&sourceOrg, "source-org", "s", "", "Source organization name (base org)")
 	migrateCmd.Flags().StringVar(&baseOrg, "base-org", "", "Base organization name (alias for --source-org)")
 	migrateCmd.Flags().StringVarP(&targetOrg, "target-org", "t", "", "Target organization name")
-	migrateCmd.MarkFlagRequired("target-org")
+	migrateCmd.Flags().StringVar(&targetOrgs, "target-orgs", "", "Comma-separated target organization names; exports the source once and migrates into each target")
+	migrateCmd.Flags().IntVar(&parallelOrgs, "parallel-orgs", migrate.DefaultParallelOrgs, "Maximum number of target organizations migrated concurrently (with --target-orgs)")
 	migrateCmd.Flags().StringVarP(&blueprints, "blueprints", "b", "", "Comma-separated list of blueprint IDs to migrate (restricts migration to blueprints resource type; migrates all blueprints if flag set without IDs; pass this flag explicitly to migrate the full blueprint set even when combined with --actions/--scorecards/--entities)")
+	migrateCmd.Flags().StringVar(&only, "only", "", "Comma-separated blueprint identifier globs (e.g. 'team-*'); migrates matching blueprints, the blueprints their relations target (see --deps), and their resources. Combines with --blueprints")
+	migrateCmd.Flags().StringVar(&deps, "deps", string(export.DepsRequired), "Relation targets migrated along with the selected blueprints: required (targets of required relations), all, or none")
 	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate migration without applying changes")
 	migrateCmd.Flags().BoolVar(&skipEntities, "skip-entities", false, "Skip migrating entities (only migrate schema and configuration)")
+	migrateCmd.Flags().StringVar(&skipEntitiesFor, "skip-entities-for", "", skipEntitiesForUsage)
 	migrateCmd.Flags().BoolVar(&skipSystemBlueprints, "skip-system-blueprints", false, "Skip system blueprint schemas (identifiers starting with _) and their entities")
 	migrateCmd.Flags().BoolVar(&skipSystemBlueprintProperties, "skip-system-blueprint-properties", false, "When used with --skip-system-blueprints, do not migrate custom properties on known system blueprints")
+	migrateCmd.Flags().BoolVar(&includeSystemBlueprints, "include-system-blueprints", false, "Also diff and update Port-managed system blueprints such as _rule (never creates them). Overwrites org-managed system schema; use with care")
 	migrateCmd.Flags().BoolVar(&includeRuleResults, "include-rule-results", true, "Include _rule_result system blueprint entities (use --include-rule-results=false to exclude)")
-	migrateCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to migrate (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations. If not specified, migrates all resources.")
+	migrateCmd.Flags().StringVar(&include, "include", "", "Comma-separated list of resources to migrate (e.g., 'blueprints,pages'). Available: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, permissions. Add ':glob' to a type to keep only matching identifiers (e.g., 'blueprints,scorecards:team-*'). If not specified, migrates all resources.")
+	migrateCmd.Flags().StringVar(&profile, "profile", "", "Resource profile from the config file supplying --include, --exclude-blueprints, --exclude-blueprint-schema and --skip-entities; flags on the command line override it")
 	migrateCmd.Flags().StringVar(&excludeBlueprints, "exclude-blueprints", "", "Comma-separated blueprint IDs to exclude entirely (schema + entities + scorecards + actions)")
 	migrateCmd.Flags().StringVar(&excludeBlueprintSchema, "exclude-blueprint-schema", "", "Comma-separated blueprint IDs to exclude schema only (entities, scorecards, actions still migrated)")
-	migrateCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+	migrateCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text, json or yaml")
+	migrateCmd.Flags().StringVar(&resultFile, "result-file", "", "Write the JSON result to this file instead of stdout (requires --output-format json)")
 	migrateCmd.Flags().BoolVar(&usersAsDisabled, "users-as-disabled", false, "Import non-admin users as DISABLED (admin users are imported normally)")
+	migrateCmd.Flags().BoolVar(&createIntegrations, "create-integrations", false, "Install integrations that are missing in the target org (installation may require credentials not included in the export)")
+	migrateCmd.Flags().StringArrayVar(&actionURLMapFlags, "action-url-map", nil, "Rewrite a URL host, URL prefix, or org/repo value in action invocation methods, as source=target (repeatable)")
+	migrateCmd.Flags().StringVar(&mapFile, "map-file", "", "YAML mapping file with teams, action_urls and strip_properties sections (see port validate-mapping); --team-map and --action-url-map win over it")
+	migrateCmd.Flags().StringArrayVar(&teamMapFlags, "team-map", nil, "Rename a team in entity ownership and permissions, as source=target (repeatable); unmapped teams keep their names")
+	migrateCmd.Flags().BoolVar(&allowBreaking, "allow-breaking", false, "Apply blueprint schema changes that could invalidate existing entities (removed required properties, type changes, narrowed enums)")
+	migrateCmd.Flags().BoolVar(&strictRelations, "strict-relations", false, "Fail the migration, before changing anything, when a blueprint relation targets a blueprint missing from both the source export and the target (by default such relations are reported as errors and the rest is applied)")
+	migrateCmd.Flags().BoolVar(&forceUpdate, "force-update", false, "Update every resource that already exists in the target, even when the diff finds it unchanged (escape hatch for a wrong diff; slower)")
+	migrateCmd.Flags().BoolVar(&preserveMetadata, "preserve-metadata", false, "Keep the original entity createdAt/createdBy/updatedAt/updatedBy where the Port API accepts them; the fields it sets itself are reported in a warning")
 	migrateCmd.Flags().IntVar(&maxErrors, "max-errors", defaultMaxErrors, "Maximum number of errors to show in text output (-1 hides errors, 0 shows all)")
+	migrateCmd.Flags().IntVar(&retryBudget, "retry-budget", 0, retryBudgetUsage)
+	migrateCmd.Flags().BoolVar(&showTimings, "timings", false, timingsUsage)
+	migrateCmd.Flags().BoolVar(&confirm, "confirm", false, confirmUsage)
+	migrateCmd.Flags().StringVar(&sinceExport, "since-export", "", "Only migrate source resources that changed since this earlier source export, and replace it with a fresh one after a successful run (created on the first run)")
+	migrateCmd.Flags().StringVar(&reportFile, "report", "", "Write a report of the planned changes to this file; the format follows the extension (.html, .json or .md)")
 
 	migrateCmd.Flags().StringVar(&scorecards, "scorecards", "", "Comma-separated scorecard IDs to migrate (restricts migration to scorecards resource type; blueprint schemas migrated alongside are scoped to only the blueprints the selected scorecards belong to — use --blueprints to migrate the full set instead)")
 	migrateCmd.Flags().StringVar(&actions, "actions", "", "Comma-separated action IDs to migrate (restricts migration to actions resource type; migrates all actions if flag set without IDs; blueprint schemas migrated alongside are scoped to only the blueprints the selected actions belong to — use --blueprints to migrate the full set instead)")
@@ -620,3 +843,85 @@ func migrationExecutionErrorMessage(err error, result *migrate.Result, maxErrors
 	}
 	return fmt.Sprintf("migration failed: %v", err)
 }
+
+// checkDistinctOrgs refuses to migrate into targetOrg when it resolves to the
+// same client ID as the source, which is almost always a misconfiguration,
+// unless --yes was passed.
+func checkDistinctOrgs(cmd *cobra.Command, source, target *config.OrganizationConfig, sourceOrg, targetOrg, outputFormat string) error {
+	if !migrate.SameOrganization(source, target) {
+		return nil
+	}
+	problem := fmt.Sprintf("source org %q and target org %q resolve to the same client ID (%s), so the organization would be migrated onto itself", sourceOrg, targetOrg, migrate.MaskClientID(source.ClientID))
+	if !ShouldSkipConfirm(cmd, false) {
+		return exitcode.Usagef("%s; pass --yes to proceed anyway", problem)
+	}
+	if !structuredOutput(outputFormat) {
+		output.WarningPrintln("Warning: " + problem + "; proceeding because --yes was passed")
+	}
+	return nil
+}
+
+// orgEndpoint describes an org's API URL and masked client ID for the
+// pre-flight banner, so the user can confirm source and target are distinct.
+func orgEndpoint(orgConfig *config.OrganizationConfig) string {
+	return fmt.Sprintf("%s (client ID %s)", orgConfig.APIURL, migrate.MaskClientID(orgConfig.ClientID))
+}
+
+// runBatchMigration migrates one source export into several target orgs and
+// reports a consolidated per-org outcome. It returns an error if any target failed.
+func runBatchMigration(ctx context.Context, migrateModule *migrate.Module, sourceOrgName string, sourceConfig *config.OrganizationConfig, targets []migrate.Target, parallelOrgs int, opts migrate.Options, outputFormat, resultFile string, maxErrors int) error {
+	if !structuredOutput(outputFormat) {
+		targetNames := make([]string, len(targets))
+		for i, t := range targets {
+			targetNames[i] = t.Org
+		}
+		output.Printf("\nBatch migration:\n")
+		output.Printf("  Source (base org): %s\n", sourceOrgName)
+		output.Printf("  Target orgs: %s\n", strings.Join(targetNames, ", "))
+		output.Printf("  Source API: %s\n", orgEndpoint(sourceConfig))
+		for _, t := range targets {
+			output.Printf("  Target API (%s): %s\n", t.Org, orgEndpoint(t.Config))
+		}
+		output.Printf("  Parallel orgs: %d\n", parallelOrgs)
+		if opts.DryRun {
+			output.Printf("  Dry run mode - no changes will be applied\n")
+		}
+	}
+
+	results, err := migrateModule.ExecuteBatch(ctx, targets, parallelOrgs, opts)
+	if err != nil {
+		return retryBudgetExit(ctx, err)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+
+	if structuredOutput(outputFormat) {
+		orgs := make([]map[string]interface{}, 0, len(results))
+		for _, r := range results {
+			orgs = append(orgs, batchTargetJSON(r))
+		}
+		jsonData := map[string]interface{}{
+			"success": failed == 0,
+			"orgs":    orgs,
+		}
+		if err := printResult(resultFile, outputFormat, jsonData); err != nil {
+			return err
+		}
+	} else {
+		printBatchMigrationSummary(results, maxErrors)
+	}
+
+	if failed > 0 {
+		code := exitcode.Partial
+		if failed == len(results) {
+			code = exitcode.Failure
+		}
+		return retryBudgetExit(ctx, exitcode.New(code, fmt.Errorf("batch migration failed for %d of %d target org(s)", failed, len(results))))
+	}
+	return nil
+}
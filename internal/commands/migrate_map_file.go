@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/migrate"
+)
+
+// applyMapFile loads the --map-file at path into opts. Its teams and
+// action_urls sections are merged under the --team-map and --action-url-map
+// values already in opts, which win for a source both map.
+func applyMapFile(path string, opts *migrate.Options) error {
+	mapping, err := migrate.LoadMappingFile(path)
+	if err != nil {
+		return exitcode.Usagef("--map-file: %w", err)
+	}
+	if len(mapping.Blueprints) > 0 || len(mapping.Entities) > 0 {
+		return exitcode.Usagef("--map-file: migrate cannot rename blueprints or entities yet; remove the blueprints and entities sections from %s", path)
+	}
+	opts.TeamMap = mergeMappings(mapping.Teams, opts.TeamMap)
+	opts.ActionURLMap = mergeMappings(mapping.ActionURLs, opts.ActionURLMap)
+	opts.StripProperties = mapping.StripProperties
+	return nil
+}
+
+// mergeMappings returns base with overrides applied on top, or nil when
+// both are empty.
+func mergeMappings(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 {
+		return overrides
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for source, target := range base {
+		merged[source] = target
+	}
+	for source, target := range overrides {
+		merged[source] = target
+	}
+	return merged
+}
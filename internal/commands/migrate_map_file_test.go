@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/migrate"
+)
+
+func writeMapFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApplyMapFile(t *testing.T) {
+	path := writeMapFile(t, `teams:
+  platform: platform-eng
+  infra: infra-eng
+action_urls:
+  hooks.prod.example.com: hooks.staging.example.com
+strip_properties:
+  service: [notes]
+`)
+	opts := migrate.Options{TeamMap: map[string]string{"infra": "sre"}}
+	if err := applyMapFile(path, &opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"platform": "platform-eng", "infra": "sre"}; !reflect.DeepEqual(opts.TeamMap, want) {
+		t.Errorf("TeamMap = %v, want %v (--team-map wins)", opts.TeamMap, want)
+	}
+	if want := map[string]string{"hooks.prod.example.com": "hooks.staging.example.com"}; !reflect.DeepEqual(opts.ActionURLMap, want) {
+		t.Errorf("ActionURLMap = %v, want %v", opts.ActionURLMap, want)
+	}
+	if want := map[string][]string{"service": {"notes"}}; !reflect.DeepEqual(opts.StripProperties, want) {
+		t.Errorf("StripProperties = %v, want %v", opts.StripProperties, want)
+	}
+}
+
+func TestApplyMapFile_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"invalid":          "teams: [a]\n",
+		"blueprint rename": "blueprints:\n  service: microservice\n",
+	} {
+		var opts migrate.Options
+		err := applyMapFile(writeMapFile(t, content), &opts)
+		if exitcode.Code(err) != exitcode.Usage {
+			t.Errorf("%s: expected a usage error, got %v", name, err)
+		}
+	}
+}
@@ -1,12 +1,50 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/modules/migrate"
 	"github.com/port-experimental/port-cli/internal/output"
 )
 
+// addMigrationCountsJSON adds the per-resource created, updated and skipped
+// counts of result to data, the same for single and batch migrations.
+func addMigrationCountsJSON(data map[string]interface{}, result *migrate.Result) {
+	data["blueprints_created"] = result.BlueprintsCreated
+	data["blueprints_updated"] = result.BlueprintsUpdated
+	data["blueprints_skipped"] = result.BlueprintsSkipped
+	data["entities_created"] = result.EntitiesCreated
+	data["entities_updated"] = result.EntitiesUpdated
+	data["entities_skipped"] = result.EntitiesSkipped
+	data["scorecards_created"] = result.ScorecardsCreated
+	data["scorecards_updated"] = result.ScorecardsUpdated
+	data["scorecards_skipped"] = result.ScorecardsSkipped
+	data["actions_created"] = result.ActionsCreated
+	data["actions_updated"] = result.ActionsUpdated
+	data["actions_skipped"] = result.ActionsSkipped
+	data["teams_created"] = result.TeamsCreated
+	data["teams_updated"] = result.TeamsUpdated
+	data["teams_skipped"] = result.TeamsSkipped
+	data["team_members_added"] = result.TeamMembersAdded
+	data["team_members_removed"] = result.TeamMembersRemoved
+	data["users_created"] = result.UsersCreated
+	data["users_updated"] = result.UsersUpdated
+	data["users_skipped"] = result.UsersSkipped
+	data["pages_created"] = result.PagesCreated
+	data["pages_updated"] = result.PagesUpdated
+	data["pages_skipped"] = result.PagesSkipped
+	data["integrations_created"] = result.IntegrationsCreated
+	data["integrations_updated"] = result.IntegrationsUpdated
+	data["integrations_skipped"] = result.IntegrationsSkipped
+	data["datasources_created"] = result.DataSourcesCreated
+	data["datasources_updated"] = result.DataSourcesUpdated
+	data["datasources_skipped"] = result.DataSourcesSkipped
+	data["blueprint_permissions_updated"] = result.BlueprintPermissionsUpdated
+	data["action_permissions_updated"] = result.ActionPermissionsUpdated
+	data["page_permissions_updated"] = result.PagePermissionsUpdated
+}
+
 func addMigrationDetailJSON(data map[string]interface{}, result *migrate.Result) {
 	if result == nil {
 		return
@@ -53,3 +91,60 @@ func printMigrationVerboseDetails(result *migrate.Result) {
 	printList("Action permissions to update", result.ActionPermissionsToUpdate)
 	printList("Page permissions to update", result.PagePermissionsToUpdate)
 }
+
+func batchTargetJSON(r migrate.TargetResult) map[string]interface{} {
+	data := map[string]interface{}{
+		"org":     r.Org,
+		"success": !r.Failed(),
+	}
+	if r.Err != nil {
+		data["error"] = r.Err.Error()
+	}
+	if r.Result == nil {
+		return data
+	}
+	data["message"] = r.Result.Message
+	addMigrationCountsJSON(data, r.Result)
+	if len(r.Result.Errors) > 0 {
+		data["errors"] = r.Result.Errors
+	}
+	addErrorDetailsJSON(data, r.Result.CategorizedErrors)
+	if len(r.Result.Warnings) > 0 {
+		data["warnings"] = r.Result.Warnings
+	}
+	return data
+}
+
+func printBatchMigrationSummary(results []migrate.TargetResult, maxErrors int) {
+	output.Printf("\nBatch migration results:\n")
+	output.Printf("  %-24s %-8s %-20s %-20s %s\n", "ORG", "STATUS", "BLUEPRINTS (C/U)", "ENTITIES (C/U)", "ERRORS")
+	for _, r := range results {
+		status := "ok"
+		if r.Failed() {
+			status = "failed"
+		}
+		bp, ent, errCount := "-", "-", 0
+		if r.Result != nil {
+			bp = fmt.Sprintf("%d/%d", r.Result.BlueprintsCreated, r.Result.BlueprintsUpdated)
+			ent = fmt.Sprintf("%d/%d", r.Result.EntitiesCreated, r.Result.EntitiesUpdated)
+			errCount = len(r.Result.Errors)
+		}
+		if errCount == 0 && r.Err != nil {
+			errCount = 1
+		}
+		output.Printf("  %-24s %-8s %-20s %-20s %d\n", r.Org, status, bp, ent, errCount)
+	}
+
+	for _, r := range results {
+		if !r.Failed() {
+			continue
+		}
+		var msg string
+		if r.Err != nil {
+			msg = migrationExecutionErrorMessage(r.Err, r.Result, maxErrors)
+		} else {
+			msg = migrationFailureMessage(r.Result, maxErrors)
+		}
+		output.ErrorPrintf("\n%s: %s\n", r.Org, msg)
+	}
+}
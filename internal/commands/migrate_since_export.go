@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+// loadSinceExport loads the --since-export snapshot. A missing file is the
+// first run of an incremental sync: every source resource counts as changed
+// and found is false.
+func loadSinceExport(path string) (data *export.Data, found bool, err error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return &export.Data{}, false, nil
+	}
+	data, err = import_module.NewLoader().LoadData(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load --since-export snapshot %s: %w", path, err)
+	}
+	if data.Manifest.Delta {
+		return nil, false, fmt.Errorf("--since-export snapshot %s is a delta bundle; pass a full export", path)
+	}
+	return data, true, nil
+}
+
+// writeSinceExport replaces the --since-export snapshot with data. It is
+// written next to path first and renamed over it, so a failed write keeps
+// the previous snapshot.
+func writeSinceExport(path string, data *export.Data) error {
+	tmp := filepath.Join(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err := export.WriteBundle(data, tmp); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write --since-export snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write --since-export snapshot: %w", err)
+	}
+	return nil
+}
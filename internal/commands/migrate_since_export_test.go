@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestSinceExportSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	data, found, err := loadSinceExport(path)
+	if err != nil || found || data == nil {
+		t.Fatalf("loadSinceExport(missing) = %v, %v, %v; want an empty snapshot", data, found, err)
+	}
+
+	snapshot := &export.Data{
+		Blueprints: []api.Blueprint{{"identifier": "service"}},
+		Entities:   []api.Entity{{"identifier": "svc-1", "blueprint": "service"}},
+	}
+	if err := writeSinceExport(path, snapshot); err != nil {
+		t.Fatalf("writeSinceExport: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), ".tmp-snapshot.json")); !os.IsNotExist(err) {
+		t.Errorf("temporary snapshot left behind: %v", err)
+	}
+
+	data, found, err = loadSinceExport(path)
+	if err != nil || !found {
+		t.Fatalf("loadSinceExport = %v, %v", found, err)
+	}
+	if len(data.Blueprints) != 1 || len(data.Entities) != 1 || data.Entities[0]["identifier"] != "svc-1" {
+		t.Errorf("loaded snapshot = %+v", data)
+	}
+}
+
+func TestLoadSinceExportRejectsDeltaBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delta.json")
+	if err := writeSinceExport(path, &export.Data{Manifest: export.Manifest{Delta: true}}); err != nil {
+		t.Fatalf("writeSinceExport: %v", err)
+	}
+	if _, _, err := loadSinceExport(path); err == nil || !strings.Contains(err.Error(), "delta bundle") {
+		t.Errorf("loadSinceExport(delta) error = %v, want a delta bundle error", err)
+	}
+}
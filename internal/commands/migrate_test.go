@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/port-experimental/port-cli/internal/modules/migrate"
 	"github.com/spf13/cobra"
 )
@@ -308,3 +311,40 @@ func TestMigrationExecutionErrorMessageUsesPartialResultErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckDistinctOrgsRequiresYesForSameClientID(t *testing.T) {
+	source := &config.OrganizationConfig{ClientID: "abcd1234efgh5678", APIURL: "https://api.getport.io/v1"}
+	same := &config.OrganizationConfig{ClientID: "abcd1234efgh5678", APIURL: "https://api.getport.io/v1"}
+	other := &config.OrganizationConfig{ClientID: "zzzz1234efgh9999", APIURL: "https://api.getport.io/v1"}
+
+	cmd := &cobra.Command{Use: "migrate"}
+	cmd.SetContext(context.Background())
+	if err := checkDistinctOrgs(cmd, source, other, "prod", "staging", "text"); err != nil {
+		t.Fatalf("expected distinct orgs to pass, got %v", err)
+	}
+	err := checkDistinctOrgs(cmd, source, same, "prod", "prod-copy", "text")
+	if exitcode.Code(err) != exitcode.Usage || !strings.Contains(err.Error(), "abcd********5678") || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected a usage error naming the masked client ID, got %v", err)
+	}
+
+	cmd.SetContext(WithGlobalFlags(context.Background(), GlobalFlags{Yes: true}))
+	if err := checkDistinctOrgs(cmd, source, same, "prod", "prod-copy", "json"); err != nil {
+		t.Fatalf("expected --yes to allow the migration, got %v", err)
+	}
+}
+
+func TestBatchTargetJSONIncludesSingleTargetCounts(t *testing.T) {
+	result := &migrate.Result{Success: true, TeamsCreated: 2, IntegrationsSkipped: 1, PagePermissionsUpdated: 3}
+	data := batchTargetJSON(migrate.TargetResult{Org: "tenant-a", Result: result})
+
+	single := map[string]interface{}{}
+	addMigrationCountsJSON(single, result)
+	for key, want := range single {
+		if got, ok := data[key]; !ok || got != want {
+			t.Errorf("batch JSON %s = %v, want %v", key, got, want)
+		}
+	}
+	if data["teams_created"] != 2 || data["integrations_skipped"] != 1 || data["page_permissions_updated"] != 3 {
+		t.Errorf("unexpected batch counts: %v", data)
+	}
+}
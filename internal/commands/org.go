@@ -0,0 +1,17 @@
+package commands
+
+import "os"
+
+// orgEnvVar names the environment variable that selects the organization for
+// every command when no org flag is given.
+const orgEnvVar = "PORT_ORG"
+
+// resolveOrg returns the organization a command should use: the value of its
+// org flag if set, otherwise PORT_ORG. An empty result leaves the choice to
+// the configuration, which falls back to its default organization.
+func resolveOrg(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(orgEnvVar)
+}
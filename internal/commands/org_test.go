@@ -0,0 +1,19 @@
+package commands
+
+import "testing"
+
+func TestResolveOrg(t *testing.T) {
+	t.Setenv(orgEnvVar, "staging")
+
+	if got := resolveOrg("production"); got != "production" {
+		t.Fatalf("flag should win over %s, got %q", orgEnvVar, got)
+	}
+	if got := resolveOrg(""); got != "staging" {
+		t.Fatalf("expected %s fallback, got %q", orgEnvVar, got)
+	}
+
+	t.Setenv(orgEnvVar, "")
+	if got := resolveOrg(""); got != "" {
+		t.Fatalf("expected empty org so the config default applies, got %q", got)
+	}
+}
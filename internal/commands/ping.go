@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultPingTimeout bounds a ping end to end, authentication included, so
+// readiness probes fail fast instead of waiting on the client's long default.
+const defaultPingTimeout = 10 * time.Second
+
+// RegisterPing registers the ping command.
+func RegisterPing(rootCmd *cobra.Command) {
+	var (
+		org          string
+		timeout      time.Duration
+		outputFormat string
+	)
+
+	pingCmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that the Port API is reachable for one organization",
+		Long: `Check that the Port API is reachable for one organization.
+
+Authenticates with the organization's credentials and fetches the
+organization, printing the latency and the organization identifier. Exits
+nonzero if the API is unreachable or authentication fails, so it can be used
+as a readiness probe:
+
+  until port ping --org production --timeout 5s; do sleep 2; done`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+			if timeout <= 0 {
+				return exitcode.Usagef("--timeout must be positive")
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return exitcode.Usagef("failed to load configuration: %w", err)
+			}
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return exitcode.New(exitcode.Usage, err)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			start := time.Now()
+			token, err := configManager.GetOrRefreshToken(ctx, useOrg)
+			if err != nil && !config.ShouldIgnoreGetOrRefreshTokenError(err) {
+				return pingFailed(outputFormat, orgConfig.APIURL, err)
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      timeout,
+			})
+			defer client.Close()
+
+			organization, err := client.GetOrganization(ctx)
+			if err != nil {
+				return pingFailed(outputFormat, orgConfig.APIURL, err)
+			}
+			latency := time.Since(start)
+
+			orgID, _ := organization["id"].(string)
+			orgName, _ := organization["name"].(string)
+			if outputFormat == "json" {
+				return output.PrintJSON(output.JSONResult{
+					Success: true,
+					Message: "Port API is reachable",
+					Data: map[string]interface{}{
+						"api_url":    orgConfig.APIURL,
+						"org_id":     orgID,
+						"org_name":   orgName,
+						"latency_ms": latency.Milliseconds(),
+					},
+				})
+			}
+			output.SuccessPrint("✓ %s reachable in %dms (organization: %s)\n", orgConfig.APIURL, latency.Milliseconds(), describeOrganization(orgID, orgName))
+			return nil
+		},
+	}
+
+	pingCmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	pingCmd.Flags().DurationVar(&timeout, "timeout", defaultPingTimeout, "Fail if authentication and the API call take longer than this")
+	pingCmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	rootCmd.AddCommand(pingCmd)
+}
+
+// pingFailed reports an unreachable API and returns the error that sets the
+// exit code.
+func pingFailed(outputFormat, apiURL string, err error) error {
+	err = fmt.Errorf("port API at %s is not reachable: %w", apiURL, err)
+	if outputFormat == "json" {
+		_ = output.PrintJSON(output.JSONResult{Success: false, Error: err.Error()})
+	}
+	return err
+}
+
+// describeOrganization formats an organization for display, preferring its
+// name and falling back to the identifier.
+func describeOrganization(id, name string) string {
+	switch {
+	case name != "" && id != "":
+		return fmt.Sprintf("%s (%s)", name, id)
+	case name != "":
+		return name
+	case id != "":
+		return id
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPingFlagsParsed(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "port"}
+	RegisterPing(rootCmd)
+
+	pingCmd, _, _ := rootCmd.Find([]string{"ping"})
+	if pingCmd == nil || pingCmd.Name() != "ping" {
+		t.Fatal("ping command not found")
+	}
+	if err := pingCmd.ParseFlags([]string{"--org", "production", "--timeout", "3s"}); err != nil {
+		t.Fatalf("unexpected error parsing flags: %v", err)
+	}
+	timeout, err := pingCmd.Flags().GetDuration("timeout")
+	if err != nil {
+		t.Fatalf("could not get --timeout: %v", err)
+	}
+	if timeout != 3*time.Second {
+		t.Errorf("expected 3s, got %v", timeout)
+	}
+}
+
+func TestDescribeOrganization(t *testing.T) {
+	tests := []struct {
+		id, name, want string
+	}{
+		{"org_123", "Acme", "Acme (org_123)"},
+		{"", "Acme", "Acme"},
+		{"org_123", "", "org_123"},
+		{"", "", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := describeOrganization(tt.id, tt.name); got != tt.want {
+			t.Errorf("describeOrganization(%q, %q) = %q, want %q", tt.id, tt.name, got, tt.want)
+		}
+	}
+}
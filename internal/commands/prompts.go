@@ -62,3 +62,13 @@ func confirmPrompt(title, description string) (bool, error) {
 	}
 	return confirmed, nil
 }
+
+// askYesNo prints question with a [y/N] suffix and reads one line of answer,
+// reporting whether it was y or Y. Unlike confirmPrompt it also works when
+// stdin is not a terminal.
+func askYesNo(cmd *cobra.Command, question string) bool {
+	cmd.Printf("%s [y/N]: ", question)
+	var response string
+	fmt.Fscanln(cmd.InOrStdin(), &response)
+	return response == "y" || response == "Y"
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/spf13/cobra"
+)
+
+// profileResourceTypes are the resource types a profile's include list may
+// name, as accepted by --include.
+var profileResourceTypes = map[string]bool{
+	"blueprints":            true,
+	"entities":              true,
+	"scorecards":            true,
+	"actions":               true,
+	"teams":                 true,
+	"users":                 true,
+	"automations":           true,
+	"pages":                 true,
+	"integrations":          true,
+	"datasources":           true,
+	"blueprint-permissions": true,
+	"action-permissions":    true,
+	"page-permissions":      true,
+}
+
+// validateResourceProfile checks the include list of a profile the way
+// --include is checked.
+func validateResourceProfile(profile config.ResourceProfile) error {
+	if len(profile.Include) == 0 {
+		return nil
+	}
+	spec, err := export.ParseIncludeSpec(strings.Join(profile.Include, ","))
+	if err != nil {
+		return err
+	}
+	for _, r := range spec.Resources {
+		if !profileResourceTypes[r] {
+			return fmt.Errorf("invalid resource: %s. Valid resources: blueprints, entities, scorecards, actions, teams, users, automations, pages, integrations, datasources, blueprint-permissions, action-permissions, page-permissions, permissions", r)
+		}
+	}
+	return nil
+}
+
+// applyResourceProfile fills the resource selection flags of export, import
+// and migrate from the profile called name in the config file. Flags given on
+// the command line keep their value. An empty name applies nothing.
+func applyResourceProfile(cmd *cobra.Command, cfg *config.Config, name string, include, excludeBlueprints, excludeBlueprintSchema *string, skipEntities *bool) error {
+	if name == "" {
+		return nil
+	}
+	profile, err := cfg.GetProfile(name)
+	if err != nil {
+		return exitcode.New(exitcode.Usage, err)
+	}
+	if err := validateResourceProfile(profile); err != nil {
+		return exitcode.Usagef("invalid profile %q: %w", name, err)
+	}
+
+	flags := cmd.Flags()
+	if len(profile.Include) > 0 && !flags.Changed("include") {
+		*include = strings.Join(profile.Include, ",")
+	}
+	if len(profile.ExcludeBlueprints) > 0 && !flags.Changed("exclude-blueprints") {
+		*excludeBlueprints = strings.Join(profile.ExcludeBlueprints, ",")
+	}
+	if len(profile.ExcludeBlueprintSchema) > 0 && !flags.Changed("exclude-blueprint-schema") {
+		*excludeBlueprintSchema = strings.Join(profile.ExcludeBlueprintSchema, ",")
+	}
+	if profile.SkipEntities && !flags.Changed("skip-entities") {
+		*skipEntities = true
+	}
+	return nil
+}
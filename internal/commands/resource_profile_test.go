@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyResourceProfile(t *testing.T) {
+	cfg := &config.Config{Profiles: map[string]config.ResourceProfile{
+		"schema-only": {
+			Include:           []string{"blueprints", "scorecards:team-*", "permissions"},
+			ExcludeBlueprints: []string{"_rule", "legacy"},
+			SkipEntities:      true,
+		},
+		"typo": {Include: []string{"blueprint"}},
+	}}
+
+	var include, excludeBlueprints, excludeBlueprintSchema string
+	var skipEntities bool
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&include, "include", "", "")
+	cmd.Flags().StringVar(&excludeBlueprints, "exclude-blueprints", "", "")
+	cmd.Flags().StringVar(&excludeBlueprintSchema, "exclude-blueprint-schema", "", "")
+	cmd.Flags().BoolVar(&skipEntities, "skip-entities", false, "")
+	if err := cmd.Flags().Parse([]string{"--exclude-blueprints", "audit"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyResourceProfile(cmd, cfg, "schema-only", &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err != nil {
+		t.Fatalf("applyResourceProfile: %v", err)
+	}
+	if include != "blueprints,scorecards:team-*,permissions" {
+		t.Errorf("include = %q", include)
+	}
+	if excludeBlueprints != "audit" {
+		t.Errorf("excludeBlueprints = %q, want the command line value", excludeBlueprints)
+	}
+	if excludeBlueprintSchema != "" || !skipEntities {
+		t.Errorf("excludeBlueprintSchema = %q, skipEntities = %v", excludeBlueprintSchema, skipEntities)
+	}
+
+	if err := applyResourceProfile(cmd, cfg, "typo", &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err == nil {
+		t.Error("expected an error for an invalid resource name")
+	}
+	if err := applyResourceProfile(cmd, cfg, "missing", &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+	if err := applyResourceProfile(cmd, cfg, "", &include, &excludeBlueprints, &excludeBlueprintSchema, &skipEntities); err != nil {
+		t.Errorf("no profile: %v", err)
+	}
+}
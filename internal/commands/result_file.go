@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/output"
+)
+
+// structuredOutput reports whether outputFormat prints the command result as
+// data (json or yaml) rather than text.
+func structuredOutput(outputFormat string) bool {
+	return outputFormat == "json" || outputFormat == "yaml"
+}
+
+func validateResultFileFlag(resultFile, outputFormat string) error {
+	if resultFile != "" && !structuredOutput(outputFormat) {
+		return exitcode.Usagef("--result-file requires --output-format json or yaml")
+	}
+	return nil
+}
+
+// printJSONResult writes a command's final JSON result to resultFile, keeping
+// it apart from anything else written to stdout, or to stdout when no result
+// file was given.
+func printJSONResult(resultFile string, data interface{}) error {
+	if resultFile == "" {
+		return output.PrintJSON(data)
+	}
+	return output.WriteJSONFile(resultFile, data)
+}
+
+// printResult writes a command's final result like printJSONResult, as YAML
+// when outputFormat is yaml. Both carry the same keys and values.
+func printResult(resultFile, outputFormat string, data interface{}) error {
+	if outputFormat != "yaml" {
+		return printJSONResult(resultFile, data)
+	}
+	if resultFile == "" {
+		return output.PrintYAML(data)
+	}
+	return output.WriteYAMLFile(resultFile, data)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+)
+
+func TestValidateResultFileFlag(t *testing.T) {
+	if err := validateResultFileFlag("", "text"); err != nil {
+		t.Errorf("expected no result file to be valid, got %v", err)
+	}
+	if err := validateResultFileFlag("result.json", "json"); err != nil {
+		t.Errorf("expected a result file with JSON output to be valid, got %v", err)
+	}
+	if err := validateResultFileFlag("result.yaml", "yaml"); err != nil {
+		t.Errorf("expected a result file with YAML output to be valid, got %v", err)
+	}
+	if err := validateResultFileFlag("result.json", "text"); exitcode.Code(err) != exitcode.Usage {
+		t.Errorf("expected a usage error with text output, got %v", err)
+	}
+}
+
+func TestPrintJSONResultWritesResultFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	if err := printJSONResult(path, map[string]interface{}{"success": true, "entities_created": 2}); err != nil {
+		t.Fatalf("printJSONResult: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("result file is not JSON: %v\n%s", err, content)
+	}
+	if got["success"] != true || got["entities_created"] != float64(2) {
+		t.Errorf("unexpected result %v", got)
+	}
+}
+
+func TestPrintResultWritesYAMLResultFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.yaml")
+	if err := printResult(path, "yaml", map[string]interface{}{"success": true, "entities_created": 2}); err != nil {
+		t.Fatalf("printResult: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "entities_created: 2\nsuccess: true\n"; string(content) != want {
+		t.Errorf("result file = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+)
+
+const retryBudgetUsage = "Maximum number of API retries across the whole operation; once spent, failing requests are not retried (0 means no limit)"
+
+func validateRetryBudgetFlag(retryBudget int) error {
+	if retryBudget < 0 {
+		return exitcode.Usagef("--retry-budget must be 0 or greater")
+	}
+	return nil
+}
+
+// withRetryBudget returns ctx carrying a retry budget of n shared by every API
+// request made with it. Once the budget is spent, the context is canceled
+// with the exhausted error as its cause, so the operation stops instead of
+// failing request after request; see retryBudgetExit. With --debug, the
+// remaining budget is logged to stderr after each retry. n = 0 leaves retries
+// limited only per request. Call the returned function once the operation is
+// done.
+func withRetryBudget(ctx context.Context, n int, debug bool) (context.Context, context.CancelFunc) {
+	if n == 0 {
+		return ctx, func() {}
+	}
+	var debugOut io.Writer
+	if debug {
+		debugOut = os.Stderr
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	budget := api.NewRetryBudget(n, debugOut)
+	budget.OnExhausted(cancel)
+	return api.WithRetryBudget(ctx, budget), func() { cancel(nil) }
+}
+
+// retryBudgetExit makes err exit with exitcode.RetryBudgetExhausted when the
+// operation run with ctx was stopped by its retry budget, and returns err
+// unchanged otherwise.
+func retryBudgetExit(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, api.ErrRetryBudgetExhausted) || errors.Is(context.Cause(ctx), api.ErrRetryBudgetExhausted) {
+		return exitcode.New(exitcode.RetryBudgetExhausted, err)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestValidateRetryBudgetFlagRejectsNegativeValues(t *testing.T) {
+	if err := validateRetryBudgetFlag(-1); exitcode.Code(err) != exitcode.Usage {
+		t.Fatalf("expected a usage error for -1, got %v", err)
+	}
+	if err := validateRetryBudgetFlag(0); err != nil {
+		t.Fatalf("expected 0 to be allowed, got %v", err)
+	}
+}
+
+func TestWithRetryBudgetZeroLeavesContextUnchanged(t *testing.T) {
+	ctx := context.Background()
+	if got, stop := withRetryBudget(ctx, 0, false); got != ctx {
+		t.Fatal("expected a zero budget to leave the context unchanged")
+	} else {
+		stop()
+	}
+	got, stop := withRetryBudget(ctx, 3, false)
+	defer stop()
+	if got == ctx {
+		t.Fatal("expected a positive budget to attach to the context")
+	}
+}
+
+func TestRetryBudgetExhaustionStopsImport(t *testing.T) {
+	var blueprints strings.Builder
+	for i := 0; i < 50; i++ {
+		if i > 0 {
+			blueprints.WriteString(",")
+		}
+		fmt.Fprintf(&blueprints, `{"identifier": "bp%d"}`, i)
+	}
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(inputPath, []byte(`{"blueprints": [`+blueprints.String()+`]}`), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	var writes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			// Every write is throttled, so each one needs retries.
+			writes.Add(1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	ctx, stop := withRetryBudget(context.Background(), 2, false)
+	defer stop()
+	module := import_module.NewModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	defer module.Close()
+	_, err := module.Execute(ctx, import_module.Options{InputPath: inputPath})
+
+	if code := exitcode.Code(retryBudgetExit(ctx, err)); code != exitcode.RetryBudgetExhausted {
+		t.Fatalf("exit code = %d, want %d (err: %v)", code, exitcode.RetryBudgetExhausted, err)
+	}
+	if !errors.Is(context.Cause(ctx), api.ErrRetryBudgetExhausted) {
+		t.Errorf("context cause = %v, want the retry budget error", context.Cause(ctx))
+	}
+	// Without stopping, each of the 50 blueprints would be attempted.
+	if got := writes.Load(); got >= 50 {
+		t.Errorf("import made %d write attempts after the budget ran out, want it to stop", got)
+	}
+}
+
+func TestRetryBudgetExit(t *testing.T) {
+	ctx := context.Background()
+	if err := retryBudgetExit(ctx, nil); err != nil {
+		t.Errorf("retryBudgetExit(nil) = %v, want nil", err)
+	}
+	plain := exitcode.New(exitcode.Partial, errors.New("interrupted"))
+	if got := exitcode.Code(retryBudgetExit(ctx, plain)); got != exitcode.Partial {
+		t.Errorf("unrelated error exit code = %d, want %d", got, exitcode.Partial)
+	}
+	exhausted := fmt.Errorf("import failed: %w", api.ErrRetryBudgetExhausted)
+	if got := exitcode.Code(retryBudgetExit(ctx, exhausted)); got != exitcode.RetryBudgetExhausted {
+		t.Errorf("exhausted error exit code = %d, want %d", got, exitcode.RetryBudgetExhausted)
+	}
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// RegisterSchema registers the schema command.
+func RegisterSchema(rootCmd *cobra.Command) {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print machine-readable schemas for CLI file formats",
+	}
+
+	schemaCmd.AddCommand(&cobra.Command{
+		Use:   "export-format",
+		Short: "Print the JSON Schema of the export format",
+		Long: `Print the JSON Schema of the export format.
+
+The schema describes the JSON document written by 'port export --format json':
+one object whose top-level keys (blueprints, entities, scorecards, ...) hold
+each resource type, and the identifier fields every item must have. Tar and
+NDJSON exports carry the same sections as separate files or records.
+
+'port import' checks JSON input files against this structure before importing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return output.PrintJSON(export.FormatSchema())
+		},
+	})
+
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// scorecardLevelCount is the number of entities that reached one level.
+type scorecardLevelCount struct {
+	Level string `json:"level" yaml:"level"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// scorecardEvaluation summarizes where a blueprint's entities stand in a
+// scorecard.
+type scorecardEvaluation struct {
+	Blueprint    string                `json:"blueprint" yaml:"blueprint"`
+	Scorecard    string                `json:"scorecard" yaml:"scorecard"`
+	Entities     int                   `json:"entities" yaml:"entities"`
+	Levels       []scorecardLevelCount `json:"levels" yaml:"levels"`
+	NotEvaluated int                   `json:"notEvaluated" yaml:"notEvaluated"`
+}
+
+// evaluateScorecard counts results by level. Levels are listed in the order
+// the scorecard defines them, each one even when no entity reached it, then
+// any level the scorecard no longer defines in alphabetical order.
+func evaluateScorecard(blueprintID string, scorecard api.Scorecard, results []api.ScorecardResult) scorecardEvaluation {
+	scorecardID, _ := scorecard["identifier"].(string)
+	evaluation := scorecardEvaluation{Blueprint: blueprintID, Scorecard: scorecardID, Entities: len(results), Levels: []scorecardLevelCount{}}
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		if result.Level == "" {
+			evaluation.NotEvaluated++
+			continue
+		}
+		counts[result.Level]++
+	}
+
+	levels, _ := scorecard["levels"].([]interface{})
+	for _, level := range levels {
+		def, _ := level.(map[string]interface{})
+		title, _ := def["title"].(string)
+		if title == "" {
+			continue
+		}
+		evaluation.Levels = append(evaluation.Levels, scorecardLevelCount{Level: title, Count: counts[title]})
+		delete(counts, title)
+	}
+	undefined := make([]string, 0, len(counts))
+	for title := range counts {
+		undefined = append(undefined, title)
+	}
+	sort.Strings(undefined)
+	for _, title := range undefined {
+		evaluation.Levels = append(evaluation.Levels, scorecardLevelCount{Level: title, Count: counts[title]})
+	}
+	return evaluation
+}
+
+// writeScorecardEvaluation prints evaluation as a level table.
+func writeScorecardEvaluation(w io.Writer, evaluation scorecardEvaluation) {
+	fmt.Fprintf(w, "Scorecard %s on %s: %d entities\n", evaluation.Scorecard, evaluation.Blueprint, evaluation.Entities)
+	width := len("not evaluated")
+	for _, level := range evaluation.Levels {
+		if len(level.Level) > width {
+			width = len(level.Level)
+		}
+	}
+	for _, level := range evaluation.Levels {
+		fmt.Fprintf(w, "  %-*s  %d\n", width, level.Level, level.Count)
+	}
+	if evaluation.NotEvaluated > 0 {
+		fmt.Fprintf(w, "  %-*s  %d\n", width, "not evaluated", evaluation.NotEvaluated)
+	}
+}
+
+// registerScorecardEvaluate registers the scorecard evaluate command.
+func registerScorecardEvaluate() *cobra.Command {
+	var org, format string
+
+	cmd := &cobra.Command{
+		Use:   "evaluate [blueprint-id] [scorecard-id]",
+		Short: "Summarize how many entities reached each scorecard level",
+		Long: `Summarize how many entities reached each scorecard level.
+
+Port evaluates scorecards on its own as entities change; this command reads
+the current results and counts the entities at each level, in the order the
+scorecard defines its levels. Entities the scorecard has not evaluated yet are
+counted separately. Use it to check scorecards after a migration, and
+'port api scorecards levels' to see the level of each entity.`,
+		Example: `  port api scorecards evaluate service production-readiness
+  port api scorecards evaluate service production-readiness --format json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blueprintID := args[0]
+			scorecardID := args[1]
+			if err := validateStringEnum("--format", format, []string{"text", "json", "yaml"}); err != nil {
+				return err
+			}
+
+			flags := GetGlobalFlags(cmd.Context())
+			configManager := newConfigManager(flags)
+
+			org = resolveOrg(org)
+			cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, org)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			useOrg := cfg.GetOrgOrDefault(org)
+			orgConfig, err := cfg.GetOrgConfig(useOrg)
+			if err != nil {
+				return err
+			}
+			token, err := getOrRefreshCommandToken(cmd, configManager, useOrg)
+			if err != nil {
+				return err
+			}
+			client := api.NewClient(api.ClientOpts{
+				Token:        token,
+				ClientID:     orgConfig.ClientID,
+				ClientSecret: orgConfig.ClientSecret,
+				APIURL:       orgConfig.APIURL,
+				RateLimit:    orgConfig.RateLimit,
+				Concurrency:  orgConfig.Concurrency,
+				Headers:      orgConfig.Headers,
+				APIVersion:   orgConfig.APIVersion,
+				CacheTTL:     orgConfig.CacheTTL,
+				NoCache:      orgConfig.NoCache,
+				Timeout:      0,
+			})
+			defer client.Close()
+
+			scorecards, err := client.GetScorecards(cmd.Context(), blueprintID)
+			if err != nil {
+				return fmt.Errorf("failed to list scorecards: %w", err)
+			}
+			var scorecard api.Scorecard
+			for _, sc := range scorecards {
+				if id, _ := sc["identifier"].(string); id == scorecardID {
+					scorecard = sc
+					break
+				}
+			}
+			if scorecard == nil {
+				return fmt.Errorf("scorecard %q not found on blueprint %q", scorecardID, blueprintID)
+			}
+
+			results, err := client.GetScorecardResults(cmd.Context(), blueprintID, scorecardID)
+			if err != nil {
+				return fmt.Errorf("failed to get scorecard results: %w", err)
+			}
+
+			evaluation := evaluateScorecard(blueprintID, scorecard, results)
+			if format == "text" {
+				writeScorecardEvaluation(os.Stdout, evaluation)
+				return nil
+			}
+			return formatOutput(evaluation, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&org, "org", "", "Organization name (uses default if not specified)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format: text, json, yaml")
+
+	return cmd
+}
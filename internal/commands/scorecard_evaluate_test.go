@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestEvaluateScorecard(t *testing.T) {
+	scorecard := api.Scorecard{
+		"identifier": "readiness",
+		"levels": []interface{}{
+			map[string]interface{}{"title": "Basic", "color": "paleBlue"},
+			map[string]interface{}{"title": "Bronze", "color": "bronze"},
+			map[string]interface{}{"title": "Gold", "color": "gold"},
+		},
+	}
+	results := []api.ScorecardResult{
+		{Identifier: "a", Level: "Bronze"},
+		{Identifier: "b", Level: "Basic"},
+		{Identifier: "c", Level: "Bronze"},
+		{Identifier: "d", Level: "Silver"},
+		{Identifier: "e"},
+	}
+
+	evaluation := evaluateScorecard("service", scorecard, results)
+	want := []scorecardLevelCount{{"Basic", 1}, {"Bronze", 2}, {"Gold", 0}, {"Silver", 1}}
+	if !reflect.DeepEqual(evaluation.Levels, want) {
+		t.Errorf("Levels = %v, want %v", evaluation.Levels, want)
+	}
+	if evaluation.Entities != 5 || evaluation.NotEvaluated != 1 || evaluation.Scorecard != "readiness" || evaluation.Blueprint != "service" {
+		t.Errorf("unexpected evaluation %+v", evaluation)
+	}
+
+	var buf bytes.Buffer
+	writeScorecardEvaluation(&buf, evaluation)
+	for _, line := range []string{"Scorecard readiness on service: 5 entities", "Bronze         2", "not evaluated  1"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("output missing %q:\n%s", line, buf.String())
+		}
+	}
+}
@@ -82,7 +82,7 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			flags := GetGlobalFlags(ctx)
-			configManager := config.NewConfigManager(flags.ConfigFile)
+			configManager := newConfigManager(flags)
 			org := skillsOrgName(cmd)
 
 			explicitTools := cmd.Flags().Changed("tool")
@@ -10,7 +10,7 @@ import (
 )
 
 func newSkillsModuleWithFlags(ctx context.Context, flags GlobalFlags, orgName string) (*skills.Module, *config.ConfigManager, error) {
-	configManager := config.NewConfigManager(flags.ConfigFile)
+	configManager := newConfigManager(flags)
 	cfg, err := configManager.LoadWithOverrides(flags.ClientID, flags.ClientSecret, flags.APIURL, orgName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
@@ -31,22 +31,22 @@ func newSkillsModuleWithFlags(ctx context.Context, flags GlobalFlags, orgName st
 
 func skillsOrgName(cmd *cobra.Command) string {
 	if cmd == nil {
-		return ""
+		return resolveOrg("")
 	}
 	if cmd.Parent() != nil {
 		org, _ := cmd.Parent().PersistentFlags().GetString("org")
-		return org
+		return resolveOrg(org)
 	}
-	return ""
+	return resolveOrg("")
 }
 
 func newSkillsModule(flags GlobalFlags) (*skills.Module, *config.ConfigManager, error) {
-	configManager := config.NewConfigManager(flags.ConfigFile)
+	configManager := newConfigManager(flags)
 	cfg, err := configManager.Load()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
-	orgCfg := &config.OrganizationConfig{APIURL: "https://api.getport.io/v1"}
+	orgCfg := &config.OrganizationConfig{APIURL: cfg.DefaultAPIURL()}
 	orgName := cfg.DefaultOrg
 	if orgName != "" {
 		if oc, ocErr := cfg.GetOrgConfig(orgName); ocErr == nil {
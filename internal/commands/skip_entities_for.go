@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// skipEntitiesForUsage is the help text of the --skip-entities-for flag of
+// export, import and migrate.
+const skipEntitiesForUsage = "Comma-separated blueprint IDs or globs (e.g. 'k8s-*') whose entities are skipped; their schemas, scorecards and actions are kept"
+
+// parseSkipEntitiesFor splits the --skip-entities-for value into blueprint
+// identifiers and globs.
+func parseSkipEntitiesFor(value string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if err := export.ValidateBlueprintPatterns(patterns); err != nil {
+		return nil, exitcode.Usagef("invalid --skip-entities-for: %w", err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+)
+
+func TestParseSkipEntitiesFor(t *testing.T) {
+	got, err := parseSkipEntitiesFor(" k8s-pod, log-*,,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"k8s-pod", "log-*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got, err := parseSkipEntitiesFor(""); err != nil || got != nil {
+		t.Errorf("expected nothing for an empty value, got %v, %v", got, err)
+	}
+
+	_, err = parseSkipEntitiesFor("k8s-[")
+	if err == nil || exitcode.Code(err) != exitcode.Usage {
+		t.Errorf("expected a usage error for a malformed glob, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"math"
+	"time"
+
+	"github.com/port-experimental/port-cli/internal/metrics"
+	"github.com/port-experimental/port-cli/internal/output"
+)
+
+const timingsUsage = "Print how long each phase took (source export, diff, target import per resource type) at the end"
+
+// addTimingsJSON adds the recorded phases to jsonData as a "timings" object of
+// seconds keyed by phase name. Nothing is added when timing was not requested.
+func addTimingsJSON(jsonData map[string]interface{}, timings *metrics.Timings) {
+	phases := timings.Phases()
+	if len(phases) == 0 {
+		return
+	}
+	seconds := make(map[string]float64, len(phases))
+	for _, p := range phases {
+		seconds[p.Name] = math.Round(p.Duration.Seconds()*1000) / 1000
+	}
+	jsonData["timings"] = seconds
+}
+
+// printTimings prints the recorded phases, in the order they started.
+func printTimings(timings *metrics.Timings) {
+	phases := timings.Phases()
+	if len(phases) == 0 {
+		return
+	}
+	output.Printf("\nTimings:\n")
+	for _, p := range phases {
+		output.Printf("  %-24s %s\n", p.Name, p.Duration.Round(time.Millisecond))
+	}
+}
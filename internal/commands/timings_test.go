@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/metrics"
+)
+
+func TestAddTimingsJSON(t *testing.T) {
+	jsonData := map[string]interface{}{}
+	addTimingsJSON(jsonData, nil)
+	if _, ok := jsonData["timings"]; ok {
+		t.Fatal("expected no timings key when timing was not requested")
+	}
+
+	timings := metrics.New()
+	timings.Start("export")()
+	timings.Start("diff")()
+	addTimingsJSON(jsonData, timings)
+	got, ok := jsonData["timings"].(map[string]float64)
+	if !ok {
+		t.Fatalf("expected a timings object, got %#v", jsonData["timings"])
+	}
+	if _, ok := got["export"]; !ok || len(got) != 2 {
+		t.Errorf("expected export and diff phases, got %v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/port-experimental/port-cli/internal/modules/migrate"
+	"github.com/port-experimental/port-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// RegisterValidateMapping registers the validate-mapping command.
+func RegisterValidateMapping(rootCmd *cobra.Command) {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "validate-mapping [file]",
+		Short: "Check a migration mapping file",
+		Long: `Check a migration mapping file, without contacting Port.
+
+A mapping file is YAML with up to five sections:
+
+  blueprints:        # source blueprint identifier -> target identifier
+    service: microservice
+  entities:          # "<blueprint>/<identifier>" -> target identifier
+    service/payments: payments-api
+  teams:             # source team name -> target team name
+    platform: platform-eng
+  action_urls:       # invocation value -> replacement
+    hooks.prod.example.com: hooks.staging.example.com
+  strip_properties:  # blueprint identifier -> properties left out
+    service: [internal_notes]
+
+Unknown sections, sources mapped twice, empty names, entity keys without a
+blueprint, and two blueprints or entities mapped to the same identifier are
+reported with their line. The command exits with code 3 when any issue is
+found. 'port migrate --map-file' applies the teams, action_urls and
+strip_properties sections.`,
+		Example: `  port validate-mapping mapping.yaml
+  port validate-mapping mapping.yaml --output-format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateStringEnum("--output-format", outputFormat, []string{"text", "json"}); err != nil {
+				return err
+			}
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return exitcode.Usagef("failed to read mapping file: %w", err)
+			}
+			mapping, issues, err := migrate.ParseMappingFile(data)
+			if err != nil {
+				issues = []migrate.MappingIssue{{Message: err.Error()}}
+			}
+			if issues == nil {
+				issues = []migrate.MappingIssue{}
+			}
+
+			if outputFormat == "json" {
+				if err := output.PrintJSON(map[string]interface{}{"valid": len(issues) == 0, "issues": issues}); err != nil {
+					return err
+				}
+			} else if err := writeMappingIssues(os.Stdout, mapping, issues); err != nil {
+				return err
+			}
+			if len(issues) > 0 {
+				return exitcode.New(exitcode.ResourceErrors, fmt.Errorf("found %d mapping file issue(s)", len(issues)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFormat, "output-format", "text", "Output format: text or json")
+
+	rootCmd.AddCommand(cmd)
+}
+
+func writeMappingIssues(w io.Writer, mapping *migrate.MappingFile, issues []migrate.MappingIssue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "  %s\n", issue); err != nil {
+			return err
+		}
+	}
+	if len(issues) > 0 {
+		_, err := fmt.Fprintf(w, "%d mapping file issue(s)\n", len(issues))
+		return err
+	}
+	summary := mapping.Summary()
+	if len(summary) == 0 {
+		_, err := fmt.Fprintf(w, "Mapping file is valid but maps nothing\n")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Mapping file is valid: %s\n", strings.Join(summary, ", "))
+	return err
+}
@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
 )
 
 func validateStringEnum(flagName, value string, allowed []string) error {
@@ -11,5 +12,5 @@ func validateStringEnum(flagName, value string, allowed []string) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("invalid value for %s: %s. Valid values: %s", flagName, value, strings.Join(allowed, ", "))
+	return exitcode.Usagef("invalid value for %s: %s. Valid values: %s", flagName, value, strings.Join(allowed, ", "))
 }
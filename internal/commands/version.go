@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 
+	"github.com/port-experimental/port-cli/internal/exitcode"
 	"github.com/port-experimental/port-cli/internal/output"
 	"github.com/port-experimental/port-cli/internal/update"
 	"github.com/port-experimental/port-cli/internal/useragent"
@@ -40,7 +41,12 @@ func RegisterVersion(rootCmd *cobra.Command) {
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show the CLI version",
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `Show the CLI version.
+
+With --check, the latest release is looked up on GitHub, or at backend.update_url
+or PORT_UPDATE_URL when set, and the command exits with status 1 when a newer
+release exists. Without network access the check only warns.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if GetGlobalFlags(cmd.Context()).Quiet {
 				output.QuietPrint("%s\n", buildInfo.Version)
 			} else {
@@ -54,28 +60,45 @@ func RegisterVersion(rootCmd *cobra.Command) {
 				output.Println(banner)
 			}
 
-			if check {
-				output.Printf("\nChecking for updates...\n")
-				checker := update.NewChecker()
-				result, err := checker.CheckLatestVersion(cmd.Context(), buildInfo.Version)
-				if err != nil {
-					output.WarningPrintf("Failed to check for updates: %v\n", err)
-					return
-				}
-
-				if result.UpdateAvailable {
-					output.Printf("\n%s A new version is available!\n", output.Warning("⚠"))
-					output.Printf("Current version: %s\n", buildInfo.Version)
-					output.Printf("Latest version: %s\n", output.Success(result.LatestVersion))
-					output.Printf("Download: %s\n", result.DownloadURL)
-				} else {
-					output.Printf("\n%s You are running the latest version.\n", output.Success("✓"))
-				}
+			if !check {
+				return nil
 			}
+			return checkForUpdate(cmd)
 		},
 	}
 
-	versionCmd.Flags().BoolVar(&check, "check", false, "Check for updates")
+	versionCmd.Flags().BoolVar(&check, "check", false, "Check for a newer release and exit 1 if there is one")
 
 	rootCmd.AddCommand(versionCmd)
 }
+
+// checkForUpdate compares buildInfo.Version with the latest release. It
+// fails only when a newer release exists: a failed lookup and a dev build
+// are reported and pass.
+func checkForUpdate(cmd *cobra.Command) error {
+	checker := update.NewChecker()
+	if cfg, err := newConfigManager(GetGlobalFlags(cmd.Context())).Load(); err == nil {
+		checker.SetURL(cfg.Backend.UpdateURL)
+	}
+
+	output.Printf("\nChecking for updates...\n")
+	result, err := checker.CheckLatestVersion(cmd.Context(), buildInfo.Version)
+	if err != nil {
+		output.WarningPrintf("Failed to check for updates: %v\n", err)
+		return nil
+	}
+
+	output.Printf("Current version: %s\n", buildInfo.Version)
+	output.Printf("Latest version: %s\n", result.LatestVersion)
+	switch {
+	case result.Unreleased:
+		output.Printf("\n%s %s is not a release version, so it was not compared.\n", output.Warning("⚠"), buildInfo.Version)
+	case result.UpdateAvailable:
+		output.Printf("\n%s A new version is available!\n", output.Warning("⚠"))
+		output.Printf("Download: %s\n", result.DownloadURL)
+		return exitcode.New(exitcode.Failure, fmt.Errorf("port %s is out of date; the latest release is %s", buildInfo.Version, result.LatestVersion))
+	default:
+		output.Printf("\n%s You are running the latest version.\n", output.Success("✓"))
+	}
+	return nil
+}
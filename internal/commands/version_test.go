@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/exitcode"
+	"github.com/spf13/cobra"
+)
+
+func TestCheckForUpdate(t *testing.T) {
+	t.Setenv("TESTING", "1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"tag_name": "v1.4.0", "html_url": "https://example.com"})
+	}))
+	defer server.Close()
+	t.Setenv("PORT_UPDATE_URL", server.URL)
+
+	orig := buildInfo
+	t.Cleanup(func() { buildInfo = orig })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(WithGlobalFlags(context.Background(), GlobalFlags{ConfigFile: filepath.Join(t.TempDir(), "config.yaml")}))
+
+	tests := []struct {
+		version  string
+		wantCode int
+	}{
+		{"1.3.9", exitcode.Failure},
+		{"1.4.0", exitcode.OK},
+		{"dev", exitcode.OK},
+	}
+	for _, tt := range tests {
+		buildInfo.Version = tt.version
+		if got := exitcode.Code(checkForUpdate(cmd)); got != tt.wantCode {
+			t.Errorf("version %s: exit code %d, want %d", tt.version, got, tt.wantCode)
+		}
+	}
+
+	t.Setenv("PORT_UPDATE_URL", "http://127.0.0.1:1")
+	buildInfo.Version = "1.0.0"
+	if err := checkForUpdate(cmd); err != nil {
+		t.Errorf("an unreachable releases URL should only warn, got %v", err)
+	}
+}
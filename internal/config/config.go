@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 )
 
 // OrganizationConfig represents configuration for a Port organization.
@@ -11,12 +14,71 @@ type OrganizationConfig struct {
 	ClientID     string `yaml:"client_id"`
 	ClientSecret string `yaml:"client_secret"`
 	APIURL       string `yaml:"api_url"`
+	// CredentialSource, when set, supplies ClientSecret; see CredentialProvider.
+	CredentialSource *CredentialSource `yaml:"credential_source,omitempty"`
+	// RateLimit caps requests per second to the org and Concurrency the
+	// requests in flight at once; 0 falls back to --rate-limit and
+	// --concurrency.
+	RateLimit   float64 `yaml:"rate_limit,omitempty"`
+	Concurrency int     `yaml:"concurrency,omitempty"`
+
+	// The fields below are not read from the file: GetOrgConfig fills them
+	// in from the ClientSettings of the ConfigManager that loaded the config.
+
+	// Headers are extra headers sent with every API request to the org.
+	Headers http.Header `yaml:"-"`
+	// APIVersion pins the Port API version: --api-version, else
+	// backend.api_version (or PORT_API_VERSION). Empty sends no version header.
+	APIVersion string `yaml:"-"`
+	// CacheTTL keeps successful GET responses cached for this long (0
+	// disables caching); NoCache refreshes them instead of reading them.
+	CacheTTL time.Duration `yaml:"-"`
+	NoCache  bool          `yaml:"-"`
+}
+
+// ClientSettings are the API client settings given by global flags and the
+// environment rather than per organization. A ConfigManager carrying them
+// applies them to every organization it resolves.
+type ClientSettings struct {
+	// Headers are extra headers sent with every API request (--header and
+	// PORT_HEADERS). Authorization cannot be set.
+	Headers http.Header
+	// APIVersion is the --api-version flag; it wins over backend.api_version.
+	APIVersion string
+	// RateLimit and Concurrency are --rate-limit and --concurrency, used for
+	// orgs without their own rate_limit and concurrency.
+	RateLimit   float64
+	Concurrency int
+	// CacheTTL and NoCache are --cache-ttl and --no-cache.
+	CacheTTL time.Duration
+	NoCache  bool
 }
 
 // BackendConfig represents configuration for the backend server (legacy, may not be used).
 type BackendConfig struct {
-	URL     string `yaml:"url"`
-	Timeout int    `yaml:"timeout"`
+	URL        string `yaml:"url"`
+	Timeout    int    `yaml:"timeout"`
+	APIVersion string `yaml:"api_version,omitempty"` // pins the Port API version header; empty sends none
+	// DefaultAPIURL replaces PortCloudAPIURL for orgs without an api_url,
+	// e.g. for the US region or a self-hosted Port.
+	DefaultAPIURL string `yaml:"default_api_url,omitempty"`
+	// UpdateURL replaces the GitHub releases URL port version --check asks
+	// for the latest release, e.g. for a mirror.
+	UpdateURL string `yaml:"update_url,omitempty"`
+}
+
+// PortCloudAPIURL is the API URL used when neither the org, PORT_DEFAULT_API_URL
+// nor backend.default_api_url sets one.
+const PortCloudAPIURL = "https://api.getport.io/v1"
+
+// defaultAPIURL returns the first non-empty candidate, or PortCloudAPIURL.
+func defaultAPIURL(candidates ...string) string {
+	for _, url := range candidates {
+		if url != "" {
+			return url
+		}
+	}
+	return PortCloudAPIURL
 }
 
 // SkillsConfig holds configuration for the port skills feature (hooks, selection, sync state).
@@ -55,12 +117,28 @@ func (p *SkillsConfig) UsesTeamGroupDefaults() bool {
 	return p.TeamGroupDefaults || len(p.IncludeGroups) > 0 || len(p.ExcludeGroups) > 0
 }
 
+// ResourceProfile is a named selection of resources for export, import and
+// migrate, applied with --profile. Each field mirrors the command flag of the
+// same name, which overrides it when given.
+type ResourceProfile struct {
+	Include                []string `yaml:"include,omitempty"`
+	ExcludeBlueprints      []string `yaml:"exclude_blueprints,omitempty"`
+	ExcludeBlueprintSchema []string `yaml:"exclude_blueprint_schema,omitempty"`
+	SkipEntities           bool     `yaml:"skip_entities,omitempty"`
+}
+
 // Config represents the main configuration structure.
 type Config struct {
+	// SchemaVersion is the file format version; see CurrentSchemaVersion.
+	SchemaVersion int                           `yaml:"schema_version,omitempty"`
 	DefaultOrg    string                        `yaml:"default_org"`
 	Organizations map[string]OrganizationConfig `yaml:"organizations"`
 	Backend       BackendConfig                 `yaml:"backend"`
 	Skills        SkillsConfig                  `yaml:"skills,omitempty"`
+	Profiles      map[string]ResourceProfile    `yaml:"profiles,omitempty"`
+
+	// client holds the settings GetOrgConfig applies to every organization.
+	client ClientSettings
 }
 
 // DefaultConfigPath returns the default path to the configuration file.
@@ -75,6 +153,12 @@ func DefaultConfigPath() string {
 	return filepath.Join(home, ".port", "config.yaml")
 }
 
+// DefaultAPIURL returns the API URL for orgs that do not set api_url:
+// PORT_DEFAULT_API_URL or backend.default_api_url, then PortCloudAPIURL.
+func (c *Config) DefaultAPIURL() string {
+	return defaultAPIURL(c.Backend.DefaultAPIURL)
+}
+
 func (c *Config) GetOrgOrDefault(orgName string) string {
 	org := orgName
 	if org == "" {
@@ -111,10 +195,53 @@ func (c *Config) GetOrgConfig(orgName string) (*OrganizationConfig, error) {
 		}
 		return nil, fmt.Errorf("organization '%s' not found in configuration. Available organizations: %v", orgName, orgNames)
 	}
+	if org.APIURL == "" {
+		org.APIURL = c.DefaultAPIURL()
+	}
+	org, err := resolveCredentialSource(orgName, org)
+	if err != nil {
+		return nil, err
+	}
+	for key := range c.client.Headers {
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			return nil, fmt.Errorf("the Authorization header cannot be overridden")
+		}
+	}
+	org.Headers = c.client.Headers
+	org.APIVersion = c.client.APIVersion
+	if org.APIVersion == "" {
+		org.APIVersion = c.Backend.APIVersion
+	}
+	// The org's own rate_limit and concurrency take precedence over the flags
+	if org.RateLimit == 0 {
+		org.RateLimit = c.client.RateLimit
+	}
+	if org.Concurrency == 0 {
+		org.Concurrency = c.client.Concurrency
+	}
+	org.CacheTTL = c.client.CacheTTL
+	org.NoCache = c.client.NoCache
 
 	return &org, nil
 }
 
+// GetProfile returns the resource profile called name.
+func (c *Config) GetProfile(name string) (ResourceProfile, error) {
+	profile, exists := c.Profiles[name]
+	if !exists {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return ResourceProfile{}, fmt.Errorf("profile '%s' not found: the configuration defines no profiles", name)
+		}
+		return ResourceProfile{}, fmt.Errorf("profile '%s' not found in configuration. Available profiles: %v", name, names)
+	}
+	return profile, nil
+}
+
 // Validate ensures the configuration is valid.
 func (c *Config) Validate() error {
 	if len(c.Organizations) == 0 {
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfigManager_Load(t *testing.T) {
@@ -171,3 +174,464 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigManager_UpsertOrg_PreservesExistingOrgs(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `default_org: existing
+organizations:
+  existing:
+    client_id: existing-id
+    client_secret: existing-secret
+    api_url: https://api.getport.io/v1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	t.Setenv("PORT_CLIENT_ID", "env-id")
+	t.Setenv("PORT_CLIENT_SECRET", "env-secret")
+
+	manager := NewConfigManager(configPath)
+	cfg, err := manager.UpsertOrg("new", OrganizationConfig{ClientID: "new-id", ClientSecret: "new-secret"}, false)
+	if err != nil {
+		t.Fatalf("UpsertOrg failed: %v", err)
+	}
+	if cfg.DefaultOrg != "existing" {
+		t.Errorf("Expected default org to stay 'existing', got '%s'", cfg.DefaultOrg)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"existing-id", "new-id", "https://api.getport.io/v1"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected config file to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "env-id") {
+		t.Errorf("Expected env credentials not to be persisted, got:\n%s", content)
+	}
+
+	cfg, err = manager.UpsertOrg("new", OrganizationConfig{ClientID: "new-id", ClientSecret: "new-secret"}, true)
+	if err != nil {
+		t.Fatalf("UpsertOrg failed: %v", err)
+	}
+	if cfg.DefaultOrg != "new" {
+		t.Errorf("Expected default org 'new', got '%s'", cfg.DefaultOrg)
+	}
+}
+
+func TestConfigManager_UpsertOrg_CreatesFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	manager := NewConfigManager(configPath)
+	cfg, err := manager.UpsertOrg("prod", OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: "https://api.us.getport.io/v1"}, false)
+	if err != nil {
+		t.Fatalf("UpsertOrg failed: %v", err)
+	}
+	if cfg.DefaultOrg != "prod" {
+		t.Errorf("Expected first org to become default, got '%s'", cfg.DefaultOrg)
+	}
+	if cfg.Organizations["prod"].APIURL != "https://api.us.getport.io/v1" {
+		t.Errorf("Expected API URL to be kept, got '%s'", cfg.Organizations["prod"].APIURL)
+	}
+}
+
+func TestLoadOrgsFromEnv(t *testing.T) {
+	cfg := &Config{Organizations: map[string]OrganizationConfig{
+		"prod": {ClientID: "file-id", ClientSecret: "file-secret", APIURL: "https://api.us.getport.io/v1"},
+	}}
+	loadOrgsFromEnv(cfg, []string{
+		"PORT_ORG_PROD_CLIENT_SECRET=env-secret",
+		"PORT_ORG_MY_STAGING_CLIENT_ID=staging-id",
+		"PORT_ORG_MY_STAGING_CLIENT_SECRET=staging-secret",
+		"PORT_ORG__CLIENT_ID=ignored",
+		"PORT_ORG_EMPTY_CLIENT_ID=",
+		"PORT_ORG_OTHER_TOKEN=ignored",
+	})
+
+	prod := cfg.Organizations["prod"]
+	if prod.ClientID != "file-id" || prod.ClientSecret != "env-secret" || prod.APIURL != "https://api.us.getport.io/v1" {
+		t.Errorf("expected env to override only the client secret of prod, got %+v", prod)
+	}
+	staging, ok := cfg.Organizations["my_staging"]
+	if !ok {
+		t.Fatalf("expected my_staging org to be registered, got %v", cfg.Organizations)
+	}
+	if staging.ClientID != "staging-id" || staging.ClientSecret != "staging-secret" || staging.APIURL != "https://api.getport.io/v1" {
+		t.Errorf("unexpected my_staging config: %+v", staging)
+	}
+	if len(cfg.Organizations) != 2 {
+		t.Errorf("expected 2 organizations, got %v", cfg.Organizations)
+	}
+}
+
+func TestConfigManager_LoadWithDualOverrides_EnvOrgsOnly(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("PORT_ORG_A_CLIENT_ID", "a-id")
+	t.Setenv("PORT_ORG_A_CLIENT_SECRET", "a-secret")
+	t.Setenv("PORT_ORG_B_CLIENT_ID", "b-id")
+	t.Setenv("PORT_ORG_B_CLIENT_SECRET", "b-secret")
+	t.Setenv("PORT_ORG_B_API_URL", "https://api.us.getport.io/v1")
+	t.Setenv("PORT_DEFAULT_ORG", "a")
+
+	manager := NewConfigManager(configPath)
+	cfg, base, target, err := manager.LoadWithDualOverrides("", "", "", "a", "", "", "", "b")
+	if err != nil {
+		t.Fatalf("LoadWithDualOverrides failed: %v", err)
+	}
+	if cfg.DefaultOrg != "a" {
+		t.Errorf("expected default org a, got %q", cfg.DefaultOrg)
+	}
+	if base.ClientID != "a-id" {
+		t.Errorf("expected base org a, got %+v", base)
+	}
+	if target.ClientID != "b-id" || target.APIURL != "https://api.us.getport.io/v1" {
+		t.Errorf("expected target org b, got %+v", target)
+	}
+}
+
+func TestConfigManager_Load_APIVersion(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `backend:
+  api_version: "2024-06-01"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	manager := NewConfigManager(configPath)
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Backend.APIVersion != "2024-06-01" {
+		t.Errorf("Expected api_version from file, got %q", cfg.Backend.APIVersion)
+	}
+
+	t.Setenv("PORT_API_VERSION", "2025-01-01")
+	cfg, err = manager.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Backend.APIVersion != "2025-01-01" {
+		t.Errorf("Expected PORT_API_VERSION to override file, got %q", cfg.Backend.APIVersion)
+	}
+}
+
+func TestConfig_GetOrgConfig_APIVersion(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: staging
+organizations:
+  staging:
+    client_id: id
+    client_secret: secret
+backend:
+  api_version: "2024-06-01"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	t.Setenv("PORT_API_VERSION", "")
+
+	cfg, err := NewConfigManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if orgConfig, err := cfg.GetOrgConfig("staging"); err != nil || orgConfig.APIVersion != "2024-06-01" {
+		t.Errorf("Expected backend.api_version on the resolved org, got %v, %v", orgConfig, err)
+	}
+
+	cfg, err = NewConfigManager(configPath).WithClientSettings(ClientSettings{APIVersion: "2025-01-01"}).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if orgConfig, err := cfg.GetOrgConfig("staging"); err != nil || orgConfig.APIVersion != "2025-01-01" {
+		t.Errorf("Expected --api-version to win over backend.api_version, got %v, %v", orgConfig, err)
+	}
+}
+
+func TestConfigManager_Load_UpdateURL(t *testing.T) {
+	t.Setenv("PORT_UPDATE_URL", "")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `backend:
+  update_url: https://mirror.example.com/releases/latest
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	manager := NewConfigManager(configPath)
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Backend.UpdateURL != "https://mirror.example.com/releases/latest" {
+		t.Errorf("Expected update_url from file, got %q", cfg.Backend.UpdateURL)
+	}
+
+	t.Setenv("PORT_UPDATE_URL", "https://other.example.com/latest")
+	cfg, err = manager.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Backend.UpdateURL != "https://other.example.com/latest" {
+		t.Errorf("Expected PORT_UPDATE_URL to override file, got %q", cfg.Backend.UpdateURL)
+	}
+}
+
+func TestConfigManager_Load_DefaultAPIURLPrecedence(t *testing.T) {
+	t.Setenv("PORT_CLIENT_ID", "")
+	t.Setenv("PORT_CLIENT_SECRET", "")
+	t.Setenv("PORT_API_URL", "")
+	t.Setenv("PORT_DEFAULT_API_URL", "")
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: eu
+backend:
+  default_api_url: https://api.us.getport.io/v1
+organizations:
+  eu:
+    client_id: eu-id
+    client_secret: eu-secret
+  pinned:
+    client_id: pinned-id
+    client_secret: pinned-secret
+    api_url: https://port.internal.example.com/v1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	manager := NewConfigManager(configPath)
+
+	apiURLOf := func(org string) string {
+		t.Helper()
+		cfg, err := manager.Load()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		orgConfig, err := cfg.GetOrgConfig(org)
+		if err != nil {
+			t.Fatalf("GetOrgConfig(%q): %v", org, err)
+		}
+		return orgConfig.APIURL
+	}
+
+	if got := apiURLOf("eu"); got != "https://api.us.getport.io/v1" {
+		t.Errorf("expected backend.default_api_url for an org without api_url, got %q", got)
+	}
+
+	t.Setenv("PORT_DEFAULT_API_URL", "https://port.self-hosted.example.com/v1")
+	if got := apiURLOf("eu"); got != "https://port.self-hosted.example.com/v1" {
+		t.Errorf("expected PORT_DEFAULT_API_URL to override the config file, got %q", got)
+	}
+	if got := apiURLOf("pinned"); got != "https://port.internal.example.com/v1" {
+		t.Errorf("expected the org's own api_url to win, got %q", got)
+	}
+
+	cfg, err := manager.LoadWithOverrides("cli-id", "cli-secret", "", "new-org")
+	if err != nil {
+		t.Fatalf("LoadWithOverrides: %v", err)
+	}
+	if got := cfg.Organizations["new-org"].APIURL; got != "https://port.self-hosted.example.com/v1" {
+		t.Errorf("expected CLI-defined org to use the default API URL, got %q", got)
+	}
+}
+
+func TestConfig_DefaultAPIURL_FallsBackToPortCloud(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.DefaultAPIURL(); got != PortCloudAPIURL {
+		t.Errorf("expected %q, got %q", PortCloudAPIURL, got)
+	}
+}
+
+func TestConfigManager_Load_ExpandsEnvironmentVariables(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: test
+backend:
+  timeout: ${PORT_TEST_TIMEOUT}
+organizations:
+  test:
+    client_id: $PORT_TEST_CLIENT_ID
+    client_secret: ${PORT_TEST_UNSET_SECRET}
+    api_url: https://${PORT_TEST_API_HOST}/v1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	t.Setenv("PORT_TEST_TIMEOUT", "42")
+	t.Setenv("PORT_TEST_CLIENT_ID", "from-env")
+	t.Setenv("PORT_TEST_API_HOST", "api.us.getport.io")
+
+	manager := NewConfigManager(configPath)
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	org := cfg.Organizations["test"]
+	if org.ClientID != "from-env" || org.APIURL != "https://api.us.getport.io/v1" {
+		t.Errorf("Expected variables to be expanded, got %+v", org)
+	}
+	if org.ClientSecret != "${PORT_TEST_UNSET_SECRET}" {
+		t.Errorf("Expected an unset variable to be kept as written, got %q", org.ClientSecret)
+	}
+	if cfg.Backend.Timeout != 42 {
+		t.Errorf("Expected an expanded integer timeout of 42, got %d", cfg.Backend.Timeout)
+	}
+
+	if _, err := manager.UpsertOrg("other", OrganizationConfig{ClientID: "id", ClientSecret: "secret"}, false); err != nil {
+		t.Fatalf("UpsertOrg failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "from-env") || !strings.Contains(string(data), "$PORT_TEST_CLIENT_ID") {
+		t.Errorf("Expected references, not expanded values, to be written back, got:\n%s", data)
+	}
+}
+
+func TestConfigManager_LoadProfiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: test
+organizations:
+  test:
+    client_id: id
+    client_secret: secret
+profiles:
+  schema-only:
+    include: [blueprints, scorecards, actions, pages]
+    exclude_blueprints: [_rule]
+    skip_entities: true
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := NewConfigManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	profile, err := cfg.GetProfile("schema-only")
+	if err != nil {
+		t.Fatalf("GetProfile: %v", err)
+	}
+	if strings.Join(profile.Include, ",") != "blueprints,scorecards,actions,pages" || !profile.SkipEntities ||
+		strings.Join(profile.ExcludeBlueprints, ",") != "_rule" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+
+	if _, err := cfg.GetProfile("missing"); err == nil || !strings.Contains(err.Error(), "schema-only") {
+		t.Errorf("expected an error listing the available profiles, got %v", err)
+	}
+}
+
+func TestConfigManager_LoadOrgRateLimit(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: staging
+organizations:
+  staging:
+    client_id: id
+    client_secret: secret
+    rate_limit: 2.5
+    concurrency: 4
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := NewConfigManager(configPath).LoadWithOverrides("override-id", "", "", "staging")
+	if err != nil {
+		t.Fatalf("Failed to load config with overrides: %v", err)
+	}
+	orgConfig, err := cfg.GetOrgConfig("staging")
+	if err != nil {
+		t.Fatalf("Failed to get org config: %v", err)
+	}
+	if orgConfig.ClientID != "override-id" {
+		t.Errorf("Expected client_id 'override-id', got '%s'", orgConfig.ClientID)
+	}
+	if orgConfig.RateLimit != 2.5 || orgConfig.Concurrency != 4 {
+		t.Errorf("Expected rate_limit 2.5 and concurrency 4 to survive overrides, got %v and %d", orgConfig.RateLimit, orgConfig.Concurrency)
+	}
+
+	// The flags only apply to orgs without their own limits
+	configContent += `  prod:
+    client_id: prod-id
+    client_secret: prod-secret
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	cfg, err = NewConfigManager(configPath).WithClientSettings(ClientSettings{RateLimit: 5, Concurrency: 3}).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if orgConfig, err := cfg.GetOrgConfig("staging"); err != nil || orgConfig.RateLimit != 2.5 || orgConfig.Concurrency != 4 {
+		t.Errorf("Expected the org's own limits to win over the flags, got %v, %v", orgConfig, err)
+	}
+	if orgConfig, err := cfg.GetOrgConfig("prod"); err != nil || orgConfig.RateLimit != 5 || orgConfig.Concurrency != 3 {
+		t.Errorf("Expected the flags for an org without limits, got %v, %v", orgConfig, err)
+	}
+}
+
+func TestConfig_GetOrgConfig_ClientSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `default_org: staging
+organizations:
+  staging:
+    client_id: id
+    client_secret: secret
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	headers := http.Header{"X-Request-Id": {"abc"}}
+	cfg, err := NewConfigManager(configPath).WithClientSettings(ClientSettings{Headers: headers, CacheTTL: time.Minute, NoCache: true}).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	orgConfig, err := cfg.GetOrgConfig("staging")
+	if err != nil {
+		t.Fatalf("Failed to get org config: %v", err)
+	}
+	if orgConfig.Headers.Get("X-Request-Id") != "abc" {
+		t.Errorf("Expected the client headers on the resolved org, got %v", orgConfig.Headers)
+	}
+	if orgConfig.CacheTTL != time.Minute || !orgConfig.NoCache {
+		t.Errorf("Expected the cache settings on the resolved org, got %v and %v", orgConfig.CacheTTL, orgConfig.NoCache)
+	}
+
+	// Orgs resolved without client settings carry none
+	cfg, err = NewConfigManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if orgConfig, err := cfg.GetOrgConfig("staging"); err != nil || orgConfig.Headers != nil {
+		t.Errorf("Expected no headers without client settings, got %v, %v", orgConfig, err)
+	}
+
+	cfg, err = NewConfigManager(configPath).WithClientSettings(ClientSettings{Headers: http.Header{"authorization": {"Bearer other"}}}).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if _, err := cfg.GetOrgConfig("staging"); err == nil {
+		t.Error("Expected an Authorization header to be rejected")
+	}
+}
+
+func TestEnvHeaders(t *testing.T) {
+	t.Setenv("TESTING", "1")
+	t.Setenv("PORT_HEADERS", "")
+	if headers := EnvHeaders(); headers != nil {
+		t.Errorf("EnvHeaders without PORT_HEADERS = %v, want none", headers)
+	}
+	t.Setenv("PORT_HEADERS", "X-Request-ID: abc;X-Feature: beta")
+	if headers := EnvHeaders(); strings.Join(headers, "|") != "X-Request-ID: abc|X-Feature: beta" {
+		t.Errorf("EnvHeaders = %q", headers)
+	}
+}
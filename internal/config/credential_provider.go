@@ -0,0 +1,183 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CredentialSource selects where an organization's client secret comes from,
+// as the credential_source field of an organization in the config file:
+//
+//	credential_source:
+//	  type: exec
+//	  command: ["aws", "secretsmanager", "get-secret-value", "--secret-id", "port/prod", "--query", "SecretString", "--output", "text"]
+//
+// Without a credential_source, or with type inline, client_secret is used.
+type CredentialSource struct {
+	Type    string   `yaml:"type"`              // inline, env, file, keyring or exec
+	Env     string   `yaml:"env,omitempty"`     // env: the variable holding the secret
+	Path    string   `yaml:"path,omitempty"`    // file: the file holding the secret
+	Service string   `yaml:"service,omitempty"` // keyring: the service the secret is stored under
+	Account string   `yaml:"account,omitempty"` // keyring: the account, defaulting to the client ID
+	Command []string `yaml:"command,omitempty"` // exec: the program and its arguments
+}
+
+// CredentialProvider resolves the client secret of an organization.
+type CredentialProvider interface {
+	ClientSecret(ctx context.Context) (string, error)
+}
+
+// credentialProviders builds the provider for each credential_source type
+// from the organization it belongs to.
+var credentialProviders = map[string]func(org OrganizationConfig) (CredentialProvider, error){
+	"inline": func(org OrganizationConfig) (CredentialProvider, error) {
+		return InlineProvider{Secret: org.ClientSecret}, nil
+	},
+	"env": func(org OrganizationConfig) (CredentialProvider, error) {
+		if org.CredentialSource.Env == "" {
+			return nil, errors.New("credential_source type env requires env")
+		}
+		return EnvProvider{Name: org.CredentialSource.Env}, nil
+	},
+	"file": func(org OrganizationConfig) (CredentialProvider, error) {
+		if org.CredentialSource.Path == "" {
+			return nil, errors.New("credential_source type file requires path")
+		}
+		return FileProvider{Path: org.CredentialSource.Path}, nil
+	},
+	"keyring": func(org OrganizationConfig) (CredentialProvider, error) {
+		if org.CredentialSource.Service == "" {
+			return nil, errors.New("credential_source type keyring requires service")
+		}
+		account := org.CredentialSource.Account
+		if account == "" {
+			account = org.ClientID
+		}
+		return KeyringProvider{Service: org.CredentialSource.Service, Account: account}, nil
+	},
+	"exec": func(org OrganizationConfig) (CredentialProvider, error) {
+		if len(org.CredentialSource.Command) == 0 {
+			return nil, errors.New("credential_source type exec requires command")
+		}
+		return ExecProvider{Command: org.CredentialSource.Command}, nil
+	},
+}
+
+// CredentialProviderFor returns the provider selected by the credential_source
+// of org, or an InlineProvider for client_secret when it has none.
+func CredentialProviderFor(org OrganizationConfig) (CredentialProvider, error) {
+	sourceType := "inline"
+	if org.CredentialSource != nil && org.CredentialSource.Type != "" {
+		sourceType = org.CredentialSource.Type
+	}
+	build, ok := credentialProviders[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown credential_source type %q (expected inline, env, file, keyring or exec)", sourceType)
+	}
+	return build(org)
+}
+
+// InlineProvider returns the client_secret written in the config file.
+type InlineProvider struct {
+	Secret string
+}
+
+func (p InlineProvider) ClientSecret(context.Context) (string, error) {
+	return p.Secret, nil
+}
+
+// EnvProvider reads the secret from an environment variable.
+type EnvProvider struct {
+	Name string
+}
+
+func (p EnvProvider) ClientSecret(context.Context) (string, error) {
+	return os.Getenv(p.Name), nil
+}
+
+// FileProvider reads the secret from a file, as ReadSecretFile does.
+type FileProvider struct {
+	Path string
+}
+
+func (p FileProvider) ClientSecret(context.Context) (string, error) {
+	return ReadSecretFile(p.Path)
+}
+
+// KeyringProvider reads the secret from the system keyring through the
+// platform's command-line tool: security on macOS and secret-tool (libsecret)
+// on Linux.
+type KeyringProvider struct {
+	Service string
+	Account string
+}
+
+func (p KeyringProvider) ClientSecret(ctx context.Context) (string, error) {
+	var command []string
+	switch runtime.GOOS {
+	case "darwin":
+		command = []string{"security", "find-generic-password", "-s", p.Service, "-a", p.Account, "-w"}
+	case "linux":
+		command = []string{"secret-tool", "lookup", "service", p.Service, "account", p.Account}
+	default:
+		return "", fmt.Errorf("the keyring credential source is not supported on %s; use exec with your platform's secret tool", runtime.GOOS)
+	}
+	secret, err := ExecProvider{Command: command}.ClientSecret(ctx)
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup for service %s, account %s: %w", p.Service, p.Account, err)
+	}
+	return secret, nil
+}
+
+// execCredentialTimeout bounds how long an exec credential command may run.
+const execCredentialTimeout = 30 * time.Second
+
+// ExecProvider runs a command and reads the secret from its standard output,
+// like a git credential helper. Its standard error is shown to the user, so
+// the command may prompt or report problems there.
+type ExecProvider struct {
+	Command []string
+}
+
+func (p ExecProvider) ClientSecret(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, execCredentialTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("credential command %s failed: %w", p.Command[0], err)
+	}
+	secret := strings.TrimSpace(stdout.String())
+	if secret == "" {
+		return "", fmt.Errorf("credential command %s printed no secret", p.Command[0])
+	}
+	return secret, nil
+}
+
+// resolveCredentialSource fills in the client secret of org from its
+// credential_source. Orgs without one are returned as they are.
+func resolveCredentialSource(name string, org OrganizationConfig) (OrganizationConfig, error) {
+	if org.CredentialSource == nil {
+		return org, nil
+	}
+	provider, err := CredentialProviderFor(org)
+	if err != nil {
+		return org, fmt.Errorf("organization '%s': %w", name, err)
+	}
+	secret, err := provider.ClientSecret(context.Background())
+	if err != nil {
+		return org, fmt.Errorf("organization '%s': %w", name, err)
+	}
+	org.ClientSecret = secret
+	return org, nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCredentialProviderFor(t *testing.T) {
+	t.Setenv("PORT_TEST_PROVIDER_SECRET", "env-secret")
+	tests := []struct {
+		name   string
+		org    OrganizationConfig
+		secret string
+	}{
+		{"no source", OrganizationConfig{ClientSecret: "inline-secret"}, "inline-secret"},
+		{"inline", OrganizationConfig{ClientSecret: "inline-secret", CredentialSource: &CredentialSource{Type: "inline"}}, "inline-secret"},
+		{"env", OrganizationConfig{CredentialSource: &CredentialSource{Type: "env", Env: "PORT_TEST_PROVIDER_SECRET"}}, "env-secret"},
+		{"file", OrganizationConfig{CredentialSource: &CredentialSource{Type: "file", Path: writeSecretFile(t, "file-secret\n")}}, "file-secret"},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, struct {
+			name   string
+			org    OrganizationConfig
+			secret string
+		}{"exec", OrganizationConfig{CredentialSource: &CredentialSource{Type: "exec", Command: []string{"sh", "-c", "echo exec-secret"}}}, "exec-secret"})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := CredentialProviderFor(tt.org)
+			if err != nil {
+				t.Fatalf("CredentialProviderFor: %v", err)
+			}
+			secret, err := provider.ClientSecret(context.Background())
+			if err != nil || secret != tt.secret {
+				t.Errorf("ClientSecret = %q, %v; want %q", secret, err, tt.secret)
+			}
+		})
+	}
+
+	for _, source := range []CredentialSource{{Type: "vault"}, {Type: "env"}, {Type: "file"}, {Type: "keyring"}, {Type: "exec"}} {
+		if _, err := CredentialProviderFor(OrganizationConfig{CredentialSource: &source}); err == nil {
+			t.Errorf("CredentialProviderFor(%+v): expected an error", source)
+		}
+	}
+}
+
+func TestExecProvider_Failures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	for _, command := range [][]string{{"sh", "-c", "exit 3"}, {"sh", "-c", "true"}} {
+		if _, err := (ExecProvider{Command: command}).ClientSecret(context.Background()); err == nil {
+			t.Errorf("ExecProvider%v: expected an error", command)
+		}
+	}
+}
+
+func TestGetOrgConfig_ResolvesCredentialSource(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	secretPath := writeSecretFile(t, "prod-secret")
+	content := `default_org: prod
+organizations:
+  prod:
+    client_id: prod-id
+    api_url: https://api.getport.io/v1
+    credential_source:
+      type: file
+      path: ` + secretPath + `
+  broken:
+    client_id: broken-id
+    credential_source:
+      type: file
+      path: ` + filepath.Join(t.TempDir(), "missing") + `
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PORT_CLIENT_ID", "")
+	t.Setenv("PORT_CLIENT_SECRET", "")
+	t.Setenv(EnvClientSecretFile, "")
+
+	cfg, err := NewConfigManager(configPath).LoadWithOverrides("", "", "", "")
+	if err != nil {
+		t.Fatalf("LoadWithOverrides: %v", err)
+	}
+	org, err := cfg.GetOrgConfig("prod")
+	if err != nil || org.ClientSecret != "prod-secret" {
+		t.Fatalf("GetOrgConfig(prod) = %+v, %v; want the secret from the file", org, err)
+	}
+	if _, err := cfg.GetOrgConfig("broken"); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("GetOrgConfig(broken): err = %v, want an error naming the org", err)
+	}
+
+	// A client ID flag keeps the configured credential source
+	cfg, err = NewConfigManager(configPath).LoadWithOverrides("other-id", "", "", "prod")
+	if err != nil {
+		t.Fatalf("LoadWithOverrides: %v", err)
+	}
+	if org, err := cfg.GetOrgConfig("prod"); err != nil || org.ClientID != "other-id" || org.ClientSecret != "prod-secret" {
+		t.Errorf("GetOrgConfig(prod) with a client ID flag = %+v, %v", org, err)
+	}
+}
+
+func TestLoadWithDualOverrides_DefaultOrgResolvesCredentialSource(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	secretPath := writeSecretFile(t, "prod-secret")
+	content := `default_org: prod
+organizations:
+  prod:
+    client_id: prod-id
+    api_url: https://api.getport.io/v1
+    credential_source:
+      type: file
+      path: ` + secretPath + `
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"PORT_CLIENT_ID", "PORT_CLIENT_SECRET", "PORT_API_URL", EnvClientSecretFile,
+		"PORT_TARGET_CLIENT_ID", "PORT_TARGET_CLIENT_SECRET", "PORT_TARGET_API_URL", EnvTargetClientSecretFile} {
+		t.Setenv(name, "")
+	}
+
+	_, base, target, err := NewConfigManager(configPath).LoadWithDualOverrides("", "", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("LoadWithDualOverrides: %v", err)
+	}
+	if base == nil || base.ClientID != "prod-id" || base.ClientSecret != "prod-secret" {
+		t.Errorf("base org = %+v, want the default org with the secret from its credential_source", base)
+	}
+	if target != nil {
+		t.Errorf("target org = %+v, want nil without a target", target)
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,12 +9,21 @@ import (
 	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/port-experimental/port-cli/internal/envexpand"
 	"gopkg.in/yaml.v3"
 )
 
 // ConfigManager manages configuration loading with precedence: CLI flags > env vars > config file.
 type ConfigManager struct {
 	configPath string
+	client     ClientSettings
+}
+
+// WithClientSettings makes the organizations resolved from cm's configuration
+// carry s, and returns cm.
+func (cm *ConfigManager) WithClientSettings(s ClientSettings) *ConfigManager {
+	cm.client = s
+	return cm
 }
 
 // ConfigPath returns the configuration file path.
@@ -78,17 +88,20 @@ func (cm *ConfigManager) Load() (*Config, error) {
 			URL:     "http://localhost:8080",
 			Timeout: 300,
 		},
+		client: cm.client,
 	}
 
 	// Load from file if exists
 	if _, err := os.Stat(cm.configPath); err == nil {
-		if err := cm.loadFromFile(cfg); err != nil {
+		if err := cm.loadFromFile(cfg, false); err != nil {
 			return nil, fmt.Errorf("failed to load config file: %w", err)
 		}
 	}
 
 	// Override with environment variables
-	cm.loadFromEnv(cfg)
+	if err := cm.loadFromEnv(cfg); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
@@ -133,8 +146,10 @@ func (cm *ConfigManager) LoadWithDualOverrides(
 		}
 	}
 	if baseOrgConfig == nil {
-		c := cfg.Organizations[cfg.DefaultOrg]
-		baseOrgConfig = &c
+		baseOrgConfig, err = cfg.GetOrgConfig(cfg.DefaultOrg)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get base org config: %w", err)
+		}
 	}
 
 	return cfg, baseOrgConfig, targetOrgConfig, nil
@@ -150,12 +165,9 @@ func (cm *ConfigManager) resolveOrgConfig(cfg *Config, clientID, clientSecret, a
 			clientID = os.Getenv("PORT_CLIENT_ID")
 		}
 	}
-	if clientSecret == "" {
-		if orgType == "target" {
-			clientSecret = os.Getenv("PORT_TARGET_CLIENT_SECRET")
-		} else {
-			clientSecret = os.Getenv("PORT_CLIENT_SECRET")
-		}
+	clientSecret, err := overrideSecretProvider(clientSecret, orgType).ClientSecret(context.Background())
+	if err != nil {
+		return nil, err
 	}
 	if apiURL == "" {
 		if orgType == "target" {
@@ -189,6 +201,10 @@ func (cm *ConfigManager) resolveOrgConfig(cfg *Config, clientID, clientSecret, a
 			ClientSecret: clientSecret,
 			APIURL:       apiURL,
 		}
+		if exists {
+			overrideConfig.RateLimit = existingOrg.RateLimit
+			overrideConfig.Concurrency = existingOrg.Concurrency
+		}
 
 		// Fill in missing values from existing config or defaults
 		if overrideConfig.ClientID == "" {
@@ -201,6 +217,7 @@ func (cm *ConfigManager) resolveOrgConfig(cfg *Config, clientID, clientSecret, a
 		if overrideConfig.ClientSecret == "" {
 			if exists {
 				overrideConfig.ClientSecret = existingOrg.ClientSecret
+				overrideConfig.CredentialSource = existingOrg.CredentialSource
 			} else {
 				return nil, fmt.Errorf("%s", MissingCredentialsForOrgMessage(orgType, cm.configPath))
 			}
@@ -209,7 +226,7 @@ func (cm *ConfigManager) resolveOrgConfig(cfg *Config, clientID, clientSecret, a
 			if exists {
 				overrideConfig.APIURL = existingOrg.APIURL
 			} else {
-				overrideConfig.APIURL = "https://api.getport.io/v1"
+				overrideConfig.APIURL = cfg.DefaultAPIURL()
 			}
 		}
 
@@ -222,6 +239,25 @@ func (cm *ConfigManager) resolveOrgConfig(cfg *Config, clientID, clientSecret, a
 	return cfg.GetOrgConfig(orgName)
 }
 
+// overrideSecretProvider returns the provider of a client secret override:
+// the --client-secret flag when given, else the environment.
+func overrideSecretProvider(flagSecret, orgType string) CredentialProvider {
+	if flagSecret != "" {
+		return InlineProvider{Secret: flagSecret}
+	}
+	return envSecretProvider{orgType: orgType}
+}
+
+// envSecretProvider resolves a client secret override from the environment,
+// as envClientSecret does.
+type envSecretProvider struct {
+	orgType string
+}
+
+func (p envSecretProvider) ClientSecret(context.Context) (string, error) {
+	return envClientSecret(p.orgType)
+}
+
 // LoadWithOverrides loads configuration with CLI flag overrides.
 // Precedence: CLI flags > env vars > config file > defaults.
 func (cm *ConfigManager) LoadWithOverrides(clientID, clientSecret, apiURL, orgName string) (*Config, error) {
@@ -250,6 +286,10 @@ func (cm *ConfigManager) LoadWithOverrides(clientID, clientSecret, apiURL, orgNa
 			ClientSecret: clientSecret,
 			APIURL:       apiURL,
 		}
+		if exists {
+			overrideConfig.RateLimit = existingOrg.RateLimit
+			overrideConfig.Concurrency = existingOrg.Concurrency
+		}
 
 		// Fill in missing values from existing config or defaults
 		if overrideConfig.ClientID == "" && exists {
@@ -257,12 +297,13 @@ func (cm *ConfigManager) LoadWithOverrides(clientID, clientSecret, apiURL, orgNa
 		}
 		if overrideConfig.ClientSecret == "" && exists {
 			overrideConfig.ClientSecret = existingOrg.ClientSecret
+			overrideConfig.CredentialSource = existingOrg.CredentialSource
 		}
 		if overrideConfig.APIURL == "" {
 			if exists {
 				overrideConfig.APIURL = existingOrg.APIURL
 			} else {
-				overrideConfig.APIURL = "https://api.getport.io/v1"
+				overrideConfig.APIURL = cfg.DefaultAPIURL()
 			}
 		}
 
@@ -273,19 +314,31 @@ func (cm *ConfigManager) LoadWithOverrides(clientID, clientSecret, apiURL, orgNa
 	return cfg, nil
 }
 
-// loadFromFile loads configuration from YAML file.
-func (cm *ConfigManager) loadFromFile(cfg *Config) error {
+// loadFromFile loads configuration from YAML file. ${VAR} and $VAR references
+// in values are replaced from the environment; references to unset variables
+// are kept as written. With keepStrings, as used by callers that write the
+// config back, only references filling number or boolean fields are expanded,
+// so secrets and other string values are never persisted.
+func (cm *ConfigManager) loadFromFile(cfg *Config, keepStrings bool) error {
 	data, err := os.ReadFile(cm.configPath)
 	if err != nil {
 		return err
 	}
 
-	fileConfig := &configFileYAML{}
-	if err := yaml.Unmarshal(data, fileConfig); err != nil {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return err
 	}
+	fileConfig := &configFileYAML{}
+	if len(doc.Content) > 0 {
+		expandYAMLNode(&doc, envexpand.New(envexpand.Lenient), keepStrings)
+		if err := doc.Decode(fileConfig); err != nil {
+			return err
+		}
+	}
 
 	// Merge file config into defaults
+	cfg.SchemaVersion = fileConfig.SchemaVersion
 	if fileConfig.DefaultOrg != "" {
 		cfg.DefaultOrg = fileConfig.DefaultOrg
 	}
@@ -298,18 +351,64 @@ func (cm *ConfigManager) loadFromFile(cfg *Config) error {
 	if fileConfig.Backend.Timeout != 0 {
 		cfg.Backend.Timeout = fileConfig.Backend.Timeout
 	}
+	if fileConfig.Backend.APIVersion != "" {
+		cfg.Backend.APIVersion = fileConfig.Backend.APIVersion
+	}
+	if fileConfig.Backend.DefaultAPIURL != "" {
+		cfg.Backend.DefaultAPIURL = fileConfig.Backend.DefaultAPIURL
+	}
+	if fileConfig.Backend.UpdateURL != "" {
+		cfg.Backend.UpdateURL = fileConfig.Backend.UpdateURL
+	}
 	cfg.Skills = mergeSkillsYAML(fileConfig.Skills, fileConfig.LegacyPlugin)
+	if fileConfig.Profiles != nil {
+		cfg.Profiles = fileConfig.Profiles
+	}
 
 	return nil
 }
 
+// expandYAMLNode expands the variables in every scalar value of node. Plain
+// scalars are re-resolved after expansion, so "${PORT_TIMEOUT}" can fill an
+// int field. With keepStrings, scalars that still resolve to a string are
+// left as written.
+func expandYAMLNode(node *yaml.Node, expander *envexpand.Expander, keepStrings bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		expanded := expander.String(node.Value)
+		if expanded == node.Value || node.Style != 0 {
+			if expanded != node.Value && !keepStrings {
+				node.Value = expanded
+			}
+			return
+		}
+		resolved := yaml.Node{Kind: yaml.ScalarNode, Value: expanded}
+		if keepStrings && resolved.ShortTag() == "!!str" {
+			return
+		}
+		node.Value = expanded
+		node.Tag = ""
+	case yaml.MappingNode:
+		// Keys are names, not values: only expand the values.
+		for i := 1; i < len(node.Content); i += 2 {
+			expandYAMLNode(node.Content[i], expander, keepStrings)
+		}
+	default:
+		for _, child := range node.Content {
+			expandYAMLNode(child, expander, keepStrings)
+		}
+	}
+}
+
 // configFileYAML mirrors Config on disk, including the legacy `plugin` key for backward compatibility.
 type configFileYAML struct {
+	SchemaVersion int                           `yaml:"schema_version,omitempty"`
 	DefaultOrg    string                        `yaml:"default_org"`
 	Organizations map[string]OrganizationConfig `yaml:"organizations"`
 	Backend       BackendConfig                 `yaml:"backend"`
 	Skills        SkillsConfig                  `yaml:"skills,omitempty"`
 	LegacyPlugin  SkillsConfig                  `yaml:"plugin,omitempty"`
+	Profiles      map[string]ResourceProfile    `yaml:"profiles,omitempty"`
 }
 
 // mergeSkillsYAML prefers the `skills` section; if it has no selection, falls back to legacy `plugin`.
@@ -335,12 +434,27 @@ func (cm *ConfigManager) AsMap(cfg *Config) (map[string]any, error) {
 }
 
 // loadFromEnv loads configuration from environment variables.
-func (cm *ConfigManager) loadFromEnv(cfg *Config) {
+func (cm *ConfigManager) loadFromEnv(cfg *Config) error {
 	// Backend URL
 	if backendURL := os.Getenv("PORT_CLI_BACKEND_URL"); backendURL != "" {
 		cfg.Backend.URL = backendURL
 	}
 
+	// API URL for orgs that do not set one
+	if defaultURL := os.Getenv(envDefaultAPIURL); defaultURL != "" {
+		cfg.Backend.DefaultAPIURL = defaultURL
+	}
+
+	// Port API version header
+	if apiVersion := os.Getenv("PORT_API_VERSION"); apiVersion != "" {
+		cfg.Backend.APIVersion = apiVersion
+	}
+
+	// Releases URL for port version --check
+	if updateURL := os.Getenv("PORT_UPDATE_URL"); updateURL != "" {
+		cfg.Backend.UpdateURL = updateURL
+	}
+
 	// Default org from environment
 	if defaultOrg := os.Getenv("PORT_DEFAULT_ORG"); defaultOrg != "" {
 		cfg.DefaultOrg = defaultOrg
@@ -348,10 +462,13 @@ func (cm *ConfigManager) loadFromEnv(cfg *Config) {
 
 	// Single organization from environment variables
 	clientID := os.Getenv("PORT_CLIENT_ID")
-	clientSecret := os.Getenv("PORT_CLIENT_SECRET")
+	clientSecret, err := envClientSecret("base")
+	if err != nil {
+		return err
+	}
 	apiURL := os.Getenv("PORT_API_URL")
 	if apiURL == "" {
-		apiURL = "https://api.getport.io/v1"
+		apiURL = cfg.DefaultAPIURL()
 	}
 
 	if clientID != "" && clientSecret != "" {
@@ -376,10 +493,87 @@ func (cm *ConfigManager) loadFromEnv(cfg *Config) {
 			cfg.DefaultOrg = orgName
 		}
 	}
+
+	loadOrgsFromEnv(cfg, os.Environ())
+	return nil
+}
+
+// envDefaultAPIURL names the environment variable overriding
+// backend.default_api_url.
+const envDefaultAPIURL = "PORT_DEFAULT_API_URL"
+
+// envHeaders names the environment variable holding extra API request
+// headers, as "Key: Value" pairs separated by semicolons.
+const envHeaders = "PORT_HEADERS"
+
+// EnvHeaders returns the "Key: Value" headers listed in PORT_HEADERS, which
+// may also be set in a .env file.
+func EnvHeaders() []string {
+	loadEnvFiles()
+	value := os.Getenv(envHeaders)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ";")
+}
+
+// envOrgPrefix prefixes environment variables that define named organizations,
+// e.g. PORT_ORG_PROD_CLIENT_ID registers the org "prod".
+const envOrgPrefix = "PORT_ORG_"
+
+// envOrgFields maps the variable suffix to the OrganizationConfig field it sets.
+var envOrgFields = []struct {
+	suffix string
+	set    func(*OrganizationConfig, string)
+}{
+	{"_CLIENT_ID", func(o *OrganizationConfig, v string) { o.ClientID = v }},
+	{"_CLIENT_SECRET", func(o *OrganizationConfig, v string) { o.ClientSecret = v }},
+	{"_API_URL", func(o *OrganizationConfig, v string) { o.APIURL = v }},
+}
+
+// loadOrgsFromEnv registers organizations defined through
+// PORT_ORG_<NAME>_CLIENT_ID, PORT_ORG_<NAME>_CLIENT_SECRET and
+// PORT_ORG_<NAME>_API_URL. <NAME> is lowercased to form the org name. Values
+// override the matching org from the config file field by field, and an org
+// that ends up without an API URL gets the default one.
+func loadOrgsFromEnv(cfg *Config, environ []string) {
+	found := make(map[string]bool)
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || value == "" || !strings.HasPrefix(key, envOrgPrefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, envOrgPrefix)
+		for _, field := range envOrgFields {
+			name, ok := strings.CutSuffix(rest, field.suffix)
+			if !ok || name == "" {
+				continue
+			}
+			orgName := strings.ToLower(name)
+			if cfg.Organizations == nil {
+				cfg.Organizations = make(map[string]OrganizationConfig)
+			}
+			org := cfg.Organizations[orgName]
+			field.set(&org, value)
+			cfg.Organizations[orgName] = org
+			found[orgName] = true
+			break
+		}
+	}
+
+	for orgName := range found {
+		org := cfg.Organizations[orgName]
+		if org.APIURL == "" {
+			org.APIURL = cfg.DefaultAPIURL()
+			cfg.Organizations[orgName] = org
+		}
+	}
 }
 
 // CreateDefaultConfig creates a default configuration file.
 func (cm *ConfigManager) CreateDefaultConfig() error {
+	apiURL := defaultAPIURL(os.Getenv(envDefaultAPIURL))
+
 	// Create default config
 	defaultConfig := &Config{
 		DefaultOrg: "production",
@@ -387,12 +581,12 @@ func (cm *ConfigManager) CreateDefaultConfig() error {
 			"production": {
 				ClientID:     "your-client-id",
 				ClientSecret: "your-client-secret",
-				APIURL:       "https://api.getport.io/v1",
+				APIURL:       apiURL,
 			},
 			"staging": {
 				ClientID:     "your-staging-client-id",
 				ClientSecret: "your-staging-client-secret",
-				APIURL:       "https://api.getport.io/v1",
+				APIURL:       apiURL,
 			},
 		},
 		Backend: BackendConfig{
@@ -411,7 +605,9 @@ func (cm *ConfigManager) Write(cfg *Config) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Write to file
+	// Write to file. Config only holds current fields, so the file is
+	// written in the current schema.
+	cfg.SchemaVersion = CurrentSchemaVersion
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -439,7 +635,7 @@ func (cm *ConfigManager) WriteBytes(data []byte) error {
 
 // WriteOrgIfMissing adds the org to the config if its missing.
 func (cm *ConfigManager) WriteOrgIfMissing(org string, apiUrl string) (*Config, error) {
-	cfg, err := cm.Load()
+	cfg, err := cm.loadFileOnly()
 	if err != nil {
 		return nil, err
 	}
@@ -461,6 +657,53 @@ func (cm *ConfigManager) WriteOrgIfMissing(org string, apiUrl string) (*Config,
 	return cfg, nil
 }
 
+// loadFileOnly loads the config file over the defaults without environment
+// overrides, keeping ${VAR} references in string values as written, for
+// callers that write the config back: neither environment credentials nor
+// expanded secrets are persisted.
+func (cm *ConfigManager) loadFileOnly() (*Config, error) {
+	cfg := &Config{
+		Organizations: make(map[string]OrganizationConfig),
+		Backend: BackendConfig{
+			URL:     "http://localhost:8080",
+			Timeout: 300,
+		},
+	}
+	if _, err := os.Stat(cm.configPath); err == nil {
+		if err := cm.loadFromFile(cfg, true); err != nil {
+			return nil, fmt.Errorf("failed to load config file: %w", err)
+		}
+	}
+	if cfg.Organizations == nil {
+		cfg.Organizations = make(map[string]OrganizationConfig)
+	}
+	return cfg, nil
+}
+
+// UpsertOrg adds or replaces an organization in the config file, preserving all
+// other organizations. Only the file is read, so credentials injected through
+// environment variables are never persisted. The org becomes the default when
+// setDefault is true or when no default org is configured yet.
+func (cm *ConfigManager) UpsertOrg(name string, org OrganizationConfig, setDefault bool) (*Config, error) {
+	cfg, err := cm.loadFileOnly()
+	if err != nil {
+		return nil, err
+	}
+
+	if org.APIURL == "" {
+		org.APIURL = defaultAPIURL(os.Getenv(envDefaultAPIURL), cfg.Backend.DefaultAPIURL)
+	}
+	cfg.Organizations[name] = org
+	if setDefault || cfg.DefaultOrg == "" {
+		cfg.DefaultOrg = name
+	}
+
+	if err := cm.Write(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 // LoadSkillsConfig loads the skills section from the config file.
 func (cm *ConfigManager) LoadSkillsConfig() (*SkillsConfig, error) {
 	cfg, err := cm.Load()
@@ -472,19 +715,13 @@ func (cm *ConfigManager) LoadSkillsConfig() (*SkillsConfig, error) {
 
 // SaveSkillsConfig persists the skills section into the config file, preserving all other fields.
 func (cm *ConfigManager) SaveSkillsConfig(skills *SkillsConfig) error {
-	cfg, err := cm.Load()
+	cfg, err := cm.loadFileOnly()
 	if err != nil {
-		// Config file doesn't exist yet -- start from an empty config.
-		// Load() wraps os errors, so check the file directly.
-		if _, statErr := os.Stat(cm.configPath); errors.Is(statErr, os.ErrNotExist) {
-			cfg = &Config{Organizations: make(map[string]OrganizationConfig)}
-		} else {
-			// File exists but couldn't be loaded (parse error, permissions, etc.)
-			return fmt.Errorf("failed to load existing config: %w", err)
-		}
+		return fmt.Errorf("failed to load existing config: %w", err)
 	}
 
 	cfg.Skills = *skills
+	cfg.SchemaVersion = CurrentSchemaVersion
 
 	dir := filepath.Dir(cm.configPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the config file format written by this version of
+// the CLI. Files without a schema_version predate it and are version 0.
+const CurrentSchemaVersion = 1
+
+// schemaMigrations[v] upgrades the root mapping of a version v config file to
+// version v+1 and describes each change it made.
+var schemaMigrations = []func(root *yaml.Node) ([]string, error){
+	migrateLegacyPluginSection,
+}
+
+// SchemaMigration describes the upgrade of a config file by MigrateSchema.
+type SchemaMigration struct {
+	From    int
+	To      int
+	Changes []string
+	// BackupPath is the copy of the original file, empty when the file was
+	// already current and left untouched.
+	BackupPath string
+}
+
+// MigrateSchema upgrades the config file to CurrentSchemaVersion, keeping a
+// copy of the original next to it with a .bak suffix. The file is edited as
+// YAML rather than decoded into Config, so comments, ${VAR} references and
+// keys this version does not know about are preserved.
+func (cm *ConfigManager) MigrateSchema() (*SchemaMigration, error) {
+	original, err := os.ReadFile(cm.configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no config file at %s", cm.configPath)
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %s is not a YAML mapping", cm.configPath)
+	}
+
+	version, err := schemaVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("config file has schema_version %d, but this version of port supports up to %d: upgrade port instead", version, CurrentSchemaVersion)
+	}
+	migration := &SchemaMigration{From: version, To: CurrentSchemaVersion}
+	if version == CurrentSchemaVersion {
+		return migration, nil
+	}
+
+	for v := version; v < CurrentSchemaVersion; v++ {
+		changes, err := schemaMigrations[v](root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate config from schema %d to %d: %w", v, v+1, err)
+		}
+		migration.Changes = append(migration.Changes, changes...)
+	}
+	setSchemaVersion(root, CurrentSchemaVersion)
+	migration.Changes = append(migration.Changes, fmt.Sprintf("set schema_version to %d", CurrentSchemaVersion))
+
+	if err := doc.Decode(&configFileYAML{}); err != nil {
+		return nil, fmt.Errorf("migrated config is invalid: %w", err)
+	}
+	upgraded, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	migration.BackupPath = cm.configPath + ".bak"
+	if err := os.WriteFile(migration.BackupPath, original, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write config backup: %w", err)
+	}
+	if err := cm.WriteBytes(upgraded); err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// schemaVersion returns the schema_version of a config file's root mapping.
+func schemaVersion(root *yaml.Node) (int, error) {
+	i := mappingIndex(root, "schema_version")
+	if i < 0 {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(root.Content[i+1].Value)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid schema_version %q in config file", root.Content[i+1].Value)
+	}
+	return version, nil
+}
+
+// setSchemaVersion sets schema_version, adding it as the first key when absent.
+func setSchemaVersion(root *yaml.Node, version int) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+	if i := mappingIndex(root, "schema_version"); i >= 0 {
+		root.Content[i+1] = value
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "schema_version"}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+// mappingIndex returns the index of key's key node in mapping, or -1.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// migrateLegacyPluginSection moves the skills settings out of the legacy
+// plugin section, keeping the section Load would have used.
+func migrateLegacyPluginSection(root *yaml.Node) ([]string, error) {
+	pluginIdx := mappingIndex(root, "plugin")
+	if pluginIdx < 0 {
+		return nil, nil
+	}
+	skillsIdx := mappingIndex(root, "skills")
+	if skillsIdx < 0 {
+		root.Content[pluginIdx].Value = "skills"
+		return []string{"renamed the legacy plugin section to skills"}, nil
+	}
+
+	var skills SkillsConfig
+	if err := root.Content[skillsIdx+1].Decode(&skills); err != nil {
+		return nil, fmt.Errorf("invalid skills section: %w", err)
+	}
+	change := "removed the legacy plugin section, superseded by skills"
+	if !skills.HasSelection() {
+		root.Content[skillsIdx+1] = root.Content[pluginIdx+1]
+		change = "replaced the empty skills section with the legacy plugin section"
+	}
+	root.Content = append(root.Content[:pluginIdx], root.Content[pluginIdx+2:]...)
+	return []string{change}, nil
+}
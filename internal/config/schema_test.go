@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSchemaMigrations_CoverEveryVersion(t *testing.T) {
+	if len(schemaMigrations) != CurrentSchemaVersion {
+		t.Fatalf("expected %d schema migrations, got %d", CurrentSchemaVersion, len(schemaMigrations))
+	}
+}
+
+func TestConfigManager_MigrateSchema_UpgradesLegacyFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# my orgs
+default_org: prod
+organizations:
+  prod:
+    client_id: id
+    client_secret: ${PORT_SECRET}
+plugin:
+  targets: [~/.cursor]
+  select_all: true
+custom_key: kept
+`
+	if err := os.WriteFile(configPath, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cm := NewConfigManager(configPath)
+	migration, err := cm.MigrateSchema()
+	if err != nil {
+		t.Fatalf("MigrateSchema: %v", err)
+	}
+	if migration.From != 0 || migration.To != CurrentSchemaVersion || migration.BackupPath != configPath+".bak" {
+		t.Errorf("unexpected migration %+v", migration)
+	}
+	if !strings.Contains(strings.Join(migration.Changes, "\n"), "renamed the legacy plugin section to skills") {
+		t.Errorf("expected the plugin rename to be reported, got %v", migration.Changes)
+	}
+
+	backup, err := os.ReadFile(migration.BackupPath)
+	if err != nil || string(backup) != original {
+		t.Errorf("expected the backup to hold the original file, got %q (%v)", backup, err)
+	}
+	upgraded, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"schema_version: 1", "# my orgs", "${PORT_SECRET}", "custom_key: kept", "skills:"} {
+		if !strings.Contains(string(upgraded), want) {
+			t.Errorf("expected upgraded file to contain %q:\n%s", want, upgraded)
+		}
+	}
+	if strings.Contains(string(upgraded), "plugin:") {
+		t.Errorf("expected the plugin section to be gone:\n%s", upgraded)
+	}
+
+	fileCfg := &Config{}
+	if err := cm.loadFromFile(fileCfg, true); err != nil {
+		t.Fatal(err)
+	}
+	if fileCfg.SchemaVersion != CurrentSchemaVersion || !fileCfg.Skills.SelectAll {
+		t.Errorf("unexpected upgraded config %+v", fileCfg)
+	}
+}
+
+func TestConfigManager_MigrateSchema_KeepsSkillsWithSelection(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "skills:\n  select_all: true\nplugin:\n  selected_skills: [old]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cm := NewConfigManager(configPath)
+	if _, err := cm.MigrateSchema(); err != nil {
+		t.Fatalf("MigrateSchema: %v", err)
+	}
+	fileCfg := &Config{}
+	if err := cm.loadFromFile(fileCfg, true); err != nil {
+		t.Fatal(err)
+	}
+	if !fileCfg.Skills.SelectAll || len(fileCfg.Skills.SelectedSkills) != 0 {
+		t.Errorf("expected the skills section to win, got %+v", fileCfg.Skills)
+	}
+}
+
+func TestConfigManager_MigrateSchema_CurrentFileIsUntouched(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	cm := NewConfigManager(configPath)
+	if _, err := cm.UpsertOrg("prod", OrganizationConfig{ClientID: "id", ClientSecret: "secret"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	migration, err := cm.MigrateSchema()
+	if err != nil {
+		t.Fatalf("MigrateSchema: %v", err)
+	}
+	if migration.BackupPath != "" || len(migration.Changes) != 0 {
+		t.Errorf("expected no changes to a file written by this version, got %+v", migration)
+	}
+	if _, err := os.Stat(configPath + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("expected no backup, stat returned %v", err)
+	}
+}
+
+func TestConfigManager_MigrateSchema_RejectsNewerFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("schema_version: 99\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewConfigManager(configPath).MigrateSchema(); err == nil || !strings.Contains(err.Error(), "upgrade port") {
+		t.Errorf("expected a newer schema to be refused, got %v", err)
+	}
+}
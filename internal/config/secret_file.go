@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables naming a file that holds a client secret, for secrets
+// mounted as files (e.g. Kubernetes secrets) so they stay out of the process
+// environment. Each ranks just below the variable holding the secret itself.
+const (
+	EnvClientSecretFile       = "PORT_CLIENT_SECRET_FILE"
+	EnvTargetClientSecretFile = "PORT_TARGET_CLIENT_SECRET_FILE"
+)
+
+// ReadSecretFile returns the contents of the file at path with surrounding
+// whitespace, such as a trailing newline, trimmed.
+func ReadSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read client secret file: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("client secret file %s is empty", path)
+	}
+	return secret, nil
+}
+
+// envClientSecret returns the client secret for the base or target org from
+// the environment: PORT_[TARGET_]CLIENT_SECRET, else the file named by
+// PORT_[TARGET_]CLIENT_SECRET_FILE. Both are read through the same providers
+// as a credential_source of type env or file.
+func envClientSecret(orgType string) (string, error) {
+	secretVar, fileVar := "PORT_CLIENT_SECRET", EnvClientSecretFile
+	if orgType == "target" {
+		secretVar, fileVar = "PORT_TARGET_CLIENT_SECRET", EnvTargetClientSecretFile
+	}
+	ctx := context.Background()
+	if secret, err := (EnvProvider{Name: secretVar}).ClientSecret(ctx); err != nil || secret != "" {
+		return secret, err
+	}
+	path := os.Getenv(fileVar)
+	if path == "" {
+		return "", nil
+	}
+	secret, err := FileProvider{Path: path}.ClientSecret(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", fileVar, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSecretFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	return path
+}
+
+func TestReadSecretFile(t *testing.T) {
+	secret, err := ReadSecretFile(writeSecretFile(t, "  s3cret\n"))
+	if err != nil || secret != "s3cret" {
+		t.Errorf("ReadSecretFile = %q, %v; want trimmed s3cret", secret, err)
+	}
+	if _, err := ReadSecretFile(writeSecretFile(t, "\n")); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("empty file: err = %v, want an empty file error", err)
+	}
+	if _, err := ReadSecretFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("missing file: want an error")
+	}
+}
+
+func TestConfigManager_LoadWithDualOverrides_SecretFiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("PORT_CLIENT_ID", "base-id")
+	t.Setenv("PORT_CLIENT_SECRET", "")
+	t.Setenv(EnvClientSecretFile, writeSecretFile(t, "base-secret\n"))
+	t.Setenv("PORT_TARGET_CLIENT_ID", "target-id")
+	t.Setenv("PORT_TARGET_CLIENT_SECRET", "")
+	t.Setenv(EnvTargetClientSecretFile, writeSecretFile(t, "target-secret"))
+
+	manager := NewConfigManager(configPath)
+	_, base, target, err := manager.LoadWithDualOverrides("", "", "", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("LoadWithDualOverrides failed: %v", err)
+	}
+	if base.ClientSecret != "base-secret" {
+		t.Errorf("base secret = %q, want base-secret from %s", base.ClientSecret, EnvClientSecretFile)
+	}
+	if target.ClientSecret != "target-secret" {
+		t.Errorf("target secret = %q, want target-secret from %s", target.ClientSecret, EnvTargetClientSecretFile)
+	}
+
+	// The secret itself ranks above the file, and a flag above both
+	t.Setenv("PORT_TARGET_CLIENT_SECRET", "env-secret")
+	_, _, target, err = manager.LoadWithDualOverrides("", "", "", "", "", "", "", "")
+	if err != nil || target.ClientSecret != "env-secret" {
+		t.Errorf("target secret = %q, %v; want env-secret", target.ClientSecret, err)
+	}
+	_, _, target, err = manager.LoadWithDualOverrides("", "", "", "", "", "flag-secret", "", "")
+	if err != nil || target.ClientSecret != "flag-secret" {
+		t.Errorf("target secret = %q, %v; want flag-secret", target.ClientSecret, err)
+	}
+}
+
+func TestConfigManager_Load_UnreadableSecretFile(t *testing.T) {
+	t.Setenv("PORT_CLIENT_ID", "id")
+	t.Setenv("PORT_CLIENT_SECRET", "")
+	t.Setenv(EnvClientSecretFile, filepath.Join(t.TempDir(), "missing"))
+
+	_, err := NewConfigManager(filepath.Join(t.TempDir(), "config.yaml")).Load()
+	if err == nil || !strings.Contains(err.Error(), EnvClientSecretFile) {
+		t.Errorf("Load error = %v, want one naming %s", err, EnvClientSecretFile)
+	}
+}
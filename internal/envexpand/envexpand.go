@@ -0,0 +1,121 @@
+// Package envexpand substitutes environment variables into values read from
+// checked-in files, so templates can reference ${PORT_API_URL} or secrets
+// without storing them.
+package envexpand
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Mode controls how references to unset variables are handled.
+type Mode string
+
+const (
+	// Off disables expansion.
+	Off Mode = ""
+	// Lenient leaves references to unset variables as written.
+	Lenient Mode = "lenient"
+	// Strict reports references to unset variables as an error.
+	Strict Mode = "strict"
+)
+
+// Modes lists the accepted values of the --expand-env flag.
+var Modes = []string{string(Lenient), string(Strict)}
+
+// reference matches ${NAME} and $NAME. The bare form only matches upper-case
+// names, so Port's own $identifier, $team and $ref keywords are left alone.
+var reference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Z_][A-Z0-9_]*)\b`)
+
+// MissingError lists the variables referenced but not set under Strict.
+type MissingError struct {
+	Names []string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("environment variable(s) not set: %s", strings.Join(e.Names, ", "))
+}
+
+// Expander substitutes variables into strings and decoded JSON or YAML
+// values, recording the variables it could not find. A nil *Expander leaves
+// everything unchanged. An Expander is not safe for concurrent use.
+type Expander struct {
+	mode    Mode
+	lookup  func(string) (string, bool)
+	missing map[string]bool
+}
+
+// New returns an expander reading the process environment, or nil for Off.
+func New(mode Mode) *Expander {
+	return NewWithLookup(mode, os.LookupEnv)
+}
+
+// NewWithLookup returns an expander reading variables through lookup, or nil
+// for Off.
+func NewWithLookup(mode Mode, lookup func(string) (string, bool)) *Expander {
+	if mode == Off {
+		return nil
+	}
+	return &Expander{mode: mode, lookup: lookup, missing: make(map[string]bool)}
+}
+
+// String returns s with every variable reference replaced by its value.
+// References to unset variables are kept as written.
+func (e *Expander) String(s string) string {
+	if e == nil || !strings.Contains(s, "$") {
+		return s
+	}
+	return reference.ReplaceAllStringFunc(s, func(ref string) string {
+		groups := reference.FindStringSubmatch(ref)
+		name := groups[1]
+		if name == "" {
+			name = groups[2]
+		}
+		if value, ok := e.lookup(name); ok {
+			return value
+		}
+		e.missing[name] = true
+		return ref
+	})
+}
+
+// Value expands every string inside a decoded JSON or YAML value in place
+// and returns it. Map keys are left as they are.
+func (e *Expander) Value(value interface{}) interface{} {
+	if e == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return e.String(v)
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = e.Value(child)
+		}
+		return v
+	case []interface{}:
+		for idx, child := range v {
+			v[idx] = e.Value(child)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// Err returns a *MissingError naming the unset variables referenced so far
+// under Strict, and nil otherwise.
+func (e *Expander) Err() error {
+	if e == nil || e.mode != Strict || len(e.missing) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(e.missing))
+	for name := range e.missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &MissingError{Names: names}
+}
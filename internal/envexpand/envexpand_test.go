@@ -0,0 +1,75 @@
+package envexpand
+
+import (
+	"errors"
+	"testing"
+)
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestExpander_String(t *testing.T) {
+	e := NewWithLookup(Lenient, lookupFrom(map[string]string{"HOST": "api.example.com", "PORT_ENV": "prod"}))
+	tests := map[string]string{
+		"https://${HOST}/v1":        "https://api.example.com/v1",
+		"$PORT_ENV-cluster":         "prod-cluster",
+		"${PORT_ENV}${PORT_ENV}":    "prodprod",
+		"$identifier":               "$identifier",
+		"{{ .entity.$team }}":       "{{ .entity.$team }}",
+		"no references":             "no references",
+		"costs $5 per ${UNIT_NAME}": "costs $5 per ${UNIT_NAME}",
+	}
+	for in, want := range tests {
+		if got := e.String(in); got != want {
+			t.Errorf("String(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExpander_LenientKeepsMissingVariables(t *testing.T) {
+	e := NewWithLookup(Lenient, lookupFrom(nil))
+	value := e.Value(map[string]interface{}{"url": "${MISSING}", "list": []interface{}{"$ALSO_MISSING"}})
+	m := value.(map[string]interface{})
+	if m["url"] != "${MISSING}" || m["list"].([]interface{})[0] != "$ALSO_MISSING" {
+		t.Errorf("expected missing variables to be kept as written, got %v", m)
+	}
+	if err := e.Err(); err != nil {
+		t.Errorf("expected no error in lenient mode, got %v", err)
+	}
+}
+
+func TestExpander_StrictReportsMissingVariables(t *testing.T) {
+	e := NewWithLookup(Strict, lookupFrom(map[string]string{"SET": "x"}))
+	value := e.Value(map[string]interface{}{
+		"a":    "${SET}-${ZED}",
+		"b":    []interface{}{"$ALPHA", 3, true},
+		"$KEY": "keys are not expanded",
+	})
+	if got := value.(map[string]interface{})["a"]; got != "x-${ZED}" {
+		t.Errorf("expected set variables to be expanded, got %v", got)
+	}
+	var missing *MissingError
+	if err := e.Err(); !errors.As(err, &missing) {
+		t.Fatalf("expected a MissingError, got %v", err)
+	}
+	if len(missing.Names) != 2 || missing.Names[0] != "ALPHA" || missing.Names[1] != "ZED" {
+		t.Errorf("expected ALPHA and ZED to be missing, got %v", missing.Names)
+	}
+}
+
+func TestNew_OffReturnsNil(t *testing.T) {
+	e := New(Off)
+	if e != nil {
+		t.Fatal("expected a nil expander for Off")
+	}
+	if got := e.String("${HOME}"); got != "${HOME}" {
+		t.Errorf("expected a nil expander to leave strings alone, got %q", got)
+	}
+	if err := e.Err(); err != nil {
+		t.Errorf("expected no error from a nil expander, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package exitcode defines the process exit codes returned by port commands.
+// The values are part of the CLI's scripting contract and must not change.
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// OK means the command succeeded with no errors.
+	OK = 0
+	// Failure means the command failed without applying any changes.
+	Failure = 1
+	// Usage means invalid flags, arguments, or configuration.
+	Usage = 2
+	// ResourceErrors means the command ran to completion but some resources
+	// failed.
+	ResourceErrors = 3
+	// Partial means the command stopped part way after applying some changes.
+	Partial = 4
+	// RetryBudgetExhausted means the command stopped because its
+	// --retry-budget ran out, whether or not it had applied changes.
+	RetryBudgetExhausted = 5
+)
+
+// Error carries the exit code a command error should produce.
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New wraps err so the process exits with code. A nil err stays nil.
+func New(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Usagef returns a usage error formatted like fmt.Errorf.
+func Usagef(format string, args ...interface{}) error {
+	return New(Usage, fmt.Errorf(format, args...))
+}
+
+// Stopped returns the code for a run that stopped early: Partial when some
+// changes were applied, Failure otherwise.
+func Stopped(applied int) int {
+	if applied > 0 {
+		return Partial
+	}
+	return Failure
+}
+
+// Code returns the exit code for err: OK for nil, the wrapped code for an
+// *Error anywhere in the chain, and Failure for anything else.
+func Code(err error) int {
+	if err == nil {
+		return OK
+	}
+	var exitErr *Error
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return Failure
+}
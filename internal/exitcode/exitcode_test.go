@@ -0,0 +1,44 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, OK},
+		{"plain error", errors.New("boom"), Failure},
+		{"usage", Usagef("invalid value for %s", "--format"), Usage},
+		{"wrapped resource errors", fmt.Errorf("import: %w", New(ResourceErrors, errors.New("completed with errors"))), ResourceErrors},
+		{"partial", New(Stopped(3), errors.New("interrupted")), Partial},
+		{"stopped before applying", New(Stopped(0), errors.New("interrupted")), Failure},
+		{"retry budget over partial", New(RetryBudgetExhausted, New(Partial, errors.New("interrupted"))), RetryBudgetExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.want {
+				t.Errorf("Code() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_KeepsMessageAndChain(t *testing.T) {
+	base := errors.New("boom")
+	err := New(Partial, base)
+	if err.Error() != "boom" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "boom")
+	}
+	if !errors.Is(err, base) {
+		t.Error("expected wrapped error to be reachable with errors.Is")
+	}
+	if New(Partial, nil) != nil {
+		t.Error("expected New with a nil error to return nil")
+	}
+}
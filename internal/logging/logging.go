@@ -0,0 +1,111 @@
+// Package logging writes structured event logs: one JSON object per line for
+// each significant event of a command (requests, resources created or failed,
+// phases), kept apart from the human and JSON output on stdout.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Formats lists the accepted --log-format values.
+var Formats = []string{"json"}
+
+// Fields holds the event-specific values of a log line.
+type Fields map[string]interface{}
+
+// Logger writes events as JSON lines. It is safe for concurrent use, and a
+// nil *Logger discards every event, so callers need not check whether
+// logging was requested.
+type Logger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	now    func() time.Time
+}
+
+// New returns a logger writing to w.
+func New(w io.Writer) *Logger {
+	return &Logger{w: w, now: time.Now}
+}
+
+// Open returns a logger appending to the file at path, creating it if needed.
+func Open(path string) (*Logger, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	l := New(file)
+	l.closer = file
+	return l, nil
+}
+
+// Close closes the file opened by Open.
+func (l *Logger) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Log writes one event with the given fields. The "time" and "event" keys
+// are set by Log and override fields of the same name.
+func (l *Logger) Log(event string, fields Fields) {
+	if l == nil {
+		return
+	}
+	line := make(map[string]interface{}, len(fields)+2)
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		line[k] = v
+	}
+	line["time"] = l.now().UTC().Format(time.RFC3339Nano)
+	line["event"] = event
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		encoded, _ = json.Marshal(map[string]interface{}{"time": line["time"], "event": event, "log_error": err.Error()})
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(append(encoded, '\n'))
+}
+
+// Phase logs a "phase.started" event and returns the function that logs the
+// matching "phase.finished" event with the phase's duration.
+func (l *Logger) Phase(name string) (finish func()) {
+	if l == nil {
+		return func() {}
+	}
+	start := l.now()
+	l.Log("phase.started", Fields{"phase": name})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.Log("phase.finished", Fields{"phase": name, "duration_ms": l.now().Sub(start).Milliseconds()})
+		})
+	}
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a context whose operations log events to l. A nil
+// logger leaves ctx unchanged.
+func WithLogger(ctx context.Context, l *Logger) context.Context {
+	if l == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the context's logger, or nil when none was set.
+func FromContext(ctx context.Context) *Logger {
+	l, _ := ctx.Value(loggerKey{}).(*Logger)
+	return l
+}
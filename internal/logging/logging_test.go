@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestLogger_WritesOneJSONObjectPerEvent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	l.Log("resource", Fields{"action": "created", "identifier": "svc"})
+	l.Log("request", Fields{"error": errors.New("boom"), "event": "ignored"})
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	if lines[0]["event"] != "resource" || lines[0]["identifier"] != "svc" || lines[0]["time"] != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected first line %v", lines[0])
+	}
+	if lines[1]["event"] != "request" || lines[1]["error"] != "boom" {
+		t.Errorf("expected errors to be logged as strings and event to be kept, got %v", lines[1])
+	}
+}
+
+func TestLogger_PhaseLogsStartAndFinish(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	finish := l.Phase("diff")
+	finish()
+	finish()
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 || lines[0]["event"] != "phase.started" || lines[1]["event"] != "phase.finished" {
+		t.Fatalf("expected a started and a finished event, got %v", lines)
+	}
+	if lines[1]["phase"] != "diff" || lines[1]["duration_ms"] != float64(2000) {
+		t.Errorf("unexpected finished event %v", lines[1])
+	}
+}
+
+func TestLogger_NilDiscardsEvents(t *testing.T) {
+	var l *Logger
+	l.Log("resource", nil)
+	l.Phase("diff")()
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close on nil logger: %v", err)
+	}
+	if FromContext(WithLogger(context.Background(), nil)) != nil {
+		t.Error("expected no logger in the context")
+	}
+}
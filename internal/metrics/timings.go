@@ -0,0 +1,86 @@
+// Package metrics records how long the phases of a long-running operation
+// take, so a slow export, import or migration can be broken down by phase.
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase is the recorded duration of one named phase.
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Timings records phase durations. Spans started under the same name are
+// merged into one phase covering the first start to the last stop, so a phase
+// run by concurrent workers reports its wall-clock time rather than the sum of
+// its workers. A nil *Timings records nothing, so callers need not check
+// whether timing was requested.
+type Timings struct {
+	mu     sync.Mutex
+	order  []string
+	phases map[string]*span
+	now    func() time.Time
+}
+
+type span struct {
+	start, end time.Time
+}
+
+// New returns an empty Timings.
+func New() *Timings {
+	return &Timings{phases: make(map[string]*span), now: time.Now}
+}
+
+// Start starts a span of the named phase and returns the function that stops
+// it.
+func (t *Timings) Start(name string) (stop func()) {
+	if t == nil {
+		return func() {}
+	}
+	start := t.now()
+	t.mu.Lock()
+	s, ok := t.phases[name]
+	if !ok {
+		s = &span{start: start}
+		t.phases[name] = s
+		t.order = append(t.order, name)
+	} else if start.Before(s.start) {
+		s.start = start
+	}
+	t.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			end := t.now()
+			t.mu.Lock()
+			if end.After(s.end) {
+				s.end = end
+			}
+			t.mu.Unlock()
+		})
+	}
+}
+
+// Phases returns the phases in the order they were first started. A phase
+// with no stopped span has a zero duration.
+func (t *Timings) Phases() []Phase {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	phases := make([]Phase, 0, len(t.order))
+	for _, name := range t.order {
+		s := t.phases[name]
+		var d time.Duration
+		if !s.end.IsZero() {
+			d = s.end.Sub(s.start)
+		}
+		phases = append(phases, Phase{Name: name, Duration: d})
+	}
+	return phases
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock returns a clock that advances by one second on every call.
+func fakeClock() func() time.Time {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func TestTimings_RecordsPhasesInStartOrder(t *testing.T) {
+	timings := New()
+	timings.now = fakeClock()
+
+	stopExport := timings.Start("export") // t=1
+	stopExport()                          // t=2
+	stopDiff := timings.Start("diff")     // t=3
+	stopDiff()                            // t=4
+
+	got := timings.Phases()
+	want := []Phase{{"export", time.Second}, {"diff", time.Second}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d phases, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("phase %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestTimings_MergesConcurrentSpans(t *testing.T) {
+	timings := New()
+	timings.now = fakeClock()
+
+	stopA := timings.Start("import.actions") // t=1
+	stopB := timings.Start("import.actions") // t=2
+	stopA()                                  // t=3
+	stopB()                                  // t=4
+	stopB()                                  // stopping twice is a no-op
+
+	got := timings.Phases()
+	if len(got) != 1 || got[0].Duration != 3*time.Second {
+		t.Fatalf("expected one 3s phase spanning both workers, got %+v", got)
+	}
+}
+
+func TestTimings_UnstoppedPhaseHasZeroDuration(t *testing.T) {
+	timings := New()
+	timings.Start("import")
+	if got := timings.Phases(); len(got) != 1 || got[0].Duration != 0 {
+		t.Fatalf("expected a zero-duration phase, got %+v", got)
+	}
+}
+
+func TestTimings_NilRecordsNothing(t *testing.T) {
+	var timings *Timings
+	timings.Start("export")()
+	if got := timings.Phases(); got != nil {
+		t.Fatalf("expected no phases, got %+v", got)
+	}
+}
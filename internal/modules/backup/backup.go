@@ -0,0 +1,146 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timestampLayout is the UTC timestamp embedded in every bundle name. It is
+// fixed-width down to the nanosecond, so it sorts lexically in chronological
+// order and two backups of the same org never share a name.
+const timestampLayout = "20060102T150405.000000000Z"
+
+// Bundle is a backup file found in a backup directory.
+type Bundle struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// DefaultDir returns the default backup directory for an organization:
+// ~/.port/backups/<org>/.
+func DefaultDir(org string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".port", "backups", orgSlug(org)), nil
+}
+
+// FileName returns the bundle file name for a backup of org taken at t.
+// format is the export format ("tar" or "json"); anything else is treated as tar.
+func FileName(org string, t time.Time, format string) string {
+	ext := ".tar.gz"
+	if format == "json" {
+		ext = ".json"
+	}
+	return fmt.Sprintf("%s-%s%s", orgSlug(org), t.UTC().Format(timestampLayout), ext)
+}
+
+// ParseFileName extracts the backup time from a bundle file name produced by
+// FileName for org. ok is false for files that do not follow the naming scheme
+// or that belong to a different organization.
+func ParseFileName(org, name string) (t time.Time, ok bool) {
+	base := name
+	switch {
+	case strings.HasSuffix(base, ".tar.gz"):
+		base = strings.TrimSuffix(base, ".tar.gz")
+	case strings.HasSuffix(base, ".json"):
+		base = strings.TrimSuffix(base, ".json")
+	default:
+		return time.Time{}, false
+	}
+
+	prefix := orgSlug(org) + "-"
+	if !strings.HasPrefix(base, prefix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timestampLayout, strings.TrimPrefix(base, prefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// List returns org's bundles in dir, newest first. Files that do not follow
+// the bundle naming scheme, including other organizations' bundles in a shared
+// directory, are ignored. A missing directory yields no bundles.
+func List(dir, org string) ([]Bundle, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var bundles []Bundle
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		createdAt, ok := ParseFileName(org, entry.Name())
+		if !ok {
+			continue
+		}
+		var size int64
+		if info, err := entry.Info(); err == nil {
+			size = info.Size()
+		}
+		bundles = append(bundles, Bundle{
+			Name:      entry.Name(),
+			Path:      filepath.Join(dir, entry.Name()),
+			CreatedAt: createdAt,
+			Size:      size,
+		})
+	}
+
+	sort.SliceStable(bundles, func(i, j int) bool {
+		return bundles[i].CreatedAt.After(bundles[j].CreatedAt)
+	})
+	return bundles, nil
+}
+
+// Prune keeps org's keep newest bundles in dir and removes the rest of org's
+// bundles, returning the removed bundles. Other organizations' bundles are left
+// alone. keep <= 0 disables rotation.
+func Prune(dir, org string, keep int) ([]Bundle, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	bundles, err := List(dir, org)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) <= keep {
+		return nil, nil
+	}
+
+	var removed []Bundle
+	for _, b := range bundles[keep:] {
+		if err := os.Remove(b.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup %s: %w", b.Name, err)
+		}
+		removed = append(removed, b)
+	}
+	return removed, nil
+}
+
+// orgSlug makes an organization name safe for use in paths.
+func orgSlug(org string) string {
+	if org == "" {
+		return "default"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, org)
+}
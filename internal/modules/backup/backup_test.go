@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeClock hands out deterministic, strictly increasing backup times.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Next() time.Time {
+	c.now = c.now.Add(time.Hour)
+	return c.now
+}
+
+func writeBundles(t *testing.T, dir, org string, clock *fakeClock, n int) []string {
+	t.Helper()
+	var names []string
+	for i := 0; i < n; i++ {
+		name := FileName(org, clock.Next(), "tar")
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("bundle"), 0600); err != nil {
+			t.Fatalf("write bundle: %v", err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestFileName_RoundTrip(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 8000, time.UTC)
+
+	name := FileName("prod-eu", ts, "tar")
+	if name != "prod-eu-20260304T050607.000008000Z.tar.gz" {
+		t.Fatalf("unexpected name %q", name)
+	}
+	if got := FileName("", ts, "json"); got != "default-20260304T050607.000008000Z.json" {
+		t.Fatalf("unexpected name for default org %q", got)
+	}
+
+	parsed, ok := ParseFileName("prod-eu", name)
+	if !ok || !parsed.Equal(ts) {
+		t.Fatalf("ParseFileName(%q) = %v, %v", name, parsed, ok)
+	}
+	if _, ok := ParseFileName("prod-eu", "notes.txt"); ok {
+		t.Fatal("expected non-bundle file to be rejected")
+	}
+	if _, ok := ParseFileName("prod", name); ok {
+		t.Fatal("expected another org's bundle to be rejected")
+	}
+}
+
+func TestFileName_SameSecondDoesNotCollide(t *testing.T) {
+	first := time.Date(2026, 3, 4, 5, 6, 7, 100, time.UTC)
+	second := first.Add(time.Millisecond)
+
+	a, b := FileName("prod", first, "tar"), FileName("prod", second, "tar")
+	if a == b {
+		t.Fatalf("expected distinct names within one second, got %q twice", a)
+	}
+	if a >= b {
+		t.Fatalf("expected names to sort chronologically, got %q >= %q", a, b)
+	}
+}
+
+func TestList_NewestFirstIgnoresForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	names := writeBundles(t, dir, "prod", clock, 3)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	bundles, err := List(dir, "prod")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(bundles) != 3 {
+		t.Fatalf("expected 3 bundles, got %d", len(bundles))
+	}
+	if bundles[0].Name != names[2] || bundles[2].Name != names[0] {
+		t.Fatalf("expected newest first, got %s..%s", bundles[0].Name, bundles[2].Name)
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	bundles, err := List(filepath.Join(t.TempDir(), "missing"), "prod")
+	if err != nil || len(bundles) != 0 {
+		t.Fatalf("expected no bundles and no error, got %v, %v", bundles, err)
+	}
+}
+
+func TestPrune_RemovesOldestBeyondKeep(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	names := writeBundles(t, dir, "prod", clock, 5)
+
+	removed, err := Prune(dir, "prod", 2)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(removed) != 3 {
+		t.Fatalf("expected 3 removed, got %d", len(removed))
+	}
+
+	remaining, err := List(dir, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 || remaining[0].Name != names[4] || remaining[1].Name != names[3] {
+		t.Fatalf("expected the two newest bundles to remain, got %+v", remaining)
+	}
+}
+
+func TestPrune_KeepZeroDisablesRotation(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	writeBundles(t, dir, "prod", clock, 3)
+
+	removed, err := Prune(dir, "prod", 0)
+	if err != nil || len(removed) != 0 {
+		t.Fatalf("expected nothing removed, got %v, %v", removed, err)
+	}
+}
+
+func TestPrune_SharedDirLeavesOtherOrgsAlone(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	prodNames := writeBundles(t, dir, "prod", clock, 3)
+	stagingNames := writeBundles(t, dir, "prod-staging", clock, 3)
+
+	listed, err := List(dir, "prod")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 3 {
+		t.Fatalf("expected only prod's 3 bundles, got %+v", listed)
+	}
+
+	removed, err := Prune(dir, "prod", 1)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("expected 2 removed, got %+v", removed)
+	}
+
+	remaining, err := List(dir, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != prodNames[2] {
+		t.Fatalf("expected prod's newest bundle to remain, got %+v", remaining)
+	}
+	for _, name := range stagingNames {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected other org's bundle %s to survive: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// isUserInputsPath reports whether path holds an action's input definitions:
+// trigger.userInputs, or userInputs on actions in the older format.
+func isUserInputsPath(path string) bool {
+	return path == "trigger.userInputs" || path == "userInputs"
+}
+
+// diffUserInputs compares two action userInputs objects input by input, so
+// an added, removed or edited input is reported under its own name, as
+// prefix.input[name], rather than as a change to the whole properties map.
+// A change to whether an input is required is reported as
+// prefix.input[name].required; order and any other keys are diffed as
+// plain fields.
+func diffUserInputs(source, target map[string]interface{}, prefix string) []FieldDiff {
+	sourceInputs, _ := source["properties"].(map[string]interface{})
+	targetInputs, _ := target["properties"].(map[string]interface{})
+	sourceRequired := requiredInputs(source)
+	targetRequired := requiredInputs(target)
+
+	names := make(map[string]bool)
+	for name := range sourceInputs {
+		names[name] = true
+	}
+	for name := range targetInputs {
+		names[name] = true
+	}
+
+	var diffs []FieldDiff
+	for name := range names {
+		path := fmt.Sprintf("%s.input[%s]", prefix, name)
+		sourceDef, inSource := sourceInputs[name]
+		targetDef, inTarget := targetInputs[name]
+		switch {
+		case !inSource:
+			diffs = append(diffs, FieldDiff{Path: path, TargetValue: targetDef})
+		case !inTarget:
+			diffs = append(diffs, FieldDiff{Path: path, SourceValue: sourceDef})
+		default:
+			if !reflect.DeepEqual(sourceDef, targetDef) {
+				sourceMap, sourceIsMap := sourceDef.(map[string]interface{})
+				targetMap, targetIsMap := targetDef.(map[string]interface{})
+				if sourceIsMap && targetIsMap {
+					diffs = append(diffs, diffFields(sourceMap, targetMap, path)...)
+				} else {
+					diffs = append(diffs, FieldDiff{Path: path, SourceValue: sourceDef, TargetValue: targetDef})
+				}
+			}
+			if sourceRequired[name] != targetRequired[name] {
+				diffs = append(diffs, FieldDiff{Path: path + ".required", SourceValue: sourceRequired[name], TargetValue: targetRequired[name]})
+			}
+		}
+	}
+
+	rest := func(userInputs map[string]interface{}) map[string]interface{} {
+		out := make(map[string]interface{}, len(userInputs))
+		for k, v := range userInputs {
+			if k == "properties" {
+				continue
+			}
+			if _, isList := v.([]interface{}); k == "required" && isList {
+				continue
+			}
+			out[k] = v
+		}
+		return out
+	}
+	diffs = append(diffs, diffFields(rest(source), rest(target), prefix)...)
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+// requiredInputs returns the input names listed in userInputs.required. A
+// required value that is not a list, such as a jq expression, is compared
+// as a plain field instead.
+func requiredInputs(userInputs map[string]interface{}) map[string]bool {
+	required := make(map[string]bool)
+	list, _ := userInputs["required"].([]interface{})
+	for _, item := range list {
+		if name, ok := item.(string); ok {
+			required[name] = true
+		}
+	}
+	return required
+}
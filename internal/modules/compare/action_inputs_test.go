@@ -0,0 +1,90 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func actionWithInputs(properties map[string]interface{}, required []interface{}, order []interface{}) api.Action {
+	userInputs := map[string]interface{}{"properties": properties, "required": required}
+	if order != nil {
+		userInputs["order"] = order
+	}
+	return api.Action{
+		"identifier": "deploy",
+		"trigger": map[string]interface{}{
+			"type":       "self-service",
+			"operation":  "DAY-2",
+			"userInputs": userInputs,
+		},
+	}
+}
+
+func TestDiffActions_UserInputsByName(t *testing.T) {
+	source := actionWithInputs(map[string]interface{}{
+		"env":     map[string]interface{}{"type": "string", "enum": []interface{}{"dev", "prod"}},
+		"version": map[string]interface{}{"type": "string", "title": "Version"},
+		"dryRun":  map[string]interface{}{"type": "boolean"},
+	}, []interface{}{"env"}, []interface{}{"env", "version", "dryRun"})
+	target := actionWithInputs(map[string]interface{}{
+		"env":     map[string]interface{}{"type": "string", "enum": []interface{}{"dev", "staging", "prod"}},
+		"version": map[string]interface{}{"type": "string", "title": "Version"},
+		"reason":  map[string]interface{}{"type": "string"},
+	}, []interface{}{"env", "version"}, []interface{}{"env", "version", "reason"})
+
+	result := NewDiffer().Diff(&export.Data{Actions: []api.Action{source}}, &export.Data{Actions: []api.Action{target}}, nil)
+	if result.Actions.Summary.Modified != 1 {
+		t.Fatalf("expected 1 modified action, got %+v", result.Actions.Summary)
+	}
+
+	got := make(map[string]FieldDiff)
+	var paths []string
+	for _, fd := range result.Actions.Modified[0].FieldDiffs {
+		got[fd.Path] = fd
+		paths = append(paths, fd.Path)
+	}
+	want := []string{
+		"trigger.userInputs.input[dryRun]",
+		"trigger.userInputs.input[env].enum",
+		"trigger.userInputs.input[reason]",
+		"trigger.userInputs.input[version].required",
+		"trigger.userInputs.order",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	if fd := got["trigger.userInputs.input[dryRun]"]; fd.SourceValue == nil || fd.TargetValue != nil {
+		t.Errorf("removed input should have only a source value, got %+v", fd)
+	}
+	if fd := got["trigger.userInputs.input[reason]"]; fd.SourceValue != nil || fd.TargetValue == nil {
+		t.Errorf("added input should have only a target value, got %+v", fd)
+	}
+	if fd := got["trigger.userInputs.input[version].required"]; fd.SourceValue != false || fd.TargetValue != true {
+		t.Errorf("required change = %+v, want false -> true", fd)
+	}
+}
+
+func TestDiffUserInputs_RequiredExpression(t *testing.T) {
+	source := map[string]interface{}{
+		"properties": map[string]interface{}{"env": map[string]interface{}{"type": "string"}},
+		"required":   map[string]interface{}{"jqQuery": `["env"]`},
+	}
+	target := map[string]interface{}{
+		"properties": map[string]interface{}{"env": map[string]interface{}{"type": "string"}},
+		"required":   map[string]interface{}{"jqQuery": `[]`},
+	}
+	diffs := diffUserInputs(source, target, "trigger.userInputs")
+	if len(diffs) != 1 || diffs[0].Path != "trigger.userInputs.required.jqQuery" {
+		t.Fatalf("expected the jq required expression diffed as a field, got %+v", diffs)
+	}
+}
+
+func TestDiffUserInputs_Identical(t *testing.T) {
+	action := actionWithInputs(map[string]interface{}{"env": map[string]interface{}{"type": "string"}}, []interface{}{"env"}, nil)
+	if diffs := diffFields(action, action, ""); len(diffs) != 0 {
+		t.Fatalf("expected no diffs, got %+v", diffs)
+	}
+}
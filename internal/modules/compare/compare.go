@@ -93,8 +93,14 @@ func (m *Module) FormatOutput(result *CompareResult, opts Options) error {
 		fmt.Printf("HTML report written to %s\n", filePath)
 		return nil
 
+	case "markdown":
+		formatter := NewMarkdownFormatter(w)
+		formatter.SetModifiedOrder(opts.SortBy, opts.MinChanges)
+		return formatter.Format(result)
+
 	default: // text
 		formatter := NewTextFormatter(w, opts.Verbose, opts.Full, opts.IncludeResources)
+		formatter.SetModifiedOrder(opts.SortBy, opts.MinChanges)
 		return formatter.Format(result)
 	}
 }
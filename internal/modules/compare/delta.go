@@ -0,0 +1,142 @@
+package compare
+
+import (
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/useragent"
+)
+
+// deltaResources are the resource types BuildDelta compares.
+var deltaResources = []string{
+	"blueprints", "actions", "scorecards", "pages", "integrations", "teams", "users",
+	"blueprint-permissions", "action-permissions", "entities",
+}
+
+// BuildDelta returns a delta bundle holding the resources that were added or
+// modified between oldData and newData, as full objects from newData, and a
+// Deletion for each resource that was removed. Importing the delta into an
+// organization that matches oldData brings it to newData; deletions are only
+// applied with port import --prune. Users are never deleted.
+func BuildDelta(oldData, newData *export.Data) *export.Data {
+	d := NewDiffer()
+	diff := d.Diff(oldData, newData, deltaResources)
+	folders := diffResources(toMaps(oldData.Folders), toMaps(newData.Folders), "identifier")
+	pagePermissions := d.diffPermissions(oldData.PagePermissions, newData.PagePermissions)
+
+	byIdentifier := func(item map[string]interface{}) string {
+		id, _ := item["identifier"].(string)
+		return id
+	}
+	delta := &export.Data{
+		Blueprints:           changedItems(newData.Blueprints, diff.Blueprints, byIdentifier),
+		Entities:             changedItems(newData.Entities, diff.Entities, entityDiffKey),
+		Scorecards:           changedItems(newData.Scorecards, diff.Scorecards, byIdentifier),
+		Actions:              changedItems(newData.Actions, diff.Actions, byIdentifier),
+		Teams:                changedItems(newData.Teams, diff.Teams, stringField("name")),
+		Users:                changedItems(newData.Users, diff.Users, stringField("email")),
+		Folders:              changedItems(newData.Folders, folders, byIdentifier),
+		Pages:                changedItems(newData.Pages, diff.Pages, byIdentifier),
+		Integrations:         changedItems(newData.Integrations, diff.Integrations, stringField("installationId")),
+		BlueprintPermissions: changedPermissions(newData.BlueprintPermissions, diff.BlueprintPermissions),
+		ActionPermissions:    changedPermissions(newData.ActionPermissions, diff.ActionPermissions),
+		PagePermissions:      changedPermissions(newData.PagePermissions, pagePermissions),
+		Manifest:             export.Manifest{Anonymized: newData.Manifest.Anonymized, Delta: true, CLIVersion: useragent.Version()},
+	}
+
+	delta.Deletions = appendDeletions(delta.Deletions, "entities", diff.Entities, func(c ResourceChange) export.Deletion {
+		bp, id, _ := strings.Cut(c.Identifier, "/")
+		return export.Deletion{Type: "entities", Identifier: id, Blueprint: bp}
+	})
+	delta.Deletions = appendDeletions(delta.Deletions, "scorecards", diff.Scorecards, func(c ResourceChange) export.Deletion {
+		bp, _ := c.SourceData["blueprintIdentifier"].(string)
+		return export.Deletion{Type: "scorecards", Identifier: c.Identifier, Blueprint: bp}
+	})
+	for _, section := range []struct {
+		name string
+		diff ResourceDiff
+	}{
+		{"actions", diff.Actions},
+		{"pages", diff.Pages},
+		{"_folders", folders},
+		{"integrations", diff.Integrations},
+		{"teams", diff.Teams},
+		{"blueprints", diff.Blueprints},
+	} {
+		delta.Deletions = appendDeletions(delta.Deletions, section.name, section.diff, nil)
+	}
+	return delta
+}
+
+// DeltaSize returns how many resources a delta bundle adds or modifies.
+func DeltaSize(delta *export.Data) int {
+	return len(delta.Blueprints) + len(delta.Entities) + len(delta.Scorecards) +
+		len(delta.Actions) + len(delta.Teams) + len(delta.Users) + len(delta.Folders) +
+		len(delta.Pages) + len(delta.Integrations) + len(delta.BlueprintPermissions) +
+		len(delta.ActionPermissions) + len(delta.PagePermissions)
+}
+
+// changedItems returns the items of newItems that diff reports as added or
+// modified, matched by key and kept in their original order.
+func changedItems[T ~map[string]interface{}](newItems []T, diff ResourceDiff, key func(map[string]interface{}) string) []T {
+	changed := changedIdentifiers(diff)
+	result := make([]T, 0, len(changed))
+	for _, item := range newItems {
+		if changed[key(item)] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// changedPermissions returns the entries of newPerms that diff reports as
+// added or modified.
+func changedPermissions(newPerms map[string]api.Permissions, diff ResourceDiff) map[string]api.Permissions {
+	changed := changedIdentifiers(diff)
+	result := make(map[string]api.Permissions, len(changed))
+	for id, perms := range newPerms {
+		if changed[id] {
+			result[id] = perms
+		}
+	}
+	return result
+}
+
+func changedIdentifiers(diff ResourceDiff) map[string]bool {
+	changed := make(map[string]bool, len(diff.Added)+len(diff.Modified))
+	for _, c := range diff.Added {
+		changed[c.Identifier] = true
+	}
+	for _, c := range diff.Modified {
+		changed[c.Identifier] = true
+	}
+	return changed
+}
+
+// appendDeletions adds a Deletion for every resource diff reports as removed.
+// A nil build records the change's identifier alone.
+func appendDeletions(deletions []export.Deletion, section string, diff ResourceDiff, build func(ResourceChange) export.Deletion) []export.Deletion {
+	for _, c := range diff.Removed {
+		if build != nil {
+			deletions = append(deletions, build(c))
+			continue
+		}
+		deletions = append(deletions, export.Deletion{Type: section, Identifier: c.Identifier})
+	}
+	return deletions
+}
+
+// entityDiffKey returns the key diffEntities compares an entity by.
+func entityDiffKey(entity map[string]interface{}) string {
+	bp, _ := entity["blueprint"].(string)
+	id, _ := entity["identifier"].(string)
+	return bp + "/" + id
+}
+
+func stringField(field string) func(map[string]interface{}) string {
+	return func(item map[string]interface{}) string {
+		value, _ := item[field].(string)
+		return value
+	}
+}
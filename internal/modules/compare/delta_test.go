@@ -0,0 +1,93 @@
+package compare
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func deltaFixtures() (oldData, newData *export.Data) {
+	oldData = &export.Data{
+		Blueprints: []api.Blueprint{
+			{"identifier": "service", "title": "Service"},
+			{"identifier": "legacy", "title": "Legacy"},
+		},
+		Entities: []api.Entity{
+			{"blueprint": "service", "identifier": "api", "title": "API"},
+			{"blueprint": "service", "identifier": "old", "title": "Old"},
+		},
+		Scorecards: []api.Scorecard{{"identifier": "readiness", "blueprintIdentifier": "legacy"}},
+	}
+	newData = &export.Data{
+		Blueprints: []api.Blueprint{
+			{"identifier": "service", "title": "Service", "updatedAt": "2026-01-02"},
+			{"identifier": "team", "title": "Team"},
+		},
+		Entities: []api.Entity{
+			{"blueprint": "service", "identifier": "api", "title": "API v2"},
+		},
+		BlueprintPermissions: map[string]api.Permissions{"team": {"entities": map[string]interface{}{}}},
+	}
+	return oldData, newData
+}
+
+func TestBuildDelta(t *testing.T) {
+	delta := BuildDelta(deltaFixtures())
+
+	if !delta.Manifest.Delta {
+		t.Error("expected the manifest to mark the bundle as a delta")
+	}
+	// updatedAt is excluded from the comparison, so service is unchanged.
+	if len(delta.Blueprints) != 1 || delta.Blueprints[0]["identifier"] != "team" {
+		t.Errorf("blueprints = %v, want only the added team blueprint", delta.Blueprints)
+	}
+	if len(delta.Entities) != 1 || delta.Entities[0]["title"] != "API v2" || delta.Entities[0]["identifier"] != "api" {
+		t.Errorf("entities = %v, want the modified api entity as a full object", delta.Entities)
+	}
+	if _, ok := delta.BlueprintPermissions["team"]; !ok || len(delta.BlueprintPermissions) != 1 {
+		t.Errorf("blueprint permissions = %v, want the added team permissions", delta.BlueprintPermissions)
+	}
+
+	want := []export.Deletion{
+		{Type: "entities", Identifier: "old", Blueprint: "service"},
+		{Type: "scorecards", Identifier: "readiness", Blueprint: "legacy"},
+		{Type: "blueprints", Identifier: "legacy"},
+	}
+	if !reflect.DeepEqual(delta.Deletions, want) {
+		t.Errorf("deletions = %+v, want %+v", delta.Deletions, want)
+	}
+	if got := DeltaSize(delta); got != 3 {
+		t.Errorf("DeltaSize = %d, want 3", got)
+	}
+}
+
+func TestBuildDelta_RoundTripsThroughBundle(t *testing.T) {
+	delta := BuildDelta(deltaFixtures())
+
+	for _, name := range []string{"delta.tar.gz", "delta.json", "delta.ndjson"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := export.WriteBundle(delta, path); err != nil {
+			t.Fatalf("WriteBundle(%s): %v", name, err)
+		}
+		loaded, err := import_module.NewLoader().LoadData(path)
+		if err != nil {
+			t.Fatalf("LoadData(%s): %v", name, err)
+		}
+		if !loaded.Manifest.Delta {
+			t.Errorf("%s: manifest lost the delta flag", name)
+		}
+		if !reflect.DeepEqual(loaded.Deletions, delta.Deletions) {
+			t.Errorf("%s: deletions = %+v, want %+v", name, loaded.Deletions, delta.Deletions)
+		}
+		if len(loaded.Blueprints) != 1 || len(loaded.Entities) != 1 {
+			t.Errorf("%s: loaded %d blueprints and %d entities, want 1 and 1", name, len(loaded.Blueprints), len(loaded.Entities))
+		}
+		if err := import_module.NewLoader().ValidateData(loaded, nil); err != nil {
+			t.Errorf("%s: ValidateData: %v", name, err)
+		}
+	}
+}
@@ -266,6 +266,7 @@ func diffResources(source, target []map[string]interface{}, idField string) Reso
 }
 
 // diffFields recursively compares two maps and returns field differences.
+// Action input definitions are compared input by input (see diffUserInputs).
 func diffFields(source, target map[string]interface{}, prefix string) []FieldDiff {
 	var diffs []FieldDiff
 
@@ -309,7 +310,9 @@ func diffFields(source, target map[string]interface{}, prefix string) []FieldDif
 			sourceMap, sourceIsMap := sourceVal.(map[string]interface{})
 			targetMap, targetIsMap := targetVal.(map[string]interface{})
 
-			if sourceIsMap && targetIsMap {
+			if sourceIsMap && targetIsMap && isUserInputsPath(path) {
+				diffs = append(diffs, diffUserInputs(sourceMap, targetMap, path)...)
+			} else if sourceIsMap && targetIsMap {
 				diffs = append(diffs, diffFields(sourceMap, targetMap, path)...)
 			} else {
 				diffs = append(diffs, FieldDiff{
@@ -106,6 +106,12 @@ func (f *Fetcher) fetchFromOrg(ctx context.Context, opts FetchOptions) (*OrgData
 		ClientID:     orgConfig.ClientID,
 		ClientSecret: orgConfig.ClientSecret,
 		APIURL:       orgConfig.APIURL,
+		RateLimit:    orgConfig.RateLimit,
+		Concurrency:  orgConfig.Concurrency,
+		Headers:      orgConfig.Headers,
+		APIVersion:   orgConfig.APIVersion,
+		CacheTTL:     orgConfig.CacheTTL,
+		NoCache:      orgConfig.NoCache,
 		Timeout:      0,
 	})
 	defer client.Close()
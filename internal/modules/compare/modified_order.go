@@ -0,0 +1,64 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Orders for the modified resources listed by the text and Markdown
+// formatters.
+const (
+	SortByName    = "name"    // alphabetical by identifier (default)
+	SortByChanges = "changes" // most changed fields first
+)
+
+// SortByValues lists the accepted --sort-by values.
+var SortByValues = []string{SortByName, SortByChanges}
+
+// modifiedOrder selects and orders the modified resources a formatter lists.
+// The zero value lists every modified resource alphabetically.
+type modifiedOrder struct {
+	sortBy     string
+	minChanges int
+}
+
+// arrange returns the modified resources with at least minChanges field
+// differences, in the requested order, and how many were left out. changes
+// itself is not reordered.
+func (o modifiedOrder) arrange(changes []ResourceChange) (shown []ResourceChange, hidden int) {
+	shown = make([]ResourceChange, 0, len(changes))
+	for _, change := range changes {
+		if len(change.FieldDiffs) < o.minChanges {
+			hidden++
+			continue
+		}
+		shown = append(shown, change)
+	}
+	if o.sortBy == SortByChanges {
+		// The differ lists modified resources alphabetically, so a stable
+		// sort keeps ties in that order.
+		sort.SliceStable(shown, func(i, j int) bool {
+			return len(shown[i].FieldDiffs) > len(shown[j].FieldDiffs)
+		})
+	}
+	return shown, hidden
+}
+
+// byChanges reports whether resources are ordered by their number of changes,
+// in which case formatters show that number next to each.
+func (o modifiedOrder) byChanges() bool {
+	return o.sortBy == SortByChanges
+}
+
+// hiddenNote describes the modified resources left out by minChanges.
+func (o modifiedOrder) hiddenNote(hidden int) string {
+	return fmt.Sprintf("%d modified with fewer than %d changed fields not shown", hidden, o.minChanges)
+}
+
+// fieldCount renders n changed fields as "1 field" or "n fields".
+func fieldCount(n int) string {
+	if n == 1 {
+		return "1 field"
+	}
+	return fmt.Sprintf("%d fields", n)
+}
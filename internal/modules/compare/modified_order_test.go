@@ -0,0 +1,103 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func modifiedWithFields(id string, n int) ResourceChange {
+	change := ResourceChange{Identifier: id}
+	for i := 0; i < n; i++ {
+		change.FieldDiffs = append(change.FieldDiffs, FieldDiff{Path: "f" + string(rune('a'+i))})
+	}
+	return change
+}
+
+func identifiers(changes []ResourceChange) string {
+	ids := make([]string, len(changes))
+	for i, c := range changes {
+		ids[i] = c.Identifier
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestModifiedOrder_Arrange(t *testing.T) {
+	changes := []ResourceChange{
+		modifiedWithFields("api", 1),
+		modifiedWithFields("billing", 3),
+		modifiedWithFields("cart", 1),
+		modifiedWithFields("db", 3),
+	}
+
+	shown, hidden := modifiedOrder{}.arrange(changes)
+	if identifiers(shown) != "api,billing,cart,db" || hidden != 0 {
+		t.Errorf("default order: got %s (hidden %d)", identifiers(shown), hidden)
+	}
+
+	shown, hidden = modifiedOrder{sortBy: SortByChanges}.arrange(changes)
+	if identifiers(shown) != "billing,db,api,cart" || hidden != 0 {
+		t.Errorf("by changes: got %s (hidden %d), want ties kept alphabetical", identifiers(shown), hidden)
+	}
+
+	shown, hidden = modifiedOrder{minChanges: 2}.arrange(changes)
+	if identifiers(shown) != "billing,db" || hidden != 2 {
+		t.Errorf("min changes: got %s (hidden %d)", identifiers(shown), hidden)
+	}
+
+	if identifiers(changes) != "api,billing,cart,db" {
+		t.Errorf("arrange reordered its input: %s", identifiers(changes))
+	}
+}
+
+func TestTextFormatter_SortByChangesWithMinimum(t *testing.T) {
+	result := &CompareResult{
+		Source: "staging",
+		Target: "production",
+		Blueprints: ResourceDiff{
+			Summary:  DiffSummary{Modified: 3},
+			Modified: []ResourceChange{modifiedWithFields("api", 2), modifiedWithFields("billing", 1), modifiedWithFields("cart", 4)},
+		},
+	}
+
+	var buf bytes.Buffer
+	f := NewTextFormatter(&buf, true, false, nil)
+	f.SetModifiedOrder(SortByChanges, 2)
+	if err := f.Format(result); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"0 added, 3 modified, 0 removed",
+		"Modified: cart (4), api (2)\n",
+		"(1 modified with fewer than 2 changed fields not shown)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownFormatter_SortByChanges(t *testing.T) {
+	result := &CompareResult{
+		Source: "staging",
+		Target: "production",
+		Actions: ResourceDiff{
+			Summary:  DiffSummary{Modified: 2},
+			Modified: []ResourceChange{modifiedWithFields("deploy", 1), modifiedWithFields("rollback", 2)},
+		},
+	}
+
+	var buf bytes.Buffer
+	f := NewMarkdownFormatter(&buf)
+	f.SetModifiedOrder(SortByChanges, 0)
+	if err := f.Format(result); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	rollback := strings.Index(out, "**Modified** `rollback` (2 fields)")
+	deploy := strings.Index(out, "**Modified** `deploy` (1 field)")
+	if rollback < 0 || deploy < 0 || rollback > deploy {
+		t.Errorf("expected rollback before deploy with field counts:\n%s", out)
+	}
+}
@@ -0,0 +1,107 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownFormatter formats comparison results as a Markdown document.
+type MarkdownFormatter struct {
+	w        io.Writer
+	modified modifiedOrder
+}
+
+// NewMarkdownFormatter creates a new Markdown formatter.
+func NewMarkdownFormatter(w io.Writer) *MarkdownFormatter {
+	return &MarkdownFormatter{w: w}
+}
+
+// SetModifiedOrder orders the listed modified resources by sortBy (SortByName
+// or SortByChanges) and leaves out those with fewer than minChanges changed
+// fields. The summary table still counts every modified resource.
+func (f *MarkdownFormatter) SetModifiedOrder(sortBy string, minChanges int) {
+	f.modified = modifiedOrder{sortBy: sortBy, minChanges: minChanges}
+}
+
+// Format outputs the comparison result as Markdown: a summary table followed
+// by one section per resource type with changes.
+func (f *MarkdownFormatter) Format(result *CompareResult) error {
+	sections := []struct {
+		name string
+		diff ResourceDiff
+	}{
+		{"Blueprints", result.Blueprints},
+		{"Actions", result.Actions},
+		{"Scorecards", result.Scorecards},
+		{"Pages", result.Pages},
+		{"Integrations", result.Integrations},
+		{"Teams", result.Teams},
+		{"Users", result.Users},
+		{"Automations", result.Automations},
+		{"Blueprint Permissions", result.BlueprintPermissions},
+		{"Action Permissions", result.ActionPermissions},
+		{"Entities", result.Entities},
+	}
+
+	fmt.Fprintf(f.w, "# %s → %s\n\n", result.Source, result.Target)
+	if result.Timestamp != "" {
+		fmt.Fprintf(f.w, "Generated %s\n\n", result.Timestamp)
+	}
+	if result.Identical {
+		fmt.Fprintf(f.w, "No differences.\n")
+		return nil
+	}
+
+	fmt.Fprintf(f.w, "| Resource | Added | Modified | Removed |\n")
+	fmt.Fprintf(f.w, "|----------|------:|---------:|--------:|\n")
+	for _, s := range sections {
+		sum := s.diff.Summary
+		if sum.Added == 0 && sum.Modified == 0 && sum.Removed == 0 {
+			continue
+		}
+		fmt.Fprintf(f.w, "| %s | %d | %d | %d |\n", s.name, sum.Added, sum.Modified, sum.Removed)
+	}
+
+	for _, s := range sections {
+		f.formatSection(s.name, s.diff)
+	}
+	return nil
+}
+
+func (f *MarkdownFormatter) formatSection(name string, diff ResourceDiff) {
+	if len(diff.Added) == 0 && len(diff.Modified) == 0 && len(diff.Removed) == 0 {
+		return
+	}
+	fmt.Fprintf(f.w, "\n## %s\n", name)
+	for _, change := range diff.Added {
+		fmt.Fprintf(f.w, "\n- **Added** `%s`\n", change.Identifier)
+	}
+	modified, hidden := f.modified.arrange(diff.Modified)
+	for _, change := range modified {
+		if f.modified.byChanges() {
+			fmt.Fprintf(f.w, "\n- **Modified** `%s` (%s)\n", change.Identifier, fieldCount(len(change.FieldDiffs)))
+		} else {
+			fmt.Fprintf(f.w, "\n- **Modified** `%s`\n", change.Identifier)
+		}
+		for _, fd := range change.FieldDiffs {
+			fmt.Fprintf(f.w, "  - `%s`: %s → %s\n", fd.Path, markdownValue(fd.SourceValue), markdownValue(fd.TargetValue))
+		}
+	}
+	if hidden > 0 {
+		fmt.Fprintf(f.w, "\n_%s._\n", f.modified.hiddenNote(hidden))
+	}
+	for _, change := range diff.Removed {
+		fmt.Fprintf(f.w, "\n- **Removed** `%s`\n", change.Identifier)
+	}
+}
+
+// markdownValue renders a field value as inline code, keeping table and list
+// syntax intact.
+func markdownValue(v interface{}) string {
+	if v == nil {
+		return "_none_"
+	}
+	s := strings.ReplaceAll(fmt.Sprintf("%v", v), "`", "'")
+	return "`" + strings.ReplaceAll(s, "\n", " ") + "`"
+}
@@ -0,0 +1,53 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownFormatter(t *testing.T) {
+	result := &CompareResult{
+		Source:    "staging",
+		Target:    "production",
+		Timestamp: "2026-02-05T19:30:00Z",
+		Blueprints: ResourceDiff{
+			Summary: DiffSummary{Added: 1, Modified: 1},
+			Added:   []ResourceChange{{Identifier: "new-bp"}},
+			Modified: []ResourceChange{{
+				Identifier: "service",
+				FieldDiffs: []FieldDiff{{Path: "title", SourceValue: "Service", TargetValue: "Microservice"}},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(&buf).Format(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# staging → production",
+		"| Blueprints | 1 | 1 | 0 |",
+		"## Blueprints",
+		"**Added** `new-bp`",
+		"`title`: `Service` → `Microservice`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "## Entities") {
+		t.Errorf("unchanged resource types should be omitted:\n%s", out)
+	}
+}
+
+func TestMarkdownFormatterIdentical(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(&buf).Format(&CompareResult{Source: "a", Target: "b", Identical: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No differences.") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -13,6 +13,7 @@ type TextFormatter struct {
 	verbose          bool
 	full             bool
 	includeResources []string
+	modified         modifiedOrder
 }
 
 // NewTextFormatter creates a new text formatter.
@@ -25,6 +26,13 @@ func NewTextFormatter(w io.Writer, verbose, full bool, includeResources []string
 	}
 }
 
+// SetModifiedOrder orders the listed modified resources by sortBy (SortByName
+// or SortByChanges) and leaves out those with fewer than minChanges changed
+// fields. The summary counts still include every modified resource.
+func (f *TextFormatter) SetModifiedOrder(sortBy string, minChanges int) {
+	f.modified = modifiedOrder{sortBy: sortBy, minChanges: minChanges}
+}
+
 // Format outputs the comparison result as text.
 func (f *TextFormatter) Format(result *CompareResult) error {
 	// Header
@@ -69,16 +77,26 @@ func (f *TextFormatter) formatResourceType(name string, diff ResourceDiff) {
 	fmt.Fprintf(f.w, "%-14s %d added, %d modified, %d removed\n",
 		name+":", s.Added, s.Modified, s.Removed)
 
+	modified, hidden := f.modified.arrange(diff.Modified)
+
 	// Verbose: show identifiers
 	if f.verbose || f.full {
 		if len(diff.Added) > 0 {
 			ids := f.getIdentifiers(diff.Added)
 			fmt.Fprintf(f.w, "  Added:    %s\n", strings.Join(ids, ", "))
 		}
-		if len(diff.Modified) > 0 {
-			ids := f.getIdentifiers(diff.Modified)
+		if len(modified) > 0 {
+			ids := f.getIdentifiers(modified)
+			if f.modified.byChanges() {
+				for i, change := range modified {
+					ids[i] = fmt.Sprintf("%s (%d)", change.Identifier, len(change.FieldDiffs))
+				}
+			}
 			fmt.Fprintf(f.w, "  Modified: %s\n", strings.Join(ids, ", "))
 		}
+		if hidden > 0 {
+			fmt.Fprintf(f.w, "  (%s)\n", f.modified.hiddenNote(hidden))
+		}
 		if len(diff.Removed) > 0 {
 			ids := f.getIdentifiers(diff.Removed)
 			fmt.Fprintf(f.w, "  Removed:  %s\n", strings.Join(ids, ", "))
@@ -91,8 +109,12 @@ func (f *TextFormatter) formatResourceType(name string, diff ResourceDiff) {
 			fmt.Fprintf(f.w, "\n  [+] %s (added)\n", change.Identifier)
 			f.formatData(change.TargetData, "      ")
 		}
-		for _, change := range diff.Modified {
-			fmt.Fprintf(f.w, "\n  [~] %s (modified)\n", change.Identifier)
+		for _, change := range modified {
+			if f.modified.byChanges() {
+				fmt.Fprintf(f.w, "\n  [~] %s (modified, %s)\n", change.Identifier, fieldCount(len(change.FieldDiffs)))
+			} else {
+				fmt.Fprintf(f.w, "\n  [~] %s (modified)\n", change.Identifier)
+			}
 			for _, fd := range change.FieldDiffs {
 				fmt.Fprintf(f.w, "      %s:\n", fd.Path)
 				fmt.Fprintf(f.w, "        - %v\n", fd.SourceValue)
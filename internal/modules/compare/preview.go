@@ -0,0 +1,144 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+// UpdatePreview lists the field-level changes an import would apply to the
+// resources of one type.
+type UpdatePreview struct {
+	ResourceType string
+	Changes      []ResourceChange
+}
+
+// PreviewUpdates compares every resource that diff plans to update with the
+// target organization's current version of it. In each FieldDiff, SourceValue
+// is the current value and TargetValue the incoming one. Resource types with
+// no updates are omitted.
+func PreviewUpdates(diff *import_module.DiffResult) []UpdatePreview {
+	if diff == nil || diff.Current == nil {
+		return nil
+	}
+	current := diff.Current
+
+	var previews []UpdatePreview
+	add := func(resourceType, diffType string, currentItems, updates []map[string]interface{}, keyFields ...string) {
+		if changes := previewChanges(currentItems, updates, normalizerFor(diff, diffType), keyFields); len(changes) > 0 {
+			previews = append(previews, UpdatePreview{ResourceType: resourceType, Changes: changes})
+		}
+	}
+
+	add("Blueprints", "blueprints", toMaps(current.Blueprints), toMaps(diff.BlueprintsToUpdate), "identifier")
+	add("Entities", "entities", toMaps(current.Entities), toMaps(diff.EntitiesToUpdate), "blueprint", "identifier")
+	add("Scorecards", "scorecards", toMaps(current.Scorecards), toMaps(diff.ScorecardsToUpdate), "blueprintIdentifier", "identifier")
+	add("Actions", "actions", toMaps(current.Actions), toMaps(diff.ActionsToUpdate), "identifier")
+	add("Teams", "teams", toMaps(current.Teams), toMaps(diff.TeamsToUpdate), "name")
+	add("Users", "users", toMaps(current.Users), toMaps(diff.UsersToUpdate), "email")
+	add("Pages", "pages", toMaps(current.Pages), toMaps(diff.PagesToUpdate), "identifier")
+	add("Integrations", "integrations", toMaps(current.Integrations), toMaps(diff.IntegrationsToUpdate), "identifier")
+	add("Data sources", "datasources", toMaps(current.DataSources), toMaps(diff.DataSourcesToUpdate), "identifier")
+
+	return previews
+}
+
+// normalizer reduces an update and the current resource it replaces to the
+// values the import diff compares.
+type normalizer func(desired, current map[string]interface{}) (map[string]interface{}, map[string]interface{})
+
+// normalizerFor returns the normalizer diff uses for resourceType.
+func normalizerFor(diff *import_module.DiffResult, resourceType string) normalizer {
+	return func(desired, current map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+		return diff.NormalizedForDiff(resourceType, desired, current)
+	}
+}
+
+// previewChanges diffs each update against the current resource with the
+// same key. When normalize is set, both are normalized first so that only
+// the differences the import diff acted on are reported.
+func previewChanges(currentItems, updates []map[string]interface{}, normalize normalizer, keyFields []string) []ResourceChange {
+	currentByKey := make(map[string]map[string]interface{}, len(currentItems))
+	for _, item := range currentItems {
+		if key, ok := previewKey(item, keyFields); ok {
+			currentByKey[key] = item
+		}
+	}
+
+	var changes []ResourceChange
+	for _, incoming := range updates {
+		key, ok := previewKey(incoming, keyFields)
+		if !ok {
+			continue
+		}
+		existing, ok := currentByKey[key]
+		if !ok {
+			continue
+		}
+		before, after := existing, incoming
+		if normalize != nil {
+			after, before = normalize(incoming, existing)
+		}
+		if fieldDiffs := diffFields(before, after, ""); len(fieldDiffs) > 0 {
+			changes = append(changes, ResourceChange{
+				Identifier: key,
+				SourceData: existing,
+				TargetData: incoming,
+				FieldDiffs: fieldDiffs,
+			})
+		}
+	}
+	return changes
+}
+
+// previewKey joins the values of keyFields with "/", matching how compare
+// identifies entities.
+func previewKey(item map[string]interface{}, keyFields []string) (string, bool) {
+	parts := make([]string, 0, len(keyFields))
+	for _, field := range keyFields {
+		v, ok := item[field].(string)
+		if !ok || v == "" {
+			return "", false
+		}
+		parts = append(parts, v)
+	}
+	return strings.Join(parts, "/"), true
+}
+
+// FormatUpdatePreviews writes previews in the same style as `compare --full`.
+func FormatUpdatePreviews(w io.Writer, previews []UpdatePreview) {
+	if len(previews) == 0 {
+		fmt.Fprintf(w, "\nNo field-level changes to preview.\n")
+		return
+	}
+	for _, preview := range previews {
+		fmt.Fprintf(w, "\n%s:\n", preview.ResourceType)
+		for _, change := range preview.Changes {
+			fmt.Fprintf(w, "  [~] %s\n", change.Identifier)
+			for _, fd := range change.FieldDiffs {
+				fmt.Fprintf(w, "      %s:\n", fd.Path)
+				fmt.Fprintf(w, "        - %v\n", fd.SourceValue)
+				fmt.Fprintf(w, "        + %v\n", fd.TargetValue)
+			}
+		}
+	}
+}
+
+// UpdatePreviewsJSON converts previews to the JSON shape used by compare,
+// keyed by lower-case resource type. Full resource bodies are left out.
+func UpdatePreviewsJSON(previews []UpdatePreview) map[string][]JSONResourceChange {
+	out := make(map[string][]JSONResourceChange, len(previews))
+	for _, preview := range previews {
+		key := strings.ToLower(preview.ResourceType)
+		for _, change := range preview.Changes {
+			jc := JSONResourceChange{Identifier: change.Identifier}
+			for _, fd := range change.FieldDiffs {
+				jc.Changes = append(jc.Changes, JSONFieldDiff(fd))
+			}
+			out[key] = append(out[key], jc)
+		}
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestPreviewUpdates_FieldLevelChanges(t *testing.T) {
+	diff := &import_module.DiffResult{
+		Current: &export.Data{
+			Blueprints: []api.Blueprint{
+				{"identifier": "service", "title": "Service", "updatedAt": "yesterday"},
+				{"identifier": "untouched", "title": "Untouched"},
+			},
+			Scorecards: []api.Scorecard{
+				{"blueprintIdentifier": "service", "identifier": "health", "title": "Health"},
+			},
+		},
+		BlueprintsToUpdate: []api.Blueprint{
+			{"identifier": "service", "title": "Microservice", "updatedAt": "today"},
+		},
+		ScorecardsToUpdate: []api.Scorecard{
+			{"blueprintIdentifier": "service", "identifier": "health", "title": "Health v2"},
+		},
+	}
+
+	previews := PreviewUpdates(diff)
+	if len(previews) != 2 {
+		t.Fatalf("expected blueprint and scorecard previews, got %+v", previews)
+	}
+
+	bp := previews[0]
+	if bp.ResourceType != "Blueprints" || len(bp.Changes) != 1 {
+		t.Fatalf("unexpected blueprint preview %+v", bp)
+	}
+	fds := bp.Changes[0].FieldDiffs
+	if len(fds) != 1 || fds[0].Path != "title" || fds[0].SourceValue != "Service" || fds[0].TargetValue != "Microservice" {
+		t.Fatalf("expected only the title change (updatedAt excluded), got %+v", fds)
+	}
+	if previews[1].Changes[0].Identifier != "service/health" {
+		t.Fatalf("unexpected scorecard key %q", previews[1].Changes[0].Identifier)
+	}
+
+	var buf bytes.Buffer
+	FormatUpdatePreviews(&buf, previews)
+	out := buf.String()
+	for _, want := range []string{"[~] service", "- Service", "+ Microservice", "[~] service/health"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPreviewUpdates_NoCurrentState(t *testing.T) {
+	if previews := PreviewUpdates(&import_module.DiffResult{}); previews != nil {
+		t.Fatalf("expected no previews without current state, got %+v", previews)
+	}
+}
+
+func TestPreviewUpdates_IgnoresArrayOrder(t *testing.T) {
+	diff := &import_module.DiffResult{
+		Current: &export.Data{
+			Actions: []api.Action{
+				{"identifier": "deploy", "title": "Deploy", "tags": []interface{}{"a", "b"}},
+			},
+		},
+		ActionsToUpdate: []api.Action{
+			{"identifier": "deploy", "title": "Deploy now", "tags": []interface{}{"b", "a"}},
+		},
+	}
+
+	previews := PreviewUpdates(diff)
+	if len(previews) != 1 || len(previews[0].Changes) != 1 {
+		t.Fatalf("expected one action preview, got %+v", previews)
+	}
+	fds := previews[0].Changes[0].FieldDiffs
+	if len(fds) != 1 || fds[0].Path != "title" {
+		t.Fatalf("expected only the title change (tags reordered), got %+v", fds)
+	}
+}
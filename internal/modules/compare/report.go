@@ -0,0 +1,165 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+// ReportExtensions lists the file extensions WriteReport accepts.
+var ReportExtensions = []string{".html", ".json", ".md"}
+
+// FromDiffResult converts the changes an import or migration planned into a
+// CompareResult, so they can be rendered by the compare formatters. before
+// names the target organization as it was, and after the data applied to it:
+// created resources are reported as added, updated resources as modified,
+// with field diffs from the target's current version, and the diff's
+// deletions as removed. Skipped resources are left out, as are deletions of
+// resources the target does not have.
+func FromDiffResult(diff *import_module.DiffResult, before, after string) *CompareResult {
+	result := &CompareResult{
+		Source:    before,
+		Target:    after,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Identical: true,
+	}
+	if diff == nil {
+		return result
+	}
+	current := diff.Current
+	if current == nil {
+		current = &export.Data{}
+	}
+	removed := deletionKeys(diff.Deletions)
+
+	result.Blueprints = reportDiff(toMaps(diff.BlueprintsToCreate), toMaps(current.Blueprints), toMaps(diff.BlueprintsToUpdate), normalizerFor(diff, "blueprints"), removed["blueprints"], "identifier")
+	result.Entities = reportDiff(toMaps(diff.EntitiesToCreate), toMaps(current.Entities), toMaps(diff.EntitiesToUpdate), normalizerFor(diff, "entities"), removed["entities"], "blueprint", "identifier")
+	result.Scorecards = reportDiff(toMaps(diff.ScorecardsToCreate), toMaps(current.Scorecards), toMaps(diff.ScorecardsToUpdate), normalizerFor(diff, "scorecards"), removed["scorecards"], "blueprintIdentifier", "identifier")
+	result.Actions = reportDiff(toMaps(diff.ActionsToCreate), toMaps(current.Actions), toMaps(diff.ActionsToUpdate), normalizerFor(diff, "actions"), removed["actions"], "identifier")
+	result.Teams = reportDiff(toMaps(diff.TeamsToCreate), toMaps(current.Teams), toMaps(diff.TeamsToUpdate), normalizerFor(diff, "teams"), removed["teams"], "name")
+	result.Users = reportDiff(toMaps(diff.UsersToCreate), toMaps(current.Users), toMaps(diff.UsersToUpdate), normalizerFor(diff, "users"), nil, "email")
+	result.Pages = reportDiff(toMaps(diff.PagesToCreate), toMaps(current.Pages), toMaps(diff.PagesToUpdate), normalizerFor(diff, "pages"), removed["pages"], "identifier")
+	result.Integrations = reportDiff(toMaps(diff.IntegrationsToCreate), toMaps(current.Integrations), toMaps(diff.IntegrationsToUpdate), normalizerFor(diff, "integrations"), removed["integrations"], "identifier")
+	result.BlueprintPermissions = reportPermissionsDiff(current.BlueprintPermissions, diff.BlueprintPermissions)
+	result.ActionPermissions = reportPermissionsDiff(current.ActionPermissions, diff.ActionPermissions)
+
+	for _, rd := range []ResourceDiff{
+		result.Blueprints, result.Entities, result.Scorecards, result.Actions, result.Teams,
+		result.Users, result.Pages, result.Integrations, result.BlueprintPermissions, result.ActionPermissions,
+	} {
+		if rd.Summary.Added > 0 || rd.Summary.Modified > 0 || rd.Summary.Removed > 0 {
+			result.Identical = false
+			break
+		}
+	}
+	return result
+}
+
+// deletionKeys groups deletions by export section, keyed like previewKey:
+// entities and scorecards as "blueprint/identifier", the rest by identifier.
+func deletionKeys(deletions []export.Deletion) map[string][]string {
+	keys := make(map[string][]string)
+	for _, del := range deletions {
+		key := del.Identifier
+		if (del.Type == "entities" || del.Type == "scorecards") && del.Blueprint != "" {
+			key = del.Blueprint + "/" + del.Identifier
+		}
+		keys[del.Type] = append(keys[del.Type], key)
+	}
+	return keys
+}
+
+func reportDiff(creates, currentItems, updates []map[string]interface{}, normalize normalizer, removedKeys []string, keyFields ...string) ResourceDiff {
+	var rd ResourceDiff
+	for _, item := range creates {
+		if key, ok := previewKey(item, keyFields); ok {
+			rd.Added = append(rd.Added, ResourceChange{Identifier: key, TargetData: item})
+		}
+	}
+	rd.Modified = previewChanges(currentItems, updates, normalize, keyFields)
+	if len(removedKeys) > 0 {
+		currentByKey := make(map[string]map[string]interface{}, len(currentItems))
+		for _, item := range currentItems {
+			if key, ok := previewKey(item, keyFields); ok {
+				currentByKey[key] = item
+			}
+		}
+		for _, key := range removedKeys {
+			if item, ok := currentByKey[key]; ok {
+				rd.Removed = append(rd.Removed, ResourceChange{Identifier: key, SourceData: item})
+			}
+		}
+	}
+	rd.Summary = DiffSummary{Added: len(rd.Added), Modified: len(rd.Modified), Removed: len(rd.Removed)}
+	return rd
+}
+
+func reportPermissionsDiff(current map[string]api.Permissions, changes []import_module.PermissionsChange) ResourceDiff {
+	currentItems := make([]map[string]interface{}, 0, len(current))
+	for id, perms := range current {
+		currentItems = append(currentItems, withIdentifier(perms, id))
+	}
+	updates := make([]map[string]interface{}, 0, len(changes))
+	for _, change := range changes {
+		updates = append(updates, withIdentifier(change.Permissions, change.Identifier))
+	}
+	rd := ResourceDiff{Modified: previewChanges(currentItems, updates, nil, []string{"identifier"})}
+	rd.Summary = DiffSummary{Modified: len(rd.Modified)}
+	return rd
+}
+
+func withIdentifier(perms api.Permissions, id string) map[string]interface{} {
+	entry := make(map[string]interface{}, len(perms)+1)
+	for k, v := range perms {
+		entry[k] = v
+	}
+	entry["identifier"] = id
+	return entry
+}
+
+// WriteReport renders result to path, choosing the formatter from the file
+// extension: .html, .json or .md.
+func WriteReport(path string, result *CompareResult) error {
+	if err := ValidateReportPath(path); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	if err := formatReport(file, path, result); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ValidateReportPath returns an error if WriteReport has no formatter for
+// path's extension.
+func ValidateReportPath(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, allowed := range ReportExtensions {
+		if ext == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported report file extension %q (expected %s)", ext, strings.Join(ReportExtensions, ", "))
+}
+
+func formatReport(w io.Writer, path string, result *CompareResult) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return NewJSONFormatter(w).Format(result)
+	case ".md":
+		return NewMarkdownFormatter(w).Format(result)
+	default:
+		return NewHTMLFormatter(w, false).Format(result)
+	}
+}
@@ -0,0 +1,210 @@
+package compare
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/modules/import_module"
+)
+
+func TestFromDiffResult(t *testing.T) {
+	diff := &import_module.DiffResult{
+		Current: &export.Data{
+			Blueprints: []api.Blueprint{{"identifier": "service", "title": "Service"}},
+			BlueprintPermissions: map[string]api.Permissions{
+				"service": {"entities": map[string]interface{}{"register": map[string]interface{}{"roles": []interface{}{"Admin"}}}},
+			},
+		},
+		BlueprintsToCreate: []api.Blueprint{{"identifier": "team-bp", "title": "Team"}},
+		BlueprintsToUpdate: []api.Blueprint{{"identifier": "service", "title": "Microservice"}},
+		EntitiesToCreate:   []api.Entity{{"blueprint": "service", "identifier": "api"}},
+		BlueprintPermissions: []import_module.PermissionsChange{
+			{Identifier: "service", Permissions: api.Permissions{"entities": map[string]interface{}{"register": map[string]interface{}{"roles": []interface{}{"Member"}}}}},
+		},
+	}
+
+	result := FromDiffResult(diff, "production", "export.json")
+	if result.Source != "production" || result.Target != "export.json" || result.Identical {
+		t.Fatalf("unexpected result header %+v", result)
+	}
+	if result.Blueprints.Summary != (DiffSummary{Added: 1, Modified: 1}) {
+		t.Fatalf("unexpected blueprint summary %+v", result.Blueprints.Summary)
+	}
+	modified := result.Blueprints.Modified[0]
+	if modified.Identifier != "service" || len(modified.FieldDiffs) != 1 || modified.FieldDiffs[0].SourceValue != "Service" {
+		t.Fatalf("unexpected blueprint change %+v", modified)
+	}
+	if len(result.Entities.Added) != 1 || result.Entities.Added[0].Identifier != "service/api" {
+		t.Fatalf("unexpected entity changes %+v", result.Entities.Added)
+	}
+	if result.BlueprintPermissions.Summary.Modified != 1 {
+		t.Fatalf("expected a permissions change, got %+v", result.BlueprintPermissions)
+	}
+}
+
+// changeIDs returns the identifiers of changes.
+func changeIDs(changes []ResourceChange) []string {
+	var ids []string
+	for _, c := range changes {
+		ids = append(ids, c.Identifier)
+	}
+	return ids
+}
+
+func TestFromDiffResult_EachResourceType(t *testing.T) {
+	diff := &import_module.DiffResult{
+		Current: &export.Data{
+			Blueprints:   []api.Blueprint{{"identifier": "service", "title": "Service"}, {"identifier": "legacy"}, {"identifier": "same"}},
+			Entities:     []api.Entity{{"blueprint": "service", "identifier": "checkout", "title": "Checkout"}, {"blueprint": "service", "identifier": "old"}},
+			Scorecards:   []api.Scorecard{{"blueprintIdentifier": "service", "identifier": "health", "title": "Health"}, {"blueprintIdentifier": "service", "identifier": "dora"}},
+			Actions:      []api.Action{{"identifier": "deploy", "title": "Deploy"}, {"identifier": "rollback"}},
+			Teams:        []api.Team{{"name": "eng", "description": "Eng"}, {"name": "ops"}},
+			Users:        []api.User{{"email": "a@example.com", "firstName": "A"}},
+			Pages:        []api.Page{{"identifier": "home", "title": "Home"}, {"identifier": "old-page"}},
+			Integrations: []api.Integration{{"identifier": "gh", "title": "GitHub"}, {"identifier": "jira"}},
+			BlueprintPermissions: map[string]api.Permissions{
+				"service": {"entities": map[string]interface{}{"register": map[string]interface{}{"roles": []interface{}{"Admin"}}}},
+			},
+			ActionPermissions: map[string]api.Permissions{
+				"deploy": {"execute": map[string]interface{}{"roles": []interface{}{"Admin"}}},
+			},
+		},
+		BlueprintsToCreate:   []api.Blueprint{{"identifier": "new-bp"}},
+		BlueprintsToUpdate:   []api.Blueprint{{"identifier": "service", "title": "Microservice"}},
+		BlueprintsToSkip:     []api.Blueprint{{"identifier": "same"}},
+		EntitiesToCreate:     []api.Entity{{"blueprint": "service", "identifier": "billing"}},
+		EntitiesToUpdate:     []api.Entity{{"blueprint": "service", "identifier": "checkout", "title": "Checkout v2"}},
+		ScorecardsToCreate:   []api.Scorecard{{"blueprintIdentifier": "service", "identifier": "security"}},
+		ScorecardsToUpdate:   []api.Scorecard{{"blueprintIdentifier": "service", "identifier": "health", "title": "Health v2"}},
+		ActionsToCreate:      []api.Action{{"identifier": "scale"}},
+		ActionsToUpdate:      []api.Action{{"identifier": "deploy", "title": "Deploy now"}},
+		TeamsToCreate:        []api.Team{{"name": "sre"}},
+		TeamsToUpdate:        []api.Team{{"name": "eng", "description": "Engineering"}},
+		UsersToCreate:        []api.User{{"email": "b@example.com"}},
+		UsersToUpdate:        []api.User{{"email": "a@example.com", "firstName": "Ada"}},
+		PagesToCreate:        []api.Page{{"identifier": "catalog"}},
+		PagesToUpdate:        []api.Page{{"identifier": "home", "title": "Start"}},
+		IntegrationsToCreate: []api.Integration{{"identifier": "pd"}},
+		IntegrationsToUpdate: []api.Integration{{"identifier": "gh", "title": "GitHub App"}},
+		BlueprintPermissions: []import_module.PermissionsChange{{
+			Identifier:  "service",
+			Permissions: api.Permissions{"entities": map[string]interface{}{"register": map[string]interface{}{"roles": []interface{}{"Admin", "Member"}}}},
+		}},
+		ActionPermissions: []import_module.PermissionsChange{{
+			Identifier:  "deploy",
+			Permissions: api.Permissions{"execute": map[string]interface{}{"roles": []interface{}{"Admin"}}},
+		}},
+	}
+	deletions := []export.Deletion{
+		{Type: "blueprints", Identifier: "legacy"},
+		{Type: "entities", Blueprint: "service", Identifier: "old"},
+		{Type: "scorecards", Blueprint: "service", Identifier: "dora"},
+		{Type: "actions", Blueprint: "service", Identifier: "rollback"},
+		{Type: "teams", Identifier: "ops"},
+		{Type: "pages", Identifier: "old-page"},
+		{Type: "integrations", Identifier: "jira"},
+		{Type: "blueprints", Identifier: "never-existed"},
+	}
+
+	diff.Deletions = deletions
+
+	result := FromDiffResult(diff, "production", "export.json")
+
+	tests := []struct {
+		name     string
+		diff     ResourceDiff
+		added    []string
+		modified []string
+		removed  []string
+	}{
+		{"blueprints", result.Blueprints, []string{"new-bp"}, []string{"service"}, []string{"legacy"}},
+		{"entities", result.Entities, []string{"service/billing"}, []string{"service/checkout"}, []string{"service/old"}},
+		{"scorecards", result.Scorecards, []string{"service/security"}, []string{"service/health"}, []string{"service/dora"}},
+		{"actions", result.Actions, []string{"scale"}, []string{"deploy"}, []string{"rollback"}},
+		{"teams", result.Teams, []string{"sre"}, []string{"eng"}, []string{"ops"}},
+		{"users", result.Users, []string{"b@example.com"}, []string{"a@example.com"}, nil},
+		{"pages", result.Pages, []string{"catalog"}, []string{"home"}, []string{"old-page"}},
+		{"integrations", result.Integrations, []string{"pd"}, []string{"gh"}, []string{"jira"}},
+		{"blueprint permissions", result.BlueprintPermissions, nil, []string{"service"}, nil},
+		{"action permissions", result.ActionPermissions, nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changeIDs(tt.diff.Added); !reflect.DeepEqual(got, tt.added) {
+				t.Errorf("Added = %v, want %v", got, tt.added)
+			}
+			if got := changeIDs(tt.diff.Modified); !reflect.DeepEqual(got, tt.modified) {
+				t.Errorf("Modified = %v, want %v", got, tt.modified)
+			}
+			if got := changeIDs(tt.diff.Removed); !reflect.DeepEqual(got, tt.removed) {
+				t.Errorf("Removed = %v, want %v", got, tt.removed)
+			}
+			want := DiffSummary{Added: len(tt.added), Modified: len(tt.modified), Removed: len(tt.removed)}
+			if tt.diff.Summary != want {
+				t.Errorf("Summary = %+v, want %+v", tt.diff.Summary, want)
+			}
+		})
+	}
+
+	if result.Identical {
+		t.Error("expected a result with changes not to be identical")
+	}
+	fds := result.Blueprints.Modified[0].FieldDiffs
+	if len(fds) != 1 || fds[0].Path != "title" || fds[0].SourceValue != "Service" || fds[0].TargetValue != "Microservice" {
+		t.Errorf("blueprint field diffs = %+v, want the title going from Service to Microservice", fds)
+	}
+	if result.Entities.Removed[0].SourceData["identifier"] != "old" {
+		t.Errorf("removed entity data = %v, want the current entity", result.Entities.Removed[0].SourceData)
+	}
+}
+
+func TestFromDiffResult_NoChangesIsIdentical(t *testing.T) {
+	if result := FromDiffResult(&import_module.DiffResult{}, "a", "b"); !result.Identical {
+		t.Fatalf("expected identical result, got %+v", result)
+	}
+}
+
+func TestWriteReport_DispatchesOnExtension(t *testing.T) {
+	result := FromDiffResult(&import_module.DiffResult{
+		BlueprintsToCreate: []api.Blueprint{{"identifier": "service"}},
+	}, "production", "staging")
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := WriteReport(jsonPath, result); err != nil {
+		t.Fatalf("WriteReport json: %v", err)
+	}
+	raw, _ := os.ReadFile(jsonPath)
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		t.Fatalf("json report is not valid JSON: %v", err)
+	}
+
+	mdPath := filepath.Join(dir, "report.md")
+	if err := WriteReport(mdPath, result); err != nil {
+		t.Fatalf("WriteReport md: %v", err)
+	}
+	raw, _ = os.ReadFile(mdPath)
+	if !strings.Contains(string(raw), "**Added** `service`") {
+		t.Fatalf("unexpected markdown report:\n%s", raw)
+	}
+
+	htmlPath := filepath.Join(dir, "report.HTML")
+	if err := WriteReport(htmlPath, result); err != nil {
+		t.Fatalf("WriteReport html: %v", err)
+	}
+	raw, _ = os.ReadFile(htmlPath)
+	if !strings.Contains(string(raw), "<html") {
+		t.Fatalf("expected an HTML document, got:\n%.200s", raw)
+	}
+
+	if err := WriteReport(filepath.Join(dir, "report.txt"), result); err == nil {
+		t.Fatal("expected an error for an unsupported extension")
+	}
+}
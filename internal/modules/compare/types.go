@@ -15,13 +15,15 @@ type Options struct {
 	SourceSecret     string   // Override source client secret
 	TargetClientID   string   // Override target client ID
 	TargetSecret     string   // Override target client secret
-	OutputFormat     string   // text, json, html
+	OutputFormat     string   // text, json, html, markdown
 	HTMLFile         string   // Output path for HTML report
 	HTMLSimple       bool     // Use simple HTML template
 	Verbose          bool     // Show identifiers
 	Full             bool     // Show full diff
 	IncludeResources []string // Filter resource types
 	FailOnDiff       bool     // Exit 1 if differences found
+	SortBy           string   // Order of modified resources in text and markdown output: name (default) or changes
+	MinChanges       int      // Hide modified resources with fewer changed fields in text and markdown output
 }
 
 // DiffSummary represents the summary of differences for a resource type.
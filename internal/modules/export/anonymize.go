@@ -0,0 +1,265 @@
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// secretKeyMarkers are lowercase substrings of field names whose values are
+// dropped from the parts of anonymized exports that hold credentials.
+var secretKeyMarkers = []string{"secret", "token", "password", "apikey", "api_key", "authorization", "credential", "privatekey", "private_key"}
+
+// entityOwnerFields are entity fields naming people or teams, dropped from
+// anonymized exports.
+var entityOwnerFields = []string{"team", "createdBy", "updatedBy"}
+
+// Anonymizer rewrites export data so it can be shared outside the
+// organization. Blueprint, scorecard and action structure is kept; entity
+// values are replaced by placeholders, teams and users are removed, and
+// secret-looking fields are dropped where resources hold credentials: action
+// webhook headers, integration configuration and data source security.
+//
+// Pseudonyms are deterministic for one Anonymizer: the same input always maps
+// to the same placeholder, so relations still point at the right entities.
+// They are keyed with a per-export salt, so placeholders cannot be reversed by
+// hashing guessed values.
+type Anonymizer struct {
+	salt []byte
+}
+
+// NewAnonymizer returns an Anonymizer keyed with salt.
+func NewAnonymizer(salt []byte) *Anonymizer {
+	return &Anonymizer{salt: salt}
+}
+
+// newRandomAnonymizer returns an Anonymizer keyed with a random salt.
+func newRandomAnonymizer() (*Anonymizer, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate anonymization salt: %w", err)
+	}
+	return NewAnonymizer(salt), nil
+}
+
+// Data anonymizes every resource in data in place.
+func (a *Anonymizer) Data(data *Data) {
+	for i, entity := range data.Entities {
+		data.Entities[i] = a.Entity(entity)
+	}
+	// Secrets are only stripped where they live. Elsewhere a secret-looking
+	// name is a schema identifier, such as a github_token_expiry property,
+	// that imports still need.
+	for _, action := range data.Actions {
+		if invocation, ok := action["invocationMethod"].(map[string]interface{}); ok {
+			stripSecretsIn(invocation, "headers")
+		}
+	}
+	for _, integration := range data.Integrations {
+		// The resources mapping names blueprint properties, not credentials.
+		stripSecretsIn(integration, "config", "resources")
+	}
+	for _, source := range data.DataSources {
+		stripSecretsIn(source, "security")
+	}
+	for _, perms := range []map[string]api.Permissions{data.BlueprintPermissions, data.ActionPermissions, data.PagePermissions} {
+		for _, p := range perms {
+			clearPrincipals(p)
+		}
+	}
+	data.Teams = []api.Team{}
+	data.Users = []api.User{}
+	data.Manifest.Anonymized = true
+}
+
+// Entity returns an anonymized copy of entity. The identifier, title,
+// properties and relations are pseudonymized and owners are dropped. Property
+// names are kept: they are the blueprint's schema.
+func (a *Anonymizer) Entity(entity api.Entity) api.Entity {
+	result := make(api.Entity, len(entity))
+	for k, v := range entity {
+		result[k] = v
+	}
+	for _, field := range entityOwnerFields {
+		delete(result, field)
+	}
+	if id, ok := entity["identifier"].(string); ok {
+		result["identifier"] = a.identifier(id)
+	}
+	if title, ok := entity["title"].(string); ok {
+		result["title"] = "title_" + a.hash(title)
+	}
+	if props, ok := entity["properties"].(map[string]interface{}); ok {
+		result["properties"] = a.object(props)
+	}
+	if relations, ok := entity["relations"].(map[string]interface{}); ok {
+		anonRelations := make(map[string]interface{}, len(relations))
+		for name, target := range relations {
+			anonRelations[name] = a.relationTarget(target)
+		}
+		result["relations"] = anonRelations
+	}
+	return result
+}
+
+// identifier returns the pseudonym for an entity identifier. Relation targets
+// use the same mapping so they keep resolving.
+func (a *Anonymizer) identifier(id string) string {
+	return "id_" + a.hash(id)
+}
+
+func (a *Anonymizer) relationTarget(target interface{}) interface{} {
+	switch v := target.(type) {
+	case string:
+		return a.identifier(v)
+	case []interface{}:
+		ids := make([]interface{}, len(v))
+		for i, item := range v {
+			ids[i] = a.relationTarget(item)
+		}
+		return ids
+	default:
+		return v
+	}
+}
+
+func (a *Anonymizer) object(obj map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		result[k] = a.value(v)
+	}
+	return result
+}
+
+// value replaces a property value with a placeholder of the same type.
+func (a *Anonymizer) value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return a.stringValue(val)
+	case float64:
+		return bucketNumber(val)
+	case []interface{}:
+		items := make([]interface{}, len(val))
+		for i, item := range val {
+			items[i] = a.value(item)
+		}
+		return items
+	case map[string]interface{}:
+		return a.object(val)
+	default:
+		// Booleans and nulls carry no data worth hiding.
+		return val
+	}
+}
+
+// stringValue keeps the shape of emails, URLs and timestamps so imported
+// placeholders still satisfy the blueprint's property formats.
+func (a *Anonymizer) stringValue(s string) string {
+	h := a.hash(s)
+	if addr, err := mail.ParseAddress(s); err == nil && addr.Address == s {
+		return "user_" + h + "@example.com"
+	}
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return "https://example.com/" + h
+	}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "2000-01-01T00:00:00Z"
+	}
+	return "str_" + h
+}
+
+func (a *Anonymizer) hash(s string) string {
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// bucketNumber rounds n down to its power of ten, keeping the sign, so values
+// keep their order of magnitude without revealing the exact figure.
+func bucketNumber(n float64) float64 {
+	if n == 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0
+	}
+	bucket := math.Pow(10, math.Floor(math.Log10(math.Abs(n))))
+	if n < 0 {
+		return -bucket
+	}
+	return bucket
+}
+
+func isSecretKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSecretsIn removes secret-looking fields from the object in obj[field]
+// and everything nested in it, leaving the keep fields of that object alone.
+func stripSecretsIn(obj map[string]interface{}, field string, keep ...string) {
+	nested, ok := obj[field].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k, v := range nested {
+		if slices.Contains(keep, k) {
+			continue
+		}
+		if isSecretKey(k) {
+			delete(nested, k)
+			continue
+		}
+		stripSecretsValue(v)
+	}
+}
+
+// stripSecrets removes secret-looking fields from obj and everything nested in
+// it.
+func stripSecrets(obj map[string]interface{}) {
+	for k, v := range obj {
+		if isSecretKey(k) {
+			delete(obj, k)
+			continue
+		}
+		stripSecretsValue(v)
+	}
+}
+
+func stripSecretsValue(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		stripSecrets(val)
+	case []interface{}:
+		for _, item := range val {
+			stripSecretsValue(item)
+		}
+	}
+}
+
+// clearPrincipals empties every users and teams list in a permissions object,
+// keeping roles and the rest of its structure.
+func clearPrincipals(obj map[string]interface{}) {
+	for k, v := range obj {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			clearPrincipals(val)
+		case []interface{}:
+			if k == "users" || k == "teams" {
+				obj[k] = []interface{}{}
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestAnonymizer_EntityIsDeterministicAndKeepsRelations(t *testing.T) {
+	anon := NewAnonymizer([]byte("salt"))
+	svc := anon.Entity(api.Entity{
+		"identifier": "payments",
+		"title":      "Payments Service",
+		"blueprint":  "service",
+		"team":       []interface{}{"platform"},
+		"createdBy":  "alice@acme.com",
+		"properties": map[string]interface{}{
+			"owner":    "alice@acme.com",
+			"repo":     "https://github.com/acme/payments",
+			"language": "go",
+			"replicas": float64(4321),
+			"public":   true,
+			"apiToken": "s3cr3t",
+		},
+	})
+	deploy := anon.Entity(api.Entity{
+		"identifier": "deploy-1",
+		"blueprint":  "deployment",
+		"relations": map[string]interface{}{
+			"service":  "payments",
+			"services": []interface{}{"payments", "billing"},
+		},
+	})
+
+	if svc["identifier"] == "payments" || svc["title"] == "Payments Service" {
+		t.Fatalf("expected identifier and title to be replaced, got %v", svc)
+	}
+	if svc["blueprint"] != "service" {
+		t.Fatalf("expected blueprint to be kept, got %v", svc["blueprint"])
+	}
+	for _, field := range []string{"team", "createdBy"} {
+		if _, ok := svc[field]; ok {
+			t.Fatalf("expected %s to be dropped, got %v", field, svc[field])
+		}
+	}
+	relations := deploy["relations"].(map[string]interface{})
+	if relations["service"] != svc["identifier"] {
+		t.Fatalf("relation %v does not match pseudonymized identifier %v", relations["service"], svc["identifier"])
+	}
+	if many := relations["services"].([]interface{}); many[0] != svc["identifier"] || many[1] == svc["identifier"] {
+		t.Fatalf("unexpected many-relation targets %v", many)
+	}
+
+	props := svc["properties"].(map[string]interface{})
+	// Property names are the blueprint schema: kept, with their values replaced.
+	if token, ok := props["apiToken"].(string); !ok || !strings.HasPrefix(token, "str_") {
+		t.Fatalf("expected secret-looking property to be kept with a placeholder, got %v", props)
+	}
+	if owner := props["owner"].(string); !strings.HasSuffix(owner, "@example.com") || strings.Contains(owner, "alice") {
+		t.Fatalf("expected email placeholder, got %q", owner)
+	}
+	if repo := props["repo"].(string); !strings.HasPrefix(repo, "https://example.com/") {
+		t.Fatalf("expected URL placeholder, got %q", repo)
+	}
+	if lang := props["language"].(string); !strings.HasPrefix(lang, "str_") {
+		t.Fatalf("expected string placeholder, got %q", lang)
+	}
+	if props["replicas"] != float64(1000) || props["public"] != true {
+		t.Fatalf("unexpected number/bool placeholders: %v", props)
+	}
+
+	again := NewAnonymizer([]byte("salt")).Entity(api.Entity{"identifier": "payments"})
+	if again["identifier"] != svc["identifier"] {
+		t.Fatalf("expected the same salt to give the same pseudonym")
+	}
+	other := NewAnonymizer([]byte("other")).Entity(api.Entity{"identifier": "payments"})
+	if other["identifier"] == svc["identifier"] {
+		t.Fatalf("expected a different salt to give a different pseudonym")
+	}
+}
+
+func TestBucketNumber(t *testing.T) {
+	tests := map[float64]float64{
+		0:     0,
+		7:     1,
+		42:    10,
+		999:   100,
+		-4321: -1000,
+		0.05:  0.01,
+	}
+	for in, want := range tests {
+		if got := bucketNumber(in); got != want {
+			t.Errorf("bucketNumber(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestAnonymizer_DataDropsPrincipalsAndSecrets(t *testing.T) {
+	data := &Data{
+		Blueprints: []api.Blueprint{{"identifier": "service"}},
+		Actions: []api.Action{{
+			"identifier": "deploy",
+			"invocationMethod": map[string]interface{}{
+				"type":    "WEBHOOK",
+				"headers": map[string]interface{}{"Authorization": "Bearer abc", "X-Env": "prod"},
+			},
+		}},
+		Teams: []api.Team{{"name": "platform"}},
+		Users: []api.User{{"email": "alice@acme.com"}},
+		BlueprintPermissions: map[string]api.Permissions{
+			"service": {"entities": map[string]interface{}{
+				"register": map[string]interface{}{"roles": []interface{}{"Admin"}, "users": []interface{}{"alice@acme.com"}, "teams": []interface{}{"platform"}},
+			}},
+		},
+	}
+
+	NewAnonymizer([]byte("salt")).Data(data)
+
+	if !data.Manifest.Anonymized {
+		t.Fatal("expected manifest to be marked anonymized")
+	}
+	if len(data.Teams) != 0 || len(data.Users) != 0 {
+		t.Fatalf("expected teams and users to be removed, got %v %v", data.Teams, data.Users)
+	}
+	headers := data.Actions[0]["invocationMethod"].(map[string]interface{})["headers"].(map[string]interface{})
+	if _, ok := headers["Authorization"]; ok || headers["X-Env"] != "prod" {
+		t.Fatalf("expected only the secret header to be dropped, got %v", headers)
+	}
+	register := data.BlueprintPermissions["service"]["entities"].(map[string]interface{})["register"].(map[string]interface{})
+	if len(register["users"].([]interface{})) != 0 || len(register["teams"].([]interface{})) != 0 || len(register["roles"].([]interface{})) != 1 {
+		t.Fatalf("expected users and teams cleared and roles kept, got %v", register)
+	}
+}
+
+func TestAnonymizer_DataKeepsSecretLookingSchemaKeys(t *testing.T) {
+	blueprint := api.Blueprint{
+		"identifier": "repository",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"github_token_expiry": map[string]interface{}{"type": "string", "format": "date-time"},
+				"passwordPolicy":      map[string]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"github_token_expiry"},
+		},
+	}
+	data := &Data{
+		Blueprints: []api.Blueprint{blueprint},
+		Integrations: []api.Integration{{
+			"installationId": "github",
+			"config": map[string]interface{}{
+				"appSecret": "s3cr3t",
+				"resources": []interface{}{map[string]interface{}{
+					"kind": "repository",
+					"port": map[string]interface{}{"entity": map[string]interface{}{"mappings": map[string]interface{}{
+						"properties": map[string]interface{}{"github_token_expiry": ".token_expires_at"},
+					}}},
+				}},
+			},
+		}},
+		DataSources: []api.Webhook{{
+			"identifier": "incoming",
+			"security":   map[string]interface{}{"secret": "s3cr3t", "signatureHeaderName": "X-Signature"},
+		}},
+	}
+
+	NewAnonymizer([]byte("salt")).Data(data)
+
+	schema := data.Blueprints[0]["schema"].(map[string]interface{})
+	props := schema["properties"].(map[string]interface{})
+	if _, ok := props["github_token_expiry"]; !ok {
+		t.Fatalf("expected the github_token_expiry property to be kept, got %v", props)
+	}
+	if _, ok := props["passwordPolicy"]; !ok {
+		t.Fatalf("expected the passwordPolicy property to be kept, got %v", props)
+	}
+	if required := schema["required"].([]interface{}); len(required) != 1 || required[0] != "github_token_expiry" {
+		t.Fatalf("expected required to be kept, got %v", required)
+	}
+
+	config := data.Integrations[0]["config"].(map[string]interface{})
+	if _, ok := config["appSecret"]; ok {
+		t.Fatalf("expected the integration secret to be dropped, got %v", config)
+	}
+	mapping := config["resources"].([]interface{})[0].(map[string]interface{})["port"].(map[string]interface{})["entity"].(map[string]interface{})["mappings"].(map[string]interface{})["properties"].(map[string]interface{})
+	if mapping["github_token_expiry"] != ".token_expires_at" {
+		t.Fatalf("expected the integration mapping to be kept, got %v", mapping)
+	}
+
+	security := data.DataSources[0]["security"].(map[string]interface{})
+	if _, ok := security["secret"]; ok || security["signatureHeaderName"] != "X-Signature" {
+		t.Fatalf("expected only the data source secret to be dropped, got %v", security)
+	}
+}
+
+func TestExecute_AnonymizeWritesManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		case "/blueprints/service/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 1})
+		case "/blueprints/service/entities":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"entities": []map[string]interface{}{{"identifier": "svc-1", "blueprint": "service", "title": "Secret Project"}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	outputPath := filepath.Join(t.TempDir(), "export.json")
+	result, err := module.Execute(context.Background(), Options{
+		OutputPath:       outputPath,
+		Format:           "json",
+		IncludeResources: []string{"blueprints", "entities"},
+		Anonymize:        true,
+	})
+	if err != nil || !result.Success {
+		t.Fatalf("export failed: err=%v result=%+v", err, result)
+	}
+	if !result.Anonymized {
+		t.Fatal("expected result to report an anonymized export")
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if strings.Contains(string(content), "svc-1") || strings.Contains(string(content), "Secret Project") {
+		t.Fatalf("expected entity data to be anonymized, got %s", content)
+	}
+	var parsed struct {
+		Manifest Manifest `json:"_manifest"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("output JSON was invalid: %v", err)
+	}
+	if !parsed.Manifest.Anonymized {
+		t.Fatalf("expected anonymized manifest, got %s", content)
+	}
+}
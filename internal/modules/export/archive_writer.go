@@ -2,12 +2,14 @@ package export
 
 import (
 	"archive/tar"
+	"bufio"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/port-experimental/port-cli/internal/api"
 )
@@ -237,9 +239,112 @@ func (w *tarArchiveWriter) Close() error {
 	return err
 }
 
+// NDJSON record fields. Every line of an ndjson export is one resource tagged
+// with its resource type; permission entries also carry the identifier of the
+// resource they belong to.
+const (
+	NDJSONTypeField = "_type"
+	NDJSONKeyField  = "_key"
+)
+
+type ndjsonArchiveWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	encoder *json.Encoder
+	closed  bool
+}
+
+func newNDJSONArchiveWriter(outputPath string) (*ndjsonArchiveWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	buf := bufio.NewWriter(file)
+	return &ndjsonArchiveWriter{file: file, buf: buf, encoder: json.NewEncoder(buf)}, nil
+}
+
+// WriteResource writes each element of a resource slice, or each entry of a
+// permissions map, as its own line. Any other object, such as the manifest, is
+// written as a single line.
+func (w *ndjsonArchiveWriter) WriteResource(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(raw, &items); err == nil {
+		for _, item := range items {
+			if err := w.writeRecord(name, "", item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var keyed map[string]map[string]interface{}
+	if err := json.Unmarshal(raw, &keyed); err != nil {
+		var single map[string]interface{}
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return fmt.Errorf("resource %s cannot be written as ndjson", name)
+		}
+		return w.writeRecord(name, "", single)
+	}
+	keys := make([]string, 0, len(keyed))
+	for key := range keyed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := w.writeRecord(name, key, keyed[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ndjsonArchiveWriter) WriteEntities(write func(EntitySink) error) error {
+	return write(w)
+}
+
+// WriteEntity implements EntitySink.
+func (w *ndjsonArchiveWriter) WriteEntity(entity api.Entity) error {
+	return w.writeRecord("entities", "", entity)
+}
+
+func (w *ndjsonArchiveWriter) writeRecord(resourceType, key string, item map[string]interface{}) error {
+	record := make(map[string]interface{}, len(item)+2)
+	for k, v := range item {
+		record[k] = v
+	}
+	record[NDJSONTypeField] = resourceType
+	if key != "" {
+		record[NDJSONKeyField] = key
+	}
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonArchiveWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err := w.buf.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
 func newArchiveWriter(formatType, outputPath string) (ArchiveWriter, error) {
-	if formatType == "tar" {
+	switch formatType {
+	case "tar":
 		return newTarArchiveWriter(outputPath)
+	case "ndjson":
+		return newNDJSONArchiveWriter(outputPath)
 	}
 	return newJSONArchiveWriter(outputPath)
 }
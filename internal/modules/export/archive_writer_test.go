@@ -78,6 +78,40 @@ func TestArchiveWriter_StreamsEntitiesToJSONAndTar(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "ndjson",
+			format: "ndjson",
+			file:   "export.ndjson",
+			read: func(t *testing.T, path string) []api.Entity {
+				t.Helper()
+				file, err := os.Open(path)
+				if err != nil {
+					t.Fatalf("open ndjson export: %v", err)
+				}
+				defer file.Close()
+				var entities []api.Entity
+				blueprints := 0
+				dec := json.NewDecoder(file)
+				for dec.More() {
+					var record map[string]interface{}
+					if err := dec.Decode(&record); err != nil {
+						t.Fatalf("decode ndjson record: %v", err)
+					}
+					switch record[NDJSONTypeField] {
+					case "blueprints":
+						blueprints++
+					case "entities":
+						entities = append(entities, api.Entity(record))
+					default:
+						t.Fatalf("unexpected record type: %v", record[NDJSONTypeField])
+					}
+				}
+				if blueprints != 1 {
+					t.Fatalf("expected 1 blueprint, got %d", blueprints)
+				}
+				return entities
+			},
+		},
 	}
 
 	for _, tt := range tests {
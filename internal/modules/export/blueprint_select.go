@@ -0,0 +1,164 @@
+package export
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// ValidateBlueprintPatterns returns an error for the first malformed
+// identifier glob in patterns.
+func ValidateBlueprintPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid blueprint pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// SelectBlueprints returns the blueprints whose identifier is listed in ids or
+// matches one of patterns (path.Match syntax, e.g. "team-*"), in their
+// original order. All blueprints are returned when ids and patterns are both
+// empty. Malformed patterns match nothing; check them with
+// ValidateBlueprintPatterns first.
+func SelectBlueprints(all []api.Blueprint, ids, patterns []string) []api.Blueprint {
+	if len(ids) == 0 && len(patterns) == 0 {
+		return all
+	}
+	idSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		idSet[id] = true
+	}
+	var out []api.Blueprint
+	for _, bp := range all {
+		id, _ := bp["identifier"].(string)
+		if idSet[id] || matchesAny(id, patterns) {
+			out = append(out, bp)
+		}
+	}
+	return out
+}
+
+// SkipsEntitiesOf reports whether blueprintID matches one of the
+// --skip-entities-for identifiers or globs, whose entities are left out while
+// the blueprint itself is kept.
+func SkipsEntitiesOf(blueprintID string, skipEntitiesFor []string) bool {
+	return matchesAny(blueprintID, skipEntitiesFor)
+}
+
+func matchesAny(identifier string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, identifier); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DependencyMode controls which relation targets ResolveBlueprintDependencies
+// brings along with the selected blueprints.
+type DependencyMode string
+
+const (
+	// DepsRequired follows only required relations, so a selection stays
+	// small while every blueprint it must reference is included.
+	DepsRequired DependencyMode = "required"
+	// DepsAll follows every relation, required or not.
+	DepsAll DependencyMode = "all"
+	// DepsNone keeps the selection as it is.
+	DepsNone DependencyMode = "none"
+)
+
+// ParseDependencyMode parses a --deps value. "" is DepsRequired.
+func ParseDependencyMode(s string) (DependencyMode, error) {
+	switch mode := DependencyMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return DepsRequired, nil
+	case DepsRequired, DepsAll, DepsNone:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid dependency mode %q: expected required, all or none", s)
+}
+
+// ResolveBlueprintDependencies returns selectedBlueprints plus the blueprints
+// in allBlueprints their relations target, transitively. mode picks the
+// relations followed; "" follows only required ones.
+func ResolveBlueprintDependencies(allBlueprints, selectedBlueprints []api.Blueprint, mode DependencyMode) []api.Blueprint {
+	if mode == DepsNone {
+		return selectedBlueprints
+	}
+	selectedIDs := make(map[string]bool)
+	allBlueprintsMap := make(map[string]api.Blueprint)
+
+	for _, bp := range allBlueprints {
+		if identifier, ok := bp["identifier"].(string); ok {
+			allBlueprintsMap[identifier] = bp
+		}
+	}
+
+	for _, bp := range selectedBlueprints {
+		if identifier, ok := bp["identifier"].(string); ok {
+			selectedIDs[identifier] = true
+		}
+	}
+
+	result := make([]api.Blueprint, len(selectedBlueprints))
+	copy(result, selectedBlueprints)
+
+	toCheck := make([]string, 0, len(selectedIDs))
+	for id := range selectedIDs {
+		toCheck = append(toCheck, id)
+	}
+
+	checked := make(map[string]bool)
+
+	for len(toCheck) > 0 {
+		blueprintID := toCheck[len(toCheck)-1]
+		toCheck = toCheck[:len(toCheck)-1]
+
+		if checked[blueprintID] {
+			continue
+		}
+		checked[blueprintID] = true
+
+		blueprint, ok := allBlueprintsMap[blueprintID]
+		if !ok {
+			continue
+		}
+
+		// Check relations
+		relations, ok := blueprint["relations"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, relation := range relations {
+			relationMap, ok := relation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			target, ok := relationMap["target"].(string)
+			if !ok || target == "" {
+				continue
+			}
+			if required, _ := relationMap["required"].(bool); !required && mode != DepsAll {
+				continue
+			}
+
+			if !selectedIDs[target] {
+				// Add dependency
+				if depBlueprint, exists := allBlueprintsMap[target]; exists {
+					result = append(result, depBlueprint)
+					selectedIDs[target] = true
+					toCheck = append(toCheck, target)
+				}
+			}
+		}
+	}
+
+	return result
+}
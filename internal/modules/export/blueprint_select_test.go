@@ -0,0 +1,125 @@
+package export
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func blueprintIDs(blueprints []api.Blueprint) []string {
+	ids := make([]string, 0, len(blueprints))
+	for _, bp := range blueprints {
+		id, _ := bp["identifier"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestSelectBlueprints(t *testing.T) {
+	all := []api.Blueprint{
+		{"identifier": "team-a"},
+		{"identifier": "service"},
+		{"identifier": "team-b"},
+		{"identifier": "domain"},
+	}
+
+	tests := []struct {
+		name     string
+		ids      []string
+		patterns []string
+		want     []string
+	}{
+		{name: "no filter", want: []string{"team-a", "service", "team-b", "domain"}},
+		{name: "exact ids", ids: []string{"service"}, want: []string{"service"}},
+		{name: "glob", patterns: []string{"team-*"}, want: []string{"team-a", "team-b"}},
+		{name: "patterns and ids union", ids: []string{"domain"}, patterns: []string{"team-?", "serv*"}, want: []string{"team-a", "service", "team-b", "domain"}},
+		{name: "no match", patterns: []string{"nothing-*"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blueprintIDs(SelectBlueprints(all, tt.ids, tt.patterns))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBlueprintPatterns(t *testing.T) {
+	if err := ValidateBlueprintPatterns([]string{"team-*", "svc-[ab]"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateBlueprintPatterns([]string{"team-["}); err == nil {
+		t.Fatal("expected an error for a malformed pattern")
+	}
+}
+
+func TestOptionsSelectBlueprints_PatternsBringRelationTargets(t *testing.T) {
+	all := []api.Blueprint{
+		{"identifier": "team-a", "relations": map[string]interface{}{"domain": map[string]interface{}{"target": "domain", "required": true}}},
+		{"identifier": "domain", "relations": map[string]interface{}{"org": map[string]interface{}{"target": "org", "required": true}}},
+		{"identifier": "org"},
+		{"identifier": "service"},
+	}
+	opts := Options{BlueprintPatterns: []string{"team-*"}}
+	got := blueprintIDs(opts.selectBlueprints(all))
+	if len(got) != 3 {
+		t.Fatalf("expected team-a and its transitive relation targets, got %v", got)
+	}
+	for _, id := range got {
+		if id == "service" {
+			t.Fatalf("unrelated blueprint selected: %v", got)
+		}
+	}
+}
+
+func TestResolveBlueprintDependencies_Modes(t *testing.T) {
+	all := []api.Blueprint{
+		{"identifier": "service", "relations": map[string]interface{}{
+			"domain": map[string]interface{}{"target": "domain", "required": true},
+			"owner":  map[string]interface{}{"target": "person"},
+		}},
+		{"identifier": "domain", "relations": map[string]interface{}{"org": map[string]interface{}{"target": "org"}}},
+		{"identifier": "person"},
+		{"identifier": "org"},
+	}
+	selected := all[:1]
+
+	tests := []struct {
+		mode DependencyMode
+		want []string
+	}{
+		{"", []string{"service", "domain"}},
+		{DepsRequired, []string{"service", "domain"}},
+		{DepsAll, []string{"service", "domain", "person", "org"}},
+		{DepsNone, []string{"service"}},
+	}
+	for _, tt := range tests {
+		got := blueprintIDs(ResolveBlueprintDependencies(all, selected, tt.mode))
+		sort.Strings(got)
+		want := append([]string(nil), tt.want...)
+		sort.Strings(want)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("mode %q: got %v, want %v", tt.mode, got, want)
+		}
+	}
+}
+
+func TestParseDependencyMode(t *testing.T) {
+	for in, want := range map[string]DependencyMode{"": DepsRequired, "required": DepsRequired, "ALL": DepsAll, " none ": DepsNone} {
+		got, err := ParseDependencyMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDependencyMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseDependencyMode("optional"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -2,28 +2,32 @@ package export
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
 	"github.com/port-experimental/port-cli/internal/api"
 	systemblueprints "github.com/port-experimental/port-cli/internal/modules/system_blueprints"
+	"github.com/port-experimental/port-cli/internal/useragent"
 	"golang.org/x/sync/errgroup"
-	"golang.org/x/sync/semaphore"
 )
 
 // Options represents export options.
 type Options struct {
 	OutputPath                    string
 	Blueprints                    []string
+	BlueprintPatterns             []string // identifier globs (path.Match syntax); matches bring their relation targets along
 	Format                        string
 	SkipEntities                  bool
 	SkipSystemBlueprints          bool // skip _* blueprint schemas and their entities
 	SkipSystemBlueprintProperties bool
 	IncludeRuleResults            bool // include the _rule_result system blueprint and its entities (excluded by default)
 	IncludeResources              []string
-	ExcludeBlueprints             []string // deep: exclude blueprint schema + all its resources
-	ExcludeBlueprintSchema        []string // shallow: exclude only the blueprint schema, keep resources
+	IncludePatterns               map[string][]string // resource type -> identifier globs from --include "type:glob"
+	ExcludeBlueprints             []string            // deep: exclude blueprint schema + all its resources
+	ExcludeBlueprintSchema        []string            // shallow: exclude only the blueprint schema, keep resources
 
 	// AutoScopeBlueprints, when true, causes Collect to record which blueprints
 	// produced at least one matching entity/scorecard/action into
@@ -34,6 +38,14 @@ type Options struct {
 	// Collect when SkipEntities is forced true).
 	AutoScopeBlueprints bool
 
+	// Dependencies picks the relation targets BlueprintPatterns matches bring
+	// along; "" follows only required relations.
+	Dependencies DependencyMode
+
+	// SkipEntitiesFor lists blueprint identifiers or globs whose entities are
+	// not exported. Their schemas, scorecards and actions still are.
+	SkipEntitiesFor []string
+
 	// Per-resource ID filters (client-side, applied after bulk fetch)
 	Entities     []string
 	Scorecards   []string
@@ -42,6 +54,32 @@ type Options struct {
 	Integrations []string
 	Teams        []string
 	Users        []string
+
+	// EntityFilters maps a blueprint identifier to a Port search query; only
+	// matching entities of that blueprint are exported (server-side).
+	EntityFilters map[string]map[string]interface{}
+
+	// Anonymize replaces entity data with placeholders and drops teams, users
+	// and secrets so the export can be shared publicly (see Anonymizer).
+	Anonymize bool
+
+	// Sample caps the entities exported per blueprint, to build small test
+	// fixtures from a real organization. 0 exports every entity.
+	Sample int
+
+	// DryRun reports what the export would write without writing it. Entities
+	// are counted through the count endpoint rather than fetched.
+	DryRun bool
+
+	// Resume reuses the entities of the blueprints an interrupted export to
+	// the same OutputPath fetched in full (see ExportStateSuffix), and fetches
+	// only the rest.
+	Resume bool
+
+	// Sort orders every resource list by identifier, and entities by
+	// blueprint and identifier, for exports that diff cleanly. By default
+	// resources keep the order the API returned them in.
+	Sort bool
 }
 
 // Validate validates export options.
@@ -50,13 +88,90 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("output_path is required")
 	}
 
-	if o.Format != "" && o.Format != "json" && o.Format != "tar" {
-		return fmt.Errorf("format must be 'json' or 'tar'")
+	if o.Format != "" && o.Format != "json" && o.Format != "tar" && o.Format != "ndjson" {
+		return fmt.Errorf("format must be 'json', 'tar', or 'ndjson'")
+	}
+
+	if o.Sample < 0 {
+		return fmt.Errorf("sample must not be negative")
+	}
+
+	if o.Resume && o.DryRun {
+		return fmt.Errorf("resume cannot be combined with a dry run")
 	}
 
 	return nil
 }
 
+// selection returns the options of o that leave resources of the
+// organization out of the export, by the name of their flag, for
+// Manifest.Selection. It is empty for a full export.
+func (o *Options) selection() []string {
+	var selection []string
+	add := func(set bool, flag string) {
+		if set {
+			selection = append(selection, flag)
+		}
+	}
+	add(len(o.Blueprints) > 0, "--blueprints")
+	add(len(o.BlueprintPatterns) > 0, "--only")
+	add(len(o.ExcludeBlueprints) > 0, "--exclude-blueprints")
+	add(len(o.ExcludeBlueprintSchema) > 0, "--exclude-blueprint-schema")
+	add(o.SkipEntities, "--skip-entities")
+	add(len(o.SkipEntitiesFor) > 0, "--skip-entities-for")
+	add(len(o.IncludeResources) > 0 || len(o.IncludePatterns) > 0, "--include")
+	add(len(o.EntityFilters) > 0, "--entity-filter")
+	add(len(o.Entities) > 0, "--entities")
+	add(len(o.Scorecards) > 0, "--scorecards")
+	add(len(o.Actions) > 0, "--actions")
+	add(len(o.Pages) > 0, "--pages")
+	add(len(o.Integrations) > 0, "--integrations")
+	add(len(o.Teams) > 0, "--teams")
+	add(len(o.Users) > 0, "--users")
+	return selection
+}
+
+// selectBlueprints applies the Blueprints and BlueprintPatterns filters to
+// all. Blueprints matched by a pattern are exported with the blueprints their
+// relations target, as Dependencies and migrate decide, so the export can be
+// imported on its own.
+func (o *Options) selectBlueprints(all []api.Blueprint) []api.Blueprint {
+	selected := SelectBlueprints(all, o.Blueprints, o.BlueprintPatterns)
+	if len(o.BlueprintPatterns) > 0 {
+		selected = ResolveBlueprintDependencies(all, selected, o.Dependencies)
+	}
+	return selected
+}
+
+// errSampleComplete stops fetching a blueprint's entities once Options.Sample
+// of them have been collected.
+var errSampleComplete = errors.New("sample complete")
+
+// ManifestResource is the archive entry holding the export's Manifest.
+const ManifestResource = "_manifest"
+
+// Manifest describes how an export archive was produced.
+type Manifest struct {
+	// Anonymized is true when entity data was replaced by placeholders, so
+	// the archive is not a faithful copy of the source organization.
+	Anonymized bool `json:"anonymized"`
+	// Delta is true for a bundle written by port diff-bundle: it holds only
+	// the resources that changed between two exports, plus Deletions.
+	Delta bool `json:"delta,omitempty"`
+	// CLIVersion is the version of the CLI that wrote the archive. Archives
+	// written before it was recorded leave it empty.
+	CLIVersion string `json:"cliVersion,omitempty"`
+	// Sample is the number of entities kept per blueprint when the archive
+	// was exported with --sample, so it is not mistaken for a complete
+	// backup. 0 means every entity was exported.
+	Sample int `json:"sample,omitempty"`
+	// Selection lists what left resources of the source organization out
+	// of the archive: the export flags, such as "--skip-entities" or
+	// "--blueprints", or the command that wrote a partial archive. It is
+	// empty for a full export.
+	Selection []string `json:"selection,omitempty"`
+}
+
 // Data represents collected export data.
 type Data struct {
 	Blueprints []api.Blueprint
@@ -80,10 +195,16 @@ type Data struct {
 	// true: the set of blueprint identifiers that produced at least one
 	// matching entity/scorecard/action during Collect. Always non-nil.
 	ReferencedBlueprintIDs map[string]bool
+	// DataSources are the webhook and ingest data sources that feed
+	// blueprints, keyed by identifier.
+	DataSources []api.Webhook
+	// Deletions lists the resources a delta bundle removes from the target.
+	Deletions []Deletion
+	Manifest  Manifest
 }
 
-// maxConcurrentBlueprints caps how many blueprints are fetched in parallel.
-// Without a cap, 100+ blueprints each spawn 3-4 goroutines simultaneously,
+// maxConcurrentBlueprints is the number of workers fetching per-blueprint
+// resources. Without a cap, 100+ blueprints each fire 3-4 requests at once,
 // exhausting the rate limit on reads before a single response returns.
 const maxConcurrentBlueprints = 10
 
@@ -227,11 +348,13 @@ func (c *Collector) Collect(ctx context.Context, opts Options) (*Data, error) {
 		Folders:                []api.Folder{},
 		Pages:                  []api.Page{},
 		Integrations:           []api.Integration{},
+		DataSources:            []api.Webhook{},
 		TimeoutErrors:          []string{},
 		BlueprintPermissions:   make(map[string]api.Permissions),
 		ActionPermissions:      make(map[string]api.Permissions),
 		PagePermissions:        make(map[string]api.Permissions),
 		ReferencedBlueprintIDs: make(map[string]bool),
+		Manifest:               Manifest{CLIVersion: useragent.Version(), Sample: opts.Sample},
 	}
 
 	// Collect blueprints first (needed for other resources)
@@ -240,7 +363,7 @@ func (c *Collector) Collect(ctx context.Context, opts Options) (*Data, error) {
 		return nil, fmt.Errorf("failed to get blueprints: %w", err)
 	}
 
-	blueprints := FilterByField(allBlueprints, opts.Blueprints, "identifier")
+	blueprints := opts.selectBlueprints(allBlueprints)
 	excludeDeep := opts.ExcludeBlueprints
 	if !opts.IncludeRuleResults {
 		excludeDeep = append(excludeDeep, "_rule_result")
@@ -257,159 +380,18 @@ func (c *Collector) Collect(ctx context.Context, opts Options) (*Data, error) {
 	}
 	blueprints = iterBlueprints
 
-	// Use errgroup for concurrent collection, bounded by semaphore to avoid
-	// firing 100+ simultaneous requests (one per blueprint) and exhausting the
-	// read-side rate limit before any response arrives.
+	// Fetch the per-blueprint resources through a bounded worker pool, and
+	// the organization-wide resources alongside it.
 	g, ctx := errgroup.WithContext(ctx)
-	sem := semaphore.NewWeighted(maxConcurrentBlueprints)
 	var mu sync.Mutex
 	var timeoutErrors []string // Track timeout errors separately
 
-	// Collect entities, scorecards, and actions concurrently per blueprint
-	for _, blueprint := range blueprints {
-		bp := blueprint
-		bpID, ok := bp["identifier"].(string)
-		if !ok {
-			continue
-		}
-
-		skipEntitiesForBP := opts.SkipEntities || (opts.SkipSystemBlueprints && strings.HasPrefix(bpID, "_"))
-		if !skipEntitiesForBP && shouldCollect("entities", opts.IncludeResources) {
-			if err := sem.Acquire(ctx, 1); err != nil {
-				return nil, err
-			}
-			g.Go(func() error {
-				defer sem.Release(1)
-				var entities []api.Entity
-				err := c.client.ForEachEntity(ctx, bpID, func(batch []api.Entity) error {
-					entities = append(entities, batch...)
-					return nil
-				})
-				if err != nil {
-					if strings.Contains(err.Error(), "410 Gone") {
-						return nil
-					}
-					return fmt.Errorf("failed to get entities for blueprint %s: %w", bpID, err)
-				}
-
-				entities = FilterByField(entities, opts.Entities, "identifier")
-				mu.Lock()
-				data.Entities = append(data.Entities, entities...)
-				if opts.AutoScopeBlueprints && len(entities) > 0 {
-					data.ReferencedBlueprintIDs[bpID] = true
-				}
-				mu.Unlock()
-				return nil
-			})
-		}
-
-		// Collect scorecards
-		if shouldCollect("scorecards", opts.IncludeResources) {
-			if err := sem.Acquire(ctx, 1); err != nil {
-				return nil, err
-			}
-			g.Go(func() error {
-				defer sem.Release(1)
-				scorecards, err := c.client.GetScorecards(ctx, bpID)
-				if err != nil {
-					// Silent skip for expected errors
-					if !strings.Contains(err.Error(), "410 Gone") {
-						return fmt.Errorf("failed to get scorecards for blueprint %s: %w", bpID, err)
-					}
-					return nil
-				}
-
-				// Ensure scorecards have blueprintIdentifier field
-				for i := range scorecards {
-					if _, exists := scorecards[i]["blueprintIdentifier"]; !exists {
-						scorecards[i]["blueprintIdentifier"] = bpID
-					}
-				}
-
-				scorecards = FilterByField(scorecards, opts.Scorecards, "identifier")
-				mu.Lock()
-				data.Scorecards = append(data.Scorecards, scorecards...)
-				if opts.AutoScopeBlueprints && len(scorecards) > 0 {
-					data.ReferencedBlueprintIDs[bpID] = true
-				}
-				mu.Unlock()
-				return nil
-			})
-		}
-
-		// Collect actions (and their permissions)
-		if shouldCollect("actions", opts.IncludeResources) {
-			if err := sem.Acquire(ctx, 1); err != nil {
-				return nil, err
-			}
-			g.Go(func() error {
-				defer sem.Release(1)
-				actions, err := c.client.GetActions(ctx, bpID)
-				if err != nil {
-					// Silent skip for expected errors
-					if !strings.Contains(err.Error(), "410 Gone") {
-						return fmt.Errorf("failed to get actions for blueprint %s: %w", bpID, err)
-					}
-					return nil
-				}
-
-				actions = FilterByField(actions, opts.Actions, "identifier")
-				mu.Lock()
-				data.Actions = append(data.Actions, actions...)
-				if opts.AutoScopeBlueprints && len(actions) > 0 {
-					data.ReferencedBlueprintIDs[bpID] = true
-				}
-				mu.Unlock()
-
-				// Fetch permissions for each action
-				if shouldCollect("action-permissions", opts.IncludeResources) || len(opts.IncludeResources) == 0 {
-					for _, action := range actions {
-						actionID, ok := action["identifier"].(string)
-						if !ok {
-							continue
-						}
-						aID := actionID // capture for goroutine closure
-						g.Go(func() error {
-							perms, err := c.client.GetActionPermissions(ctx, aID)
-							if err != nil {
-								mu.Lock()
-								data.Warnings = append(data.Warnings, fmt.Sprintf("failed to fetch permissions for action %s: %v", aID, err))
-								mu.Unlock()
-								return nil
-							}
-							mu.Lock()
-							data.ActionPermissions[aID] = perms
-							mu.Unlock()
-							return nil
-						})
-					}
-				}
-				return nil
-			})
-		}
-
-		// Collect blueprint permissions
-		if shouldCollect("blueprint-permissions", opts.IncludeResources) || len(opts.IncludeResources) == 0 {
-			bpIDCopy := bpID // capture for goroutine closure
-			if err := sem.Acquire(ctx, 1); err != nil {
-				return nil, err
-			}
-			g.Go(func() error {
-				defer sem.Release(1)
-				perms, err := c.client.GetBlueprintPermissions(ctx, bpIDCopy)
-				if err != nil {
-					mu.Lock()
-					data.Warnings = append(data.Warnings, fmt.Sprintf("failed to fetch permissions for blueprint %s: %v", bpIDCopy, err))
-					mu.Unlock()
-					return nil
-				}
-				mu.Lock()
-				data.BlueprintPermissions[bpIDCopy] = perms
-				mu.Unlock()
-				return nil
-			})
-		}
-	}
+	jobs := blueprintJobs(blueprints, opts)
+	g.Go(func() error {
+		return runBlueprintJobs(ctx, jobs, maxConcurrentBlueprints, func(job blueprintJob) error {
+			return c.collectBlueprintResource(ctx, job, opts, data, &mu)
+		})
+	})
 
 	// Collect organization-wide resources
 	if !opts.SkipEntities && shouldCollect("teams", opts.IncludeResources) {
@@ -554,6 +536,20 @@ func (c *Collector) Collect(ctx context.Context, opts Options) (*Data, error) {
 		})
 	}
 
+	if shouldCollect("datasources", opts.IncludeResources) {
+		g.Go(func() error {
+			sources, err := c.client.GetWebhooks(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get data sources: %w", err)
+			}
+
+			mu.Lock()
+			data.DataSources = FilterDataSources(sources, blueprints)
+			mu.Unlock()
+			return nil
+		})
+	}
+
 	// Wait for all goroutines to complete
 	if err := g.Wait(); err != nil {
 		return nil, err
@@ -561,10 +557,288 @@ func (c *Collector) Collect(ctx context.Context, opts Options) (*Data, error) {
 
 	// Attach timeout errors to data
 	data.TimeoutErrors = timeoutErrors
+	FilterByIncludePatterns(data, opts.IncludePatterns)
 
 	return data, nil
 }
 
+// blueprintJob is the fetch of one resource type of one blueprint.
+type blueprintJob struct {
+	blueprint string
+	resource  string // entities, scorecards, actions, blueprint-permissions or entities-count
+}
+
+// blueprintJobs lists the per-blueprint fetches opts asks for, blueprint by
+// blueprint.
+func blueprintJobs(blueprints []api.Blueprint, opts Options) []blueprintJob {
+	var jobs []blueprintJob
+	for _, bp := range blueprints {
+		bpID, ok := bp["identifier"].(string)
+		if !ok {
+			continue
+		}
+		skipEntitiesForBP := opts.SkipEntities || (opts.SkipSystemBlueprints && strings.HasPrefix(bpID, "_")) ||
+			SkipsEntitiesOf(bpID, opts.SkipEntitiesFor)
+		if !skipEntitiesForBP && shouldCollect("entities", opts.IncludeResources) {
+			jobs = append(jobs, blueprintJob{bpID, "entities"})
+		}
+		if shouldCollect("scorecards", opts.IncludeResources) {
+			jobs = append(jobs, blueprintJob{bpID, "scorecards"})
+		}
+		if shouldCollect("actions", opts.IncludeResources) {
+			jobs = append(jobs, blueprintJob{bpID, "actions"})
+		}
+		if shouldCollect("blueprint-permissions", opts.IncludeResources) || len(opts.IncludeResources) == 0 {
+			jobs = append(jobs, blueprintJob{bpID, "blueprint-permissions"})
+		}
+	}
+	return jobs
+}
+
+// runBlueprintJobs runs jobs on a pool of workers, so at most workers
+// requests are in flight however many blueprints the org has. It stops
+// handing out jobs once one fails or ctx is done, and returns the first
+// error.
+func runBlueprintJobs(ctx context.Context, jobs []blueprintJob, workers int, run func(blueprintJob) error) error {
+	queue := make(chan blueprintJob)
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		defer close(queue)
+		for _, job := range jobs {
+			select {
+			case queue <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+	for w := 0; w < workers && w < len(jobs); w++ {
+		g.Go(func() error {
+			for job := range queue {
+				if err := run(job); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// collectBlueprintResource runs one job, adding what it fetched to data.
+func (c *Collector) collectBlueprintResource(ctx context.Context, job blueprintJob, opts Options, data *Data, mu *sync.Mutex) error {
+	bpID := job.blueprint
+	switch job.resource {
+	case "entities":
+		var entities []api.Entity
+		err := forEachEntity(ctx, c.client, bpID, opts.EntityFilters, func(batch []api.Entity) error {
+			entities = append(entities, FilterByField(batch, opts.Entities, "identifier")...)
+			if opts.Sample > 0 && len(entities) >= opts.Sample {
+				entities = entities[:opts.Sample]
+				return errSampleComplete
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errSampleComplete) {
+			if api.HasStatus(err, http.StatusGone) {
+				return nil
+			}
+			return fmt.Errorf("failed to get entities for blueprint %s: %w", bpID, err)
+		}
+
+		mu.Lock()
+		data.Entities = append(data.Entities, entities...)
+		if opts.AutoScopeBlueprints && len(entities) > 0 {
+			data.ReferencedBlueprintIDs[bpID] = true
+		}
+		mu.Unlock()
+
+	case "scorecards":
+		scorecards, err := c.client.GetScorecards(ctx, bpID)
+		if err != nil {
+			// Silent skip for expected errors
+			if !api.HasStatus(err, http.StatusGone) {
+				return fmt.Errorf("failed to get scorecards for blueprint %s: %w", bpID, err)
+			}
+			return nil
+		}
+
+		// Ensure scorecards have blueprintIdentifier field
+		for i := range scorecards {
+			if _, exists := scorecards[i]["blueprintIdentifier"]; !exists {
+				scorecards[i]["blueprintIdentifier"] = bpID
+			}
+		}
+
+		scorecards = FilterByField(scorecards, opts.Scorecards, "identifier")
+		mu.Lock()
+		data.Scorecards = append(data.Scorecards, scorecards...)
+		if opts.AutoScopeBlueprints && len(scorecards) > 0 {
+			data.ReferencedBlueprintIDs[bpID] = true
+		}
+		mu.Unlock()
+
+	case "actions":
+		actions, err := c.client.GetActions(ctx, bpID)
+		if err != nil {
+			// Silent skip for expected errors
+			if !api.HasStatus(err, http.StatusGone) {
+				return fmt.Errorf("failed to get actions for blueprint %s: %w", bpID, err)
+			}
+			return nil
+		}
+
+		actions = FilterByField(actions, opts.Actions, "identifier")
+		mu.Lock()
+		data.Actions = append(data.Actions, actions...)
+		if opts.AutoScopeBlueprints && len(actions) > 0 {
+			data.ReferencedBlueprintIDs[bpID] = true
+		}
+		mu.Unlock()
+
+		// Fetch permissions for each action on this worker, so they count
+		// against the pool's concurrency too.
+		if shouldCollect("action-permissions", opts.IncludeResources) || len(opts.IncludeResources) == 0 {
+			for _, action := range actions {
+				actionID, ok := action["identifier"].(string)
+				if !ok {
+					continue
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				perms, err := c.client.GetActionPermissions(ctx, actionID)
+				mu.Lock()
+				if err != nil {
+					data.Warnings = append(data.Warnings, fmt.Sprintf("failed to fetch permissions for action %s: %v", actionID, err))
+				} else {
+					data.ActionPermissions[actionID] = perms
+				}
+				mu.Unlock()
+			}
+		}
+
+	case "blueprint-permissions":
+		perms, err := c.client.GetBlueprintPermissions(ctx, bpID)
+		mu.Lock()
+		if err != nil {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("failed to fetch permissions for blueprint %s: %v", bpID, err))
+		} else {
+			data.BlueprintPermissions[bpID] = perms
+		}
+		mu.Unlock()
+	}
+	return nil
+}
+
+// CountEntities returns, by blueprint identifier, how many entities an
+// export with opts would write from blueprints. It asks Port for each
+// blueprint's entity count instead of fetching the entities, so it is cheap
+// even for large organizations; Sample caps each count. The count endpoint
+// does not apply EntityFilters, Entities or entity include patterns, so when
+// any is set the counts are upper bounds and approximate is true.
+func (c *Collector) CountEntities(ctx context.Context, blueprints []api.Blueprint, opts Options) (counts map[string]int, approximate bool, err error) {
+	counts = make(map[string]int, len(blueprints))
+	var jobs []blueprintJob
+	for _, bp := range blueprints {
+		if bpID, ok := bp["identifier"].(string); ok && bpID != "" {
+			jobs = append(jobs, blueprintJob{bpID, "entities-count"})
+		}
+	}
+
+	var mu sync.Mutex
+	err = runBlueprintJobs(ctx, jobs, maxConcurrentBlueprints, func(job blueprintJob) error {
+		count, err := c.client.GetEntitiesCount(ctx, job.blueprint)
+		if err != nil {
+			if api.HasStatus(err, http.StatusGone) {
+				return nil
+			}
+			return fmt.Errorf("failed to count entities for blueprint %s: %w", job.blueprint, err)
+		}
+		if opts.Sample > 0 && count > opts.Sample {
+			count = opts.Sample
+		}
+		mu.Lock()
+		counts[job.blueprint] = count
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	approximate = len(opts.EntityFilters) > 0 || len(opts.Entities) > 0 || len(opts.IncludePatterns["entities"]) > 0
+	return counts, approximate, nil
+}
+
+// EntityBatch is a page of one blueprint's entities sent by StreamEntities.
+// The last batch of a blueprint has Done set and may hold no entities.
+type EntityBatch struct {
+	Blueprint string
+	Entities  []api.Entity
+	Done      bool
+}
+
+// StreamEntities fetches the entities of blueprintIDs through the worker
+// pool and sends them to batches page by page, applying the Entities,
+// entity include pattern and Sample options. A blueprint's batches are sent
+// in order, but batches of different blueprints interleave. Blueprints that
+// no longer exist are sent as done with no entities. batches is not closed.
+func (c *Collector) StreamEntities(ctx context.Context, blueprintIDs []string, opts Options, batches chan<- EntityBatch) error {
+	entitySet := make(map[string]bool, len(opts.Entities))
+	for _, id := range opts.Entities {
+		entitySet[id] = true
+	}
+	jobs := make([]blueprintJob, len(blueprintIDs))
+	for i, bpID := range blueprintIDs {
+		jobs[i] = blueprintJob{bpID, "entities"}
+	}
+
+	return runBlueprintJobs(ctx, jobs, maxConcurrentBlueprints, func(job blueprintJob) error {
+		send := func(batch EntityBatch) error {
+			select {
+			case batches <- batch:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		kept := 0
+		err := forEachEntity(ctx, c.client, job.blueprint, opts.EntityFilters, func(entities []api.Entity) error {
+			page := make([]api.Entity, 0, len(entities))
+			sampled := false
+			for _, entity := range entities {
+				id, _ := entity["identifier"].(string)
+				if len(entitySet) > 0 && !entitySet[id] {
+					continue
+				}
+				if !IncludesIdentifier(opts.IncludePatterns, "entities", id) {
+					continue
+				}
+				page = append(page, entity)
+				kept++
+				if opts.Sample > 0 && kept >= opts.Sample {
+					sampled = true
+					break
+				}
+			}
+			if len(page) > 0 {
+				if err := send(EntityBatch{Blueprint: job.blueprint, Entities: page}); err != nil {
+					return err
+				}
+			}
+			if sampled {
+				return errSampleComplete
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errSampleComplete) && !api.HasStatus(err, http.StatusGone) {
+			return fmt.Errorf("failed to get entities for blueprint %s: %w", job.blueprint, err)
+		}
+		return send(EntityBatch{Blueprint: job.blueprint, Done: true})
+	})
+}
+
 // ApplyBlueprintExclusions returns two filtered slices from all:
 //   - iterList: used to iterate for fetching entities/scorecards/actions (deep-excluded removed, schema-only kept)
 //   - dataList: written to data.Blueprints for export output (both deep and schema-only excluded)
@@ -3,10 +3,15 @@ package export
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/config"
@@ -1328,3 +1333,196 @@ func TestCollector_OrgWideActionOnly_RecordsReferencedBlueprintIDs(t *testing.T)
 		t.Errorf("did not expect 'domain' referenced, got %v", data.ReferencedBlueprintIDs)
 	}
 }
+
+func TestCollector_EntitiesGoneIsNotFatal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		case "/blueprints/service/entities":
+			w.WriteHeader(http.StatusGone)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "gone"})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	data, err := NewCollector(client).Collect(context.Background(), Options{IncludeResources: []string{"blueprints", "entities"}})
+	if err != nil {
+		t.Fatalf("expected 410 on entities to be skipped, got: %v", err)
+	}
+	if len(data.Entities) != 0 {
+		t.Errorf("expected no entities, got %d", len(data.Entities))
+	}
+}
+
+func TestCollector_EntitiesErrorMentioningGoneIsFatal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		case "/blueprints/service/entities":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"message":"upstream returned 410 Gone"}`))
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	_, err := NewCollector(client).Collect(context.Background(), Options{IncludeResources: []string{"blueprints", "entities"}})
+	if err == nil {
+		t.Fatal("expected a 400 to fail collection even though its body mentions 410 Gone")
+	}
+}
+
+// raisePeak raises peak to v if v is higher.
+func raisePeak(peak *atomic.Int64, v int64) {
+	for {
+		old := peak.Load()
+		if v <= old || peak.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
+
+// BenchmarkCollector_ManyBlueprints collects an org with many blueprints, each
+// with a few actions, and reports the peak number of goroutines and of
+// requests in flight while collecting. Each request takes a millisecond, so
+// requests overlap as they would against the API.
+func BenchmarkCollector_ManyBlueprints(b *testing.B) {
+	const numBlueprints, actionsPerBlueprint = 500, 3
+	blueprints := make([]map[string]interface{}, numBlueprints)
+	for i := range blueprints {
+		blueprints[i] = map[string]interface{}{"identifier": fmt.Sprintf("bp%d", i)}
+	}
+
+	var inFlight, peakInFlight, peakGoroutines atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raisePeak(&peakInFlight, inFlight.Add(1))
+		defer inFlight.Add(-1)
+		raisePeak(&peakGoroutines, int64(runtime.NumGoroutine()))
+		time.Sleep(time.Millisecond)
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.URL.Path == "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": blueprints})
+		case strings.HasSuffix(r.URL.Path, "/entities-count"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 1})
+		case strings.HasSuffix(r.URL.Path, "/entities"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []map[string]interface{}{{"identifier": "e", "blueprint": parts[1]}}})
+		case len(parts) == 3 && parts[0] == "blueprints" && parts[2] == "actions":
+			actions := make([]map[string]interface{}, actionsPerBlueprint)
+			for i := range actions {
+				actions[i] = map[string]interface{}{"identifier": fmt.Sprintf("%s-a%d", parts[1], i)}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "actions": actions})
+		case strings.HasSuffix(r.URL.Path, "/scorecards"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecards": []interface{}{}})
+		case strings.HasSuffix(r.URL.Path, "/permissions"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "permissions": map[string]interface{}{}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := NewCollector(client).Collect(context.Background(), Options{IncludeResources: []string{"entities", "scorecards", "actions", "blueprint-permissions", "action-permissions"}})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(data.ActionPermissions) != numBlueprints*actionsPerBlueprint {
+			b.Fatalf("expected %d action permissions, got %d", numBlueprints*actionsPerBlueprint, len(data.ActionPermissions))
+		}
+	}
+	b.ReportMetric(float64(peakGoroutines.Load()), "peak-goroutines")
+	b.ReportMetric(float64(peakInFlight.Load()), "peak-requests")
+}
+
+func TestRunBlueprintJobs_CapsConcurrency(t *testing.T) {
+	jobs := make([]blueprintJob, 50)
+	for i := range jobs {
+		jobs[i] = blueprintJob{blueprint: fmt.Sprintf("bp%d", i), resource: "scorecards"}
+	}
+	var running, peak atomic.Int64
+	var done atomic.Int64
+	err := runBlueprintJobs(context.Background(), jobs, 4, func(blueprintJob) error {
+		raisePeak(&peak, running.Add(1))
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done.Load() != int64(len(jobs)) {
+		t.Errorf("expected %d jobs to run, got %d", len(jobs), done.Load())
+	}
+	if peak.Load() > 4 {
+		t.Errorf("expected at most 4 jobs at once, got %d", peak.Load())
+	}
+}
+
+func TestRunBlueprintJobs_StopsAfterFailure(t *testing.T) {
+	jobs := make([]blueprintJob, 100)
+	for i := range jobs {
+		jobs[i] = blueprintJob{blueprint: fmt.Sprintf("bp%d", i), resource: "actions"}
+	}
+	var ran atomic.Int64
+	err := runBlueprintJobs(context.Background(), jobs, 2, func(job blueprintJob) error {
+		ran.Add(1)
+		if job.blueprint == "bp0" {
+			return fmt.Errorf("boom")
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected the job's error, got %v", err)
+	}
+	if ran.Load() >= int64(len(jobs)) {
+		t.Errorf("expected the queue to stop after the failure, but all %d jobs ran", ran.Load())
+	}
+}
+
+func TestBlueprintJobs_SkipEntitiesFor(t *testing.T) {
+	blueprints := []api.Blueprint{{"identifier": "service"}, {"identifier": "k8s-pod"}, {"identifier": "log-entry"}}
+	jobs := blueprintJobs(blueprints, Options{
+		IncludeResources: []string{"entities", "scorecards"},
+		SkipEntitiesFor:  []string{"k8s-*", "log-entry"},
+	})
+
+	got := make(map[string][]string)
+	for _, job := range jobs {
+		got[job.blueprint] = append(got[job.blueprint], job.resource)
+	}
+	if len(got["service"]) != 2 {
+		t.Errorf("expected entities and scorecards for service, got %v", got["service"])
+	}
+	for _, bpID := range []string{"k8s-pod", "log-entry"} {
+		if len(got[bpID]) != 1 || got[bpID][0] != "scorecards" {
+			t.Errorf("expected only scorecards for %s, got %v", bpID, got[bpID])
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import "github.com/port-experimental/port-cli/internal/api"
+
+// DataSourceBlueprints returns the blueprints the mappings of data source ds
+// write entities to, in mapping order.
+func DataSourceBlueprints(ds api.Webhook) []string {
+	mappings, _ := ds["mappings"].([]interface{})
+	var blueprints []string
+	for _, m := range mappings {
+		mapping, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if bpID, ok := mapping["blueprint"].(string); ok && bpID != "" {
+			blueprints = append(blueprints, bpID)
+		}
+	}
+	return blueprints
+}
+
+// FilterDataSources keeps the data sources that feed at least one of
+// blueprints, along with those that have no mappings yet, so an export scoped
+// to some blueprints does not carry the ingest configs of the others.
+func FilterDataSources(sources []api.Webhook, blueprints []api.Blueprint) []api.Webhook {
+	selected := make(map[string]bool, len(blueprints))
+	for _, blueprint := range blueprints {
+		if bpID, ok := blueprint["identifier"].(string); ok {
+			selected[bpID] = true
+		}
+	}
+
+	filtered := make([]api.Webhook, 0, len(sources))
+	for _, ds := range sources {
+		targets := DataSourceBlueprints(ds)
+		keep := len(targets) == 0
+		for _, bpID := range targets {
+			if selected[bpID] {
+				keep = true
+				break
+			}
+		}
+		if keep {
+			filtered = append(filtered, ds)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestFilterDataSources(t *testing.T) {
+	sources := []api.Webhook{
+		{"identifier": "github", "mappings": []interface{}{
+			map[string]interface{}{"blueprint": "repository"},
+			map[string]interface{}{"blueprint": "pullRequest"},
+		}},
+		{"identifier": "pagerduty", "mappings": []interface{}{
+			map[string]interface{}{"blueprint": "incident"},
+		}},
+		{"identifier": "unmapped"},
+	}
+	blueprints := []api.Blueprint{{"identifier": "pullRequest"}}
+
+	got := FilterDataSources(sources, blueprints)
+	var ids []string
+	for _, ds := range got {
+		ids = append(ids, ds["identifier"].(string))
+	}
+	if len(ids) != 2 || ids[0] != "github" || ids[1] != "unmapped" {
+		t.Errorf("expected [github unmapped], got %v", ids)
+	}
+}
+
+func TestDataSourceBlueprints(t *testing.T) {
+	ds := api.Webhook{"mappings": []interface{}{
+		map[string]interface{}{"blueprint": "service"},
+		map[string]interface{}{"filter": "true"},
+		"not a mapping",
+	}}
+	got := DataSourceBlueprints(ds)
+	if len(got) != 1 || got[0] != "service" {
+		t.Errorf("expected [service], got %v", got)
+	}
+}
@@ -0,0 +1,16 @@
+package export
+
+// DeletionsResource is the archive entry listing the resources a delta bundle
+// removes from the target organization.
+const DeletionsResource = "_deletions"
+
+// Deletion identifies a resource removed between the two exports a delta
+// bundle was built from. Type is the export section the resource lived in,
+// such as "blueprints" or "entities".
+type Deletion struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+	// Blueprint is set for entities, scorecards and blueprint-level actions,
+	// which are addressed through their blueprint.
+	Blueprint string `json:"blueprint,omitempty"`
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"gopkg.in/yaml.v3"
+)
+
+// entityFilterPageSize is the page size used when searching filtered entities.
+const entityFilterPageSize = 1000
+
+// LoadEntityFilters reads a YAML or JSON file mapping blueprint identifiers to
+// Port search queries. Each value is either a rule group
+// ({"combinator": "and", "rules": [...]}) or a single rule
+// ({"property": "environment", "operator": "=", "value": "prod"}), which is
+// wrapped in an "and" group.
+func LoadEntityFilters(path string) (map[string]map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entity filter file: %w", err)
+	}
+
+	var raw map[string]map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse entity filter file: expected a map of blueprint identifier to search query: %w", err)
+	}
+
+	filters := make(map[string]map[string]interface{}, len(raw))
+	for bpID, query := range raw {
+		normalized, err := normalizeSearchQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("entity filter for blueprint %s: %w", bpID, err)
+		}
+		filters[bpID] = normalized
+	}
+	return filters, nil
+}
+
+// LoadSearchQuery reads a YAML or JSON file holding a single Port search
+// query, either a rule group or a single rule, which is wrapped in an "and"
+// group.
+func LoadSearchQuery(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query file: %w", err)
+	}
+
+	var query map[string]interface{}
+	if err := yaml.Unmarshal(content, &query); err != nil {
+		return nil, fmt.Errorf("failed to parse query file: expected a search query: %w", err)
+	}
+	normalized, err := normalizeSearchQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return normalized, nil
+}
+
+// normalizeSearchQuery returns query as a rule group.
+func normalizeSearchQuery(query map[string]interface{}) (map[string]interface{}, error) {
+	switch {
+	case query["rules"] != nil:
+		if _, ok := query["rules"].([]interface{}); !ok {
+			return nil, errors.New("\"rules\" must be a list")
+		}
+		if _, ok := query["combinator"]; !ok {
+			query["combinator"] = "and"
+		}
+		return query, nil
+	case query["operator"] != nil:
+		return map[string]interface{}{
+			"combinator": "and",
+			"rules":      []interface{}{query},
+		}, nil
+	default:
+		return nil, errors.New("must be a rule or a rule group with \"rules\"")
+	}
+}
+
+// ValidateEntityFilters returns an error naming every filtered blueprint that
+// is not in blueprints.
+func ValidateEntityFilters(filters map[string]map[string]interface{}, blueprints []api.Blueprint) error {
+	known := make(map[string]bool, len(blueprints))
+	for _, bp := range blueprints {
+		if id, ok := bp["identifier"].(string); ok {
+			known[id] = true
+		}
+	}
+
+	var unknown []string
+	for bpID := range filters {
+		if !known[bpID] {
+			unknown = append(unknown, bpID)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("entity filter references unknown blueprint(s): %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// forEachEntity streams a blueprint's entities, using the search endpoint with
+// the blueprint's filter when one is configured.
+func forEachEntity(ctx context.Context, client *api.Client, bpID string, filters map[string]map[string]interface{}, yield func([]api.Entity) error) error {
+	query, ok := filters[bpID]
+	if !ok {
+		return client.ForEachEntity(ctx, bpID, yield)
+	}
+	return client.ForEachEntityPage(ctx, bpID, map[string]interface{}{
+		"query": query,
+		"limit": entityFilterPageSize,
+	}, yield)
+}
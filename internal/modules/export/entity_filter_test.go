@@ -0,0 +1,157 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestLoadEntityFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	content := `service:
+  property: environment
+  operator: "="
+  value: prod
+deployment:
+  combinator: or
+  rules:
+    - property: status
+      operator: "="
+      value: live
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	filters, err := LoadEntityFilters(path)
+	if err != nil {
+		t.Fatalf("LoadEntityFilters: %v", err)
+	}
+	svc := filters["service"]
+	if svc["combinator"] != "and" {
+		t.Fatalf("expected single rule to be wrapped in an and group, got %v", svc)
+	}
+	if rules, _ := svc["rules"].([]interface{}); len(rules) != 1 {
+		t.Fatalf("expected one wrapped rule, got %v", svc["rules"])
+	}
+	if filters["deployment"]["combinator"] != "or" {
+		t.Fatalf("expected rule group to be kept as-is, got %v", filters["deployment"])
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"service": {"value": "prod"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEntityFilters(bad); err == nil || !strings.Contains(err.Error(), "service") {
+		t.Fatalf("expected invalid filter to be rejected, got %v", err)
+	}
+}
+
+func TestLoadSearchQuery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "query.json")
+	if err := os.WriteFile(path, []byte(`{"property": "$title", "operator": "contains", "value": "test-"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	query, err := LoadSearchQuery(path)
+	if err != nil {
+		t.Fatalf("LoadSearchQuery: %v", err)
+	}
+	if rules, _ := query["rules"].([]interface{}); query["combinator"] != "and" || len(rules) != 1 {
+		t.Fatalf("expected single rule to be wrapped in an and group, got %v", query)
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("rules: not-a-list\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSearchQuery(bad); err == nil || !strings.Contains(err.Error(), "must be a list") {
+		t.Fatalf("expected invalid query to be rejected, got %v", err)
+	}
+}
+
+func TestExecute_EntityFilterUsesSearchQuery(t *testing.T) {
+	var searchQuery map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		case "/blueprints/service/entities", "/blueprints/service/entities-count":
+			http.Error(w, "unexpected unfiltered entities call", http.StatusInternalServerError)
+		case "/blueprints/service/entities/search":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode search body: %v", err)
+			}
+			searchQuery, _ = body["query"].(map[string]interface{})
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"entities": []map[string]interface{}{{"identifier": "svc-prod", "blueprint": "service"}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	filter := map[string]interface{}{
+		"combinator": "and",
+		"rules": []interface{}{
+			map[string]interface{}{"property": "environment", "operator": "=", "value": "prod"},
+		},
+	}
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	result, err := module.Execute(context.Background(), Options{
+		OutputPath:       filepath.Join(t.TempDir(), "export.json"),
+		Format:           "json",
+		IncludeResources: []string{"entities"},
+		EntityFilters:    map[string]map[string]interface{}{"service": filter},
+	})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !result.Success || result.EntitiesCount != 1 {
+		t.Fatalf("expected 1 filtered entity, got %+v", result)
+	}
+	if searchQuery["combinator"] != "and" {
+		t.Fatalf("expected filter to be sent as the search query, got %v", searchQuery)
+	}
+}
+
+func TestExecute_EntityFilterUnknownBlueprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	_, err := module.Execute(context.Background(), Options{
+		OutputPath:    filepath.Join(t.TempDir(), "export.json"),
+		EntityFilters: map[string]map[string]interface{}{"servcie": {"combinator": "and", "rules": []interface{}{}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown blueprint(s): servcie") {
+		t.Fatalf("expected unknown blueprint error, got %v", err)
+	}
+}
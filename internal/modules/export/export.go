@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/auth"
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/logging"
 )
 
 // Module handles exporting data from Port.
@@ -25,6 +27,12 @@ func NewModule(token *auth.Token, orgConfig *config.OrganizationConfig) *Module
 		ClientID:     orgConfig.ClientID,
 		ClientSecret: orgConfig.ClientSecret,
 		APIURL:       orgConfig.APIURL,
+		RateLimit:    orgConfig.RateLimit,
+		Concurrency:  orgConfig.Concurrency,
+		Headers:      orgConfig.Headers,
+		APIVersion:   orgConfig.APIVersion,
+		CacheTTL:     orgConfig.CacheTTL,
+		NoCache:      orgConfig.NoCache,
 		Timeout:      0,
 	})
 	return &Module{
@@ -42,11 +50,21 @@ type Result struct {
 	ActionsCount      int
 	PagesCount        int
 	IntegrationsCount int
+	DataSourcesCount  int
 	UsersCount        int
 	TeamsCount        int
 	FoldersCount      int
 	Format            string
 	TimeoutErrors     []string // Blueprints that timed out during export
+	Anonymized        bool
+	Sample            int // entities kept per blueprint with --sample; 0 for a full export
+	DryRun            bool
+	// EntitiesApproximate is set on a dry run whose entity count is an upper
+	// bound, as the count endpoint ignores entity filters.
+	EntitiesApproximate bool
+	// ResumedBlueprints is the number of blueprints whose entities were
+	// taken from an interrupted export instead of being fetched again.
+	ResumedBlueprints int
 	Error             error
 }
 
@@ -57,12 +75,26 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		return nil, err
 	}
 
+	// Fail fast on filters for blueprints that do not exist, before any
+	// resources are collected.
+	if len(opts.EntityFilters) > 0 {
+		blueprints, err := m.client.GetBlueprints(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blueprints: %w", err)
+		}
+		if err := ValidateEntityFilters(opts.EntityFilters, blueprints); err != nil {
+			return nil, err
+		}
+	}
+
 	// Collect non-entity data concurrently. Entity data can be much larger than
 	// the rest of the export, so it is streamed directly to the archive below.
 	collector := NewCollector(m.client)
 	metadataOpts := opts
 	metadataOpts.SkipEntities = true
+	finishCollect := logging.FromContext(ctx).Phase("collect")
 	data, err := collector.Collect(ctx, metadataOpts)
+	finishCollect()
 	if err != nil {
 		return &Result{
 			Success: false,
@@ -70,20 +102,22 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 			Error:   err,
 		}, nil
 	}
+	// Collect ran with entities skipped, so the selection comes from opts.
+	data.Manifest.Selection = opts.selection()
 
 	// Write output
 	formatType := opts.Format
 	if formatType == "" {
-		// Determine format from file extension
-		ext := strings.ToLower(filepath.Ext(opts.OutputPath))
-		if ext == ".json" {
-			formatType = "json"
-		} else {
-			formatType = "tar"
-		}
+		formatType = formatForPath(opts.OutputPath)
+	}
+
+	if opts.DryRun {
+		return m.planExport(ctx, collector, data, opts, formatType)
 	}
 
-	entitiesCount, timeoutErrors, err := m.writeStreamingExport(ctx, data, opts, formatType)
+	finishWrite := logging.FromContext(ctx).Phase("write")
+	entitiesCount, resumed, timeoutErrors, err := m.writeStreamingExport(ctx, data, opts, formatType)
+	finishWrite()
 	if err != nil {
 		return &Result{
 			Success: false,
@@ -102,18 +136,70 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		ActionsCount:      len(data.Actions),
 		PagesCount:        len(data.Pages),
 		IntegrationsCount: len(data.Integrations),
+		DataSourcesCount:  len(data.DataSources),
 		UsersCount:        len(data.Users),
 		TeamsCount:        len(data.Teams),
 		FoldersCount:      len(data.Folders),
 		Format:            formatType,
 		TimeoutErrors:     data.TimeoutErrors,
+		Anonymized:        data.Manifest.Anonymized,
+		Sample:            data.Manifest.Sample,
+		ResumedBlueprints: resumed,
 	}, nil
 }
 
-func (m *Module) writeStreamingExport(ctx context.Context, data *Data, opts Options, formatType string) (int, []string, error) {
+// planExport reports what Execute would write for data and opts, counting
+// entities instead of streaming them. Nothing is written.
+func (m *Module) planExport(ctx context.Context, collector *Collector, data *Data, opts Options, formatType string) (*Result, error) {
+	entitiesCount := 0
+	approximate := false
+	if shouldStreamEntities(opts) {
+		blueprints, err := m.blueprintsForEntityStreaming(ctx, opts)
+		if err != nil {
+			return &Result{Success: false, Message: "Export failed", Error: err}, nil
+		}
+		counts, approx, err := collector.CountEntities(ctx, blueprints, opts)
+		if err != nil {
+			return &Result{Success: false, Message: "Export failed", Error: err}, nil
+		}
+		approximate = approx
+		for bpID, count := range counts {
+			entitiesCount += count
+			if opts.AutoScopeBlueprints && count > 0 {
+				data.ReferencedBlueprintIDs[bpID] = true
+			}
+		}
+	}
+	if opts.AutoScopeBlueprints && shouldCollect("blueprints", opts.IncludeResources) {
+		data.Blueprints = FilterBlueprintsToReferenced(data.Blueprints, data.ReferencedBlueprintIDs)
+	}
+
+	return &Result{
+		Success:             true,
+		Message:             fmt.Sprintf("Dry run: would export data to %s", opts.OutputPath),
+		OutputPath:          opts.OutputPath,
+		BlueprintsCount:     len(data.Blueprints),
+		EntitiesCount:       entitiesCount,
+		ActionsCount:        len(data.Actions),
+		PagesCount:          len(data.Pages),
+		IntegrationsCount:   len(data.Integrations),
+		DataSourcesCount:    len(data.DataSources),
+		UsersCount:          len(data.Users),
+		TeamsCount:          len(data.Teams),
+		FoldersCount:        len(data.Folders),
+		Format:              formatType,
+		TimeoutErrors:       data.TimeoutErrors,
+		Anonymized:          opts.Anonymize,
+		Sample:              opts.Sample,
+		DryRun:              true,
+		EntitiesApproximate: approximate,
+	}, nil
+}
+
+func (m *Module) writeStreamingExport(ctx context.Context, data *Data, opts Options, formatType string) (int, int, []string, error) {
 	writer, err := newArchiveWriter(formatType, opts.OutputPath)
 	if err != nil {
-		return 0, nil, err
+		return 0, 0, nil, err
 	}
 	closed := false
 	defer func() {
@@ -122,6 +208,14 @@ func (m *Module) writeStreamingExport(ctx context.Context, data *Data, opts Opti
 		}
 	}()
 
+	var anonymizer *Anonymizer
+	if opts.Anonymize {
+		anonymizer, err = newRandomAnonymizer()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+	}
+
 	// Entities are streamed BEFORE "blueprints" is written: when
 	// AutoScopeBlueprints is set, streaming records which blueprints actually
 	// had a matching entity into data.ReferencedBlueprintIDs, and that has to
@@ -129,23 +223,34 @@ func (m *Module) writeStreamingExport(ctx context.Context, data *Data, opts Opti
 	// effect on the archive's correctness (see archive_writer.go — each
 	// resource is its own tar entry / JSON object key, read back by name).
 	entitiesCount := 0
+	resumed := 0
 	timeoutErrors := []string{}
+	var state *exportState
 	if shouldStreamEntities(opts) {
-		count, errs, err := m.writeEntities(ctx, writer, opts, data)
+		state, err = openExportState(opts.OutputPath, opts, opts.Resume)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		defer state.close()
+		entitiesCount, resumed, err = m.writeEntities(ctx, writer, state, opts, data, anonymizer)
 		if err != nil {
-			return 0, nil, err
+			return 0, 0, nil, err
 		}
-		entitiesCount = count
-		timeoutErrors = errs
 	} else if err := writer.WriteResource("entities", []api.Entity{}); err != nil {
-		return 0, nil, err
+		return 0, 0, nil, err
 	}
 
 	if opts.AutoScopeBlueprints && shouldCollect("blueprints", opts.IncludeResources) {
 		data.Blueprints = FilterBlueprintsToReferenced(data.Blueprints, data.ReferencedBlueprintIDs)
 	}
+	if anonymizer != nil {
+		anonymizer.Data(data)
+	}
+	if opts.Sort {
+		SortData(data)
+	}
 	if err := writer.WriteResource("blueprints", data.Blueprints); err != nil {
-		return 0, nil, err
+		return 0, 0, nil, err
 	}
 
 	resources := []struct {
@@ -159,73 +264,120 @@ func (m *Module) writeStreamingExport(ctx context.Context, data *Data, opts Opti
 		{"_folders", data.Folders},
 		{"pages", data.Pages},
 		{"integrations", data.Integrations},
+		{"datasources", data.DataSources},
 		{"blueprint_permissions", data.BlueprintPermissions},
 		{"action_permissions", data.ActionPermissions},
 		{"page_permissions", data.PagePermissions},
+		{ManifestResource, data.Manifest},
 	}
 	for _, resource := range resources {
 		if err := writer.WriteResource(resource.name, resource.value); err != nil {
-			return 0, nil, err
+			return 0, 0, nil, err
 		}
 	}
 	if err := writer.Close(); err != nil {
-		return 0, nil, err
+		return 0, 0, nil, err
 	}
 	closed = true
-	return entitiesCount, timeoutErrors, nil
+	if state != nil {
+		if err := state.remove(); err != nil {
+			return 0, 0, nil, fmt.Errorf("failed to remove export state: %w", err)
+		}
+	}
+	return entitiesCount, resumed, timeoutErrors, nil
 }
 
 func shouldStreamEntities(opts Options) bool {
 	return !opts.SkipEntities && shouldCollect("entities", opts.IncludeResources)
 }
 
-func (m *Module) writeEntities(ctx context.Context, writer ArchiveWriter, opts Options, data *Data) (int, []string, error) {
+// writeEntities fetches the entities of every blueprint that state has not
+// completed, spooling each page into state as the collector sends it, then
+// writes all spooled entities into writer in blueprint order, anonymizing
+// them first when anonymizer is non-nil. If fetching fails, the blueprints
+// completed so far stay in state for a resume. It returns the number of
+// entities written and of blueprints taken from an earlier run.
+func (m *Module) writeEntities(ctx context.Context, writer ArchiveWriter, state *exportState, opts Options, data *Data, anonymizer *Anonymizer) (int, int, error) {
 	blueprints, err := m.blueprintsForEntityStreaming(ctx, opts)
 	if err != nil {
-		return 0, nil, err
+		return 0, 0, err
 	}
 
-	entitySet := make(map[string]bool, len(opts.Entities))
-	for _, id := range opts.Entities {
-		entitySet[id] = true
+	var blueprintIDs, pending []string
+	resumed := 0
+	for _, bp := range blueprints {
+		bpID, _ := bp["identifier"].(string)
+		if bpID == "" {
+			continue
+		}
+		blueprintIDs = append(blueprintIDs, bpID)
+		if _, done := state.Completed[bpID]; done {
+			resumed++
+		} else {
+			pending = append(pending, bpID)
+		}
+	}
+
+	// The collector's workers send pages over batches; this goroutine is the
+	// only one writing to state.
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	batches := make(chan EntityBatch)
+	fetchErr := make(chan error, 1)
+	go func() {
+		fetchErr <- NewCollector(m.client).StreamEntities(fetchCtx, pending, opts, batches)
+		close(batches)
+	}()
+	var spoolErr error
+	for batch := range batches {
+		if spoolErr != nil {
+			continue
+		}
+		if spoolErr = state.add(batch); spoolErr != nil {
+			cancel()
+		}
+	}
+	if err := <-fetchErr; spoolErr == nil && err != nil {
+		spoolErr = err
+	}
+	if spoolErr != nil {
+		return 0, 0, fmt.Errorf("%w (%d of %d blueprint(s) completed; use --resume to continue)", spoolErr, len(state.Completed), len(blueprintIDs))
 	}
 
+	if opts.Sort {
+		sort.Strings(blueprintIDs)
+	}
+	transform := func(entity api.Entity) api.Entity {
+		if anonymizer != nil {
+			return anonymizer.Entity(entity)
+		}
+		return entity
+	}
 	total := 0
-	timeoutErrors := []string{}
 	err = writer.WriteEntities(func(sink EntitySink) error {
-		for _, bp := range blueprints {
-			bpID, _ := bp["identifier"].(string)
-			if bpID == "" {
-				continue
+		writeEntity := func(entity api.Entity) error {
+			total++
+			return sink.WriteEntity(entity)
+		}
+		for _, bpID := range blueprintIDs {
+			var err error
+			if opts.Sort {
+				err = sortedSpooledEntities(state, bpID, transform, writeEntity)
+			} else {
+				err = state.forEachEntity(bpID, func(entity api.Entity) error {
+					return writeEntity(transform(entity))
+				})
 			}
-			err := m.client.ForEachEntity(ctx, bpID, func(entities []api.Entity) error {
-				for _, entity := range entities {
-					if len(entitySet) > 0 {
-						id, _ := entity["identifier"].(string)
-						if !entitySet[id] {
-							continue
-						}
-					}
-					if err := sink.WriteEntity(entity); err != nil {
-						return err
-					}
-					total++
-					if opts.AutoScopeBlueprints {
-						data.ReferencedBlueprintIDs[bpID] = true
-					}
-				}
-				return nil
-			})
 			if err != nil {
-				if strings.Contains(err.Error(), "410 Gone") {
-					continue
-				}
-				return fmt.Errorf("failed to get entities for blueprint %s: %w", bpID, err)
+				return err
+			}
+			if opts.AutoScopeBlueprints && state.Completed[bpID] > 0 {
+				data.ReferencedBlueprintIDs[bpID] = true
 			}
 		}
 		return nil
 	})
-	return total, timeoutErrors, err
+	return total, resumed, err
 }
 
 func (m *Module) blueprintsForEntityStreaming(ctx context.Context, opts Options) ([]api.Blueprint, error) {
@@ -234,19 +386,7 @@ func (m *Module) blueprintsForEntityStreaming(ctx context.Context, opts Options)
 		return nil, fmt.Errorf("failed to get blueprints: %w", err)
 	}
 
-	blueprints := allBlueprints
-	if len(opts.Blueprints) > 0 {
-		blueprintSet := make(map[string]bool, len(opts.Blueprints))
-		for _, bpID := range opts.Blueprints {
-			blueprintSet[bpID] = true
-		}
-		blueprints = nil
-		for _, bp := range allBlueprints {
-			if identifier, ok := bp["identifier"].(string); ok && blueprintSet[identifier] {
-				blueprints = append(blueprints, bp)
-			}
-		}
-	}
+	blueprints := opts.selectBlueprints(allBlueprints)
 
 	excludeDeep := append([]string{}, opts.ExcludeBlueprints...)
 	if !opts.IncludeRuleResults {
@@ -261,7 +401,16 @@ func (m *Module) blueprintsForEntityStreaming(ctx context.Context, opts Options)
 		}
 	}
 	iterBlueprints, _ := ApplyBlueprintExclusions(blueprints, excludeDeep, opts.ExcludeBlueprintSchema)
-	return iterBlueprints, nil
+	if len(opts.SkipEntitiesFor) == 0 {
+		return iterBlueprints, nil
+	}
+	withEntities := make([]api.Blueprint, 0, len(iterBlueprints))
+	for _, bp := range iterBlueprints {
+		if id, _ := bp["identifier"].(string); !SkipsEntitiesOf(id, opts.SkipEntitiesFor) {
+			withEntities = append(withEntities, bp)
+		}
+	}
+	return withEntities, nil
 }
 
 // Close closes the API client.
@@ -272,6 +421,29 @@ func (m *Module) Close() error {
 	return nil
 }
 
+// formatForPath returns the archive format implied by an output file's
+// extension: json, ndjson, or tar for anything else.
+func formatForPath(outputPath string) string {
+	switch strings.ToLower(filepath.Ext(outputPath)) {
+	case ".json":
+		return "json"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	default:
+		return "tar"
+	}
+}
+
+// WriteBundle writes data to outputPath in the format implied by its
+// extension, the same way port export chooses one.
+func WriteBundle(data *Data, outputPath string) error {
+	writer, err := newArchiveWriter(formatForPath(outputPath), outputPath)
+	if err != nil {
+		return err
+	}
+	return writeDataArchive(data, writer)
+}
+
 // writeTar writes data to a tar.gz file.
 func writeTar(data *Data, outputPath string) error {
 	writer, err := newTarArchiveWriter(outputPath)
@@ -293,6 +465,7 @@ func (d *Data) WriteJSON(w io.Writer) error {
 		"_folders":              d.Folders,
 		"pages":                 d.Pages,
 		"integrations":          d.Integrations,
+		"datasources":           d.DataSources,
 		"blueprint_permissions": d.BlueprintPermissions,
 		"action_permissions":    d.ActionPermissions,
 		"page_permissions":      d.PagePermissions,
@@ -330,9 +503,17 @@ func writeDataArchive(data *Data, writer ArchiveWriter) error {
 		{"_folders", data.Folders},
 		{"pages", data.Pages},
 		{"integrations", data.Integrations},
+		{"datasources", data.DataSources},
 		{"blueprint_permissions", data.BlueprintPermissions},
 		{"action_permissions", data.ActionPermissions},
 		{"page_permissions", data.PagePermissions},
+		{ManifestResource, data.Manifest},
+	}
+	if len(data.Deletions) > 0 {
+		resources = append(resources, struct {
+			name  string
+			value interface{}
+		}{DeletionsResource, data.Deletions})
 	}
 	for _, resource := range resources {
 		if err := writer.WriteResource(resource.name, resource.value); err != nil {
@@ -0,0 +1,195 @@
+package export
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// ExportStateSuffix names the directory, next to the output file, that holds
+// the entities of an export in progress. It is removed once the export is
+// written, and kept when the export fails so Options.Resume can pick up the
+// blueprints that were not finished.
+const ExportStateSuffix = ".partial"
+
+const exportStateFile = "state.json"
+
+// exportState records which blueprints' entities an export has fetched in
+// full. Each blueprint's entities are spooled to their own file in the state
+// directory as pages arrive, and the blueprint is recorded as completed once
+// its last page is on disk. It is used from a single goroutine.
+type exportState struct {
+	dir string
+	// Fingerprint identifies the entity options the state was written with,
+	// so a resume does not mix entities selected differently.
+	Fingerprint string `json:"fingerprint"`
+	// Completed maps a blueprint identifier to its number of spooled entities.
+	Completed map[string]int `json:"completed"`
+
+	spools map[string]*entitySpool
+}
+
+type entitySpool struct {
+	file    *os.File
+	buf     *bufio.Writer
+	encoder *json.Encoder
+	count   int
+}
+
+// entityStateFingerprint hashes the options that decide which entities of a
+// blueprint are exported.
+func entityStateFingerprint(opts Options) string {
+	raw, _ := json.Marshal(struct {
+		Entities      []string
+		Patterns      []string
+		EntityFilters map[string]map[string]interface{}
+		Sample        int
+	}{opts.Entities, opts.IncludePatterns["entities"], opts.EntityFilters, opts.Sample})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// openExportState opens the export state for outputPath. With resume, the
+// blueprints completed by an earlier, interrupted export with the same
+// entity options are kept; otherwise any earlier state is discarded.
+func openExportState(outputPath string, opts Options, resume bool) (*exportState, error) {
+	dir := outputPath + ExportStateSuffix
+	fingerprint := entityStateFingerprint(opts)
+	state := &exportState{dir: dir, Fingerprint: fingerprint, Completed: map[string]int{}, spools: map[string]*entitySpool{}}
+
+	if resume {
+		content, err := os.ReadFile(filepath.Join(dir, exportStateFile))
+		switch {
+		case err == nil:
+			var saved exportState
+			if err := json.Unmarshal(content, &saved); err != nil {
+				return nil, fmt.Errorf("failed to read export state in %s: %w", dir, err)
+			}
+			if saved.Fingerprint != fingerprint {
+				return nil, fmt.Errorf("the export state in %s was written with different entity options; run without --resume to start over", dir)
+			}
+			for bpID, count := range saved.Completed {
+				state.Completed[bpID] = count
+			}
+			// Drop the spools of blueprints that were not finished.
+			names, err := os.ReadDir(dir)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range names {
+				if filepath.Ext(entry.Name()) == ".tmp" {
+					os.Remove(filepath.Join(dir, entry.Name()))
+				}
+			}
+			return state, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("failed to read export state in %s: %w", dir, err)
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clear export state in %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export state directory: %w", err)
+	}
+	return state, state.save()
+}
+
+func (s *exportState) spoolPath(bpID string) string {
+	return filepath.Join(s.dir, hex.EncodeToString([]byte(bpID))+".ndjson")
+}
+
+// add spools a batch of entities, and records the blueprint as completed on
+// its last batch.
+func (s *exportState) add(batch EntityBatch) error {
+	spool, ok := s.spools[batch.Blueprint]
+	if !ok {
+		file, err := os.Create(s.spoolPath(batch.Blueprint) + ".tmp")
+		if err != nil {
+			return fmt.Errorf("failed to spool entities of blueprint %s: %w", batch.Blueprint, err)
+		}
+		buf := bufio.NewWriter(file)
+		spool = &entitySpool{file: file, buf: buf, encoder: json.NewEncoder(buf)}
+		s.spools[batch.Blueprint] = spool
+	}
+	for _, entity := range batch.Entities {
+		if err := spool.encoder.Encode(entity); err != nil {
+			return fmt.Errorf("failed to spool entities of blueprint %s: %w", batch.Blueprint, err)
+		}
+		spool.count++
+	}
+	if !batch.Done {
+		return nil
+	}
+
+	delete(s.spools, batch.Blueprint)
+	if err := spool.buf.Flush(); err != nil {
+		spool.file.Close()
+		return err
+	}
+	if err := spool.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(spool.file.Name(), s.spoolPath(batch.Blueprint)); err != nil {
+		return err
+	}
+	s.Completed[batch.Blueprint] = spool.count
+	return s.save()
+}
+
+// save writes the state file through a temporary file, so an interruption
+// leaves either the previous or the new state.
+func (s *exportState) save() error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.dir, exportStateFile+".tmp")
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to save export state: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(s.dir, exportStateFile))
+}
+
+// forEachEntity reads back the spooled entities of a completed blueprint.
+func (s *exportState) forEachEntity(bpID string, yield func(api.Entity) error) error {
+	file, err := os.Open(s.spoolPath(bpID))
+	if err != nil {
+		return fmt.Errorf("failed to read spooled entities of blueprint %s: %w", bpID, err)
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for decoder.More() {
+		var entity api.Entity
+		if err := decoder.Decode(&entity); err != nil {
+			return fmt.Errorf("failed to read spooled entities of blueprint %s: %w", bpID, err)
+		}
+		if err := yield(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the spools of unfinished blueprints, keeping the state on
+// disk for a resume.
+func (s *exportState) close() {
+	for bpID, spool := range s.spools {
+		spool.file.Close()
+		delete(s.spools, bpID)
+	}
+}
+
+// remove deletes the state directory once the export is written.
+func (s *exportState) remove() error {
+	s.close()
+	return os.RemoveAll(s.dir)
+}
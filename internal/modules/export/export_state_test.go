@@ -0,0 +1,66 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestExportState_Resume(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "export.tar.gz")
+	opts := Options{Sample: 5}
+
+	state, err := openExportState(outputPath, opts, false)
+	if err != nil {
+		t.Fatalf("openExportState: %v", err)
+	}
+	batches := []EntityBatch{
+		{Blueprint: "service", Entities: []api.Entity{{"identifier": "a"}}},
+		{Blueprint: "team/core", Entities: []api.Entity{{"identifier": "t"}}},
+		{Blueprint: "service", Entities: []api.Entity{{"identifier": "b"}}, Done: true},
+	}
+	for _, batch := range batches {
+		if err := state.add(batch); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	state.close() // interrupted before team/core finished
+
+	resumed, err := openExportState(outputPath, opts, true)
+	if err != nil {
+		t.Fatalf("openExportState with resume: %v", err)
+	}
+	if len(resumed.Completed) != 1 || resumed.Completed["service"] != 2 {
+		t.Fatalf("completed = %v, want service with 2 entities", resumed.Completed)
+	}
+	var ids []string
+	if err := resumed.forEachEntity("service", func(entity api.Entity) error {
+		ids = append(ids, entity["identifier"].(string))
+		return nil
+	}); err != nil {
+		t.Fatalf("forEachEntity: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("spooled entities = %v, want [a b]", ids)
+	}
+
+	if _, err := openExportState(outputPath, Options{Sample: 10}, true); err == nil {
+		t.Error("expected resuming with different entity options to fail")
+	}
+
+	fresh, err := openExportState(outputPath, opts, false)
+	if err != nil {
+		t.Fatalf("openExportState: %v", err)
+	}
+	if len(fresh.Completed) != 0 {
+		t.Errorf("expected a fresh state without --resume, got %v", fresh.Completed)
+	}
+	if err := fresh.remove(); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := os.Stat(outputPath + ExportStateSuffix); !os.IsNotExist(err) {
+		t.Errorf("state directory still exists: %v", err)
+	}
+}
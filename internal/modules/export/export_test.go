@@ -8,7 +8,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/port-experimental/port-cli/internal/api"
@@ -55,6 +57,22 @@ func TestOptions_Validate(t *testing.T) {
 	}
 }
 
+func TestOptions_Selection(t *testing.T) {
+	if got := (&Options{OutputPath: "out.json", Format: "json", Sort: true}).selection(); len(got) != 0 {
+		t.Errorf("expected a full export to have no selection, got %v", got)
+	}
+	opts := Options{
+		SkipEntitiesFor:   []string{"k8s-*"},
+		BlueprintPatterns: []string{"team-*"},
+		EntityFilters:     map[string]map[string]interface{}{"service": {}},
+		Entities:          []string{"svc"},
+	}
+	want := []string{"--only", "--skip-entities-for", "--entity-filter", "--entities"}
+	if got := opts.selection(); !reflect.DeepEqual(got, want) {
+		t.Errorf("selection = %v, want %v", got, want)
+	}
+}
+
 func TestNewCollector(t *testing.T) {
 	// This is a simple test to ensure NewCollector doesn't panic
 	// We can't test collection without a real API client
@@ -534,3 +552,224 @@ func TestExecute_BlueprintsExplicit_KeepsFullSetAlongsideActions(t *testing.T) {
 // 1. A test Port organization
 // 2. Valid credentials
 // 3. Mock HTTP server or test fixtures
+
+func TestExecute_SampleStopsAfterNEntitiesPerBlueprint(t *testing.T) {
+	searchCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}},
+			})
+		case "/blueprints/service/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 10001})
+		case "/blueprints/service/entities/search":
+			searchCalls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"next": "cursor",
+				"entities": []map[string]interface{}{
+					{"identifier": "svc-1", "blueprint": "service"},
+					{"identifier": "svc-2", "blueprint": "service"},
+					{"identifier": "svc-3", "blueprint": "service"},
+				},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	outputPath := filepath.Join(t.TempDir(), "export.json")
+	result, err := module.Execute(context.Background(), Options{
+		OutputPath:       outputPath,
+		Format:           "json",
+		IncludeResources: []string{"entities"},
+		Sample:           2,
+	})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("export failed: %v", result.Error)
+	}
+	if result.EntitiesCount != 2 || result.Sample != 2 {
+		t.Fatalf("expected 2 sampled entities, got %d (sample %d)", result.EntitiesCount, result.Sample)
+	}
+	if searchCalls != 1 {
+		t.Fatalf("expected pagination to stop after the first page, got %d search calls", searchCalls)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var parsed struct {
+		Entities []map[string]interface{} `json:"entities"`
+		Manifest Manifest                 `json:"_manifest"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("output JSON was invalid: %v", err)
+	}
+	if len(parsed.Entities) != 2 || parsed.Manifest.Sample != 2 {
+		t.Fatalf("expected 2 entities and a sample manifest, got %d entities and %+v", len(parsed.Entities), parsed.Manifest)
+	}
+	if !reflect.DeepEqual(parsed.Manifest.Selection, []string{"--include"}) {
+		t.Fatalf("expected the manifest to record --include, got %v", parsed.Manifest.Selection)
+	}
+}
+
+func TestExecute_DryRunCountsWithoutWriting(t *testing.T) {
+	var entityFetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.URL.Path == "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}, {"identifier": "team"}},
+			})
+		case r.URL.Path == "/blueprints/service/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 120})
+		case r.URL.Path == "/blueprints/team/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 3})
+		case strings.Contains(r.URL.Path, "/entities"):
+			entityFetches++
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []interface{}{}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	outputPath := filepath.Join(t.TempDir(), "export.tar.gz")
+	result, err := module.Execute(context.Background(), Options{
+		OutputPath:       outputPath,
+		IncludeResources: []string{"blueprints", "entities"},
+		Sample:           50,
+		DryRun:           true,
+	})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !result.Success || !result.DryRun {
+		t.Fatalf("expected a successful dry run, got %+v", result)
+	}
+	if result.BlueprintsCount != 2 || result.EntitiesCount != 53 || result.EntitiesApproximate {
+		t.Errorf("counts = %d blueprints, %d entities (approximate %v); want 2 and 50+3 exact", result.BlueprintsCount, result.EntitiesCount, result.EntitiesApproximate)
+	}
+	if result.Format != "tar" || result.OutputPath != outputPath {
+		t.Errorf("destination = %s (%s), want %s (tar)", result.OutputPath, result.Format, outputPath)
+	}
+	if entityFetches != 0 {
+		t.Errorf("dry run fetched entities %d time(s), want only counts", entityFetches)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote %s", outputPath)
+	}
+
+	result, err = module.Execute(context.Background(), Options{
+		OutputPath:       outputPath,
+		IncludeResources: []string{"entities"},
+		Entities:         []string{"checkout"},
+		DryRun:           true,
+	})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !result.EntitiesApproximate {
+		t.Error("expected an entity ID filter to make the count approximate")
+	}
+}
+
+func TestExecute_ResumeSkipsCompletedBlueprints(t *testing.T) {
+	var mu sync.Mutex
+	entityCalls := map[string]int{}
+	teamFails := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service"}, {"identifier": "team"}},
+			})
+		case "/blueprints/service/entities-count", "/blueprints/team/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 1})
+		case "/blueprints/service/entities", "/blueprints/team/entities":
+			bpID := strings.Split(r.URL.Path, "/")[2]
+			mu.Lock()
+			entityCalls[bpID]++
+			fail := bpID == "team" && teamFails
+			mu.Unlock()
+			if fail {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "forbidden"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"entities": []map[string]interface{}{{"identifier": bpID + "-1", "blueprint": bpID}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := &Module{client: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	outputPath := filepath.Join(t.TempDir(), "export.json")
+	opts := Options{OutputPath: outputPath, Format: "json", IncludeResources: []string{"entities"}}
+
+	result, err := module.Execute(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result.Success {
+		t.Fatal("expected the first export to fail on blueprint team")
+	}
+	if _, err := os.Stat(outputPath + ExportStateSuffix); err != nil {
+		t.Fatalf("expected export state to be kept: %v", err)
+	}
+
+	mu.Lock()
+	teamFails = false
+	mu.Unlock()
+	opts.Resume = true
+	result, err = module.Execute(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("resumed export failed: %v", result.Error)
+	}
+	if result.EntitiesCount != 2 || result.ResumedBlueprints != 1 {
+		t.Errorf("entities = %d, resumed = %d; want 2 and 1", result.EntitiesCount, result.ResumedBlueprints)
+	}
+	if entityCalls["service"] != 1 {
+		t.Errorf("service entities fetched %d time(s), want once", entityCalls["service"])
+	}
+	if _, err := os.Stat(outputPath + ExportStateSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected export state to be removed after a successful export, got %v", err)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var parsed struct {
+		Entities []map[string]interface{} `json:"entities"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("output JSON was invalid: %v", err)
+	}
+	if len(parsed.Entities) != 2 || parsed.Entities[0]["identifier"] != "service-1" || parsed.Entities[1]["identifier"] != "team-1" {
+		t.Errorf("entities = %v, want service-1 then team-1", parsed.Entities)
+	}
+}
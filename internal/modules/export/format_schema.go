@@ -0,0 +1,162 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatSection describes one top-level key of an exported JSON document.
+// The same table generates FormatSchema and drives ValidateFormat, so the
+// published contract and the check applied on import cannot drift apart.
+type formatSection struct {
+	key         string
+	description string
+	// keyed is true for objects keyed by identifier; otherwise the section
+	// is an array of objects.
+	keyed bool
+	// required lists the fields every item must have.
+	required []string
+}
+
+var formatSections = []formatSection{
+	{key: "blueprints", description: "Blueprint definitions", required: []string{"identifier"}},
+	{key: "entities", description: "Entities of every exported blueprint", required: []string{"identifier", "blueprint"}},
+	{key: "scorecards", description: "Scorecards", required: []string{"identifier", "blueprintIdentifier"}},
+	{key: "actions", description: "Self-service actions and automations", required: []string{"identifier"}},
+	{key: "automations", description: "Deprecated: automations from older exports, read as actions", required: []string{"identifier"}},
+	{key: "teams", description: "Teams", required: []string{"name"}},
+	{key: "users", description: "Users", required: []string{"email"}},
+	{key: "_folders", description: "Sidebar folders", required: []string{"identifier"}},
+	{key: "pages", description: "Pages", required: []string{"identifier"}},
+	{key: "integrations", description: "Integration configurations", required: []string{"identifier"}},
+	{key: "datasources", description: "Webhook and ingest data sources that feed blueprints", required: []string{"identifier"}},
+	{key: "blueprint_permissions", description: "Permissions by blueprint identifier", keyed: true},
+	{key: "action_permissions", description: "Permissions by action identifier", keyed: true},
+	{key: "page_permissions", description: "Permissions by page identifier", keyed: true},
+	{key: DeletionsResource, description: "Resources a delta bundle removes, applied by port import --prune", required: []string{"type", "identifier"}},
+}
+
+// FormatSchema returns a JSON Schema describing an exported JSON document: a
+// single object whose top-level keys hold each resource type. Tar archives
+// and NDJSON exports carry the same sections as separate files or records.
+// Unknown keys are allowed so newer exports stay readable.
+func FormatSchema() map[string]interface{} {
+	properties := make(map[string]interface{}, len(formatSections)+1)
+	for _, section := range formatSections {
+		if section.keyed {
+			properties[section.key] = map[string]interface{}{
+				"description":          section.description,
+				"type":                 "object",
+				"additionalProperties": map[string]interface{}{"type": "object"},
+			}
+			continue
+		}
+		itemProperties := make(map[string]interface{}, len(section.required))
+		for _, field := range section.required {
+			itemProperties[field] = map[string]interface{}{"type": "string", "minLength": 1}
+		}
+		properties[section.key] = map[string]interface{}{
+			"description": section.description,
+			"type":        "array",
+			"items": map[string]interface{}{
+				"type":       "object",
+				"required":   section.required,
+				"properties": itemProperties,
+			},
+		}
+	}
+	properties[ManifestResource] = map[string]interface{}{
+		"description": "How the export was produced",
+		"type":        "object",
+		"properties": map[string]interface{}{
+			"anonymized": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Entity data was replaced by placeholders",
+			},
+			"delta": map[string]interface{}{
+				"type":        "boolean",
+				"description": "The bundle holds only the changes between two exports",
+			},
+			"cliVersion": map[string]interface{}{
+				"type":        "string",
+				"description": "Version of the port CLI that wrote the export",
+			},
+			"sample": map[string]interface{}{
+				"type":        "integer",
+				"description": "Entities kept per blueprint by --sample; absent for a full export",
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "Port CLI export",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": true,
+	}
+}
+
+// FormatError lists the ways a document does not match FormatSchema.
+type FormatError struct {
+	Problems []string
+}
+
+// maxFormatProblems caps how many problems FormatError.Error lists.
+const maxFormatProblems = 10
+
+func (e *FormatError) Error() string {
+	problems := e.Problems
+	suffix := ""
+	if len(problems) > maxFormatProblems {
+		suffix = fmt.Sprintf(" (and %d more)", len(problems)-maxFormatProblems)
+		problems = problems[:maxFormatProblems]
+	}
+	return fmt.Sprintf("export does not match the export format: %s%s", strings.Join(problems, "; "), suffix)
+}
+
+// ValidateFormat checks the top-level structure of a decoded export document
+// against FormatSchema: each known section has the right type and each item
+// is an object with its required fields. It returns a *FormatError listing
+// every problem, or nil.
+func ValidateFormat(doc map[string]interface{}) error {
+	var problems []string
+	for _, section := range formatSections {
+		value, ok := doc[section.key]
+		if !ok || value == nil {
+			continue
+		}
+		if section.keyed {
+			if _, isObject := value.(map[string]interface{}); !isObject {
+				problems = append(problems, fmt.Sprintf("%s must be an object", section.key))
+			}
+			continue
+		}
+		items, isArray := value.([]interface{})
+		if !isArray {
+			problems = append(problems, fmt.Sprintf("%s must be an array", section.key))
+			continue
+		}
+		for i, item := range items {
+			obj, isObject := item.(map[string]interface{})
+			if !isObject {
+				problems = append(problems, fmt.Sprintf("%s[%d] must be an object", section.key, i))
+				continue
+			}
+			for _, field := range section.required {
+				if s, _ := obj[field].(string); s == "" {
+					problems = append(problems, fmt.Sprintf("%s[%d] is missing %s", section.key, i, field))
+				}
+			}
+		}
+	}
+	if manifest, ok := doc[ManifestResource]; ok && manifest != nil {
+		if _, isObject := manifest.(map[string]interface{}); !isObject {
+			problems = append(problems, fmt.Sprintf("%s must be an object", ManifestResource))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &FormatError{Problems: problems}
+}
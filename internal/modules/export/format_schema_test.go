@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatSchema_DescribesEverySection(t *testing.T) {
+	schema := FormatSchema()
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("schema is not serializable: %v", err)
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"blueprints", "entities", "scorecards", "actions", "teams", "users", "pages", "integrations", "blueprint_permissions", ManifestResource} {
+		if _, ok := properties[key]; !ok {
+			t.Errorf("schema is missing %s", key)
+		}
+	}
+	entities, _ := properties["entities"].(map[string]interface{})
+	items, _ := entities["items"].(map[string]interface{})
+	required, _ := items["required"].([]string)
+	if strings.Join(required, ",") != "identifier,blueprint" {
+		t.Errorf("unexpected entity required fields %v", required)
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	valid := map[string]interface{}{
+		"blueprints":            []interface{}{map[string]interface{}{"identifier": "service"}},
+		"entities":              []interface{}{map[string]interface{}{"identifier": "api", "blueprint": "service"}},
+		"blueprint_permissions": map[string]interface{}{"service": map[string]interface{}{}},
+		"someFutureSection":     "ignored",
+	}
+	if err := ValidateFormat(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]interface{}{
+		"blueprints":            map[string]interface{}{"identifier": "service"},
+		"entities":              []interface{}{map[string]interface{}{"identifier": "api"}, "not-an-object"},
+		"blueprint_permissions": []interface{}{},
+	}
+	err := ValidateFormat(invalid)
+	var formatErr *FormatError
+	if !errors.As(err, &formatErr) {
+		t.Fatalf("expected a FormatError, got %v", err)
+	}
+	want := []string{
+		"blueprints must be an array",
+		"entities[0] is missing blueprint",
+		"entities[1] must be an object",
+		"blueprint_permissions must be an object",
+	}
+	if strings.Join(formatErr.Problems, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected problems:\n got %v\nwant %v", formatErr.Problems, want)
+	}
+}
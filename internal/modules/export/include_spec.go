@@ -0,0 +1,147 @@
+package export
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// IncludeSpec is a parsed --include value. Each comma-separated entry names a
+// resource type, optionally followed by ":glob" to keep only the resources of
+// that type whose identifier matches, e.g. "blueprints,scorecards:team-*".
+type IncludeSpec struct {
+	// Resources lists the included resource types, in order and without
+	// duplicates, as Options.IncludeResources expects them.
+	Resources []string
+	// Patterns maps a resource type to its identifier globs. Types listed
+	// without a glob anywhere in the value have no entry and are included in
+	// full.
+	Patterns map[string][]string
+}
+
+// PermissionsResource is the --include shorthand for every permissions
+// resource type: blueprint and action permissions, and page permissions when
+// pages are included too.
+const PermissionsResource = "permissions"
+
+// ParseIncludeSpec parses an --include value, expanding PermissionsResource
+// into the permission types it stands for. Resource type names are not
+// checked here; malformed globs are reported as errors.
+func ParseIncludeSpec(value string) (IncludeSpec, error) {
+	spec := IncludeSpec{Patterns: make(map[string][]string)}
+	seen := make(map[string]bool)
+	unrestricted := make(map[string]bool)
+	entries := strings.Split(value, ",")
+	permissionTypes := []string{"blueprint-permissions", "action-permissions"}
+	for _, entry := range entries {
+		if resourceType, _, _ := strings.Cut(entry, ":"); strings.TrimSpace(resourceType) == "pages" {
+			permissionTypes = append(permissionTypes, "page-permissions")
+			break
+		}
+	}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		resourceType, pattern, hasPattern := strings.Cut(entry, ":")
+		resourceType = strings.TrimSpace(resourceType)
+		pattern = strings.TrimSpace(pattern)
+		if hasPattern && pattern == "" {
+			return IncludeSpec{}, fmt.Errorf("empty pattern for %s", resourceType)
+		}
+		if hasPattern {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return IncludeSpec{}, fmt.Errorf("invalid pattern %q for %s: %w", pattern, resourceType, err)
+			}
+		}
+		resourceTypes := []string{resourceType}
+		if resourceType == PermissionsResource {
+			resourceTypes = permissionTypes
+		}
+		for _, resourceType := range resourceTypes {
+			if !seen[resourceType] {
+				seen[resourceType] = true
+				spec.Resources = append(spec.Resources, resourceType)
+			}
+			if !hasPattern {
+				unrestricted[resourceType] = true
+				continue
+			}
+			spec.Patterns[resourceType] = append(spec.Patterns[resourceType], pattern)
+		}
+	}
+	for resourceType := range unrestricted {
+		delete(spec.Patterns, resourceType)
+	}
+	return spec, nil
+}
+
+// includeIdentifierFields names the field an include pattern is matched
+// against for resource types not identified by "identifier".
+var includeIdentifierFields = map[string]string{
+	"teams":        "name",
+	"users":        "email",
+	"integrations": "installationId",
+}
+
+// IncludesIdentifier reports whether the resource of resourceType with the
+// given identifier passes the include patterns. Resource types without
+// patterns include every identifier. Automations are collected together with
+// actions, so patterns given for either type apply to both.
+func IncludesIdentifier(patterns map[string][]string, resourceType, identifier string) bool {
+	globs := patterns[resourceType]
+	if resourceType == "actions" || resourceType == "automations" {
+		globs = append(append([]string{}, patterns["actions"]...), patterns["automations"]...)
+	}
+	if len(globs) == 0 {
+		return true
+	}
+	return matchesAny(identifier, globs)
+}
+
+// FilterByIncludePatterns drops every resource in data whose identifier does
+// not pass the include patterns. Permissions are matched by the identifier
+// they are keyed by.
+func FilterByIncludePatterns(data *Data, patterns map[string][]string) {
+	if len(patterns) == 0 {
+		return
+	}
+	data.Blueprints = filterIncluded(data.Blueprints, patterns, "blueprints")
+	data.Entities = filterIncluded(data.Entities, patterns, "entities")
+	data.Scorecards = filterIncluded(data.Scorecards, patterns, "scorecards")
+	data.Actions = filterIncluded(data.Actions, patterns, "actions")
+	data.Teams = filterIncluded(data.Teams, patterns, "teams")
+	data.Users = filterIncluded(data.Users, patterns, "users")
+	data.Pages = filterIncluded(data.Pages, patterns, "pages")
+	data.Integrations = filterIncluded(data.Integrations, patterns, "integrations")
+	data.DataSources = filterIncluded(data.DataSources, patterns, "datasources")
+	filterIncludedPermissions(data.BlueprintPermissions, patterns, "blueprint-permissions")
+	filterIncludedPermissions(data.ActionPermissions, patterns, "action-permissions")
+	filterIncludedPermissions(data.PagePermissions, patterns, "page-permissions")
+}
+
+func filterIncluded[T ~map[string]interface{}](items []T, patterns map[string][]string, resourceType string) []T {
+	field := includeIdentifierFields[resourceType]
+	if field == "" {
+		field = "identifier"
+	}
+	out := items[:0:0]
+	for _, item := range items {
+		id, _ := item[field].(string)
+		if IncludesIdentifier(patterns, resourceType, id) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func filterIncludedPermissions(perms map[string]api.Permissions, patterns map[string][]string, resourceType string) {
+	for id := range perms {
+		if !IncludesIdentifier(patterns, resourceType, id) {
+			delete(perms, id)
+		}
+	}
+}
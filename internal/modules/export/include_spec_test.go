@@ -0,0 +1,118 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestParseIncludeSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantResources []string
+		wantPatterns  map[string][]string
+	}{
+		{
+			name:          "plain list",
+			value:         "blueprints, pages",
+			wantResources: []string{"blueprints", "pages"},
+			wantPatterns:  map[string][]string{},
+		},
+		{
+			name:          "per-type globs",
+			value:         "blueprints,scorecards:team-*,scorecards:svc-?",
+			wantResources: []string{"blueprints", "scorecards"},
+			wantPatterns:  map[string][]string{"scorecards": {"team-*", "svc-?"}},
+		},
+		{
+			name:          "plain entry includes the whole type",
+			value:         "scorecards:team-*,scorecards",
+			wantResources: []string{"scorecards"},
+			wantPatterns:  map[string][]string{},
+		},
+		{
+			name:          "permissions shorthand",
+			value:         "blueprints,permissions:svc-*",
+			wantResources: []string{"blueprints", "blueprint-permissions", "action-permissions"},
+			wantPatterns:  map[string][]string{"blueprint-permissions": {"svc-*"}, "action-permissions": {"svc-*"}},
+		},
+		{
+			name:          "permissions shorthand covers pages when included",
+			value:         "permissions,pages",
+			wantResources: []string{"blueprint-permissions", "action-permissions", "page-permissions", "pages"},
+			wantPatterns:  map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseIncludeSpec(tt.value)
+			if err != nil {
+				t.Fatalf("ParseIncludeSpec(%q): %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(spec.Resources, tt.wantResources) {
+				t.Errorf("Resources = %v, want %v", spec.Resources, tt.wantResources)
+			}
+			if !reflect.DeepEqual(spec.Patterns, tt.wantPatterns) {
+				t.Errorf("Patterns = %v, want %v", spec.Patterns, tt.wantPatterns)
+			}
+		})
+	}
+}
+
+func TestParseIncludeSpec_RejectsBadPatterns(t *testing.T) {
+	for _, value := range []string{"scorecards:", "scorecards:[team"} {
+		if _, err := ParseIncludeSpec(value); err == nil {
+			t.Errorf("ParseIncludeSpec(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestIncludesIdentifier(t *testing.T) {
+	patterns := map[string][]string{"scorecards": {"team-*"}, "automations": {"auto-*"}}
+	cases := []struct {
+		resourceType, id string
+		want             bool
+	}{
+		{"scorecards", "team-readiness", true},
+		{"scorecards", "security", false},
+		{"blueprints", "anything", true},
+		{"actions", "auto-sync", true},
+		{"actions", "deploy", false},
+	}
+	for _, c := range cases {
+		if got := IncludesIdentifier(patterns, c.resourceType, c.id); got != c.want {
+			t.Errorf("IncludesIdentifier(%s, %s) = %v, want %v", c.resourceType, c.id, got, c.want)
+		}
+	}
+}
+
+func TestFilterByIncludePatterns(t *testing.T) {
+	data := &Data{
+		Blueprints: []api.Blueprint{{"identifier": "service"}, {"identifier": "team"}},
+		Scorecards: []api.Scorecard{{"identifier": "team-ready"}, {"identifier": "security"}},
+		Teams:      []api.Team{{"name": "platform"}, {"name": "sales"}},
+		BlueprintPermissions: map[string]api.Permissions{
+			"service": {}, "team": {},
+		},
+	}
+	FilterByIncludePatterns(data, map[string][]string{
+		"scorecards":            {"team-*"},
+		"teams":                 {"plat*"},
+		"blueprint-permissions": {"service"},
+	})
+
+	if len(data.Blueprints) != 2 {
+		t.Errorf("blueprints = %v, want both (no pattern)", data.Blueprints)
+	}
+	if len(data.Scorecards) != 1 || data.Scorecards[0]["identifier"] != "team-ready" {
+		t.Errorf("scorecards = %v, want only team-ready", data.Scorecards)
+	}
+	if len(data.Teams) != 1 || data.Teams[0]["name"] != "platform" {
+		t.Errorf("teams = %v, want only platform (matched by name)", data.Teams)
+	}
+	if _, ok := data.BlueprintPermissions["team"]; ok || len(data.BlueprintPermissions) != 1 {
+		t.Errorf("blueprint permissions = %v, want only service", data.BlueprintPermissions)
+	}
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// sortByFields stably orders items by the string values of fields, compared
+// in turn. Items missing a field sort before those that have it.
+func sortByFields[T ~map[string]interface{}](items []T, fields ...string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, field := range fields {
+			a, _ := items[i][field].(string)
+			b, _ := items[j][field].(string)
+			if a != b {
+				return a < b
+			}
+		}
+		return false
+	})
+}
+
+// identifierField returns the field resources of resourceType are identified
+// by.
+func identifierField(resourceType string) string {
+	if field, ok := includeIdentifierFields[resourceType]; ok {
+		return field
+	}
+	return "identifier"
+}
+
+// SortData orders every resource list in data by identifier (teams by name,
+// users by email and integrations by installation ID), and scorecards by
+// blueprint first, so two exports of the same organization diff cleanly.
+// Entities are streamed separately; see sortEntities.
+func SortData(data *Data) {
+	sortByFields(data.Blueprints, identifierField("blueprints"))
+	sortByFields(data.Scorecards, "blueprint", identifierField("scorecards"))
+	sortByFields(data.Actions, identifierField("actions"))
+	sortByFields(data.Teams, identifierField("teams"))
+	sortByFields(data.Users, identifierField("users"))
+	sortByFields(data.Folders, identifierField("folders"))
+	sortByFields(data.Pages, identifierField("pages"))
+	sortByFields(data.Integrations, identifierField("integrations"))
+	sortByFields(data.DataSources, identifierField("datasources"))
+	sortEntities(data.Entities)
+}
+
+// sortEntities orders entities by blueprint, then identifier.
+func sortEntities(entities []api.Entity) {
+	sortByFields(entities, "blueprint", "identifier")
+}
+
+// sortedSpooledEntities reads the spooled entities of bpID into memory,
+// applies transform and yields them by identifier. Only one blueprint's
+// entities are held at a time.
+func sortedSpooledEntities(state *exportState, bpID string, transform func(api.Entity) api.Entity, yield func(api.Entity) error) error {
+	entities := make([]api.Entity, 0, state.Completed[bpID])
+	if err := state.forEachEntity(bpID, func(entity api.Entity) error {
+		entities = append(entities, transform(entity))
+		return nil
+	}); err != nil {
+		return err
+	}
+	sortEntities(entities)
+	for _, entity := range entities {
+		if err := yield(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestSortData(t *testing.T) {
+	data := &Data{
+		Blueprints: []api.Blueprint{{"identifier": "team"}, {"identifier": "service"}},
+		Entities: []api.Entity{
+			{"blueprint": "team", "identifier": "a"},
+			{"blueprint": "service", "identifier": "b"},
+			{"blueprint": "service", "identifier": "a"},
+		},
+		Scorecards: []api.Scorecard{
+			{"blueprint": "team", "identifier": "health"},
+			{"blueprint": "service", "identifier": "security"},
+			{"blueprint": "service", "identifier": "health"},
+		},
+		Teams: []api.Team{{"name": "platform"}, {"name": "data"}},
+		Users: []api.User{{"email": "z@example.com"}, {"email": "a@example.com"}},
+		Pages: []api.Page{
+			{"identifier": "services", "after": "home"},
+			{"identifier": "home"},
+		},
+		Integrations: []api.Integration{{"installationId": "k8s"}, {"installationId": "github"}},
+	}
+
+	SortData(data)
+
+	checks := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"blueprints", sortKeys(data.Blueprints, "identifier"), []string{"service", "team"}},
+		{"entities", sortKeys(data.Entities, "blueprint", "identifier"), []string{"service/a", "service/b", "team/a"}},
+		{"scorecards", sortKeys(data.Scorecards, "blueprint", "identifier"), []string{"service/health", "service/security", "team/health"}},
+		{"teams", sortKeys(data.Teams, "name"), []string{"data", "platform"}},
+		{"users", sortKeys(data.Users, "email"), []string{"a@example.com", "z@example.com"}},
+		{"pages", sortKeys(data.Pages, "identifier"), []string{"home", "services"}},
+		{"integrations", sortKeys(data.Integrations, "installationId"), []string{"github", "k8s"}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if data.Pages[1]["after"] != "home" {
+		t.Error("sorting changed a page's fields")
+	}
+}
+
+// sortKeys joins the given fields of each item with "/", in order.
+func sortKeys[T ~map[string]interface{}](items []T, fields ...string) []string {
+	var keys []string
+	for _, item := range items {
+		values := make([]string, len(fields))
+		for i, field := range fields {
+			values[i], _ = item[field].(string)
+		}
+		keys = append(keys, strings.Join(values, "/"))
+	}
+	return keys
+}
@@ -0,0 +1,186 @@
+package import_module
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// ParseActionURLMap parses --action-url-map values of the form
+// "source=target" into a source -> target map. A source is a URL host
+// (hooks.prod.example.com), a URL prefix (https://hooks.prod.example.com/port)
+// or a plain value such as a GitHub org or repository name.
+func ParseActionURLMap(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	urlMap := make(map[string]string, len(values))
+	for _, value := range values {
+		source, target, ok := strings.Cut(value, "=")
+		source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+		if !ok || source == "" || target == "" {
+			return nil, fmt.Errorf("invalid action URL mapping %q: expected source=target", value)
+		}
+		if existing, dup := urlMap[source]; dup && existing != target {
+			return nil, fmt.Errorf("%q is mapped to both %q and %q", source, existing, target)
+		}
+		urlMap[source] = target
+	}
+	return urlMap, nil
+}
+
+// RewriteActionInvocations returns actions with the environment-specific
+// values in each invocationMethod rewritten through urlMap: webhook URLs,
+// GitHub and GitLab orgs, repositories and projects, and the like. Actions
+// are copied only when something changes, so the input is left untouched.
+func RewriteActionInvocations(actions []api.Action, urlMap map[string]string) []api.Action {
+	if len(urlMap) == 0 || actions == nil {
+		return actions
+	}
+	rewritten := make([]api.Action, len(actions))
+	for i, action := range actions {
+		rewritten[i] = action
+		method, ok := action["invocationMethod"]
+		if !ok {
+			continue
+		}
+		if value, changed := rewriteInvocationValue(method, urlMap); changed {
+			out := make(api.Action, len(action))
+			for k, v := range action {
+				out[k] = v
+			}
+			out["invocationMethod"] = value
+			rewritten[i] = out
+		}
+	}
+	return rewritten
+}
+
+// rewriteInvocationValue copies value, rewriting every string it holds, and
+// reports whether anything changed.
+func rewriteInvocationValue(value interface{}, urlMap map[string]string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		return rewriteInvocationString(v, urlMap)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		changed := false
+		for k, child := range v {
+			var childChanged bool
+			out[k], childChanged = rewriteInvocationValue(child, urlMap)
+			changed = changed || childChanged
+		}
+		return out, changed
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		changed := false
+		for i, child := range v {
+			var childChanged bool
+			out[i], childChanged = rewriteInvocationValue(child, urlMap)
+			changed = changed || childChanged
+		}
+		return out, changed
+	default:
+		return value, false
+	}
+}
+
+// rewriteInvocationString rewrites s when it equals a source, when it is a
+// URL whose host is a source, or when it starts with a source URL prefix.
+// The longest matching prefix wins.
+func rewriteInvocationString(s string, urlMap map[string]string) (string, bool) {
+	if target, ok := urlMap[s]; ok {
+		return target, true
+	}
+	if u, err := url.Parse(s); err == nil && u.Host != "" {
+		if target, ok := urlMap[u.Host]; ok {
+			u.Host = target
+			return u.String(), true
+		}
+	}
+	best := ""
+	for source := range urlMap {
+		if strings.Contains(source, "://") && strings.HasPrefix(s, source) && len(source) > len(best) {
+			best = source
+		}
+	}
+	if best != "" {
+		return urlMap[best] + strings.TrimPrefix(s, best), true
+	}
+	return s, false
+}
+
+// actionURLMapWarning warns about actions whose invocationMethod would still
+// mention a --action-url-map source after rewriting, e.g. a source host
+// embedded in a template string, as they would call the source environment.
+func actionURLMapWarning(warnings []ValidationWarning, leftover []string) []ValidationWarning {
+	if len(leftover) == 0 {
+		return warnings
+	}
+	return append(warnings, ValidationWarning{
+		Type:    "action_url_map",
+		Message: fmt.Sprintf("%d action(s) still reference a source mapped by --action-url-map in their invocation method", len(leftover)),
+		Details: leftover,
+	})
+}
+
+// ActionURLMapLeftovers checks actions before RewriteActionInvocations. For
+// each action with a string in its invocationMethod that contains a urlMap
+// source but would not be rewritten, it returns a line naming the action and
+// the source.
+func ActionURLMapLeftovers(actions []api.Action, urlMap map[string]string) []string {
+	if len(urlMap) == 0 {
+		return nil
+	}
+	sources := make([]string, 0, len(urlMap))
+	for source := range urlMap {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var leftover []string
+	for _, action := range actions {
+		var values []string
+		collectInvocationStrings(action["invocationMethod"], &values)
+		sort.Strings(values)
+		if source := leftoverSource(values, sources, urlMap); source != "" {
+			identifier, _ := action["identifier"].(string)
+			leftover = append(leftover, fmt.Sprintf("%s: %s", identifier, source))
+		}
+	}
+	return leftover
+}
+
+// leftoverSource returns the first source contained in a value that
+// rewriteInvocationString leaves unchanged, or "".
+func leftoverSource(values, sources []string, urlMap map[string]string) string {
+	for _, v := range values {
+		if _, changed := rewriteInvocationString(v, urlMap); changed {
+			continue
+		}
+		for _, source := range sources {
+			if strings.Contains(v, source) {
+				return source
+			}
+		}
+	}
+	return ""
+}
+
+func collectInvocationStrings(value interface{}, values *[]string) {
+	switch v := value.(type) {
+	case string:
+		*values = append(*values, v)
+	case map[string]interface{}:
+		for _, child := range v {
+			collectInvocationStrings(child, values)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectInvocationStrings(child, values)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package import_module
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestParseActionURLMap(t *testing.T) {
+	urlMap, err := ParseActionURLMap([]string{"hooks.prod.example.com=hooks.staging.example.com", " acme = acme-staging "})
+	if err != nil {
+		t.Fatalf("ParseActionURLMap: %v", err)
+	}
+	want := map[string]string{"hooks.prod.example.com": "hooks.staging.example.com", "acme": "acme-staging"}
+	if !reflect.DeepEqual(urlMap, want) {
+		t.Errorf("ParseActionURLMap = %v, want %v", urlMap, want)
+	}
+
+	for _, values := range [][]string{{"acme"}, {"=acme"}, {"acme=a", "acme=b"}} {
+		if _, err := ParseActionURLMap(values); err == nil {
+			t.Errorf("ParseActionURLMap(%q): expected an error", values)
+		}
+	}
+}
+
+func TestRewriteActionInvocations(t *testing.T) {
+	urlMap := map[string]string{
+		"hooks.prod.example.com":              "hooks.staging.example.com",
+		"https://ci.example.com/prod":         "https://ci.example.com/staging",
+		"acme":                                "acme-staging",
+		"https://ci.example.com/prod/private": "https://ci.example.com/staging/private",
+	}
+	actions := []api.Action{
+		{"identifier": "deploy", "invocationMethod": map[string]interface{}{
+			"type": "WEBHOOK", "url": "https://hooks.prod.example.com/deploy?env=prod", "synchronized": true,
+		}},
+		{"identifier": "build", "invocationMethod": map[string]interface{}{
+			"type": "GITHUB", "org": "acme", "repo": "infra", "workflow": "build.yml",
+		}},
+		{"identifier": "notify", "invocationMethod": map[string]interface{}{
+			"type": "WEBHOOK", "url": "https://ci.example.com/prod/private/notify",
+		}},
+		{"identifier": "manual", "title": "Manual"},
+	}
+
+	rewritten := RewriteActionInvocations(actions, urlMap)
+
+	wantMethods := []interface{}{
+		map[string]interface{}{"type": "WEBHOOK", "url": "https://hooks.staging.example.com/deploy?env=prod", "synchronized": true},
+		map[string]interface{}{"type": "GITHUB", "org": "acme-staging", "repo": "infra", "workflow": "build.yml"},
+		map[string]interface{}{"type": "WEBHOOK", "url": "https://ci.example.com/staging/private/notify"},
+		nil,
+	}
+	for i, want := range wantMethods {
+		if got := rewritten[i]["invocationMethod"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: invocationMethod = %v, want %v", actions[i]["identifier"], got, want)
+		}
+	}
+	if actions[1]["invocationMethod"].(map[string]interface{})["org"] != "acme" {
+		t.Error("the input actions were modified")
+	}
+	if rewritten[3]["title"] != "Manual" {
+		t.Error("an action without an invocation method was changed")
+	}
+}
+
+func TestActionURLMapLeftovers(t *testing.T) {
+	urlMap := map[string]string{"hooks.prod.example.com": "hooks.staging.example.com", "acme": "acme-staging"}
+	actions := []api.Action{
+		{"identifier": "deploy", "invocationMethod": map[string]interface{}{
+			"type": "WEBHOOK", "url": "https://hooks.prod.example.com/deploy",
+		}},
+		{"identifier": "templated", "invocationMethod": map[string]interface{}{
+			"type": "WEBHOOK", "url": "{{ \"https://hooks.prod.example.com/\" + .inputs.path }}",
+		}},
+		{"identifier": "build", "invocationMethod": map[string]interface{}{
+			"type": "GITHUB", "org": "acme", "workflowInputs": map[string]interface{}{"repo": "acme/infra"},
+		}},
+	}
+
+	leftover := ActionURLMapLeftovers(actions, urlMap)
+	want := []string{"templated: hooks.prod.example.com", "build: acme"}
+	if !reflect.DeepEqual(leftover, want) {
+		t.Errorf("ActionURLMapLeftovers = %v, want %v", leftover, want)
+	}
+
+	warnings := actionURLMapWarning(nil, leftover)
+	if len(warnings) != 1 || warnings[0].Type != "action_url_map" || !strings.Contains(warnings[0].Message, "2 action(s)") {
+		t.Errorf("actionURLMapWarning = %+v", warnings)
+	}
+	if ActionURLMapLeftovers(actions, nil) != nil {
+		t.Error("expected no leftovers without a map")
+	}
+}
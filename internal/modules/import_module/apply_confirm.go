@@ -0,0 +1,67 @@
+package import_module
+
+import "errors"
+
+// ErrApplyDeclined is returned by Execute when Options.ConfirmApply declines
+// the planned changes. Nothing has been changed.
+var ErrApplyDeclined = errors.New("changes declined")
+
+// ApplyConfirmer is shown the diff an import is about to apply and approves
+// it, before anything is changed. entitiesStreamed is true when entities are
+// compared while they are streamed, so the diff does not count them.
+type ApplyConfirmer func(diff *DiffResult, entitiesStreamed bool) (bool, error)
+
+// ChangeCount is the number of resources of one type a diff creates and
+// updates.
+type ChangeCount struct {
+	Resource string
+	Create   int
+	Update   int
+}
+
+// ChangeCounts lists the creates and updates planned by d for each resource
+// type that has any. Permission changes count as updates.
+func (d *DiffResult) ChangeCounts() []ChangeCount {
+	all := []ChangeCount{
+		{"blueprints", len(d.BlueprintsToCreate), len(d.BlueprintsToUpdate)},
+		{"entities", len(d.EntitiesToCreate), len(d.EntitiesToUpdate)},
+		{"scorecards", len(d.ScorecardsToCreate), len(d.ScorecardsToUpdate)},
+		{"actions", len(d.ActionsToCreate), len(d.ActionsToUpdate)},
+		{"teams", len(d.TeamsToCreate), len(d.TeamsToUpdate)},
+		{"team memberships", 0, len(d.TeamMemberships)},
+		{"users", len(d.UsersToCreate), len(d.UsersToUpdate)},
+		{"pages", len(d.PagesToCreate), len(d.PagesToUpdate)},
+		{"integrations", len(d.IntegrationsToCreate), len(d.IntegrationsToUpdate)},
+		{"data sources", len(d.DataSourcesToCreate), len(d.DataSourcesToUpdate)},
+		{"blueprint permissions", 0, len(d.BlueprintPermissions)},
+		{"action permissions", 0, len(d.ActionPermissions)},
+		{"page permissions", 0, len(d.PagePermissions)},
+	}
+	var counts []ChangeCount
+	for _, c := range all {
+		if c.Create > 0 || c.Update > 0 {
+			counts = append(counts, c)
+		}
+	}
+	return counts
+}
+
+// ConfirmApply asks confirm to approve diff and returns ErrApplyDeclined
+// when it declines. A nil confirm, or a diff with nothing to apply, is
+// approved without asking.
+func ConfirmApply(diff *DiffResult, entitiesStreamed bool, confirm ApplyConfirmer) error {
+	if confirm == nil {
+		return nil
+	}
+	if len(diff.ChangeCounts()) == 0 && len(diff.Deletions) == 0 && !entitiesStreamed {
+		return nil
+	}
+	confirmed, err := confirm(diff, entitiesStreamed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return ErrApplyDeclined
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package import_module
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestDiffResult_ChangeCounts(t *testing.T) {
+	diff := &DiffResult{
+		BlueprintsToCreate:   []api.Blueprint{{"identifier": "a"}, {"identifier": "b"}},
+		BlueprintsToUpdate:   []api.Blueprint{{"identifier": "c"}},
+		BlueprintsToSkip:     []api.Blueprint{{"identifier": "d"}},
+		PagesToUpdate:        []api.Page{{"identifier": "home"}},
+		BlueprintPermissions: []PermissionsChange{{Identifier: "a"}},
+	}
+	want := []ChangeCount{
+		{Resource: "blueprints", Create: 2, Update: 1},
+		{Resource: "pages", Update: 1},
+		{Resource: "blueprint permissions", Update: 1},
+	}
+	if got := diff.ChangeCounts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangeCounts = %+v, want %+v", got, want)
+	}
+}
+
+func TestConfirmApply(t *testing.T) {
+	asked := 0
+	answer := false
+	confirm := func(*DiffResult, bool) (bool, error) {
+		asked++
+		return answer, nil
+	}
+	changes := &DiffResult{ActionsToCreate: []api.Action{{"identifier": "deploy"}}}
+
+	if err := ConfirmApply(changes, false, nil); err != nil {
+		t.Errorf("nil confirmer: %v", err)
+	}
+	if err := ConfirmApply(&DiffResult{}, false, confirm); err != nil || asked != 0 {
+		t.Errorf("empty diff: err %v, asked %d times; want approval without asking", err, asked)
+	}
+	if err := ConfirmApply(changes, false, confirm); !errors.Is(err, ErrApplyDeclined) {
+		t.Errorf("declined: err = %v, want ErrApplyDeclined", err)
+	}
+	answer = true
+	if err := ConfirmApply(&DiffResult{}, true, confirm); err != nil || asked != 2 {
+		t.Errorf("streamed entities: err %v, asked %d times; want to ask", err, asked)
+	}
+	if err := ConfirmApply(&DiffResult{Deletions: []export.Deletion{{Type: "pages", Identifier: "old"}}}, false, confirm); err != nil || asked != 3 {
+		t.Errorf("deletions only: err %v, asked %d times; want to ask", err, asked)
+	}
+}
@@ -1,6 +1,7 @@
 package import_module
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -76,7 +77,12 @@ func IsRelationError(err error) bool {
 		return false
 	}
 
+	// Match API errors on the Port error code and message only, so the request
+	// URL (e.g. /blueprints/...) can't produce false positives.
 	errStr := strings.ToLower(err.Error())
+	if apiErr, ok := api.AsAPIError(err); ok {
+		errStr = apiErrorDetail(apiErr)
+	}
 
 	// More specific error patterns for relation issues to avoid false positives
 	relationErrorPatterns := []string{
@@ -366,6 +372,44 @@ func ValidateRelationTargets(bp api.Blueprint, existingBlueprints map[string]boo
 	return missing
 }
 
+// ValidateDependentPropertyTargets splits a blueprint's mirror or aggregation
+// properties (field is "mirrorProperties" or "aggregationProperties") into the
+// ones that can be applied and the ones whose target blueprint does not exist,
+// with the reason for each. A mirror property reads through the relation its
+// path starts with, looked up in relations; an aggregation property names its
+// target. Mirror paths starting with a $ meta-relation are not checked.
+func ValidateDependentPropertyTargets(field string, props, relations map[string]interface{}, existingBlueprints map[string]bool) (valid map[string]interface{}, invalid map[string]string) {
+	valid = make(map[string]interface{}, len(props))
+	invalid = make(map[string]string)
+	for name, prop := range props {
+		propMap, _ := prop.(map[string]interface{})
+		switch field {
+		case "mirrorProperties":
+			path, _ := propMap["path"].(string)
+			relName, _, _ := strings.Cut(path, ".")
+			if relName == "" || strings.HasPrefix(relName, "$") {
+				break
+			}
+			rel, ok := relations[relName].(map[string]interface{})
+			if !ok {
+				invalid[name] = fmt.Sprintf("path %q does not start with a relation of the blueprint", path)
+				continue
+			}
+			if target, _ := rel["target"].(string); target != "" && !existingBlueprints[target] {
+				invalid[name] = fmt.Sprintf("relation %q targets blueprint %q, which does not exist", relName, target)
+				continue
+			}
+		case "aggregationProperties":
+			if target, _ := propMap["target"].(string); target != "" && !existingBlueprints[target] {
+				invalid[name] = fmt.Sprintf("target blueprint %q does not exist", target)
+				continue
+			}
+		}
+		valid[name] = prop
+	}
+	return valid, invalid
+}
+
 // ValidateAllDependencies checks if all dependencies exist in the provided blueprint set.
 func ValidateAllDependencies(bp api.Blueprint, existingBlueprints map[string]bool) []string {
 	missing := []string{}
@@ -728,3 +772,171 @@ func HasEntityRelations(entity api.Entity) bool {
 	}
 	return len(relations) > 0
 }
+
+// Dependent is a blueprint relation that targets another blueprint.
+type Dependent struct {
+	Blueprint string `json:"blueprint"`
+	Relation  string `json:"relation"`
+	Required  bool   `json:"required"`
+	Many      bool   `json:"many"`
+}
+
+// DependencyIndex is a reverse-dependency index over a set of blueprints:
+// for each blueprint it records the relations on other blueprints that target it.
+type DependencyIndex struct {
+	dependents map[string][]Dependent
+}
+
+// NewDependencyIndex builds the reverse-dependency index for blueprints.
+func NewDependencyIndex(blueprints []api.Blueprint) *DependencyIndex {
+	idx := &DependencyIndex{dependents: make(map[string][]Dependent)}
+	for _, bp := range blueprints {
+		id, ok := bp["identifier"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		for name, relation := range ExtractRelations(bp) {
+			relationMap, ok := relation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			target, _ := relationMap["target"].(string)
+			if target == "" {
+				continue
+			}
+			required, _ := relationMap["required"].(bool)
+			many, _ := relationMap["many"].(bool)
+			idx.dependents[target] = append(idx.dependents[target], Dependent{
+				Blueprint: id,
+				Relation:  name,
+				Required:  required,
+				Many:      many,
+			})
+		}
+	}
+	for target := range idx.dependents {
+		deps := idx.dependents[target]
+		sort.Slice(deps, func(i, j int) bool {
+			if deps[i].Blueprint != deps[j].Blueprint {
+				return deps[i].Blueprint < deps[j].Blueprint
+			}
+			return deps[i].Relation < deps[j].Relation
+		})
+	}
+	return idx
+}
+
+// GetDependents returns the relations that target the blueprint id, sorted by
+// blueprint and relation name. Self-relations are included.
+func (idx *DependencyIndex) GetDependents(id string) []Dependent {
+	return idx.dependents[id]
+}
+
+// Targets returns every blueprint that has at least one dependent, sorted.
+func (idx *DependencyIndex) Targets() []string {
+	targets := make([]string, 0, len(idx.dependents))
+	for target := range idx.dependents {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// RelationEdge is a relation from one blueprint to the blueprint it targets.
+type RelationEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Required bool   `json:"required"`
+	Many     bool   `json:"many"`
+	// Cyclic is true when the edge lies on a dependency cycle, so the
+	// blueprints it joins cannot be created in dependency order.
+	Cyclic bool `json:"cyclic"`
+}
+
+// RelationGraph is the blueprint -> blueprint relation graph of a set of
+// blueprints.
+type RelationGraph struct {
+	// Blueprints lists the blueprint identifiers, sorted.
+	Blueprints []string
+	// Titles maps blueprint identifiers to their titles, where set.
+	Titles map[string]string
+	// Edges lists every relation, sorted by source blueprint and relation.
+	Edges []RelationEdge
+}
+
+// BuildRelationGraph builds the relation graph of blueprints. Edges are
+// marked cyclic when their target leads back to their source through the
+// blueprints TopologicalSort could not order.
+func BuildRelationGraph(blueprints []api.Blueprint) RelationGraph {
+	graph := RelationGraph{Titles: make(map[string]string)}
+	for _, bp := range blueprints {
+		id, ok := bp["identifier"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		graph.Blueprints = append(graph.Blueprints, id)
+		if title, _ := bp["title"].(string); title != "" {
+			graph.Titles[id] = title
+		}
+		for name, relation := range ExtractRelations(bp) {
+			relationMap, ok := relation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			target, _ := relationMap["target"].(string)
+			if target == "" {
+				continue
+			}
+			required, _ := relationMap["required"].(bool)
+			many, _ := relationMap["many"].(bool)
+			graph.Edges = append(graph.Edges, RelationEdge{From: id, To: target, Relation: name, Required: required, Many: many})
+		}
+	}
+	sort.Strings(graph.Blueprints)
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].Relation < graph.Edges[j].Relation
+	})
+
+	_, cyclic := TopologicalSort(blueprints, nil)
+	unsorted := make(map[string]bool, len(cyclic))
+	for _, bp := range cyclic {
+		id, _ := bp["identifier"].(string)
+		unsorted[id] = true
+	}
+	next := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		if unsorted[edge.From] && unsorted[edge.To] {
+			next[edge.From] = append(next[edge.From], edge.To)
+		}
+	}
+	for i, edge := range graph.Edges {
+		if unsorted[edge.From] && unsorted[edge.To] {
+			graph.Edges[i].Cyclic = reaches(next, edge.To, edge.From)
+		}
+	}
+	return graph
+}
+
+// reaches reports whether to can be reached from from by following next.
+func reaches(next map[string][]string, from, to string) bool {
+	seen := map[string]bool{from: true}
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == to {
+			return true
+		}
+		for _, n := range next[id] {
+			if !seen[n] {
+				seen[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package import_module
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/port-experimental/port-cli/internal/api"
@@ -553,3 +555,102 @@ func TestPartitionBlueprintRelationsRuleResultTarget_table(t *testing.T) {
 		t.Fatalf("kept: %#v", kept)
 	}
 }
+
+func TestDependencyIndex_GetDependents(t *testing.T) {
+	blueprints := []api.Blueprint{
+		{"identifier": "team"},
+		{"identifier": "service", "relations": map[string]interface{}{
+			"owner":  map[string]interface{}{"target": "team", "required": true},
+			"parent": map[string]interface{}{"target": "service"},
+		}},
+		{"identifier": "deployment", "relations": map[string]interface{}{
+			"service": map[string]interface{}{"target": "service", "many": true},
+			"team":    map[string]interface{}{"target": "team"},
+		}},
+	}
+
+	idx := NewDependencyIndex(blueprints)
+
+	teamDeps := idx.GetDependents("team")
+	want := []Dependent{
+		{Blueprint: "deployment", Relation: "team"},
+		{Blueprint: "service", Relation: "owner", Required: true},
+	}
+	if !reflect.DeepEqual(teamDeps, want) {
+		t.Fatalf("team dependents = %+v, want %+v", teamDeps, want)
+	}
+
+	serviceDeps := idx.GetDependents("service")
+	if len(serviceDeps) != 2 || serviceDeps[0].Blueprint != "deployment" || !serviceDeps[0].Many || serviceDeps[1].Relation != "parent" {
+		t.Fatalf("unexpected service dependents %+v", serviceDeps)
+	}
+
+	if deps := idx.GetDependents("deployment"); len(deps) != 0 {
+		t.Fatalf("expected no dependents for deployment, got %+v", deps)
+	}
+	if targets := idx.Targets(); !reflect.DeepEqual(targets, []string{"service", "team"}) {
+		t.Fatalf("unexpected targets %v", targets)
+	}
+}
+
+func TestBuildRelationGraph_MarksCyclicEdges(t *testing.T) {
+	blueprints := []api.Blueprint{
+		{"identifier": "service", "title": "Service", "relations": map[string]interface{}{
+			"team":   map[string]interface{}{"target": "team", "required": true},
+			"system": map[string]interface{}{"target": "system", "many": true},
+		}},
+		{"identifier": "system", "relations": map[string]interface{}{
+			"owner": map[string]interface{}{"target": "service"},
+		}},
+		{"identifier": "team"},
+	}
+	graph := BuildRelationGraph(blueprints)
+
+	if len(graph.Blueprints) != 3 || graph.Titles["service"] != "Service" {
+		t.Fatalf("unexpected blueprints %v / titles %v", graph.Blueprints, graph.Titles)
+	}
+	want := []RelationEdge{
+		{From: "service", To: "system", Relation: "system", Many: true, Cyclic: true},
+		{From: "service", To: "team", Relation: "team", Required: true},
+		{From: "system", To: "service", Relation: "owner", Cyclic: true},
+	}
+	if len(graph.Edges) != len(want) {
+		t.Fatalf("expected %d edges, got %v", len(want), graph.Edges)
+	}
+	for i := range want {
+		if graph.Edges[i] != want[i] {
+			t.Errorf("edge %d: got %+v, want %+v", i, graph.Edges[i], want[i])
+		}
+	}
+}
+
+func TestValidateDependentPropertyTargets(t *testing.T) {
+	relations := map[string]interface{}{
+		"service": map[string]interface{}{"target": "service"},
+		"cluster": map[string]interface{}{"target": "cluster"},
+	}
+	existing := map[string]bool{"service": true}
+	mirrors := map[string]interface{}{
+		"tier":     map[string]interface{}{"path": "service.tier"},
+		"region":   map[string]interface{}{"path": "cluster.region"},
+		"owner":    map[string]interface{}{"path": "missing.owner"},
+		"teamName": map[string]interface{}{"path": "$team.$title"},
+	}
+
+	valid, invalid := ValidateDependentPropertyTargets("mirrorProperties", mirrors, relations, existing)
+	if len(valid) != 2 || valid["tier"] == nil || valid["teamName"] == nil {
+		t.Errorf("expected tier and teamName to be valid, got %v", valid)
+	}
+	if !strings.Contains(invalid["region"], `"cluster"`) || !strings.Contains(invalid["owner"], "does not start with a relation") {
+		t.Errorf("unexpected invalid mirror properties %v", invalid)
+	}
+
+	aggs := map[string]interface{}{
+		"services": map[string]interface{}{"target": "service"},
+		"clusters": map[string]interface{}{"target": "cluster"},
+	}
+	valid, invalid = ValidateDependentPropertyTargets("aggregationProperties", aggs, nil, existing)
+	if len(valid) != 1 || valid["services"] == nil || invalid["clusters"] == "" {
+		t.Errorf("expected only the clusters aggregation to be invalid, got valid=%v invalid=%v", valid, invalid)
+	}
+}
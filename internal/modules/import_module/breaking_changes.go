@@ -0,0 +1,187 @@
+package import_module
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// Kinds of breaking blueprint schema change reported by DetectBreakingChanges.
+const (
+	BreakingRemovedRequired = "removed_required_property"
+	BreakingNewlyRequired   = "newly_required_property"
+	BreakingTypeChanged     = "type_changed"
+	BreakingEnumNarrowed    = "enum_narrowed"
+)
+
+// BreakingChange is a blueprint schema change that can make existing entities
+// in the target invalid.
+type BreakingChange struct {
+	Blueprint string
+	Path      string // e.g. schema.properties.tier.type
+	Kind      string
+	Detail    string
+}
+
+func (c BreakingChange) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Blueprint, c.Path, c.Detail)
+}
+
+// BreakingChangesError is returned when an import or migration would apply
+// breaking schema changes without AllowBreaking.
+type BreakingChangesError struct {
+	Changes []BreakingChange
+}
+
+func (e *BreakingChangesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d breaking blueprint schema change(s) could invalidate existing entities (use --allow-breaking to apply anyway):", len(e.Changes))
+	for _, c := range e.Changes {
+		b.WriteString("\n  - ")
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
+
+// DetectBreakingChanges compares each blueprint in diff.BlueprintsToUpdate with
+// the target's current schema and returns the changes that could invalidate
+// existing entities: removed required properties, properties that become
+// required, property type or format changes, and enum values that are no
+// longer allowed.
+func DetectBreakingChanges(diff *DiffResult) []BreakingChange {
+	if diff == nil || diff.Current == nil {
+		return nil
+	}
+	current := make(map[string]api.Blueprint, len(diff.Current.Blueprints))
+	for _, bp := range diff.Current.Blueprints {
+		if id, ok := bp["identifier"].(string); ok {
+			current[id] = bp
+		}
+	}
+
+	var changes []BreakingChange
+	for _, bp := range diff.BlueprintsToUpdate {
+		id, _ := bp["identifier"].(string)
+		old, ok := current[id]
+		if !ok {
+			continue
+		}
+		changes = append(changes, compareBlueprintSchemas(id, schemaOf(old), schemaOf(bp))...)
+	}
+	return changes
+}
+
+// appendBreakingChangeWarning adds a warning listing changes, if there are any.
+func appendBreakingChangeWarning(warnings []ValidationWarning, changes []BreakingChange) []ValidationWarning {
+	if len(changes) == 0 {
+		return warnings
+	}
+	details := make([]string, len(changes))
+	for i, c := range changes {
+		details[i] = c.String()
+	}
+	return append(warnings, ValidationWarning{
+		Type:    "breaking_change",
+		Message: fmt.Sprintf("%d breaking blueprint schema change(s) could invalidate existing entities", len(changes)),
+		Details: details,
+	})
+}
+
+func schemaOf(bp api.Blueprint) map[string]interface{} {
+	schema, _ := bp["schema"].(map[string]interface{})
+	return schema
+}
+
+// compareBlueprintSchemas returns the breaking changes from oldSchema to
+// newSchema, sorted by path.
+func compareBlueprintSchemas(blueprint string, oldSchema, newSchema map[string]interface{}) []BreakingChange {
+	// A payload without a schema leaves the target schema untouched.
+	if newSchema == nil {
+		return nil
+	}
+	oldProps, _ := oldSchema["properties"].(map[string]interface{})
+	newProps, _ := newSchema["properties"].(map[string]interface{})
+	oldRequired := requiredSet(oldSchema)
+	newRequired := requiredSet(newSchema)
+
+	var changes []BreakingChange
+	add := func(path, kind, detail string) {
+		changes = append(changes, BreakingChange{Blueprint: blueprint, Path: path, Kind: kind, Detail: detail})
+	}
+
+	for name := range oldProps {
+		if _, ok := newProps[name]; !ok && oldRequired[name] {
+			add("schema.properties."+name, BreakingRemovedRequired, "required property removed")
+		}
+	}
+	for name := range newRequired {
+		if !oldRequired[name] {
+			add("schema.required."+name, BreakingNewlyRequired, "property became required")
+		}
+	}
+	for name, rawNew := range newProps {
+		oldProp, _ := oldProps[name].(map[string]interface{})
+		newProp, _ := rawNew.(map[string]interface{})
+		if oldProp == nil || newProp == nil {
+			continue
+		}
+		path := "schema.properties." + name
+		for _, field := range []string{"type", "format"} {
+			if !reflect.DeepEqual(oldProp[field], newProp[field]) {
+				add(path+"."+field, BreakingTypeChanged, fmt.Sprintf("%s changed from %v to %v", field, valueOrNone(oldProp[field]), valueOrNone(newProp[field])))
+			}
+		}
+		if removed := removedEnumValues(oldProp["enum"], newProp["enum"]); len(removed) > 0 {
+			add(path+".enum", BreakingEnumNarrowed, fmt.Sprintf("enum no longer allows %s", strings.Join(removed, ", ")))
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// removedEnumValues returns the values allowed by oldEnum that newEnum no
+// longer allows. Adding an enum to an unrestricted property narrows it too.
+func removedEnumValues(oldEnum, newEnum interface{}) []string {
+	newValues, ok := newEnum.([]interface{})
+	if !ok {
+		return nil
+	}
+	allowed := make(map[string]bool, len(newValues))
+	for _, v := range newValues {
+		allowed[fmt.Sprint(v)] = true
+	}
+	oldValues, ok := oldEnum.([]interface{})
+	if !ok {
+		return []string{"values outside " + fmt.Sprint(newValues)}
+	}
+	var removed []string
+	for _, v := range oldValues {
+		if !allowed[fmt.Sprint(v)] {
+			removed = append(removed, fmt.Sprint(v))
+		}
+	}
+	return removed
+}
+
+// requiredSet returns the property names listed in schema's "required" array.
+func requiredSet(schema map[string]interface{}) map[string]bool {
+	items, _ := schema["required"].([]interface{})
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func valueOrNone(v interface{}) interface{} {
+	if v == nil {
+		return "<none>"
+	}
+	return v
+}
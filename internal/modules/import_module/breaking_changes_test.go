@@ -0,0 +1,98 @@
+package import_module
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestDetectBreakingChanges(t *testing.T) {
+	current := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"owner": map[string]interface{}{"type": "string"},
+				"tier":  map[string]interface{}{"type": "string", "enum": []interface{}{"gold", "silver", "bronze"}},
+				"port":  map[string]interface{}{"type": "string"},
+				"url":   map[string]interface{}{"type": "string", "format": "url"},
+			},
+			"required": []interface{}{"owner"},
+		},
+	}
+	incoming := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"tier": map[string]interface{}{"type": "string", "enum": []interface{}{"gold", "silver"}},
+				"port": map[string]interface{}{"type": "number"},
+				"url":  map[string]interface{}{"type": "string", "format": "url"},
+				"team": map[string]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"team"},
+		},
+	}
+	diff := &DiffResult{
+		BlueprintsToUpdate: []api.Blueprint{incoming},
+		Current:            &export.Data{Blueprints: []api.Blueprint{current}},
+	}
+
+	changes := DetectBreakingChanges(diff)
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Kind+" "+c.Path)
+	}
+	want := []string{
+		BreakingRemovedRequired + " schema.properties.owner",
+		BreakingTypeChanged + " schema.properties.port.type",
+		BreakingEnumNarrowed + " schema.properties.tier.enum",
+		BreakingNewlyRequired + " schema.required.team",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectBreakingChanges() = %v, want %v", got, want)
+	}
+	if !strings.Contains(changes[2].Detail, "bronze") {
+		t.Errorf("expected enum change to name the removed value, got %q", changes[2].Detail)
+	}
+}
+
+func TestDetectBreakingChanges_IgnoresAdditiveChanges(t *testing.T) {
+	current := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"tier": map[string]interface{}{"type": "string", "enum": []interface{}{"gold"}},
+			},
+		},
+	}
+	incoming := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"tier":  map[string]interface{}{"type": "string", "enum": []interface{}{"gold", "silver"}, "title": "Tier"},
+				"owner": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	diff := &DiffResult{
+		BlueprintsToUpdate: []api.Blueprint{incoming},
+		Current:            &export.Data{Blueprints: []api.Blueprint{current}},
+	}
+
+	if changes := DetectBreakingChanges(diff); len(changes) != 0 {
+		t.Errorf("expected no breaking changes, got %v", changes)
+	}
+}
+
+func TestBreakingChangesError_ListsEachChange(t *testing.T) {
+	err := &BreakingChangesError{Changes: []BreakingChange{
+		{Blueprint: "service", Path: "schema.properties.port.type", Detail: "type changed from string to number"},
+	}}
+	msg := err.Error()
+	if !strings.Contains(msg, "--allow-breaking") || !strings.Contains(msg, "service: schema.properties.port.type") {
+		t.Errorf("unexpected error message: %q", msg)
+	}
+}
@@ -0,0 +1,140 @@
+package import_module
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConflictStrategy selects what an import does with a resource that already
+// exists in the target organization.
+type ConflictStrategy string
+
+const (
+	// ConflictUpdate overwrites the existing resource. It is the default.
+	ConflictUpdate ConflictStrategy = "update"
+	// ConflictSkip leaves the existing resource untouched.
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictFail reports the existing resource as an error, so the import
+	// only ever creates.
+	ConflictFail ConflictStrategy = "fail"
+)
+
+// ConflictStrategies lists the accepted --on-conflict values.
+var ConflictStrategies = []string{string(ConflictUpdate), string(ConflictSkip), string(ConflictFail)}
+
+// ErrResourceExists is recorded for every existing resource an import meets
+// under ConflictFail.
+var ErrResourceExists = errors.New("resource already exists in the target (--on-conflict fail)")
+
+// updateExisting reports whether a resource found to already exist in the
+// target should be updated. Under ConflictSkip the resource is counted as
+// skipped; under ConflictFail it is recorded as an error.
+func (i *Importer) updateExisting(counts *ResourceCounts, resourceType, id string) bool {
+	switch i.onConflict {
+	case ConflictSkip:
+		counts.Skipped.Add(1)
+		i.reportResource(ResourceSkipped, resourceType, id)
+		return false
+	case ConflictFail:
+		i.errors.Add(ErrResourceExists, resourceType, id)
+		return false
+	}
+	return true
+}
+
+// applyConflictStrategy adjusts the diff for strategy. Under ConflictSkip
+// every would-be update moves to the skip bucket, along with the membership
+// changes of teams that already exist, and the number of resources moved is
+// returned. Other strategies leave the diff unchanged: under ConflictFail the
+// comparer plans every existing resource as an update, including unchanged
+// ones, and the importer reports each one as an error.
+func (d *DiffResult) applyConflictStrategy(strategy ConflictStrategy) int {
+	if strategy != ConflictSkip {
+		return 0
+	}
+	moved := len(d.BlueprintsToUpdate) + len(d.EntitiesToUpdate) + len(d.ScorecardsToUpdate) +
+		len(d.ActionsToUpdate) + len(d.TeamsToUpdate) + len(d.UsersToUpdate) +
+		len(d.PagesToUpdate) + len(d.IntegrationsToUpdate) + len(d.DataSourcesToUpdate)
+
+	d.BlueprintsToSkip, d.BlueprintsToUpdate = append(d.BlueprintsToSkip, d.BlueprintsToUpdate...), nil
+	d.EntitiesToSkip, d.EntitiesToUpdate = append(d.EntitiesToSkip, d.EntitiesToUpdate...), nil
+	d.ScorecardsToSkip, d.ScorecardsToUpdate = append(d.ScorecardsToSkip, d.ScorecardsToUpdate...), nil
+	d.ActionsToSkip, d.ActionsToUpdate = append(d.ActionsToSkip, d.ActionsToUpdate...), nil
+	d.TeamsToSkip, d.TeamsToUpdate = append(d.TeamsToSkip, d.TeamsToUpdate...), nil
+	d.UsersToSkip, d.UsersToUpdate = append(d.UsersToSkip, d.UsersToUpdate...), nil
+	d.PagesToSkip, d.PagesToUpdate = append(d.PagesToSkip, d.PagesToUpdate...), nil
+	d.IntegrationsToSkip, d.IntegrationsToUpdate = append(d.IntegrationsToSkip, d.IntegrationsToUpdate...), nil
+	d.DataSourcesToSkip, d.DataSourcesToUpdate = append(d.DataSourcesToSkip, d.DataSourcesToUpdate...), nil
+
+	created := make(map[string]bool, len(d.TeamsToCreate))
+	for _, team := range d.TeamsToCreate {
+		if name, ok := team["name"].(string); ok {
+			created[name] = true
+		}
+	}
+	memberships := d.TeamMemberships[:0]
+	for _, change := range d.TeamMemberships {
+		if created[change.Team] {
+			memberships = append(memberships, change)
+		}
+	}
+	d.TeamMemberships = memberships
+	return moved
+}
+
+// existingConflictWarning warns, in a dry run under ConflictFail, about the
+// resources that already exist in the target and would be reported as errors.
+func existingConflictWarning(warnings []ValidationWarning, d *DiffResult, strategy ConflictStrategy) []ValidationWarning {
+	if strategy != ConflictFail || d == nil {
+		return warnings
+	}
+	var details []string
+	add := func(resourceType string, items []map[string]interface{}, field string) {
+		for _, item := range items {
+			id, _ := item[field].(string)
+			details = append(details, resourceType+" "+id)
+		}
+	}
+	add("blueprint", toMaps(d.BlueprintsToUpdate), "identifier")
+	for _, e := range d.EntitiesToUpdate {
+		bp, _ := e["blueprint"].(string)
+		id, _ := e["identifier"].(string)
+		details = append(details, "entity "+bp+"/"+id)
+	}
+	add("scorecard", toMaps(d.ScorecardsToUpdate), "identifier")
+	add("action", toMaps(d.ActionsToUpdate), "identifier")
+	add("team", toMaps(d.TeamsToUpdate), "name")
+	add("user", toMaps(d.UsersToUpdate), "email")
+	add("page", toMaps(d.PagesToUpdate), "identifier")
+	add("integration", toMaps(d.IntegrationsToUpdate), "identifier")
+	add("data source", toMaps(d.DataSourcesToUpdate), "identifier")
+	if len(details) == 0 {
+		return warnings
+	}
+	return append(warnings, ValidationWarning{
+		Type:    "conflict",
+		Message: fmt.Sprintf("%d resource(s) already exist in the target and would fail with --on-conflict fail", len(details)),
+		Details: details,
+	})
+}
+
+// ForceUpdateWarning is reported by imports and migrations run with
+// --force-update.
+const ForceUpdateWarning = "--force-update ignores the diff: resources that already match the target are updated too"
+
+// appendForceUpdateWarning warns that forceUpdate bypasses the diff's
+// skipping of unchanged resources.
+func appendForceUpdateWarning(warnings []ValidationWarning, forceUpdate bool) []ValidationWarning {
+	if !forceUpdate {
+		return warnings
+	}
+	return append(warnings, ValidationWarning{Type: "force_update", Message: ForceUpdateWarning})
+}
+
+func toMaps[T ~map[string]interface{}](items []T) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(items))
+	for j, item := range items {
+		out[j] = item
+	}
+	return out
+}
@@ -0,0 +1,279 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/config"
+	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+)
+
+// conflictTeamServer answers every team create with 409 and counts updates.
+func conflictTeamServer(t *testing.T, updates *atomic.Int32) *api.Client {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/teams":
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "conflict"})
+		case r.URL.Path == "/teams/platform":
+			updates.Add(1)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "team": map[string]interface{}{"name": "platform"}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return client
+}
+
+func TestImportTeams_OnConflict(t *testing.T) {
+	tests := []struct {
+		strategy    ConflictStrategy
+		wantUpdates int32
+		wantUpdated int
+		wantSkipped int
+		wantErr     bool
+	}{
+		{strategy: "", wantUpdates: 1, wantUpdated: 1},
+		{strategy: ConflictUpdate, wantUpdates: 1, wantUpdated: 1},
+		{strategy: ConflictSkip, wantSkipped: 1},
+		{strategy: ConflictFail, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			var updates atomic.Int32
+			importer := NewImporter(conflictTeamServer(t, &updates))
+			importer.onConflict = tt.strategy
+
+			result := &Result{}
+			pool := NewWorkerPool(1)
+			importer.importTeams(context.Background(), []api.Team{{"name": "platform"}}, result, pool)
+			pool.Wait()
+			result.From(&importer.counts)
+
+			if got := updates.Load(); got != tt.wantUpdates {
+				t.Errorf("expected %d update call(s), got %d", tt.wantUpdates, got)
+			}
+			if result.TeamsUpdated != tt.wantUpdated {
+				t.Errorf("expected TeamsUpdated=%d, got %d", tt.wantUpdated, result.TeamsUpdated)
+			}
+			if result.ResourcesSkipped != tt.wantSkipped {
+				t.Errorf("expected ResourcesSkipped=%d, got %d", tt.wantSkipped, result.ResourcesSkipped)
+			}
+			errs := importer.errors.ToStringSlice()
+			if tt.wantErr {
+				if len(errs) != 1 || !strings.Contains(errs[0], "already exists") || !strings.Contains(errs[0], "platform") {
+					t.Errorf("expected one already-exists error for platform, got %v", errs)
+				}
+			} else if len(errs) != 0 {
+				t.Errorf("expected no errors, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestApplyConflictStrategy(t *testing.T) {
+	newDiff := func() *DiffResult {
+		return &DiffResult{
+			BlueprintsToCreate: []api.Blueprint{{"identifier": "new"}},
+			BlueprintsToUpdate: []api.Blueprint{{"identifier": "service"}},
+			EntitiesToUpdate:   []api.Entity{{"identifier": "svc-1", "blueprint": "service"}},
+			TeamsToCreate:      []api.Team{{"name": "new-team"}},
+			TeamsToUpdate:      []api.Team{{"name": "platform"}},
+			TeamMemberships: []TeamMembershipChange{
+				{Team: "new-team", Add: []string{"a@example.com"}},
+				{Team: "platform", Add: []string{"b@example.com"}},
+			},
+		}
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		d := newDiff()
+		if moved := d.applyConflictStrategy(ConflictSkip); moved != 3 {
+			t.Errorf("expected 3 updates moved to skip, got %d", moved)
+		}
+		if len(d.BlueprintsToUpdate) != 0 || len(d.EntitiesToUpdate) != 0 || len(d.TeamsToUpdate) != 0 {
+			t.Errorf("expected no updates left, got %+v", d)
+		}
+		if len(d.BlueprintsToSkip) != 1 || len(d.EntitiesToSkip) != 1 || len(d.TeamsToSkip) != 1 {
+			t.Errorf("expected updates in the skip buckets, got %+v", d)
+		}
+		if len(d.BlueprintsToCreate) != 1 || len(d.TeamsToCreate) != 1 {
+			t.Errorf("expected creates to be kept, got %+v", d)
+		}
+		if len(d.TeamMemberships) != 1 || d.TeamMemberships[0].Team != "new-team" {
+			t.Errorf("expected only the new team's memberships to remain, got %+v", d.TeamMemberships)
+		}
+	})
+
+	for _, strategy := range []ConflictStrategy{"", ConflictUpdate, ConflictFail} {
+		t.Run("unchanged/"+string(strategy), func(t *testing.T) {
+			d := newDiff()
+			if moved := d.applyConflictStrategy(strategy); moved != 0 {
+				t.Errorf("expected nothing moved, got %d", moved)
+			}
+			if len(d.BlueprintsToUpdate) != 1 || len(d.TeamMemberships) != 2 {
+				t.Errorf("expected the diff to be unchanged, got %+v", d)
+			}
+		})
+	}
+}
+
+func TestExistingConflictWarning(t *testing.T) {
+	d := &DiffResult{
+		BlueprintsToUpdate: []api.Blueprint{{"identifier": "service"}},
+		UsersToUpdate:      []api.User{{"email": "a@example.com"}},
+	}
+	if got := existingConflictWarning(nil, d, ConflictUpdate); len(got) != 0 {
+		t.Fatalf("expected no warning under update, got %+v", got)
+	}
+	got := existingConflictWarning(nil, d, ConflictFail)
+	if len(got) != 1 || got[0].Type != "conflict" {
+		t.Fatalf("expected one conflict warning, got %+v", got)
+	}
+	want := []string{"blueprint service", "user a@example.com"}
+	if strings.Join(got[0].Details, ",") != strings.Join(want, ",") {
+		t.Errorf("expected details %v, got %v", want, got[0].Details)
+	}
+}
+
+func TestImportBlueprintEntities_OnConflict(t *testing.T) {
+	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
+		return yield([]api.Entity{{"identifier": "svc-1", "blueprint": "service", "title": "old"}})
+	})
+	var skipped []string
+	run := func(strategy ConflictStrategy) (*Result, *Importer) {
+		importer := NewImporter(api.NewClient(api.ClientOpts{}))
+		importer.onConflict = strategy
+		importer.SetResourceCallback(func(action, resourceType, identifier string) {
+			if action == ResourceSkipped {
+				skipped = append(skipped, resourceType+" "+identifier)
+			}
+		})
+		desired := entitystream.EntityIterator(2, func(yield func(api.Entity) error) error {
+			if err := yield(api.Entity{"identifier": "svc-1", "blueprint": "service", "title": "new"}); err != nil {
+				return err
+			}
+			return yield(api.Entity{"identifier": "svc-2", "blueprint": "service"})
+		})
+		result := &Result{}
+		err := importer.ImportBlueprintEntities(context.Background(), "service", desired, currentSource, EntityStreamOptions{}, result, true, &EntityImportContext{}, t.TempDir())
+		if err != nil {
+			t.Fatalf("ImportBlueprintEntities error: %v", err)
+		}
+		return result, importer
+	}
+
+	result, _ := run(ConflictUpdate)
+	if result.EntitiesCreated != 1 || result.EntitiesUpdated != 1 {
+		t.Errorf("update: expected 1 create and 1 update, got %d and %d", result.EntitiesCreated, result.EntitiesUpdated)
+	}
+
+	result, _ = run(ConflictSkip)
+	if result.EntitiesCreated != 1 || result.EntitiesUpdated != 0 || result.ResourcesSkipped != 1 {
+		t.Errorf("skip: expected 1 create and 1 skip, got %+v", result)
+	}
+	if len(skipped) != 1 || skipped[0] != "entity svc-1" {
+		t.Errorf("skip: expected svc-1 to be reported as skipped, got %v", skipped)
+	}
+
+	result, _ = run(ConflictFail)
+	if result.EntitiesCreated != 1 || result.EntitiesUpdated != 0 {
+		t.Errorf("fail: expected 1 create and no update, got %+v", result)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != "conflict" || result.Warnings[0].Details[0] != "entity service/svc-1" {
+		t.Errorf("fail: expected a conflict warning for svc-1, got %+v", result.Warnings)
+	}
+}
+
+func TestUpdateExisting_FailRecordsResourceExists(t *testing.T) {
+	importer := NewImporter(api.NewClient(api.ClientOpts{}))
+	importer.onConflict = ConflictFail
+	if importer.updateExisting(&importer.counts.Pages, "page", "home") {
+		t.Fatal("expected updateExisting to refuse the update under fail")
+	}
+	all := importer.errors.All()
+	if len(all) != 1 || !errors.Is(all[0].Cause, ErrResourceExists) || all[0].Category != ErrConflict {
+		t.Errorf("expected one CONFLICT error wrapping ErrResourceExists, got %+v", all)
+	}
+}
+
+func TestImportBlueprintEntities_FailReportsUnchangedExistingEntity(t *testing.T) {
+	entity := api.Entity{"identifier": "svc-1", "blueprint": "service", "title": "same"}
+	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
+		return yield([]api.Entity{entity})
+	})
+	for _, dryRun := range []bool{true, false} {
+		importer := NewImporter(api.NewClient(api.ClientOpts{}))
+		importer.onConflict = ConflictFail
+		skipped := 0
+		result := &Result{}
+		err := importer.ImportBlueprintEntities(context.Background(), "service", entitystream.EntityIterator(1, func(yield func(api.Entity) error) error {
+			return yield(entity)
+		}), currentSource, EntityStreamOptions{OnEntitySkipped: func(api.Entity) { skipped++ }}, result, dryRun, &EntityImportContext{}, t.TempDir())
+		if err != nil {
+			t.Fatalf("ImportBlueprintEntities error: %v", err)
+		}
+		if skipped != 0 {
+			t.Errorf("dryRun=%v: expected the unchanged entity not to be skipped under fail", dryRun)
+		}
+		if dryRun {
+			if len(result.Warnings) != 1 || result.Warnings[0].Type != "conflict" {
+				t.Errorf("dry run: expected a conflict warning for svc-1, got %+v", result.Warnings)
+			}
+			continue
+		}
+		all := importer.errors.All()
+		if len(all) != 1 || !errors.Is(all[0].Cause, ErrResourceExists) {
+			t.Errorf("expected ErrResourceExists for svc-1, got %+v", all)
+		}
+	}
+}
+
+func TestExecute_FailReportsUnchangedExistingBlueprint(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.json": `{"blueprints": [{"identifier": "service", "title": "Service"}]}`,
+	})
+	var creates atomic.Int32
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []map[string]interface{}{{"identifier": "service", "title": "Service"}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/blueprints":
+			creates.Add(1)
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "conflict"})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	})
+
+	module := NewModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: srv.URL})
+	result, err := module.Execute(context.Background(), Options{
+		InputPath:        filepath.Join(dir, "export.json"),
+		IncludeResources: []string{"blueprints"},
+		OnConflict:       ConflictFail,
+	})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if creates.Load() == 0 {
+		t.Fatal("expected the unchanged existing blueprint to reach the importer")
+	}
+	if result.Success || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "already exists") {
+		t.Errorf("expected the existing blueprint to fail the import, got success=%v errors=%v", result.Success, result.Errors)
+	}
+}
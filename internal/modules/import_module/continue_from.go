@@ -0,0 +1,45 @@
+package import_module
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ImportOrder lists the resource types in the order an import applies them.
+// Resource types imported concurrently are listed in a fixed order.
+var ImportOrder = []string{
+	"blueprints",
+	"entities",
+	"scorecards",
+	"actions",
+	"automations",
+	"teams",
+	"users",
+	"integrations",
+	"datasources",
+	"pages",
+	"blueprint-permissions",
+	"action-permissions",
+	"page-permissions",
+}
+
+// ContinueFrom returns the resource types from resourceType onward in
+// ImportOrder, for resuming an import whose earlier types are already done.
+// When include is non-empty, only the included types are kept.
+func ContinueFrom(resourceType string, include []string) ([]string, error) {
+	start := slices.Index(ImportOrder, resourceType)
+	if start < 0 {
+		return nil, fmt.Errorf("unknown resource type %q: expected one of %s", resourceType, strings.Join(ImportOrder, ", "))
+	}
+	var resources []string
+	for _, r := range ImportOrder[start:] {
+		if len(include) == 0 || slices.Contains(include, r) {
+			resources = append(resources, r)
+		}
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("--include selects no resource type at or after %q", resourceType)
+	}
+	return resources, nil
+}
@@ -0,0 +1,38 @@
+package import_module
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContinueFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		include []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "suffix of the order", from: "pages", want: []string{"pages", "blueprint-permissions", "action-permissions", "page-permissions"}},
+		{name: "intersects include", from: "entities", include: []string{"blueprints", "entities", "pages"}, want: []string{"entities", "pages"}},
+		{name: "include before start", from: "pages", include: []string{"blueprints"}, wantErr: true},
+		{name: "unknown type", from: "widgets", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContinueFrom(tt.from, tt.include)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContinueFrom error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package import_module
+
+import "sync/atomic"
+
+// ResourceCounts tallies the outcome of writes for one resource type. It is
+// safe for concurrent use, so workers can record results without holding a
+// shared lock.
+type ResourceCounts struct {
+	Created atomic.Int64
+	Updated atomic.Int64
+	Skipped atomic.Int64
+}
+
+// take returns the current counts and resets them to zero.
+func (c *ResourceCounts) take() (created, updated, skipped int) {
+	return int(c.Created.Swap(0)), int(c.Updated.Swap(0)), int(c.Skipped.Swap(0))
+}
+
+// Counters aggregates ResourceCounts for every resource type an import or
+// migration writes.
+type Counters struct {
+	Blueprints           ResourceCounts
+	Entities             ResourceCounts
+	Scorecards           ResourceCounts
+	Actions              ResourceCounts
+	Teams                ResourceCounts
+	Users                ResourceCounts
+	Pages                ResourceCounts
+	Integrations         ResourceCounts
+	DataSources          ResourceCounts
+	BlueprintPermissions ResourceCounts
+	ActionPermissions    ResourceCounts
+	PagePermissions      ResourceCounts
+}
+
+// CountsSnapshot is a point-in-time copy of Counters, drained with Take.
+type CountsSnapshot struct {
+	BlueprintsCreated, BlueprintsUpdated, BlueprintsSkipped       int
+	EntitiesCreated, EntitiesUpdated, EntitiesSkipped             int
+	ScorecardsCreated, ScorecardsUpdated, ScorecardsSkipped       int
+	ActionsCreated, ActionsUpdated, ActionsSkipped                int
+	TeamsCreated, TeamsUpdated, TeamsSkipped                      int
+	UsersCreated, UsersUpdated, UsersSkipped                      int
+	PagesCreated, PagesUpdated, PagesSkipped                      int
+	IntegrationsCreated, IntegrationsUpdated, IntegrationsSkipped int
+	DataSourcesCreated, DataSourcesUpdated, DataSourcesSkipped    int
+	BlueprintPermissionsUpdated                                   int
+	ActionPermissionsUpdated                                      int
+	PagePermissionsUpdated                                        int
+}
+
+// Take returns the counts recorded so far and resets c, so the same Counters
+// can be flushed into a result repeatedly without double counting.
+func (c *Counters) Take() CountsSnapshot {
+	var s CountsSnapshot
+	s.BlueprintsCreated, s.BlueprintsUpdated, s.BlueprintsSkipped = c.Blueprints.take()
+	s.EntitiesCreated, s.EntitiesUpdated, s.EntitiesSkipped = c.Entities.take()
+	s.ScorecardsCreated, s.ScorecardsUpdated, s.ScorecardsSkipped = c.Scorecards.take()
+	s.ActionsCreated, s.ActionsUpdated, s.ActionsSkipped = c.Actions.take()
+	s.TeamsCreated, s.TeamsUpdated, s.TeamsSkipped = c.Teams.take()
+	s.UsersCreated, s.UsersUpdated, s.UsersSkipped = c.Users.take()
+	s.PagesCreated, s.PagesUpdated, s.PagesSkipped = c.Pages.take()
+	s.IntegrationsCreated, s.IntegrationsUpdated, s.IntegrationsSkipped = c.Integrations.take()
+	s.DataSourcesCreated, s.DataSourcesUpdated, s.DataSourcesSkipped = c.DataSources.take()
+	_, s.BlueprintPermissionsUpdated, _ = c.BlueprintPermissions.take()
+	_, s.ActionPermissionsUpdated, _ = c.ActionPermissions.take()
+	_, s.PagePermissionsUpdated, _ = c.PagePermissions.take()
+	return s
+}
+
+// From adds the counts recorded in c to r and resets c. Call it once the
+// workers writing to c have finished; it must not run concurrently with
+// another From on the same Result.
+func (r *Result) From(c *Counters) {
+	s := c.Take()
+	r.BlueprintsCreated += s.BlueprintsCreated
+	r.BlueprintsUpdated += s.BlueprintsUpdated
+	r.EntitiesCreated += s.EntitiesCreated
+	r.EntitiesUpdated += s.EntitiesUpdated
+	r.ScorecardsCreated += s.ScorecardsCreated
+	r.ScorecardsUpdated += s.ScorecardsUpdated
+	r.ActionsCreated += s.ActionsCreated
+	r.ActionsUpdated += s.ActionsUpdated
+	r.TeamsCreated += s.TeamsCreated
+	r.TeamsUpdated += s.TeamsUpdated
+	r.UsersCreated += s.UsersCreated
+	r.UsersUpdated += s.UsersUpdated
+	r.PagesCreated += s.PagesCreated
+	r.PagesUpdated += s.PagesUpdated
+	r.IntegrationsCreated += s.IntegrationsCreated
+	r.IntegrationsUpdated += s.IntegrationsUpdated
+	r.DataSourcesCreated += s.DataSourcesCreated
+	r.DataSourcesUpdated += s.DataSourcesUpdated
+	r.BlueprintPermissionsUpdated += s.BlueprintPermissionsUpdated
+	r.ActionPermissionsUpdated += s.ActionPermissionsUpdated
+	r.PagePermissionsUpdated += s.PagePermissionsUpdated
+	r.ResourcesSkipped += s.BlueprintsSkipped + s.EntitiesSkipped + s.ScorecardsSkipped +
+		s.ActionsSkipped + s.TeamsSkipped + s.UsersSkipped + s.PagesSkipped + s.IntegrationsSkipped +
+		s.DataSourcesSkipped
+}
@@ -0,0 +1,51 @@
+package import_module
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCounters_ConcurrentIncrements(t *testing.T) {
+	var counts Counters
+	var wg sync.WaitGroup
+	const workers, perWorker = 16, 500
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < perWorker; n++ {
+				counts.Blueprints.Created.Add(1)
+				counts.Entities.Updated.Add(2)
+				counts.PagePermissions.Updated.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := &Result{EntitiesUpdated: 3}
+	result.From(&counts)
+	if result.BlueprintsCreated != workers*perWorker {
+		t.Fatalf("BlueprintsCreated = %d, want %d", result.BlueprintsCreated, workers*perWorker)
+	}
+	if result.EntitiesUpdated != 3+2*workers*perWorker {
+		t.Fatalf("EntitiesUpdated = %d, want %d", result.EntitiesUpdated, 3+2*workers*perWorker)
+	}
+	if result.PagePermissionsUpdated != workers*perWorker {
+		t.Fatalf("PagePermissionsUpdated = %d, want %d", result.PagePermissionsUpdated, workers*perWorker)
+	}
+}
+
+func TestResultFrom_DrainsCounters(t *testing.T) {
+	var counts Counters
+	counts.Teams.Created.Add(2)
+
+	result := &Result{}
+	result.From(&counts)
+	result.From(&counts)
+	if result.TeamsCreated != 2 {
+		t.Fatalf("TeamsCreated = %d after flushing twice, want 2", result.TeamsCreated)
+	}
+	if got := counts.Teams.Created.Load(); got != 0 {
+		t.Fatalf("expected counters to be reset, got %d", got)
+	}
+}
@@ -0,0 +1,97 @@
+package import_module
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// dataSourceSystemFields are data source fields generated by the target
+// organization. The webhook URL and key are issued per organization, so the
+// ones of the source are never sent.
+var dataSourceSystemFields = []string{"id", "createdBy", "updatedBy", "createdAt", "updatedAt", "orgId", "url", "webhookKey"}
+
+// compareDataSources compares import data sources with current data sources
+// by identifier.
+func (d *DiffComparer) compareDataSources(importSources, currentSources []api.Webhook, includeResources []string) (create, update, skip []api.Webhook) {
+	if !shouldImport("datasources", includeResources) {
+		return nil, nil, nil
+	}
+
+	currentMap := make(map[string]api.Webhook)
+	for _, ds := range currentSources {
+		if identifier, ok := ds["identifier"].(string); ok {
+			currentMap[identifier] = ds
+		}
+	}
+
+	for _, ds := range importSources {
+		identifier, ok := ds["identifier"].(string)
+		if !ok || identifier == "" {
+			continue
+		}
+
+		currentDS, exists := currentMap[identifier]
+		if !exists {
+			create = append(create, ds)
+		} else if !d.unchanged(ds, currentDS, ignoredFields("datasources", ds)) {
+			update = append(update, ds)
+		} else {
+			skip = append(skip, ds)
+		}
+	}
+
+	return create, update, skip
+}
+
+// CreateDataSource creates a data source that does not exist in the target
+// organization. The target issues it a new webhook URL.
+func CreateDataSource(ctx context.Context, client *api.Client, ds api.Webhook) error {
+	_, err := client.CreateWebhook(ctx, cleanSystemFields(ds, dataSourceSystemFields))
+	return err
+}
+
+// UpdateDataSource replaces the configuration of an existing data source,
+// keeping its webhook URL.
+func UpdateDataSource(ctx context.Context, client *api.Client, ds api.Webhook) error {
+	identifier, _ := ds["identifier"].(string)
+	_, err := client.UpdateWebhook(ctx, identifier, cleanSystemFields(ds, dataSourceSystemFields))
+	return err
+}
+
+// importDataSources creates each data source, updating it instead when it
+// already exists in the target.
+func (i *Importer) importDataSources(ctx context.Context, sources []api.Webhook, pool *WorkerPool) {
+	for _, ds := range sources {
+		ds := ds
+		pool.Go(func() {
+			identifier, ok := ds["identifier"].(string)
+			if !ok || identifier == "" {
+				i.errors.Add(fmt.Errorf("data source is missing identifier field, skipping"), "datasource", "<unknown>")
+				return
+			}
+
+			err := CreateDataSource(ctx, i.client, ds)
+			if err == nil {
+				i.counts.DataSources.Created.Add(1)
+				i.reportResource(ResourceCreated, "datasource", identifier)
+				return
+			}
+			if !isConflictError(err) {
+				i.errors.Add(err, "datasource", identifier)
+				return
+			}
+
+			if !i.updateExisting(&i.counts.DataSources, "datasource", identifier) {
+				return
+			}
+			if err := UpdateDataSource(ctx, i.client, ds); err != nil {
+				i.errors.Add(err, "datasource", identifier)
+				return
+			}
+			i.counts.DataSources.Updated.Add(1)
+			i.reportResource(ResourceUpdated, "datasource", identifier)
+		})
+	}
+}
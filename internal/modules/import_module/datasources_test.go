@@ -0,0 +1,90 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestCompareDataSources(t *testing.T) {
+	current := []api.Webhook{
+		{"identifier": "github", "title": "GitHub", "url": "https://ingest.getport.io/abc", "webhookKey": "abc"},
+		{"identifier": "pagerduty", "title": "PagerDuty"},
+	}
+	imported := []api.Webhook{
+		{"identifier": "github", "title": "GitHub", "url": "https://ingest.getport.io/xyz", "webhookKey": "xyz"},
+		{"identifier": "pagerduty", "title": "PagerDuty incidents"},
+		{"identifier": "sentry", "title": "Sentry"},
+		{"title": "no identifier"},
+	}
+
+	d := &DiffComparer{}
+	create, update, skip := d.compareDataSources(imported, current, nil)
+	if len(create) != 1 || create[0]["identifier"] != "sentry" {
+		t.Errorf("expected sentry to be created, got %v", create)
+	}
+	if len(update) != 1 || update[0]["identifier"] != "pagerduty" {
+		t.Errorf("expected pagerduty to be updated, got %v", update)
+	}
+	// The webhook URL and key are issued per organization and do not count
+	// as a change.
+	if len(skip) != 1 || skip[0]["identifier"] != "github" {
+		t.Errorf("expected github to be skipped, got %v", skip)
+	}
+
+	if c, u, s := d.compareDataSources(imported, current, []string{"blueprints"}); c != nil || u != nil || s != nil {
+		t.Errorf("expected nothing when datasources are not included, got %v %v %v", c, u, s)
+	}
+}
+
+func TestImportDataSources_CreatesAndUpdatesOnConflict(t *testing.T) {
+	var created, updated []map[string]interface{}
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/webhooks":
+			if body["identifier"] == "github" {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "conflict"})
+				return
+			}
+			created = append(created, body)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "webhook": body})
+		case r.Method == http.MethodPatch && r.URL.Path == "/webhooks/github":
+			updated = append(updated, body)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "webhook": body})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	importer := NewImporter(client)
+	result := &Result{}
+	pool := NewWorkerPool(1)
+	importer.importDataSources(context.Background(), []api.Webhook{
+		{"identifier": "github", "url": "https://ingest.getport.io/abc", "webhookKey": "abc", "mappings": []interface{}{}},
+		{"identifier": "sentry", "createdAt": "2024-01-01T00:00:00Z"},
+	}, pool)
+	pool.Wait()
+	result.From(&importer.counts)
+
+	if errs := importer.errors.ToStringSlice(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	if result.DataSourcesCreated != 1 || result.DataSourcesUpdated != 1 {
+		t.Errorf("expected 1 created and 1 updated, got %d and %d", result.DataSourcesCreated, result.DataSourcesUpdated)
+	}
+	if len(created) != 1 || created[0]["createdAt"] != nil {
+		t.Errorf("expected sentry to be created without system fields, got %v", created)
+	}
+	if len(updated) != 1 || updated[0]["url"] != nil || updated[0]["webhookKey"] != nil {
+		t.Errorf("expected github to be updated without its webhook URL and key, got %v", updated)
+	}
+}
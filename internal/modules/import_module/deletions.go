@@ -0,0 +1,151 @@
+package import_module
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// ResourceDeleted is passed to ResourceCallback for each resource Prune or
+// ReplaceAll removes.
+const ResourceDeleted = "deleted"
+
+// deletionKinds lists the export sections a delta bundle can delete from, in
+// the order they are deleted: dependents before the resources they depend on,
+// so entities go before their blueprints and pages before their folders.
+// include is the --include resource type that selects the section.
+var deletionKinds = []struct {
+	section  string
+	resource string
+	include  string
+}{
+	{"entities", "entity", "entities"},
+	{"scorecards", "scorecard", "scorecards"},
+	{"actions", "action", "actions"},
+	{"pages", "page", "pages"},
+	{"_folders", "folder", "pages"},
+	{"integrations", "integration", "integrations"},
+	{"teams", "team", "teams"},
+	{"blueprints", "blueprint", "blueprints"},
+}
+
+// pruneDeletions deletes the resources listed in a delta bundle's _deletions
+// and returns how many were removed. Resources that are already gone count as
+// removed. Failures are collected like any other import error.
+func (i *Importer) pruneDeletions(ctx context.Context, deletions []export.Deletion, opts Options) int {
+	bySection := make(map[string][]export.Deletion)
+	known := make(map[string]bool, len(deletionKinds))
+	for _, kind := range deletionKinds {
+		known[kind.section] = true
+	}
+	for _, d := range deletions {
+		if !known[d.Type] {
+			i.errors.Add(fmt.Errorf("deleting %s is not supported", d.Type), d.Type, d.Identifier)
+			continue
+		}
+		bySection[d.Type] = append(bySection[d.Type], d)
+	}
+
+	deleted := 0
+	for _, kind := range deletionKinds {
+		if !pruneSelected(kind.section, kind.include, opts) {
+			continue
+		}
+		for _, d := range bySection[kind.section] {
+			if ctx.Err() != nil {
+				return deleted
+			}
+			id := d.Identifier
+			if d.Blueprint != "" {
+				id = d.Blueprint + "/" + d.Identifier
+			}
+			err := i.deleteResource(ctx, d)
+			if err != nil && !api.HasStatus(err, http.StatusNotFound) {
+				i.errors.Add(fmt.Errorf("failed to delete %s: %w", kind.resource, err), kind.resource, id)
+				continue
+			}
+			deleted++
+			i.reportResource(ResourceDeleted, kind.resource, id)
+		}
+	}
+	return deleted
+}
+
+// DeleteResources deletes deletions the way Prune does, dependents first,
+// and returns how many were removed. Failures are collected; see
+// CollectedErrors.
+func (i *Importer) DeleteResources(ctx context.Context, deletions []export.Deletion) int {
+	return i.pruneDeletions(ctx, deletions, Options{})
+}
+
+// deleteResource deletes one resource through the endpoint for its section.
+func (i *Importer) deleteResource(ctx context.Context, d export.Deletion) error {
+	switch d.Type {
+	case "entities":
+		return i.client.DeleteEntity(ctx, d.Blueprint, d.Identifier)
+	case "scorecards":
+		return i.client.DeleteScorecard(ctx, d.Blueprint, d.Identifier)
+	case "actions":
+		if d.Blueprint != "" {
+			return i.client.DeleteAction(ctx, d.Blueprint, d.Identifier)
+		}
+		return i.client.DeleteAutomation(ctx, d.Identifier)
+	case "pages":
+		return i.client.DeletePage(ctx, d.Identifier)
+	case "_folders":
+		return i.client.DeleteFolder(ctx, d.Identifier)
+	case "integrations":
+		return i.client.DeleteIntegration(ctx, d.Identifier)
+	case "teams":
+		return i.client.DeleteTeam(ctx, d.Identifier)
+	case "blueprints":
+		return i.client.DeleteBlueprint(ctx, d.Identifier)
+	}
+	return fmt.Errorf("deleting %s is not supported", d.Type)
+}
+
+// pruneSelected reports whether deletions from section are applied under
+// opts' resource selection.
+func pruneSelected(section, include string, opts Options) bool {
+	if section == "entities" && opts.SkipEntities {
+		return false
+	}
+	return shouldImport(include, opts.IncludeResources)
+}
+
+// countDeletions returns how many deletions pruneDeletions would attempt.
+func countDeletions(deletions []export.Deletion, opts Options) int {
+	return len(selectedDeletions(deletions, opts))
+}
+
+// selectedDeletions returns the deletions pruneDeletions would attempt.
+func selectedDeletions(deletions []export.Deletion, opts Options) []export.Deletion {
+	var selected []export.Deletion
+	for _, d := range deletions {
+		for _, kind := range deletionKinds {
+			if d.Type == "entities" && export.SkipsEntitiesOf(d.Blueprint, opts.SkipEntitiesFor) {
+				break
+			}
+			if kind.section == d.Type && pruneSelected(kind.section, kind.include, opts) {
+				selected = append(selected, d)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// appendDeletionsWarning warns when a bundle lists deletions that will not be
+// applied because --prune was not given.
+func appendDeletionsWarning(warnings []ValidationWarning, deletions []export.Deletion, prune bool) []ValidationWarning {
+	if len(deletions) == 0 || prune {
+		return warnings
+	}
+	return append(warnings, ValidationWarning{
+		Type:    "deletions_skipped",
+		Message: fmt.Sprintf("Input lists %d deleted resource(s); pass --prune to delete them from the target", len(deletions)),
+	})
+}
@@ -0,0 +1,92 @@
+package import_module
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestPruneDeletions_DeletesDependentsFirst(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/teams/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+
+	importer := NewImporter(client)
+	var reported []string
+	importer.SetResourceCallback(func(action, resourceType, identifier string) {
+		reported = append(reported, action+" "+resourceType+" "+identifier)
+	})
+	deletions := []export.Deletion{
+		{Type: "blueprints", Identifier: "legacy"},
+		{Type: "teams", Identifier: "gone"},
+		{Type: "users", Identifier: "someone@example.com"},
+		{Type: "entities", Identifier: "old", Blueprint: "legacy"},
+		{Type: "actions", Identifier: "deploy"},
+	}
+
+	got := importer.pruneDeletions(context.Background(), deletions, Options{})
+	if got != 4 {
+		t.Errorf("pruneDeletions = %d, want 4 (a missing team counts as deleted)", got)
+	}
+	wantPaths := []string{"/blueprints/legacy/entities/old", "/actions/deploy", "/teams/gone", "/blueprints/legacy"}
+	if !reflect.DeepEqual(deleted, wantPaths) {
+		t.Errorf("DELETE requests = %v, want %v", deleted, wantPaths)
+	}
+	if len(reported) != 5 || reported[0] != "failed users someone@example.com" || reported[1] != "deleted entity legacy/old" {
+		t.Errorf("reported = %v", reported)
+	}
+	errs := importer.CollectedErrors()
+	if len(errs) != 1 || !strings.Contains(errs[0], "deleting users is not supported") {
+		t.Errorf("errors = %v, want one unsupported users deletion", errs)
+	}
+}
+
+func TestPruneDeletions_RespectsResourceSelection(t *testing.T) {
+	deletions := []export.Deletion{
+		{Type: "entities", Identifier: "old", Blueprint: "service"},
+		{Type: "blueprints", Identifier: "legacy"},
+		{Type: "_folders", Identifier: "archive"},
+	}
+	if got := countDeletions(deletions, Options{SkipEntities: true}); got != 2 {
+		t.Errorf("countDeletions with SkipEntities = %d, want 2", got)
+	}
+	if got := countDeletions(deletions, Options{SkipEntitiesFor: []string{"serv*"}}); got != 2 {
+		t.Errorf("countDeletions with SkipEntitiesFor = %d, want 2", got)
+	}
+	if got := countDeletions(deletions, Options{IncludeResources: []string{"pages"}}); got != 1 {
+		t.Errorf("countDeletions for pages = %d, want 1 (folders go with pages)", got)
+	}
+}
+
+func TestAppendDeletionsWarning(t *testing.T) {
+	deletions := []export.Deletion{{Type: "blueprints", Identifier: "legacy"}}
+	if warnings := appendDeletionsWarning(nil, deletions, true); len(warnings) != 0 {
+		t.Errorf("expected no warning with --prune, got %v", warnings)
+	}
+	warnings := appendDeletionsWarning(nil, deletions, false)
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "--prune") {
+		t.Errorf("expected a warning pointing at --prune, got %v", warnings)
+	}
+}
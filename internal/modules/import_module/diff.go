@@ -41,16 +41,30 @@ type DiffResult struct {
 	PagesToCreate        []api.Page
 	PagesToUpdate        []api.Page
 	PagesToSkip          []api.Page
+	IntegrationsToCreate []api.Integration
 	IntegrationsToUpdate []api.Integration
 	IntegrationsToSkip   []api.Integration
+	DataSourcesToCreate  []api.Webhook
+	DataSourcesToUpdate  []api.Webhook
+	DataSourcesToSkip    []api.Webhook
 	BlueprintPermissions []PermissionsChange
 	ActionPermissions    []PermissionsChange
 	PagePermissions      []PermissionsChange
+	TeamMemberships      []TeamMembershipChange
+
+	// Current is the target organization's state the import was compared against.
+	Current *export.Data
+	// Deletions are the resources the import will delete from the target, set
+	// under Prune and ReplaceAll.
+	Deletions []export.Deletion
 }
 
 // DiffComparer compares import data with current organization state.
 type DiffComparer struct {
 	client *api.Client
+	// forceUpdate plans every existing resource as an update, even when it
+	// compares equal to the target.
+	forceUpdate bool
 }
 
 // NewDiffComparer creates a new diff comparer.
@@ -68,27 +82,36 @@ func (d *DiffComparer) Compare(ctx context.Context, importData *export.Data, opt
 		return nil, fmt.Errorf("failed to export current state: %w", err)
 	}
 
-	result := &DiffResult{}
+	result := &DiffResult{Current: currentData}
+	// Under ConflictFail every existing resource is a conflict, unchanged
+	// or not, so each is planned as an update for the importer to report.
+	d.forceUpdate = opts.ForceUpdate || opts.OnConflict == ConflictFail
 
 	// Compare each resource type
-	result.BlueprintsToCreate, result.BlueprintsToUpdate, result.BlueprintsToSkip = d.compareBlueprints(importData.Blueprints, currentData.Blueprints, opts.IncludeResources)
-	result.EntitiesToCreate, result.EntitiesToUpdate, result.EntitiesToSkip = d.compareEntities(importData.Entities, currentData.Entities, opts.IncludeResources)
+	result.BlueprintsToCreate, result.BlueprintsToUpdate, result.BlueprintsToSkip = d.compareBlueprints(importData.Blueprints, currentData.Blueprints, opts.IncludeResources, opts.IncludeSystemBlueprints)
+	result.EntitiesToCreate, result.EntitiesToUpdate, result.EntitiesToSkip = d.compareEntities(importData.Entities, currentData.Entities, computedProperties(currentData.Blueprints, importData.Blueprints), opts.IncludeResources)
 	result.ScorecardsToCreate, result.ScorecardsToUpdate, result.ScorecardsToSkip = d.compareScorecards(importData.Scorecards, currentData.Scorecards, opts.IncludeResources)
 	result.ActionsToCreate, result.ActionsToUpdate, result.ActionsToSkip = d.compareActions(importData.Actions, currentData.Actions, opts.IncludeResources)
 	result.TeamsToCreate, result.TeamsToUpdate, result.TeamsToSkip = d.compareTeams(importData.Teams, currentData.Teams, opts.IncludeResources)
+	// Current teams, and so their members, are only known when teams were
+	// exported from the target.
+	if !opts.SkipEntities && shouldImport("teams", opts.IncludeResources) {
+		result.TeamMemberships = CompareTeamMemberships(importData.Teams, currentData.Teams)
+	}
 	result.UsersToCreate, result.UsersToUpdate, result.UsersToSkip = d.compareUsers(importData.Users, currentData.Users, opts.IncludeResources)
 	result.PagesToCreate, result.PagesToUpdate, result.PagesToSkip = d.comparePages(importData.Pages, currentData.Pages, opts.IncludeResources)
-	result.IntegrationsToUpdate, result.IntegrationsToSkip = d.compareIntegrations(importData.Integrations, currentData.Integrations, opts.IncludeResources)
+	result.IntegrationsToCreate, result.IntegrationsToUpdate, result.IntegrationsToSkip = d.compareIntegrations(importData.Integrations, currentData.Integrations, opts.IncludeResources, opts.CreateIntegrations)
+	result.DataSourcesToCreate, result.DataSourcesToUpdate, result.DataSourcesToSkip = d.compareDataSources(importData.DataSources, currentData.DataSources, opts.IncludeResources)
 
 	// Compare permissions when included (or when no --include filter is set)
 	if shouldImport("blueprint-permissions", opts.IncludeResources) {
-		result.BlueprintPermissions = comparePermissions(currentData.BlueprintPermissions, importData.BlueprintPermissions)
+		result.BlueprintPermissions = comparePermissions(currentData.BlueprintPermissions, importData.BlueprintPermissions, opts.ForceUpdate)
 	}
 	if shouldImport("action-permissions", opts.IncludeResources) {
-		result.ActionPermissions = comparePermissions(currentData.ActionPermissions, importData.ActionPermissions)
+		result.ActionPermissions = comparePermissions(currentData.ActionPermissions, importData.ActionPermissions, opts.ForceUpdate)
 	}
 	if shouldImport("page-permissions", opts.IncludeResources) {
-		result.PagePermissions = comparePermissions(currentData.PagePermissions, importData.PagePermissions)
+		result.PagePermissions = comparePermissions(currentData.PagePermissions, importData.PagePermissions, opts.ForceUpdate)
 	}
 
 	return result, nil
@@ -104,10 +127,26 @@ func (d *DiffComparer) exportCurrentState(ctx context.Context, opts Options) (*e
 		IncludeResources:       opts.IncludeResources,
 		ExcludeBlueprints:      opts.ExcludeBlueprints,
 		ExcludeBlueprintSchema: opts.ExcludeBlueprintSchema,
+		SkipEntitiesFor:        opts.SkipEntitiesFor,
 	}
 	return collector.Collect(ctx, exportOpts)
 }
 
+// RecordEntityChange adds a streamed entity to EntitiesToCreate or, when
+// current holds the target's version of it, to EntitiesToUpdate, keeping
+// current in Current so reports and update previews can diff the two.
+func (d *DiffResult) RecordEntityChange(entity, current api.Entity) {
+	if current == nil {
+		d.EntitiesToCreate = append(d.EntitiesToCreate, entity)
+		return
+	}
+	if d.Current == nil {
+		d.Current = &export.Data{}
+	}
+	d.Current.Entities = append(d.Current.Entities, current)
+	d.EntitiesToUpdate = append(d.EntitiesToUpdate, entity)
+}
+
 // FilterData filters import data to only include resources that need to be created or updated.
 func (d *DiffResult) FilterData(original *export.Data) *export.Data {
 	return &export.Data{
@@ -119,10 +158,24 @@ func (d *DiffResult) FilterData(original *export.Data) *export.Data {
 		Users:        append(d.UsersToCreate, d.UsersToUpdate...),
 		Folders:      original.Folders,
 		Pages:        append(d.PagesToCreate, d.PagesToUpdate...),
-		Integrations: d.IntegrationsToUpdate,
+		Integrations: append(d.IntegrationsToCreate, d.IntegrationsToUpdate...),
+		DataSources:  append(d.DataSourcesToCreate, d.DataSourcesToUpdate...),
+		Deletions:    original.Deletions,
+		Manifest:     original.Manifest,
 	}
 }
 
+// orderSignificantArrayKeys lists fields whose arrays of objects keep their
+// order during comparison because the order carries meaning: page widgets are
+// rendered in order, scorecard levels are ranked, and integration mapping
+// resources are applied sequentially. Arrays of objects under any other key
+// are compared order-insensitively.
+var orderSignificantArrayKeys = map[string]bool{
+	"widgets":   true,
+	"levels":    true,
+	"resources": true,
+}
+
 // normalizeResource normalizes a resource by removing system fields and ensuring consistent structure.
 func normalizeResource(resource map[string]interface{}, systemFields []string) map[string]interface{} {
 	normalized := make(map[string]interface{})
@@ -133,48 +186,87 @@ func normalizeResource(resource map[string]interface{}, systemFields []string) m
 
 	for k, v := range resource {
 		if !removeSet[k] {
-			normalized[k] = normalizeValue(v)
+			normalized[k] = normalizeField(k, v)
 		}
 	}
 
 	return normalized
 }
 
-// normalizeValue recursively normalizes a value for comparison.
+// normalizeValue recursively normalizes a value for comparison. Numbers need
+// no handling: both sides are decoded by encoding/json, which turns 5 and 5.0
+// alike into float64. Map key order is made canonical by JSON marshaling in
+// resourcesEqual.
 func normalizeValue(v interface{}) interface{} {
+	return normalizeField("", v)
+}
+
+// normalizeField normalizes v, the value stored under key in its parent map.
+// The key decides whether an array of objects may be reordered.
+func normalizeField(key string, v interface{}) interface{} {
 	switch val := v.(type) {
 	case map[string]interface{}:
 		normalized := make(map[string]interface{})
 		for k, v := range val {
-			normalized[k] = normalizeValue(v)
+			normalized[k] = normalizeField(k, v)
 		}
 		return normalized
 	case []interface{}:
 		normalized := make([]interface{}, len(val))
 		for i, item := range val {
-			normalized[i] = normalizeValue(item)
-		}
-		// Sort slice only if ALL elements are strings
-		if len(normalized) > 0 {
-			allStrings := true
-			for _, item := range normalized {
-				if _, ok := item.(string); !ok {
-					allStrings = false
-					break
-				}
-			}
-			if allStrings {
-				sort.Slice(normalized, func(i, j int) bool {
-					return normalized[i].(string) < normalized[j].(string)
-				})
-			}
+			normalized[i] = normalizeField("", item)
 		}
+		sortNormalizedSlice(normalized, !orderSignificantArrayKeys[key])
 		return normalized
 	default:
 		return v
 	}
 }
 
+// sortNormalizedSlice sorts a normalized slice in place when ALL elements are
+// strings, or, if sortObjects is set, when ALL elements are maps. Maps are
+// ordered by their canonical JSON serialization. Mixed slices are left as-is.
+func sortNormalizedSlice(normalized []interface{}, sortObjects bool) {
+	if len(normalized) == 0 {
+		return
+	}
+	allStrings, allMaps := true, true
+	for _, item := range normalized {
+		if _, ok := item.(string); !ok {
+			allStrings = false
+		}
+		if _, ok := item.(map[string]interface{}); !ok {
+			allMaps = false
+		}
+	}
+
+	switch {
+	case allStrings:
+		sort.Slice(normalized, func(i, j int) bool {
+			return normalized[i].(string) < normalized[j].(string)
+		})
+	case allMaps && sortObjects:
+		type keyedItem struct {
+			key  string
+			item interface{}
+		}
+		keyed := make([]keyedItem, len(normalized))
+		for i, item := range normalized {
+			b, err := json.Marshal(item)
+			if err != nil {
+				return
+			}
+			keyed[i] = keyedItem{key: string(b), item: item}
+		}
+		sort.SliceStable(keyed, func(i, j int) bool {
+			return keyed[i].key < keyed[j].key
+		})
+		for i := range keyed {
+			normalized[i] = keyed[i].item
+		}
+	}
+}
+
 // resourcesEqual checks if two resources are equal after normalization.
 func resourcesEqual(a, b map[string]interface{}, systemFields []string) bool {
 	normA := normalizeResource(a, systemFields)
@@ -198,8 +290,10 @@ var portManagedBlueprints = map[string]bool{
 	"_rule": true, // Managed through scorecards, not directly editable
 }
 
-// compareBlueprints compares import blueprints with current blueprints.
-func (d *DiffComparer) compareBlueprints(importBPs, currentBPs []api.Blueprint, includeResources []string) (create, update, skip []api.Blueprint) {
+// compareBlueprints compares import blueprints with current blueprints. With
+// includeSystem, Port-managed blueprints are diffed like any other, and system
+// blueprints missing from the target are skipped rather than created.
+func (d *DiffComparer) compareBlueprints(importBPs, currentBPs []api.Blueprint, includeResources []string, includeSystem bool) (create, update, skip []api.Blueprint) {
 	if !shouldImport("blueprints", includeResources) {
 		return nil, nil, nil
 	}
@@ -221,7 +315,7 @@ func (d *DiffComparer) compareBlueprints(importBPs, currentBPs []api.Blueprint,
 
 		// Skip Port-managed blueprints that cannot be modified directly, unless
 		// the input is a minimal custom-property patch.
-		if portManagedBlueprints[identifier] && !isSystemPatch {
+		if portManagedBlueprints[identifier] && !isSystemPatch && !includeSystem {
 			skip = append(skip, bp)
 			continue
 		}
@@ -231,13 +325,15 @@ func (d *DiffComparer) compareBlueprints(importBPs, currentBPs []api.Blueprint,
 		// system blueprints may fail, which is expected behavior.
 
 		currentBP, exists := currentMap[identifier]
-		if !exists {
+		if !exists && includeSystem && IsSystemBlueprint(identifier) {
+			skip = append(skip, bp)
+		} else if !exists {
 			create = append(create, bp)
-		} else if isSystemPatch && !systemblueprints.CustomPatchEqual(bp, currentBP) {
+		} else if isSystemPatch && (d.forceUpdate || !systemblueprints.CustomPatchEqual(bp, currentBP)) {
 			update = append(update, bp)
 		} else if isSystemPatch {
 			skip = append(skip, bp)
-		} else if !resourcesEqual(bp, currentBP, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(bp, currentBP, ignoredFields("blueprints", bp)) {
 			update = append(update, bp)
 		} else {
 			skip = append(skip, bp)
@@ -247,34 +343,126 @@ func (d *DiffComparer) compareBlueprints(importBPs, currentBPs []api.Blueprint,
 	return create, update, skip
 }
 
-// compareEntities compares import entities with current entities.
-func (d *DiffComparer) compareEntities(importEnts, currentEnts []api.Entity, includeResources []string) (create, update, skip []api.Entity) {
+// auditFields are generated by the target on every resource.
+var auditFields = []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}
+
+// ignoredFields returns the fields the diff ignores when comparing desired, a
+// resource of resourceType (named as in --include), with its current version.
+func ignoredFields(resourceType string, desired map[string]interface{}) []string {
+	switch resourceType {
+	case "teams":
+		return append(append([]string{}, auditFields...), teamMembersField)
+	case "pages":
+		return pageIgnoredFields(api.Page(desired))
+	case "datasources":
+		return dataSourceSystemFields
+	default:
+		return auditFields
+	}
+}
+
+// NormalizedForDiff returns desired and current, two versions of a resource of
+// resourceType (named as in --include), as the diff compares them: without
+// the fields it ignores or, for entities, the properties their blueprint
+// computes, and with order-insensitive arrays sorted. They differ exactly
+// where the import would change the resource.
+func (r *DiffResult) NormalizedForDiff(resourceType string, desired, current map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if resourceType == "entities" {
+		var blueprints []api.Blueprint
+		if r.Current != nil {
+			blueprints = r.Current.Blueprints
+		}
+		computed := computedProperties(blueprints, r.BlueprintsToCreate, r.BlueprintsToUpdate, r.BlueprintsToSkip)
+		desired = withoutComputedProperties(desired, computed)
+		current = withoutComputedProperties(current, computed)
+	}
+	ignored := ignoredFields(resourceType, desired)
+	return normalizeResource(desired, ignored), normalizeResource(current, ignored)
+}
+
+// unchanged reports whether an existing resource can be skipped because it
+// equals its current state, ignoring systemFields. Under --force-update
+// nothing is unchanged.
+func (d *DiffComparer) unchanged(desired, current map[string]interface{}, systemFields []string) bool {
+	return !d.forceUpdate && resourcesEqual(desired, current, systemFields)
+}
+
+// computedPropertyFields are the blueprint fields whose properties Port
+// computes on every entity. The API returns their values with the entity's
+// properties, but an import file cannot set them.
+var computedPropertyFields = []string{"mirrorProperties", "calculationProperties", "aggregationProperties"}
+
+// computedProperties returns the computed property identifiers of each
+// blueprint, by blueprint identifier, across all the given blueprint lists.
+func computedProperties(blueprintLists ...[]api.Blueprint) map[string]map[string]bool {
+	computed := make(map[string]map[string]bool)
+	for _, blueprints := range blueprintLists {
+		for _, bp := range blueprints {
+			identifier, _ := bp["identifier"].(string)
+			if identifier == "" {
+				continue
+			}
+			for _, field := range computedPropertyFields {
+				props, _ := bp[field].(map[string]interface{})
+				for name := range props {
+					if computed[identifier] == nil {
+						computed[identifier] = make(map[string]bool)
+					}
+					computed[identifier][name] = true
+				}
+			}
+		}
+	}
+	return computed
+}
+
+// withoutComputedProperties returns a copy of ent without the properties its
+// blueprint computes, or ent itself when there are none to remove.
+func withoutComputedProperties(ent api.Entity, computed map[string]map[string]bool) api.Entity {
+	blueprint, _ := ent["blueprint"].(string)
+	props, ok := ent["properties"].(map[string]interface{})
+	if !ok || len(computed[blueprint]) == 0 {
+		return ent
+	}
+	kept := make(map[string]interface{}, len(props))
+	for name, v := range props {
+		if !computed[blueprint][name] {
+			kept[name] = v
+		}
+	}
+	stripped := make(api.Entity, len(ent))
+	for k, v := range ent {
+		stripped[k] = v
+	}
+	stripped["properties"] = kept
+	return stripped
+}
+
+// compareEntities compares import entities with current entities. Properties
+// in computed, by blueprint, are ignored: mirror, calculation and aggregation
+// values come back from the API but are never part of an import file.
+func (d *DiffComparer) compareEntities(importEnts, currentEnts []api.Entity, computed map[string]map[string]bool, includeResources []string) (create, update, skip []api.Entity) {
 	if !shouldImport("entities", includeResources) {
 		return nil, nil, nil
 	}
 
-	currentMap := make(map[string]api.Entity)
+	currentMap := make(map[ResourceKey]api.Entity)
 	for _, ent := range currentEnts {
-		blueprintID, ok1 := ent["blueprint"].(string)
-		entityID, ok2 := ent["identifier"].(string)
-		if ok1 && ok2 {
-			key := fmt.Sprintf("%s:%s", blueprintID, entityID)
+		if key, ok := EntityKey(ent); ok {
 			currentMap[key] = ent
 		}
 	}
 
 	for _, ent := range importEnts {
-		blueprintID, ok1 := ent["blueprint"].(string)
-		entityID, ok2 := ent["identifier"].(string)
-		if !ok1 || !ok2 || blueprintID == "" || entityID == "" {
+		key, ok := EntityKey(ent)
+		if !ok {
 			continue
 		}
 
-		key := fmt.Sprintf("%s:%s", blueprintID, entityID)
 		currentEnt, exists := currentMap[key]
 		if !exists {
 			create = append(create, ent)
-		} else if !resourcesEqual(ent, currentEnt, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(withoutComputedProperties(ent, computed), withoutComputedProperties(currentEnt, computed), ignoredFields("entities", ent)) {
 			update = append(update, ent)
 		} else {
 			skip = append(skip, ent)
@@ -290,28 +478,23 @@ func (d *DiffComparer) compareScorecards(importScs, currentScs []api.Scorecard,
 		return nil, nil, nil
 	}
 
-	currentMap := make(map[string]api.Scorecard)
+	currentMap := make(map[ResourceKey]api.Scorecard)
 	for _, sc := range currentScs {
-		blueprintID, ok1 := sc["blueprintIdentifier"].(string)
-		scorecardID, ok2 := sc["identifier"].(string)
-		if ok1 && ok2 {
-			key := fmt.Sprintf("%s:%s", blueprintID, scorecardID)
+		if key, ok := ScorecardKey(sc); ok {
 			currentMap[key] = sc
 		}
 	}
 
 	for _, sc := range importScs {
-		blueprintID, ok1 := sc["blueprintIdentifier"].(string)
-		scorecardID, ok2 := sc["identifier"].(string)
-		if !ok1 || !ok2 || blueprintID == "" || scorecardID == "" {
+		key, ok := ScorecardKey(sc)
+		if !ok {
 			continue
 		}
 
-		key := fmt.Sprintf("%s:%s", blueprintID, scorecardID)
 		currentSc, exists := currentMap[key]
 		if !exists {
 			create = append(create, sc)
-		} else if !resourcesEqual(sc, currentSc, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(sc, currentSc, ignoredFields("scorecards", sc)) {
 			update = append(update, sc)
 		} else {
 			skip = append(skip, sc)
@@ -343,7 +526,7 @@ func (d *DiffComparer) compareActions(importActs, currentActs []api.Action, incl
 		currentAct, exists := currentMap[identifier]
 		if !exists {
 			create = append(create, act)
-		} else if !resourcesEqual(act, currentAct, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(act, currentAct, ignoredFields("actions", act)) {
 			update = append(update, act)
 		} else {
 			skip = append(skip, act)
@@ -353,7 +536,8 @@ func (d *DiffComparer) compareActions(importActs, currentActs []api.Action, incl
 	return create, update, skip
 }
 
-// compareTeams compares import teams with current teams.
+// compareTeams compares import teams with current teams. Members are compared
+// separately by CompareTeamMemberships.
 func (d *DiffComparer) compareTeams(importTeams, currentTeams []api.Team, includeResources []string) (create, update, skip []api.Team) {
 	if !shouldImport("teams", includeResources) {
 		return nil, nil, nil
@@ -375,7 +559,7 @@ func (d *DiffComparer) compareTeams(importTeams, currentTeams []api.Team, includ
 		currentTeam, exists := currentMap[name]
 		if !exists {
 			create = append(create, team)
-		} else if !resourcesEqual(team, currentTeam, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(team, currentTeam, ignoredFields("teams", team)) {
 			update = append(update, team)
 		} else {
 			skip = append(skip, team)
@@ -407,7 +591,7 @@ func (d *DiffComparer) compareUsers(importUsers, currentUsers []api.User, includ
 		currentUser, exists := currentMap[email]
 		if !exists {
 			create = append(create, user)
-		} else if !resourcesEqual(user, currentUser, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		} else if !d.unchanged(user, currentUser, ignoredFields("users", user)) {
 			update = append(update, user)
 		} else {
 			skip = append(skip, user)
@@ -417,7 +601,13 @@ func (d *DiffComparer) compareUsers(importUsers, currentUsers []api.User, includ
 	return create, update, skip
 }
 
-// pagesEqual compares two pages for equality.
+// pagesEqual compares two pages for equality, ignoring pageIgnoredFields.
+func pagesEqual(importPage, currentPage api.Page) bool {
+	return resourcesEqual(map[string]interface{}(importPage), map[string]interface{}(currentPage), pageIgnoredFields(importPage))
+}
+
+// pageIgnoredFields returns the fields ignored when comparing importPage with
+// the current page.
 //
 // Nav fields that are nil/null in the import page are excluded from comparison —
 // we don't send null nav fields to Port (sending null clears existing values),
@@ -425,7 +615,7 @@ func (d *DiffComparer) compareUsers(importUsers, currentUsers []api.User, includ
 //
 // requiredQueryParams: null and [] are both treated as "empty" and excluded
 // when the source value is empty, since we strip it before sending.
-func pagesEqual(importPage, currentPage api.Page) bool {
+func pageIgnoredFields(importPage api.Page) []string {
 	exclude := []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id", "protected"}
 
 	for _, field := range pageNavFields {
@@ -442,8 +632,7 @@ func pagesEqual(importPage, currentPage api.Page) bool {
 			exclude = append(exclude, field)
 		}
 	}
-
-	return resourcesEqual(map[string]interface{}(importPage), map[string]interface{}(currentPage), exclude)
+	return exclude
 }
 
 // comparePages compares import pages with current pages.
@@ -475,7 +664,7 @@ func (d *DiffComparer) comparePages(importPages, currentPages []api.Page, includ
 		currentPage, exists := currentMap[identifier]
 		if !exists {
 			create = append(create, page)
-		} else if !pagesEqual(page, currentPage) {
+		} else if d.forceUpdate || !pagesEqual(page, currentPage) {
 			update = append(update, page)
 		} else {
 			skip = append(skip, page)
@@ -486,9 +675,12 @@ func (d *DiffComparer) comparePages(importPages, currentPages []api.Page, includ
 }
 
 // compareIntegrations compares import integrations with current integrations.
-func (d *DiffComparer) compareIntegrations(importInts, currentInts []api.Integration, includeResources []string) (update, skip []api.Integration) {
+// Integrations missing from the target are only planned for creation when
+// createMissing is set, since installing them usually needs credentials that
+// are not part of an export.
+func (d *DiffComparer) compareIntegrations(importInts, currentInts []api.Integration, includeResources []string, createMissing bool) (create, update, skip []api.Integration) {
 	if !shouldImport("integrations", includeResources) {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	currentMap := make(map[string]api.Integration)
@@ -506,25 +698,27 @@ func (d *DiffComparer) compareIntegrations(importInts, currentInts []api.Integra
 
 		currentInteg, exists := currentMap[identifier]
 		if !exists {
-			// Integration doesn't exist, skip (can't create integrations)
-			continue
-		} else if !resourcesEqual(integ, currentInteg, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+			if createMissing {
+				create = append(create, integ)
+			}
+		} else if !d.unchanged(integ, currentInteg, ignoredFields("integrations", integ)) {
 			update = append(update, integ)
 		} else {
 			skip = append(skip, integ)
 		}
 	}
 
-	return update, skip
+	return create, update, skip
 }
 
 // comparePermissions compares desired permissions against current permissions and
-// returns a slice of changes for entries that are new or differ from current state.
-func comparePermissions(current, desired map[string]api.Permissions) []PermissionsChange {
+// returns a slice of changes for entries that are new or differ from current
+// state, or for every entry with force.
+func comparePermissions(current, desired map[string]api.Permissions, force bool) []PermissionsChange {
 	var changes []PermissionsChange
 	for id, desiredPerms := range desired {
 		currentPerms, exists := current[id]
-		if !exists || !resourcesEqual(
+		if force || !exists || !resourcesEqual(
 			map[string]interface{}(desiredPerms),
 			map[string]interface{}(currentPerms),
 			nil,
@@ -2,6 +2,9 @@ package import_module
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/port-experimental/port-cli/internal/api"
@@ -20,7 +23,7 @@ func TestComparePermissions_BlueprintDiff(t *testing.T) {
 		"service": {"entities": map[string]interface{}{"view": []string{"$admin"}}},
 	}
 
-	changes := comparePermissions(current, desired)
+	changes := comparePermissions(current, desired, false)
 	if len(changes) == 0 {
 		t.Error("expected blueprint permissions diff")
 	}
@@ -34,7 +37,7 @@ func TestComparePermissions_ActionDiff(t *testing.T) {
 		"deploy": {"execute": map[string]interface{}{"users": []string{"alice@example.com"}}},
 	}
 
-	changes := comparePermissions(current, desired)
+	changes := comparePermissions(current, desired, false)
 	if len(changes) == 0 {
 		t.Error("expected action permissions diff")
 	}
@@ -45,7 +48,7 @@ func TestComparePermissions_NoChange(t *testing.T) {
 		"service": {"entities": map[string]interface{}{"view": []string{"$team"}}},
 	}
 
-	changes := comparePermissions(perms, perms)
+	changes := comparePermissions(perms, perms, false)
 	if len(changes) != 0 {
 		t.Errorf("expected no changes, got %d", len(changes))
 	}
@@ -57,7 +60,7 @@ func TestComparePermissions_NewEntry(t *testing.T) {
 		"service": {"entities": map[string]interface{}{"view": []string{"$admin"}}},
 	}
 
-	changes := comparePermissions(current, desired)
+	changes := comparePermissions(current, desired, false)
 	if len(changes) != 1 {
 		t.Errorf("expected 1 change, got %d", len(changes))
 	}
@@ -85,7 +88,7 @@ func TestCompareBlueprints_AllowsRuleCustomSystemBlueprintPatch(t *testing.T) {
 		},
 	}
 
-	_, update, skip := comparer.compareBlueprints(source, current, nil)
+	_, update, skip := comparer.compareBlueprints(source, current, nil, false)
 	if len(skip) != 0 {
 		t.Fatalf("expected _rule custom patch not to be skipped, got %#v", skip)
 	}
@@ -117,7 +120,7 @@ func TestComparePermissions_DetectsExtraFieldsAsChange(t *testing.T) {
 		},
 	}
 
-	changes := comparePermissions(current, desired)
+	changes := comparePermissions(current, desired, false)
 	if len(changes) != 1 {
 		t.Errorf("expected 1 change (extra field in current is a diff), got %d", len(changes))
 	}
@@ -135,7 +138,7 @@ func TestComparePermissions_NormalizesStringSliceOrder(t *testing.T) {
 		},
 	}
 
-	changes := comparePermissions(current, desired)
+	changes := comparePermissions(current, desired, false)
 	if len(changes) != 0 {
 		t.Errorf("expected no changes (string slice order should be normalized), got %d", len(changes))
 	}
@@ -184,3 +187,303 @@ func TestCompare_ActionPermissions(t *testing.T) {
 
 // Compile-time check: Context import used to avoid unused import error.
 var _ = context.Background
+
+func TestCompareIntegrations_CreateMissing(t *testing.T) {
+	d := &DiffComparer{}
+	importInts := []api.Integration{
+		{"identifier": "existing", "config": map[string]interface{}{"a": "b"}},
+		{"identifier": "missing", "config": map[string]interface{}{"a": "b"}},
+	}
+	currentInts := []api.Integration{
+		{"identifier": "existing", "config": map[string]interface{}{"a": "c"}},
+	}
+
+	create, update, _ := d.compareIntegrations(importInts, currentInts, nil, false)
+	if len(create) != 0 {
+		t.Errorf("expected no integrations to create without createMissing, got %d", len(create))
+	}
+	if len(update) != 1 {
+		t.Errorf("expected 1 integration to update, got %d", len(update))
+	}
+
+	create, _, _ = d.compareIntegrations(importInts, currentInts, nil, true)
+	if len(create) != 1 || create[0]["identifier"] != "missing" {
+		t.Errorf("expected missing integration to be created, got %v", create)
+	}
+}
+
+func TestCompareBlueprints_IntegerValuedFloatIsUnchanged(t *testing.T) {
+	// The bundle and the API encode the same default differently; both are
+	// decoded with encoding/json, as the importer does.
+	decode := func(raw string) api.Blueprint {
+		t.Helper()
+		var bp api.Blueprint
+		if err := json.Unmarshal([]byte(raw), &bp); err != nil {
+			t.Fatal(err)
+		}
+		return bp
+	}
+	bundle := decode(`{"identifier":"svc","schema":{"properties":{"replicas":{"type":"number","default":5}}}}`)
+	existing := decode(`{"identifier":"svc","schema":{"properties":{"replicas":{"type":"number","default":5.0}}}}`)
+	changed := decode(`{"identifier":"svc","schema":{"properties":{"replicas":{"type":"number","default":5.5}}}}`)
+
+	comparer := &DiffComparer{}
+	create, update, _ := comparer.compareBlueprints([]api.Blueprint{bundle}, []api.Blueprint{existing}, nil, false)
+	if len(create) != 0 || len(update) != 0 {
+		t.Errorf("expected 5 and 5.0 to compare equal, got %d creates and %d updates", len(create), len(update))
+	}
+
+	_, update, _ = comparer.compareBlueprints([]api.Blueprint{changed}, []api.Blueprint{existing}, nil, false)
+	if len(update) != 1 {
+		t.Errorf("expected 5.5 and 5.0 to differ, got %d updates", len(update))
+	}
+}
+
+func TestResourcesEqual_BoolAndStringDiffer(t *testing.T) {
+	a := map[string]interface{}{"required": true}
+	b := map[string]interface{}{"required": "true"}
+	if resourcesEqual(a, b, nil) {
+		t.Error("expected true and \"true\" to differ")
+	}
+}
+
+func TestResourcesEqual_ReorderedMapKeys(t *testing.T) {
+	var a, b map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"identifier":"svc","schema":{"properties":{"x":{"type":"number","default":1}},"required":[]}}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"schema":{"required":[],"properties":{"x":{"default":1.0,"type":"number"}}},"identifier":"svc"}`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !resourcesEqual(a, b, nil) {
+		t.Error("expected resources with reordered keys to compare equal")
+	}
+}
+
+func TestResourcesEqual_ReorderedScorecardRules(t *testing.T) {
+	var a, b map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"identifier":"sc","rules":[
+		{"identifier":"has-readme","level":"Bronze","query":{"combinator":"and","conditions":[{"property":"readme","operator":"isNotEmpty"}]}},
+		{"identifier":"has-owner","level":"Silver","query":{"combinator":"and","conditions":[{"property":"$team","operator":"isNotEmpty"}]}}
+	]}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"identifier":"sc","rules":[
+		{"level":"Silver","identifier":"has-owner","query":{"conditions":[{"operator":"isNotEmpty","property":"$team"}],"combinator":"and"}},
+		{"identifier":"has-readme","level":"Bronze","query":{"combinator":"and","conditions":[{"property":"readme","operator":"isNotEmpty"}]}}
+	]}`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !resourcesEqual(a, b, nil) {
+		t.Error("expected scorecards with reordered rules to compare equal")
+	}
+
+	b["rules"].([]interface{})[0].(map[string]interface{})["level"] = "Gold"
+	if resourcesEqual(a, b, nil) {
+		t.Error("expected a changed rule to still be detected")
+	}
+}
+
+func TestResourcesEqual_OrderSignificantArraysKeepOrder(t *testing.T) {
+	a := map[string]interface{}{"levels": []interface{}{
+		map[string]interface{}{"title": "Bronze"},
+		map[string]interface{}{"title": "Silver"},
+	}}
+	b := map[string]interface{}{"levels": []interface{}{
+		map[string]interface{}{"title": "Silver"},
+		map[string]interface{}{"title": "Bronze"},
+	}}
+	if resourcesEqual(a, b, nil) {
+		t.Error("expected reordered scorecard levels to differ")
+	}
+}
+
+func TestCompareTeamMemberships(t *testing.T) {
+	importTeams := []api.Team{
+		{"name": "platform", "users": []interface{}{"alice@example.com", "carol@example.com"}},
+		{"name": "new-team", "users": []interface{}{map[string]interface{}{"email": "dave@example.com"}}},
+		{"name": "no-members"},
+		{"name": "unchanged", "users": []interface{}{"erin@example.com"}},
+	}
+	currentTeams := []api.Team{
+		{"name": "platform", "users": []interface{}{"alice@example.com", "bob@example.com"}},
+		{"name": "no-members", "users": []interface{}{"frank@example.com"}},
+		{"name": "unchanged", "users": []interface{}{"erin@example.com"}},
+	}
+
+	got := CompareTeamMemberships(importTeams, currentTeams)
+	want := []TeamMembershipChange{
+		{Team: "new-team", Add: []string{"dave@example.com"}},
+		{Team: "platform", Add: []string{"carol@example.com"}, Remove: []string{"bob@example.com"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareTeamMemberships() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithoutTeams(t *testing.T) {
+	changes := []TeamMembershipChange{{Team: "broken"}, {Team: "platform"}}
+	got := WithoutTeams(changes, map[string]bool{"broken": true})
+	if want := []TeamMembershipChange{{Team: "platform"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WithoutTeams() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompare_SkipsTeamMembershipsWithoutTeams(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.URL.Path == "/teams" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "teams": []interface{}{}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+	importData := &export.Data{Teams: []api.Team{{"name": "platform", "users": []interface{}{"alice@example.com"}}}}
+
+	for name, opts := range map[string]Options{
+		"skip entities":   {SkipEntities: true},
+		"teams excluded":  {IncludeResources: []string{"blueprints"}},
+		"teams selected":  {IncludeResources: []string{"teams"}},
+		"everything else": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff, err := NewDiffComparer(client).Compare(context.Background(), importData, opts)
+			if err != nil {
+				t.Fatalf("Compare: %v", err)
+			}
+			wantSynced := !opts.SkipEntities && shouldImport("teams", opts.IncludeResources)
+			if got := len(diff.TeamMemberships) > 0; got != wantSynced {
+				t.Errorf("expected memberships synced = %v, got %+v", wantSynced, diff.TeamMemberships)
+			}
+		})
+	}
+}
+
+func TestCompareTeams_IgnoresMembers(t *testing.T) {
+	d := &DiffComparer{}
+	importTeams := []api.Team{{"name": "platform", "description": "Platform", "users": []interface{}{"alice@example.com"}}}
+	currentTeams := []api.Team{{"name": "platform", "description": "Platform", "users": []interface{}{"bob@example.com"}}}
+
+	_, update, skip := d.compareTeams(importTeams, currentTeams, nil)
+	if len(update) != 0 || len(skip) != 1 {
+		t.Errorf("expected membership-only change to skip the team update, got %d update(s), %d skip(s)", len(update), len(skip))
+	}
+}
+
+func TestCompareBlueprints_IncludeSystemBlueprints(t *testing.T) {
+	comparer := &DiffComparer{}
+	source := []api.Blueprint{
+		{"identifier": "_rule", "title": "Rule (customized)"},
+		{"identifier": "_ai_agent", "title": "AI Agent"},
+		{"identifier": "service", "title": "Service"},
+	}
+	current := []api.Blueprint{
+		{"identifier": "_rule", "title": "Rule"},
+	}
+
+	create, update, skip := comparer.compareBlueprints(source, current, nil, false)
+	if len(update) != 0 || len(create) != 2 || len(skip) != 1 {
+		t.Fatalf("default: expected _rule skipped and 2 creates, got create=%d update=%d skip=%d", len(create), len(update), len(skip))
+	}
+
+	create, update, skip = comparer.compareBlueprints(source, current, nil, true)
+	if len(update) != 1 || update[0]["identifier"] != "_rule" {
+		t.Errorf("expected _rule to be updated, got %#v", update)
+	}
+	if len(create) != 1 || create[0]["identifier"] != "service" {
+		t.Errorf("expected only service to be created, got %#v", create)
+	}
+	if len(skip) != 1 || skip[0]["identifier"] != "_ai_agent" {
+		t.Errorf("expected missing system blueprint to be skipped, got %#v", skip)
+	}
+}
+
+func TestDiffComparer_ForceUpdatePlansUnchangedResourcesAsUpdates(t *testing.T) {
+	d := &DiffComparer{forceUpdate: true}
+	current := []api.Blueprint{
+		{"identifier": "service", "title": "Service"},
+		{"identifier": "_rule", "title": "Rule"},
+	}
+	source := []api.Blueprint{
+		{"identifier": "service", "title": "Service"},
+		{"identifier": "_rule", "title": "Rule"},
+		{"identifier": "domain", "title": "Domain"},
+	}
+
+	create, update, skip := d.compareBlueprints(source, current, nil, false)
+	if len(create) != 1 || create[0]["identifier"] != "domain" {
+		t.Errorf("expected missing blueprints to still be created, got %#v", create)
+	}
+	if len(update) != 1 || update[0]["identifier"] != "service" {
+		t.Errorf("expected the unchanged blueprint to be updated, got %#v", update)
+	}
+	if len(skip) != 1 || skip[0]["identifier"] != "_rule" {
+		t.Errorf("expected Port-managed blueprints to stay skipped, got %#v", skip)
+	}
+
+	pages := []api.Page{{"identifier": "home", "title": "Home"}, {"identifier": "$run", "protected": true}}
+	_, updatePages, skipPages := d.comparePages(pages, pages, nil)
+	if len(updatePages) != 1 || len(skipPages) != 1 {
+		t.Errorf("expected 1 forced page update and the protected page skipped, got %d update(s), %d skip(s)", len(updatePages), len(skipPages))
+	}
+
+	perms := map[string]api.Permissions{"service": {"entities": map[string]interface{}{"view": []string{"$team"}}}}
+	if changes := comparePermissions(perms, perms, true); len(changes) != 1 {
+		t.Errorf("expected unchanged permissions to be forced, got %d change(s)", len(changes))
+	}
+}
+
+func TestCompareEntities_ColonInIdentifierDoesNotCollide(t *testing.T) {
+	d := &DiffComparer{}
+	// Joined as "blueprint:identifier", both entities would read "a:b:c".
+	current := []api.Entity{{"blueprint": "a:b", "identifier": "c"}}
+	desired := []api.Entity{{"blueprint": "a", "identifier": "b:c"}}
+
+	create, update, skip := d.compareEntities(desired, current, nil, nil)
+	if len(create) != 1 || len(update) != 0 || len(skip) != 0 {
+		t.Errorf("expected the entity to be created, got %d create(s), %d update(s), %d skip(s)", len(create), len(update), len(skip))
+	}
+
+	currentScs := []api.Scorecard{{"blueprintIdentifier": "a:b", "identifier": "c"}}
+	desiredScs := []api.Scorecard{{"blueprintIdentifier": "a", "identifier": "b:c"}}
+	createScs, _, skipScs := d.compareScorecards(desiredScs, currentScs, nil)
+	if len(createScs) != 1 || len(skipScs) != 0 {
+		t.Errorf("expected the scorecard to be created, got %d create(s), %d skip(s)", len(createScs), len(skipScs))
+	}
+}
+
+func TestCompareEntities_IgnoresComputedProperties(t *testing.T) {
+	d := &DiffComparer{}
+	blueprints := []api.Blueprint{{
+		"identifier":            "service",
+		"mirrorProperties":      map[string]interface{}{"team_name": map[string]interface{}{"path": "team.$title"}},
+		"calculationProperties": map[string]interface{}{"url": map[string]interface{}{"calculation": ".identifier"}},
+	}}
+	computed := computedProperties(blueprints)
+	current := []api.Entity{{
+		"blueprint":  "service",
+		"identifier": "checkout",
+		"properties": map[string]interface{}{"language": "go", "team_name": "Payments", "url": "checkout"},
+	}}
+
+	desired := []api.Entity{{
+		"blueprint":  "service",
+		"identifier": "checkout",
+		"properties": map[string]interface{}{"language": "go", "team_name": "Billing"},
+	}}
+	create, update, skip := d.compareEntities(desired, current, computed, nil)
+	if len(create) != 0 || len(update) != 0 || len(skip) != 1 {
+		t.Errorf("expected a differing mirror value to be skipped, got %d create(s), %d update(s), %d skip(s)", len(create), len(update), len(skip))
+	}
+	if desired[0]["properties"].(map[string]interface{})["team_name"] != "Billing" {
+		t.Error("the desired entity was modified")
+	}
+
+	desired[0]["properties"].(map[string]interface{})["language"] = "rust"
+	_, update, _ = d.compareEntities(desired, current, computed, nil)
+	if len(update) != 1 {
+		t.Errorf("expected a differing regular property to be updated, got %d update(s)", len(update))
+	}
+}
@@ -0,0 +1,239 @@
+package import_module
+
+import (
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// EntityOrder is the order ImportEntities writes entities in, computed from
+// entity relations by SortEntitiesByRelations.
+type EntityOrder struct {
+	// Levels lists entities in dependency order: every relation target inside
+	// the import set is in an earlier level.
+	Levels [][]api.Entity
+	// Deferred lists the entities whose relations cannot be ordered: targets
+	// outside the import set, non-identifier relation values, and cycles.
+	// They are written without relations and related in a second pass.
+	Deferred []api.Entity
+
+	deferred map[ResourceKey]bool          // keys of Deferred
+	deps     map[ResourceKey][]ResourceKey // entity key -> keys of its relation targets in the set
+}
+
+// SortEntitiesByRelations orders entities so relation targets are created
+// before the entities pointing at them, the same way TopologicalSort orders
+// blueprints. relationTargets maps blueprint -> relation name -> target
+// blueprint; when a relation's target blueprint is unknown, a value is matched
+// to the only entity in the set with that identifier. Entities without a
+// blueprint or identifier are dropped.
+func SortEntitiesByRelations(entities []api.Entity, relationTargets map[string]map[string]string) EntityOrder {
+	order := EntityOrder{deferred: make(map[ResourceKey]bool), deps: make(map[ResourceKey][]ResourceKey)}
+
+	byKey := make(map[ResourceKey]api.Entity, len(entities))
+	keysByID := make(map[string][]ResourceKey)
+	var keys []ResourceKey
+	for _, entity := range entities {
+		bp, _ := entity["blueprint"].(string)
+		id, _ := entity["identifier"].(string)
+		if bp == "" || id == "" {
+			continue
+		}
+		key := entityKey(bp, id)
+		if _, dup := byKey[key]; !dup {
+			keys = append(keys, key)
+			keysByID[id] = append(keysByID[id], key)
+		}
+		byKey[key] = entity
+	}
+
+	// Kahn's algorithm. Deferred entities are written without relations, so
+	// they have no dependencies of their own and start in the first level.
+	inDegree := make(map[ResourceKey]int, len(keys))
+	dependents := make(map[ResourceKey][]ResourceKey)
+	for _, key := range keys {
+		deps, ok := entityRelationKeys(byKey[key], byKey, keysByID, relationTargets)
+		if !ok {
+			order.deferred[key] = true
+			continue
+		}
+		order.deps[key] = deps
+		inDegree[key] = len(deps)
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+
+	var current []ResourceKey
+	for _, key := range keys {
+		if inDegree[key] == 0 {
+			current = append(current, key)
+		}
+	}
+	placed := make(map[ResourceKey]bool, len(keys))
+	for len(current) > 0 {
+		level := make([]api.Entity, 0, len(current))
+		var next []ResourceKey
+		for _, key := range current {
+			placed[key] = true
+			level = append(level, byKey[key])
+			for _, dependent := range dependents[key] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		order.Levels = append(order.Levels, level)
+		current = next
+	}
+
+	// Whatever could not be placed is in, or depends on, a cycle. Written
+	// without relations, these entities can all go in one last level.
+	var cyclic []api.Entity
+	for _, key := range keys {
+		if !placed[key] {
+			order.deferred[key] = true
+			cyclic = append(cyclic, byKey[key])
+		}
+	}
+	if len(cyclic) > 0 {
+		order.Levels = append(order.Levels, cyclic)
+	}
+	for _, key := range keys {
+		if order.deferred[key] {
+			order.Deferred = append(order.Deferred, byKey[key])
+		}
+	}
+	return order
+}
+
+// IsDeferred reports whether entity is written without its relations in the
+// first pass.
+func (o EntityOrder) IsDeferred(entity api.Entity) bool {
+	bp, _ := entity["blueprint"].(string)
+	id, _ := entity["identifier"].(string)
+	return o.deferred[entityKey(bp, id)]
+}
+
+// dependencies returns the keys of the entities in the set that entity's
+// relations point at.
+func (o EntityOrder) dependencies(entity api.Entity) []ResourceKey {
+	bp, _ := entity["blueprint"].(string)
+	id, _ := entity["identifier"].(string)
+	return o.deps[entityKey(bp, id)]
+}
+
+// entityRelationKeys returns the keys of the entities in the import set that
+// entity's relations point at. ok is false when a relation cannot be ordered:
+// it targets an entity outside the set, itself, or holds something other
+// than identifiers, such as a search query.
+func entityRelationKeys(entity api.Entity, byKey map[ResourceKey]api.Entity, keysByID map[string][]ResourceKey, relationTargets map[string]map[string]string) ([]ResourceKey, bool) {
+	var deps []ResourceKey
+	bp, _ := entity["blueprint"].(string)
+	id, _ := entity["identifier"].(string)
+	self := entityKey(bp, id)
+	for relName, value := range ExtractEntityRelations(entity) {
+		var ids []string
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			ids = []string{v}
+		case []interface{}:
+			for _, item := range v {
+				s, isString := item.(string)
+				if !isString {
+					return nil, false
+				}
+				ids = append(ids, s)
+			}
+		default:
+			return nil, false
+		}
+
+		targetBP := relationTargets[bp][relName]
+		for _, targetID := range ids {
+			var key ResourceKey
+			if targetBP != "" {
+				key = entityKey(targetBP, targetID)
+				if _, inSet := byKey[key]; !inSet {
+					return nil, false
+				}
+			} else if candidates := keysByID[targetID]; len(candidates) == 1 {
+				key = candidates[0]
+			} else {
+				return nil, false
+			}
+			if key == self {
+				return nil, false
+			}
+			deps = append(deps, key)
+		}
+	}
+	return deps, true
+}
+
+// SortBlueprintsByRelations orders blueprint identifiers for the streaming
+// entity import, which writes one blueprint's entities at a time: every
+// blueprint comes after the blueprints its relations target, so an entity's
+// relation targets exist when its relations are written. relationTargets maps
+// blueprint -> relation name -> target blueprint. Blueprints in, or depending
+// on, a relation cycle come last and are returned in cyclic: their relations
+// can only be written once all of their entities exist.
+func SortBlueprintsByRelations(ids []string, relationTargets map[string]map[string]string) ([]string, map[string]bool) {
+	inSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		inSet[id] = true
+	}
+
+	inDegree := make(map[string]int, len(ids))
+	dependents := make(map[string][]string)
+	for _, id := range ids {
+		seen := make(map[string]bool)
+		for _, target := range relationTargets[id] {
+			// Relations within a blueprint are written after all of its
+			// entities, so they need no ordering.
+			if target == id || !inSet[target] || seen[target] {
+				continue
+			}
+			seen[target] = true
+			inDegree[id]++
+			dependents[target] = append(dependents[target], id)
+		}
+	}
+
+	ordered := make([]string, 0, len(ids))
+	var current []string
+	for _, id := range ids {
+		if inDegree[id] == 0 {
+			current = append(current, id)
+		}
+	}
+	for len(current) > 0 {
+		var next []string
+		for _, id := range current {
+			ordered = append(ordered, id)
+			for _, dependent := range dependents[id] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	cyclic := make(map[string]bool)
+	if len(ordered) < len(ids) {
+		placed := make(map[string]bool, len(ordered))
+		for _, id := range ordered {
+			placed[id] = true
+		}
+		for _, id := range ids {
+			if !placed[id] {
+				cyclic[id] = true
+				ordered = append(ordered, id)
+			}
+		}
+	}
+	return ordered, cyclic
+}
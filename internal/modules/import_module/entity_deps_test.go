@@ -0,0 +1,290 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func entityIDs(entities []api.Entity) []string {
+	ids := make([]string, len(entities))
+	for i, e := range entities {
+		ids[i], _ = e["identifier"].(string)
+	}
+	return ids
+}
+
+func TestSortEntitiesByRelations(t *testing.T) {
+	relationTargets := map[string]map[string]string{
+		"service": {"env": "environment", "dependsOn": "service"},
+	}
+	entities := []api.Entity{
+		{"identifier": "svc-a", "blueprint": "service", "relations": map[string]interface{}{"env": "prod", "dependsOn": []interface{}{"svc-b"}}},
+		{"identifier": "svc-b", "blueprint": "service", "relations": map[string]interface{}{"env": "prod"}},
+		{"identifier": "prod", "blueprint": "environment"},
+		{"identifier": "svc-x", "blueprint": "service", "relations": map[string]interface{}{"env": "staging"}},
+		{"identifier": "cyc-1", "blueprint": "service", "relations": map[string]interface{}{"dependsOn": "cyc-2"}},
+		{"identifier": "cyc-2", "blueprint": "service", "relations": map[string]interface{}{"dependsOn": "cyc-1"}},
+	}
+
+	order := SortEntitiesByRelations(entities, relationTargets)
+
+	var levels [][]string
+	for _, level := range order.Levels {
+		levels = append(levels, entityIDs(level))
+	}
+	want := [][]string{{"prod", "svc-x"}, {"svc-b"}, {"svc-a"}, {"cyc-1", "cyc-2"}}
+	if len(levels) != len(want) {
+		t.Fatalf("levels = %v, want %v", levels, want)
+	}
+	for i := range want {
+		if strings.Join(levels[i], ",") != strings.Join(want[i], ",") {
+			t.Fatalf("levels = %v, want %v", levels, want)
+		}
+	}
+	// svc-x relates to an environment outside the import; cyc-1/cyc-2 form a cycle.
+	if got := strings.Join(entityIDs(order.Deferred), ","); got != "svc-x,cyc-1,cyc-2" {
+		t.Fatalf("deferred = %s, want svc-x,cyc-1,cyc-2", got)
+	}
+}
+
+func TestSortEntitiesByRelations_ColonInIdentifier(t *testing.T) {
+	relationTargets := map[string]map[string]string{"a": {"parent": "a:b"}}
+	entities := []api.Entity{
+		{"identifier": "b:c", "blueprint": "a", "relations": map[string]interface{}{"parent": "c"}},
+		{"identifier": "c", "blueprint": "a:b"},
+	}
+
+	order := SortEntitiesByRelations(entities, relationTargets)
+
+	if len(order.Levels) != 2 || len(order.Deferred) != 0 {
+		t.Fatalf("expected two levels and nothing deferred, got levels %v, deferred %v", order.Levels, entityIDs(order.Deferred))
+	}
+	if got := strings.Join(entityIDs(order.Levels[0]), ","); got != "c" {
+		t.Errorf("first level = %s, want c", got)
+	}
+	if got := strings.Join(entityIDs(order.Levels[1]), ","); got != "b:c" {
+		t.Errorf("second level = %s, want b:c", got)
+	}
+}
+
+func TestSortBlueprintsByRelations(t *testing.T) {
+	relationTargets := map[string]map[string]string{
+		"service":     {"env": "environment", "parent": "service"},
+		"environment": {"cluster": "cluster"},
+		"cluster":     {},
+		"a":           {"b": "b", "missing": "outside"},
+		"b":           {"a": "a"},
+		"c":           {"a": "a"},
+	}
+	ordered, cyclic := SortBlueprintsByRelations([]string{"service", "a", "b", "c", "environment", "cluster"}, relationTargets)
+
+	if got := strings.Join(ordered, ","); got != "cluster,environment,service,a,b,c" {
+		t.Fatalf("order = %s, want cluster,environment,service,a,b,c", got)
+	}
+	// a and b relate to each other; c depends on the cycle.
+	if len(cyclic) != 3 || !cyclic["a"] || !cyclic["b"] || !cyclic["c"] {
+		t.Fatalf("cyclic = %v, want a, b and c", cyclic)
+	}
+}
+
+// streamImportCalls imports entities through the streaming path against a
+// target whose blueprints have the given relations, and returns the bulk
+// calls made as "blueprint:upsert" in order.
+func streamImportCalls(t *testing.T, relations map[string]map[string]string, entities string) []string {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/blueprints":
+			var blueprints []interface{}
+			for id, rels := range relations {
+				relDefs := map[string]interface{}{}
+				for name, target := range rels {
+					relDefs[name] = map[string]interface{}{"target": target}
+				}
+				blueprints = append(blueprints, map[string]interface{}{"identifier": id, "relations": relDefs})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": blueprints})
+		case strings.HasSuffix(r.URL.Path, "/entities-count"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 0})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/entities"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []interface{}{}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/entities/bulk"):
+			blueprint := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/blueprints/"), "/entities/bulk")
+			mu.Lock()
+			calls = append(calls, blueprint+":"+r.URL.Query().Get("upsert"))
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(inputPath, []byte(`{"entities": `+entities+`}`), 0o644); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	if err := NewImporter(client).ImportEntitiesFromStream(context.Background(), inputPath, Options{}, &Result{}, false); err != nil {
+		t.Fatalf("ImportEntitiesFromStream returned error: %v", err)
+	}
+	return calls
+}
+
+func TestImportEntitiesFromStream_ImportsRelationTargetBlueprintFirst(t *testing.T) {
+	// Entity A (a service) relates to entity B (an environment). The
+	// environment is imported first, so A's relation is written after B exists.
+	calls := streamImportCalls(t,
+		map[string]map[string]string{"service": {"env": "environment"}, "environment": {}},
+		`[{"identifier":"A","blueprint":"service","relations":{"env":"B"}},
+		  {"identifier":"B","blueprint":"environment"}]`)
+
+	want := "environment:false,service:false,service:true"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("bulk calls = %s, want %s", got, want)
+	}
+}
+
+func TestImportEntitiesFromStream_DefersRelationsOfCyclicBlueprints(t *testing.T) {
+	calls := streamImportCalls(t,
+		map[string]map[string]string{"service": {"env": "environment"}, "environment": {"owner": "service"}},
+		`[{"identifier":"A","blueprint":"service","relations":{"env":"B"}},
+		  {"identifier":"B","blueprint":"environment","relations":{"owner":"A"}}]`)
+
+	// Both blueprints are created before either one's relations are written.
+	want := "environment:false,service:false,environment:true,service:true"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("bulk calls = %s, want %s", got, want)
+	}
+}
+
+func TestImportEntities_CreatesRelationTargetFirst(t *testing.T) {
+	type bulkCall struct {
+		blueprint string
+		upsert    string
+		entities  []map[string]interface{}
+	}
+	var calls []bulkCall
+	var mu sync.Mutex
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/blueprints" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []interface{}{
+				map[string]interface{}{"identifier": "service", "relations": map[string]interface{}{
+					"env": map[string]interface{}{"target": "environment"},
+				}},
+				map[string]interface{}{"identifier": "environment"},
+			}})
+			return
+		}
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/entities/bulk") {
+			var body struct {
+				Entities []map[string]interface{} `json:"entities"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			blueprint := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/blueprints/"), "/entities/bulk")
+			mu.Lock()
+			calls = append(calls, bulkCall{blueprint, r.URL.Query().Get("upsert"), body.Entities})
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{}})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	// Entity A (a service) relates to entity B (an environment).
+	entities := []api.Entity{
+		{"identifier": "A", "blueprint": "service", "relations": map[string]interface{}{"env": "B"}},
+		{"identifier": "B", "blueprint": "environment"},
+	}
+	result := &Result{}
+	if err := NewImporter(client).ImportEntities(context.Background(), entities, false, result); err != nil {
+		t.Fatalf("ImportEntities returned error: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 bulk calls, got %d: %+v", len(calls), calls)
+	}
+	if calls[0].blueprint != "environment" || calls[1].blueprint != "service" {
+		t.Fatalf("expected B to be created before A, got call order %s, %s", calls[0].blueprint, calls[1].blueprint)
+	}
+	for _, c := range calls {
+		if c.upsert != "false" {
+			t.Fatalf("expected no relations pass once targets are ordered, got upsert=%s for %s", c.upsert, c.blueprint)
+		}
+	}
+	relations, _ := calls[1].entities[0]["relations"].(map[string]interface{})
+	if relations["env"] != "B" {
+		t.Fatalf("expected A to be written with its relation, got %v", calls[1].entities[0])
+	}
+	if result.EntitiesCreated != 2 {
+		t.Fatalf("expected 2 entities created, got %d", result.EntitiesCreated)
+	}
+}
+
+func TestImportEntities_RelatesLaterWhenTargetFails(t *testing.T) {
+	var mu sync.Mutex
+	var serviceCalls []map[string]interface{}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/blueprints" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []interface{}{}})
+			return
+		}
+		if r.URL.Path == "/blueprints/environment/entities/bulk" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{
+				map[string]interface{}{"identifier": "B", "statusCode": 422, "message": "invalid"},
+			}})
+			return
+		}
+		if r.URL.Path == "/blueprints/service/entities/bulk" {
+			var body struct {
+				Entities []map[string]interface{} `json:"entities"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			serviceCalls = append(serviceCalls, body.Entities[0])
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{}})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	entities := []api.Entity{
+		{"identifier": "A", "blueprint": "service", "relations": map[string]interface{}{"env": "B"}},
+		{"identifier": "B", "blueprint": "environment"},
+	}
+	if err := NewImporter(client).ImportEntities(context.Background(), entities, false, &Result{}); err != nil {
+		t.Fatalf("ImportEntities returned error: %v", err)
+	}
+
+	// A is still created, without the relation to the failed B, and its
+	// relations are retried in Phase 2.
+	if len(serviceCalls) != 2 {
+		t.Fatalf("expected A to be written twice, got %d", len(serviceCalls))
+	}
+	if _, ok := serviceCalls[0]["relations"]; ok {
+		t.Fatalf("expected A to be created without relations, got %v", serviceCalls[0])
+	}
+	if _, ok := serviceCalls[1]["relations"]; !ok {
+		t.Fatalf("expected A's relations in the second pass, got %v", serviceCalls[1])
+	}
+}
@@ -0,0 +1,142 @@
+package import_module
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// SchemaViolation is an entity property that its blueprint's schema does not
+// allow, as left behind when a schema change narrows an enum, changes a type
+// or makes a property required.
+type SchemaViolation struct {
+	Blueprint string `json:"blueprint"`
+	Entity    string `json:"entity"`
+	Property  string `json:"property"`
+	Detail    string `json:"detail"`
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s/%s: %s: %s", v.Blueprint, v.Entity, v.Property, v.Detail)
+}
+
+// ValidateEntitySchema checks entity's properties against bp's schema:
+// required properties must be set, values must match their property's type,
+// and enum properties must hold allowed values. Properties missing from the
+// schema and null optional values are not reported. Violations are sorted by
+// property.
+func ValidateEntitySchema(bp api.Blueprint, entity api.Entity) []SchemaViolation {
+	schema := schemaOf(bp)
+	if schema == nil {
+		return nil
+	}
+	bpID, _ := bp["identifier"].(string)
+	entityID, _ := entity["identifier"].(string)
+	schemaProps, _ := schema["properties"].(map[string]interface{})
+	values, _ := entity["properties"].(map[string]interface{})
+
+	var violations []SchemaViolation
+	add := func(property, detail string) {
+		violations = append(violations, SchemaViolation{Blueprint: bpID, Entity: entityID, Property: property, Detail: detail})
+	}
+	for name := range requiredSet(schema) {
+		if values[name] == nil {
+			add(name, "required property is missing")
+		}
+	}
+	for name, value := range values {
+		prop, _ := schemaProps[name].(map[string]interface{})
+		if prop == nil || value == nil {
+			continue
+		}
+		if detail := propertyValueViolation(prop, value); detail != "" {
+			add(name, detail)
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Property < violations[j].Property })
+	return violations
+}
+
+// propertyValueViolation describes why value does not fit the property schema
+// prop, or returns "" when it does.
+func propertyValueViolation(prop map[string]interface{}, value interface{}) string {
+	typ, _ := prop["type"].(string)
+	if typ != "" && !valueHasType(value, typ) {
+		return fmt.Sprintf("expected %s, got %s", typ, jsonTypeOf(value))
+	}
+	if enum, ok := prop["enum"].([]interface{}); ok && !enumAllows(enum, value) {
+		return fmt.Sprintf("value %v is not one of %s", value, enumList(enum))
+	}
+	if items, ok := value.([]interface{}); ok {
+		itemSchema, _ := prop["items"].(map[string]interface{})
+		for i, item := range items {
+			if itemSchema == nil || item == nil {
+				continue
+			}
+			if detail := propertyValueViolation(itemSchema, item); detail != "" {
+				return fmt.Sprintf("item %d: %s", i, detail)
+			}
+		}
+	}
+	return ""
+}
+
+// valueHasType reports whether a decoded JSON value has the JSON schema type
+// typ. Unknown types are accepted.
+func valueHasType(value interface{}, typ string) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumAllows(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
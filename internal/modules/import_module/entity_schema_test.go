@@ -0,0 +1,48 @@
+package import_module
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestValidateEntitySchema(t *testing.T) {
+	bp := api.Blueprint{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"required": []interface{}{"owner", "tier"},
+			"properties": map[string]interface{}{
+				"owner":    map[string]interface{}{"type": "string"},
+				"tier":     map[string]interface{}{"type": "string", "enum": []interface{}{"gold", "silver"}},
+				"replicas": map[string]interface{}{"type": "number"},
+				"tags":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "enum": []interface{}{"a", "b"}}},
+				"notes":    map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+
+	valid := api.Entity{"identifier": "ok", "properties": map[string]interface{}{
+		"owner": "team-a", "tier": "gold", "replicas": float64(3), "tags": []interface{}{"a"}, "notes": nil, "legacy": true,
+	}}
+	if got := ValidateEntitySchema(bp, valid); len(got) != 0 {
+		t.Errorf("expected a valid entity, got %v", got)
+	}
+
+	orphan := api.Entity{"identifier": "svc", "properties": map[string]interface{}{
+		"tier": "platinum", "replicas": "3", "tags": []interface{}{"a", "c"},
+	}}
+	var got []string
+	for _, v := range ValidateEntitySchema(bp, orphan) {
+		got = append(got, v.String())
+	}
+	want := []string{
+		"service/svc: owner: required property is missing",
+		"service/svc: replicas: expected number, got string",
+		"service/svc: tags: item 1: value c is not one of [a, b]",
+		"service/svc: tier: value platinum is not one of [gold, silver]",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
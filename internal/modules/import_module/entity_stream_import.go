@@ -4,13 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/envexpand"
 	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+	"github.com/port-experimental/port-cli/internal/modules/export"
 )
 
 type entityPartition struct {
@@ -113,6 +117,7 @@ func partitionEntities(inputPath string, opts Options) (*entityPartitions, error
 	for _, id := range opts.ExcludeBlueprints {
 		deepSet[id] = true
 	}
+	expander := envexpand.New(opts.ExpandEnv)
 	err = loader.ForEachEntity(inputPath, func(entity api.Entity) error {
 		bpID, _ := entity["blueprint"].(string)
 		if bpID == "" {
@@ -121,11 +126,16 @@ func partitionEntities(inputPath string, opts Options) (*entityPartitions, error
 		if opts.SkipSystemBlueprints && strings.HasPrefix(bpID, "_") {
 			return nil
 		}
-		if deepSet[bpID] {
+		if deepSet[bpID] || export.SkipsEntitiesOf(bpID, opts.SkipEntitiesFor) {
 			return nil
 		}
+		expander.Value(map[string]interface{}(entity))
+		ApplyTransforms(opts.Transforms, "entities", entity)
 		return partitions.write(entity)
 	})
+	if err == nil {
+		err = expander.Err()
+	}
 	if closeErr := partitions.close(); closeErr != nil && err == nil {
 		err = closeErr
 	}
@@ -144,6 +154,30 @@ func forEachPartitionEntity(ctx context.Context, path string, yield func(api.Ent
 type EntityImportContext struct {
 	InheritedOwnershipBlueprints map[string]bool
 	BlueprintsToSkip             map[string]bool
+	// RelationTargets maps blueprint -> relation name -> target blueprint in
+	// the target organization.
+	RelationTargets map[string]map[string]string
+
+	deferRelations   map[string]bool // blueprints whose relations wait for RelateDeferredEntities
+	pendingRelations []pendingRelations
+}
+
+// pendingRelations is a blueprint's Phase 2 postponed by OrderBlueprints.
+type pendingRelations struct {
+	path               string
+	count              int
+	successfulEntities map[ResourceKey]bool
+	successMu          *sync.Mutex
+}
+
+// OrderBlueprints returns ids in the order their entities should be imported:
+// relation targets first, see SortBlueprintsByRelations. The relations of
+// blueprints in a relation cycle are not written by ImportBlueprintEntities;
+// call RelateDeferredEntities once every blueprint has been imported.
+func (c *EntityImportContext) OrderBlueprints(ids []string) []string {
+	ordered, cyclic := SortBlueprintsByRelations(ids, c.RelationTargets)
+	c.deferRelations = cyclic
+	return ordered
 }
 
 // NewEntityImportContext prepares the target-side metadata used by blueprint-scoped entity imports.
@@ -158,6 +192,7 @@ func (i *Importer) NewEntityImportContext(ctx context.Context) *EntityImportCont
 	return &EntityImportContext{
 		InheritedOwnershipBlueprints: inheritedOwnershipBPs,
 		BlueprintsToSkip:             blueprintsToSkip,
+		RelationTargets:              relationTargets,
 	}
 }
 
@@ -165,12 +200,19 @@ func (i *Importer) NewEntityImportContext(ctx context.Context) *EntityImportCont
 type EntityStreamOptions struct {
 	IncludeRuleResults bool
 	EntityIDs          []string
+	EntityPatterns     []string // identifier globs; empty imports every entity
+	ForceUpdate        bool     // update existing entities even when they are unchanged
 	OnEntitySkipped    func(api.Entity)
+	// OnEntityChanged is called for each entity to create, with a nil
+	// current, or to update, with the target's current version.
+	OnEntityChanged func(entity, current api.Entity)
 }
 
 func entityStreamOptionsFromImportOptions(opts Options) EntityStreamOptions {
 	return EntityStreamOptions{
 		IncludeRuleResults: opts.IncludeRuleResults,
+		EntityPatterns:     opts.IncludePatterns["entities"],
+		ForceUpdate:        opts.ForceUpdate,
 	}
 }
 
@@ -184,13 +226,24 @@ func (i *Importer) ImportEntitiesFromStream(ctx context.Context, inputPath strin
 	importCtx := i.NewEntityImportContext(ctx)
 	currentSource := entitystream.FromAPI(i.client)
 
+	paths := make(map[string]string)
+	var blueprints []string
 	for _, partition := range partitions.list() {
-		iterator := entitystream.JSONLPageIterator(partition.Path, EntityBulkBatchSize)
-		if err := i.ImportBlueprintEntities(ctx, partition.Blueprint, iterator, currentSource, entityStreamOptionsFromImportOptions(opts), result, dryRun, importCtx, filepath.Dir(partition.Path)); err != nil {
+		paths[partition.Blueprint] = partition.Path
+		blueprints = append(blueprints, partition.Blueprint)
+	}
+	sort.Strings(blueprints)
+	streamOpts := entityStreamOptionsFromImportOptions(opts)
+	if opts.RecordEntityChanges && result.DiffResult != nil {
+		streamOpts.OnEntityChanged = result.DiffResult.RecordEntityChange
+	}
+	for _, bpID := range importCtx.OrderBlueprints(blueprints) {
+		iterator := entitystream.JSONLPageIterator(paths[bpID], EntityBulkBatchSize)
+		if err := i.ImportBlueprintEntities(ctx, bpID, iterator, currentSource, streamOpts, result, dryRun, importCtx, filepath.Dir(paths[bpID])); err != nil {
 			return err
 		}
 	}
-	return nil
+	return i.RelateDeferredEntities(ctx, importCtx)
 }
 
 func (i *Importer) importEntityPartition(
@@ -239,7 +292,7 @@ func (i *Importer) ImportBlueprintEntities(
 	}
 	currentMap, err := entitystream.CurrentMap(ctx, currentSource, blueprintID)
 	if err != nil {
-		if strings.Contains(err.Error(), "410 Gone") {
+		if api.HasStatus(err, http.StatusGone) {
 			currentMap = make(map[string]api.Entity)
 		} else {
 			return err
@@ -263,10 +316,19 @@ func (i *Importer) ImportBlueprintEntities(
 		return err
 	}
 	changedPath := changedFile.Name()
-	defer os.Remove(changedPath)
+	// A deferred Phase 2 reads the file later, so it is kept; that needs a
+	// caller-owned tempDir that outlives this call.
+	keepChanged := false
+	defer func() {
+		if !keepChanged {
+			os.Remove(changedPath)
+		}
+	}()
 	changedEncoder := json.NewEncoder(changedFile)
 	changedCount := 0
 	entityIDFilter := stringSet(opts.EntityIDs)
+	entityPatterns := map[string][]string{"entities": opts.EntityPatterns}
+	var existing []string
 
 	err = entitystream.ForEachEntity(ctx, desired, func(entity api.Entity) error {
 		bpID, _ := entity["blueprint"].(string)
@@ -280,16 +342,39 @@ func (i *Importer) ImportBlueprintEntities(
 		if len(entityIDFilter) > 0 && !entityIDFilter[entityID] {
 			return nil
 		}
+		if !export.IncludesIdentifier(entityPatterns, "entities", entityID) {
+			return nil
+		}
 		if isProtectedBlueprint(bpID, opts.IncludeRuleResults) || importCtx.InheritedOwnershipBlueprints[bpID] || importCtx.BlueprintsToSkip[bpID] {
 			return nil
 		}
 		currentEntity, exists := currentMap[entityID]
-		if exists && resourcesEqual(entity, currentEntity, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
+		// Under ConflictFail an existing entity is a conflict even when
+		// it is unchanged.
+		if exists && i.onConflict == ConflictFail {
+			if dryRun {
+				existing = append(existing, "entity "+blueprintID+"/"+entityID)
+			} else {
+				i.errors.Add(ErrResourceExists, "entity", entityID)
+			}
+			return nil
+		}
+		if exists && !opts.ForceUpdate && resourcesEqual(entity, currentEntity, []string{"createdBy", "updatedBy", "createdAt", "updatedAt", "id"}) {
 			if opts.OnEntitySkipped != nil {
 				opts.OnEntitySkipped(entity)
 			}
 			return nil
 		}
+		if exists && !i.updateExisting(&i.counts.Entities, "entity", entityID) {
+			return nil
+		}
+		if opts.OnEntityChanged != nil {
+			if exists {
+				opts.OnEntityChanged(entity, currentEntity)
+			} else {
+				opts.OnEntityChanged(entity, nil)
+			}
+		}
 		if dryRun {
 			if exists {
 				result.EntitiesUpdated++
@@ -307,14 +392,22 @@ func (i *Importer) ImportBlueprintEntities(
 	if err != nil {
 		return err
 	}
+	if len(existing) > 0 {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Type:    "conflict",
+			Message: fmt.Sprintf("%d entities of blueprint %s already exist in the target and would fail with --on-conflict fail", len(existing), blueprintID),
+			Details: existing,
+		})
+	}
 	if dryRun || changedCount == 0 {
+		result.From(&i.counts)
 		return nil
 	}
 
 	i.reportProgress("Entities Phase 1", 0, changedCount)
 	processedCount := 0
 	var progressMu sync.Mutex
-	successfulEntities := make(map[string]bool)
+	successfulEntities := make(map[ResourceKey]bool)
 	var successMu sync.Mutex
 	if err := i.processChangedEntityFile(ctx, changedPath, false, result, successfulEntities, &successMu, "Entities Phase 1", changedCount, &processedCount, &progressMu); err != nil {
 		return err
@@ -327,10 +420,37 @@ func (i *Importer) ImportBlueprintEntities(
 	if relationCount == 0 {
 		return nil
 	}
-	i.reportProgress("Entities Phase 2 (relations)", 0, relationCount)
+	pending := pendingRelations{path: changedPath, count: relationCount, successfulEntities: successfulEntities, successMu: &successMu}
+	if importCtx.deferRelations[blueprintID] && !cleanupTempDir {
+		keepChanged = true
+		importCtx.pendingRelations = append(importCtx.pendingRelations, pending)
+		return nil
+	}
+	return i.relateChangedEntities(ctx, pending)
+}
+
+// RelateDeferredEntities writes the relations ImportBlueprintEntities
+// postponed for blueprints in a relation cycle, now that their entities exist.
+func (i *Importer) RelateDeferredEntities(ctx context.Context, importCtx *EntityImportContext) error {
+	pending := importCtx.pendingRelations
+	importCtx.pendingRelations = nil
+	var err error
+	for _, p := range pending {
+		if err == nil {
+			err = i.relateChangedEntities(ctx, p)
+		}
+		os.Remove(p.path)
+	}
+	return err
+}
+
+// relateChangedEntities runs Phase 2 for one blueprint: it re-upserts the
+// entities created in Phase 1 with their relations.
+func (i *Importer) relateChangedEntities(ctx context.Context, p pendingRelations) error {
+	i.reportProgress("Entities Phase 2 (relations)", 0, p.count)
 	phase2Count := 0
 	var phase2ProgressMu sync.Mutex
-	return i.processChangedEntityFile(ctx, changedPath, true, nil, successfulEntities, &successMu, "Entities Phase 2 (relations)", relationCount, &phase2Count, &phase2ProgressMu)
+	return i.processChangedEntityFile(ctx, p.path, true, nil, p.successfulEntities, p.successMu, "Entities Phase 2 (relations)", p.count, &phase2Count, &phase2ProgressMu)
 }
 
 func stringSet(values []string) map[string]bool {
@@ -349,7 +469,7 @@ func (i *Importer) processChangedEntityFile(
 	path string,
 	withRelations bool,
 	result *Result,
-	successfulEntities map[string]bool,
+	successfulEntities map[ResourceKey]bool,
 	successMu *sync.Mutex,
 	phaseName string,
 	total int,
@@ -373,7 +493,7 @@ func (i *Importer) processChangedEntityFile(
 		}
 		if withRelations {
 			successMu.Lock()
-			success := successfulEntities[fmt.Sprintf("%s:%s", bpID, entityID)]
+			success := successfulEntities[entityKey(bpID, entityID)]
 			successMu.Unlock()
 			if !success || !HasEntityRelations(entity) {
 				return nil
@@ -390,10 +510,13 @@ func (i *Importer) processChangedEntityFile(
 	for bpID := range batches {
 		flush(bpID)
 	}
+	if result != nil {
+		result.From(&i.counts)
+	}
 	return err
 }
 
-func countSuccessfulRelationEntities(ctx context.Context, path string, successfulEntities map[string]bool, successMu *sync.Mutex) (int, error) {
+func countSuccessfulRelationEntities(ctx context.Context, path string, successfulEntities map[ResourceKey]bool, successMu *sync.Mutex) (int, error) {
 	count := 0
 	err := forEachPartitionEntity(ctx, path, func(entity api.Entity) error {
 		if !HasEntityRelations(entity) {
@@ -402,7 +525,7 @@ func countSuccessfulRelationEntities(ctx context.Context, path string, successfu
 		bpID, _ := entity["blueprint"].(string)
 		entityID, _ := entity["identifier"].(string)
 		successMu.Lock()
-		success := successfulEntities[fmt.Sprintf("%s:%s", bpID, entityID)]
+		success := successfulEntities[entityKey(bpID, entityID)]
 		successMu.Unlock()
 		if success {
 			count++
@@ -3,6 +3,7 @@ package import_module
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -134,10 +135,93 @@ func TestImportBlueprintEntities_DryRunUsesInjectedSourcesAndFilters(t *testing.
 	}
 }
 
+func TestImportBlueprintEntities_DryRunFiltersByEntityPatterns(t *testing.T) {
+	importer := NewImporter(api.NewClient(api.ClientOpts{}))
+	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
+		return nil
+	})
+	desired := entitystream.EntityIterator(1, func(yield func(api.Entity) error) error {
+		for _, id := range []string{"team-a", "team-b", "svc-1"} {
+			if err := yield(api.Entity{"identifier": id, "blueprint": "service"}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	result := &Result{}
+	err := importer.ImportBlueprintEntities(context.Background(), "service", desired, currentSource,
+		EntityStreamOptions{EntityPatterns: []string{"team-*"}}, result, true, &EntityImportContext{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportBlueprintEntities error: %v", err)
+	}
+	if result.EntitiesCreated != 2 {
+		t.Fatalf("expected the two team-* entities to be created, got %d", result.EntitiesCreated)
+	}
+}
+
+func TestImportBlueprintEntities_ForceUpdateUpdatesUnchangedEntities(t *testing.T) {
+	importer := NewImporter(api.NewClient(api.ClientOpts{}))
+	entity := api.Entity{"identifier": "svc-1", "blueprint": "service", "title": "Service 1"}
+	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
+		return yield([]api.Entity{entity})
+	})
+	desired := entitystream.EntityIterator(1, func(yield func(api.Entity) error) error {
+		return yield(entity)
+	})
+
+	result := &Result{}
+	skipped := 0
+	err := importer.ImportBlueprintEntities(context.Background(), "service", desired, currentSource,
+		EntityStreamOptions{ForceUpdate: true, OnEntitySkipped: func(api.Entity) { skipped++ }},
+		result, true, &EntityImportContext{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportBlueprintEntities error: %v", err)
+	}
+	if result.EntitiesUpdated != 1 || skipped != 0 {
+		t.Fatalf("expected the unchanged entity to be updated, got %d update(s), %d skip(s)", result.EntitiesUpdated, skipped)
+	}
+}
+
+func TestImportBlueprintEntities_RecordsChangesInDiffResult(t *testing.T) {
+	importer := NewImporter(api.NewClient(api.ClientOpts{}))
+	current := api.Entity{"identifier": "svc-existing", "blueprint": "service", "title": "Old"}
+	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
+		return yield([]api.Entity{current})
+	})
+	desired := entitystream.EntityIterator(1, func(yield func(api.Entity) error) error {
+		for _, entity := range []api.Entity{
+			{"identifier": "svc-new", "blueprint": "service"},
+			{"identifier": "svc-existing", "blueprint": "service", "title": "New"},
+		} {
+			if err := yield(entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	diff := &DiffResult{}
+	err := importer.ImportBlueprintEntities(context.Background(), "service", desired, currentSource,
+		EntityStreamOptions{OnEntityChanged: diff.RecordEntityChange}, &Result{}, true, &EntityImportContext{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportBlueprintEntities error: %v", err)
+	}
+	if len(diff.EntitiesToCreate) != 1 || diff.EntitiesToCreate[0]["identifier"] != "svc-new" {
+		t.Errorf("expected svc-new to be recorded as created, got %v", diff.EntitiesToCreate)
+	}
+	if len(diff.EntitiesToUpdate) != 1 || diff.EntitiesToUpdate[0]["title"] != "New" {
+		t.Errorf("expected svc-existing to be recorded as updated, got %v", diff.EntitiesToUpdate)
+	}
+	if diff.Current == nil || len(diff.Current.Entities) != 1 || diff.Current.Entities[0]["title"] != "Old" {
+		t.Errorf("expected the target's svc-existing to be kept for the diff, got %v", diff.Current)
+	}
+}
+
 func TestImportBlueprintEntities_CurrentSource410TreatsTargetAsEmpty(t *testing.T) {
 	importer := NewImporter(api.NewClient(api.ClientOpts{}))
 	currentSource := entitystream.BlueprintEntitySourceFunc(func(ctx context.Context, blueprintID string, yield func([]api.Entity) error) error {
-		return errors.New("API request failed: 410 Gone")
+		return &api.APIError{Method: "GET", URL: "/v1/blueprints/service/entities", Status: http.StatusGone}
 	})
 	desired := entitystream.EntityIterator(1, func(yield func(api.Entity) error) error {
 		return yield(api.Entity{"identifier": "svc-1", "blueprint": "service"})
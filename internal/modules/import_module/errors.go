@@ -2,8 +2,11 @@ package import_module
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
+
+	"github.com/port-experimental/port-cli/internal/api"
 )
 
 // ErrorCategory represents the type of error encountered during import.
@@ -77,49 +80,27 @@ func CategorizeError(err error, resourceType, resourceID string) *ImportError {
 		Cause:        err,
 	}
 
+	if apiErr, ok := api.AsAPIError(err); ok {
+		ie.Category, ie.Retryable = categorizeAPIError(apiErr)
+		return ie
+	}
+
 	// Check for blueprint configuration errors (inherited ownership, protected, etc.)
-	if containsAny(errStr, []string{
-		"inherited_ownership_enabled",
-		"inherited ownership",
-		"protected_resource",
-		"protected resource",
-		"protected_entity",
-		"protected entity",
-	}) {
+	if containsAny(errStr, blueprintConfigErrorPatterns) {
 		ie.Category = ErrBlueprintConfig
 		ie.Retryable = false
 		return ie
 	}
 
 	// Check for schema mismatch errors
-	if containsAny(errStr, []string{
-		"blueprint_schema_mismatch",
-		"schema mismatch",
-		"missing required property",
-		"required property",
-		"missing_property",
-		"required_relation",
-		"relation is required",
-	}) {
+	if containsAny(errStr, schemaMismatchErrorPatterns) {
 		ie.Category = ErrSchemaMismatch
 		ie.Retryable = false
 		return ie
 	}
 
 	// Check for dependency errors (missing references)
-	if containsAny(errStr, []string{
-		"was not found",
-		"not found",
-		"not_found",
-		"does not exist",
-		"missing blueprint",
-		"target blueprint",
-		"relation target",
-		"invalid relation",
-		"blueprint with identifier",
-		"after_item_not_in_parent",
-		"is not in the parent folder",
-	}) {
+	if containsAny(errStr, dependencyErrorPatterns) {
 		ie.Category = ErrDependency
 		ie.Retryable = true
 		return ie
@@ -221,6 +202,95 @@ func CategorizeError(err error, resourceType, resourceID string) *ImportError {
 	return ie
 }
 
+var blueprintConfigErrorPatterns = []string{
+	"inherited_ownership_enabled",
+	"inherited ownership",
+	"protected_resource",
+	"protected resource",
+	"protected_entity",
+	"protected entity",
+}
+
+var schemaMismatchErrorPatterns = []string{
+	"blueprint_schema_mismatch",
+	"schema mismatch",
+	"missing required property",
+	"required property",
+	"missing_property",
+	"required_relation",
+	"relation is required",
+}
+
+var dependencyErrorPatterns = []string{
+	"was not found",
+	"not found",
+	"not_found",
+	"does not exist",
+	"missing blueprint",
+	"target blueprint",
+	"relation target",
+	"invalid relation",
+	"blueprint with identifier",
+	"after_item_not_in_parent",
+	"is not in the parent folder",
+}
+
+// integrationCredentialsErrorPatterns match the Port error code or message of
+// an integration install rejected for missing or invalid credentials. They
+// name credentials specifically, so ordinary validation errors on the rest of
+// the integration are not reported as missing credentials.
+var integrationCredentialsErrorPatterns = []string{
+	"credential",
+	"secret",
+	"access token",
+	"access_token",
+	"api key",
+	"api_key",
+	"private key",
+	"private_key",
+	"installation parameter",
+	"installation_parameter",
+}
+
+// apiErrorDetail returns the lowercased Port error code and message of an
+// API error, falling back to the raw body when the body had neither.
+func apiErrorDetail(apiErr *api.APIError) string {
+	if apiErr.Code == "" && apiErr.Message == "" {
+		return strings.ToLower(apiErr.Body)
+	}
+	return strings.ToLower(apiErr.Code + " " + apiErr.Message)
+}
+
+// categorizeAPIError categorizes an API error by its Port error code and
+// message first, then by its HTTP status.
+func categorizeAPIError(apiErr *api.APIError) (ErrorCategory, bool) {
+	detail := apiErrorDetail(apiErr)
+	switch {
+	case containsAny(detail, blueprintConfigErrorPatterns):
+		return ErrBlueprintConfig, false
+	case containsAny(detail, schemaMismatchErrorPatterns):
+		return ErrSchemaMismatch, false
+	case containsAny(detail, dependencyErrorPatterns):
+		return ErrDependency, true
+	}
+
+	switch status := apiErr.Status; {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth, false
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimit, true
+	case status == http.StatusConflict:
+		return ErrConflict, false
+	case status == http.StatusNotFound:
+		return ErrNotFound, false
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrValidation, false
+	case status >= 500:
+		return ErrServerError, false
+	}
+	return ErrUnknown, false
+}
+
 // containsAny checks if s contains any of the substrings.
 func containsAny(s string, substrings []string) bool {
 	for _, sub := range substrings {
@@ -239,6 +309,9 @@ type ErrorCollector struct {
 	// Grouped views (populated on demand)
 	byCategory map[ErrorCategory][]*ImportError
 	byResource map[string][]*ImportError
+
+	// onAdd, when set, is called with each error as it is collected.
+	onAdd func(*ImportError)
 }
 
 // NewErrorCollector creates a new error collector.
@@ -256,13 +329,7 @@ func (ec *ErrorCollector) Add(err error, resourceType, resourceID string) {
 		return
 	}
 
-	ie := CategorizeError(err, resourceType, resourceID)
-	ec.mu.Lock()
-	defer ec.mu.Unlock()
-
-	ec.errors = append(ec.errors, ie)
-	ec.byCategory[ie.Category] = append(ec.byCategory[ie.Category], ie)
-	ec.byResource[resourceType] = append(ec.byResource[resourceType], ie)
+	ec.AddImportError(CategorizeError(err, resourceType, resourceID))
 }
 
 // AddImportError adds a pre-categorized ImportError.
@@ -272,11 +339,15 @@ func (ec *ErrorCollector) AddImportError(ie *ImportError) {
 	}
 
 	ec.mu.Lock()
-	defer ec.mu.Unlock()
-
 	ec.errors = append(ec.errors, ie)
 	ec.byCategory[ie.Category] = append(ec.byCategory[ie.Category], ie)
 	ec.byResource[ie.ResourceType] = append(ec.byResource[ie.ResourceType], ie)
+	onAdd := ec.onAdd
+	ec.mu.Unlock()
+
+	if onAdd != nil {
+		onAdd(ie)
+	}
 }
 
 // HasErrors returns true if any errors were collected.
@@ -404,6 +475,37 @@ func (ec *ErrorCollector) ToStringSlice() []string {
 	return result
 }
 
+// ErrorDetail is the machine-readable form of an ImportError used in JSON
+// output.
+type ErrorDetail struct {
+	Category     ErrorCategory `json:"category"`
+	ResourceType string        `json:"resource_type"`
+	ResourceID   string        `json:"resource_id"`
+	Message      string        `json:"message"`
+	Retryable    bool          `json:"retryable"`
+}
+
+// ErrorReport converts errs to error details and counts them by category.
+// Nil entries are skipped.
+func ErrorReport(errs []*ImportError) ([]ErrorDetail, map[ErrorCategory]int) {
+	details := make([]ErrorDetail, 0, len(errs))
+	counts := make(map[ErrorCategory]int)
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		details = append(details, ErrorDetail{
+			Category:     e.Category,
+			ResourceType: e.ResourceType,
+			ResourceID:   e.ResourceID,
+			Message:      e.Message,
+			Retryable:    e.Retryable,
+		})
+		counts[e.Category]++
+	}
+	return details, counts
+}
+
 // truncate shortens a string to maxLen, adding "..." if truncated.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -1,8 +1,12 @@
 package import_module
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
 )
 
 func TestCategorizeError_Dependency(t *testing.T) {
@@ -321,3 +325,94 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestErrorCollector_CountsCanceledRequests(t *testing.T) {
+	ec := NewErrorCollector()
+	ec.Add(fmt.Errorf("failed to execute request: %w", context.Canceled), "entity", "svc")
+	ec.Add(errors.New("boom"), "entity", "other")
+
+	if ec.Count() != 2 {
+		t.Fatalf("expected both errors to be collected, got %d errors", ec.Count())
+	}
+}
+
+func TestInterruptedResult_DropsCanceledRequests(t *testing.T) {
+	importer := &Importer{errors: NewErrorCollector()}
+	importer.errors.Add(fmt.Errorf("failed to execute request: %w", context.Canceled), "entity", "svc")
+	importer.errors.Add(errors.New("boom"), "entity", "other")
+
+	result, err := interruptedResult(&Result{}, importer, context.Canceled)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected an interruption error, got %v", err)
+	}
+	if len(result.Errors) != 1 || len(result.CategorizedErrors) != 1 || result.CategorizedErrors[0].ResourceID != "other" {
+		t.Fatalf("expected only the real failure to be reported, got %v", result.Errors)
+	}
+	if result.Success || result.Message != "Import interrupted with 1 error(s)" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestCategorizeError_APIErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{"409 without conflict text", &api.APIError{Status: 409, Body: "{}"}, ErrConflict},
+		{"404 missing blueprint", &api.APIError{Status: 404, Message: "Blueprint not found"}, ErrDependency},
+		{"401", &api.APIError{Status: 401}, ErrAuth},
+		{"422", &api.APIError{Status: 422, Code: "invalid_body"}, ErrValidation},
+		{"503 wrapped", fmt.Errorf("failed to create entity: %w", &api.APIError{Status: 503}), ErrServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ie := CategorizeError(tt.err, "entity", "test")
+			if ie.Category != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, ie.Category)
+			}
+		})
+	}
+}
+
+func TestIsConflictError_UsesAPIErrorStatus(t *testing.T) {
+	// A 422 whose body happens to mention "Conflict" is not a conflict.
+	err := &api.APIError{Status: 422, Body: `{"message":"Conflict between properties"}`}
+	if isConflictError(err) {
+		t.Error("expected 422 APIError not to be treated as a conflict")
+	}
+	if !isConflictError(fmt.Errorf("wrapped: %w", &api.APIError{Status: 409})) {
+		t.Error("expected wrapped 409 APIError to be a conflict")
+	}
+}
+
+func TestIsRelationError_IgnoresAPIErrorURL(t *testing.T) {
+	err := &api.APIError{Method: "PATCH", URL: "https://api.getport.io/v1/blueprints/x/relations", Status: 500, Message: "Internal error"}
+	if IsRelationError(err) {
+		t.Error("expected the request URL not to make an error a relation error")
+	}
+	err.Message = "Relation target blueprint does not exist"
+	if !IsRelationError(err) {
+		t.Error("expected the API message to be matched")
+	}
+}
+
+func TestErrorReport(t *testing.T) {
+	errs := []*ImportError{
+		CategorizeError(&api.APIError{Status: 401}, "blueprint", "service"),
+		nil,
+		CategorizeError(&api.APIError{Status: 429}, "entity", "svc-a"),
+		CategorizeError(&api.APIError{Status: 429}, "entity", "svc-b"),
+	}
+
+	details, counts := ErrorReport(errs)
+	if len(details) != 3 {
+		t.Fatalf("expected 3 details, got %d", len(details))
+	}
+	if d := details[1]; d.Category != ErrRateLimit || d.ResourceType != "entity" || d.ResourceID != "svc-a" || !d.Retryable || d.Message == "" {
+		t.Errorf("unexpected detail %+v", d)
+	}
+	if counts[ErrAuth] != 1 || counts[ErrRateLimit] != 2 || len(counts) != 2 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+}
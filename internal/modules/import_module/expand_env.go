@@ -0,0 +1,66 @@
+package import_module
+
+import (
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/envexpand"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// expandDataEnv substitutes environment variables into every string value of
+// the loaded resources, so a bundle can reference ${VAR} in place of
+// per-environment URLs or secrets. Under envexpand.Strict, references to
+// unset variables are reported as an error once everything is expanded.
+func expandDataEnv(data *export.Data, mode envexpand.Mode) error {
+	expander := envexpand.New(mode)
+	if expander == nil || data == nil {
+		return nil
+	}
+	for _, bp := range data.Blueprints {
+		expander.Value(map[string]interface{}(bp))
+	}
+	for _, entity := range data.Entities {
+		expander.Value(map[string]interface{}(entity))
+	}
+	for _, sc := range data.Scorecards {
+		expander.Value(map[string]interface{}(sc))
+	}
+	for _, action := range data.Actions {
+		expander.Value(map[string]interface{}(action))
+	}
+	for _, perms := range []map[string]interface{}{
+		permissionValues(data.BlueprintPermissions),
+		permissionValues(data.ActionPermissions),
+		permissionValues(data.PagePermissions),
+	} {
+		expander.Value(perms)
+	}
+	for _, team := range data.Teams {
+		expander.Value(map[string]interface{}(team))
+	}
+	for _, user := range data.Users {
+		expander.Value(map[string]interface{}(user))
+	}
+	for _, folder := range data.Folders {
+		expander.Value(map[string]interface{}(folder))
+	}
+	for _, page := range data.Pages {
+		expander.Value(map[string]interface{}(page))
+	}
+	for _, integration := range data.Integrations {
+		expander.Value(map[string]interface{}(integration))
+	}
+	for _, source := range data.DataSources {
+		expander.Value(map[string]interface{}(source))
+	}
+	return expander.Err()
+}
+
+// permissionValues views a permissions map as a plain value for expansion.
+// The permission objects are shared, so expanding them updates perms.
+func permissionValues(perms map[string]api.Permissions) map[string]interface{} {
+	values := make(map[string]interface{}, len(perms))
+	for id, p := range perms {
+		values[id] = map[string]interface{}(p)
+	}
+	return values
+}
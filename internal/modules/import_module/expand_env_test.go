@@ -0,0 +1,88 @@
+package import_module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/envexpand"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestExpandDataEnv_Lenient(t *testing.T) {
+	t.Setenv("PORT_TEST_WEBHOOK", "https://hooks.example.com")
+	data := &export.Data{
+		Blueprints: []api.Blueprint{{"identifier": "service", "title": "$identifier"}},
+		Actions: []api.Action{{
+			"identifier":       "deploy",
+			"invocationMethod": map[string]interface{}{"url": "${PORT_TEST_WEBHOOK}/deploy", "body": "${PORT_TEST_UNSET}"},
+		}},
+		ActionPermissions: map[string]api.Permissions{"deploy": {"execute": map[string]interface{}{"teams": []interface{}{"$PORT_TEST_UNSET_TEAM"}}}},
+		DataSources:       []api.Webhook{{"identifier": "incoming", "url": "${PORT_TEST_WEBHOOK}/incoming"}},
+	}
+
+	if err := expandDataEnv(data, envexpand.Lenient); err != nil {
+		t.Fatalf("expandDataEnv: %v", err)
+	}
+	method := data.Actions[0]["invocationMethod"].(map[string]interface{})
+	if method["url"] != "https://hooks.example.com/deploy" {
+		t.Errorf("expected the webhook URL to be expanded, got %v", method["url"])
+	}
+	if method["body"] != "${PORT_TEST_UNSET}" {
+		t.Errorf("expected an unset variable to be kept, got %v", method["body"])
+	}
+	if data.DataSources[0]["url"] != "https://hooks.example.com/incoming" {
+		t.Errorf("expected the data source URL to be expanded, got %v", data.DataSources[0]["url"])
+	}
+	if data.Blueprints[0]["title"] != "$identifier" {
+		t.Errorf("expected Port's $identifier to be left alone, got %v", data.Blueprints[0]["title"])
+	}
+}
+
+func TestExpandDataEnv_StrictReportsMissingVariables(t *testing.T) {
+	data := &export.Data{
+		Pages:             []api.Page{{"identifier": "home", "title": "${PORT_TEST_UNSET_TITLE}"}},
+		ActionPermissions: map[string]api.Permissions{"deploy": {"execute": map[string]interface{}{"teams": []interface{}{"$PORT_TEST_UNSET_TEAM"}}}},
+	}
+
+	err := expandDataEnv(data, envexpand.Strict)
+	var missing *envexpand.MissingError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected a MissingError, got %v", err)
+	}
+	if len(missing.Names) != 2 || missing.Names[0] != "PORT_TEST_UNSET_TEAM" || missing.Names[1] != "PORT_TEST_UNSET_TITLE" {
+		t.Errorf("unexpected missing variables %v", missing.Names)
+	}
+}
+
+func TestPartitionEntities_ExpandsEnv(t *testing.T) {
+	t.Setenv("PORT_TEST_OWNER", "platform")
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	content := `{"entities": [{"identifier":"svc-1","blueprint":"service","properties":{"owner":"${PORT_TEST_OWNER}","url":"$PORT_TEST_UNSET_URL"}}]}`
+	if err := os.WriteFile(inputPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	if _, err := partitionEntities(inputPath, Options{ExpandEnv: envexpand.Strict}); err == nil {
+		t.Fatal("expected strict mode to fail on the unset variable")
+	}
+
+	partitions, err := partitionEntities(inputPath, Options{ExpandEnv: envexpand.Lenient})
+	if err != nil {
+		t.Fatalf("partitionEntities error: %v", err)
+	}
+	defer partitions.cleanup()
+	var entities []api.Entity
+	if err := forEachPartitionEntity(t.Context(), partitions.list()[0].Path, func(entity api.Entity) error {
+		entities = append(entities, entity)
+		return nil
+	}); err != nil {
+		t.Fatalf("read partition entities: %v", err)
+	}
+	props := entities[0]["properties"].(map[string]interface{})
+	if props["owner"] != "platform" || props["url"] != "$PORT_TEST_UNSET_URL" {
+		t.Errorf("unexpected properties %v", props)
+	}
+}
@@ -3,7 +3,9 @@ package import_module
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
 	"sort"
@@ -13,6 +15,8 @@ import (
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/auth"
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/envexpand"
+	"github.com/port-experimental/port-cli/internal/logging"
 	"github.com/port-experimental/port-cli/internal/modules/export"
 	systemblueprints "github.com/port-experimental/port-cli/internal/modules/system_blueprints"
 )
@@ -29,6 +33,12 @@ func NewModule(token *auth.Token, orgConfig *config.OrganizationConfig) *Module
 		ClientID:     orgConfig.ClientID,
 		ClientSecret: orgConfig.ClientSecret,
 		APIURL:       orgConfig.APIURL,
+		RateLimit:    orgConfig.RateLimit,
+		Concurrency:  orgConfig.Concurrency,
+		Headers:      orgConfig.Headers,
+		APIVersion:   orgConfig.APIVersion,
+		CacheTTL:     orgConfig.CacheTTL,
+		NoCache:      orgConfig.NoCache,
 		Timeout:      0,
 	})
 	return &Module{
@@ -41,6 +51,19 @@ func NewModule(token *auth.Token, orgConfig *config.OrganizationConfig) *Module
 // phase is the current phase name, current is the number of items processed, total is the total count.
 type ProgressCallback func(phase string, current, total int)
 
+// ResourceCallback is called once for every resource an import creates,
+// updates, deletes under --prune or --replace-all, skips under --on-conflict
+// skip, or fails to import. action is one of the Resource* constants.
+type ResourceCallback func(action, resourceType, identifier string)
+
+// Actions passed to ResourceCallback.
+const (
+	ResourceCreated = "created"
+	ResourceUpdated = "updated"
+	ResourceSkipped = "skipped"
+	ResourceFailed  = "failed"
+)
+
 // Options represents import options.
 type Options struct {
 	InputPath                     string
@@ -48,20 +71,44 @@ type Options struct {
 	SkipEntities                  bool
 	SkipSystemBlueprints          bool // skip _* blueprint schemas and their entities
 	SkipSystemBlueprintProperties bool
+	IncludeSystemBlueprints       bool // diff and update Port-managed system blueprints too; never creates them
 	IncludeRuleResults            bool // include _rule_result system blueprint entities (included by default)
 	IncludeResources              []string
-	ExcludeBlueprints             []string // deep: exclude blueprint schema + all its resources
-	ExcludeBlueprintSchema        []string // shallow: exclude only the blueprint schema, keep resources
-	UsersAsDisabled               bool     // import non-admin users as DISABLED after staging
+	IncludePatterns               map[string][]string // resource type -> identifier globs from --include "type:glob"
+	ExcludeBlueprints             []string            // deep: exclude blueprint schema + all its resources
+	ExcludeBlueprintSchema        []string            // shallow: exclude only the blueprint schema, keep resources
+	UsersAsDisabled               bool                // import non-admin users as DISABLED after staging
+	CreateIntegrations            bool                // install integrations missing from the target instead of skipping them
+	AllowBreaking                 bool                // apply blueprint schema changes that could invalidate existing entities
+	Prune                         bool                // delete the resources a delta bundle lists under _deletions
+	ReplaceAll                    bool                // also delete every target resource missing from the input, so the target mirrors it
+	ConfirmDeletions              DeletionConfirmer   // approves ReplaceAll's deletions before anything changes; nil approves them
+	ConfirmApply                  ApplyConfirmer      // approves the diff before anything changes; nil approves it
+	OnConflict                    ConflictStrategy    // what to do with resources that already exist; empty means ConflictUpdate
+	ForceUpdate                   bool                // update existing resources even when the diff finds them unchanged
+	StrictRelations               bool                // fail on relations to missing blueprints instead of reporting them and continuing
 	Verbose                       bool
 	ShowPagesPipeline             bool
+	Transforms                    []TransformRule
+	ActionURLMap                  map[string]string // rewrites values in action invocation methods, from --action-url-map
+	ExpandEnv                     envexpand.Mode    // substitute ${VAR} references in the input files; Off leaves them as written
 	ProgressCallback              ProgressCallback
+	ResourceCallback              ResourceCallback
 	LogCallback                   func(string)
+
+	// SkipEntitiesFor lists blueprint identifiers or globs whose entities are
+	// neither compared nor imported; the rest of the import is unchanged.
+	SkipEntitiesFor []string
+
+	// RecordEntityChanges keeps the entities created or updated while
+	// streaming in Result.DiffResult, for change reports and update previews.
+	// Streamed entities are otherwise not held in memory.
+	RecordEntityChanges bool
 }
 
 // ValidationWarning represents a pre-import validation warning.
 type ValidationWarning struct {
-	Type    string // "cycle", "missing_dependency", "protected_resource", "orphaned_permission_field"
+	Type    string // "cycle", "missing_dependency", "protected_resource", "orphaned_permission_field", "breaking_change"
 	Message string
 	Details []string
 }
@@ -84,10 +131,17 @@ type Result struct {
 	UsersUpdated                int
 	PagesCreated                int
 	PagesUpdated                int
+	IntegrationsCreated         int
 	IntegrationsUpdated         int
+	DataSourcesCreated          int
+	DataSourcesUpdated          int
 	BlueprintPermissionsUpdated int
 	ActionPermissionsUpdated    int
 	PagePermissionsUpdated      int
+	TeamMembersAdded            int
+	TeamMembersRemoved          int
+	ResourcesDeleted            int // resources removed by Prune or ReplaceAll
+	ResourcesSkipped            int // existing resources left untouched under ConflictSkip
 	Errors                      []string
 	ErrorsByCategory            map[string][]string // Categorized errors for verbose output
 	Warnings                    []ValidationWarning // Pre-import validation warnings
@@ -97,6 +151,25 @@ type Result struct {
 	IgnoredRuleResultTargetRelationCount int
 	// IgnoredRuleResultTargetRelationKeys lists relation identifiers omitted (sorted, unique).
 	IgnoredRuleResultTargetRelationKeys []string
+	// CategorizedErrors holds Errors with their category, resource and
+	// retryability, for structured output.
+	CategorizedErrors []*ImportError
+}
+
+// Applied returns how many changes the import wrote to the target org.
+func (r *Result) Applied() int {
+	return r.BlueprintsCreated + r.BlueprintsUpdated +
+		r.EntitiesCreated + r.EntitiesUpdated +
+		r.ScorecardsCreated + r.ScorecardsUpdated +
+		r.ActionsCreated + r.ActionsUpdated +
+		r.TeamsCreated + r.TeamsUpdated +
+		r.TeamMembersAdded + r.TeamMembersRemoved +
+		r.UsersCreated + r.UsersUpdated +
+		r.PagesCreated + r.PagesUpdated +
+		r.IntegrationsCreated + r.IntegrationsUpdated +
+		r.DataSourcesCreated + r.DataSourcesUpdated +
+		r.BlueprintPermissionsUpdated + r.ActionPermissionsUpdated + r.PagePermissionsUpdated +
+		r.ResourcesDeleted
 }
 
 type SidebarPipelineOperation struct {
@@ -110,11 +183,35 @@ type SidebarPipelineStep struct {
 	Operations []SidebarPipelineOperation
 }
 
+// interruptedResult marks result as stopped by a canceled context so callers
+// can still report what was imported before the interruption. Errors from
+// requests that were aborted by the cancellation are dropped so the summary
+// only lists real failures.
+func interruptedResult(result *Result, importer *Importer, err error) (*Result, error) {
+	result.Errors = nil
+	result.CategorizedErrors = nil
+	for _, ie := range importer.errors.All() {
+		if errors.Is(ie.Cause, context.Canceled) {
+			continue
+		}
+		result.Errors = append(result.Errors, ie.Error())
+		result.CategorizedErrors = append(result.CategorizedErrors, ie)
+	}
+	result.Success = false
+	result.Message = fmt.Sprintf("Import interrupted with %d error(s)", len(result.Errors))
+	return result, fmt.Errorf("import interrupted: %w", err)
+}
+
 // Execute performs the import operation.
 func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
+	logger := logging.FromContext(ctx)
+
 	// Load data
+	finishLoad := logger.Phase("load")
 	loader := NewLoader()
-	streamEntities := !opts.SkipEntities && shouldImport("entities", opts.IncludeResources)
+	// ReplaceAll loads every entity, as it must find the target's entities
+	// missing from the input.
+	streamEntities := !opts.SkipEntities && shouldImport("entities", opts.IncludeResources) && !opts.ReplaceAll
 	var data *export.Data
 	var err error
 	if streamEntities {
@@ -122,29 +219,76 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 	} else {
 		data, err = loader.LoadData(opts.InputPath)
 	}
+	finishLoad()
+	if err == nil {
+		err = expandDataEnv(data, opts.ExpandEnv)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load data: %w", err)
 	}
 
+	if opts.ReplaceAll {
+		if err := checkReplaceAllInput(data.Manifest); err != nil {
+			return nil, err
+		}
+		opts.Prune = true
+	}
+
 	// Apply blueprint exclusions before diffing/importing
 	applyDataExclusion(data, opts.ExcludeBlueprints, opts.ExcludeBlueprintSchema, opts.SkipSystemBlueprints, opts.SkipSystemBlueprintProperties)
+	data.Entities = withoutSkippedEntities(data.Entities, opts.SkipEntitiesFor)
+
+	// Keep only the identifiers selected by --include "type:glob" entries
+	export.FilterByIncludePatterns(data, opts.IncludePatterns)
+
+	// Apply transforms before the diff so it reflects the transformed data
+	applyTransformsToData(data, opts.Transforms)
+	actionURLLeftovers := ActionURLMapLeftovers(data.Actions, opts.ActionURLMap)
+	data.Actions = RewriteActionInvocations(data.Actions, opts.ActionURLMap)
 
 	// Validate data
 	if err := loader.ValidateData(data, opts.IncludeResources); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Under --strict-relations, stop before changing anything when a relation
+	// targets a blueprint missing from both the input and the target
+	if opts.StrictRelations && shouldImport("blueprints", opts.IncludeResources) {
+		if err := CheckRelationTargets(ctx, m.client, data.Blueprints); err != nil {
+			return nil, err
+		}
+	}
+
 	// Diff validation (always enabled)
 	comparer := NewDiffComparer(m.client)
 	compareOpts := opts
 	if streamEntities {
 		compareOpts.SkipEntities = true
 	}
+	finishDiff := logger.Phase("diff")
 	diffResult, err := comparer.Compare(ctx, data, compareOpts)
+	finishDiff()
 	if err != nil {
 		return nil, fmt.Errorf("diff comparison failed: %w", err)
 	}
 
+	// Stop before applying schema changes that could invalidate existing entities
+	breaking := DetectBreakingChanges(diffResult)
+	if len(breaking) > 0 && !opts.AllowBreaking && !opts.DryRun {
+		return nil, &BreakingChangesError{Changes: breaking}
+	}
+
+	// Under --replace-all, delete what the target has beyond the input
+	if opts.ReplaceAll {
+		data.Deletions = mergeDeletions(data.Deletions, replaceAllDeletions(data, diffResult.Current, opts))
+	}
+	if opts.Prune {
+		diffResult.Deletions = selectedDeletions(data.Deletions, opts)
+	}
+
+	// Leave existing resources alone under --on-conflict skip
+	conflictsSkipped := diffResult.applyConflictStrategy(opts.OnConflict)
+
 	// Use diff result to filter data
 	data = diffResult.FilterData(data)
 
@@ -153,8 +297,19 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 	// Dry run - show what would happen
 	if opts.DryRun {
 		result := m.generateDryRunResult(data, diffResult, opts)
+		result.ResourcesSkipped = conflictsSkipped
+		result.Warnings = appendManifestWarning(result.Warnings, data.Manifest)
+		result.Warnings = existingConflictWarning(result.Warnings, diffResult, opts.OnConflict)
+		result.Warnings = appendBreakingChangeWarning(result.Warnings, breaking)
+		result.Warnings = appendDeletionsWarning(result.Warnings, data.Deletions, opts.Prune)
+		result.Warnings = appendForceUpdateWarning(result.Warnings, opts.ForceUpdate)
+		result.Warnings = actionURLMapWarning(result.Warnings, actionURLLeftovers)
+		if opts.Prune {
+			result.ResourcesDeleted = countDeletions(data.Deletions, opts)
+		}
 		if streamEntities {
 			importer := NewImporter(m.client)
+			importer.onConflict = opts.OnConflict
 			if opts.ProgressCallback != nil {
 				importer.SetProgressCallback(opts.ProgressCallback)
 			}
@@ -166,8 +321,25 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		return result, nil
 	}
 
+	if err := ConfirmApply(diffResult, streamEntities, opts.ConfirmApply); err != nil {
+		return nil, err
+	}
+
+	if opts.ReplaceAll && opts.ConfirmDeletions != nil {
+		if deletions := selectedDeletions(data.Deletions, opts); len(deletions) > 0 {
+			confirmed, err := opts.ConfirmDeletions(deletions)
+			if err != nil {
+				return nil, err
+			}
+			if !confirmed {
+				return nil, ErrDeletionsDeclined
+			}
+		}
+	}
+
 	// Import data using new reliable importer
 	importer := NewImporter(m.client)
+	importer.SetIntegrationsToCreate(diffResult.IntegrationsToCreate)
 	if len(sidebarPipeline) > 0 && opts.LogCallback != nil && opts.ShowPagesPipeline {
 		opts.LogCallback("Proposed sidebar pipeline:")
 		for _, line := range DescribeSidebarPipeline(sidebarPipeline) {
@@ -178,16 +350,38 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 	if streamEntities {
 		importOpts.SkipEntities = true
 	}
+	finishImport := logger.Phase("import")
 	result, err := importer.Import(ctx, data, importOpts)
+	finishImport()
 	if err != nil {
 		return nil, fmt.Errorf("import failed: %w", err)
 	}
+	result.ResourcesSkipped += conflictsSkipped
+	result.Warnings = appendManifestWarning(result.Warnings, data.Manifest)
+	result.Warnings = appendBreakingChangeWarning(result.Warnings, breaking)
+	result.Warnings = appendDeletionsWarning(result.Warnings, data.Deletions, opts.Prune)
+	result.Warnings = appendForceUpdateWarning(result.Warnings, opts.ForceUpdate)
+	result.Warnings = actionURLMapWarning(result.Warnings, actionURLLeftovers)
+	result.DiffResult = diffResult
+	if ctx.Err() != nil {
+		return interruptedResult(result, importer, context.Cause(ctx))
+	}
 	if streamEntities {
-		if err := importer.ImportEntitiesFromStream(ctx, opts.InputPath, opts, result, false); err != nil {
+		finishEntities := logger.Phase("entities")
+		err := importer.ImportEntitiesFromStream(ctx, opts.InputPath, opts, result, false)
+		finishEntities()
+		if err != nil {
+			if ctx.Err() != nil {
+				return interruptedResult(result, importer, context.Cause(ctx))
+			}
 			return nil, fmt.Errorf("streaming entity import failed: %w", err)
 		}
 	}
 
+	if !importOpts.SkipEntities && shouldImport("teams", opts.IncludeResources) {
+		result.TeamMembersAdded, result.TeamMembersRemoved = importer.importTeamMemberships(ctx, diffResult)
+	}
+
 	// Import permissions (blueprint and action permissions depend on resources existing)
 	bpUpdated, actionUpdated, pageUpdated, permWarnings := importer.importPermissions(ctx, diffResult)
 
@@ -199,8 +393,16 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		})
 	}
 
-	// Merge any permission errors into result
+	// Delete what the delta bundle removed, or under --replace-all what the
+	// target has beyond the input, once everything it adds or changes is in
+	// place
+	if opts.Prune {
+		result.ResourcesDeleted = importer.pruneDeletions(ctx, data.Deletions, opts)
+	}
+
+	// Merge any permission and deletion errors into result
 	result.Errors = importer.errors.ToStringSlice()
+	result.CategorizedErrors = importer.errors.All()
 	result.BlueprintPermissionsUpdated = bpUpdated
 	result.ActionPermissionsUpdated = actionUpdated
 	result.PagePermissionsUpdated = pageUpdated
@@ -212,7 +414,6 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		result.Success = true
 		result.Message = "Successfully imported data"
 	}
-	result.DiffResult = diffResult
 	result.SidebarPipeline = DescribeSidebarPipeline(sidebarPipeline)
 	return result, nil
 }
@@ -220,6 +421,7 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 // generateDryRunResult generates a dry run result with accurate predictions.
 func (m *Module) generateDryRunResult(data *export.Data, diffResult *DiffResult, _ Options) *Result {
 	if diffResult != nil {
+		membersAdded, membersRemoved := CountTeamMembershipChanges(diffResult.TeamMemberships)
 		return &Result{
 			Success:                     true,
 			Message:                     "Validation passed (dry run - no changes applied)",
@@ -237,10 +439,15 @@ func (m *Module) generateDryRunResult(data *export.Data, diffResult *DiffResult,
 			UsersUpdated:                len(diffResult.UsersToUpdate),
 			PagesCreated:                len(diffResult.PagesToCreate),
 			PagesUpdated:                len(diffResult.PagesToUpdate),
+			IntegrationsCreated:         len(diffResult.IntegrationsToCreate),
 			IntegrationsUpdated:         len(diffResult.IntegrationsToUpdate),
+			DataSourcesCreated:          len(diffResult.DataSourcesToCreate),
+			DataSourcesUpdated:          len(diffResult.DataSourcesToUpdate),
 			BlueprintPermissionsUpdated: len(diffResult.BlueprintPermissions),
 			ActionPermissionsUpdated:    len(diffResult.ActionPermissions),
 			PagePermissionsUpdated:      len(diffResult.PagePermissions),
+			TeamMembersAdded:            membersAdded,
+			TeamMembersRemoved:          membersRemoved,
 			DiffResult:                  diffResult,
 		}
 	}
@@ -294,6 +501,10 @@ func isConflictError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if apiErr, ok := api.AsAPIError(err); ok {
+		return apiErr.Status == http.StatusConflict
+	}
+	// Errors built from bulk per-entity results carry no status.
 	errStr := err.Error()
 	return strings.Contains(errStr, "409") || strings.Contains(errStr, "Conflict")
 }
@@ -406,20 +617,86 @@ type Importer struct {
 	client                 *api.Client
 	errors                 *ErrorCollector
 	mu                     sync.Mutex
+	counts                 Counters
 	log                    func(string)
 	verbose                bool
 	progress               ProgressCallback
+	onResource             ResourceCallback
 	ruleResultIgnoreDedupe map[string]struct{}
+	integrationsToCreate   map[string]bool
+	onConflict             ConflictStrategy
+	strictRelations        bool
+	relationWriter         *RelationWriter
 }
 
 // NewImporter creates a new importer.
 func NewImporter(client *api.Client) *Importer {
 	return &Importer{
-		client: client,
-		errors: NewErrorCollector(),
+		client:         client,
+		errors:         NewErrorCollector(),
+		relationWriter: NewRelationWriter(client),
 	}
 }
 
+// SetIntegrationsToCreate marks integrations that do not exist in the target
+// and must be installed rather than having their config patched.
+func (i *Importer) SetIntegrationsToCreate(integrations []api.Integration) {
+	i.integrationsToCreate = make(map[string]bool, len(integrations))
+	for _, integ := range integrations {
+		if id, ok := integ["identifier"].(string); ok && id != "" {
+			i.integrationsToCreate[id] = true
+		}
+	}
+}
+
+// SetResourceCallback sets the callback notified of each created, updated,
+// skipped or failed resource.
+func (i *Importer) SetResourceCallback(cb ResourceCallback) {
+	i.onResource = cb
+	if cb == nil {
+		i.errors.onAdd = nil
+		return
+	}
+	i.errors.onAdd = func(ie *ImportError) {
+		cb(ResourceFailed, ie.ResourceType, ie.ResourceID)
+	}
+}
+
+// reportResource passes a resource and what happened to it to the resource
+// callback.
+func (i *Importer) reportResource(action, resourceType, identifier string) {
+	if i.onResource != nil {
+		i.onResource(action, resourceType, identifier)
+	}
+}
+
+// reportBulkResources reports every entity in a bulk request that is not
+// listed in errs.
+func (i *Importer) reportBulkResources(action, resourceType string, entities []api.Entity, errs []api.BulkEntityError) {
+	if i.onResource == nil {
+		return
+	}
+	for _, id := range BulkSucceeded(entities, errs) {
+		i.onResource(action, resourceType, id)
+	}
+}
+
+// BulkSucceeded returns the identifiers of entities in a bulk request that are
+// not listed in the request's per-entity errors.
+func BulkSucceeded(entities []api.Entity, errs []api.BulkEntityError) []string {
+	failed := make(map[string]bool, len(errs))
+	for _, be := range errs {
+		failed[be.Identifier] = true
+	}
+	ids := make([]string, 0, len(entities))
+	for _, e := range entities {
+		if id, _ := e["identifier"].(string); id != "" && !failed[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // SetProgressCallback sets the progress callback for the importer.
 func (i *Importer) SetProgressCallback(cb ProgressCallback) {
 	i.progress = cb
@@ -430,6 +707,12 @@ func (i *Importer) CollectedErrors() []string {
 	return i.errors.ToStringSlice()
 }
 
+// CategorizedErrors returns the errors collected so far, in the order of
+// CollectedErrors.
+func (i *Importer) CategorizedErrors() []*ImportError {
+	return i.errors.All()
+}
+
 func (i *Importer) SetLogCallback(cb func(string)) {
 	i.log = cb
 }
@@ -447,10 +730,15 @@ func (i *Importer) Import(ctx context.Context, data *export.Data, opts Options)
 	if opts.ProgressCallback != nil {
 		i.progress = opts.ProgressCallback
 	}
+	if opts.ResourceCallback != nil {
+		i.SetResourceCallback(opts.ResourceCallback)
+	}
 	i.verbose = opts.Verbose
 	if opts.LogCallback != nil {
 		i.log = opts.LogCallback
 	}
+	i.onConflict = opts.OnConflict
+	i.strictRelations = opts.StrictRelations
 
 	result := &Result{
 		Errors:           []string{},
@@ -473,6 +761,7 @@ func (i *Importer) Import(ctx context.Context, data *export.Data, opts Options)
 
 	// Convert collected errors to string slice for backward compatibility
 	result.Errors = i.errors.ToStringSlice()
+	result.CategorizedErrors = i.errors.All()
 
 	// Populate errors by category for verbose output
 	for _, category := range []ErrorCategory{
@@ -500,6 +789,8 @@ func (i *Importer) Import(ctx context.Context, data *export.Data, opts Options)
 // Phase 2c: Add mirrorProperties (depend on relations existing)
 // Phase 2d: Add aggregationProperties (depend on properties existing on OTHER blueprints)
 // Phase 3: Update system blueprints
+// Mirror and aggregation properties whose target blueprint does not exist are
+// reported as errors and left out of Phases 2c and 2d.
 func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Blueprint, result *Result) error {
 	// Separate system and non-system blueprints
 	nonSystemBPs, systemBPs := SeparateSystemBlueprints(blueprints)
@@ -572,8 +863,10 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 		})
 	}
 
-	// Track successfully created blueprints
+	// Track successfully created blueprints, and the existing ones left alone
+	// under --on-conflict skip or fail
 	successfulBPs := make(map[string]bool)
+	untouchedBPs := make(map[string]bool)
 	for id := range existingBPs {
 		successfulBPs[id] = true
 	}
@@ -598,9 +891,13 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 					i.errors.Add(err, "blueprint", id)
 				} else {
 					if created {
-						result.BlueprintsCreated++
+						i.counts.Blueprints.Created.Add(1)
+						i.reportResource(ResourceCreated, "blueprint", id)
 					} else if updated {
-						result.BlueprintsUpdated++
+						i.counts.Blueprints.Updated.Add(1)
+						i.reportResource(ResourceUpdated, "blueprint", id)
+					} else {
+						untouchedBPs[id] = true
 					}
 					levelMu.Lock()
 					successfulBPs[id] = true
@@ -627,9 +924,13 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 					i.errors.Add(err, "blueprint", id)
 				} else {
 					if created {
-						result.BlueprintsCreated++
+						i.counts.Blueprints.Created.Add(1)
+						i.reportResource(ResourceCreated, "blueprint", id)
 					} else if updated {
-						result.BlueprintsUpdated++
+						i.counts.Blueprints.Updated.Add(1)
+						i.reportResource(ResourceUpdated, "blueprint", id)
+					} else {
+						untouchedBPs[id] = true
 					}
 					successfulBPs[id] = true
 				}
@@ -640,6 +941,16 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 		pool.Wait()
 	}
 
+	// Existing blueprints that were not updated keep their relations and
+	// dependent properties too
+	for id := range untouchedBPs {
+		delete(storedRelations, id)
+		delete(storedCalcProps, id)
+		delete(storedMirrorProps, id)
+		delete(storedAggProps, id)
+		delete(storedOwnership, id)
+	}
+
 	// Fetch ALL existing blueprints from target for validation
 	allExistingBPs := make(map[string]bool)
 	for id := range successfulBPs {
@@ -655,6 +966,9 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 	}
 
 	// Phase 2a: Add relations back to all blueprints
+	if err := CheckStoredRelationTargets(i.strictRelations, storedRelations, allExistingBPs); err != nil {
+		return err
+	}
 	if len(storedRelations) > 0 {
 		i.reportProgress("Blueprints (adding relations)", 0, len(storedRelations))
 		count := 0
@@ -664,7 +978,10 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 			}
 			id, relations := id, relations
 			pool.Go(func() {
-				err := i.updateBlueprintFieldsDirect(ctx, id, map[string]interface{}{"relations": relations}, result)
+				applied, err := i.relationWriter.Apply(ctx, id, relations)
+				if !applied {
+					err = i.updateBlueprintFieldsDirect(ctx, id, map[string]interface{}{"relations": relations}, result)
+				}
 				i.mu.Lock()
 				if err != nil {
 					i.errors.Add(err, "blueprint", id)
@@ -677,6 +994,28 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 		pool.Wait()
 	}
 
+	// Check the targets of mirror and aggregation properties before applying
+	// them, so a property reading from a missing blueprint is reported by name
+	// instead of failing the update of all the blueprint's properties.
+	relationsByBP := make(map[string]map[string]interface{})
+	for _, bp := range targetBlueprints {
+		if id, ok := bp["identifier"].(string); ok && id != "" {
+			relationsByBP[id] = ExtractRelations(bp)
+		}
+	}
+	for id, relations := range storedRelations {
+		merged := make(map[string]interface{}, len(relationsByBP[id])+len(relations))
+		for name, rel := range relationsByBP[id] {
+			merged[name] = rel
+		}
+		for name, rel := range relations {
+			merged[name] = rel
+		}
+		relationsByBP[id] = merged
+	}
+	i.dropUnresolvableProperties("mirrorProperties", storedMirrorProps, relationsByBP, allExistingBPs)
+	i.dropUnresolvableProperties("aggregationProperties", storedAggProps, relationsByBP, allExistingBPs)
+
 	// Phase 2b: Add calculationProperties (self-contained, no cross-blueprint deps)
 	if len(storedCalcProps) > 0 {
 		i.reportProgress("Blueprints (adding calculationProperties)", 0, len(storedCalcProps))
@@ -866,7 +1205,8 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 				if err != nil {
 					i.errors.Add(err, "blueprint", id)
 				} else if updated {
-					result.BlueprintsUpdated++
+					i.counts.Blueprints.Updated.Add(1)
+					i.reportResource(ResourceUpdated, "blueprint", id)
 				}
 				sysCount++
 				i.reportProgress("System blueprints", sysCount, len(systemBPs))
@@ -904,10 +1244,37 @@ func (i *Importer) importBlueprints(ctx context.Context, blueprints []api.Bluepr
 	if len(result.IgnoredRuleResultTargetRelationKeys) > 0 {
 		sort.Strings(result.IgnoredRuleResultTargetRelationKeys)
 	}
+	result.From(&i.counts)
 
 	return nil
 }
 
+// dropUnresolvableProperties removes from stored the mirror or aggregation
+// properties (field) whose target blueprint does not exist, recording an
+// error for each. relations maps each blueprint to its relations.
+func (i *Importer) dropUnresolvableProperties(field string, stored, relations map[string]map[string]interface{}, existing map[string]bool) {
+	label := "mirror property"
+	if field == "aggregationProperties" {
+		label = "aggregation property"
+	}
+	for id, props := range stored {
+		valid, invalid := ValidateDependentPropertyTargets(field, props, relations[id], existing)
+		names := make([]string, 0, len(invalid))
+		for name := range invalid {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			i.errors.Add(fmt.Errorf("%s %q: %s", label, name, invalid[name]), "blueprint", id)
+		}
+		if len(valid) == 0 {
+			delete(stored, id)
+		} else {
+			stored[id] = valid
+		}
+	}
+}
+
 // createOrUpdateBlueprint creates or updates a single blueprint.
 // Returns (created, updated, error).
 func (i *Importer) createOrUpdateBlueprint(ctx context.Context, bp api.Blueprint, result *Result) (bool, bool, error) {
@@ -927,6 +1294,9 @@ func (i *Importer) createOrUpdateBlueprint(ctx context.Context, bp api.Blueprint
 	}
 
 	if isConflictError(err) {
+		if !i.updateExisting(&i.counts.Blueprints, "blueprint", id) {
+			return false, false, nil
+		}
 		var updateErr error
 		if id == "_rule_result" {
 			_, updateErr = i.client.PatchBlueprint(ctx, id, sendBP)
@@ -1073,7 +1443,13 @@ func (i *Importer) importOtherResources(ctx context.Context, data *export.Data,
 		i.importIntegrations(ctx, data.Integrations, result, pool)
 	}
 
+	// Import data sources; their mappings need the blueprints imported above
+	if shouldImport("datasources", opts.IncludeResources) {
+		i.importDataSources(ctx, data.DataSources, pool)
+	}
+
 	pool.Wait()
+	result.From(&i.counts)
 
 	// Import pages level-by-level in topological `after` order.
 	// Sidebar resources are executed through a shared pipeline so folders and pages
@@ -1096,9 +1472,7 @@ func (i *Importer) importSidebarPipeline(ctx context.Context, pipeline []Sidebar
 					folderID := op.Identifier
 					postedFolder := CleanFolderForCreate(op.Folder)
 					if err := i.client.CreateFolder(ctx, postedFolder); err != nil && !isConflictError(err) {
-						i.mu.Lock()
 						i.errors.Add(err, "folder", folderID)
-						i.mu.Unlock()
 						return
 					}
 					i.logFolderCreateMismatch(ctx, folderID, postedFolder)
@@ -1109,6 +1483,7 @@ func (i *Importer) importSidebarPipeline(ctx context.Context, pipeline []Sidebar
 		}
 		pool.Wait()
 	}
+	result.From(&i.counts)
 }
 
 // ImportEntities imports entities with two-phase bulk approach.
@@ -1168,49 +1543,50 @@ func (i *Importer) ImportEntities(ctx context.Context, entities []api.Entity, in
 	}
 
 	total := len(filteredEntities)
+	order := SortEntitiesByRelations(filteredEntities, relationTargets)
 
-	// Separate entities with and without relations
-	entitiesWithRelations := make([]api.Entity, 0)
-	for _, entity := range filteredEntities {
-		if HasEntityRelations(entity) {
-			entitiesWithRelations = append(entitiesWithRelations, entity)
-		}
-	}
-
-	// Phase 1: Bulk upsert all entities with relations stripped
+	// Phase 1: bulk upsert entities level by level in relation order, so an
+	// entity's relation targets exist before it is written with its relations.
+	// Entities whose relations cannot be ordered (targets outside the import,
+	// cycles) are written without relations and related in Phase 2.
 	i.reportProgress(fmt.Sprintf("Entities Phase 1%s", skippedMsg), 0, total)
 	processedCount := 0
 	var progressMu sync.Mutex
-	successfulEntities := make(map[string]bool)
+	successfulEntities := make(map[ResourceKey]bool)
 	var successMu sync.Mutex
 
-	strippedEntities := make([]api.Entity, 0, len(filteredEntities))
-	for _, entity := range filteredEntities {
-		bp, ok1 := entity["blueprint"].(string)
-		id, ok2 := entity["identifier"].(string)
-		if !ok1 || !ok2 || bp == "" || id == "" {
-			continue
+	var relateLater []api.Entity
+	for _, level := range order.Levels {
+		batch := make([]api.Entity, 0, len(level))
+		for _, entity := range level {
+			if order.IsDeferred(entity) || !dependenciesSucceeded(order.dependencies(entity), successfulEntities, &successMu) {
+				// A relation target failed, so writing the relation would
+				// fail too: create the entity and retry its relations later.
+				if HasEntityRelations(entity) {
+					relateLater = append(relateLater, entity)
+				}
+				batch = append(batch, StripEntityRelations(entity))
+				continue
+			}
+			batch = append(batch, entity)
 		}
-		strippedEntities = append(strippedEntities, StripEntityRelations(entity))
+		i.bulkUpsertEntities(ctx, batch, false, result, successfulEntities, &successMu, "Entities Phase 1", total, &processedCount, &progressMu)
 	}
 
-	i.bulkUpsertEntities(ctx, strippedEntities, false, result, successfulEntities, &successMu, "Entities Phase 1", total, &processedCount, &progressMu)
-
 	// Phase 2: Bulk update entities with relations (upsert=true — known to exist from Phase 1)
-	if len(entitiesWithRelations) > 0 {
-		phase2Total := len(entitiesWithRelations)
+	if len(relateLater) > 0 {
+		phase2Total := len(relateLater)
 		i.reportProgress("Entities Phase 2 (relations)", 0, phase2Total)
 		phase2Count := 0
 		var phase2ProgressMu sync.Mutex
 
 		// Filter to entities that succeeded in Phase 1
-		successfulWithRelations := make([]api.Entity, 0, len(entitiesWithRelations))
-		for _, entity := range entitiesWithRelations {
+		successfulWithRelations := make([]api.Entity, 0, len(relateLater))
+		for _, entity := range relateLater {
 			blueprintID, _ := entity["blueprint"].(string)
 			entityID, _ := entity["identifier"].(string)
-			key := fmt.Sprintf("%s:%s", blueprintID, entityID)
 			successMu.Lock()
-			ok := successfulEntities[key]
+			ok := successfulEntities[entityKey(blueprintID, entityID)]
 			successMu.Unlock()
 			if ok {
 				successfulWithRelations = append(successfulWithRelations, entity)
@@ -1224,6 +1600,19 @@ func (i *Importer) ImportEntities(ctx context.Context, entities []api.Entity, in
 	return nil
 }
 
+// dependenciesSucceeded reports whether every entity key in deps was written
+// successfully.
+func dependenciesSucceeded(deps []ResourceKey, successfulEntities map[ResourceKey]bool, successMu *sync.Mutex) bool {
+	successMu.Lock()
+	defer successMu.Unlock()
+	for _, dep := range deps {
+		if !successfulEntities[dep] {
+			return false
+		}
+	}
+	return true
+}
+
 // createOrUpdateEntity creates or updates a single entity.
 func (i *Importer) createOrUpdateEntity(ctx context.Context, blueprintID, entityID string, entity api.Entity) (bool, bool, error) {
 	_, err := i.client.CreateEntity(ctx, blueprintID, entity)
@@ -1232,6 +1621,9 @@ func (i *Importer) createOrUpdateEntity(ctx context.Context, blueprintID, entity
 	}
 
 	if isConflictError(err) {
+		if !i.updateExisting(&i.counts.Entities, "entity", entityID) {
+			return false, false, nil
+		}
 		_, updateErr := i.client.UpdateEntity(ctx, blueprintID, entityID, entity)
 		if updateErr != nil {
 			return false, false, updateErr
@@ -1251,7 +1643,7 @@ func (i *Importer) processBulkChunk(
 	chunk []api.Entity,
 	upsert bool,
 	result *Result,
-	successfulEntities map[string]bool,
+	successfulEntities map[ResourceKey]bool,
 	successMu *sync.Mutex,
 	phaseName string,
 	total int,
@@ -1260,12 +1652,10 @@ func (i *Importer) processBulkChunk(
 ) {
 	bulkErrs, err := i.client.BulkUpsertEntities(ctx, blueprintID, chunk, upsert)
 	if err != nil {
-		i.mu.Lock()
 		for _, e := range chunk {
 			id, _ := e["identifier"].(string)
 			i.errors.Add(err, "entity", id)
 		}
-		i.mu.Unlock()
 		progressMu.Lock()
 		*processedCount += len(chunk)
 		progressMu.Unlock()
@@ -1284,17 +1674,20 @@ func (i *Importer) processBulkChunk(
 		id, _ := entity["identifier"].(string)
 		if bErr, failed := errByID[id]; failed {
 			if int(bErr.StatusCode) == 409 && !upsert {
-				conflicts = append(conflicts, entity)
+				if i.updateExisting(&i.counts.Entities, "entity", id) {
+					conflicts = append(conflicts, entity)
+				}
 			} else {
-				i.mu.Lock()
 				i.errors.Add(fmt.Errorf("%s", bErr.Message), "entity", id)
-				i.mu.Unlock()
 			}
 		} else {
 			created++
+			if result != nil {
+				i.reportResource(ResourceCreated, "entity", id)
+			}
 			if successfulEntities != nil {
 				successMu.Lock()
-				successfulEntities[fmt.Sprintf("%s:%s", blueprintID, id)] = true
+				successfulEntities[entityKey(blueprintID, id)] = true
 				successMu.Unlock()
 			}
 		}
@@ -1304,12 +1697,10 @@ func (i *Importer) processBulkChunk(
 	if len(conflicts) > 0 {
 		retryErrs, retryErr := i.client.BulkUpsertEntities(ctx, blueprintID, conflicts, true)
 		if retryErr != nil {
-			i.mu.Lock()
 			for _, e := range conflicts {
 				id, _ := e["identifier"].(string)
 				i.errors.Add(retryErr, "entity", id)
 			}
-			i.mu.Unlock()
 		} else {
 			retryErrByID := make(map[string]api.BulkEntityError, len(retryErrs))
 			for _, re := range retryErrs {
@@ -1318,14 +1709,15 @@ func (i *Importer) processBulkChunk(
 			for _, entity := range conflicts {
 				id, _ := entity["identifier"].(string)
 				if rErr, failed := retryErrByID[id]; failed {
-					i.mu.Lock()
 					i.errors.Add(fmt.Errorf("%s", rErr.Message), "entity", id)
-					i.mu.Unlock()
 				} else {
 					updated++
+					if result != nil {
+						i.reportResource(ResourceUpdated, "entity", id)
+					}
 					if successfulEntities != nil {
 						successMu.Lock()
-						successfulEntities[fmt.Sprintf("%s:%s", blueprintID, id)] = true
+						successfulEntities[entityKey(blueprintID, id)] = true
 						successMu.Unlock()
 					}
 				}
@@ -1334,10 +1726,8 @@ func (i *Importer) processBulkChunk(
 	}
 
 	if result != nil {
-		i.mu.Lock()
-		result.EntitiesCreated += created
-		result.EntitiesUpdated += updated
-		i.mu.Unlock()
+		i.counts.Entities.Created.Add(int64(created))
+		i.counts.Entities.Updated.Add(int64(updated))
 	}
 
 	progressMu.Lock()
@@ -1357,7 +1747,7 @@ func (i *Importer) bulkUpsertEntities(
 	entities []api.Entity,
 	upsert bool,
 	result *Result,
-	successfulEntities map[string]bool,
+	successfulEntities map[ResourceKey]bool,
 	successMu *sync.Mutex,
 	phaseName string,
 	total int,
@@ -1390,6 +1780,9 @@ func (i *Importer) bulkUpsertEntities(
 	}
 
 	pool.Wait()
+	if result != nil {
+		result.From(&i.counts)
+	}
 }
 
 // importScorecards imports scorecards grouped by blueprint.
@@ -1415,25 +1808,24 @@ func (i *Importer) importScorecards(ctx context.Context, scorecards []api.Scorec
 				scID := sc["identifier"].(string)
 				_, err := i.client.CreateScorecard(ctx, bpID, sc)
 
-				i.mu.Lock()
 				if err == nil {
-					result.ScorecardsCreated++
+					i.counts.Scorecards.Created.Add(1)
+					i.reportResource(ResourceCreated, "scorecard", scID)
 				} else if isConflictError(err) {
-					toMerge = append(toMerge, sc)
+					if i.updateExisting(&i.counts.Scorecards, "scorecard", scID) {
+						toMerge = append(toMerge, sc)
+					}
 				} else {
 					i.errors.Add(err, "scorecard", scID)
 				}
-				i.mu.Unlock()
 			}
 
-			// Port has no PATCH endpoint for individual scorecards, so we
-			// fetch the full set, merge in our updates, and bulk PUT.
+			// Update through the bulk PUT, which replaces the blueprint's
+			// full set: fetch it, merge in our updates, and send it back.
 			if len(toMerge) > 0 {
 				existing, fetchErr := i.client.GetScorecards(ctx, bpID)
 				if fetchErr != nil {
-					i.mu.Lock()
 					i.errors.Add(fetchErr, "scorecard", fmt.Sprintf("fetch:%s", bpID))
-					i.mu.Unlock()
 					return
 				}
 
@@ -1458,18 +1850,36 @@ func (i *Importer) importScorecards(ctx context.Context, scorecards []api.Scorec
 				}
 
 				_, putErr := i.client.UpdateScorecards(ctx, bpID, merged)
-				i.mu.Lock()
 				if putErr != nil {
-					i.errors.Add(putErr, "scorecard", fmt.Sprintf("bulk-put:%s", bpID))
+					// One invalid scorecard fails the whole bulk PUT. Retry
+					// one at a time so the valid ones still apply and only
+					// the bad one is reported.
+					i.updateScorecardsIndividually(ctx, bpID, toMerge)
 				} else {
-					result.ScorecardsUpdated += len(toMerge)
+					i.counts.Scorecards.Updated.Add(int64(len(toMerge)))
+					for _, sc := range toMerge {
+						i.reportResource(ResourceUpdated, "scorecard", sc["identifier"].(string))
+					}
 				}
-				i.mu.Unlock()
 			}
 		})
 	}
 }
 
+// updateScorecardsIndividually updates each scorecard on its own, after the
+// bulk PUT for their blueprint failed.
+func (i *Importer) updateScorecardsIndividually(ctx context.Context, bpID string, scorecards []api.Scorecard) {
+	for _, sc := range scorecards {
+		scID := sc["identifier"].(string)
+		if _, err := i.client.UpdateScorecard(ctx, bpID, scID, sc); err != nil {
+			i.errors.Add(err, "scorecard", scID)
+			continue
+		}
+		i.counts.Scorecards.Updated.Add(1)
+		i.reportResource(ResourceUpdated, "scorecard", scID)
+	}
+}
+
 // importActions imports actions/automations.
 func (i *Importer) importActions(ctx context.Context, actions []api.Action, result *Result, pool *WorkerPool) {
 	for _, action := range actions {
@@ -1485,20 +1895,23 @@ func (i *Importer) importActions(ctx context.Context, actions []api.Action, resu
 
 			_, err := i.client.CreateAutomation(ctx, apiAction)
 
-			i.mu.Lock()
 			if err == nil {
-				result.ActionsCreated++
+				i.counts.Actions.Created.Add(1)
+				i.reportResource(ResourceCreated, "action", actionID)
 			} else if isConflictError(err) {
+				if !i.updateExisting(&i.counts.Actions, "action", actionID) {
+					return
+				}
 				_, updateErr := i.client.UpdateAutomation(ctx, actionID, apiAction)
 				if updateErr != nil {
 					i.errors.Add(updateErr, "action", actionID)
 				} else {
-					result.ActionsUpdated++
+					i.counts.Actions.Updated.Add(1)
+					i.reportResource(ResourceUpdated, "action", actionID)
 				}
 			} else {
 				i.errors.Add(err, "action", actionID)
 			}
-			i.mu.Unlock()
 		})
 	}
 }
@@ -1527,23 +1940,27 @@ func (i *Importer) importTeams(ctx context.Context, teams []api.Team, result *Re
 				return
 			}
 
-			sanitized := sanitizeTeamFields(team)
+			// Members are synced separately by importTeamMemberships.
+			sanitized := WithoutTeamMembers(sanitizeTeamFields(team))
 			_, err := i.client.CreateTeam(ctx, sanitized)
 
-			i.mu.Lock()
 			if err == nil {
-				result.TeamsCreated++
+				i.counts.Teams.Created.Add(1)
+				i.reportResource(ResourceCreated, "team", teamName)
 			} else if isConflictError(err) {
+				if !i.updateExisting(&i.counts.Teams, "team", teamName) {
+					return
+				}
 				_, updateErr := i.client.UpdateTeam(ctx, teamName, sanitized)
 				if updateErr != nil {
 					i.errors.Add(updateErr, "team", teamName)
 				} else {
-					result.TeamsUpdated++
+					i.counts.Teams.Updated.Add(1)
+					i.reportResource(ResourceUpdated, "team", teamName)
 				}
 			} else {
 				i.errors.Add(err, "team", teamName)
 			}
-			i.mu.Unlock()
 		})
 	}
 }
@@ -1624,66 +2041,81 @@ func (i *Importer) importUsers(ctx context.Context, users []api.User, result *Re
 		if len(entities) == 0 {
 			continue
 		}
+		i.importUserBatch(ctx, entities, byEmail)
+	}
+	result.From(&i.counts)
+}
 
-		errs, err := i.client.CreateUserEntitiesBulk(ctx, entities, false)
-		if err != nil {
-			i.mu.Lock()
+// importUserBatch creates one bulk batch of _user entities, updating those
+// that already exist. When the API rejects the batch as a whole, e.g. over
+// one malformed email, each user is retried on its own so the rest still get
+// created and the error names the user at fault.
+func (i *Importer) importUserBatch(ctx context.Context, entities []api.Entity, byEmail map[string]api.User) {
+	errs, err := i.client.CreateUserEntitiesBulk(ctx, entities, false)
+	if err != nil {
+		if len(entities) > 1 && isBatchRejection(err) {
 			for _, e := range entities {
-				if email, ok := e["identifier"].(string); ok {
-					i.errors.Add(err, "user", email)
-				}
+				i.importUserBatch(ctx, []api.Entity{e}, byEmail)
+			}
+			return
+		}
+		for _, e := range entities {
+			if email, ok := e["identifier"].(string); ok {
+				i.errors.Add(err, "user", email)
 			}
-			i.mu.Unlock()
-			continue
 		}
+		return
+	}
 
-		i.mu.Lock()
-		result.UsersCreated += len(entities) - len(errs)
-		i.mu.Unlock()
+	i.counts.Users.Created.Add(int64(len(entities) - len(errs)))
+	i.reportBulkResources(ResourceCreated, "user", entities, errs)
 
-		// Collect conflicting users and re-POST with upsert=true, source data as-is
-		var conflictEntities []api.Entity
-		var nonConflictErrs []api.BulkEntityError
-		for _, be := range errs {
-			if int(be.StatusCode) == 409 {
-				if orig, ok := byEmail[be.Identifier]; ok {
-					conflictEntities = append(conflictEntities, UserToEntity(orig, ""))
-				}
-			} else {
-				nonConflictErrs = append(nonConflictErrs, be)
+	// Collect conflicting users and re-POST with upsert=true, source data as-is
+	var conflictEntities []api.Entity
+	var nonConflictErrs []api.BulkEntityError
+	for _, be := range errs {
+		if int(be.StatusCode) == 409 {
+			if !i.updateExisting(&i.counts.Users, "user", be.Identifier) {
+				continue
+			}
+			if orig, ok := byEmail[be.Identifier]; ok {
+				conflictEntities = append(conflictEntities, UserToEntity(orig, ""))
 			}
+		} else {
+			nonConflictErrs = append(nonConflictErrs, be)
 		}
+	}
 
-		for _, be := range nonConflictErrs {
-			i.mu.Lock()
-			i.errors.Add(fmt.Errorf("%s: %s", be.Error, be.Message), "user", be.Identifier)
-			i.mu.Unlock()
-		}
+	for _, be := range nonConflictErrs {
+		i.errors.Add(fmt.Errorf("%s: %s", be.Error, be.Message), "user", be.Identifier)
+	}
 
-		if len(conflictEntities) > 0 {
-			updateErrs, updateErr := i.client.CreateUserEntitiesBulk(ctx, conflictEntities, true)
-			if updateErr != nil {
-				i.mu.Lock()
-				for _, e := range conflictEntities {
-					if email, ok := e["identifier"].(string); ok {
-						i.errors.Add(updateErr, "user", email)
-					}
-				}
-				i.mu.Unlock()
-			} else {
-				i.mu.Lock()
-				result.UsersUpdated += len(conflictEntities) - len(updateErrs)
-				i.mu.Unlock()
-				for _, be := range updateErrs {
-					i.mu.Lock()
-					i.errors.Add(fmt.Errorf("%s: %s", be.Error, be.Message), "user", be.Identifier)
-					i.mu.Unlock()
+	if len(conflictEntities) > 0 {
+		updateErrs, updateErr := i.client.CreateUserEntitiesBulk(ctx, conflictEntities, true)
+		if updateErr != nil {
+			for _, e := range conflictEntities {
+				if email, ok := e["identifier"].(string); ok {
+					i.errors.Add(updateErr, "user", email)
 				}
 			}
+		} else {
+			i.counts.Users.Updated.Add(int64(len(conflictEntities) - len(updateErrs)))
+			i.reportBulkResources(ResourceUpdated, "user", conflictEntities, updateErrs)
+			for _, be := range updateErrs {
+				i.errors.Add(fmt.Errorf("%s: %s", be.Error, be.Message), "user", be.Identifier)
+			}
 		}
 	}
 }
 
+// isBatchRejection reports whether err is the API refusing a bulk request's
+// content (400 or 422), which retrying its items one by one can narrow down.
+// Rate limits and server errors are already retried by the client, and
+// splitting the batch would only multiply them.
+func isBatchRejection(err error) bool {
+	return api.HasStatus(err, http.StatusBadRequest) || api.HasStatus(err, http.StatusUnprocessableEntity)
+}
+
 // isSidebarParentNotFound returns true when Port rejects a page because its parent
 // sidebar item does not exist in the target organisation.
 func isSidebarParentNotFound(err error) bool {
@@ -2201,6 +2633,7 @@ func (i *Importer) importPages(ctx context.Context, pages []api.Page, result *Re
 		}
 		pool.Wait()
 	}
+	result.From(&i.counts)
 }
 
 // importPage imports a single page.
@@ -2248,17 +2681,16 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 	createdPage, err = i.client.CreatePage(ctx, createPosted)
 
 	needsUpdate := false
-	i.mu.Lock()
 	if err == nil {
-		result.PagesCreated++
-		i.mu.Unlock()
+		i.counts.Pages.Created.Add(1)
+		i.reportResource(ResourceCreated, "page", pageID)
 		i.logPageCreateMismatch(ctx, pageID, pageForCreate, createPosted, createdPage)
 		return
 	} else if IsAfterItemNotInParent(err) || extractAdditionalProperty(err) != "" {
 		createPosted, createdPage, retryErr := i.retryCreatePageWithNarrowFallbacks(ctx, pageForCreate, err)
 		if retryErr == nil {
-			result.PagesCreated++
-			i.mu.Unlock()
+			i.counts.Pages.Created.Add(1)
+			i.reportResource(ResourceCreated, "page", pageID)
 			i.logPageCreateMismatch(ctx, pageID, pageForCreate, createPosted, createdPage)
 			return
 		} else if isConflictError(retryErr) {
@@ -2270,6 +2702,9 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 		// Create failed with agentIdentifier — check if the page already exists.
 		existingPage, fetchErr := i.client.GetPage(ctx, pageID)
 		if fetchErr == nil && existingPage != nil {
+			if !i.updateExisting(&i.counts.Pages, "page", pageID) {
+				return
+			}
 			pageWithoutWidgets := make(api.Page)
 			for k, v := range pageForUpdate {
 				if k != "widgets" {
@@ -2280,7 +2715,8 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 			if updateErr != nil {
 				i.errors.Add(updateErr, "page", pageID)
 			} else {
-				result.PagesUpdated++
+				i.counts.Pages.Updated.Add(1)
+				i.reportResource(ResourceUpdated, "page", pageID)
 			}
 		} else {
 			i.errors.Add(err, "page", pageID)
@@ -2289,6 +2725,9 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 		i.errors.Add(err, "page", pageID)
 	}
 
+	if needsUpdate && !i.updateExisting(&i.counts.Pages, "page", pageID) {
+		return
+	}
 	if needsUpdate {
 		// Fetch existing page to preserve fields like agentIdentifier.
 		existingPage, fetchErr := i.client.GetPage(ctx, pageID)
@@ -2307,7 +2746,8 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 				if retryErr != nil {
 					i.errors.Add(retryErr, "page", pageID)
 				} else {
-					result.PagesUpdated++
+					i.counts.Pages.Updated.Add(1)
+					i.reportResource(ResourceUpdated, "page", pageID)
 				}
 			} else if strings.Contains(updateErr.Error(), "agentIdentifier") {
 				// Fetch existing page to merge agentIdentifiers from its widgets, then retry.
@@ -2331,19 +2771,21 @@ func (i *Importer) importPage(ctx context.Context, page api.Page, result *Result
 					if lastErr != nil {
 						i.errors.Add(lastErr, "page", pageID)
 					} else {
-						result.PagesUpdated++
+						i.counts.Pages.Updated.Add(1)
+						i.reportResource(ResourceUpdated, "page", pageID)
 					}
 				} else {
-					result.PagesUpdated++
+					i.counts.Pages.Updated.Add(1)
+					i.reportResource(ResourceUpdated, "page", pageID)
 				}
 			} else {
 				i.errors.Add(updateErr, "page", pageID)
 			}
 		} else {
-			result.PagesUpdated++
+			i.counts.Pages.Updated.Add(1)
+			i.reportResource(ResourceUpdated, "page", pageID)
 		}
 	}
-	i.mu.Unlock()
 }
 
 func (i *Importer) logPageCreateMismatch(ctx context.Context, pageID string, intended api.Page, posted api.Page, created api.Page) {
@@ -2823,7 +3265,8 @@ func mergeGroupAgentIdentifiers(newGroups, existingGroups []interface{}) []inter
 	return result
 }
 
-// importIntegrations imports integrations (update config only).
+// importIntegrations imports integrations. Integrations marked via
+// SetIntegrationsToCreate are installed; all others get their config updated.
 func (i *Importer) importIntegrations(ctx context.Context, integrations []api.Integration, result *Result, pool *WorkerPool) {
 	for _, integration := range integrations {
 		integration := integration
@@ -2834,6 +3277,21 @@ func (i *Importer) importIntegrations(ctx context.Context, integrations []api.In
 				return
 			}
 
+			if i.integrationsToCreate[integrationID] {
+				err := CreateIntegration(ctx, i.client, integration)
+				if err != nil {
+					i.errors.Add(err, "integration", integrationID)
+				} else {
+					i.counts.Integrations.Created.Add(1)
+					i.reportResource(ResourceCreated, "integration", integrationID)
+				}
+				return
+			}
+
+			if !i.updateExisting(&i.counts.Integrations, "integration", integrationID) {
+				return
+			}
+
 			// The integration config endpoint expects {"config": {...}} wrapper
 			config, ok := integration["config"].(map[string]interface{})
 			if !ok || config == nil {
@@ -2849,17 +3307,50 @@ func (i *Importer) importIntegrations(ctx context.Context, integrations []api.In
 
 			_, err := i.client.UpdateIntegrationConfig(ctx, integrationID, payload)
 
-			i.mu.Lock()
 			if err != nil {
 				i.errors.Add(err, "integration", integrationID)
 			} else {
-				result.IntegrationsUpdated++
+				i.counts.Integrations.Updated.Add(1)
+				i.reportResource(ResourceUpdated, "integration", integrationID)
 			}
-			i.mu.Unlock()
 		})
 	}
 }
 
+// integrationSystemFields are read-only integration fields that must not be
+// sent when installing an integration.
+var integrationSystemFields = []string{"id", "createdBy", "updatedBy", "createdAt", "updatedAt", "resyncState", "statusInfo"}
+
+// CreateIntegration installs an integration that does not exist in the target
+// organization. Exports never contain integration credentials, so when the API
+// rejects the installation for missing credentials the returned error says so
+// explicitly.
+func CreateIntegration(ctx context.Context, client *api.Client, integration api.Integration) error {
+	payload := api.Integration(cleanSystemFields(integration, integrationSystemFields))
+	if _, ok := payload["installationId"]; !ok {
+		if id, ok := integration["identifier"].(string); ok {
+			payload["installationId"] = id
+		}
+	}
+
+	_, err := client.CreateIntegration(ctx, payload)
+	if err != nil && isIntegrationCredentialsError(err) {
+		return fmt.Errorf("integration could not be installed, it likely requires credentials that are not included in the export; install it manually and re-run to sync its config: %w", err)
+	}
+	return err
+}
+
+// isIntegrationCredentialsError checks if an integration install was rejected
+// because of missing or invalid credentials: a 400 or 422 whose Port error
+// code or message names them. Other validation errors are returned as is.
+func isIntegrationCredentialsError(err error) bool {
+	if !api.HasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity) {
+		return false
+	}
+	apiErr, _ := api.AsAPIError(err)
+	return containsAny(apiErrorDetail(apiErr), integrationCredentialsErrorPatterns)
+}
+
 // importPermissions applies blueprint and action permission changes from a DiffResult.
 // Permissions are applied after all other resources have been imported so that the
 // underlying blueprints, actions, and pages are guaranteed to exist.
@@ -2930,6 +3421,21 @@ func (i *Importer) importPermissions(ctx context.Context, diff *DiffResult) (bpU
 	return
 }
 
+// withoutSkippedEntities drops the entities of the blueprints matched by
+// skipEntitiesFor.
+func withoutSkippedEntities(entities []api.Entity, skipEntitiesFor []string) []api.Entity {
+	if len(skipEntitiesFor) == 0 {
+		return entities
+	}
+	kept := entities[:0:0]
+	for _, e := range entities {
+		if bpID, _ := e["blueprint"].(string); !export.SkipsEntitiesOf(bpID, skipEntitiesFor) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
 // applyDataExclusion filters data in-place before diffing/importing.
 // excludeDeep removes the blueprint schema AND all its entities/scorecards/actions.
 // excludeSchema removes only the blueprint schema; resources for that blueprint are kept.
@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -229,6 +231,41 @@ func TestModuleExecute_DryRunKeepsCustomSystemBlueprintPatch(t *testing.T) {
 	}
 }
 
+func TestExecute_DryRunRecordsStreamedEntityChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.json": `{"blueprints": [{"identifier": "service", "title": "Service"}], "entities": [{"identifier": "svc-1", "blueprint": "service"}]}`,
+	})
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	module := NewModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: srv.URL})
+	for _, record := range []bool{false, true} {
+		result, err := module.Execute(context.Background(), Options{
+			InputPath:           filepath.Join(dir, "export.json"),
+			DryRun:              true,
+			RecordEntityChanges: record,
+		})
+		if err != nil {
+			t.Fatalf("Execute returned error: %v", err)
+		}
+		if result.EntitiesCreated != 1 {
+			t.Fatalf("expected 1 entity to create, got %d", result.EntitiesCreated)
+		}
+		want := 0
+		if record {
+			want = 1
+		}
+		if got := len(result.DiffResult.EntitiesToCreate); got != want {
+			t.Errorf("RecordEntityChanges=%v: expected %d recorded entity creation(s), got %d", record, want, got)
+		}
+	}
+}
+
 func TestApplyDataExclusion_SchemaOnly(t *testing.T) {
 	data := &export.Data{
 		Blueprints: []api.Blueprint{
@@ -1126,6 +1163,85 @@ func TestSanitizeTeamFields_NoNulls(t *testing.T) {
 	}
 }
 
+func TestImportTeamMemberships_UsesMembershipEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string][]interface{}{}
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/teams/") && strings.HasSuffix(r.URL.Path, "/users") {
+			var body map[string][]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			calls[r.Method+" "+r.URL.Path] = body["users"]
+			mu.Unlock()
+			if strings.HasPrefix(r.URL.Path, "/teams/broken") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	importer := NewImporter(client)
+	importer.errors.Add(errors.New("team create failed"), "team", "failed")
+	added, removed := importer.importTeamMemberships(context.Background(), &DiffResult{
+		TeamMemberships: []TeamMembershipChange{
+			{Team: "platform", Add: []string{"carol@example.com"}, Remove: []string{"bob@example.com"}},
+			{Team: "broken", Add: []string{"dave@example.com"}},
+			{Team: "failed", Add: []string{"erin@example.com"}},
+		},
+	})
+
+	if added != 1 || removed != 1 {
+		t.Errorf("expected 1 added and 1 removed, got %d and %d", added, removed)
+	}
+	if got := calls["POST /teams/platform/users"]; !reflect.DeepEqual(got, []interface{}{"carol@example.com"}) {
+		t.Errorf("unexpected add payload: %v", got)
+	}
+	if got := calls["DELETE /teams/platform/users"]; !reflect.DeepEqual(got, []interface{}{"bob@example.com"}) {
+		t.Errorf("unexpected remove payload: %v", got)
+	}
+	if errs := importer.errors.GetByResource("team_members"); len(errs) != 1 || errs[0].ResourceID != "broken" {
+		t.Errorf("expected one error for team broken, got %v", errs)
+	}
+	if _, ok := calls["POST /teams/failed/users"]; ok {
+		t.Error("expected members of a team that failed to import to be left alone")
+	}
+}
+
+func TestImportTeams_OmitsMembersFromTeamPayload(t *testing.T) {
+	var received map[string]interface{}
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/teams" {
+			json.NewDecoder(r.Body).Decode(&received)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "team": received})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	importer := NewImporter(client)
+	pool := NewWorkerPool(1)
+	importer.importTeams(context.Background(), []api.Team{
+		{"name": "platform", "users": []interface{}{"alice@example.com"}},
+	}, &Result{}, pool)
+	pool.Wait()
+
+	if _, ok := received["users"]; ok {
+		t.Errorf("expected users to be omitted from the team payload, got %v", received)
+	}
+	if received["name"] != "platform" {
+		t.Errorf("expected team name to be sent, got %v", received)
+	}
+}
+
 func TestImportPermissions_CountsOnlySuccesses(t *testing.T) {
 	// bp1/action1 succeed; bp2/action2 fail — only successes should be counted.
 	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -1781,6 +1897,7 @@ func TestImportScorecards_CreateSuccess(t *testing.T) {
 
 	importer.importScorecards(context.Background(), scorecards, result, pool)
 	pool.Wait()
+	result.From(&importer.counts)
 
 	mu.Lock()
 	defer mu.Unlock()
@@ -1796,6 +1913,49 @@ func TestImportScorecards_CreateSuccess(t *testing.T) {
 	}
 }
 
+func TestImporter_ResourceCallbackReportsEachResource(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/blueprints/service/scorecards" {
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	var mu sync.Mutex
+	var lines []string
+	importer := NewImporter(client)
+	importer.SetResourceCallback(func(action, resourceType, identifier string) {
+		mu.Lock()
+		lines = append(lines, action+" "+resourceType+" "+identifier)
+		mu.Unlock()
+	})
+	pool := NewWorkerPool(1)
+	importer.importScorecards(context.Background(), []api.Scorecard{
+		{"identifier": "readiness", "blueprintIdentifier": "service"},
+		{"identifier": "quality", "blueprintIdentifier": "service"},
+	}, &Result{}, pool)
+	pool.Wait()
+
+	sort.Strings(lines)
+	want := []string{"created scorecard quality", "created scorecard readiness"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("resource lines = %v, want %v", lines, want)
+	}
+}
+
+func TestBulkSucceeded_ExcludesFailedIdentifiers(t *testing.T) {
+	entities := []api.Entity{{"identifier": "a"}, {"identifier": "b"}, {"identifier": "c"}}
+	got := BulkSucceeded(entities, []api.BulkEntityError{{Identifier: "b"}})
+	if !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Fatalf("BulkSucceeded = %v, want [a c]", got)
+	}
+}
+
 // TestImportScorecards_ConflictUsesFetchMergePUT verifies that when a
 // scorecard already exists (409 on create), the importer fetches the full
 // set, merges the updates, and performs a single bulk PUT instead of using
@@ -1850,6 +2010,7 @@ func TestImportScorecards_ConflictUsesFetchMergePUT(t *testing.T) {
 
 	importer.importScorecards(context.Background(), scorecards, result, pool)
 	pool.Wait()
+	result.From(&importer.counts)
 
 	mu.Lock()
 	defer mu.Unlock()
@@ -1904,6 +2065,7 @@ func TestImportScorecards_BulkPutFailureRecordsError(t *testing.T) {
 
 	importer.importScorecards(context.Background(), scorecards, result, pool)
 	pool.Wait()
+	result.From(&importer.counts)
 
 	if result.ScorecardsUpdated != 0 {
 		t.Fatalf("expected ScorecardsUpdated=0 on failure, got %d", result.ScorecardsUpdated)
@@ -1917,6 +2079,69 @@ func TestImportScorecards_BulkPutFailureRecordsError(t *testing.T) {
 	}
 }
 
+// TestImportScorecards_BulkPutFailureFallsBackToIndividualUpdates verifies
+// that one invalid scorecard does not fail the rest of its blueprint: after
+// the bulk PUT fails, each scorecard is updated on its own and only the
+// invalid one is recorded as an error.
+func TestImportScorecards_BulkPutFailureFallsBackToIndividualUpdates(t *testing.T) {
+	var mu sync.Mutex
+	var patched []string
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/blueprints/service/scorecards":
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Conflict"})
+		case r.Method == http.MethodGet && r.URL.Path == "/blueprints/service/scorecards":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecards": []interface{}{}})
+		case r.Method == http.MethodPut && r.URL.Path == "/blueprints/service/scorecards":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "validation failed"})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/blueprints/service/scorecards/"):
+			id := strings.TrimPrefix(r.URL.Path, "/blueprints/service/scorecards/")
+			mu.Lock()
+			patched = append(patched, id)
+			mu.Unlock()
+			if id == "broken" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid rule"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "scorecard": map[string]interface{}{"identifier": id}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	importer := NewImporter(client)
+	result := &Result{}
+	pool := NewWorkerPool(1)
+
+	scorecards := []api.Scorecard{
+		{"identifier": "readiness", "blueprintIdentifier": "service", "title": "Readiness"},
+		{"identifier": "broken", "blueprintIdentifier": "service", "title": "Broken"},
+	}
+
+	importer.importScorecards(context.Background(), scorecards, result, pool)
+	pool.Wait()
+	result.From(&importer.counts)
+
+	if len(patched) != 2 {
+		t.Fatalf("expected both scorecards to be updated individually, got %v", patched)
+	}
+	if result.ScorecardsUpdated != 1 {
+		t.Fatalf("expected ScorecardsUpdated=1, got %d", result.ScorecardsUpdated)
+	}
+	errs := importer.errors.GetByResource("scorecard")
+	if len(errs) != 1 || errs[0].ResourceID != "broken" {
+		t.Fatalf("expected a single error for the broken scorecard, got %v", errs)
+	}
+}
+
 func TestBulkUpsertEntities_AllSucceed(t *testing.T) {
 	var bulkPaths []string
 	var mu sync.Mutex
@@ -1949,7 +2174,7 @@ func TestBulkUpsertEntities_AllSucceed(t *testing.T) {
 	}
 
 	result := &Result{}
-	successful := make(map[string]bool)
+	successful := make(map[ResourceKey]bool)
 	var successMu sync.Mutex
 	count := 0
 	var progressMu sync.Mutex
@@ -2010,7 +2235,7 @@ func TestBulkUpsertEntities_ConflictsRetried(t *testing.T) {
 	}
 
 	result := &Result{}
-	successful := make(map[string]bool)
+	successful := make(map[ResourceKey]bool)
 	var successMu sync.Mutex
 	count := 0
 	var progressMu sync.Mutex
@@ -2142,3 +2367,159 @@ func TestImportEntities_UsesBulkForBothPhases(t *testing.T) {
 		t.Error("progress must report 'Entities Phase 2 (relations)'")
 	}
 }
+
+func TestCreateIntegration_SurfacesMissingCredentials(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/integration":
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_request", "message": "Missing installation parameter clientSecret"})
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	err := CreateIntegration(context.Background(), client, api.Integration{
+		"identifier": "gh",
+		"createdAt":  "2024-01-01",
+		"config":     map[string]interface{}{},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "requires credentials") {
+		t.Errorf("expected credentials hint in error, got %v", err)
+	}
+	if body["installationId"] != "gh" {
+		t.Errorf("expected installationId to default to identifier, got %v", body["installationId"])
+	}
+	if _, ok := body["createdAt"]; ok {
+		t.Errorf("expected system fields to be stripped, got %v", body)
+	}
+}
+
+func TestIsIntegrationCredentialsError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"422 secret required", &api.APIError{Status: http.StatusUnprocessableEntity, Code: "invalid_request", Message: "secret 'githubToken' is required"}, true},
+		{"400 missing parameter", &api.APIError{Status: http.StatusBadRequest, Message: "Missing installation parameter appId"}, true},
+		{"wrapped", fmt.Errorf("create: %w", &api.APIError{Status: http.StatusBadRequest, Code: "credentials_invalid"}), true},
+		{"400 unrelated", &api.APIError{Status: http.StatusBadRequest, Code: "identifier_taken", Message: "Integration gh already exists"}, false},
+		{"422 generic validation", &api.APIError{Status: http.StatusUnprocessableEntity, Code: "invalid_request"}, false},
+		{"400 unrelated missing parameter", &api.APIError{Status: http.StatusBadRequest, Code: "invalid_request", Message: "Missing required parameter 'config.resources' is invalid"}, false},
+		{"500 mentioning credentials", &api.APIError{Status: http.StatusInternalServerError, Message: "credential store unavailable"}, false},
+		{"plain error text", errors.New("request failed: 400 Bad Request"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIntegrationCredentialsError(tt.err); got != tt.want {
+				t.Errorf("isIntegrationCredentialsError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// blueprintStore serves /blueprints from memory, applying creates and
+// full-replace updates like Port does.
+func blueprintStore(t *testing.T) (map[string]map[string]interface{}, *api.Client) {
+	t.Helper()
+	var mu sync.Mutex
+	store := make(map[string]map[string]interface{})
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		id := strings.TrimPrefix(r.URL.Path, "/blueprints/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/blueprints":
+			list := make([]map[string]interface{}, 0, len(store))
+			for _, bp := range store {
+				list = append(list, bp)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": list})
+		case r.Method == http.MethodPost && r.URL.Path == "/blueprints":
+			var bp map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&bp)
+			store[bp["identifier"].(string)] = bp
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": bp})
+		case r.Method == http.MethodGet && store[id] != nil:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": store[id]})
+		case r.Method == http.MethodPut && store[id] != nil:
+			var bp map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&bp)
+			store[id] = bp
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": bp})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return store, client
+}
+
+func TestImportBlueprints_ReappliesDependentProperties(t *testing.T) {
+	store, client := blueprintStore(t)
+	importer := NewImporter(client)
+	blueprints := []api.Blueprint{
+		{"identifier": "cluster", "title": "Cluster", "schema": map[string]interface{}{"properties": map[string]interface{}{"region": map[string]interface{}{"type": "string"}}}},
+		{
+			"identifier": "service",
+			"title":      "Service",
+			"relations":  map[string]interface{}{"cluster": map[string]interface{}{"target": "cluster", "required": false, "many": false}},
+			"mirrorProperties": map[string]interface{}{
+				"region": map[string]interface{}{"path": "cluster.region"},
+				"broken": map[string]interface{}{"path": "owner.name"},
+			},
+			"calculationProperties": map[string]interface{}{"label": map[string]interface{}{"calculation": ".title", "type": "string"}},
+			"aggregationProperties": map[string]interface{}{"deployments": map[string]interface{}{"target": "deployment"}},
+		},
+	}
+
+	result := &Result{}
+	if err := importer.importBlueprints(context.Background(), blueprints, result); err != nil {
+		t.Fatalf("importBlueprints: %v", err)
+	}
+
+	service := store["service"]
+	mirrors, _ := service["mirrorProperties"].(map[string]interface{})
+	if len(mirrors) != 1 || mirrors["region"] == nil {
+		t.Errorf("expected the region mirror property to survive the import, got %v", service["mirrorProperties"])
+	}
+	if calcs, _ := service["calculationProperties"].(map[string]interface{}); calcs["label"] == nil {
+		t.Errorf("expected the calculation property to survive the import, got %v", service["calculationProperties"])
+	}
+	if rels, _ := service["relations"].(map[string]interface{}); rels["cluster"] == nil {
+		t.Errorf("expected the relation to be applied, got %v", service["relations"])
+	}
+	if _, ok := service["aggregationProperties"]; ok {
+		t.Errorf("expected the aggregation on a missing blueprint to be left out, got %v", service["aggregationProperties"])
+	}
+	errs := strings.Join(importer.errors.ToStringSlice(), "\n")
+	if !strings.Contains(errs, `mirror property "broken"`) || !strings.Contains(errs, `aggregation property "deployments"`) {
+		t.Errorf("expected the unresolvable properties to be reported, got:\n%s", errs)
+	}
+}
+
+func TestWithoutSkippedEntities(t *testing.T) {
+	entities := []api.Entity{
+		{"identifier": "a", "blueprint": "service"},
+		{"identifier": "b", "blueprint": "k8s-pod"},
+		{"identifier": "c", "blueprint": "k8s-node"},
+	}
+	got := withoutSkippedEntities(entities, []string{"k8s-*"})
+	if len(got) != 1 || got[0]["identifier"] != "a" {
+		t.Errorf("expected only the service entity, got %v", got)
+	}
+	if got := withoutSkippedEntities(entities, nil); len(got) != 3 {
+		t.Errorf("expected every entity without patterns, got %v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -257,3 +258,60 @@ func TestImportUsers_BatchBoundary(t *testing.T) {
 		t.Errorf("expected 2 API calls for 21 users, got %d", callCount)
 	}
 }
+
+func TestImportUsers_RejectedBatchFallsBackToIndividualUsers(t *testing.T) {
+	var callCount int32
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/blueprints/_user/entities/bulk" {
+			atomic.AddInt32(&callCount, 1)
+			var payload struct {
+				Entities []map[string]interface{} `json:"entities"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			for _, e := range payload.Entities {
+				if e["identifier"] == "not-an-email" {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					w.Write([]byte(`{"ok":false,"error":"invalid_request","message":"identifier must be an email"}`))
+					return
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{}})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	users := []api.User{
+		{"email": "alice@example.com"},
+		{"email": "not-an-email"},
+		{"email": "bob@example.com"},
+	}
+
+	importer := NewImporter(client)
+	var created []string
+	importer.SetResourceCallback(func(action, resourceType, identifier string) {
+		if action == ResourceCreated {
+			created = append(created, identifier)
+		}
+	})
+	result := &Result{}
+	importer.importUsers(context.Background(), users, result, false)
+
+	if result.UsersCreated != 2 {
+		t.Errorf("UsersCreated = %d; want 2", result.UsersCreated)
+	}
+	if len(created) != 2 || created[0] != "alice@example.com" || created[1] != "bob@example.com" {
+		t.Errorf("created users = %v; want alice and bob", created)
+	}
+	errs := importer.errors.ToStringSlice()
+	if len(errs) != 1 || !strings.Contains(errs[0], "not-an-email") {
+		t.Errorf("errors = %v; want one error naming not-an-email", errs)
+	}
+	if got := atomic.LoadInt32(&callCount); got != 4 {
+		t.Errorf("API calls = %d; want the batch and 3 individual retries", got)
+	}
+}
@@ -0,0 +1,110 @@
+package import_module
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// jsonFileError locates a JSON decoding error in the file at path, so a typo
+// in a hand-edited bundle is reported as "path:line:column: message" rather
+// than as a bare byte offset. content is the decoded input, or nil to read it
+// back from path. Errors that did not come from decoding JSON are returned
+// unchanged.
+func jsonFileError(path string, content []byte, err error) error {
+	offset, ok := jsonErrorOffset(err)
+	if !ok {
+		return err
+	}
+	if content == nil {
+		var readErr error
+		if content, readErr = os.ReadFile(path); readErr != nil {
+			return fmt.Errorf("%s: byte %d: %w", path, offset, err)
+		}
+	}
+	var typeErr *json.UnmarshalTypeError
+	var valueErr *valueError
+	if errors.As(err, &valueErr) && errors.As(err, &typeErr) {
+		// The decoded sections and entities are maps, so a type error
+		// means the whole value has the wrong type: point at its start.
+		offset = valueStart(content, valueErr.start) + 1
+	}
+	if offset < 0 || offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	line, column := lineColumn(content, offset)
+	return fmt.Errorf("%s:%d:%d: %w", path, line, column, err)
+}
+
+// jsonErrorOffset returns the input offset at which decoding failed. A
+// truncated document fails at its end, reported as offset -1.
+func jsonErrorOffset(err error) (int64, bool) {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset, true
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return typeErr.Offset, true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return -1, true
+	}
+	return 0, false
+}
+
+// lineColumn converts a byte offset into a 1-based line and column. The
+// decoder reports the offset just past the offending byte, so that byte is
+// the one located.
+func lineColumn(content []byte, offset int64) (int, int) {
+	if offset > 0 {
+		offset--
+	}
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - (bytes.LastIndexByte(before, '\n') + 1) + 1
+	return line, column
+}
+
+// valueError marks an error from a Decode call that started at offset start
+// of the input. The decoder locates type errors relative to the value being
+// decoded, so jsonFileError locates them by start instead.
+type valueError struct {
+	start int64
+	err   error
+}
+
+func (e *valueError) Error() string { return e.err.Error() }
+func (e *valueError) Unwrap() error { return e.err }
+
+// decodeLocated runs decode, a single Decode call on dec, recording where in
+// the input the value starts so a type error can be located in the file.
+func decodeLocated(dec *json.Decoder, decode func() error) error {
+	start := dec.InputOffset()
+	if err := decode(); err != nil {
+		return &valueError{start: start, err: err}
+	}
+	return nil
+}
+
+// valueStart returns the offset of the first byte of the value following
+// start, skipping whitespace and the ':' or ',' separating it from the
+// previous token.
+func valueStart(content []byte, start int64) int64 {
+	offset := start
+	skip := func() {
+		for offset < int64(len(content)) && strings.ContainsRune(" \t\r\n", rune(content[offset])) {
+			offset++
+		}
+	}
+	skip()
+	if offset < int64(len(content)) && (content[offset] == ':' || content[offset] == ',') {
+		offset++
+		skip()
+	}
+	return offset
+}
@@ -0,0 +1,86 @@
+package import_module
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestJSONFileError_ReportsLineAndColumn(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"syntax.json":    "{\n  \"blueprints\": [\n    {\"identifier\": \"svc\",}\n  ]\n}\n",
+		"type.json":      "{\n  \"blueprints\": {\"identifier\": \"svc\"}\n}\n",
+		"truncated.json": "{\n  \"blueprints\": [\n",
+	})
+	cases := []struct {
+		file, want string
+		streamOnly bool
+	}{
+		{file: "syntax.json", want: "syntax.json:3:26:"},
+		// The full loader validates the format before decoding sections.
+		{file: "type.json", want: "type.json:2:17:", streamOnly: true},
+		{file: "truncated.json", want: "truncated.json:"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			path := filepath.Join(dir, tc.file)
+			if !tc.streamOnly {
+				_, err := NewLoader().LoadData(path)
+				if err == nil || !strings.Contains(err.Error(), tc.want) {
+					t.Errorf("Loader: expected an error naming %s, got %v", tc.want, err)
+				}
+			}
+			_, err := NewStreamLoader().LoadDataWithoutEntities(path)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("StreamLoader: expected an error naming %s, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestJSONFileError_LocatesBadEntity(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.json": "{\"entities\": [\n  {\"identifier\": \"svc\"},\n  42\n]}\n",
+	})
+	path := filepath.Join(dir, "export.json")
+	err := NewStreamLoader().ForEachEntity(path, func(api.Entity) error { return nil })
+	if want := path + ":3:3:"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected an error naming %s, got %v", want, err)
+	}
+
+	yieldErr := errors.New("stop")
+	if err := NewStreamLoader().ForEachEntity(path, func(api.Entity) error { return yieldErr }); err != yieldErr {
+		t.Fatalf("expected the yield error unchanged, got %v", err)
+	}
+}
+
+func TestJSONFileError_PassesOtherErrorsThrough(t *testing.T) {
+	err := errors.New("yield failed")
+	if got := jsonFileError("export.json", nil, err); got != err {
+		t.Errorf("expected the error unchanged, got %v", got)
+	}
+}
+
+func TestLineColumn(t *testing.T) {
+	content := []byte("ab\ncde\nf")
+	for _, tc := range []struct {
+		offset       int64
+		line, column int
+	}{
+		{1, 1, 1},
+		{2, 1, 2},
+		{4, 2, 1},
+		{6, 2, 3},
+		{8, 3, 1},
+	} {
+		line, column := lineColumn(content, tc.offset)
+		if line != tc.line || column != tc.column {
+			t.Errorf("lineColumn(%d) = %d:%d, want %d:%d", tc.offset, line, column, tc.line, tc.column)
+		}
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/modules/export"
+	"github.com/port-experimental/port-cli/internal/useragent"
 )
 
 // Loader loads data from tar.gz or JSON files.
@@ -39,9 +41,11 @@ func (l *Loader) LoadData(inputPath string) (*export.Data, error) {
 		return l.loadTar(inputPath)
 	} else if isJSON {
 		return l.loadJSON(inputPath)
+	} else if isNDJSONPath(inputPath) {
+		return l.loadNDJSON(inputPath)
 	}
 
-	return nil, fmt.Errorf("unsupported file format: %s (expected .json or .tar.gz)", ext)
+	return nil, fmt.Errorf("unsupported file format: %s (expected .json, .ndjson, or .tar.gz)", ext)
 }
 
 // loadTar loads data from a tar.gz file.
@@ -166,6 +170,13 @@ func (l *Loader) loadTar(tarPath string) (*export.Data, error) {
 			}
 			data.Integrations = items
 
+		case "datasources":
+			var items []api.Webhook
+			if err := dec.Decode(&items); err != nil {
+				return nil, fmt.Errorf("failed to parse data sources: %w", err)
+			}
+			data.DataSources = items
+
 		case "blueprint_permissions":
 			var items map[string]api.Permissions
 			if err := dec.Decode(&items); err != nil {
@@ -186,6 +197,16 @@ func (l *Loader) loadTar(tarPath string) (*export.Data, error) {
 				return nil, fmt.Errorf("failed to parse page permissions: %w", err)
 			}
 			data.PagePermissions = items
+
+		case export.ManifestResource:
+			if err := dec.Decode(&data.Manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest: %w", err)
+			}
+
+		case export.DeletionsResource:
+			if err := dec.Decode(&data.Deletions); err != nil {
+				return nil, fmt.Errorf("failed to parse deletions: %w", err)
+			}
 		}
 	}
 
@@ -202,7 +223,13 @@ func (l *Loader) loadJSON(jsonPath string) (*export.Data, error) {
 
 	var rawData map[string]interface{}
 	if err := json.NewDecoder(file).Decode(&rawData); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		return nil, fmt.Errorf("failed to decode JSON: %w (the file is malformed; expected a file written by \"port export -o <file>.json\")", jsonFileError(jsonPath, nil, err))
+	}
+	if err := resolveSectionRefs(rawData, filepath.Dir(jsonPath)); err != nil {
+		return nil, err
+	}
+	if err := export.ValidateFormat(rawData); err != nil {
+		return nil, err
 	}
 
 	data := &export.Data{
@@ -299,6 +326,14 @@ func (l *Loader) loadJSON(jsonPath string) (*export.Data, error) {
 		}
 	}
 
+	if sources, ok := rawData["datasources"].([]interface{}); ok {
+		for _, s := range sources {
+			if sMap, ok := s.(map[string]interface{}); ok {
+				data.DataSources = append(data.DataSources, api.Webhook(sMap))
+			}
+		}
+	}
+
 	for _, key := range []string{"BlueprintPermissions", "blueprint_permissions"} {
 		if perms, ok := rawData[key].(map[string]interface{}); ok {
 			data.BlueprintPermissions = make(map[string]api.Permissions)
@@ -335,15 +370,107 @@ func (l *Loader) loadJSON(jsonPath string) (*export.Data, error) {
 		}
 	}
 
+	if manifest, ok := rawData[export.ManifestResource].(map[string]interface{}); ok {
+		data.Manifest = decodeManifest(manifest)
+	}
+
+	if deletions, ok := rawData[export.DeletionsResource].([]interface{}); ok {
+		for _, d := range deletions {
+			if dMap, ok := d.(map[string]interface{}); ok {
+				data.Deletions = append(data.Deletions, decodeDeletion(dMap))
+			}
+		}
+	}
+
 	return data, nil
 }
 
+// decodeManifest converts a manifest read as a generic JSON object. Fields it
+// does not recognize are ignored.
+func decodeManifest(raw map[string]interface{}) export.Manifest {
+	var manifest export.Manifest
+	manifest.Anonymized, _ = raw["anonymized"].(bool)
+	manifest.Delta, _ = raw["delta"].(bool)
+	manifest.CLIVersion, _ = raw["cliVersion"].(string)
+	if sample, ok := raw["sample"].(float64); ok {
+		manifest.Sample = int(sample)
+	}
+	selection, _ := raw["selection"].([]interface{})
+	for _, flag := range selection {
+		if flag, ok := flag.(string); ok {
+			manifest.Selection = append(manifest.Selection, flag)
+		}
+	}
+	return manifest
+}
+
+// decodeDeletion converts a deletion read as a generic JSON object.
+func decodeDeletion(raw map[string]interface{}) export.Deletion {
+	var deletion export.Deletion
+	deletion.Type, _ = raw["type"].(string)
+	deletion.Identifier, _ = raw["identifier"].(string)
+	deletion.Blueprint, _ = raw["blueprint"].(string)
+	return deletion
+}
+
+// appendManifestWarning warns when the loaded archive is not a faithful copy of
+// its source organization, or was written by a CLI of another major version.
+func appendManifestWarning(warnings []ValidationWarning, manifest export.Manifest) []ValidationWarning {
+	if manifest.Anonymized {
+		warnings = append(warnings, ValidationWarning{
+			Type:    "anonymized_bundle",
+			Message: "Input was exported with --anonymize: entity identifiers and values are placeholders, and teams and users were removed",
+		})
+	}
+	if manifest.Sample > 0 {
+		warnings = append(warnings, ValidationWarning{
+			Type:    "sampled_bundle",
+			Message: fmt.Sprintf("Input was exported with --sample %d: it holds at most %d entities per blueprint and is not a complete backup", manifest.Sample, manifest.Sample),
+		})
+	}
+	return appendVersionWarning(warnings, manifest.CLIVersion, useragent.Version())
+}
+
+// appendVersionWarning warns when the archive was written by a CLI whose
+// major version differs from the running one, as the export format may have
+// changed between them. Unknown and development versions are not compared.
+func appendVersionWarning(warnings []ValidationWarning, archiveVersion, cliVersion string) []ValidationWarning {
+	archiveMajor, ok := majorVersion(archiveVersion)
+	if !ok {
+		return warnings
+	}
+	cliMajor, ok := majorVersion(cliVersion)
+	if !ok || archiveMajor == cliMajor {
+		return warnings
+	}
+	return append(warnings, ValidationWarning{
+		Type: "version_mismatch",
+		Message: fmt.Sprintf("Input was exported by port CLI %s, but this is %s: fields may have been renamed between major versions. "+
+			"Re-export it with this version (port export -o <file>) if the import reports missing or unexpected data", archiveVersion, cliVersion),
+	})
+}
+
+// majorVersion returns the major component of a version such as "v1.4.2".
+func majorVersion(version string) (int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	major, _, _ := strings.Cut(version, ".")
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
 // ValidateData validates the loaded data structure.
 // When includeResources is non-empty, blueprints are only required if
 // blueprints (or blueprint-dependent types like entities/scorecards) are
 // being imported. Org-level resources (pages, integrations, teams, users)
 // can be imported without blueprints in the file.
 func (l *Loader) ValidateData(data *export.Data, includeResources []string) error {
+	// A delta bundle only holds what changed, so it may have no blueprints.
+	if data.Manifest.Delta {
+		return nil
+	}
 	if len(includeResources) > 0 {
 		blueprintsNeeded := false
 		for _, r := range includeResources {
@@ -353,12 +480,37 @@ func (l *Loader) ValidateData(data *export.Data, includeResources []string) erro
 			}
 		}
 		if blueprintsNeeded && len(data.Blueprints) == 0 {
-			return fmt.Errorf("missing required data: blueprints")
+			return missingBlueprintsError(data)
 		}
 		return nil
 	}
 	if len(data.Blueprints) == 0 {
-		return fmt.Errorf("missing required data: blueprints")
+		return missingBlueprintsError(data)
 	}
 	return nil
 }
+
+// missingBlueprintsError explains an input without blueprints: either the
+// file parsed but holds no resources at all, or it holds only resources that
+// can be imported without blueprints.
+func missingBlueprintsError(data *export.Data) error {
+	if isEmptyData(data) {
+		msg := "input contains no resources: the file parsed, but every section is empty or missing"
+		if data.Manifest.CLIVersion == "" {
+			msg += ". If it was written by an older port CLI, its sections may use different names; re-export it with \"port export -o <file>\""
+		} else {
+			msg += ". Check that the export selected any resources, then re-run \"port export -o <file>\""
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	return fmt.Errorf("missing required data: blueprints. The input has other resources, which can be imported without blueprints using --include (for example \"port import -i <file> --include pages,teams\")")
+}
+
+// isEmptyData reports whether data holds no resources.
+func isEmptyData(data *export.Data) bool {
+	return len(data.Blueprints) == 0 && len(data.Entities) == 0 && len(data.Scorecards) == 0 &&
+		len(data.Actions) == 0 && len(data.Teams) == 0 && len(data.Users) == 0 &&
+		len(data.Folders) == 0 && len(data.Pages) == 0 && len(data.Integrations) == 0 &&
+		len(data.DataSources) == 0 &&
+		len(data.BlueprintPermissions) == 0 && len(data.ActionPermissions) == 0 && len(data.PagePermissions) == 0
+}
@@ -45,6 +45,24 @@ func TestLoader_LoadJSON_IncludesFolders(t *testing.T) {
 	}
 }
 
+func TestLoader_LoadJSON_RejectsMalformedStructure(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	content := `{"blueprints": {"identifier": "service"}, "entities": [{"identifier": "api"}]}`
+	if err := os.WriteFile(inputPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	_, err := NewLoader().LoadData(inputPath)
+	if err == nil {
+		t.Fatal("expected an error for a malformed export")
+	}
+	for _, want := range []string{"blueprints must be an array", "entities[0] is missing blueprint"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got %v", want, err)
+		}
+	}
+}
+
 func TestCleanFolderForCreate(t *testing.T) {
 	folder := map[string]interface{}{
 		"identifier":  "quorum",
@@ -183,6 +201,97 @@ func TestStreamLoader_LoadMetadataAndIteratesEntities(t *testing.T) {
 	}
 }
 
+func TestLoader_LoadNDJSON_BucketsRecordsByType(t *testing.T) {
+	tempDir := t.TempDir()
+	inputPath := filepath.Join(tempDir, "export.ndjson")
+
+	content := `{"_type":"blueprints","identifier":"service"}
+{"_type":"entities","identifier":"svc-1","blueprint":"service"}
+{"_type":"automations","identifier":"on_create"}
+{"_type":"blueprint_permissions","_key":"service","entities":{"register":{"roles":["Admin"]}}}
+{"_type":"entities","identifier":"svc-2","blueprint":"service"}
+`
+	if err := os.WriteFile(inputPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	data, err := NewLoader().LoadData(inputPath)
+	if err != nil {
+		t.Fatalf("LoadData error: %v", err)
+	}
+	if len(data.Blueprints) != 1 || len(data.Entities) != 2 || len(data.Actions) != 1 {
+		t.Fatalf("unexpected counts: blueprints=%d entities=%d actions=%d", len(data.Blueprints), len(data.Entities), len(data.Actions))
+	}
+	if _, ok := data.Entities[0]["_type"]; ok {
+		t.Fatalf("expected _type tag to be stripped, got %v", data.Entities[0])
+	}
+	perms, ok := data.BlueprintPermissions["service"]
+	if !ok || perms["entities"] == nil {
+		t.Fatalf("expected blueprint permissions keyed by service, got %v", data.BlueprintPermissions)
+	}
+
+	loader := NewStreamLoader()
+	metadata, err := loader.LoadDataWithoutEntities(inputPath)
+	if err != nil {
+		t.Fatalf("LoadDataWithoutEntities error: %v", err)
+	}
+	if len(metadata.Entities) != 0 || len(metadata.Blueprints) != 1 {
+		t.Fatalf("expected metadata without entities, got %+v", metadata)
+	}
+	var ids []string
+	if err := loader.ForEachEntity(inputPath, func(entity api.Entity) error {
+		id, _ := entity["identifier"].(string)
+		ids = append(ids, id)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachEntity error: %v", err)
+	}
+	if strings.Join(ids, ",") != "svc-1,svc-2" {
+		t.Fatalf("unexpected entity ids: %v", ids)
+	}
+}
+
+func TestLoader_ReadsAnonymizedManifest(t *testing.T) {
+	tempDir := t.TempDir()
+	jsonPath := filepath.Join(tempDir, "export.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"blueprints":[],"_manifest":{"anonymized":true}}`), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	ndjsonPath := filepath.Join(tempDir, "export.ndjson")
+	if err := os.WriteFile(ndjsonPath, []byte(`{"_type":"_manifest","anonymized":true}`+"\n"), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	for _, path := range []string{jsonPath, ndjsonPath} {
+		data, err := NewLoader().LoadData(path)
+		if err != nil {
+			t.Fatalf("LoadData(%s) error: %v", path, err)
+		}
+		if !data.Manifest.Anonymized {
+			t.Fatalf("LoadData(%s): expected anonymized manifest", path)
+		}
+		metadata, err := NewStreamLoader().LoadDataWithoutEntities(path)
+		if err != nil {
+			t.Fatalf("LoadDataWithoutEntities(%s) error: %v", path, err)
+		}
+		if !metadata.Manifest.Anonymized {
+			t.Fatalf("LoadDataWithoutEntities(%s): expected anonymized manifest", path)
+		}
+	}
+
+	warnings := appendManifestWarning(nil, export.Manifest{Anonymized: true})
+	if len(warnings) != 1 || warnings[0].Type != "anonymized_bundle" {
+		t.Fatalf("expected an anonymized bundle warning, got %v", warnings)
+	}
+	if warnings := appendManifestWarning(nil, export.Manifest{}); len(warnings) != 0 {
+		t.Fatalf("expected no warning for a regular export, got %v", warnings)
+	}
+	sampled := decodeManifest(map[string]interface{}{"sample": float64(5)})
+	if warnings := appendManifestWarning(nil, sampled); len(warnings) != 1 || warnings[0].Type != "sampled_bundle" {
+		t.Fatalf("expected a sampled bundle warning, got %v", warnings)
+	}
+}
+
 func TestStreamLoader_TarMetadataAndEntities(t *testing.T) {
 	tempDir := t.TempDir()
 	inputPath := filepath.Join(tempDir, "export.tar.gz")
@@ -387,3 +496,67 @@ func TestValidateData_MixedInclude_RequiresBlueprintsWhenNeeded(t *testing.T) {
 		t.Error("mixed include with entities should require blueprints")
 	}
 }
+
+func TestValidateData_EmptyInputExplainsItself(t *testing.T) {
+	err := NewLoader().ValidateData(&export.Data{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "contains no resources") || !strings.Contains(err.Error(), "older port CLI") {
+		t.Fatalf("expected an empty-input error pointing at older CLIs, got %v", err)
+	}
+
+	err = NewLoader().ValidateData(&export.Data{Manifest: export.Manifest{CLIVersion: "1.0.0"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "contains no resources") || strings.Contains(err.Error(), "older port CLI") {
+		t.Fatalf("expected an empty-input error without the older-CLI hint, got %v", err)
+	}
+}
+
+func TestValidateData_NoBlueprintsSuggestsInclude(t *testing.T) {
+	data := &export.Data{Pages: []api.Page{{"identifier": "home"}}}
+	err := NewLoader().ValidateData(data, nil)
+	if err == nil || !strings.Contains(err.Error(), "missing required data: blueprints") || !strings.Contains(err.Error(), "--include") {
+		t.Fatalf("expected a missing-blueprints error suggesting --include, got %v", err)
+	}
+}
+
+func TestLoader_LoadJSON_MalformedFileSaysSo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(path, []byte(`{"blueprints": [`), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	_, err := NewLoader().LoadData(path)
+	if err == nil || !strings.Contains(err.Error(), "malformed") {
+		t.Fatalf("expected a malformed-file error, got %v", err)
+	}
+}
+
+func TestAppendVersionWarning(t *testing.T) {
+	tests := []struct {
+		archive, cli string
+		want         bool
+	}{
+		{"1.2.0", "2.0.1", true},
+		{"v1.2.0", "1.9.0", false},
+		{"", "2.0.0", false},
+		{"1.0.0", "dev", false},
+	}
+	for _, tt := range tests {
+		warnings := appendVersionWarning(nil, tt.archive, tt.cli)
+		if got := len(warnings) == 1 && warnings[0].Type == "version_mismatch"; got != tt.want {
+			t.Errorf("archive %q, cli %q: expected warning=%v, got %+v", tt.archive, tt.cli, tt.want, warnings)
+		}
+	}
+}
+
+func TestLoader_LoadJSON_ReadsManifestCLIVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	content := `{"blueprints": [{"identifier": "svc"}], "_manifest": {"cliVersion": "0.2.0"}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	data, err := NewLoader().LoadData(path)
+	if err != nil {
+		t.Fatalf("LoadData error: %v", err)
+	}
+	if data.Manifest.CLIVersion != "0.2.0" {
+		t.Errorf("expected manifest CLI version 0.2.0, got %q", data.Manifest.CLIVersion)
+	}
+}
@@ -0,0 +1,126 @@
+package import_module
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// isNDJSONPath reports whether inputPath is a newline-delimited JSON export.
+func isNDJSONPath(inputPath string) bool {
+	ext := strings.ToLower(filepath.Ext(inputPath))
+	return ext == ".ndjson" || ext == ".jsonl"
+}
+
+// forEachNDJSONRecord calls yield with the resource type, permission key, and
+// body of every record in an ndjson export. The "_type"/"_key" tags are removed
+// from the body before yield sees it.
+func forEachNDJSONRecord(path string, yield func(resourceType, key string, record map[string]interface{}) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open ndjson file: %w", err)
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+	for line := 1; ; line++ {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode ndjson record %d: %w", line, err)
+		}
+
+		resourceType, _ := record[export.NDJSONTypeField].(string)
+		if resourceType == "" {
+			return fmt.Errorf("ndjson record %d is missing %q", line, export.NDJSONTypeField)
+		}
+		key, _ := record[export.NDJSONKeyField].(string)
+		delete(record, export.NDJSONTypeField)
+		delete(record, export.NDJSONKeyField)
+
+		if err := yield(resourceType, key, record); err != nil {
+			return err
+		}
+	}
+}
+
+// addNDJSONRecord buckets a record into data by its resource type. Unknown
+// types are ignored, matching how archive entries are read.
+func addNDJSONRecord(data *export.Data, resourceType, key string, record map[string]interface{}) {
+	switch resourceType {
+	case "blueprints":
+		data.Blueprints = append(data.Blueprints, api.Blueprint(record))
+	case "entities":
+		data.Entities = append(data.Entities, api.Entity(record))
+	case "scorecards":
+		data.Scorecards = append(data.Scorecards, api.Scorecard(record))
+	case "actions", "automations":
+		data.Actions = append(data.Actions, api.Action(record))
+	case "teams":
+		data.Teams = append(data.Teams, api.Team(record))
+	case "users":
+		data.Users = append(data.Users, api.User(record))
+	case "pages":
+		data.Pages = append(data.Pages, api.Page(record))
+	case "_folders":
+		data.Folders = append(data.Folders, api.Folder(record))
+	case "integrations":
+		data.Integrations = append(data.Integrations, api.Integration(record))
+	case "datasources":
+		data.DataSources = append(data.DataSources, api.Webhook(record))
+	case "blueprint_permissions":
+		data.BlueprintPermissions[key] = api.Permissions(record)
+	case "action_permissions":
+		data.ActionPermissions[key] = api.Permissions(record)
+	case "page_permissions":
+		data.PagePermissions[key] = api.Permissions(record)
+	case export.ManifestResource:
+		data.Manifest = decodeManifest(record)
+	case export.DeletionsResource:
+		data.Deletions = append(data.Deletions, decodeDeletion(record))
+	}
+}
+
+// loadNDJSON loads a complete ndjson export, entities included.
+func (l *Loader) loadNDJSON(path string) (*export.Data, error) {
+	data := emptyExportData()
+	err := forEachNDJSONRecord(path, func(resourceType, key string, record map[string]interface{}) error {
+		addNDJSONRecord(data, resourceType, key, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// loadNDJSONMetadata loads every record of an ndjson export except entities.
+func (l *StreamLoader) loadNDJSONMetadata(path string) (*export.Data, error) {
+	data := emptyExportData()
+	err := forEachNDJSONRecord(path, func(resourceType, key string, record map[string]interface{}) error {
+		if resourceType != "entities" {
+			addNDJSONRecord(data, resourceType, key, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (l *StreamLoader) forEachNDJSONEntity(path string, yield func(api.Entity) error) error {
+	return forEachNDJSONRecord(path, func(resourceType, _ string, record map[string]interface{}) error {
+		if resourceType != "entities" {
+			return nil
+		}
+		return yield(api.Entity(record))
+	})
+}
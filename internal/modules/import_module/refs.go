@@ -0,0 +1,340 @@
+package import_module
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth caps how deeply $ref includes may nest.
+const maxRefDepth = 16
+
+// refPrefetchLimit caps how many $ref files are read at once.
+const refPrefetchLimit = 8
+
+// refResolver inlines "$ref" includes in JSON input files. A map holding a
+// "$ref" string that names a relative file is replaced by that file's
+// content (JSON, or YAML for .yaml/.yml files); any other keys in the map are
+// laid over the included map, so a fragment can be included and extended.
+// Refs inside an included file resolve relative to that file. References
+// starting with "#" or holding a URL are left alone, as they belong to JSON
+// Schema rather than to the bundle. Included files must lie inside the
+// directory of the input file, so a bundle cannot pull in arbitrary files
+// from disk.
+//
+// The files referenced at each level are read concurrently before they are
+// inlined, so bundles split into many fragments load quickly.
+type refResolver struct {
+	// root is the directory included files must lie in.
+	root string
+	// stack holds the files currently being included, to detect cycles.
+	stack []string
+	// files holds the decoded content of prefetched files by path. Each
+	// include takes a copy, as resolving mutates the included value.
+	files map[string]interface{}
+}
+
+// refSections are the input sections whose $refs are resolved: blueprints,
+// actions and other configuration. Entities, teams and users hold data, in
+// which a "$ref" key, such as one in an OpenAPI spec property, is kept as is.
+var refSections = []string{"blueprints", "scorecards", "actions", "folders", "pages", "integrations", "datasources"}
+
+// resolveRefs resolves every $ref in value against baseDir, which included
+// files must lie in.
+func resolveRefs(value interface{}, baseDir string) (interface{}, error) {
+	r := &refResolver{root: filepath.Clean(baseDir), files: make(map[string]interface{})}
+	r.prefetch(value, baseDir)
+	return r.resolve(value, baseDir)
+}
+
+// resolveMapRefs resolves the $refs in a single resource, which must still be
+// a map once its own $ref, if any, is included.
+func resolveMapRefs(m map[string]interface{}, baseDir string) (map[string]interface{}, error) {
+	resolved, err := resolveRefs(m, baseDir)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("$ref in %v resolved to %T, expected an object", m["$ref"], resolved)
+	}
+	return out, nil
+}
+
+func (r *refResolver) resolve(value interface{}, baseDir string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && isFileRef(ref) {
+			return r.include(ref, v, baseDir)
+		}
+		for key, child := range v {
+			resolved, err := r.resolve(child, baseDir)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+		return v, nil
+	case []interface{}:
+		for idx, child := range v {
+			resolved, err := r.resolve(child, baseDir)
+			if err != nil {
+				return nil, err
+			}
+			v[idx] = resolved
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+func (r *refResolver) include(ref string, holder map[string]interface{}, baseDir string) (interface{}, error) {
+	if filepath.IsAbs(ref) {
+		return nil, fmt.Errorf("$ref %q: only relative paths are supported", ref)
+	}
+	path := filepath.Clean(filepath.Join(baseDir, ref))
+	if !r.contains(path) {
+		return nil, fmt.Errorf("$ref %q: included files must be inside %s", ref, r.root)
+	}
+	for _, open := range r.stack {
+		if open == path {
+			return nil, fmt.Errorf("$ref cycle: %s -> %s", strings.Join(r.stack, " -> "), path)
+		}
+	}
+	if len(r.stack) >= maxRefDepth {
+		return nil, fmt.Errorf("$ref %q: includes nested more than %d deep", ref, maxRefDepth)
+	}
+
+	included, err := r.load(path)
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	r.prefetch(included, filepath.Dir(path))
+	r.stack = append(r.stack, path)
+	included, err = r.resolve(included, filepath.Dir(path))
+	r.stack = r.stack[:len(r.stack)-1]
+	if err != nil {
+		return nil, err
+	}
+
+	if len(holder) == 1 {
+		return included, nil
+	}
+	base, ok := included.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("$ref %q: cannot add sibling keys to %T content", ref, included)
+	}
+	for key, child := range holder {
+		if key == "$ref" {
+			continue
+		}
+		resolved, err := r.resolve(child, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		base[key] = resolved
+	}
+	return base, nil
+}
+
+// contains reports whether path lies inside the root directory, once
+// symbolic links are followed.
+func (r *refResolver) contains(path string) bool {
+	if !isWithin(r.root, path) {
+		return false
+	}
+	root, err := filepath.EvalSymlinks(r.root)
+	if err != nil {
+		return false
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		// A missing file is reported when it is read.
+		return os.IsNotExist(err)
+	}
+	return isWithin(root, resolved)
+}
+
+// isWithin reports whether path is dir or lies below it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// load returns a copy of the prefetched content of path, or reads the file
+// when it was not prefetched.
+func (r *refResolver) load(path string) (interface{}, error) {
+	if content, ok := r.files[path]; ok {
+		return copyJSONValue(content), nil
+	}
+	return readRefFile(path)
+}
+
+// prefetch reads the files that value references directly, concurrently, and
+// caches their decoded content. Files that fail to load are left out: include
+// reads them again and reports the error along with the $ref that named them.
+func (r *refResolver) prefetch(value interface{}, baseDir string) {
+	var paths []string
+	seen := make(map[string]bool)
+	collectFileRefs(value, baseDir, func(path string) {
+		if _, cached := r.files[path]; !cached && !seen[path] && r.contains(path) {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	})
+	if len(paths) < 2 {
+		return
+	}
+
+	loaded := make([]interface{}, len(paths))
+	var g errgroup.Group
+	g.SetLimit(refPrefetchLimit)
+	for idx, path := range paths {
+		g.Go(func() error {
+			if content, err := readRefFile(path); err == nil {
+				loaded[idx] = content
+			}
+			return nil
+		})
+	}
+	g.Wait()
+	for idx, path := range paths {
+		if loaded[idx] != nil {
+			r.files[path] = loaded[idx]
+		}
+	}
+}
+
+// collectFileRefs calls found with the path of every relative file $ref in
+// value, without following the references.
+func collectFileRefs(value interface{}, baseDir string, found func(string)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && isFileRef(ref) && !filepath.IsAbs(ref) {
+			found(filepath.Clean(filepath.Join(baseDir, ref)))
+		}
+		for key, child := range v {
+			if key != "$ref" {
+				collectFileRefs(child, baseDir, found)
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectFileRefs(child, baseDir, found)
+		}
+	}
+}
+
+// copyJSONValue deep-copies decoded JSON.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[key] = copyJSONValue(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for idx, child := range v {
+			out[idx] = copyJSONValue(child)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// isFileRef reports whether ref names a file rather than a JSON Schema
+// pointer or a remote document.
+func isFileRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#") && !strings.Contains(ref, "://")
+}
+
+func readRefFile(path string) (interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode YAML: %w", path, err)
+		}
+		// Round-trip through JSON so YAML content has the same shape
+		// (map[string]interface{}, float64) as the rest of the input.
+		asJSON, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert YAML: %w", err)
+		}
+		value = nil
+		if err := json.Unmarshal(asJSON, &value); err != nil {
+			return nil, fmt.Errorf("failed to convert YAML: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(content, &value); err != nil {
+			return nil, jsonFileError(path, content, err)
+		}
+	}
+	return value, nil
+}
+
+// resolveSectionRefs resolves the $refs in the refSections of a decoded input
+// file. A section may itself be a $ref to a file holding its list.
+func resolveSectionRefs(raw map[string]interface{}, baseDir string) error {
+	for _, section := range refSections {
+		value, ok := raw[section]
+		if !ok {
+			continue
+		}
+		resolved, err := resolveRefs(value, baseDir)
+		if err != nil {
+			return err
+		}
+		raw[section] = resolved
+	}
+	return nil
+}
+
+// resolveDataRefs resolves the $refs in the refSections of data, for loaders
+// that decode sections directly into export.Data.
+func resolveDataRefs(data *export.Data, baseDir string) error {
+	if err := resolveItemRefs(data.Blueprints, baseDir); err != nil {
+		return err
+	}
+	if err := resolveItemRefs(data.Scorecards, baseDir); err != nil {
+		return err
+	}
+	if err := resolveItemRefs(data.Actions, baseDir); err != nil {
+		return err
+	}
+	if err := resolveItemRefs(data.Folders, baseDir); err != nil {
+		return err
+	}
+	if err := resolveItemRefs(data.Pages, baseDir); err != nil {
+		return err
+	}
+	if err := resolveItemRefs(data.Integrations, baseDir); err != nil {
+		return err
+	}
+	return resolveItemRefs(data.DataSources, baseDir)
+}
+
+func resolveItemRefs[T ~map[string]interface{}](items []T, baseDir string) error {
+	for idx, item := range items {
+		resolved, err := resolveMapRefs(item, baseDir)
+		if err != nil {
+			return err
+		}
+		items[idx] = T(resolved)
+	}
+	return nil
+}
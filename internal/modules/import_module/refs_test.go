@@ -0,0 +1,217 @@
+package import_module
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func TestLoader_LoadJSON_ResolvesRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.json":       `{"blueprints": [{"identifier": "service", "schema": {"properties": {"$ref": "./common/props.json"}}}]}`,
+		"common/props.json": `{"tags": {"$ref": "tags.yaml"}, "owner": {"type": "string"}}`,
+		"common/tags.yaml":  "type: array\ntitle: Tags\n",
+	})
+
+	data, err := NewLoader().LoadData(filepath.Join(dir, "export.json"))
+	if err != nil {
+		t.Fatalf("LoadData error: %v", err)
+	}
+	props := data.Blueprints[0]["schema"].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := props["owner"]; !ok {
+		t.Errorf("expected the included owner property, got %v", props)
+	}
+	tags, _ := props["tags"].(map[string]interface{})
+	if tags["title"] != "Tags" {
+		t.Errorf("expected the nested YAML include to resolve relative to its file, got %v", props["tags"])
+	}
+}
+
+func TestResolveRefs_SiblingKeysOverrideInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"base.json": `{"type": "string", "title": "Base"}`})
+
+	got, err := resolveMapRefs(map[string]interface{}{"$ref": "base.json", "title": "Owner"}, dir)
+	if err != nil {
+		t.Fatalf("resolveMapRefs error: %v", err)
+	}
+	if got["type"] != "string" || got["title"] != "Owner" {
+		t.Errorf("expected the include extended by sibling keys, got %v", got)
+	}
+}
+
+func TestResolveRefs_LeavesSchemaPointersAlone(t *testing.T) {
+	value := map[string]interface{}{"items": map[string]interface{}{"$ref": "#/definitions/tag"}}
+	got, err := resolveRefs(value, t.TempDir())
+	if err != nil {
+		t.Fatalf("resolveRefs error: %v", err)
+	}
+	if got.(map[string]interface{})["items"].(map[string]interface{})["$ref"] != "#/definitions/tag" {
+		t.Errorf("expected the JSON pointer to be kept, got %v", got)
+	}
+}
+
+func TestResolveRefs_DetectsCycles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.json": `{"next": {"$ref": "b.json"}}`,
+		"b.json": `{"next": {"$ref": "a.json"}}`,
+	})
+	_, err := resolveRefs(map[string]interface{}{"$ref": "a.json"}, dir)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+}
+
+func TestResolveRefs_CapsDepth(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{}
+	for i := 0; i <= maxRefDepth; i++ {
+		files[filepath.Join(strings.Repeat("d/", i), "f.json")] = `{"next": {"$ref": "d/f.json"}}`
+	}
+	writeFiles(t, dir, files)
+	_, err := resolveRefs(map[string]interface{}{"$ref": "f.json"}, dir)
+	if err == nil || !strings.Contains(err.Error(), "nested more than") {
+		t.Fatalf("expected a depth error, got %v", err)
+	}
+}
+
+func TestStreamLoader_ResolvesRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.json": `{"blueprints": [{"$ref": "bp.json"}], "actions": [{"identifier": "deploy", "userInputs": {"$ref": "inputs.json"}}]}`,
+		"bp.json":     `{"identifier": "service"}`,
+		"inputs.json": `{"properties": {}}`,
+	})
+	data, err := NewStreamLoader().LoadDataWithoutEntities(filepath.Join(dir, "export.json"))
+	if err != nil {
+		t.Fatalf("LoadDataWithoutEntities error: %v", err)
+	}
+	if len(data.Blueprints) != 1 || data.Blueprints[0]["identifier"] != "service" {
+		t.Errorf("expected the included blueprint, got %v", data.Blueprints)
+	}
+	if inputs, _ := data.Actions[0]["userInputs"].(map[string]interface{}); inputs["properties"] == nil {
+		t.Errorf("expected the included action inputs, got %v", data.Actions[0]["userInputs"])
+	}
+}
+
+func TestLoaders_KeepRefsInEntityProperties(t *testing.T) {
+	dir := t.TempDir()
+	// An OpenAPI spec stored on an entity uses $ref for its own components;
+	// neither loader may treat it as an include.
+	writeFiles(t, dir, map[string]string{
+		"export.json":          `{"blueprints": [{"identifier": "api"}], "entities": [{"identifier": "petstore", "blueprint": "api", "properties": {"spec": {"paths": {"/pets": {"$ref": "components/pets.yaml"}}}}}]}`,
+		"components/pets.yaml": "get: {}\n",
+	})
+	path := filepath.Join(dir, "export.json")
+	specRef := func(e api.Entity) interface{} {
+		spec := e["properties"].(map[string]interface{})["spec"].(map[string]interface{})
+		return spec["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["$ref"]
+	}
+
+	data, err := NewLoader().LoadData(path)
+	if err != nil {
+		t.Fatalf("LoadData error: %v", err)
+	}
+	if got := specRef(data.Entities[0]); got != "components/pets.yaml" {
+		t.Errorf("expected the entity's $ref to round-trip unchanged, got %v", data.Entities[0]["properties"])
+	}
+
+	var streamed api.Entity
+	if err := NewStreamLoader().ForEachEntity(path, func(e api.Entity) error {
+		streamed = e
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachEntity error: %v", err)
+	}
+	if got := specRef(streamed); got != "components/pets.yaml" {
+		t.Errorf("expected the streamed entity's $ref to round-trip unchanged, got %v", streamed["properties"])
+	}
+}
+
+func TestResolveRefs_RejectsFilesOutsideInputDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"secret.json":          `{"key": "do not upload"}`,
+		"bundle/shared.json":   `{"type": "string"}`,
+		"bundle/nested/a.json": `{"shared": {"$ref": "../shared.json"}}`,
+	})
+	dir := filepath.Join(root, "bundle")
+
+	if _, err := resolveRefs(map[string]interface{}{"$ref": "nested/a.json"}, dir); err != nil {
+		t.Errorf("expected a ref to a sibling directory inside the input to resolve, got %v", err)
+	}
+	for _, ref := range []string{"../secret.json", "nested/../../secret.json"} {
+		_, err := resolveRefs(map[string]interface{}{"$ref": ref}, dir)
+		if err == nil || !strings.Contains(err.Error(), "must be inside") {
+			t.Errorf("$ref %q: expected to be rejected, got %v", ref, err)
+		}
+	}
+
+	if err := os.Symlink(filepath.Join(root, "secret.json"), filepath.Join(dir, "link.json")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if _, err := resolveRefs(map[string]interface{}{"$ref": "link.json"}, dir); err == nil {
+		t.Error("expected a symlink leaving the input directory to be rejected")
+	}
+}
+
+func TestResolveRefs_SharedFragmentIsCopiedPerInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"owner.json": `{"type": "string", "title": "Owner"}`,
+		"tier.json":  `{"type": "string"}`,
+	})
+	value := map[string]interface{}{
+		"a":    map[string]interface{}{"$ref": "owner.json", "title": "Primary"},
+		"b":    map[string]interface{}{"$ref": "owner.json"},
+		"tier": map[string]interface{}{"$ref": "tier.json"},
+	}
+	got, err := resolveRefs(value, dir)
+	if err != nil {
+		t.Fatalf("resolveRefs error: %v", err)
+	}
+	resolved := got.(map[string]interface{})
+	if resolved["a"].(map[string]interface{})["title"] != "Primary" || resolved["b"].(map[string]interface{})["title"] != "Owner" {
+		t.Errorf("expected each include of a shared fragment to be independent, got %v", resolved)
+	}
+	if resolved["tier"].(map[string]interface{})["type"] != "string" {
+		t.Errorf("expected the prefetched tier include, got %v", resolved["tier"])
+	}
+}
+
+func TestResolveRefs_NamesFileAndLineOfParseErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.json": `{"type": "string"}`,
+		"b.json": "{\n  \"type\": \"string\",\n  \"title\" \"Broken\"\n}\n",
+	})
+	_, err := resolveRefs(map[string]interface{}{
+		"a": map[string]interface{}{"$ref": "a.json"},
+		"b": map[string]interface{}{"$ref": "b.json"},
+	}, dir)
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+	want := filepath.Join(dir, "b.json") + ":3:11:"
+	if !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), `$ref "b.json"`) {
+		t.Fatalf("expected the error to name %s, got %v", want, err)
+	}
+}
@@ -0,0 +1,149 @@
+package import_module
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// RelationMismatch is a relation that Port would reject when entities are
+// written: its target blueprint is missing, an entity gives it a value of the
+// wrong cardinality, or an entity points at a target entity that does not
+// exist.
+type RelationMismatch struct {
+	Blueprint    string `json:"blueprint"`
+	Relation     string `json:"relation"`
+	Target       string `json:"target"`
+	Entity       string `json:"entity,omitempty"`
+	TargetEntity string `json:"targetEntity,omitempty"`
+	Detail       string `json:"detail"`
+}
+
+func (m RelationMismatch) String() string {
+	from := m.Blueprint + "." + m.Relation
+	if m.Entity != "" {
+		from = fmt.Sprintf("%s/%s.%s", m.Blueprint, m.Entity, m.Relation)
+	}
+	to := m.Target
+	if m.TargetEntity != "" {
+		to = m.Target + "/" + m.TargetEntity
+	}
+	return fmt.Sprintf("%s -> %s: %s", from, to, m.Detail)
+}
+
+// FindRelationMismatches checks the relations of blueprints, and the relation
+// values of entities, grouped by blueprint. A relation's target blueprint
+// must be one of blueprints or a common system blueprint. A "many" relation
+// takes a list of identifiers and any other relation a single identifier.
+// Each identifier must be an entity of the target blueprint, which is only
+// checked when entities holds the target blueprint's entities (possibly an
+// empty list); relations set by a search query are not checked. Port-managed
+// rule_result_target relations are skipped. Mismatches are sorted by
+// blueprint, entity and relation.
+func FindRelationMismatches(blueprints []api.Blueprint, entities map[string][]api.Entity) []RelationMismatch {
+	existing := BuildExistingBlueprintsSet(CommonSystemBlueprints())
+	for _, bp := range blueprints {
+		if id, ok := bp["identifier"].(string); ok {
+			existing[id] = true
+		}
+	}
+	identifiers := make(map[string]map[string]bool, len(entities))
+	for bpID, ents := range entities {
+		ids := make(map[string]bool, len(ents))
+		for _, entity := range ents {
+			if id, ok := entity["identifier"].(string); ok {
+				ids[id] = true
+			}
+		}
+		identifiers[bpID] = ids
+	}
+
+	var mismatches []RelationMismatch
+	for _, missing := range FindMissingRelationTargets(blueprints, existing) {
+		mismatches = append(mismatches, RelationMismatch{
+			Blueprint: missing.Blueprint, Relation: missing.Relation, Target: missing.Target,
+			Detail: "target blueprint does not exist",
+		})
+	}
+
+	for _, bp := range blueprints {
+		bpID, _ := bp["identifier"].(string)
+		relations, _ := PartitionBlueprintRelationsRuleResultTarget(ExtractRelations(bp))
+		for _, entity := range entities[bpID] {
+			entityID, _ := entity["identifier"].(string)
+			values, _ := entity["relations"].(map[string]interface{})
+			for name, value := range values {
+				def, _ := relations[name].(map[string]interface{})
+				target, _ := def["target"].(string)
+				if target == "" || value == nil || !existing[target] {
+					continue
+				}
+				many, _ := def["many"].(bool)
+				mismatch := RelationMismatch{Blueprint: bpID, Relation: name, Target: target, Entity: entityID}
+				refs, detail := relationRefs(value, many)
+				if detail != "" {
+					mismatch.Detail = detail
+					mismatches = append(mismatches, mismatch)
+					continue
+				}
+				known, loaded := identifiers[target]
+				if !loaded {
+					continue
+				}
+				for _, ref := range refs {
+					if !known[ref] {
+						mismatch.TargetEntity = ref
+						mismatch.Detail = fmt.Sprintf("no %s entity with this identifier", target)
+						mismatches = append(mismatches, mismatch)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(mismatches, func(a, b int) bool {
+		x, y := mismatches[a], mismatches[b]
+		if x.Blueprint != y.Blueprint {
+			return x.Blueprint < y.Blueprint
+		}
+		if x.Entity != y.Entity {
+			return x.Entity < y.Entity
+		}
+		if x.Relation != y.Relation {
+			return x.Relation < y.Relation
+		}
+		return x.TargetEntity < y.TargetEntity
+	})
+	return mismatches
+}
+
+// relationRefs returns the target identifiers in an entity's relation value,
+// or a detail when the value does not fit the relation's cardinality. Search
+// query values (objects) have no identifiers to check.
+func relationRefs(value interface{}, many bool) ([]string, string) {
+	switch v := value.(type) {
+	case string:
+		if many {
+			return nil, "many relation holds a single identifier, expected a list"
+		}
+		return []string{v}, ""
+	case []interface{}:
+		if !many {
+			return nil, "single relation holds a list, expected one identifier"
+		}
+		refs := make([]string, 0, len(v))
+		for _, item := range v {
+			ref, ok := item.(string)
+			if !ok {
+				return nil, fmt.Sprintf("expected identifiers, got %s", jsonTypeOf(item))
+			}
+			refs = append(refs, ref)
+		}
+		return refs, ""
+	case map[string]interface{}:
+		return nil, ""
+	default:
+		return nil, fmt.Sprintf("expected an identifier, got %s", jsonTypeOf(value))
+	}
+}
@@ -0,0 +1,57 @@
+package import_module
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestFindRelationMismatches(t *testing.T) {
+	blueprints := []api.Blueprint{
+		{"identifier": "service", "relations": map[string]interface{}{
+			"domain":       map[string]interface{}{"target": "domain", "many": false},
+			"dependencies": map[string]interface{}{"target": "service", "many": true},
+			"owner":        map[string]interface{}{"target": "_user"},
+			"cluster":      map[string]interface{}{"target": "cluster"},
+		}},
+		{"identifier": "domain"},
+	}
+	entities := map[string][]api.Entity{
+		"service": {
+			{"identifier": "checkout", "relations": map[string]interface{}{
+				"domain":       "payments",
+				"dependencies": []interface{}{"cart", "ledger"},
+				"owner":        "someone@example.com",
+			}},
+			{"identifier": "cart", "relations": map[string]interface{}{
+				"domain":       []interface{}{"shop"},
+				"dependencies": "checkout",
+				"cluster":      "eu-1",
+			}},
+			{"identifier": "search", "relations": map[string]interface{}{
+				"domain":       "shop",
+				"dependencies": map[string]interface{}{"combinator": "and", "rules": []interface{}{}},
+			}},
+		},
+		"domain": {{"identifier": "shop"}},
+	}
+
+	got := FindRelationMismatches(blueprints, entities)
+	want := []RelationMismatch{
+		{Blueprint: "service", Relation: "cluster", Target: "cluster", Detail: "target blueprint does not exist"},
+		{Blueprint: "service", Relation: "dependencies", Target: "service", Entity: "cart", Detail: "many relation holds a single identifier, expected a list"},
+		{Blueprint: "service", Relation: "domain", Target: "domain", Entity: "cart", Detail: "single relation holds a list, expected one identifier"},
+		{Blueprint: "service", Relation: "dependencies", Target: "service", Entity: "checkout", TargetEntity: "ledger", Detail: "no service entity with this identifier"},
+		{Blueprint: "service", Relation: "domain", Target: "domain", Entity: "checkout", TargetEntity: "payments", Detail: "no domain entity with this identifier"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindRelationMismatches =\n%v\nwant\n%v", got, want)
+	}
+	if s := want[4].String(); s != "service/checkout.domain -> domain/payments: no domain entity with this identifier" {
+		t.Errorf("String() = %q", s)
+	}
+	if s := want[0].String(); s != "service.cluster -> cluster: target blueprint does not exist" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,111 @@
+package import_module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// MissingRelationTarget is a relation whose target blueprint exists neither
+// in the input nor in the target organization.
+type MissingRelationTarget struct {
+	Blueprint string
+	Relation  string
+	Target    string
+}
+
+func (m MissingRelationTarget) String() string {
+	return fmt.Sprintf("%s.%s -> %s", m.Blueprint, m.Relation, m.Target)
+}
+
+// MissingRelationTargetsError fails an import or migration run with
+// --strict-relations whose relations target missing blueprints. It is
+// returned before anything is applied, and retrying does not help: the
+// target blueprints have to be added to the input or the target first.
+type MissingRelationTargetsError struct {
+	Missing []MissingRelationTarget
+}
+
+func (e *MissingRelationTargetsError) Error() string {
+	parts := make([]string, len(e.Missing))
+	for i, m := range e.Missing {
+		parts[i] = m.String()
+	}
+	return fmt.Sprintf("%d relation(s) target blueprints that do not exist (--strict-relations): %s", len(e.Missing), strings.Join(parts, ", "))
+}
+
+// FindMissingRelationTargets returns the relations of blueprints whose target
+// is not in existing, sorted by blueprint and relation. Port-managed
+// rule_result_target relations are not checked.
+func FindMissingRelationTargets(blueprints []api.Blueprint, existing map[string]bool) []MissingRelationTarget {
+	var missing []MissingRelationTarget
+	for _, bp := range blueprints {
+		id, _ := bp["identifier"].(string)
+		kept, _ := PartitionBlueprintRelationsRuleResultTarget(ExtractRelations(bp))
+		for name, rel := range kept {
+			relMap, _ := rel.(map[string]interface{})
+			target, _ := relMap["target"].(string)
+			if target != "" && !existing[target] {
+				missing = append(missing, MissingRelationTarget{Blueprint: id, Relation: name, Target: target})
+			}
+		}
+	}
+	sort.Slice(missing, func(a, b int) bool {
+		if missing[a].Blueprint != missing[b].Blueprint {
+			return missing[a].Blueprint < missing[b].Blueprint
+		}
+		return missing[a].Relation < missing[b].Relation
+	})
+	return missing
+}
+
+// CheckRelationTargets is the --strict-relations check run before an import
+// or migration changes anything. It returns a *MissingRelationTargetsError
+// when a relation of blueprints targets a blueprint that is neither among
+// blueprints nor in the organization client points at.
+func CheckRelationTargets(ctx context.Context, client *api.Client, blueprints []api.Blueprint) error {
+	existing := BuildExistingBlueprintsSet(CommonSystemBlueprints())
+	for _, bp := range blueprints {
+		if id, ok := bp["identifier"].(string); ok {
+			existing[id] = true
+		}
+	}
+	if len(FindMissingRelationTargets(blueprints, existing)) > 0 {
+		current, err := client.GetBlueprints(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch target blueprints for relation validation: %w", err)
+		}
+		for _, bp := range current {
+			if id, ok := bp["identifier"].(string); ok {
+				existing[id] = true
+			}
+		}
+	}
+	if missing := FindMissingRelationTargets(blueprints, existing); len(missing) > 0 {
+		return &MissingRelationTargetsError{Missing: missing}
+	}
+	return nil
+}
+
+// CheckStoredRelationTargets applies --strict-relations to the second pass
+// that re-applies relations (blueprint -> relations) once the blueprints
+// exist: with strict set, a relation whose target is missing from existing,
+// for instance because the target failed to be created, fails the run with a
+// *MissingRelationTargetsError. Without strict it returns nil, and each
+// blueprint with a missing target is reported on its own.
+func CheckStoredRelationTargets(strict bool, relations map[string]map[string]interface{}, existing map[string]bool) error {
+	if !strict {
+		return nil
+	}
+	blueprints := make([]api.Blueprint, 0, len(relations))
+	for id, rels := range relations {
+		blueprints = append(blueprints, api.Blueprint{"identifier": id, "relations": rels})
+	}
+	if missing := FindMissingRelationTargets(blueprints, existing); len(missing) > 0 {
+		return &MissingRelationTargetsError{Missing: missing}
+	}
+	return nil
+}
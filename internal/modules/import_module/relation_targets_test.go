@@ -0,0 +1,73 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func TestFindMissingRelationTargets(t *testing.T) {
+	blueprints := []api.Blueprint{
+		{"identifier": "service", "relations": map[string]interface{}{
+			"team":    map[string]interface{}{"target": "_team"},
+			"domain":  map[string]interface{}{"target": "domain"},
+			"cluster": map[string]interface{}{"target": "cluster"},
+		}},
+		{"identifier": "_rule_result", "relations": map[string]interface{}{
+			"service": map[string]interface{}{"target": "nowhere", "type": RuleResultTargetRelationType},
+		}},
+	}
+	existing := map[string]bool{"_team": true, "domain": true}
+
+	missing := FindMissingRelationTargets(blueprints, existing)
+	if len(missing) != 1 || missing[0] != (MissingRelationTarget{Blueprint: "service", Relation: "cluster", Target: "cluster"}) {
+		t.Errorf("expected only service.cluster to be missing, got %v", missing)
+	}
+}
+
+func TestCheckRelationTargets_ConsultsTarget(t *testing.T) {
+	calls := 0
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if authHandler(w, r) {
+			return
+		}
+		calls++
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []map[string]interface{}{{"identifier": "cluster"}}})
+	})
+	service := func(target string) []api.Blueprint {
+		return []api.Blueprint{{"identifier": "service", "relations": map[string]interface{}{"rel": map[string]interface{}{"target": target}}}}
+	}
+
+	if err := CheckRelationTargets(context.Background(), client, service("service")); err != nil || calls != 0 {
+		t.Fatalf("expected a self-relation to pass without fetching the target, got err=%v calls=%d", err, calls)
+	}
+	if err := CheckRelationTargets(context.Background(), client, service("cluster")); err != nil {
+		t.Fatalf("expected a target that exists in the organization to pass, got %v", err)
+	}
+	err := CheckRelationTargets(context.Background(), client, service("domain"))
+	var missingErr *MissingRelationTargetsError
+	if !errors.As(err, &missingErr) || !strings.Contains(err.Error(), "service.rel -> domain") {
+		t.Fatalf("expected a MissingRelationTargetsError naming service.rel, got %v", err)
+	}
+}
+
+func TestCheckStoredRelationTargets(t *testing.T) {
+	relations := map[string]map[string]interface{}{
+		"service": {"domain": map[string]interface{}{"target": "domain"}},
+	}
+	if err := CheckStoredRelationTargets(false, relations, nil); err != nil {
+		t.Errorf("expected lenient mode to leave missing targets to the per-blueprint errors, got %v", err)
+	}
+	if err := CheckStoredRelationTargets(true, relations, map[string]bool{"domain": true}); err != nil {
+		t.Errorf("expected existing targets to pass, got %v", err)
+	}
+	var missingErr *MissingRelationTargetsError
+	if err := CheckStoredRelationTargets(true, relations, nil); !errors.As(err, &missingErr) {
+		t.Errorf("expected strict mode to fail on the missing target, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package import_module
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// RelationWriter adds relations to blueprints through the per-relation
+// endpoints, so the relation pass neither fetches nor rewrites the rest of
+// each blueprint. Not every Port deployment offers those endpoints: the first
+// time one answers 404 or 405 the writer remembers it, and Apply leaves the
+// blueprint to the caller's fetch-merge-update path from then on.
+type RelationWriter struct {
+	client      *api.Client
+	unavailable atomic.Bool
+}
+
+// NewRelationWriter creates a relation writer for client.
+func NewRelationWriter(client *api.Client) *RelationWriter {
+	return &RelationWriter{client: client}
+}
+
+// Apply creates each relation on blueprint id, replacing relations that
+// already exist. It returns false, having written nothing, when the relation
+// endpoints are unavailable; the caller must then update the whole blueprint.
+// The _rule_result blueprint is always left to the caller, which patches it.
+func (w *RelationWriter) Apply(ctx context.Context, id string, relations map[string]interface{}) (bool, error) {
+	if id == "_rule_result" || w.unavailable.Load() {
+		return false, nil
+	}
+	names := make([]string, 0, len(relations))
+	for name := range relations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for n, name := range names {
+		relation, ok := relations[name].(map[string]interface{})
+		if !ok {
+			return true, fmt.Errorf("relation %s: unexpected definition %T", name, relations[name])
+		}
+		err := w.client.CreateBlueprintRelation(ctx, id, name, relation)
+		if api.HasStatus(err, http.StatusConflict) {
+			err = w.client.UpdateBlueprintRelation(ctx, id, name, relation)
+		}
+		if n == 0 && endpointUnavailable(err) {
+			w.unavailable.Store(true)
+			return false, nil
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to add relation %s: %w", name, err)
+		}
+	}
+	return true, nil
+}
+
+// endpointUnavailable reports whether err means the API does not serve the
+// relation endpoints at all.
+func endpointUnavailable(err error) bool {
+	return api.HasStatus(err, http.StatusNotFound) || api.HasStatus(err, http.StatusMethodNotAllowed)
+}
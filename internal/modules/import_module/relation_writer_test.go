@@ -0,0 +1,108 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+func newRelationTestServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) (*api.Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access_token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+			return
+		}
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		handle(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}), &calls
+}
+
+func TestRelationWriter_CreatesAndReplacesRelations(t *testing.T) {
+	client, calls := newRelationTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["identifier"] == "owner" {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "relation_already_exists"})
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	applied, err := NewRelationWriter(client).Apply(context.Background(), "service", map[string]interface{}{
+		"owner":  map[string]interface{}{"target": "team"},
+		"system": map[string]interface{}{"target": "system"},
+	})
+	if !applied || err != nil {
+		t.Fatalf("Apply = %v, %v; want applied without error", applied, err)
+	}
+	want := "POST /blueprints/service/relations,PUT /blueprints/service/relations/owner,POST /blueprints/service/relations"
+	if got := strings.Join(*calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestRelationWriter_FallsBackWhenEndpointsAreMissing(t *testing.T) {
+	client, calls := newRelationTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	writer := NewRelationWriter(client)
+	relations := map[string]interface{}{"owner": map[string]interface{}{"target": "team"}}
+
+	for _, id := range []string{"service", "system"} {
+		applied, err := writer.Apply(context.Background(), id, relations)
+		if applied || err != nil {
+			t.Fatalf("Apply(%s) = %v, %v; want a fallback", id, applied, err)
+		}
+	}
+	if len(*calls) != 1 {
+		t.Errorf("expected the endpoints to be probed once, got %v", *calls)
+	}
+}
+
+func TestRelationWriter_LeavesRuleResultToCaller(t *testing.T) {
+	client, calls := newRelationTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+	applied, err := NewRelationWriter(client).Apply(context.Background(), "_rule_result", map[string]interface{}{
+		"entity": map[string]interface{}{"target": "service"},
+	})
+	if applied || err != nil || len(*calls) != 0 {
+		t.Errorf("Apply = %v, %v with calls %v; want _rule_result left to the caller", applied, err, *calls)
+	}
+}
+
+func TestRelationWriter_ReportsFailuresAfterTheFirstRelation(t *testing.T) {
+	client, _ := newRelationTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["identifier"] == "system" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_target"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+	applied, err := NewRelationWriter(client).Apply(context.Background(), "service", map[string]interface{}{
+		"owner":  map[string]interface{}{"target": "team"},
+		"system": map[string]interface{}{"target": "missing"},
+	})
+	if !applied || err == nil || !strings.Contains(err.Error(), "system") {
+		t.Errorf("Apply = %v, %v; want an error naming the failed relation", applied, err)
+	}
+}
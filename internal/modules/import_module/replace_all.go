@@ -0,0 +1,171 @@
+package import_module
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// ErrDeletionsDeclined is returned by Execute when Options.ConfirmDeletions
+// declines the deletions planned for --replace-all. Nothing has been changed.
+var ErrDeletionsDeclined = errors.New("deletions declined")
+
+// DeletionConfirmer is asked to approve the deletions --replace-all is about to
+// make, before the import changes anything.
+type DeletionConfirmer func(deletions []export.Deletion) (bool, error)
+
+// checkReplaceAllInput refuses --replace-all for bundles that do not hold the
+// full state of an organization, as mirroring them would delete everything
+// they leave out.
+func checkReplaceAllInput(manifest export.Manifest) error {
+	if manifest.Delta {
+		return fmt.Errorf("--replace-all cannot be used with a delta bundle, which only holds changed resources; use --prune instead")
+	}
+	if manifest.Sample > 0 {
+		return fmt.Errorf("--replace-all cannot be used with a bundle exported with --sample %d, which does not hold every entity", manifest.Sample)
+	}
+	if manifest.Anonymized {
+		return fmt.Errorf("--replace-all cannot be used with an anonymized bundle, whose identifiers do not match the target's")
+	}
+	if len(manifest.Selection) > 0 {
+		return fmt.Errorf("--replace-all cannot be used with a bundle exported with %s, which does not hold the full organization", strings.Join(manifest.Selection, ", "))
+	}
+	return nil
+}
+
+// replaceAllDeletions returns a Deletion for every resource of the target's
+// current state that bundle does not contain, so the import leaves the target
+// mirroring the bundle. current is narrowed by the same --include patterns and
+// blueprint exclusions as the bundle, so resources left out of the import are
+// never deleted. System blueprints, their entities and users are never listed.
+func replaceAllDeletions(bundle, current *export.Data, opts Options) []export.Deletion {
+	// Filter a copy: both filters replace the slices they filter.
+	target := &export.Data{
+		Blueprints:   current.Blueprints,
+		Entities:     current.Entities,
+		Scorecards:   current.Scorecards,
+		Actions:      current.Actions,
+		Teams:        current.Teams,
+		Folders:      current.Folders,
+		Pages:        current.Pages,
+		Integrations: current.Integrations,
+	}
+	export.FilterByIncludePatterns(target, opts.IncludePatterns)
+	applyDataExclusion(target, opts.ExcludeBlueprints, opts.ExcludeBlueprintSchema, false, false)
+
+	var deletions []export.Deletion
+	keep := make(map[ResourceKey]bool)
+	for _, e := range bundle.Entities {
+		if key, ok := EntityKey(e); ok {
+			keep[key] = true
+		}
+	}
+	for _, e := range target.Entities {
+		key, ok := EntityKey(e)
+		if ok && !keep[key] && !IsSystemBlueprint(key.Blueprint) {
+			deletions = append(deletions, export.Deletion{Type: "entities", Blueprint: key.Blueprint, Identifier: key.Identifier})
+		}
+	}
+
+	keep = make(map[ResourceKey]bool)
+	for _, sc := range bundle.Scorecards {
+		if key, ok := ScorecardKey(sc); ok {
+			keep[key] = true
+		}
+	}
+	for _, sc := range target.Scorecards {
+		if key, ok := ScorecardKey(sc); ok && !keep[key] {
+			deletions = append(deletions, export.Deletion{Type: "scorecards", Blueprint: key.Blueprint, Identifier: key.Identifier})
+		}
+	}
+
+	keepActions := identifierSet(bundle.Actions, "identifier")
+	seenActions := make(map[string]bool)
+	for _, action := range target.Actions {
+		id, _ := action["identifier"].(string)
+		if id == "" || keepActions[id] || seenActions[id] {
+			continue
+		}
+		seenActions[id] = true
+		deletions = append(deletions, export.Deletion{Type: "actions", Blueprint: export.ActionBlueprintID(action), Identifier: id})
+	}
+
+	deletions = appendMissing(deletions, "pages", target.Pages, bundle.Pages, "identifier")
+	deletions = appendMissing(deletions, "_folders", target.Folders, bundle.Folders, "identifier")
+	deletions = appendMissing(deletions, "integrations", target.Integrations, bundle.Integrations, "installationId")
+	deletions = appendMissing(deletions, "teams", target.Teams, bundle.Teams, "name")
+
+	keepBlueprints := identifierSet(bundle.Blueprints, "identifier")
+	var extra []api.Blueprint
+	for _, bp := range target.Blueprints {
+		if id, _ := bp["identifier"].(string); id != "" && !keepBlueprints[id] && !IsSystemBlueprint(id) {
+			extra = append(extra, bp)
+		}
+	}
+	for _, bp := range BlueprintDeletionOrder(extra) {
+		deletions = append(deletions, export.Deletion{Type: "blueprints", Identifier: bp["identifier"].(string)})
+	}
+	return deletions
+}
+
+// BlueprintDeletionOrder orders blueprints so each comes before the
+// blueprints it relates to, as Port refuses to delete the target of a
+// relation. Blueprints in a relation cycle come last; deleting them reports
+// the API's error.
+func BlueprintDeletionOrder(blueprints []api.Blueprint) []api.Blueprint {
+	levels, cyclic := TopologicalSort(blueprints, nil)
+	ordered := make([]api.Blueprint, 0, len(blueprints))
+	for i := len(levels) - 1; i >= 0; i-- {
+		level := levels[i]
+		sort.Slice(level, func(a, b int) bool {
+			return level[a]["identifier"].(string) < level[b]["identifier"].(string)
+		})
+		ordered = append(ordered, level...)
+	}
+	sort.Slice(cyclic, func(a, b int) bool {
+		return cyclic[a]["identifier"].(string) < cyclic[b]["identifier"].(string)
+	})
+	return append(ordered, cyclic...)
+}
+
+// appendMissing adds a Deletion of type section for each item of target whose
+// field is not the field of any item of bundle.
+func appendMissing[T ~map[string]interface{}](deletions []export.Deletion, section string, target, bundle []T, field string) []export.Deletion {
+	keep := identifierSet(bundle, field)
+	for _, item := range target {
+		if id, _ := item[field].(string); id != "" && !keep[id] {
+			deletions = append(deletions, export.Deletion{Type: section, Identifier: id})
+			keep[id] = true
+		}
+	}
+	return deletions
+}
+
+func identifierSet[T ~map[string]interface{}](items []T, field string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		if id, _ := item[field].(string); id != "" {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// mergeDeletions appends the deletions of extra missing from deletions.
+func mergeDeletions(deletions, extra []export.Deletion) []export.Deletion {
+	seen := make(map[export.Deletion]bool, len(deletions))
+	for _, d := range deletions {
+		seen[d] = true
+	}
+	for _, d := range extra {
+		if !seen[d] {
+			seen[d] = true
+			deletions = append(deletions, d)
+		}
+	}
+	return deletions
+}
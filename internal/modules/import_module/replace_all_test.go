@@ -0,0 +1,201 @@
+package import_module
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestReplaceAllDeletions(t *testing.T) {
+	relatesTo := func(id, target string) api.Blueprint {
+		return api.Blueprint{"identifier": id, "relations": map[string]interface{}{
+			target: map[string]interface{}{"target": target},
+		}}
+	}
+	bundle := &export.Data{
+		Blueprints: []api.Blueprint{{"identifier": "service"}},
+		Entities:   []api.Entity{{"blueprint": "service", "identifier": "checkout"}},
+		Scorecards: []api.Scorecard{{"blueprintIdentifier": "service", "identifier": "health"}},
+		Actions:    []api.Action{{"identifier": "deploy"}},
+		Teams:      []api.Team{{"name": "platform"}},
+		Pages:      []api.Page{{"identifier": "home"}},
+	}
+	current := &export.Data{
+		Blueprints: []api.Blueprint{
+			{"identifier": "service"},
+			{"identifier": "_user"},
+			{"identifier": "region"},
+			relatesTo("cluster", "region"),
+			relatesTo("deployment", "cluster"),
+			{"identifier": "excluded"},
+		},
+		Entities: []api.Entity{
+			{"blueprint": "service", "identifier": "checkout"},
+			{"blueprint": "service", "identifier": "legacy"},
+			{"blueprint": "_user", "identifier": "someone@example.com"},
+			{"blueprint": "excluded", "identifier": "kept"},
+		},
+		Scorecards: []api.Scorecard{
+			{"blueprintIdentifier": "service", "identifier": "health"},
+			{"blueprintIdentifier": "service", "identifier": "old"},
+		},
+		Actions: []api.Action{
+			{"identifier": "deploy"},
+			{"identifier": "rollback", "trigger": map[string]interface{}{"blueprintIdentifier": "service"}},
+			{"identifier": "rollback", "trigger": map[string]interface{}{"blueprintIdentifier": "service"}},
+		},
+		Teams: []api.Team{{"name": "platform"}, {"name": "former"}},
+		Pages: []api.Page{{"identifier": "home"}, {"identifier": "stale"}},
+	}
+
+	got := replaceAllDeletions(bundle, current, Options{ExcludeBlueprints: []string{"excluded"}})
+	want := []export.Deletion{
+		{Type: "entities", Blueprint: "service", Identifier: "legacy"},
+		{Type: "scorecards", Blueprint: "service", Identifier: "old"},
+		{Type: "actions", Blueprint: "service", Identifier: "rollback"},
+		{Type: "pages", Identifier: "stale"},
+		{Type: "teams", Identifier: "former"},
+		{Type: "blueprints", Identifier: "deployment"},
+		{Type: "blueprints", Identifier: "cluster"},
+		{Type: "blueprints", Identifier: "region"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replaceAllDeletions =\n%v\nwant\n%v", got, want)
+	}
+	if len(current.Blueprints) != 6 || len(current.Entities) != 4 {
+		t.Error("replaceAllDeletions modified the current state it was given")
+	}
+}
+
+func TestReplaceAllDeletions_IncludePatterns(t *testing.T) {
+	bundle := &export.Data{}
+	current := &export.Data{
+		Blueprints: []api.Blueprint{{"identifier": "svc-a"}, {"identifier": "team-b"}},
+	}
+	got := replaceAllDeletions(bundle, current, Options{IncludePatterns: map[string][]string{"blueprints": {"svc-*"}}})
+	want := []export.Deletion{{Type: "blueprints", Identifier: "svc-a"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replaceAllDeletions = %v, want %v (only what --include selects)", got, want)
+	}
+}
+
+func TestCheckReplaceAllInput(t *testing.T) {
+	if err := checkReplaceAllInput(export.Manifest{}); err != nil {
+		t.Errorf("full export: %v", err)
+	}
+	if err := checkReplaceAllInput(export.Manifest{Delta: true}); err == nil || !strings.Contains(err.Error(), "delta") {
+		t.Errorf("delta bundle: err = %v, want a delta error", err)
+	}
+	if err := checkReplaceAllInput(export.Manifest{Sample: 10}); err == nil || !strings.Contains(err.Error(), "--sample 10") {
+		t.Errorf("sampled bundle: err = %v, want a sample error", err)
+	}
+	if err := checkReplaceAllInput(export.Manifest{Anonymized: true}); err == nil || !strings.Contains(err.Error(), "anonymized") {
+		t.Errorf("anonymized bundle: err = %v, want an anonymized error", err)
+	}
+	err := checkReplaceAllInput(export.Manifest{Selection: []string{"--skip-entities", "--blueprints"}})
+	if err == nil || !strings.Contains(err.Error(), "--skip-entities, --blueprints") {
+		t.Errorf("partial bundle: err = %v, want an error naming the export flags", err)
+	}
+}
+
+func TestMergeDeletions(t *testing.T) {
+	listed := []export.Deletion{{Type: "blueprints", Identifier: "legacy"}}
+	got := mergeDeletions(listed, []export.Deletion{
+		{Type: "blueprints", Identifier: "legacy"},
+		{Type: "pages", Identifier: "stale"},
+	})
+	want := []export.Deletion{{Type: "blueprints", Identifier: "legacy"}, {Type: "pages", Identifier: "stale"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeDeletions = %v, want %v", got, want)
+	}
+}
+
+func TestModuleExecute_ReplaceAllRefusesPartialBundles(t *testing.T) {
+	for name, manifest := range map[string]string{
+		"anonymized": `{"anonymized": true}`,
+		"partial":    `{"anonymized": false, "selection": ["--skip-entities-for"]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			inputPath := filepath.Join(t.TempDir(), "export.json")
+			content := `{"_manifest": ` + manifest + `, "blueprints": [{"identifier": "service"}]}`
+			if err := os.WriteFile(inputPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("write input: %v", err)
+			}
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				http.NotFound(w, r)
+			}))
+			defer server.Close()
+
+			module := NewModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+			_, err := module.Execute(context.Background(), Options{InputPath: inputPath, ReplaceAll: true})
+			if err == nil || !strings.Contains(err.Error(), "--replace-all cannot be used") {
+				t.Fatalf("Execute error = %v, want --replace-all to be refused", err)
+			}
+			if requests > 0 {
+				t.Errorf("expected no API requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestModuleExecute_ReplaceAllDeclined(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(inputPath, []byte(`{"blueprints": [{"identifier": "service", "title": "Service"}]}`), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	var writes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.Method != http.MethodGet:
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case r.URL.Path == "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"blueprints": []map[string]interface{}{
+					{"identifier": "service", "title": "Old"},
+					{"identifier": "legacy", "title": "Legacy"},
+				},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	module := NewModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})
+	var asked []export.Deletion
+	_, err := module.Execute(context.Background(), Options{
+		InputPath:        inputPath,
+		IncludeResources: []string{"blueprints"},
+		ReplaceAll:       true,
+		ConfirmDeletions: func(deletions []export.Deletion) (bool, error) {
+			asked = deletions
+			return false, nil
+		},
+	})
+	if !errors.Is(err, ErrDeletionsDeclined) {
+		t.Fatalf("Execute error = %v, want ErrDeletionsDeclined", err)
+	}
+	if want := []export.Deletion{{Type: "blueprints", Identifier: "legacy"}}; !reflect.DeepEqual(asked, want) {
+		t.Errorf("confirmation asked for %v, want %v", asked, want)
+	}
+	if len(writes) > 0 {
+		t.Errorf("declined import still wrote: %v", writes)
+	}
+}
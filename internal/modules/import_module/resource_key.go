@@ -0,0 +1,33 @@
+package import_module
+
+import "github.com/port-experimental/port-cli/internal/api"
+
+// ResourceKey identifies a resource scoped to a blueprint, such as an entity
+// or a scorecard. Unlike a "blueprint:identifier" string, it cannot make two
+// resources collide when an identifier contains a colon.
+type ResourceKey struct {
+	Blueprint  string
+	Identifier string
+}
+
+// EntityKey returns the key of entity. ok is false when the entity lacks a
+// blueprint or an identifier.
+func EntityKey(entity api.Entity) (key ResourceKey, ok bool) {
+	bp, _ := entity["blueprint"].(string)
+	id, _ := entity["identifier"].(string)
+	return ResourceKey{Blueprint: bp, Identifier: id}, bp != "" && id != ""
+}
+
+// ScorecardKey returns the key of scorecard. ok is false when the scorecard
+// lacks a blueprint or an identifier.
+func ScorecardKey(scorecard api.Scorecard) (key ResourceKey, ok bool) {
+	bp, _ := scorecard["blueprintIdentifier"].(string)
+	id, _ := scorecard["identifier"].(string)
+	return ResourceKey{Blueprint: bp, Identifier: id}, bp != "" && id != ""
+}
+
+// entityKey returns the key of the entity with the given blueprint and
+// identifier.
+func entityKey(blueprintID, entityID string) ResourceKey {
+	return ResourceKey{Blueprint: blueprintID, Identifier: entityID}
+}
@@ -31,7 +31,10 @@ func (l *StreamLoader) LoadDataWithoutEntities(inputPath string) (*export.Data,
 	if strings.ToLower(filepath.Ext(inputPath)) == ".json" {
 		return l.loadJSONMetadata(inputPath)
 	}
-	return nil, fmt.Errorf("unsupported file format: %s (expected .json or .tar.gz)", filepath.Ext(inputPath))
+	if isNDJSONPath(inputPath) {
+		return l.loadNDJSONMetadata(inputPath)
+	}
+	return nil, fmt.Errorf("unsupported file format: %s (expected .json, .ndjson, or .tar.gz)", filepath.Ext(inputPath))
 }
 
 func (l *StreamLoader) ForEachEntity(inputPath string, yield func(api.Entity) error) error {
@@ -44,7 +47,10 @@ func (l *StreamLoader) ForEachEntity(inputPath string, yield func(api.Entity) er
 	if strings.ToLower(filepath.Ext(inputPath)) == ".json" {
 		return l.forEachJSONEntity(inputPath, yield)
 	}
-	return fmt.Errorf("unsupported file format: %s (expected .json or .tar.gz)", filepath.Ext(inputPath))
+	if isNDJSONPath(inputPath) {
+		return l.forEachNDJSONEntity(inputPath, yield)
+	}
+	return fmt.Errorf("unsupported file format: %s (expected .json, .ndjson, or .tar.gz)", filepath.Ext(inputPath))
 }
 
 func isTarPath(inputPath string) bool {
@@ -120,8 +126,11 @@ func (l *StreamLoader) loadJSONMetadata(jsonPath string) (*export.Data, error) {
 		if key == "entities" {
 			return skipJSONValue(dec)
 		}
-		return decodeDataSection(dec, key, data)
+		return decodeLocated(dec, func() error { return decodeDataSection(dec, key, data) })
 	}); err != nil {
+		return nil, jsonFileError(jsonPath, nil, err)
+	}
+	if err := resolveDataRefs(data, filepath.Dir(jsonPath)); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -161,12 +170,22 @@ func (l *StreamLoader) forEachJSONEntity(jsonPath string, yield func(api.Entity)
 	}
 	defer file.Close()
 	dec := json.NewDecoder(file)
-	return readJSONObject(dec, func(key string) error {
+	// Errors returned by yield are passed through as they are; only
+	// decoding errors are located in the file.
+	var yieldErr error
+	err = readJSONObject(dec, func(key string) error {
 		if key == "entities" {
-			return decodeEntityArray(dec, yield)
+			return decodeEntityArray(dec, func(entity api.Entity) error {
+				yieldErr = yield(entity)
+				return yieldErr
+			})
 		}
 		return skipJSONValue(dec)
 	})
+	if err != nil && err != yieldErr {
+		return jsonFileError(jsonPath, nil, err)
+	}
+	return err
 }
 
 func readJSONObject(dec *json.Decoder, handle func(string) error) error {
@@ -206,7 +225,7 @@ func decodeEntityArray(dec *json.Decoder, yield func(api.Entity) error) error {
 	}
 	for dec.More() {
 		var entity api.Entity
-		if err := dec.Decode(&entity); err != nil {
+		if err := decodeLocated(dec, func() error { return dec.Decode(&entity) }); err != nil {
 			return err
 		}
 		if err := yield(entity); err != nil {
@@ -242,12 +261,18 @@ func decodeDataSection(dec *json.Decoder, key string, data *export.Data) error {
 		return dec.Decode(&data.Folders)
 	case "integrations":
 		return dec.Decode(&data.Integrations)
+	case "datasources":
+		return dec.Decode(&data.DataSources)
 	case "BlueprintPermissions", "blueprint_permissions":
 		return dec.Decode(&data.BlueprintPermissions)
 	case "ActionPermissions", "action_permissions":
 		return dec.Decode(&data.ActionPermissions)
 	case "PagePermissions", "page_permissions":
 		return dec.Decode(&data.PagePermissions)
+	case export.ManifestResource:
+		return dec.Decode(&data.Manifest)
+	case export.DeletionsResource:
+		return dec.Decode(&data.Deletions)
 	default:
 		return skipJSONValue(dec)
 	}
@@ -0,0 +1,170 @@
+package import_module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/port-experimental/port-cli/internal/api"
+)
+
+// teamMembersField is the team field holding its members.
+const teamMembersField = "users"
+
+// TeamMembershipChange lists the members to add to and remove from one team.
+type TeamMembershipChange struct {
+	Team   string
+	Add    []string
+	Remove []string
+}
+
+// TeamMembers returns the member emails of team. ok is false when the team has
+// no member list at all, which is different from an empty one: a team exported
+// without members must not remove everyone from the target team.
+func TeamMembers(team api.Team) (members []string, ok bool) {
+	raw, ok := team[teamMembersField].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, item := range raw {
+		switch v := item.(type) {
+		case string:
+			members = append(members, v)
+		case map[string]interface{}:
+			if email, _ := v["email"].(string); email != "" {
+				members = append(members, email)
+			}
+		}
+	}
+	return members, true
+}
+
+// WithoutTeamMembers returns a copy of team without its member list. Members
+// are synced through the team membership endpoints instead of the team
+// create/update payload.
+func WithoutTeamMembers(team api.Team) api.Team {
+	result := make(api.Team, len(team))
+	for k, v := range team {
+		if k != teamMembersField {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// CompareTeamMemberships returns the membership changes needed to make each
+// current team's members match the imported team. Teams whose import carries
+// no member list are left alone.
+func CompareTeamMemberships(importTeams, currentTeams []api.Team) []TeamMembershipChange {
+	currentMembers := make(map[string]map[string]bool)
+	for _, team := range currentTeams {
+		name, _ := team["name"].(string)
+		if name == "" {
+			continue
+		}
+		members, _ := TeamMembers(team)
+		set := make(map[string]bool, len(members))
+		for _, m := range members {
+			set[m] = true
+		}
+		currentMembers[name] = set
+	}
+
+	var changes []TeamMembershipChange
+	for _, team := range importTeams {
+		name, _ := team["name"].(string)
+		if name == "" {
+			continue
+		}
+		members, ok := TeamMembers(team)
+		if !ok {
+			continue
+		}
+		current := currentMembers[name]
+		desired := make(map[string]bool, len(members))
+		change := TeamMembershipChange{Team: name}
+		for _, m := range members {
+			desired[m] = true
+			if !current[m] {
+				change.Add = append(change.Add, m)
+			}
+		}
+		for m := range current {
+			if !desired[m] {
+				change.Remove = append(change.Remove, m)
+			}
+		}
+		if len(change.Add) == 0 && len(change.Remove) == 0 {
+			continue
+		}
+		sort.Strings(change.Add)
+		sort.Strings(change.Remove)
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(a, b int) bool { return changes[a].Team < changes[b].Team })
+	return changes
+}
+
+// CountTeamMembershipChanges returns the total members to add and remove.
+func CountTeamMembershipChanges(changes []TeamMembershipChange) (added, removed int) {
+	for _, change := range changes {
+		added += len(change.Add)
+		removed += len(change.Remove)
+	}
+	return added, removed
+}
+
+// WithoutTeams returns changes without those of the teams in skip, such as
+// teams whose create or update failed.
+func WithoutTeams(changes []TeamMembershipChange, skip map[string]bool) []TeamMembershipChange {
+	if len(skip) == 0 {
+		return changes
+	}
+	kept := make([]TeamMembershipChange, 0, len(changes))
+	for _, change := range changes {
+		if !skip[change.Team] {
+			kept = append(kept, change)
+		}
+	}
+	return kept
+}
+
+// SyncTeamMembers applies one membership change and returns how many members
+// were added and removed.
+func SyncTeamMembers(ctx context.Context, client *api.Client, change TeamMembershipChange) (added, removed int, err error) {
+	if len(change.Add) > 0 {
+		if err := client.AddTeamMembers(ctx, change.Team, change.Add); err != nil {
+			return 0, 0, fmt.Errorf("failed to add members to team %s: %w", change.Team, err)
+		}
+		added = len(change.Add)
+	}
+	if len(change.Remove) > 0 {
+		if err := client.RemoveTeamMembers(ctx, change.Team, change.Remove); err != nil {
+			return added, 0, fmt.Errorf("failed to remove members from team %s: %w", change.Team, err)
+		}
+		removed = len(change.Remove)
+	}
+	return added, removed, nil
+}
+
+// importTeamMemberships applies the team membership changes from a DiffResult.
+// It runs after teams and users are imported so both sides of each membership
+// exist. Teams that failed to import are left alone.
+func (i *Importer) importTeamMemberships(ctx context.Context, diff *DiffResult) (added, removed int) {
+	if diff == nil {
+		return 0, 0
+	}
+	failed := make(map[string]bool)
+	for _, e := range i.errors.GetByResource("team") {
+		failed[e.ResourceID] = true
+	}
+	for _, change := range WithoutTeams(diff.TeamMemberships, failed) {
+		a, r, err := SyncTeamMembers(ctx, i.client, change)
+		added += a
+		removed += r
+		if err != nil {
+			i.errors.Add(err, "team_members", change.Team)
+		}
+	}
+	return added, removed
+}
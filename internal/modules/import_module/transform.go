@@ -0,0 +1,167 @@
+package import_module
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"gopkg.in/yaml.v3"
+)
+
+// Transform operations supported by TransformRule.
+const (
+	TransformSet    = "set"
+	TransformRemove = "remove"
+	TransformRename = "rename"
+)
+
+// TransformRule rewrites one field of matching resources before they are
+// diffed and imported. Paths are dot-separated keys, optionally prefixed with
+// "$." (e.g. "$.properties.url").
+type TransformRule struct {
+	Op        string      `yaml:"op"`
+	Resource  string      `yaml:"resource,omitempty"`  // "entities" or "blueprints"; empty matches both
+	Blueprint string      `yaml:"blueprint,omitempty"` // only resources of this blueprint
+	Path      string      `yaml:"path"`
+	To        string      `yaml:"to,omitempty"`    // destination path for rename
+	Value     interface{} `yaml:"value,omitempty"` // new value for set
+}
+
+type transformFile struct {
+	Rules []TransformRule `yaml:"rules"`
+}
+
+// LoadTransforms reads transform rules from a YAML or JSON file of the form
+// {"rules": [...]}.
+func LoadTransforms(path string) ([]TransformRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transform file: %w", err)
+	}
+	var file transformFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse transform file: %w", err)
+	}
+	for i, rule := range file.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid transform rule %d: %w", i+1, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+func (r TransformRule) validate() error {
+	switch r.Op {
+	case TransformSet, TransformRemove:
+	case TransformRename:
+		if len(splitTransformPath(r.To)) == 0 {
+			return fmt.Errorf("rename requires a \"to\" path")
+		}
+	default:
+		return fmt.Errorf("unknown op %q (expected set, remove, or rename)", r.Op)
+	}
+	if r.Resource != "" && r.Resource != "entities" && r.Resource != "blueprints" {
+		return fmt.Errorf("unknown resource %q (expected entities or blueprints)", r.Resource)
+	}
+	if len(splitTransformPath(r.Path)) == 0 {
+		return fmt.Errorf("path is required")
+	}
+	return nil
+}
+
+// ApplyTransforms applies every rule matching resourceType ("entities" or
+// "blueprints") to resource in order, modifying it in place.
+func ApplyTransforms(rules []TransformRule, resourceType string, resource map[string]interface{}) {
+	for _, rule := range rules {
+		if !rule.matches(resourceType, resource) {
+			continue
+		}
+		path := splitTransformPath(rule.Path)
+		switch rule.Op {
+		case TransformSet:
+			setPath(resource, path, rule.Value)
+		case TransformRemove:
+			removePath(resource, path)
+		case TransformRename:
+			if v, ok := getPath(resource, path); ok {
+				removePath(resource, path)
+				setPath(resource, splitTransformPath(rule.To), v)
+			}
+		}
+	}
+}
+
+// applyTransformsToData applies rules to the blueprints and entities in data.
+func applyTransformsToData(data *export.Data, rules []TransformRule) {
+	if len(rules) == 0 {
+		return
+	}
+	for _, bp := range data.Blueprints {
+		ApplyTransforms(rules, "blueprints", bp)
+	}
+	for _, entity := range data.Entities {
+		ApplyTransforms(rules, "entities", entity)
+	}
+}
+
+func (r TransformRule) matches(resourceType string, resource map[string]interface{}) bool {
+	if r.Resource != "" && r.Resource != resourceType {
+		return false
+	}
+	if r.Blueprint == "" {
+		return true
+	}
+	key := "blueprint"
+	if resourceType == "blueprints" {
+		key = "identifier"
+	}
+	bpID, _ := resource[key].(string)
+	return bpID == r.Blueprint
+}
+
+func splitTransformPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+func getPath(resource map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = resource
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath sets the value at path, creating intermediate objects as needed.
+func setPath(resource map[string]interface{}, path []string, value interface{}) {
+	current := resource
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+func removePath(resource map[string]interface{}, path []string) {
+	parent, ok := getPath(resource, path[:len(path)-1])
+	if !ok {
+		return
+	}
+	if m, ok := parent.(map[string]interface{}); ok {
+		delete(m, path[len(path)-1])
+	}
+}
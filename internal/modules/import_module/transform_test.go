@@ -0,0 +1,127 @@
+package import_module
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyTransforms_SetNestedPath(t *testing.T) {
+	entity := map[string]interface{}{
+		"identifier": "svc",
+		"blueprint":  "service",
+		"properties": map[string]interface{}{"url": "https://old.example.com"},
+	}
+	rules := []TransformRule{
+		{Op: TransformSet, Path: "$.properties.url", Value: "https://new.example.com"},
+		{Op: TransformSet, Path: "properties.links.docs", Value: "https://docs.example.com"},
+	}
+
+	ApplyTransforms(rules, "entities", entity)
+
+	props := entity["properties"].(map[string]interface{})
+	if props["url"] != "https://new.example.com" {
+		t.Fatalf("expected url to be set, got %v", props["url"])
+	}
+	links, ok := props["links"].(map[string]interface{})
+	if !ok || links["docs"] != "https://docs.example.com" {
+		t.Fatalf("expected intermediate object to be created, got %v", props["links"])
+	}
+}
+
+func TestApplyTransforms_RemoveNestedPath(t *testing.T) {
+	entity := map[string]interface{}{
+		"properties": map[string]interface{}{"deprecated": true, "keep": 1},
+	}
+	rules := []TransformRule{
+		{Op: TransformRemove, Path: "properties.deprecated"},
+		{Op: TransformRemove, Path: "properties.missing.deeper"},
+	}
+
+	ApplyTransforms(rules, "entities", entity)
+
+	want := map[string]interface{}{"properties": map[string]interface{}{"keep": 1}}
+	if !reflect.DeepEqual(entity, want) {
+		t.Fatalf("got %v, want %v", entity, want)
+	}
+}
+
+func TestApplyTransforms_RenameNestedPath(t *testing.T) {
+	bp := map[string]interface{}{
+		"identifier": "service",
+		"schema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"old_name": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	rules := []TransformRule{
+		{Op: TransformRename, Resource: "blueprints", Path: "schema.properties.old_name", To: "schema.properties.new_name"},
+		{Op: TransformRename, Resource: "blueprints", Path: "schema.properties.absent", To: "schema.properties.other"},
+	}
+
+	ApplyTransforms(rules, "blueprints", bp)
+
+	props := bp["schema"].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := props["old_name"]; ok {
+		t.Fatal("expected old_name to be removed")
+	}
+	if !reflect.DeepEqual(props["new_name"], map[string]interface{}{"type": "string"}) {
+		t.Fatalf("expected value moved to new_name, got %v", props["new_name"])
+	}
+	if _, ok := props["other"]; ok {
+		t.Fatal("renaming a missing path must not create the destination")
+	}
+}
+
+func TestApplyTransforms_FiltersByResourceAndBlueprint(t *testing.T) {
+	svc := map[string]interface{}{"blueprint": "service", "title": "a"}
+	team := map[string]interface{}{"blueprint": "team", "title": "b"}
+	bp := map[string]interface{}{"identifier": "service", "title": "c"}
+	rules := []TransformRule{
+		{Op: TransformSet, Resource: "entities", Blueprint: "service", Path: "title", Value: "changed"},
+	}
+
+	ApplyTransforms(rules, "entities", svc)
+	ApplyTransforms(rules, "entities", team)
+	ApplyTransforms(rules, "blueprints", bp)
+
+	if svc["title"] != "changed" || team["title"] != "b" || bp["title"] != "c" {
+		t.Fatalf("unexpected results: svc=%v team=%v bp=%v", svc["title"], team["title"], bp["title"])
+	}
+}
+
+func TestLoadTransforms(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transforms.yaml")
+	content := `rules:
+  - op: set
+    path: properties.tier
+    value: gold
+  - op: rename
+    resource: entities
+    path: properties.owner
+    to: properties.team
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := LoadTransforms(path)
+	if err != nil {
+		t.Fatalf("LoadTransforms: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Value != "gold" || rules[1].To != "properties.team" {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"rules":[{"op":"rename","path":"a"}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTransforms(bad); err == nil || !strings.Contains(err.Error(), "rule 1") {
+		t.Fatalf("expected rename without destination to be rejected, got %v", err)
+	}
+}
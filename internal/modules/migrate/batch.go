@@ -0,0 +1,102 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/auth"
+	"github.com/port-experimental/port-cli/internal/config"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultParallelOrgs is the default number of target organizations migrated
+// concurrently by ExecuteBatch.
+const DefaultParallelOrgs = 3
+
+// Target identifies one target organization of a batch migration.
+type Target struct {
+	Org    string
+	Token  *auth.Token
+	Config *config.OrganizationConfig
+}
+
+// TargetResult is the outcome of migrating into one target organization.
+// Err is set when the migration into that target stopped with an error;
+// Result may still hold partial counts in that case.
+type TargetResult struct {
+	Org    string
+	Result *Result
+	Err    error
+}
+
+// Failed reports whether the migration into this target did not fully succeed.
+func (r TargetResult) Failed() bool {
+	return r.Err != nil || r.Result == nil || !r.Result.Success
+}
+
+// NewSourceModule creates a migration module with only a source client.
+// Use WithTarget or ExecuteBatch to migrate into target organizations.
+func NewSourceModule(sourceToken *auth.Token, sourceConfig *config.OrganizationConfig) *Module {
+	return &Module{sourceClient: newOrgClient(sourceToken, sourceConfig)}
+}
+
+// WithTarget returns a module that shares m's source client, resource
+// callback and timings but migrates into the given target organization.
+func (m *Module) WithTarget(targetToken *auth.Token, targetConfig *config.OrganizationConfig) *Module {
+	return &Module{
+		sourceClient: m.sourceClient,
+		targetClient: newOrgClient(targetToken, targetConfig),
+		onResource:   m.onResource,
+		timings:      m.timings,
+	}
+}
+
+// newOrgClient creates the API client for the source or a target organization.
+func newOrgClient(token *auth.Token, orgConfig *config.OrganizationConfig) *api.Client {
+	return api.NewClient(api.ClientOpts{
+		Token:        token,
+		ClientID:     orgConfig.ClientID,
+		ClientSecret: orgConfig.ClientSecret,
+		APIURL:       orgConfig.APIURL,
+		RateLimit:    orgConfig.RateLimit,
+		Concurrency:  orgConfig.Concurrency,
+		Headers:      orgConfig.Headers,
+		APIVersion:   orgConfig.APIVersion,
+		CacheTTL:     orgConfig.CacheTTL,
+		NoCache:      orgConfig.NoCache,
+		Timeout:      0,
+	})
+}
+
+// ExecuteBatch exports the source once and migrates it into every target,
+// running at most parallel targets at a time. A failing target does not stop
+// the others; results are returned in the same order as targets.
+func (m *Module) ExecuteBatch(ctx context.Context, targets []Target, parallel int, opts Options) ([]TargetResult, error) {
+	source, err := m.ExportSource(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if parallel <= 0 {
+		parallel = DefaultParallelOrgs
+	}
+
+	results := make([]TargetResult, len(targets))
+	g := new(errgroup.Group)
+	g.SetLimit(parallel)
+	for i, target := range targets {
+		g.Go(func() error {
+			targetModule := m.WithTarget(target.Token, target.Config)
+			defer targetModule.targetClient.Close()
+
+			result, err := targetModule.ExecuteFromSource(ctx, source, opts)
+			results[i] = TargetResult{Org: target.Org, Result: result, Err: err}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,115 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/metrics"
+)
+
+func TestExecuteBatch_ExportsSourceOnceAndReportsPerTarget(t *testing.T) {
+	var sourceBlueprintFetches int32
+	sourceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			atomic.AddInt32(&sourceBlueprintFetches, 1)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service", "title": "Service"}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer sourceServer.Close()
+
+	newTarget := func(failBlueprints bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/auth/access_token":
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+			case "/blueprints":
+				if failBlueprints {
+					w.WriteHeader(http.StatusForbidden)
+					json.NewEncoder(w).Encode(map[string]interface{}{"ok": false})
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []map[string]interface{}{}})
+			default:
+				json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+			}
+		}))
+	}
+	okServer := newTarget(false)
+	defer okServer.Close()
+	failServer := newTarget(true)
+	defer failServer.Close()
+
+	m := &Module{
+		sourceClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: sourceServer.URL}),
+	}
+	targets := []Target{
+		{Org: "tenant-a", Config: &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: okServer.URL}},
+		{Org: "tenant-b", Config: &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: failServer.URL}},
+		{Org: "tenant-c", Config: &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: okServer.URL}},
+	}
+
+	results, err := m.ExecuteBatch(context.Background(), targets, 2, Options{
+		DryRun:           true,
+		IncludeResources: []string{"blueprints"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&sourceBlueprintFetches); got != 1 {
+		t.Errorf("expected source to be exported once, got %d blueprint fetches", got)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, want := range []string{"tenant-a", "tenant-b", "tenant-c"} {
+		if results[i].Org != want {
+			t.Errorf("result %d: expected org %s, got %s", i, want, results[i].Org)
+		}
+	}
+	if results[0].Failed() || results[2].Failed() {
+		t.Errorf("expected tenant-a and tenant-c to succeed, got %+v / %+v", results[0], results[2])
+	}
+	if !results[1].Failed() {
+		t.Error("expected tenant-b to fail")
+	}
+	if results[0].Result.BlueprintsCreated != 1 {
+		t.Errorf("expected 1 blueprint to create in tenant-a, got %d", results[0].Result.BlueprintsCreated)
+	}
+}
+
+func TestWithTarget_KeepsResourceCallbackAndTimings(t *testing.T) {
+	var reported []string
+	m := NewSourceModule(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: "http://127.0.0.1:0"})
+	defer m.Close()
+	m.SetResourceCallback(func(action, resourceType, identifier string) {
+		reported = append(reported, action+" "+resourceType+" "+identifier)
+	})
+
+	timings := metrics.New()
+	m.SetTimings(timings)
+
+	target := m.WithTarget(nil, &config.OrganizationConfig{ClientID: "id", ClientSecret: "secret", APIURL: "http://127.0.0.1:0"})
+	defer target.targetClient.Close()
+	target.reportResource("created", "blueprint", "service")
+	if target.timings != timings {
+		t.Error("expected the target module to record into the source module's timings")
+	}
+
+	if len(reported) != 1 || reported[0] != "created blueprint service" {
+		t.Fatalf("reported = %v, want the source module's callback to be used", reported)
+	}
+}
@@ -0,0 +1,364 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+	"gopkg.in/yaml.v3"
+)
+
+// MappingFile is the YAML file passed to migrate --map-file and checked by
+// port validate-mapping. It keeps every source -> target mapping of a
+// migration in one place. All sections are optional:
+//
+//	blueprints:        # source blueprint identifier -> target identifier
+//	  service: microservice
+//	entities:          # "<blueprint>/<identifier>" -> target identifier
+//	  service/payments: payments-api
+//	teams:             # source team name -> target team name, like --team-map
+//	  platform: platform-eng
+//	action_urls:       # invocation value -> replacement, like --action-url-map
+//	  hooks.prod.example.com: hooks.staging.example.com
+//	strip_properties:  # blueprint identifier -> properties left out
+//	  service: [internal_notes, cost_center]
+//
+// Two blueprints, or two entities of one blueprint, may not be mapped to the
+// same identifier. Several teams may be merged into one.
+type MappingFile struct {
+	Blueprints      map[string]string   `yaml:"blueprints"`
+	Entities        map[string]string   `yaml:"entities"`
+	Teams           map[string]string   `yaml:"teams"`
+	ActionURLs      map[string]string   `yaml:"action_urls"`
+	StripProperties map[string][]string `yaml:"strip_properties"`
+}
+
+// mappingSections lists the sections of a MappingFile in file order.
+var mappingSections = []string{"blueprints", "entities", "teams", "action_urls", "strip_properties"}
+
+// MappingIssue is a problem found in a mapping file. Line is 1-based.
+type MappingIssue struct {
+	Line    int    `json:"line"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (i MappingIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Path, i.Message)
+}
+
+// MappingError is returned by LoadMappingFile for a file with issues.
+type MappingError struct {
+	Path   string
+	Issues []MappingIssue
+}
+
+func (e *MappingError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return fmt.Sprintf("invalid mapping file %s:\n  %s", e.Path, strings.Join(lines, "\n  "))
+}
+
+// LoadMappingFile reads and validates the mapping file at path. A file with
+// issues returns a *MappingError listing all of them.
+func LoadMappingFile(path string) (*MappingFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	mapping, issues, err := ParseMappingFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+	if len(issues) > 0 {
+		return nil, &MappingError{Path: path, Issues: issues}
+	}
+	return mapping, nil
+}
+
+// ParseMappingFile validates data as a mapping file and decodes it. Every
+// issue is returned, rather than the first; the mapping is nil when there are
+// any. err is set only when data is not YAML at all.
+func ParseMappingFile(data []byte) (*MappingFile, []MappingIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &MappingFile{}, nil, nil
+	}
+	root := doc.Content[0]
+	issues := validateMappingRoot(root)
+	if len(issues) > 0 {
+		return nil, issues, nil
+	}
+	var mapping MappingFile
+	if err := root.Decode(&mapping); err != nil {
+		return nil, nil, err
+	}
+	return &mapping, nil, nil
+}
+
+func validateMappingRoot(root *yaml.Node) []MappingIssue {
+	if isNullNode(root) {
+		return nil
+	}
+	if root.Kind != yaml.MappingNode {
+		return []MappingIssue{{Line: root.Line, Message: "expected a mapping of sections (" + strings.Join(mappingSections, ", ") + ")"}}
+	}
+	var issues []MappingIssue
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		section := key.Value
+		switch {
+		case seen[section]:
+			issues = append(issues, MappingIssue{Line: key.Line, Path: section, Message: "duplicate section"})
+			continue
+		case !isMappingSection(section):
+			issues = append(issues, MappingIssue{Line: key.Line, Path: section, Message: "unknown section, expected one of " + strings.Join(mappingSections, ", ")})
+			continue
+		}
+		seen[section] = true
+		if isNullNode(value) {
+			continue
+		}
+		if value.Kind != yaml.MappingNode {
+			issues = append(issues, MappingIssue{Line: value.Line, Path: section, Message: "expected a mapping"})
+			continue
+		}
+		if section == "strip_properties" {
+			issues = append(issues, validateStripProperties(value)...)
+		} else {
+			issues = append(issues, validateRenames(section, value)...)
+		}
+	}
+	return issues
+}
+
+func isMappingSection(name string) bool {
+	for _, section := range mappingSections {
+		if section == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// validateRenames checks a section of source -> target strings. In the
+// blueprints and entities sections, targets must be unique, as two resources
+// cannot share an identifier.
+func validateRenames(section string, mapping *yaml.Node) []MappingIssue {
+	var issues []MappingIssue
+	seen := make(map[string]bool)
+	sourceOf := make(map[string]string)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		path := section + "." + key.Value
+		if key.Kind != yaml.ScalarNode || strings.TrimSpace(key.Value) == "" {
+			issues = append(issues, MappingIssue{Line: key.Line, Path: section, Message: "expected a non-empty source name"})
+			continue
+		}
+		if seen[key.Value] {
+			issues = append(issues, MappingIssue{Line: key.Line, Path: path, Message: "mapped more than once"})
+			continue
+		}
+		seen[key.Value] = true
+		if value.Kind != yaml.ScalarNode || isNullNode(value) || strings.TrimSpace(value.Value) == "" {
+			issues = append(issues, MappingIssue{Line: value.Line, Path: path, Message: "expected a non-empty target name"})
+			continue
+		}
+
+		target := value.Value
+		switch section {
+		case "entities":
+			blueprint, identifier, ok := strings.Cut(key.Value, "/")
+			if !ok || blueprint == "" || identifier == "" {
+				issues = append(issues, MappingIssue{Line: key.Line, Path: path, Message: `expected "<blueprint>/<identifier>"`})
+				continue
+			}
+			target = blueprint + "/" + value.Value
+		case "teams", "action_urls":
+			continue
+		}
+		if other, dup := sourceOf[target]; dup {
+			issues = append(issues, MappingIssue{Line: value.Line, Path: path, Message: fmt.Sprintf("%q is also the target of %s", value.Value, other)})
+			continue
+		}
+		sourceOf[target] = key.Value
+	}
+	return issues
+}
+
+// validateStripProperties checks a section of blueprint -> property lists.
+func validateStripProperties(mapping *yaml.Node) []MappingIssue {
+	var issues []MappingIssue
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		path := "strip_properties." + key.Value
+		if key.Kind != yaml.ScalarNode || strings.TrimSpace(key.Value) == "" {
+			issues = append(issues, MappingIssue{Line: key.Line, Path: "strip_properties", Message: "expected a non-empty blueprint identifier"})
+			continue
+		}
+		if seen[key.Value] {
+			issues = append(issues, MappingIssue{Line: key.Line, Path: path, Message: "listed more than once"})
+			continue
+		}
+		seen[key.Value] = true
+		if value.Kind != yaml.SequenceNode {
+			issues = append(issues, MappingIssue{Line: value.Line, Path: path, Message: "expected a list of property names"})
+			continue
+		}
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode || isNullNode(item) || strings.TrimSpace(item.Value) == "" {
+				issues = append(issues, MappingIssue{Line: item.Line, Path: path, Message: "expected a non-empty property name"})
+			}
+		}
+	}
+	return issues
+}
+
+// Summary describes the mappings in m, one "<count> <section>" part per
+// non-empty section.
+func (m *MappingFile) Summary() []string {
+	counts := []struct {
+		name  string
+		count int
+	}{
+		{"blueprint rename(s)", len(m.Blueprints)},
+		{"entity rename(s)", len(m.Entities)},
+		{"team mapping(s)", len(m.Teams)},
+		{"action URL mapping(s)", len(m.ActionURLs)},
+		{"blueprint(s) with stripped properties", len(m.StripProperties)},
+	}
+	var parts []string
+	for _, c := range counts {
+		if c.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.count, c.name))
+		}
+	}
+	return parts
+}
+
+// stripProperties returns a copy of data without the properties strip lists
+// for each blueprint: they are removed from the blueprint's schema, its
+// required list and computed properties, and from its entities. data itself
+// is left untouched, like remapTeams.
+func stripProperties(data *export.Data, strip map[string][]string) *export.Data {
+	if len(strip) == 0 || data == nil {
+		return data
+	}
+	stripped := *data
+	stripped.Blueprints = make([]api.Blueprint, len(data.Blueprints))
+	for i, bp := range data.Blueprints {
+		stripped.Blueprints[i] = stripBlueprintProperties(bp, strip)
+	}
+	stripped.Entities = make([]api.Entity, len(data.Entities))
+	for i, entity := range data.Entities {
+		bpID, _ := entity["blueprint"].(string)
+		stripped.Entities[i] = stripEntityProperties(entity, stringSet(strip[bpID]))
+	}
+	return &stripped
+}
+
+func stripBlueprintProperties(bp api.Blueprint, strip map[string][]string) api.Blueprint {
+	bpID, _ := bp["identifier"].(string)
+	names := stringSet(strip[bpID])
+	if len(names) == 0 {
+		return bp
+	}
+	out := make(api.Blueprint, len(bp))
+	for k, v := range bp {
+		out[k] = v
+	}
+	if schema, ok := bp["schema"].(map[string]interface{}); ok {
+		newSchema := make(map[string]interface{}, len(schema))
+		for k, v := range schema {
+			newSchema[k] = v
+		}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			newSchema["properties"] = withoutKeys(properties, names)
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			kept := make([]interface{}, 0, len(required))
+			for _, name := range required {
+				if s, _ := name.(string); !names[s] {
+					kept = append(kept, name)
+				}
+			}
+			newSchema["required"] = kept
+		}
+		out["schema"] = newSchema
+	}
+	for _, field := range []string{"mirrorProperties", "calculationProperties", "aggregationProperties"} {
+		if properties, ok := bp[field].(map[string]interface{}); ok {
+			out[field] = withoutKeys(properties, names)
+		}
+	}
+	return out
+}
+
+// stripEntityProperties returns entity without the properties in names. The
+// entity is copied only when it has properties.
+func stripEntityProperties(entity api.Entity, names map[string]bool) api.Entity {
+	properties, ok := entity["properties"].(map[string]interface{})
+	if len(names) == 0 || !ok {
+		return entity
+	}
+	out := make(api.Entity, len(entity))
+	for k, v := range entity {
+		out[k] = v
+	}
+	out["properties"] = withoutKeys(properties, names)
+	return out
+}
+
+// stripIteratorProperties strips the properties of blueprint bpID listed in
+// strip from every entity iter yields.
+func stripIteratorProperties(iter entitystream.PageIterator, bpID string, strip map[string][]string) entitystream.PageIterator {
+	names := stringSet(strip[bpID])
+	if len(names) == 0 {
+		return iter
+	}
+	return func(ctx context.Context, yield func([]api.Entity) error) error {
+		return iter(ctx, func(page []api.Entity) error {
+			stripped := make([]api.Entity, len(page))
+			for i, entity := range page {
+				stripped[i] = stripEntityProperties(entity, names)
+			}
+			return yield(stripped)
+		})
+	}
+}
+
+func withoutKeys(m map[string]interface{}, names map[string]bool) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if !names[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
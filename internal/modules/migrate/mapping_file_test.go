@@ -0,0 +1,166 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestParseMappingFile_Valid(t *testing.T) {
+	mapping, issues, err := ParseMappingFile([]byte(`
+blueprints:
+  service: microservice
+entities:
+  service/payments: payments-api
+  team/payments: payments-api
+teams:
+  platform: platform-eng
+  infra: platform-eng
+action_urls:
+  hooks.prod.example.com: hooks.staging.example.com
+strip_properties:
+  service: [internal_notes, cost_center]
+`))
+	if err != nil || len(issues) > 0 {
+		t.Fatalf("unexpected err %v, issues %v", err, issues)
+	}
+	want := &MappingFile{
+		Blueprints:      map[string]string{"service": "microservice"},
+		Entities:        map[string]string{"service/payments": "payments-api", "team/payments": "payments-api"},
+		Teams:           map[string]string{"platform": "platform-eng", "infra": "platform-eng"},
+		ActionURLs:      map[string]string{"hooks.prod.example.com": "hooks.staging.example.com"},
+		StripProperties: map[string][]string{"service": {"internal_notes", "cost_center"}},
+	}
+	if !reflect.DeepEqual(mapping, want) {
+		t.Fatalf("mapping = %+v, want %+v", mapping, want)
+	}
+}
+
+func TestParseMappingFile_Empty(t *testing.T) {
+	for _, data := range []string{"", "# nothing yet\n", "teams:\n"} {
+		mapping, issues, err := ParseMappingFile([]byte(data))
+		if err != nil || len(issues) > 0 || mapping == nil {
+			t.Errorf("%q: got %v, %v, %v", data, mapping, issues, err)
+		}
+	}
+}
+
+func TestParseMappingFile_Issues(t *testing.T) {
+	_, issues, err := ParseMappingFile([]byte(`blueprints:
+  a: x
+  b: x
+entities:
+  payments: y
+teams:
+  platform: ""
+teams: {}
+action_urls: [a, b]
+strip_properties:
+  service: internal
+  domain: [ok, ""]
+typo: {}
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`line 3: blueprints.b: "x" is also the target of a`,
+		`line 5: entities.payments: expected "<blueprint>/<identifier>"`,
+		`line 7: teams.platform: expected a non-empty target name`,
+		`line 8: teams: duplicate section`,
+		`line 9: action_urls: expected a mapping`,
+		`line 11: strip_properties.service: expected a list of property names`,
+		`line 12: strip_properties.domain: expected a non-empty property name`,
+		`line 13: typo: unknown section, expected one of blueprints, entities, teams, action_urls, strip_properties`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseMappingFile_NotAMapping(t *testing.T) {
+	_, issues, err := ParseMappingFile([]byte("- teams\n"))
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("expected one issue, got %v, %v", issues, err)
+	}
+	if _, _, err := ParseMappingFile([]byte("teams: [\n")); err == nil {
+		t.Fatal("expected a YAML syntax error")
+	}
+}
+
+func TestLoadMappingFile_ReturnsMappingError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	if err := os.WriteFile(path, []byte("unknown: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadMappingFile(path)
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) || len(mappingErr.Issues) != 1 {
+		t.Fatalf("expected a *MappingError with one issue, got %v", err)
+	}
+}
+
+func TestStripProperties(t *testing.T) {
+	data := &export.Data{
+		Blueprints: []api.Blueprint{
+			{
+				"identifier": "service",
+				"schema": map[string]interface{}{
+					"properties": map[string]interface{}{"language": map[string]interface{}{}, "notes": map[string]interface{}{}},
+					"required":   []interface{}{"language", "notes"},
+				},
+				"calculationProperties": map[string]interface{}{"notes": map[string]interface{}{}, "score": map[string]interface{}{}},
+			},
+			{"identifier": "domain", "schema": map[string]interface{}{"properties": map[string]interface{}{"notes": map[string]interface{}{}}}},
+		},
+		Entities: []api.Entity{
+			{"blueprint": "service", "identifier": "payments", "properties": map[string]interface{}{"language": "go", "notes": "x"}},
+			{"blueprint": "domain", "identifier": "billing", "properties": map[string]interface{}{"notes": "y"}},
+		},
+	}
+	stripped := stripProperties(data, map[string][]string{"service": {"notes"}})
+
+	schema := stripped.Blueprints[0]["schema"].(map[string]interface{})
+	if _, ok := schema["properties"].(map[string]interface{})["notes"]; ok {
+		t.Error("notes should be removed from the service schema")
+	}
+	if !reflect.DeepEqual(schema["required"], []interface{}{"language"}) {
+		t.Errorf("required = %v, want [language]", schema["required"])
+	}
+	if _, ok := stripped.Blueprints[0]["calculationProperties"].(map[string]interface{})["notes"]; ok {
+		t.Error("notes should be removed from the calculation properties")
+	}
+	if _, ok := stripped.Entities[0]["properties"].(map[string]interface{})["notes"]; ok {
+		t.Error("notes should be removed from service entities")
+	}
+	if _, ok := stripped.Entities[1]["properties"].(map[string]interface{})["notes"]; !ok {
+		t.Error("other blueprints keep their properties")
+	}
+	if _, ok := data.Entities[0]["properties"].(map[string]interface{})["notes"]; !ok {
+		t.Error("the source data must be left untouched")
+	}
+
+	iter := stripIteratorProperties(func(ctx context.Context, yield func([]api.Entity) error) error {
+		return yield([]api.Entity{{"identifier": "payments", "properties": map[string]interface{}{"notes": "x", "language": "go"}}})
+	}, "service", map[string][]string{"service": {"notes"}})
+	err := iter(context.Background(), func(page []api.Entity) error {
+		if !reflect.DeepEqual(page[0]["properties"], map[string]interface{}{"language": "go"}) {
+			t.Errorf("streamed entity properties = %v", page[0]["properties"])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
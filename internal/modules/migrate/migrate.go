@@ -2,7 +2,9 @@ package migrate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"github.com/port-experimental/port-cli/internal/api"
 	"github.com/port-experimental/port-cli/internal/auth"
 	"github.com/port-experimental/port-cli/internal/config"
+	"github.com/port-experimental/port-cli/internal/logging"
+	"github.com/port-experimental/port-cli/internal/metrics"
 	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
 	"github.com/port-experimental/port-cli/internal/modules/export"
 	"github.com/port-experimental/port-cli/internal/modules/import_module"
@@ -49,40 +53,100 @@ func stripBlueprintSystemFields(bp api.Blueprint) api.Blueprint {
 type Module struct {
 	sourceClient *api.Client
 	targetClient *api.Client
+	onResource   import_module.ResourceCallback
+	timings      *metrics.Timings
+}
+
+// SetTimings sets the recorder that phase durations are reported to.
+func (m *Module) SetTimings(t *metrics.Timings) {
+	m.timings = t
+}
+
+// startPhase starts timing the named phase and logs it to the context's
+// structured logger, returning the function that ends it.
+func (m *Module) startPhase(ctx context.Context, name string) (stop func()) {
+	stopTiming := m.timings.Start(name)
+	finish := logging.FromContext(ctx).Phase(name)
+	return func() {
+		stopTiming()
+		finish()
+	}
+}
+
+// SetResourceCallback sets the callback notified of each resource created or
+// updated in the target organization.
+func (m *Module) SetResourceCallback(cb import_module.ResourceCallback) {
+	m.onResource = cb
+}
+
+// reportResource passes a created or updated resource to the resource callback.
+func (m *Module) reportResource(action, resourceType, identifier string) {
+	if m.onResource != nil {
+		m.onResource(action, resourceType, identifier)
+	}
+}
+
+// reportBulkResources reports every entity in a bulk request that is not
+// listed in errs.
+func (m *Module) reportBulkResources(action, resourceType string, entities []api.Entity, errs []api.BulkEntityError) {
+	if m.onResource == nil {
+		return
+	}
+	for _, id := range import_module.BulkSucceeded(entities, errs) {
+		m.onResource(action, resourceType, id)
+	}
 }
 
 // NewModule creates a new migration module.
 func NewModule(sourceToken, targetToken *auth.Token, sourceConfig, targetConfig *config.OrganizationConfig) *Module {
-	return &Module{
-		sourceClient: api.NewClient(api.ClientOpts{
-			Token:        sourceToken,
-			ClientID:     sourceConfig.ClientID,
-			ClientSecret: sourceConfig.ClientSecret,
-			APIURL:       sourceConfig.APIURL,
-			Timeout:      0,
-		}),
-		targetClient: api.NewClient(api.ClientOpts{
-			Token:        targetToken,
-			ClientID:     targetConfig.ClientID,
-			ClientSecret: targetConfig.ClientSecret,
-			APIURL:       targetConfig.APIURL,
-			Timeout:      0,
-		}),
-	}
+	return NewSourceModule(sourceToken, sourceConfig).WithTarget(targetToken, targetConfig)
 }
 
 // Options represents migration options.
 type Options struct {
 	Blueprints                    []string
+	BlueprintPatterns             []string // identifier globs (path.Match syntax), unioned with Blueprints
 	DryRun                        bool
 	SkipEntities                  bool
 	SkipSystemBlueprints          bool // skip _* blueprint schemas and their entities
 	SkipSystemBlueprintProperties bool
+	IncludeSystemBlueprints       bool // diff and update Port-managed system blueprints too; never creates them
 	IncludeRuleResults            bool // include _rule_result system blueprint entities (included by default)
 	IncludeResources              []string
-	ExcludeBlueprints             []string // deep: exclude blueprint schema + all its resources
-	ExcludeBlueprintSchema        []string // shallow: exclude only the blueprint schema, keep resources
-	UsersAsDisabled               bool     // import non-admin users as DISABLED after staging
+	IncludePatterns               map[string][]string // resource type -> identifier globs from --include "type:glob"
+	ExcludeBlueprints             []string            // deep: exclude blueprint schema + all its resources
+	ExcludeBlueprintSchema        []string            // shallow: exclude only the blueprint schema, keep resources
+	UsersAsDisabled               bool                // import non-admin users as DISABLED after staging
+	CreateIntegrations            bool                // install integrations missing from the target instead of skipping them
+	AllowBreaking                 bool                // apply blueprint schema changes that could invalidate existing entities
+	ForceUpdate                   bool                // update existing resources even when the diff finds them unchanged
+	StrictRelations               bool                // fail on relations to missing blueprints instead of reporting them and continuing
+	TeamMap                       map[string]string   // source team name -> target team name, from --team-map
+	ActionURLMap                  map[string]string   // rewrites values in action invocation methods, from --action-url-map
+	PreserveMetadata              bool                // keep the entity createdAt/createdBy/updatedAt/updatedBy values the API accepts
+	SinceExport                   *export.Data        // earlier source snapshot; only resources changed since it are diffed against the target
+
+	// ConfirmApply approves the diff before anything changes in the target;
+	// nil approves it.
+	ConfirmApply import_module.ApplyConfirmer
+
+	// Dependencies picks the relation targets migrated along with the
+	// selected blueprints; "" follows only required relations.
+	Dependencies export.DependencyMode
+
+	// SkipEntitiesFor lists blueprint identifiers or globs whose entities are
+	// not migrated. Their schemas, scorecards and actions still are.
+	SkipEntitiesFor []string
+
+	// RecordEntityChanges keeps the entities created or updated in
+	// Result.DiffResult, for change reports. Migrated entities are otherwise
+	// not held in memory.
+	RecordEntityChanges bool
+
+	// StripProperties lists, per blueprint, properties left out of the
+	// migrated schema and entities, from the strip_properties section of
+	// --map-file.
+	StripProperties map[string][]string
 
 	// AutoScopeBlueprints, when true, narrows the blueprint schemas returned by
 	// exportFromSource to only the blueprints referenced by a matching
@@ -126,11 +190,17 @@ type Result struct {
 	PagesCreated                         int
 	PagesUpdated                         int
 	PagesSkipped                         int
+	IntegrationsCreated                  int
 	IntegrationsUpdated                  int
 	IntegrationsSkipped                  int
+	DataSourcesCreated                   int
+	DataSourcesUpdated                   int
+	DataSourcesSkipped                   int
 	BlueprintPermissionsUpdated          int
 	ActionPermissionsUpdated             int
 	PagePermissionsUpdated               int
+	TeamMembersAdded                     int
+	TeamMembersRemoved                   int
 	BlueprintsToCreate                   []string
 	BlueprintsToUpdate                   []string
 	BlueprintsToSkip                     []string
@@ -142,16 +212,166 @@ type Result struct {
 	DiffResult                           *import_module.DiffResult
 	IgnoredRuleResultTargetRelationCount int
 	IgnoredRuleResultTargetRelationKeys  []string
+	// SourceSnapshot is the full source export taken under
+	// Options.SinceExport, to be saved as the snapshot for the next run.
+	SourceSnapshot *export.Data
+	// DeletedSinceExport lists the resources removed from the source since
+	// Options.SinceExport. They are reported, not deleted from the target.
+	DeletedSinceExport []export.Deletion
+	// UnchangedSinceExport counts the source resources left out of the diff
+	// because they match Options.SinceExport.
+	UnchangedSinceExport int
+	// CategorizedErrors holds Errors with their category, resource and
+	// retryability, for structured output.
+	CategorizedErrors []*import_module.ImportError
+}
+
+// addErrorf records err for the resource of resourceType: the formatted line
+// goes to Errors and the categorized error to CategorizedErrors. Callers
+// running concurrently hold the result's lock.
+func (r *Result) addErrorf(resourceType, resourceID string, err error, format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+	r.CategorizedErrors = append(r.CategorizedErrors, import_module.CategorizeError(err, resourceType, resourceID))
+}
+
+// Applied returns how many changes the migration wrote to the target org.
+func (r *Result) Applied() int {
+	return r.BlueprintsCreated + r.BlueprintsUpdated +
+		r.EntitiesCreated + r.EntitiesUpdated +
+		r.ScorecardsCreated + r.ScorecardsUpdated +
+		r.ActionsCreated + r.ActionsUpdated +
+		r.TeamsCreated + r.TeamsUpdated +
+		r.TeamMembersAdded + r.TeamMembersRemoved +
+		r.UsersCreated + r.UsersUpdated +
+		r.PagesCreated + r.PagesUpdated +
+		r.IntegrationsCreated + r.IntegrationsUpdated +
+		r.DataSourcesCreated + r.DataSourcesUpdated +
+		r.BlueprintPermissionsUpdated + r.ActionPermissionsUpdated + r.PagePermissionsUpdated
+}
+
+// From adds the counts recorded in c to r and resets c. Call it once the
+// workers writing to c have finished.
+func (r *Result) From(c *import_module.Counters) {
+	s := c.Take()
+	r.BlueprintsCreated += s.BlueprintsCreated
+	r.BlueprintsUpdated += s.BlueprintsUpdated
+	r.BlueprintsSkipped += s.BlueprintsSkipped
+	r.EntitiesCreated += s.EntitiesCreated
+	r.EntitiesUpdated += s.EntitiesUpdated
+	r.EntitiesSkipped += s.EntitiesSkipped
+	r.ScorecardsCreated += s.ScorecardsCreated
+	r.ScorecardsUpdated += s.ScorecardsUpdated
+	r.ScorecardsSkipped += s.ScorecardsSkipped
+	r.ActionsCreated += s.ActionsCreated
+	r.ActionsUpdated += s.ActionsUpdated
+	r.ActionsSkipped += s.ActionsSkipped
+	r.TeamsCreated += s.TeamsCreated
+	r.TeamsUpdated += s.TeamsUpdated
+	r.TeamsSkipped += s.TeamsSkipped
+	r.UsersCreated += s.UsersCreated
+	r.UsersUpdated += s.UsersUpdated
+	r.UsersSkipped += s.UsersSkipped
+	r.PagesCreated += s.PagesCreated
+	r.PagesUpdated += s.PagesUpdated
+	r.PagesSkipped += s.PagesSkipped
+	r.IntegrationsCreated += s.IntegrationsCreated
+	r.IntegrationsUpdated += s.IntegrationsUpdated
+	r.IntegrationsSkipped += s.IntegrationsSkipped
+	r.DataSourcesCreated += s.DataSourcesCreated
+	r.DataSourcesUpdated += s.DataSourcesUpdated
+	r.DataSourcesSkipped += s.DataSourcesSkipped
+	r.BlueprintPermissionsUpdated += s.BlueprintPermissionsUpdated
+	r.ActionPermissionsUpdated += s.ActionPermissionsUpdated
+	r.PagePermissionsUpdated += s.PagePermissionsUpdated
+}
+
+// SourceExport holds the data exported from the source organization, so a
+// single export can be migrated into several target organizations.
+type SourceExport struct {
+	Data             *export.Data
+	entityBlueprints []api.Blueprint
+	cachedEntities   map[string][]api.Entity
+
+	// Set under Options.SinceExport; see Result.
+	snapshot  *export.Data
+	deletions []export.Deletion
+	unchanged int
 }
 
 // Execute performs the migration operation.
 func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
-	// Export from source
+	source, err := m.ExportSource(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return m.ExecuteFromSource(ctx, source, opts)
+}
+
+// ExportSource exports the resources selected by opts from the source organization.
+func (m *Module) ExportSource(ctx context.Context, opts Options) (*SourceExport, error) {
+	defer m.startPhase(ctx, "export")()
 	sourceData, entityBlueprints, cachedMatchedEntities, err := m.exportFromSource(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to export from source: %w", err)
 	}
+	source := &SourceExport{
+		Data:             sourceData,
+		entityBlueprints: entityBlueprints,
+		cachedEntities:   cachedMatchedEntities,
+	}
+	if opts.SinceExport != nil {
+		if err := m.sinceExport(ctx, source, opts); err != nil {
+			return nil, fmt.Errorf("failed to export from source: %w", err)
+		}
+	}
+	return source, nil
+}
+
+// rewriteActions returns a copy of data whose action invocation methods are
+// rewritten through urlMap, leaving data itself untouched like remapTeams.
+func rewriteActions(data *export.Data, urlMap map[string]string) *export.Data {
+	if len(urlMap) == 0 || data == nil {
+		return data
+	}
+	rewritten := *data
+	rewritten.Actions = import_module.RewriteActionInvocations(data.Actions, urlMap)
+	return &rewritten
+}
+
+// ExecuteFromSource migrates a previously exported source into the target
+// organization. The source export is only read, so the same export may be
+// passed to several modules concurrently.
+func (m *Module) ExecuteFromSource(ctx context.Context, source *SourceExport, opts Options) (*Result, error) {
+	sourceData := remapTeams(source.Data, opts.TeamMap)
+	sourceData = stripProperties(sourceData, opts.StripProperties)
+	entityBlueprints := source.entityBlueprints
+	cachedMatchedEntities := source.cachedEntities
 	streamEntities := !opts.SkipEntities && shouldCollect("entities", opts.IncludeResources)
+	warnings := m.teamMapWarnings(ctx, sourceData, opts.TeamMap)
+	if leftover := import_module.ActionURLMapLeftovers(sourceData.Actions, opts.ActionURLMap); len(leftover) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d action(s) still reference a source mapped by --action-url-map in their invocation method: %s", len(leftover), strings.Join(leftover, ", ")))
+	}
+	sourceData = rewriteActions(sourceData, opts.ActionURLMap)
+	sourceData = withEntityMetadata(sourceData, opts.PreserveMetadata)
+	if opts.PreserveMetadata {
+		if warning := preserveMetadataWarning(); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if opts.ForceUpdate {
+		warnings = append(warnings, import_module.ForceUpdateWarning)
+	}
+	if warning := sinceExportDeletionsWarning(source.deletions); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// Under --strict-relations, stop before changing anything when a relation
+	// targets a blueprint missing from both the source export and the target
+	if opts.StrictRelations && shouldCollect("blueprints", opts.IncludeResources) {
+		if err := import_module.CheckRelationTargets(ctx, m.targetClient, sourceData.Blueprints); err != nil {
+			return nil, err
+		}
+	}
 
 	// Diff validation - compare source data with target organization's current state
 	comparer := import_module.NewDiffComparer(m.targetClient)
@@ -159,15 +379,36 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		SkipEntities:                  opts.SkipEntities || streamEntities,
 		SkipSystemBlueprints:          opts.SkipSystemBlueprints,
 		SkipSystemBlueprintProperties: opts.SkipSystemBlueprintProperties,
+		IncludeSystemBlueprints:       opts.IncludeSystemBlueprints,
 		IncludeRuleResults:            opts.IncludeRuleResults,
 		IncludeResources:              opts.IncludeResources,
 		ExcludeBlueprints:             opts.ExcludeBlueprints,
 		ExcludeBlueprintSchema:        opts.ExcludeBlueprintSchema,
+		CreateIntegrations:            opts.CreateIntegrations,
+		ForceUpdate:                   opts.ForceUpdate,
+		SkipEntitiesFor:               opts.SkipEntitiesFor,
 	}
+	stopDiff := m.startPhase(ctx, "diff")
 	diffResult, err := comparer.Compare(ctx, sourceData, diffOpts)
+	stopDiff()
 	if err != nil {
 		return nil, fmt.Errorf("diff comparison failed: %w", err)
 	}
+	// The diff skips teams along with the streamed entities, so their
+	// members are compared against the target's teams fetched here.
+	if streamEntities && shouldCollect("teams", opts.IncludeResources) {
+		currentTeams, err := m.targetClient.GetTeams(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target teams: %w", err)
+		}
+		diffResult.TeamMemberships = import_module.CompareTeamMemberships(sourceData.Teams, currentTeams)
+	}
+
+	// Stop before applying schema changes that could invalidate existing entities
+	breaking := import_module.DetectBreakingChanges(diffResult)
+	if len(breaking) > 0 && !opts.AllowBreaking && !opts.DryRun {
+		return nil, &import_module.BreakingChangesError{Changes: breaking}
+	}
 
 	// Use diff result to filter data - only migrate what needs to be created or updated
 	filteredData := diffResult.FilterData(sourceData)
@@ -175,6 +416,10 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 	// Dry run - show what would happen
 	if opts.DryRun {
 		result := m.generateDryRunResult(diffResult)
+		result.Warnings = append(result.Warnings, warnings...)
+		result.DeletedSinceExport = source.deletions
+		result.UnchangedSinceExport = source.unchanged
+		result.Warnings = appendBreakingChangeWarnings(result.Warnings, breaking)
 		if streamEntities {
 			if err := m.migrateEntities(ctx, entityBlueprints, opts, result, true, cachedMatchedEntities); err != nil {
 				markMigrationStopped(result, diffResult, err)
@@ -184,13 +429,28 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		return result, nil
 	}
 
+	if err := import_module.ConfirmApply(diffResult, streamEntities, opts.ConfirmApply); err != nil {
+		return nil, err
+	}
+
 	// Import to target using filtered data
-	result, err := m.importToTarget(ctx, filteredData, diffResult, opts.UsersAsDisabled)
+	result, err := m.importToTarget(ctx, filteredData, diffResult, opts.UsersAsDisabled, opts.StrictRelations)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import to target: %w", err)
 	}
+	result.Warnings = append(result.Warnings, warnings...)
+	result.Warnings = appendBreakingChangeWarnings(result.Warnings, breaking)
+	result.DiffResult = diffResult
+	if ctx.Err() != nil {
+		markMigrationInterrupted(result, diffResult)
+		return result, fmt.Errorf("migration interrupted: %w", context.Cause(ctx))
+	}
 	if streamEntities {
 		if err := m.migrateEntities(ctx, entityBlueprints, opts, result, false, cachedMatchedEntities); err != nil {
+			if ctx.Err() != nil {
+				markMigrationInterrupted(result, diffResult)
+				return result, fmt.Errorf("migration interrupted: %w", context.Cause(ctx))
+			}
 			markMigrationStopped(result, diffResult, err)
 			return result, fmt.Errorf("failed to migrate entities: %w", err)
 		}
@@ -204,6 +464,9 @@ func (m *Module) Execute(ctx context.Context, opts Options) (*Result, error) {
 		result.Message = "Migration completed successfully"
 	}
 	result.DiffResult = diffResult
+	result.SourceSnapshot = source.snapshot
+	result.DeletedSinceExport = source.deletions
+	result.UnchangedSinceExport = source.unchanged
 	return result, nil
 }
 
@@ -213,14 +476,50 @@ func markMigrationStopped(result *Result, diffResult *import_module.DiffResult,
 	}
 	if len(result.Errors) == 0 && err != nil {
 		result.Errors = append(result.Errors, err.Error())
+		result.CategorizedErrors = append(result.CategorizedErrors, import_module.CategorizeError(err, "migration", ""))
 	}
 	result.Success = false
 	result.Message = fmt.Sprintf("Migration stopped with %d error(s)", len(result.Errors))
 	result.DiffResult = diffResult
 }
 
+// markMigrationInterrupted marks result as stopped by a canceled context. Errors
+// from requests that were aborted by the cancellation are dropped so the
+// summary only lists real failures.
+func markMigrationInterrupted(result *Result, diffResult *import_module.DiffResult) {
+	if result == nil {
+		return
+	}
+	kept := result.Errors[:0]
+	for _, e := range result.Errors {
+		if !strings.HasSuffix(e, context.Canceled.Error()) {
+			kept = append(kept, e)
+		}
+	}
+	result.Errors = kept
+	keptCategorized := result.CategorizedErrors[:0]
+	for _, e := range result.CategorizedErrors {
+		if e != nil && !errors.Is(e.Cause, context.Canceled) {
+			keptCategorized = append(keptCategorized, e)
+		}
+	}
+	result.CategorizedErrors = keptCategorized
+	result.Success = false
+	result.Message = fmt.Sprintf("Migration interrupted with %d error(s)", len(result.Errors))
+	result.DiffResult = diffResult
+}
+
+// appendBreakingChangeWarnings adds one warning per breaking schema change.
+func appendBreakingChangeWarnings(warnings []string, changes []import_module.BreakingChange) []string {
+	for _, c := range changes {
+		warnings = append(warnings, "Breaking schema change in "+c.String())
+	}
+	return warnings
+}
+
 // generateDryRunResult generates a dry run result with accurate predictions.
 func (m *Module) generateDryRunResult(diffResult *import_module.DiffResult) *Result {
+	membersAdded, membersRemoved := import_module.CountTeamMembershipChanges(diffResult.TeamMemberships)
 	return &Result{
 		Success:                      true,
 		Message:                      "Migration validation passed (dry run - no changes applied)",
@@ -245,11 +544,17 @@ func (m *Module) generateDryRunResult(diffResult *import_module.DiffResult) *Res
 		PagesCreated:                 len(diffResult.PagesToCreate),
 		PagesUpdated:                 len(diffResult.PagesToUpdate),
 		PagesSkipped:                 len(diffResult.PagesToSkip),
+		IntegrationsCreated:          len(diffResult.IntegrationsToCreate),
 		IntegrationsUpdated:          len(diffResult.IntegrationsToUpdate),
 		IntegrationsSkipped:          len(diffResult.IntegrationsToSkip),
+		DataSourcesCreated:           len(diffResult.DataSourcesToCreate),
+		DataSourcesUpdated:           len(diffResult.DataSourcesToUpdate),
+		DataSourcesSkipped:           len(diffResult.DataSourcesToSkip),
 		BlueprintPermissionsUpdated:  len(diffResult.BlueprintPermissions),
 		ActionPermissionsUpdated:     len(diffResult.ActionPermissions),
 		PagePermissionsUpdated:       len(diffResult.PagePermissions),
+		TeamMembersAdded:             membersAdded,
+		TeamMembersRemoved:           membersRemoved,
 		BlueprintsToCreate:           blueprintIdentifiers(diffResult.BlueprintsToCreate),
 		BlueprintsToUpdate:           blueprintIdentifiers(diffResult.BlueprintsToUpdate),
 		BlueprintsToSkip:             blueprintIdentifiers(diffResult.BlueprintsToSkip),
@@ -287,24 +592,10 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 	}
 
 	// Filter blueprints if specified
-	var selectedBlueprints []api.Blueprint
-	if len(opts.Blueprints) > 0 {
-		blueprintSet := make(map[string]bool)
-		for _, bpID := range opts.Blueprints {
-			blueprintSet[bpID] = true
-		}
-
-		for _, bp := range allBlueprints {
-			if identifier, ok := bp["identifier"].(string); ok && blueprintSet[identifier] {
-				selectedBlueprints = append(selectedBlueprints, bp)
-			}
-		}
-	} else {
-		selectedBlueprints = allBlueprints
-	}
+	selectedBlueprints := export.SelectBlueprints(allBlueprints, opts.Blueprints, opts.BlueprintPatterns)
 
 	// Resolve dependencies
-	resolvedBlueprints := m.resolveDependencies(allBlueprints, selectedBlueprints)
+	resolvedBlueprints := export.ResolveBlueprintDependencies(allBlueprints, selectedBlueprints, opts.Dependencies)
 
 	// Apply exclusions: iterBlueprints is used to fetch entities/scorecards/actions,
 	// dataBlueprints is what ends up in data.Blueprints (schema output).
@@ -336,6 +627,9 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 			if opts.SkipSystemBlueprints && strings.HasPrefix(bpID, "_") {
 				continue
 			}
+			if export.SkipsEntitiesOf(bpID, opts.SkipEntitiesFor) {
+				continue
+			}
 			entityBlueprints = append(entityBlueprints, blueprint)
 		}
 	}
@@ -350,6 +644,7 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 		Folders:              []api.Folder{},
 		Pages:                []api.Page{},
 		Integrations:         []api.Integration{},
+		DataSources:          []api.Webhook{},
 		BlueprintPermissions: make(map[string]api.Permissions),
 		ActionPermissions:    make(map[string]api.Permissions),
 		PagePermissions:      make(map[string]api.Permissions),
@@ -380,7 +675,7 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 				defer sem.Release(1)
 				scorecards, err := m.sourceClient.GetScorecards(ctx, bpID)
 				if err != nil {
-					if !strings.Contains(err.Error(), "410 Gone") {
+					if !api.HasStatus(err, http.StatusGone) {
 						return fmt.Errorf("failed to get scorecards for blueprint %s: %w", bpID, err)
 					}
 					return nil
@@ -413,7 +708,7 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 				defer sem.Release(1)
 				actions, err := m.sourceClient.GetActions(ctx, bpID)
 				if err != nil {
-					if !strings.Contains(err.Error(), "410 Gone") {
+					if !api.HasStatus(err, http.StatusGone) {
 						return fmt.Errorf("failed to get actions for blueprint %s: %w", bpID, err)
 					}
 					return nil
@@ -658,6 +953,20 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 		})
 	}
 
+	if shouldCollect("datasources", opts.IncludeResources) {
+		g.Go(func() error {
+			sources, err := m.sourceClient.GetWebhooks(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get data sources: %w", err)
+			}
+
+			mu.Lock()
+			data.DataSources = export.FilterDataSources(sources, iterBlueprints)
+			mu.Unlock()
+			return nil
+		})
+	}
+
 	// Wait for all goroutines to complete
 	if err := g.Wait(); err != nil {
 		return nil, nil, nil, err
@@ -675,6 +984,7 @@ func (m *Module) exportFromSource(ctx context.Context, opts Options) (*export.Da
 		data.Blueprints = export.FilterBlueprintsToReferenced(dataBlueprints, referencedBlueprintIDs)
 		entityBlueprints = export.FilterBlueprintsToReferenced(entityBlueprints, referencedBlueprintIDs)
 	}
+	export.FilterByIncludePatterns(data, opts.IncludePatterns)
 
 	return data, entityBlueprints, cachedMatchedEntities, nil
 }
@@ -699,7 +1009,7 @@ func (m *Module) blueprintHasMatchingEntity(ctx context.Context, bpID string, en
 	if len(entityIDs) == 0 {
 		count, err := m.sourceClient.GetEntitiesCount(ctx, bpID)
 		if err != nil {
-			if strings.Contains(err.Error(), "410 Gone") {
+			if api.HasStatus(err, http.StatusGone) {
 				return false, nil, nil
 			}
 			return false, nil, err
@@ -722,7 +1032,7 @@ func (m *Module) blueprintHasMatchingEntity(ctx context.Context, bpID string, en
 		return nil
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "410 Gone") {
+		if api.HasStatus(err, http.StatusGone) {
 			return false, nil, nil
 		}
 		return false, nil, err
@@ -733,81 +1043,10 @@ func (m *Module) blueprintHasMatchingEntity(ctx context.Context, bpID string, en
 	return true, matched, nil
 }
 
-// resolveDependencies resolves blueprint dependencies.
-// If a blueprint has relations to other blueprints, ensure those blueprints are also included.
-func (m *Module) resolveDependencies(allBlueprints, selectedBlueprints []api.Blueprint) []api.Blueprint {
-	selectedIDs := make(map[string]bool)
-	allBlueprintsMap := make(map[string]api.Blueprint)
-
-	for _, bp := range allBlueprints {
-		if identifier, ok := bp["identifier"].(string); ok {
-			allBlueprintsMap[identifier] = bp
-		}
-	}
-
-	for _, bp := range selectedBlueprints {
-		if identifier, ok := bp["identifier"].(string); ok {
-			selectedIDs[identifier] = true
-		}
-	}
-
-	result := make([]api.Blueprint, len(selectedBlueprints))
-	copy(result, selectedBlueprints)
-
-	toCheck := make([]string, 0, len(selectedIDs))
-	for id := range selectedIDs {
-		toCheck = append(toCheck, id)
-	}
-
-	checked := make(map[string]bool)
-
-	for len(toCheck) > 0 {
-		blueprintID := toCheck[len(toCheck)-1]
-		toCheck = toCheck[:len(toCheck)-1]
-
-		if checked[blueprintID] {
-			continue
-		}
-		checked[blueprintID] = true
-
-		blueprint, ok := allBlueprintsMap[blueprintID]
-		if !ok {
-			continue
-		}
-
-		// Check relations
-		relations, ok := blueprint["relations"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		for _, relation := range relations {
-			relationMap, ok := relation.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			target, ok := relationMap["target"].(string)
-			if !ok || target == "" {
-				continue
-			}
-
-			if !selectedIDs[target] {
-				// Add dependency
-				if depBlueprint, exists := allBlueprintsMap[target]; exists {
-					result = append(result, depBlueprint)
-					selectedIDs[target] = true
-					toCheck = append(toCheck, target)
-				}
-			}
-		}
-	}
-
-	return result
-}
-
 // importToTarget imports data to the target organization using diff result.
-func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResult *import_module.DiffResult, usersAsDisabled bool) (*Result, error) {
+func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResult *import_module.DiffResult, usersAsDisabled, strictRelations bool) (*Result, error) {
+	defer m.startPhase(ctx, "import")()
+	stopBlueprints := m.startPhase(ctx, "import.blueprints")
 	result := &Result{
 		Errors: []string{},
 	}
@@ -831,26 +1070,23 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		}
 	}
 
-	entitiesToCreate := make(map[string]bool)
-	entitiesToUpdate := make(map[string]bool)
+	entitiesToCreate := make(map[import_module.ResourceKey]bool)
+	entitiesToUpdate := make(map[import_module.ResourceKey]bool)
 	for _, ent := range diffResult.EntitiesToCreate {
-		bpID, ok1 := ent["blueprint"].(string)
-		entID, ok2 := ent["identifier"].(string)
-		if ok1 && ok2 {
-			entitiesToCreate[fmt.Sprintf("%s:%s", bpID, entID)] = true
+		if key, ok := import_module.EntityKey(ent); ok {
+			entitiesToCreate[key] = true
 		}
 	}
 	for _, ent := range diffResult.EntitiesToUpdate {
-		bpID, ok1 := ent["blueprint"].(string)
-		entID, ok2 := ent["identifier"].(string)
-		if ok1 && ok2 {
-			entitiesToUpdate[fmt.Sprintf("%s:%s", bpID, entID)] = true
+		if key, ok := import_module.EntityKey(ent); ok {
+			entitiesToUpdate[key] = true
 		}
 	}
 
 	// Import blueprints first (needed for other resources) using two-pass strategy
 	g, ctx := errgroup.WithContext(origCtx)
 	var mu sync.Mutex
+	var counts import_module.Counters
 
 	// Store each field type separately for ordered phase updates.
 	// Ordering mirrors import.go: relations → calcProps → mirrorProps → aggProps.
@@ -945,13 +1181,14 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 						failedBlueprints[identifier] = bp
 						failedBlueprintActions[identifier] = action
 					} else {
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", identifier, err))
+						result.addErrorf("blueprint", identifier, err, "Blueprint %s: %v", identifier, err)
 					}
 					mu.Unlock()
 					return nil
 				}
+				counts.Blueprints.Created.Add(1)
+				m.reportResource(import_module.ResourceCreated, "blueprint", identifier)
 				mu.Lock()
-				result.BlueprintsCreated++
 				successfulBlueprints[identifier] = true
 				mu.Unlock()
 			} else if action == "update" {
@@ -962,7 +1199,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					existing, fetchErr := m.targetClient.GetBlueprint(ctx, identifier)
 					if fetchErr != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", identifier, fetchErr))
+						result.addErrorf("blueprint", identifier, fetchErr, "Blueprint %s: %v", identifier, fetchErr)
 						mu.Unlock()
 						return nil
 					}
@@ -977,13 +1214,14 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 						failedBlueprints[identifier] = bp
 						failedBlueprintActions[identifier] = action
 					} else {
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", identifier, err))
+						result.addErrorf("blueprint", identifier, err, "Blueprint %s: %v", identifier, err)
 					}
 					mu.Unlock()
 					return nil
 				}
+				counts.Blueprints.Updated.Add(1)
+				m.reportResource(import_module.ResourceUpdated, "blueprint", identifier)
 				mu.Lock()
-				result.BlueprintsUpdated++
 				successfulBlueprints[identifier] = true
 				mu.Unlock()
 			}
@@ -1010,12 +1248,13 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					_, err := m.targetClient.CreateBlueprint(ctx, apiBp)
 					if err != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", bpID, err))
+						result.addErrorf("blueprint", bpID, err, "Blueprint %s: %v", bpID, err)
 						mu.Unlock()
 						return nil
 					}
+					counts.Blueprints.Created.Add(1)
+					m.reportResource(import_module.ResourceCreated, "blueprint", bpID)
 					mu.Lock()
-					result.BlueprintsCreated++
 					successfulBlueprints[bpID] = true
 					mu.Unlock()
 				} else if action == "update" {
@@ -1026,7 +1265,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 						existing, fetchErr := m.targetClient.GetBlueprint(ctx, bpID)
 						if fetchErr != nil {
 							mu.Lock()
-							result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", bpID, fetchErr))
+							result.addErrorf("blueprint", bpID, fetchErr, "Blueprint %s: %v", bpID, fetchErr)
 							mu.Unlock()
 							return nil
 						}
@@ -1037,12 +1276,13 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					}
 					if err != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s: %v", bpID, err))
+						result.addErrorf("blueprint", bpID, err, "Blueprint %s: %v", bpID, err)
 						mu.Unlock()
 						return nil
 					}
+					counts.Blueprints.Updated.Add(1)
+					m.reportResource(import_module.ResourceUpdated, "blueprint", bpID)
 					mu.Lock()
-					result.BlueprintsUpdated++
 					successfulBlueprints[bpID] = true
 					mu.Unlock()
 				}
@@ -1094,8 +1334,12 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		}
 	}
 
+	relationWriter := import_module.NewRelationWriter(m.targetClient)
+
 	// runBlueprintPhase applies a single field to all blueprints that have it,
-	// concurrently. It fetches the existing blueprint and merges the field in.
+	// concurrently. It fetches the existing blueprint and merges the field in,
+	// except for relations, which go through the relation endpoints when the
+	// target offers them.
 	runBlueprintPhase := func(phaseName string, fieldsByID map[string]map[string]interface{}) error {
 		if len(fieldsByID) == 0 {
 			return nil
@@ -1108,10 +1352,21 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			bpID := identifier
 			fieldsCopy := fields
 			g.Go(func() error {
+				if relations, ok := fieldsCopy["relations"].(map[string]interface{}); ok && len(fieldsCopy) == 1 {
+					applied, err := relationWriter.Apply(gCtx, bpID, relations)
+					if applied {
+						if err != nil {
+							mu.Lock()
+							result.addErrorf("blueprint", bpID, err, "Blueprint %s (%s): %v", bpID, phaseName, err)
+							mu.Unlock()
+						}
+						return nil
+					}
+				}
 				existing, err := m.targetClient.GetBlueprint(gCtx, bpID)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s (%s): failed to fetch: %v", bpID, phaseName, err))
+					result.addErrorf("blueprint", bpID, err, "Blueprint %s (%s): failed to fetch: %v", bpID, phaseName, err)
 					mu.Unlock()
 					return nil
 				}
@@ -1127,7 +1382,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				}
 				if updateErr != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s (%s): %v", bpID, phaseName, updateErr))
+					result.addErrorf("blueprint", bpID, updateErr, "Blueprint %s (%s): %v", bpID, phaseName, updateErr)
 					mu.Unlock()
 				}
 				return nil
@@ -1137,13 +1392,16 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	}
 
 	// Phase 2a: relations
+	if err := import_module.CheckStoredRelationTargets(strictRelations, blueprintRelations, existingInTarget); err != nil {
+		return nil, err
+	}
 	if err := runBlueprintPhase("relations", func() map[string]map[string]interface{} {
 		out := make(map[string]map[string]interface{})
 		for id, rels := range blueprintRelations {
 			missing := import_module.ValidateRelationTargets(api.Blueprint{"relations": rels}, existingInTarget)
 			if len(missing) > 0 {
 				mu.Lock()
-				result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s (relations): missing target blueprints: %v", id, missing))
+				result.addErrorf("blueprint", id, fmt.Errorf("missing target blueprints: %v", missing), "Blueprint %s (relations): missing target blueprints: %v", id, missing)
 				mu.Unlock()
 				continue
 			}
@@ -1283,7 +1541,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					existing, err := m.targetClient.GetBlueprint(gCtx, bpID)
 					if err != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s (ownership): failed to fetch: %v", bpID, err))
+						result.addErrorf("blueprint", bpID, err, "Blueprint %s (ownership): failed to fetch: %v", bpID, err)
 						mu.Unlock()
 						return nil
 					}
@@ -1291,7 +1549,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					_, updateErr := m.targetClient.UpdateBlueprint(gCtx, bpID, stripBlueprintSystemFields(existing))
 					if updateErr != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Blueprint %s (ownership): %v", bpID, updateErr))
+						result.addErrorf("blueprint", bpID, updateErr, "Blueprint %s (ownership): %v", bpID, updateErr)
 						mu.Unlock()
 					}
 					return nil
@@ -1315,34 +1573,36 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		}
 	}
 
+	stopBlueprints()
+
 	// Import other resources concurrently
 	g, ctx = errgroup.WithContext(origCtx)
 
 	// filterEntitiesByDiff limits to only entities that differ from the target (create or update).
 	entityImporter := import_module.NewImporter(m.targetClient)
+	entityImporter.SetResourceCallback(m.onResource)
 	importResult := &import_module.Result{}
 	filtered := filterEntitiesByDiff(data.Entities, entitiesToCreate, entitiesToUpdate)
 	// Entity errors are always soft (collected, not fatal) — ImportEntities never returns non-nil.
+	stopEntities := m.startPhase(ctx, "import.entities")
 	_ = entityImporter.ImportEntities(ctx, filtered, false, importResult)
+	stopEntities()
 	result.EntitiesCreated += importResult.EntitiesCreated
 	result.EntitiesUpdated += importResult.EntitiesUpdated
 	result.Errors = append(result.Errors, entityImporter.CollectedErrors()...)
+	result.CategorizedErrors = append(result.CategorizedErrors, entityImporter.CategorizedErrors()...)
 
 	// Group scorecards by blueprint and separate into create/update
-	scorecardsToCreate := make(map[string]bool)
-	scorecardsToUpdate := make(map[string]bool)
+	scorecardsToCreate := make(map[import_module.ResourceKey]bool)
+	scorecardsToUpdate := make(map[import_module.ResourceKey]bool)
 	for _, sc := range diffResult.ScorecardsToCreate {
-		bpID, ok1 := sc["blueprintIdentifier"].(string)
-		scID, ok2 := sc["identifier"].(string)
-		if ok1 && ok2 {
-			scorecardsToCreate[fmt.Sprintf("%s:%s", bpID, scID)] = true
+		if key, ok := import_module.ScorecardKey(sc); ok {
+			scorecardsToCreate[key] = true
 		}
 	}
 	for _, sc := range diffResult.ScorecardsToUpdate {
-		bpID, ok1 := sc["blueprintIdentifier"].(string)
-		scID, ok2 := sc["identifier"].(string)
-		if ok1 && ok2 {
-			scorecardsToUpdate[fmt.Sprintf("%s:%s", bpID, scID)] = true
+		if key, ok := import_module.ScorecardKey(sc); ok {
+			scorecardsToUpdate[key] = true
 		}
 	}
 
@@ -1350,13 +1610,12 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	stripFields := map[string]bool{"createdBy": true, "updatedBy": true, "createdAt": true, "updatedAt": true, "id": true, "blueprint": true, "blueprintIdentifier": true}
 	for _, scorecard := range data.Scorecards {
 		sc := scorecard
-		blueprintID, ok1 := sc["blueprintIdentifier"].(string)
-		scorecardID, ok2 := sc["identifier"].(string)
-		if !ok1 || !ok2 || blueprintID == "" || scorecardID == "" {
+		key, ok := import_module.ScorecardKey(sc)
+		if !ok {
 			continue
 		}
 
-		key := fmt.Sprintf("%s:%s", blueprintID, scorecardID)
+		blueprintID := key.Blueprint
 		if scorecardsToCreate[key] || scorecardsToUpdate[key] {
 			cleaned := make(api.Scorecard)
 			for k, v := range sc {
@@ -1372,22 +1631,22 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		bpID := blueprintID
 		scs := scorecards
 		g.Go(func() error {
+			defer m.startPhase(ctx, "import.scorecards")()
 			var toMerge []api.Scorecard
 			for _, sc := range scs {
 				scID, _ := sc["identifier"].(string)
-				key := fmt.Sprintf("%s:%s", bpID, scID)
+				key := import_module.ResourceKey{Blueprint: bpID, Identifier: scID}
 
 				if scorecardsToCreate[key] {
 					_, err := m.targetClient.CreateScorecard(ctx, bpID, sc)
 					if err != nil {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Scorecard %s: %v", scID, err))
+						result.addErrorf("scorecard", scID, err, "Scorecard %s: %v", scID, err)
 						mu.Unlock()
 						continue
 					}
-					mu.Lock()
-					result.ScorecardsCreated++
-					mu.Unlock()
+					counts.Scorecards.Created.Add(1)
+					m.reportResource(import_module.ResourceCreated, "scorecard", scID)
 				} else if scorecardsToUpdate[key] {
 					toMerge = append(toMerge, sc)
 				}
@@ -1399,7 +1658,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				existing, fetchErr := m.targetClient.GetScorecards(ctx, bpID)
 				if fetchErr != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Scorecards fetch %s: %v", bpID, fetchErr))
+					result.addErrorf("blueprint", bpID, fetchErr, "Scorecards fetch %s: %v", bpID, fetchErr)
 					mu.Unlock()
 					return nil
 				}
@@ -1431,11 +1690,28 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				}
 
 				_, putErr := m.targetClient.UpdateScorecards(ctx, bpID, merged)
-				mu.Lock()
 				if putErr != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("Scorecards bulk-put %s: %v", bpID, putErr))
-				} else {
-					result.ScorecardsUpdated += len(toMerge)
+					// One invalid scorecard fails the whole bulk PUT. Retry
+					// one at a time so the valid ones still apply and only
+					// the bad one is reported.
+					for _, sc := range toMerge {
+						scID := sc["identifier"].(string)
+						_, updateErr := m.targetClient.UpdateScorecard(ctx, bpID, scID, sc)
+						mu.Lock()
+						if updateErr != nil {
+							result.addErrorf("scorecard", scID, updateErr, "Scorecard %s: %v", scID, updateErr)
+						} else {
+							counts.Scorecards.Updated.Add(1)
+							m.reportResource(import_module.ResourceUpdated, "scorecard", scID)
+						}
+						mu.Unlock()
+					}
+					return nil
+				}
+				mu.Lock()
+				counts.Scorecards.Updated.Add(int64(len(toMerge)))
+				for _, sc := range toMerge {
+					m.reportResource(import_module.ResourceUpdated, "scorecard", sc["identifier"].(string))
 				}
 				mu.Unlock()
 			}
@@ -1461,6 +1737,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	for _, action := range data.Actions {
 		act := action
 		g.Go(func() error {
+			defer m.startPhase(ctx, "import.actions")()
 			identifier, ok := act["identifier"].(string)
 			if !ok || identifier == "" {
 				return nil
@@ -1473,30 +1750,29 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				_, err := m.targetClient.CreateAutomation(ctx, apiAction)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Action %s: %v", identifier, err))
+					result.addErrorf("action", identifier, err, "Action %s: %v", identifier, err)
 					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				result.ActionsCreated++
-				mu.Unlock()
+				counts.Actions.Created.Add(1)
+				m.reportResource(import_module.ResourceCreated, "action", identifier)
 			} else if actionsToUpdate[identifier] {
 				_, err := m.targetClient.UpdateAutomation(ctx, identifier, apiAction)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Action %s: %v", identifier, err))
+					result.addErrorf("action", identifier, err, "Action %s: %v", identifier, err)
 					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				result.ActionsUpdated++
-				mu.Unlock()
+				counts.Actions.Updated.Add(1)
+				m.reportResource(import_module.ResourceUpdated, "action", identifier)
 			}
 			return nil
 		})
 	}
 
 	// Import teams
+	failedTeams := make(map[string]bool)
 	teamsToCreate := make(map[string]bool)
 	teamsToUpdate := make(map[string]bool)
 	for _, t := range diffResult.TeamsToCreate {
@@ -1513,35 +1789,37 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	for _, team := range data.Teams {
 		t := team
 		g.Go(func() error {
+			defer m.startPhase(ctx, "import.teams")()
 			teamName, ok := t["name"].(string)
 			if !ok || teamName == "" {
 				return nil
 			}
 
-			apiTeam := api.Team(t)
+			// Members are synced separately once users exist in the target.
+			apiTeam := import_module.WithoutTeamMembers(api.Team(t))
 
 			if teamsToCreate[teamName] {
 				_, err := m.targetClient.CreateTeam(ctx, apiTeam)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Team %s: %v", teamName, err))
+					result.addErrorf("team", teamName, err, "Team %s: %v", teamName, err)
+					failedTeams[teamName] = true
 					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				result.TeamsCreated++
-				mu.Unlock()
+				counts.Teams.Created.Add(1)
+				m.reportResource(import_module.ResourceCreated, "team", teamName)
 			} else if teamsToUpdate[teamName] {
 				_, err := m.targetClient.UpdateTeam(ctx, teamName, apiTeam)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Team %s: %v", teamName, err))
+					result.addErrorf("team", teamName, err, "Team %s: %v", teamName, err)
+					failedTeams[teamName] = true
 					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				result.TeamsUpdated++
-				mu.Unlock()
+				counts.Teams.Updated.Add(1)
+				m.reportResource(import_module.ResourceUpdated, "team", teamName)
 			}
 			return nil
 		})
@@ -1550,6 +1828,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	// Import users via _user blueprint entity API.
 	// New users are staged (or disabled for non-admins when usersAsDisabled is true).
 	// Existing users are updated with source data as-is.
+	stopUsers := m.startPhase(ctx, "import.users")
 	usersToCreate := make(map[string]bool)
 	usersToUpdate := make(map[string]bool)
 	for _, u := range diffResult.UsersToCreate {
@@ -1599,16 +1878,15 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			mu.Lock()
 			for _, e := range entities {
 				if email, ok := e["identifier"].(string); ok {
-					result.Errors = append(result.Errors, fmt.Sprintf("User %s: %v", email, err))
+					result.addErrorf("user", email, err, "User %s: %v", email, err)
 				}
 			}
 			mu.Unlock()
 			continue
 		}
 
-		mu.Lock()
-		result.UsersCreated += len(entities) - len(bulkErrs)
-		mu.Unlock()
+		counts.Users.Created.Add(int64(len(entities) - len(bulkErrs)))
+		m.reportBulkResources(import_module.ResourceCreated, "user", entities, bulkErrs)
 
 		// Re-POST with upsert=true for conflicts, source data as-is
 		var conflictEntities []api.Entity
@@ -1619,7 +1897,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				}
 			} else {
 				mu.Lock()
-				result.Errors = append(result.Errors, fmt.Sprintf("User %s: %s: %s", be.Identifier, be.Error, be.Message))
+				result.addErrorf("user", be.Identifier, fmt.Errorf("%s: %s", be.Error, be.Message), "User %s: %s: %s", be.Identifier, be.Error, be.Message)
 				mu.Unlock()
 			}
 		}
@@ -1629,13 +1907,14 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			if updateErr != nil {
 				for _, e := range conflictEntities {
 					if email, ok := e["identifier"].(string); ok {
-						result.Errors = append(result.Errors, fmt.Sprintf("User %s: %v", email, updateErr))
+						result.addErrorf("user", email, updateErr, "User %s: %v", email, updateErr)
 					}
 				}
 			} else {
-				result.UsersUpdated += len(conflictEntities) - len(updateErrs)
+				counts.Users.Updated.Add(int64(len(conflictEntities) - len(updateErrs)))
+				m.reportBulkResources(import_module.ResourceUpdated, "user", conflictEntities, updateErrs)
 				for _, be := range updateErrs {
-					result.Errors = append(result.Errors, fmt.Sprintf("User %s: %s: %s", be.Identifier, be.Error, be.Message))
+					result.addErrorf("user", be.Identifier, fmt.Errorf("%s: %s", be.Error, be.Message), "User %s: %s: %s", be.Identifier, be.Error, be.Message)
 				}
 			}
 			mu.Unlock()
@@ -1660,18 +1939,22 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		if err != nil {
 			for _, e := range entities {
 				if email, ok := e["identifier"].(string); ok {
-					result.Errors = append(result.Errors, fmt.Sprintf("User %s: %v", email, err))
+					result.addErrorf("user", email, err, "User %s: %v", email, err)
 				}
 			}
 		} else {
-			result.UsersUpdated += len(entities) - len(updateErrs)
+			counts.Users.Updated.Add(int64(len(entities) - len(updateErrs)))
+			m.reportBulkResources(import_module.ResourceUpdated, "user", entities, updateErrs)
 			for _, be := range updateErrs {
-				result.Errors = append(result.Errors, fmt.Sprintf("User %s: %s: %s", be.Identifier, be.Error, be.Message))
+				result.addErrorf("user", be.Identifier, fmt.Errorf("%s: %s", be.Error, be.Message), "User %s: %s: %s", be.Identifier, be.Error, be.Message)
 			}
 		}
 		mu.Unlock()
 	}
 
+	stopUsers()
+
+	stopPages := m.startPhase(ctx, "import.pages")
 	pagesToCreate := make(map[string]bool)
 	pagesToUpdate := make(map[string]bool)
 	for _, p := range diffResult.PagesToCreate {
@@ -1695,7 +1978,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					folderID := op.Identifier
 					if err := m.targetClient.CreateFolder(stepCtx, import_module.CleanFolderForCreate(op.Folder)); err != nil && !strings.Contains(strings.ToLower(err.Error()), "duplicate") && !strings.Contains(strings.ToLower(err.Error()), "already exists") && !strings.Contains(err.Error(), "409") && !strings.Contains(strings.ToLower(err.Error()), "conflict") {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Folder %s: %v", folderID, err))
+						result.addErrorf("folder", folderID, err, "Folder %s: %v", folderID, err)
 						mu.Unlock()
 					}
 					return nil
@@ -1709,18 +1992,16 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 					apiPage := api.Page(p)
 					addPageError := func(err error) {
 						mu.Lock()
-						result.Errors = append(result.Errors, fmt.Sprintf("Page %s: %v", pageID, err))
+						result.addErrorf("page", pageID, err, "Page %s: %v", pageID, err)
 						mu.Unlock()
 					}
 					markPageCreated := func() {
-						mu.Lock()
-						result.PagesCreated++
-						mu.Unlock()
+						counts.Pages.Created.Add(1)
+						m.reportResource(import_module.ResourceCreated, "page", pageID)
 					}
 					markPageUpdated := func() {
-						mu.Lock()
-						result.PagesUpdated++
-						mu.Unlock()
+						counts.Pages.Updated.Add(1)
+						m.reportResource(import_module.ResourceUpdated, "page", pageID)
 					}
 					updateExistingPage := func() {
 						cleanedPage := import_module.CleanPageForUpdate(apiPage)
@@ -1823,7 +2104,15 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 		}
 	}
 
+	stopPages()
+
 	// Import integrations
+	integrationsToCreate := make(map[string]bool)
+	for _, integ := range diffResult.IntegrationsToCreate {
+		if id, ok := integ["identifier"].(string); ok {
+			integrationsToCreate[id] = true
+		}
+	}
 	integrationsToUpdate := make(map[string]bool)
 	for _, integ := range diffResult.IntegrationsToUpdate {
 		if id, ok := integ["identifier"].(string); ok {
@@ -1834,11 +2123,24 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	for _, integration := range data.Integrations {
 		integ := integration
 		g.Go(func() error {
+			defer m.startPhase(ctx, "import.integrations")()
 			integrationID, ok := integ["identifier"].(string)
 			if !ok || integrationID == "" {
 				return nil
 			}
 
+			if integrationsToCreate[integrationID] {
+				if err := import_module.CreateIntegration(ctx, m.targetClient, integ); err != nil {
+					mu.Lock()
+					result.addErrorf("integration", integrationID, err, "Integration %s: %v", integrationID, err)
+					mu.Unlock()
+					return nil
+				}
+				counts.Integrations.Created.Add(1)
+				m.reportResource(import_module.ResourceCreated, "integration", integrationID)
+				return nil
+			}
+
 			if integrationsToUpdate[integrationID] {
 				// The integration config endpoint expects {"config": {...}} wrapper — only send config.
 				config, ok := integ["config"].(map[string]interface{})
@@ -1850,12 +2152,56 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 				_, err := m.targetClient.UpdateIntegrationConfig(ctx, integrationID, configMap)
 				if err != nil {
 					mu.Lock()
-					result.Errors = append(result.Errors, fmt.Sprintf("Integration %s: %v", integrationID, err))
+					result.addErrorf("integration", integrationID, err, "Integration %s: %v", integrationID, err)
 					mu.Unlock()
 					return nil
 				}
+				counts.Integrations.Updated.Add(1)
+				m.reportResource(import_module.ResourceUpdated, "integration", integrationID)
+			}
+			return nil
+		})
+	}
+
+	// Import data sources; the blueprints their mappings write to exist by now
+	dataSourcesToCreate := make(map[string]bool)
+	for _, ds := range diffResult.DataSourcesToCreate {
+		if id, ok := ds["identifier"].(string); ok {
+			dataSourcesToCreate[id] = true
+		}
+	}
+	dataSourcesToUpdate := make(map[string]bool)
+	for _, ds := range diffResult.DataSourcesToUpdate {
+		if id, ok := ds["identifier"].(string); ok {
+			dataSourcesToUpdate[id] = true
+		}
+	}
+
+	for _, dataSource := range data.DataSources {
+		ds := dataSource
+		g.Go(func() error {
+			defer m.startPhase(ctx, "import.datasources")()
+			dsID, ok := ds["identifier"].(string)
+			if !ok || dsID == "" {
+				return nil
+			}
+
+			var err error
+			switch {
+			case dataSourcesToCreate[dsID]:
+				if err = import_module.CreateDataSource(ctx, m.targetClient, ds); err == nil {
+					counts.DataSources.Created.Add(1)
+					m.reportResource(import_module.ResourceCreated, "datasource", dsID)
+				}
+			case dataSourcesToUpdate[dsID]:
+				if err = import_module.UpdateDataSource(ctx, m.targetClient, ds); err == nil {
+					counts.DataSources.Updated.Add(1)
+					m.reportResource(import_module.ResourceUpdated, "datasource", dsID)
+				}
+			}
+			if err != nil {
 				mu.Lock()
-				result.IntegrationsUpdated++
+				result.addErrorf("datasource", dsID, err, "Data source %s: %v", dsID, err)
 				mu.Unlock()
 			}
 			return nil
@@ -1866,8 +2212,25 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+	result.From(&counts)
+
+	// Sync team members (teams and users both exist now)
+	stopMembers := m.startPhase(ctx, "import.team-members")
+	// The diff only lists memberships for selected teams; teams that failed
+	// to import are left alone.
+	for _, change := range import_module.WithoutTeams(diffResult.TeamMemberships, failedTeams) {
+		added, removed, err := import_module.SyncTeamMembers(origCtx, m.targetClient, change)
+		result.TeamMembersAdded += added
+		result.TeamMembersRemoved += removed
+		if err != nil {
+			result.addErrorf("team-members", change.Team, err, "Team members %s: %v", change.Team, err)
+		}
+	}
+
+	stopMembers()
 
 	// Import permissions (blueprint and action permissions depend on resources existing)
+	defer m.startPhase(ctx, "import.permissions")()
 	for _, change := range diffResult.BlueprintPermissions {
 		perms := change.Permissions
 		_, err := m.targetClient.UpdateBlueprintPermissions(origCtx, change.Identifier, perms)
@@ -1880,7 +2243,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			}
 		}
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Blueprint permissions %s: %v", change.Identifier, err))
+			result.addErrorf("blueprint-permissions", change.Identifier, err, "Blueprint permissions %s: %v", change.Identifier, err)
 		} else {
 			result.BlueprintPermissionsUpdated++
 		}
@@ -1897,7 +2260,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			}
 		}
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Action permissions %s: %v", change.Identifier, err))
+			result.addErrorf("action-permissions", change.Identifier, err, "Action permissions %s: %v", change.Identifier, err)
 		} else {
 			result.ActionPermissionsUpdated++
 		}
@@ -1914,7 +2277,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 			}
 		}
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Page permissions %s: %v", change.Identifier, err))
+			result.addErrorf("page-permissions", change.Identifier, err, "Page permissions %s: %v", change.Identifier, err)
 		} else {
 			result.PagePermissionsUpdated++
 		}
@@ -1929,6 +2292,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 	result.UsersSkipped = len(diffResult.UsersToSkip)
 	result.PagesSkipped = len(diffResult.PagesToSkip)
 	result.IntegrationsSkipped = len(diffResult.IntegrationsToSkip)
+	result.DataSourcesSkipped = len(diffResult.DataSourcesToSkip)
 
 	if len(result.IgnoredRuleResultTargetRelationKeys) > 0 {
 		sort.Strings(result.IgnoredRuleResultTargetRelationKeys)
@@ -1942,6 +2306,7 @@ func (m *Module) importToTarget(ctx context.Context, data *export.Data, diffResu
 // AutoScopeBlueprints relevance pre-scan (see blueprintHasMatchingEntity) —
 // when present for a blueprint, it's used in place of a fresh source fetch.
 func (m *Module) migrateEntities(ctx context.Context, blueprints []api.Blueprint, opts Options, result *Result, dryRun bool, cachedEntities map[string][]api.Entity) error {
+	defer m.startPhase(ctx, "entities")()
 	if len(blueprints) == 0 {
 		return nil
 	}
@@ -1952,6 +2317,7 @@ func (m *Module) migrateEntities(ctx context.Context, blueprints []api.Blueprint
 	defer os.RemoveAll(tempDir)
 
 	entityImporter := import_module.NewImporter(m.targetClient)
+	entityImporter.SetResourceCallback(m.onResource)
 	importCtx := entityImporter.NewEntityImportContext(ctx)
 	importResult := &import_module.Result{}
 	flushed := false
@@ -1963,17 +2329,24 @@ func (m *Module) migrateEntities(ctx context.Context, blueprints []api.Blueprint
 		result.EntitiesCreated += importResult.EntitiesCreated
 		result.EntitiesUpdated += importResult.EntitiesUpdated
 		result.Errors = append(result.Errors, entityImporter.CollectedErrors()...)
+		result.CategorizedErrors = append(result.CategorizedErrors, entityImporter.CategorizedErrors()...)
 	}
 	currentSource := entitystream.FromAPI(m.targetClient)
 	source := entitystream.FromAPI(m.sourceClient)
 	streamOpts := import_module.EntityStreamOptions{
 		IncludeRuleResults: opts.IncludeRuleResults,
 		EntityIDs:          opts.Entities,
+		EntityPatterns:     opts.IncludePatterns["entities"],
+		ForceUpdate:        opts.ForceUpdate,
 		OnEntitySkipped: func(api.Entity) {
 			result.EntitiesSkipped++
 		},
 	}
+	if opts.RecordEntityChanges && result.DiffResult != nil {
+		streamOpts.OnEntityChanged = result.DiffResult.RecordEntityChange
+	}
 
+	var bpIDs []string
 	for _, blueprint := range blueprints {
 		bpID, _ := blueprint["identifier"].(string)
 		if bpID == "" {
@@ -1982,6 +2355,11 @@ func (m *Module) migrateEntities(ctx context.Context, blueprints []api.Blueprint
 		if opts.SkipSystemBlueprints && strings.HasPrefix(bpID, "_") {
 			continue
 		}
+		bpIDs = append(bpIDs, bpID)
+	}
+	// Relation targets are migrated first, so relations can be written as
+	// soon as each blueprint's entities exist.
+	for _, bpID := range importCtx.OrderBlueprints(bpIDs) {
 		var iterator entitystream.PageIterator
 		if cached, ok := cachedEntities[bpID]; ok {
 			iterator = entitystream.EntityIterator(0, func(yield func(api.Entity) error) error {
@@ -1995,12 +2373,20 @@ func (m *Module) migrateEntities(ctx context.Context, blueprints []api.Blueprint
 		} else {
 			iterator = entitystream.BlueprintIterator(source, bpID)
 		}
+		iterator = remapIteratorTeams(iterator, opts.TeamMap)
+		iterator = stripIteratorProperties(iterator, bpID, opts.StripProperties)
+		iterator = entityMetadataIterator(iterator, opts.PreserveMetadata)
 		if err := entityImporter.ImportBlueprintEntities(ctx, bpID, iterator, currentSource, streamOpts, importResult, dryRun, importCtx, tempDir); err != nil {
 			flushImportResult()
-			result.Errors = append(result.Errors, fmt.Sprintf("Entities %s: %v", bpID, err))
+			result.addErrorf("entities", bpID, err, "Entities %s: %v", bpID, err)
 			return fmt.Errorf("entities %s: %w", bpID, err)
 		}
 	}
+	if err := entityImporter.RelateDeferredEntities(ctx, importCtx); err != nil {
+		flushImportResult()
+		result.addErrorf("entities", "", err, "Entity relations: %v", err)
+		return fmt.Errorf("entity relations: %w", err)
+	}
 
 	flushImportResult()
 	return nil
@@ -2026,15 +2412,13 @@ func (m *Module) Close() error {
 }
 
 // filterEntitiesByDiff returns only entities present in entitiesToCreate or entitiesToUpdate.
-func filterEntitiesByDiff(entities []api.Entity, entitiesToCreate, entitiesToUpdate map[string]bool) []api.Entity {
+func filterEntitiesByDiff(entities []api.Entity, entitiesToCreate, entitiesToUpdate map[import_module.ResourceKey]bool) []api.Entity {
 	out := make([]api.Entity, 0, len(entities))
 	for _, e := range entities {
-		bp, ok1 := e["blueprint"].(string)
-		id, ok2 := e["identifier"].(string)
-		if !ok1 || !ok2 || bp == "" || id == "" {
+		key, ok := import_module.EntityKey(e)
+		if !ok {
 			continue
 		}
-		key := fmt.Sprintf("%s:%s", bp, id)
 		if entitiesToCreate[key] || entitiesToUpdate[key] {
 			out = append(out, e)
 		}
@@ -79,6 +79,52 @@ func TestExportFromSource_SkipEntities_SkipsTeamsAndUsers(t *testing.T) {
 	}
 }
 
+func TestExportFromSource_BlueprintPatternsIncludeDependencies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"blueprints": []map[string]interface{}{
+					{"identifier": "team-a", "relations": map[string]interface{}{
+						"owner":   map[string]interface{}{"target": "person", "required": true},
+						"service": map[string]interface{}{"target": "service"},
+					}},
+					{"identifier": "team-b"},
+					{"identifier": "person"},
+					{"identifier": "service"},
+				},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	m := &Module{
+		sourceClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}),
+		targetClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}),
+	}
+	data, _, _, err := m.exportFromSource(context.Background(), Options{
+		BlueprintPatterns: []string{"team-*"},
+		IncludeResources:  []string{"blueprints"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, bp := range data.Blueprints {
+		id, _ := bp["identifier"].(string)
+		got[id] = true
+	}
+	if len(got) != 3 || !got["team-a"] || !got["team-b"] || !got["person"] {
+		t.Fatalf("expected team-a, team-b and required relation target person, got %v", got)
+	}
+}
+
 func TestExportFromSource_SkipSystemBlueprints_ExcludesSchemaAndEntities(t *testing.T) {
 	entitiesHit := false
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1250,7 +1296,7 @@ func TestImportToTarget_PagePermissions_RetriesOnOrphanedFields(t *testing.T) {
 		},
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1346,7 +1392,7 @@ func TestImportToTarget_AggPropsAppliedInTopologicalOrder(t *testing.T) {
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1406,6 +1452,13 @@ func TestImportToTarget_RelationTargetAlreadyInTarget_NotFlaggedMissing(t *testi
 				mu.Unlock()
 			}
 			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": body})
+		case r.Method == "POST" && r.URL.Path == "/blueprints/service/relations":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			appliedRelations = map[string]interface{}{body["identifier"].(string): body}
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
 		default:
 			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
 		}
@@ -1440,7 +1493,7 @@ func TestImportToTarget_RelationTargetAlreadyInTarget_NotFlaggedMissing(t *testi
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1452,7 +1505,7 @@ func TestImportToTarget_RelationTargetAlreadyInTarget_NotFlaggedMissing(t *testi
 	mu.Lock()
 	defer mu.Unlock()
 	if appliedRelations == nil {
-		t.Fatal("expected service's relations to be applied via PUT, but relations field was never sent")
+		t.Fatal("expected service's relations to be applied, but they were never sent")
 	}
 	if _, ok := appliedRelations["domain_rel"]; !ok {
 		t.Fatalf("expected domain_rel relation to be applied, got %v", appliedRelations)
@@ -1508,7 +1561,7 @@ func TestImportToTarget_RelationTargetGenuinelyMissing_ReportsError(t *testing.T
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1565,7 +1618,7 @@ func TestImportToTarget_PageCreateConflictFallsBackToUpdate(t *testing.T) {
 		PagesToCreate: data.Pages,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1647,7 +1700,7 @@ func TestImportToTarget_FailedAggPropsRetried(t *testing.T) {
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1726,7 +1779,7 @@ func TestImportToTarget_OwnershipAppliedInTopologicalOrder(t *testing.T) {
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1802,7 +1855,7 @@ func TestImportToTarget_FailedAggPropsRetryAlsoFails_ReportsError(t *testing.T)
 		BlueprintsToCreate: data.Blueprints,
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1893,7 +1946,7 @@ func TestImportToTarget_FailedMirrorPropsRetriedAfterAggProps(t *testing.T) {
 		BlueprintsToSkip:   []api.Blueprint{{"identifier": "service"}},
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1963,7 +2016,7 @@ func TestImportToTarget_UsersCreated(t *testing.T) {
 		UsersToUpdate: []api.User{bob},
 	}
 
-	result, err := m.importToTarget(context.Background(), data, diff, false)
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -2037,7 +2090,7 @@ func TestImportToTarget_UsersAsDisabled(t *testing.T) {
 		UsersToCreate: []api.User{alice, carol},
 	}
 
-	_, err := m.importToTarget(context.Background(), data, diff, true)
+	_, err := m.importToTarget(context.Background(), data, diff, true, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -2097,12 +2150,12 @@ func TestMigrate_EntitiesUseBulkEndpoint(t *testing.T) {
 		{"identifier": "svc-2", "blueprint": "service"},
 		{"identifier": "svc-3", "blueprint": "service"},
 	}
-	entitiesToCreate := map[string]bool{
-		"service:svc-1": true,
-		"service:svc-2": true,
-		"service:svc-3": true,
+	entitiesToCreate := map[import_module.ResourceKey]bool{
+		{Blueprint: "service", Identifier: "svc-1"}: true,
+		{Blueprint: "service", Identifier: "svc-2"}: true,
+		{Blueprint: "service", Identifier: "svc-3"}: true,
 	}
-	entitiesToUpdate := map[string]bool{}
+	entitiesToUpdate := map[import_module.ResourceKey]bool{}
 
 	importResult := &import_module.Result{}
 	entityImporter := import_module.NewImporter(targetClient)
@@ -2168,3 +2221,101 @@ func TestExportFromSource_OrgWideActionOnly_ScopesBlueprintsToReferenced(t *test
 		t.Fatalf("expected only 'service' blueprint (referenced via org-wide action), got %v", data.Blueprints)
 	}
 }
+
+func TestMarkMigrationInterrupted_DropsCanceledErrors(t *testing.T) {
+	result := &Result{
+		Success: true,
+		Errors: []string{
+			"Blueprint svc: context canceled",
+			"Blueprint api: API request to POST /v1/blueprints failed: 422. Body: {}",
+		},
+	}
+
+	markMigrationInterrupted(result, &import_module.DiffResult{})
+
+	if result.Success {
+		t.Fatal("expected interrupted migration to be unsuccessful")
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "422") {
+		t.Fatalf("expected only the real failure to remain, got %v", result.Errors)
+	}
+	if result.Message != "Migration interrupted with 1 error(s)" {
+		t.Fatalf("unexpected message %q", result.Message)
+	}
+}
+
+func TestResultAddErrorf_CategorizesErrors(t *testing.T) {
+	result := &Result{}
+	result.addErrorf("blueprint", "svc", context.Canceled, "Blueprint svc: %v", context.Canceled)
+	result.addErrorf("blueprint", "api", &api.APIError{Status: 422}, "Blueprint api: %v", &api.APIError{Status: 422})
+
+	if len(result.Errors) != 2 || len(result.CategorizedErrors) != 2 {
+		t.Fatalf("expected 2 errors of each kind, got %v and %v", result.Errors, result.CategorizedErrors)
+	}
+	if e := result.CategorizedErrors[1]; e.Category != import_module.ErrValidation || e.ResourceType != "blueprint" || e.ResourceID != "api" {
+		t.Errorf("unexpected categorized error %+v", e)
+	}
+
+	markMigrationInterrupted(result, &import_module.DiffResult{})
+	if len(result.CategorizedErrors) != 1 || result.CategorizedErrors[0].ResourceID != "api" {
+		t.Errorf("expected the canceled error to be dropped, got %v", result.CategorizedErrors)
+	}
+}
+
+func TestImportToTarget_StrictRelationsFailsOnMissingTarget(t *testing.T) {
+	var relationUpdates atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case r.Method == "GET" && r.URL.Path == "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprints": []map[string]interface{}{}})
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/blueprints/"):
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if _, ok := body["relations"]; ok {
+				relationUpdates.Add(1)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": body})
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/blueprints/"):
+			id := strings.TrimPrefix(r.URL.Path, "/blueprints/")
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": map[string]interface{}{"identifier": id}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "blueprint": map[string]interface{}{}})
+		}
+	}))
+	defer server.Close()
+
+	newData := func() (*export.Data, *import_module.DiffResult) {
+		data := &export.Data{
+			Blueprints: []api.Blueprint{{
+				"identifier": "service",
+				"relations":  map[string]interface{}{"domain": map[string]interface{}{"target": "domain"}},
+			}},
+		}
+		return data, &import_module.DiffResult{BlueprintsToCreate: data.Blueprints}
+	}
+	m := &Module{
+		sourceClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}),
+		targetClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL}),
+	}
+
+	data, diff := newData()
+	result, err := m.importToTarget(context.Background(), data, diff, false, false)
+	if err != nil {
+		t.Fatalf("expected the lenient default to continue, got %v", err)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "missing target blueprints") {
+		t.Errorf("expected the missing target to be reported, got %v", result.Errors)
+	}
+
+	data, diff = newData()
+	_, err = m.importToTarget(context.Background(), data, diff, false, true)
+	var missingErr *import_module.MissingRelationTargetsError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("expected --strict-relations to fail the migration, got %v", err)
+	}
+	if relationUpdates.Load() != 0 {
+		t.Errorf("expected no relation updates, got %d", relationUpdates.Load())
+	}
+}
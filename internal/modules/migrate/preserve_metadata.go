@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// entityMetadataFields are the provenance fields Port records on every
+// entity. They are excluded from the diff, so they never cause an update.
+var entityMetadataFields = []string{"createdAt", "createdBy", "updatedAt", "updatedBy"}
+
+// honoredEntityMetadataFields lists the entityMetadataFields that the entity
+// create, update and bulk endpoints accept from the caller. Port currently
+// sets all four itself and ignores them in request bodies, so --preserve-metadata
+// passes nothing through; add a field here once the API honors it.
+var honoredEntityMetadataFields = map[string]bool{}
+
+// preserveMetadataWarning names the metadata fields --preserve-metadata
+// cannot carry over, or returns "" when every field is honored.
+func preserveMetadataWarning() string {
+	var ignored []string
+	for _, field := range entityMetadataFields {
+		if !honoredEntityMetadataFields[field] {
+			ignored = append(ignored, field)
+		}
+	}
+	if len(ignored) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("--preserve-metadata: the Port API sets %s itself, so migrated entities get new values for them", strings.Join(ignored, ", "))
+}
+
+// entityMetadata returns entity without the metadata fields the target would
+// regenerate. With preserve, the fields the API honors are kept. The entity
+// is copied only when a field is removed.
+func entityMetadata(entity api.Entity, preserve bool) api.Entity {
+	var out api.Entity
+	for _, field := range entityMetadataFields {
+		if preserve && honoredEntityMetadataFields[field] {
+			continue
+		}
+		if _, ok := entity[field]; !ok {
+			continue
+		}
+		if out == nil {
+			out = make(api.Entity, len(entity))
+			for k, v := range entity {
+				out[k] = v
+			}
+		}
+		delete(out, field)
+	}
+	if out == nil {
+		return entity
+	}
+	return out
+}
+
+// withEntityMetadata returns a copy of data whose entities went through
+// entityMetadata, leaving data itself untouched like remapTeams.
+func withEntityMetadata(data *export.Data, preserve bool) *export.Data {
+	if data == nil || len(data.Entities) == 0 {
+		return data
+	}
+	out := *data
+	out.Entities = make([]api.Entity, len(data.Entities))
+	for i, entity := range data.Entities {
+		out.Entities[i] = entityMetadata(entity, preserve)
+	}
+	return &out
+}
+
+// entityMetadataIterator applies entityMetadata to every streamed entity.
+func entityMetadataIterator(iter entitystream.PageIterator, preserve bool) entitystream.PageIterator {
+	return func(ctx context.Context, yield func([]api.Entity) error) error {
+		return iter(ctx, func(page []api.Entity) error {
+			out := make([]api.Entity, len(page))
+			for i, entity := range page {
+				out[i] = entityMetadata(entity, preserve)
+			}
+			return yield(out)
+		})
+	}
+}
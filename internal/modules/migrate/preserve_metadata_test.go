@@ -0,0 +1,73 @@
+package migrate
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestEntityMetadata(t *testing.T) {
+	entity := api.Entity{
+		"identifier": "svc", "blueprint": "service",
+		"createdAt": "2024-01-01T00:00:00Z", "createdBy": "alice", "updatedAt": "2024-02-01T00:00:00Z", "updatedBy": "bob",
+	}
+	want := api.Entity{"identifier": "svc", "blueprint": "service"}
+	for _, preserve := range []bool{false, true} {
+		if got := entityMetadata(entity, preserve); !reflect.DeepEqual(got, want) {
+			t.Errorf("entityMetadata(preserve=%v) = %v, want %v", preserve, got, want)
+		}
+	}
+	if _, ok := entity["createdAt"]; !ok {
+		t.Error("the input entity was modified")
+	}
+
+	honoredEntityMetadataFields["createdAt"] = true
+	defer delete(honoredEntityMetadataFields, "createdAt")
+	if got := entityMetadata(entity, true); got["createdAt"] != "2024-01-01T00:00:00Z" || got["updatedBy"] != nil {
+		t.Errorf("entityMetadata kept the wrong fields: %v", got)
+	}
+	if got := entityMetadata(entity, false); got["createdAt"] != nil {
+		t.Errorf("entityMetadata without preserve kept createdAt: %v", got)
+	}
+	if warning := preserveMetadataWarning(); strings.Contains(warning, "createdAt") || !strings.Contains(warning, "updatedBy") {
+		t.Errorf("preserveMetadataWarning = %q", warning)
+	}
+}
+
+func TestPreserveMetadataWarning(t *testing.T) {
+	warning := preserveMetadataWarning()
+	for _, field := range entityMetadataFields {
+		if !strings.Contains(warning, field) {
+			t.Errorf("warning %q does not name %s", warning, field)
+		}
+	}
+}
+
+func TestWithEntityMetadataAndIterator(t *testing.T) {
+	data := &export.Data{Entities: []api.Entity{{"identifier": "a", "updatedAt": "2024-01-01"}}}
+	stripped := withEntityMetadata(data, true)
+	if _, ok := stripped.Entities[0]["updatedAt"]; ok {
+		t.Error("withEntityMetadata kept updatedAt")
+	}
+	if _, ok := data.Entities[0]["updatedAt"]; !ok {
+		t.Error("withEntityMetadata modified its input")
+	}
+
+	iter := func(ctx context.Context, yield func([]api.Entity) error) error {
+		return yield(data.Entities)
+	}
+	var got []api.Entity
+	if err := entityMetadataIterator(iter, false)(context.Background(), func(page []api.Entity) error {
+		got = append(got, page...)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0]["updatedAt"] != nil {
+		t.Errorf("entityMetadataIterator yielded %v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package migrate
+
+import (
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/config"
+)
+
+// SameOrganization reports whether source and target resolve to the same
+// client ID. Client IDs belong to a single organization, so migrating between
+// them would write the organization onto itself. Matching API URLs alone are
+// not reported: every organization in a Port region shares one.
+func SameOrganization(source, target *config.OrganizationConfig) bool {
+	if source == nil || target == nil {
+		return false
+	}
+	sourceID := strings.TrimSpace(source.ClientID)
+	return sourceID != "" && sourceID == strings.TrimSpace(target.ClientID)
+}
+
+// MaskClientID returns id with all but its first and last four characters
+// masked, so two client IDs can be told apart without printing them.
+func MaskClientID(id string) string {
+	if id == "" {
+		return "<none>"
+	}
+	if len(id) <= 8 {
+		return strings.Repeat("*", len(id))
+	}
+	return id[:4] + strings.Repeat("*", len(id)-8) + id[len(id)-4:]
+}
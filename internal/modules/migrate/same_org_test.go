@@ -0,0 +1,44 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/config"
+)
+
+func TestSameOrganization(t *testing.T) {
+	source := &config.OrganizationConfig{ClientID: "client-a", APIURL: "https://api.getport.io/v1"}
+	tests := []struct {
+		name   string
+		target *config.OrganizationConfig
+		want   bool
+	}{
+		{"same client ID", &config.OrganizationConfig{ClientID: "client-a", APIURL: "https://api.getport.io/v1"}, true},
+		{"same client ID in another region", &config.OrganizationConfig{ClientID: "client-a", APIURL: "https://api.us.getport.io/v1"}, true},
+		{"same API URL only", &config.OrganizationConfig{ClientID: "client-b", APIURL: "https://api.getport.io/v1"}, false},
+		{"no client IDs", &config.OrganizationConfig{}, false},
+		{"no target", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameOrganization(source, tt.target); got != tt.want {
+				t.Errorf("SameOrganization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if SameOrganization(&config.OrganizationConfig{}, &config.OrganizationConfig{}) {
+		t.Error("expected empty client IDs not to match")
+	}
+}
+
+func TestMaskClientID(t *testing.T) {
+	for id, want := range map[string]string{
+		"":                 "<none>",
+		"short":            "*****",
+		"abcd1234efgh5678": "abcd********5678",
+	} {
+		if got := MaskClientID(id); got != want {
+			t.Errorf("MaskClientID(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/compare"
+	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// deletionResources maps the sections of export.Deletion to the --include
+// resource type that selects them.
+var deletionResources = map[string]string{
+	"entities":     "entities",
+	"scorecards":   "scorecards",
+	"actions":      "actions",
+	"pages":        "pages",
+	"_folders":     "pages",
+	"integrations": "integrations",
+	"teams":        "teams",
+	"blueprints":   "blueprints",
+}
+
+// sinceExport narrows source to the resources that changed since the
+// snapshot in opts.SinceExport, the way port diff-bundle does. The full
+// source, entities included, becomes the snapshot for the next run, and the
+// resources removed from the source since the snapshot are returned as
+// deletions; the migration never deletes them from the target.
+func (m *Module) sinceExport(ctx context.Context, source *SourceExport, opts Options) error {
+	current := *source.Data
+	current.Entities = []api.Entity{}
+	if !opts.SkipEntities && shouldCollect("entities", opts.IncludeResources) {
+		for _, blueprint := range source.entityBlueprints {
+			bpID, _ := blueprint["identifier"].(string)
+			entities, ok := source.cachedEntities[bpID]
+			if !ok {
+				err := entitystream.BlueprintIterator(entitystream.FromAPI(m.sourceClient), bpID)(ctx, func(page []api.Entity) error {
+					entities = append(entities, page...)
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to get entities for blueprint %s: %w", bpID, err)
+				}
+			}
+			current.Entities = append(current.Entities, entities...)
+		}
+	}
+
+	delta := compare.BuildDelta(opts.SinceExport, &current)
+
+	// Entities are migrated per blueprint from cachedEntities, so only the
+	// blueprints with changed entities are visited.
+	changedEntities := make(map[string][]api.Entity)
+	for _, entity := range delta.Entities {
+		bpID, _ := entity["blueprint"].(string)
+		changedEntities[bpID] = append(changedEntities[bpID], entity)
+	}
+	entityBlueprints := make([]api.Blueprint, 0, len(changedEntities))
+	for _, blueprint := range source.entityBlueprints {
+		if bpID, _ := blueprint["identifier"].(string); changedEntities[bpID] != nil {
+			entityBlueprints = append(entityBlueprints, blueprint)
+		}
+	}
+
+	var deletions []export.Deletion
+	for _, d := range delta.Deletions {
+		resource := deletionResources[d.Type]
+		if resource == "entities" && opts.SkipEntities {
+			continue
+		}
+		if shouldCollect(resource, opts.IncludeResources) {
+			deletions = append(deletions, d)
+		}
+	}
+
+	changed := compare.DeltaSize(delta)
+	delta.Entities = []api.Entity{}
+	delta.Deletions = nil
+	delta.Manifest = source.Data.Manifest
+	source.Data = delta
+	source.entityBlueprints = entityBlueprints
+	source.cachedEntities = changedEntities
+	source.snapshot = &current
+	source.deletions = deletions
+	source.unchanged = compare.DeltaSize(&current) - changed
+	return nil
+}
+
+// sinceExportDeletionsWarning describes the resources deleted from the source
+// since the snapshot, which stay in the target.
+func sinceExportDeletionsWarning(deletions []export.Deletion) string {
+	if len(deletions) == 0 {
+		return ""
+	}
+	names := make([]string, len(deletions))
+	for i, d := range deletions {
+		names[i] = strings.TrimPrefix(d.Type, "_") + " " + d.Identifier
+		if d.Blueprint != "" {
+			names[i] = strings.TrimPrefix(d.Type, "_") + " " + d.Blueprint + "/" + d.Identifier
+		}
+	}
+	return fmt.Sprintf("%d resource(s) were deleted from the source since the --since-export snapshot and are left in the target: %s", len(deletions), strings.Join(names, ", "))
+}
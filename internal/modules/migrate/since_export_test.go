@@ -0,0 +1,88 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestExportSource_SinceExportKeepsChangedResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/blueprints":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"blueprints": []map[string]interface{}{{"identifier": "service", "title": "Service"}},
+			})
+		case "/blueprints/service/entities-count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "count": 3})
+		case "/blueprints/service/entities":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "entities": []map[string]interface{}{
+				{"identifier": "svc-1", "blueprint": "service", "title": "One"},
+				{"identifier": "svc-2", "blueprint": "service", "title": "Two, renamed"},
+				{"identifier": "svc-3", "blueprint": "service", "title": "Three"},
+			}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	snapshot := &export.Data{
+		Blueprints: []api.Blueprint{{"identifier": "service", "title": "Service"}},
+		Entities: []api.Entity{
+			{"identifier": "svc-1", "blueprint": "service", "title": "One"},
+			{"identifier": "svc-2", "blueprint": "service", "title": "Two"},
+			{"identifier": "svc-gone", "blueprint": "service", "title": "Gone"},
+		},
+	}
+	m := &Module{sourceClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	source, err := m.ExportSource(context.Background(), Options{
+		IncludeResources: []string{"blueprints", "entities"},
+		SinceExport:      snapshot,
+	})
+	if err != nil {
+		t.Fatalf("ExportSource: %v", err)
+	}
+
+	if len(source.Data.Blueprints) != 0 {
+		t.Errorf("unchanged blueprint kept: %v", source.Data.Blueprints)
+	}
+	changed := source.cachedEntities["service"]
+	if len(changed) != 2 || changed[0]["identifier"] != "svc-2" || changed[1]["identifier"] != "svc-3" {
+		t.Errorf("changed entities = %v, want svc-2 and svc-3", changed)
+	}
+	if len(source.entityBlueprints) != 1 {
+		t.Errorf("entity blueprints = %v, want service only", source.entityBlueprints)
+	}
+	if len(source.deletions) != 1 || source.deletions[0] != (export.Deletion{Type: "entities", Identifier: "svc-gone", Blueprint: "service"}) {
+		t.Errorf("deletions = %v, want service/svc-gone", source.deletions)
+	}
+	if source.unchanged != 2 {
+		t.Errorf("unchanged = %d, want 2", source.unchanged)
+	}
+	if source.snapshot == nil || len(source.snapshot.Entities) != 3 || len(source.snapshot.Blueprints) != 1 {
+		t.Errorf("snapshot = %+v, want the full source", source.snapshot)
+	}
+}
+
+func TestSinceExportDeletionsWarning(t *testing.T) {
+	if warning := sinceExportDeletionsWarning(nil); warning != "" {
+		t.Errorf("warning without deletions = %q", warning)
+	}
+	warning := sinceExportDeletionsWarning([]export.Deletion{
+		{Type: "entities", Identifier: "svc-gone", Blueprint: "service"},
+		{Type: "_folders", Identifier: "old-folder"},
+	})
+	if !strings.HasPrefix(warning, "2 resource(s)") || !strings.Contains(warning, "entities service/svc-gone, folders old-folder") {
+		t.Errorf("warning = %q", warning)
+	}
+}
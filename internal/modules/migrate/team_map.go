@@ -0,0 +1,194 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	entitystream "github.com/port-experimental/port-cli/internal/modules/entity_stream"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+// ParseTeamMap parses --team-map values of the form "source=target" into a
+// source team name -> target team name map.
+func ParseTeamMap(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	teamMap := make(map[string]string, len(values))
+	for _, value := range values {
+		source, target, ok := strings.Cut(value, "=")
+		source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+		if !ok || source == "" || target == "" {
+			return nil, fmt.Errorf("invalid team mapping %q: expected source=target", value)
+		}
+		if existing, dup := teamMap[source]; dup && existing != target {
+			return nil, fmt.Errorf("team %q is mapped to both %q and %q", source, existing, target)
+		}
+		teamMap[source] = target
+	}
+	return teamMap, nil
+}
+
+// remapTeams returns a copy of data whose team references are rewritten
+// through teamMap: the team field of each entity and the team lists in
+// blueprint, action and page permissions. data itself is left untouched, as
+// one source export may be migrated into several targets. Teams missing from
+// teamMap keep their names.
+func remapTeams(data *export.Data, teamMap map[string]string) *export.Data {
+	if len(teamMap) == 0 || data == nil {
+		return data
+	}
+	remapped := *data
+	remapped.Entities = make([]api.Entity, len(data.Entities))
+	for i, entity := range data.Entities {
+		remapped.Entities[i] = remapEntityTeam(entity, teamMap)
+	}
+	remapped.BlueprintPermissions = remapPermissionTeams(data.BlueprintPermissions, teamMap)
+	remapped.ActionPermissions = remapPermissionTeams(data.ActionPermissions, teamMap)
+	remapped.PagePermissions = remapPermissionTeams(data.PagePermissions, teamMap)
+	return &remapped
+}
+
+// remapEntityTeam returns entity with its team field rewritten through
+// teamMap. The entity is copied only when its team field changes.
+func remapEntityTeam(entity api.Entity, teamMap map[string]string) api.Entity {
+	team, ok := entity["team"]
+	if !ok {
+		return entity
+	}
+	remapped, changed := remapTeamValue(team, teamMap)
+	if !changed {
+		return entity
+	}
+	out := make(api.Entity, len(entity))
+	for k, v := range entity {
+		out[k] = v
+	}
+	out["team"] = remapped
+	return out
+}
+
+// remapTeamValue rewrites a team name, or a list of team names, through
+// teamMap and reports whether anything changed.
+func remapTeamValue(value interface{}, teamMap map[string]string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if target, ok := teamMap[v]; ok {
+			return target, true
+		}
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		changed := false
+		for i, item := range v {
+			out[i] = item
+			if name, ok := item.(string); ok {
+				if target, ok := teamMap[name]; ok {
+					out[i] = target
+					changed = true
+				}
+			}
+		}
+		if changed {
+			return out, true
+		}
+	}
+	return value, false
+}
+
+func remapPermissionTeams(perms map[string]api.Permissions, teamMap map[string]string) map[string]api.Permissions {
+	if perms == nil {
+		return nil
+	}
+	out := make(map[string]api.Permissions, len(perms))
+	for id, p := range perms {
+		out[id] = api.Permissions(remapTeamLists(map[string]interface{}(p), teamMap).(map[string]interface{}))
+	}
+	return out
+}
+
+// remapTeamLists copies value, rewriting every "teams" list it holds.
+func remapTeamLists(value interface{}, teamMap map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			if k == "teams" {
+				out[k], _ = remapTeamValue(child, teamMap)
+				continue
+			}
+			out[k] = remapTeamLists(child, teamMap)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = remapTeamLists(child, teamMap)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// remapIteratorTeams rewrites the team field of every entity iter yields.
+func remapIteratorTeams(iter entitystream.PageIterator, teamMap map[string]string) entitystream.PageIterator {
+	if len(teamMap) == 0 {
+		return iter
+	}
+	return func(ctx context.Context, yield func([]api.Entity) error) error {
+		return iter(ctx, func(page []api.Entity) error {
+			remapped := make([]api.Entity, len(page))
+			for i, entity := range page {
+				remapped[i] = remapEntityTeam(entity, teamMap)
+			}
+			return yield(remapped)
+		})
+	}
+}
+
+// missingMappedTeams returns the --team-map targets that neither exist in the
+// target organization nor are created by this migration.
+func (m *Module) missingMappedTeams(ctx context.Context, data *export.Data, teamMap map[string]string) ([]string, error) {
+	if len(teamMap) == 0 {
+		return nil, nil
+	}
+	targetTeams, err := m.targetClient.GetTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target teams: %w", err)
+	}
+	known := make(map[string]bool, len(targetTeams)+len(data.Teams))
+	for _, teams := range [][]api.Team{targetTeams, data.Teams} {
+		for _, team := range teams {
+			if name, ok := team["name"].(string); ok {
+				known[name] = true
+			}
+		}
+	}
+	var missing []string
+	seen := make(map[string]bool)
+	for _, target := range teamMap {
+		if !known[target] && !seen[target] {
+			seen[target] = true
+			missing = append(missing, target)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// teamMapWarnings warns about --team-map targets missing from the target
+// organization. Entities owned by such a team would fail to import.
+func (m *Module) teamMapWarnings(ctx context.Context, data *export.Data, teamMap map[string]string) []string {
+	missing, err := m.missingMappedTeams(ctx, data, teamMap)
+	if err != nil {
+		return []string{fmt.Sprintf("could not check --team-map targets: %v", err)}
+	}
+	warnings := make([]string, 0, len(missing))
+	for _, team := range missing {
+		warnings = append(warnings, fmt.Sprintf("--team-map target team %q does not exist in the target organization", team))
+	}
+	return warnings
+}
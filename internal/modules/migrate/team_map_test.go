@@ -0,0 +1,86 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/port-experimental/port-cli/internal/api"
+	"github.com/port-experimental/port-cli/internal/modules/export"
+)
+
+func TestParseTeamMap(t *testing.T) {
+	got, err := ParseTeamMap([]string{"eng=engineering", " ops = platform "})
+	if err != nil {
+		t.Fatalf("ParseTeamMap error: %v", err)
+	}
+	if got["eng"] != "engineering" || got["ops"] != "platform" {
+		t.Errorf("unexpected map %v", got)
+	}
+
+	for _, bad := range [][]string{{"eng"}, {"=engineering"}, {"eng="}, {"eng=a", "eng=b"}} {
+		if _, err := ParseTeamMap(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestRemapTeams_RewritesOwnershipWithoutMutatingSource(t *testing.T) {
+	teamMap := map[string]string{"eng": "engineering"}
+	data := &export.Data{
+		Entities: []api.Entity{
+			{"identifier": "a", "team": "eng"},
+			{"identifier": "b", "team": []interface{}{"eng", "ops"}},
+			{"identifier": "c"},
+		},
+		BlueprintPermissions: map[string]api.Permissions{
+			"service": {"entities": map[string]interface{}{"register": map[string]interface{}{"teams": []interface{}{"eng"}}}},
+		},
+	}
+
+	got := remapTeams(data, teamMap)
+
+	if got.Entities[0]["team"] != "engineering" {
+		t.Errorf("expected team to be remapped, got %v", got.Entities[0]["team"])
+	}
+	if teams := got.Entities[1]["team"].([]interface{}); teams[0] != "engineering" || teams[1] != "ops" {
+		t.Errorf("expected only mapped teams to change, got %v", teams)
+	}
+	register := got.BlueprintPermissions["service"]["entities"].(map[string]interface{})["register"].(map[string]interface{})
+	if register["teams"].([]interface{})[0] != "engineering" {
+		t.Errorf("expected permission teams to be remapped, got %v", register["teams"])
+	}
+
+	if data.Entities[0]["team"] != "eng" || data.Entities[1]["team"].([]interface{})[0] != "eng" {
+		t.Errorf("source entities were mutated: %v", data.Entities)
+	}
+	srcRegister := data.BlueprintPermissions["service"]["entities"].(map[string]interface{})["register"].(map[string]interface{})
+	if srcRegister["teams"].([]interface{})[0] != "eng" {
+		t.Errorf("source permissions were mutated: %v", srcRegister)
+	}
+}
+
+func TestTeamMapWarnings_ReportsMissingTargetTeams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/access_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "accessToken": "tok", "expiresIn": 3600})
+		case "/teams":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "teams": []interface{}{map[string]interface{}{"name": "engineering"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	m := &Module{targetClient: api.NewClient(api.ClientOpts{ClientID: "id", ClientSecret: "secret", APIURL: server.URL})}
+	data := &export.Data{Teams: []api.Team{{"name": "platform"}}}
+	teamMap := map[string]string{"eng": "engineering", "ops": "platform", "sre": "reliability"}
+
+	warnings := m.teamMapWarnings(context.Background(), data, teamMap)
+	if len(warnings) != 1 || warnings[0] != `--team-map target team "reliability" does not exist in the target organization` {
+		t.Errorf("expected a warning for reliability only, got %v", warnings)
+	}
+}
@@ -24,6 +24,12 @@ func NewModule(token *auth.Token, orgConfig *config.OrganizationConfig, configMa
 		ClientID:     orgConfig.ClientID,
 		ClientSecret: orgConfig.ClientSecret,
 		APIURL:       orgConfig.APIURL,
+		RateLimit:    orgConfig.RateLimit,
+		Concurrency:  orgConfig.Concurrency,
+		Headers:      orgConfig.Headers,
+		APIVersion:   orgConfig.APIVersion,
+		CacheTTL:     orgConfig.CacheTTL,
+		NoCache:      orgConfig.NoCache,
 		Token:        token,
 	})
 	return &Module{
@@ -20,7 +20,7 @@ func newTestModule(t *testing.T) (*Module, *config.ConfigManager, string) {
 	orgCfg := &config.OrganizationConfig{
 		ClientID:     "test-id",
 		ClientSecret: "test-secret",
-		APIURL:       "https://api.getport.io/v1",
+		APIURL:       config.PortCloudAPIURL,
 	}
 	return NewModule(nil, orgCfg, cm), cm, dir
 }
@@ -2,6 +2,8 @@ package output
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 )
 
@@ -13,13 +15,55 @@ type JSONResult struct {
 	Error   string                 `json:"error,omitempty"`
 }
 
-// PrintJSON prints data as JSON to stdout.
+// compactJSON makes JSON printed to stdout single-line instead of indented.
+var compactJSON bool
+
+// SetCompactJSON sets whether JSON printed to stdout is compact, one value per
+// line, which is smaller and easier to pipe into other tools. Indented JSON
+// is the default.
+func SetCompactJSON(compact bool) {
+	compactJSON = compact
+}
+
+// NewJSONEncoder returns an encoder for JSON printed to w, indented unless
+// compact JSON is set.
+func NewJSONEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	if !compactJSON {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder
+}
+
+// PrintJSON prints data as JSON to stdout, compact when set.
 func PrintJSON(data interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
+	return NewJSONEncoder(os.Stdout).Encode(data)
+}
+
+// WriteJSON writes data to w as indented JSON.
+func WriteJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
 
+// WriteJSONFile writes data as indented JSON to the file at path, replacing
+// it if it exists.
+func WriteJSONFile(path string, data interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := WriteJSON(file, data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
 // PrintJSONResult prints a JSONResult as JSON.
 func PrintJSONResult(result JSONResult) error {
 	return PrintJSON(result)
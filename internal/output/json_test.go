@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, map[string]interface{}{"success": true}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if want := "{\n  \"success\": true\n}\n"; buf.String() != want {
+		t.Errorf("WriteJSON wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNewJSONEncoder_Compact(t *testing.T) {
+	SetCompactJSON(true)
+	defer SetCompactJSON(false)
+
+	var buf bytes.Buffer
+	if err := NewJSONEncoder(&buf).Encode(map[string]interface{}{"success": true}); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if want := "{\"success\":true}\n"; buf.String() != want {
+		t.Errorf("compact encoder wrote %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, map[string]interface{}{"success": true}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if want := "{\n  \"success\": true\n}\n"; buf.String() != want {
+		t.Errorf("WriteJSON wrote %q, want files to stay indented", buf.String())
+	}
+}
+
+func TestWriteJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	if err := os.WriteFile(path, []byte("stale content that is longer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSONFile(path, JSONResult{Success: true, Message: "done"}); err != nil {
+		t.Fatalf("WriteJSONFile: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"success\": true,\n  \"message\": \"done\"\n}\n"; string(got) != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+
+	if err := WriteJSONFile(filepath.Join(t.TempDir(), "missing", "result.json"), nil); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
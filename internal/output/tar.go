@@ -66,36 +66,3 @@ func WriteTar(data *export.Data, outputPath string) error {
 
 	return nil
 }
-
-// WriteJSON writes data to a JSON file.
-func WriteJSON(data *export.Data, outputPath string) error {
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
-	}
-
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	output := map[string]interface{}{
-		"blueprints":   data.Blueprints,
-		"entities":     data.Entities,
-		"scorecards":   data.Scorecards,
-		"actions":      data.Actions,
-		"teams":        data.Teams,
-		"_folders":     data.Folders,
-		"pages":        data.Pages,
-		"integrations": data.Integrations,
-	}
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(output); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
-	}
-
-	return nil
-}
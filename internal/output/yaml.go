@@ -0,0 +1,52 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteYAML writes data to w as YAML with the keys and values its JSON
+// encoding has: data goes through JSON first, so json tags name the keys and
+// a result printed as YAML matches the same result printed as JSON.
+func WriteYAML(w io.Writer, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// PrintYAML prints data as YAML to stdout, as WriteYAML does.
+func PrintYAML(data interface{}) error {
+	return WriteYAML(os.Stdout, data)
+}
+
+// WriteYAMLFile writes data as YAML to the file at path, replacing it if it
+// exists.
+func WriteYAMLFile(path string, data interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := WriteYAML(file, data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
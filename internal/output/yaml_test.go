@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteYAML_MatchesJSON(t *testing.T) {
+	result := JSONResult{
+		Success: true,
+		Message: "Import completed",
+		Data:    map[string]interface{}{"entities_created": 3, "errors": []string{"boom"}, "ratio": 0.5},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, result); err != nil {
+		t.Fatalf("WriteYAML: %v", err)
+	}
+	var fromYAML interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("output is not YAML: %v\n%s", err, buf.String())
+	}
+
+	raw, _ := json.Marshal(result)
+	var fromJSON interface{}
+	_ = json.Unmarshal(raw, &fromJSON)
+	// JSON numbers decode as float64 and YAML integers as int.
+	normalized, _ := json.Marshal(fromYAML)
+	var fromYAMLJSON interface{}
+	_ = json.Unmarshal(normalized, &fromYAMLJSON)
+	if !reflect.DeepEqual(fromYAMLJSON, fromJSON) {
+		t.Errorf("YAML result %v differs from JSON result %v", fromYAMLJSON, fromJSON)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("entities_created: 3\n")) {
+		t.Errorf("expected json tag keys in:\n%s", buf.String())
+	}
+}
@@ -25,15 +25,21 @@ type CheckResult struct {
 	CurrentVersion  string
 	UpdateAvailable bool
 	DownloadURL     string
-	Error           error
+	// Unreleased is set when CurrentVersion is not a semantic version, such
+	// as a dev build, so it was not compared.
+	Unreleased bool
+	Error      error
 }
 
 // Checker checks for updates.
 type Checker struct {
 	httpClient *http.Client
+	url        string
 }
 
-// NewChecker creates a new update checker.
+// NewChecker creates a new update checker. Like the API client it uses the
+// default transport, so requests go through the proxy named by
+// HTTPS_PROXY/NO_PROXY and trust the CAs in SSL_CERT_FILE/SSL_CERT_DIR.
 func NewChecker() *Checker {
 	return &Checker{
 		httpClient: &http.Client{
@@ -42,9 +48,20 @@ func NewChecker() *Checker {
 	}
 }
 
+// SetURL makes the checker ask url instead of the GitHub releases API for
+// the latest release. url must answer like GitHub's latest release endpoint,
+// with a JSON object holding tag_name and html_url; "" restores the default.
+func (c *Checker) SetURL(url string) {
+	c.url = url
+}
+
 // CheckLatestVersion checks for the latest version on GitHub.
 func (c *Checker) CheckLatestVersion(ctx context.Context, currentVersion string) (*CheckResult, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", releasesURL, nil)
+	url := c.url
+	if url == "" {
+		url = releasesURL
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return &CheckResult{
 			CurrentVersion: currentVersion,
@@ -92,58 +109,25 @@ func (c *Checker) CheckLatestVersion(ctx context.Context, currentVersion string)
 	}
 
 	latestVersion := strings.TrimPrefix(release.TagName, "v")
-	updateAvailable := compareVersions(currentVersion, latestVersion) < 0
-
-	result := &CheckResult{
-		LatestVersion:   latestVersion,
-		CurrentVersion:  currentVersion,
-		UpdateAvailable: updateAvailable,
-		DownloadURL:     release.HTMLURL,
-	}
-
-	return result, nil
-}
-
-// compareVersions compares two version strings.
-// Returns -1 if v1 < v2, 0 if v1 == v2, 1 if v1 > v2.
-func compareVersions(v1, v2 string) int {
-	// Simple version comparison - remove 'v' prefix and compare
-	v1 = strings.TrimPrefix(strings.TrimSpace(v1), "v")
-	v2 = strings.TrimPrefix(strings.TrimSpace(v2), "v")
-
-	// Handle dev versions
-	if v1 == "dev" {
-		return -1
-	}
-	if v2 == "dev" {
-		return 1
+	latest, err := parseVersion(latestVersion)
+	if err != nil {
+		err = fmt.Errorf("latest release has %w", err)
+		return &CheckResult{
+			CurrentVersion: currentVersion,
+			Error:          err,
+		}, err
 	}
 
-	// Simple string comparison for semantic versions
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
-
-	maxLen := len(parts1)
-	if len(parts2) > maxLen {
-		maxLen = len(parts2)
+	result := &CheckResult{
+		LatestVersion:  latestVersion,
+		CurrentVersion: currentVersion,
+		DownloadURL:    release.HTMLURL,
 	}
-
-	for i := 0; i < maxLen; i++ {
-		var p1, p2 string
-		if i < len(parts1) {
-			p1 = parts1[i]
-		}
-		if i < len(parts2) {
-			p2 = parts2[i]
-		}
-
-		if p1 < p2 {
-			return -1
-		}
-		if p1 > p2 {
-			return 1
-		}
+	if current, err := parseVersion(currentVersion); err != nil {
+		result.Unreleased = true
+	} else {
+		result.UpdateAvailable = current.compare(latest) < 0
 	}
 
-	return 0
+	return result, nil
 }
@@ -48,3 +48,69 @@ func TestChecker_UserAgent(t *testing.T) {
 		t.Errorf("User-Agent = %q, want %q", gotUA, wantUA)
 	}
 }
+
+func releaseServer(t *testing.T, tag string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"tag_name": tag, "html_url": "https://example.com/" + tag})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChecker_CheckLatestVersion(t *testing.T) {
+	server := releaseServer(t, "v1.10.0")
+	checker := NewChecker()
+	checker.SetURL(server.URL)
+
+	tests := []struct {
+		current         string
+		updateAvailable bool
+		unreleased      bool
+	}{
+		{"1.9.2", true, false},
+		{"1.10.0", false, false},
+		{"v1.10.0", false, false},
+		{"1.10.0-rc.1", true, false},
+		{"1.11.0", false, false},
+		{"dev", false, true},
+	}
+	for _, tt := range tests {
+		result, err := checker.CheckLatestVersion(context.Background(), tt.current)
+		if err != nil {
+			t.Fatalf("CheckLatestVersion(%q): %v", tt.current, err)
+		}
+		if result.LatestVersion != "1.10.0" {
+			t.Errorf("LatestVersion = %q, want 1.10.0", result.LatestVersion)
+		}
+		if result.UpdateAvailable != tt.updateAvailable || result.Unreleased != tt.unreleased {
+			t.Errorf("CheckLatestVersion(%q) = update %v, unreleased %v; want %v, %v",
+				tt.current, result.UpdateAvailable, result.Unreleased, tt.updateAvailable, tt.unreleased)
+		}
+	}
+}
+
+func TestChecker_InvalidLatestTag(t *testing.T) {
+	checker := NewChecker()
+	checker.SetURL(releaseServer(t, "nightly").URL)
+	if _, err := checker.CheckLatestVersion(context.Background(), "1.0.0"); err == nil {
+		t.Fatal("expected an error for a non-semver release tag")
+	}
+}
+
+func TestChecker_Unreachable(t *testing.T) {
+	server := releaseServer(t, "v1.0.0")
+	url := server.URL
+	server.Close()
+
+	checker := NewChecker()
+	checker.SetURL(url)
+	result, err := checker.CheckLatestVersion(context.Background(), "1.0.0")
+	if err == nil {
+		t.Fatal("expected an error when the releases URL is unreachable")
+	}
+	if result.UpdateAvailable {
+		t.Error("UpdateAvailable should be false when the check fails")
+	}
+}
@@ -0,0 +1,97 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed semantic version. Build metadata is dropped, as it
+// does not take part in ordering.
+type version struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+// parseVersion parses a semantic version such as 1.2.3, v1.2.3 or
+// 1.2.3-rc.1+build.5.
+func parseVersion(s string) (version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	core, _, _ := strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(core, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return version{}, fmt.Errorf("invalid version %q: expected major.minor.patch", s)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (len(part) > 1 && part[0] == '0') {
+			return version{}, fmt.Errorf("invalid version %q: %q is not a version number", s, part)
+		}
+		nums[i] = n
+	}
+
+	v := version{major: nums[0], minor: nums[1], patch: nums[2]}
+	if hasPre {
+		v.prerelease = strings.Split(pre, ".")
+		for _, id := range v.prerelease {
+			if id == "" {
+				return version{}, fmt.Errorf("invalid version %q: empty prerelease identifier", s)
+			}
+		}
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 as v is lower than, equal to or higher than o,
+// following the semver precedence rules: a prerelease is lower than its
+// release, and prerelease identifiers compare numerically when both are
+// numbers and lexically otherwise.
+func (v version) compare(o version) int {
+	for _, d := range [][2]int{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		if d[0] != d[1] {
+			return cmpInt(d[0], d[1])
+		}
+	}
+	switch {
+	case len(v.prerelease) == 0 && len(o.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(o.prerelease); i++ {
+		a, b := v.prerelease[i], o.prerelease[i]
+		if a == b {
+			continue
+		}
+		an, aErr := strconv.Atoi(a)
+		bn, bErr := strconv.Atoi(b)
+		switch {
+		case aErr == nil && bErr == nil:
+			return cmpInt(an, bn)
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case a < b:
+			return -1
+		default:
+			return 1
+		}
+	}
+	return cmpInt(len(v.prerelease), len(o.prerelease))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
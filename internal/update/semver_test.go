@@ -0,0 +1,46 @@
+package update
+
+import "testing"
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3+build.1", "1.2.3+build.2", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.9.0", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+	}
+	for _, tt := range tests {
+		a, err := parseVersion(tt.a)
+		if err != nil {
+			t.Fatalf("parseVersion(%q): %v", tt.a, err)
+		}
+		b, err := parseVersion(tt.b)
+		if err != nil {
+			t.Fatalf("parseVersion(%q): %v", tt.b, err)
+		}
+		if got := a.compare(b); got != tt.want {
+			t.Errorf("compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.compare(a); got != -tt.want {
+			t.Errorf("compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestParseVersion_Invalid(t *testing.T) {
+	for _, s := range []string{"dev", "", "1.2", "1.2.3.4", "1.02.3", "1.x.3", "1.2.3-", "1.2.3-rc..1"} {
+		if _, err := parseVersion(s); err == nil {
+			t.Errorf("parseVersion(%q) succeeded, want an error", s)
+		}
+	}
+}
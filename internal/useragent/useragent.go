@@ -17,6 +17,11 @@ func SetVersion(v string) {
 	}
 }
 
+// Version returns the CLI version, "dev" for builds without one.
+func Version() string {
+	return version
+}
+
 // String returns the User-Agent value, e.g. "port-cli/0.1.3 (darwin/arm64)".
 func String() string {
 	return fmt.Sprintf("%s/%s (%s/%s)", Name, version, runtime.GOOS, runtime.GOARCH)